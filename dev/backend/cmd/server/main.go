@@ -2,7 +2,10 @@ package main
 
 import (
 	"backend/config"
+	domainUsecase "backend/internal/domain/usecase"
+	"backend/internal/infrastructure/prompt"
 	"backend/internal/infrastructure/queue"
+	"backend/internal/infrastructure/realtime"
 	"backend/internal/repository"
 	"backend/internal/router"
 	"backend/internal/usecase"
@@ -92,6 +95,12 @@ func main() {
 	}
 	// defer genaiClient.Close() // main関数終了時に閉じる必要はないが、明示的に書くならここ
 
+	// プロンプトテンプレートの読み込み
+	promptRenderer, err := prompt.NewRenderer(cfg.Prompt.Dir, cfg.Prompt.DefaultLanguage)
+	if err != nil {
+		log.Fatalf("プロンプトテンプレートの読み込みに失敗: %v", err)
+	}
+
 	// Watermill Publisher の初期化
 	publisher, err := queue.NewPublisher(sqlDB, slog.Default())
 	if err != nil {
@@ -107,30 +116,73 @@ func main() {
 	defer subscriber.Close()
 
 	// Worker の初期化と起動
-	summaryWorker := setupWorker(db, genaiClient, subscriber)
+	summaryWorker := setupWorker(db, genaiClient, subscriber, promptRenderer)
 	go func() {
 		if err := summaryWorker.Run(context.Background()); err != nil {
 			slog.Error("SummaryWorker failed", "error", err)
 		}
 	}()
 
+	// リアルタイム通知のハブ (Worker が生成した回答もサーバーの接続に通知する)
+	chatEventHub := realtime.NewHub()
+	workerChatUsecase := setupWorkerChatUsecase(db, genaiClient, publisher, promptRenderer, chatEventHub)
+
+	// フォークしたチャットの最初の回答をバックグラウンドで生成する Worker を起動
+	firstAnswerWorker := worker.NewFirstAnswerWorker(subscriber, workerChatUsecase)
+	go func() {
+		if err := firstAnswerWorker.Run(context.Background()); err != nil {
+			slog.Error("FirstAnswerWorker failed", "error", err)
+		}
+	}()
+
+	// 長い回答からフォーク候補を自動で抽出する場合のみ Worker を起動
+	if cfg.ForkSuggestion.Auto {
+		forkSuggestionWorker := worker.NewForkSuggestionWorker(subscriber, workerChatUsecase)
+		go func() {
+			if err := forkSuggestionWorker.Run(context.Background()); err != nil {
+				slog.Error("ForkSuggestionWorker failed", "error", err)
+			}
+		}()
+	}
+
 	// サーバーの初期化
-	e := setupServer(cfg, db, genaiClient, publisher)
+	e := setupServer(cfg, db, genaiClient, publisher, promptRenderer, chatEventHub)
 
 	// サーバーの起動
 	e.Logger.Fatal(e.Start(cfg.Server.Address))
 }
 
 // Workerの依存関係を初期化する
-func setupWorker(db *gorm.DB, genaiClient *genai.Client, subscriber message.Subscriber) *worker.SummaryWorker {
+func setupWorker(db *gorm.DB, genaiClient *genai.Client, subscriber message.Subscriber, promptRenderer domainUsecase.PromptRenderer) *worker.SummaryWorker {
 	messageRepo := repository.NewMessageRepository(db)
 	genaiClientWrapper := usecase.NewGenAIClientWrapper(genaiClient)
-	return worker.NewSummaryWorker(subscriber, messageRepo, genaiClientWrapper)
+	return worker.NewSummaryWorker(subscriber, messageRepo, genaiClientWrapper, promptRenderer)
+}
+
+// ChatUsecase を使う Worker (フォーク候補抽出・最初の回答の生成) の依存関係を初期化する
+// サーバーと同じ依存関係を使い、リアルタイム通知のハブも共有する
+func setupWorkerChatUsecase(db *gorm.DB, genaiClient *genai.Client, publisher message.Publisher, promptRenderer domainUsecase.PromptRenderer, chatEventHub domainUsecase.ChatEventHub) domainUsecase.ChatUsecase {
+	return usecase.NewChatUsecase(
+		repository.NewChatRepository(db),
+		repository.NewMessageRepository(db),
+		repository.NewMessageSelectionRepository(db),
+		repository.NewEdgeRepository(db),
+		repository.NewChatMergeEventRepository(db),
+		repository.NewBranchComparisonRepository(db),
+		repository.NewForkSuggestionRepository(db),
+		repository.NewProjectRepository(db),
+		repository.NewUserRepository(db),
+		repository.NewTransactionManager(db),
+		usecase.NewGenAIClientWrapper(genaiClient),
+		publisher,
+		promptRenderer,
+		chatEventHub,
+	)
 }
 
 // サーバーの依存関係を初期化する
-func setupServer(cfg *config.Config, db *gorm.DB, genaiClient *genai.Client, publisher message.Publisher) *echo.Echo {
+func setupServer(cfg *config.Config, db *gorm.DB, genaiClient *genai.Client, publisher message.Publisher, promptRenderer domainUsecase.PromptRenderer, chatEventHub domainUsecase.ChatEventHub) *echo.Echo {
 	e := echo.New()
-	router.InitRoutes(e, db, cfg, genaiClient, publisher)
+	router.InitRoutes(e, db, cfg, genaiClient, publisher, promptRenderer, chatEventHub)
 	return e
 }
@@ -2,6 +2,8 @@ package main
 
 import (
 	"backend/config"
+	"backend/internal/infrastructure/prompt"
+	"backend/internal/infrastructure/realtime"
 	"backend/internal/worker"
 	"context"
 	"testing"
@@ -84,7 +86,9 @@ func Test_setupServer(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			cfg, db, genaiClient, publisher := tt.setup(t)
-			e := setupServer(cfg, db, genaiClient, publisher)
+			promptRenderer, err := prompt.NewRenderer("", "")
+			assert.NoError(t, err)
+			e := setupServer(cfg, db, genaiClient, publisher, promptRenderer, realtime.NewHub())
 			tt.assertion(t, e)
 		})
 	}
@@ -115,8 +119,23 @@ func Test_setupWorker(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			db, genaiClient, subscriber := tt.setup(t)
-			w := setupWorker(db, genaiClient, subscriber)
+			promptRenderer, err := prompt.NewRenderer("", "")
+			assert.NoError(t, err)
+			w := setupWorker(db, genaiClient, subscriber, promptRenderer)
 			tt.assertion(t, w)
 		})
 	}
 }
+
+func Test_setupWorkerChatUsecase(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	genaiClient, err := genai.NewClient(context.Background(), &genai.ClientConfig{APIKey: "dummy"})
+	assert.NoError(t, err)
+	promptRenderer, err := prompt.NewRenderer("", "")
+	assert.NoError(t, err)
+
+	chatUsecase := setupWorkerChatUsecase(db, genaiClient, new(MockPublisher), promptRenderer, realtime.NewHub())
+	assert.NotNil(t, chatUsecase)
+	assert.NotNil(t, worker.NewFirstAnswerWorker(new(MockSubscriber), chatUsecase))
+}
@@ -17,6 +17,13 @@ type Config struct {
 	JWT      JWTConfig      `yaml:"jwt"`
 	Logger   LoggerConfig   `yaml:"logger"`
 	Gemini   GeminiConfig   `yaml:"gemini"`
+	Prompt   PromptConfig   `yaml:"prompt"`
+	OIDC     OIDCConfig     `yaml:"oidc"`
+	Cookie   CookieConfig   `yaml:"cookie"`
+	// 長い回答からのフォーク候補の自動抽出
+	ForkSuggestion ForkSuggestionConfig `yaml:"forkSuggestion"`
+	// プロジェクトツリーの自動レイアウト
+	Layout LayoutConfig `yaml:"layout"`
 }
 
 type ServerConfig struct {
@@ -32,8 +39,51 @@ type DatabaseConfig struct {
 }
 
 type JWTConfig struct {
-	Secret     string        `yaml:"secret"`
+	// 署名鍵 (keys が設定されていない場合、または kid のないトークンの検証に使用する)
+	Secret string `yaml:"secret"`
+	// アクセストークンの有効期限（0の場合は15分）
 	Expiration time.Duration `yaml:"expiration"`
+	// リフレッシュトークンの有効期限（0の場合は30日）
+	RefreshExpiration time.Duration `yaml:"refreshExpiration"`
+	// 署名に使用する鍵のID
+	CurrentKeyID string `yaml:"currentKeyID"`
+	// 鍵ID -> 署名鍵（鍵のローテーション時は旧鍵を残したまま currentKeyID を切り替える）
+	Keys map[string]string `yaml:"keys"`
+}
+
+// トークンの署名に使用する鍵IDと鍵を返す処理
+// 鍵IDが設定されていない場合は secret を使用し、鍵IDは空文字を返す
+func (c JWTConfig) SigningKey() (string, []byte) {
+	if c.CurrentKeyID != "" {
+		if secret, ok := c.Keys[c.CurrentKeyID]; ok {
+			return c.CurrentKeyID, []byte(secret)
+		}
+	}
+	return "", []byte(c.Secret)
+}
+
+// トークンの検証に使用する鍵を返す処理
+// 鍵IDが空の場合はローテーション導入前のトークンとして secret を返す
+func (c JWTConfig) VerificationKey(kid string) ([]byte, error) {
+	if kid == "" {
+		return []byte(c.Secret), nil
+	}
+	secret, ok := c.Keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id: %s", kid)
+	}
+	return []byte(secret), nil
+}
+
+type CookieConfig struct {
+	// Secure 属性を付与する（HTTPSで配信する場合は true にする）
+	Secure bool `yaml:"secure"`
+	// アクセストークンのクッキーに HttpOnly 属性を付与する（リフレッシュトークンには常に付与する）
+	HttpOnly bool `yaml:"httpOnly"`
+	// クッキーの Domain 属性（空の場合は付与しない）
+	Domain string `yaml:"domain"`
+	// SameSite 属性 (lax, strict, none。空の場合は lax)
+	SameSite string `yaml:"sameSite"`
 }
 
 type LoggerConfig struct {
@@ -45,6 +95,43 @@ type GeminiConfig struct {
 	APIKey string `yaml:"apiKey"`
 }
 
+type PromptConfig struct {
+	// 組み込みテンプレートを上書きするテンプレートのディレクトリ（空の場合は組み込みのみ使用）
+	Dir string `yaml:"dir"`
+	// 出力言語が指定されていない場合に使用する言語
+	DefaultLanguage string `yaml:"defaultLanguage"`
+}
+
+type ForkSuggestionConfig struct {
+	// 長い回答の生成後にバックグラウンドでフォーク候補を抽出する (false の場合は手動で抽出した場合のみ)
+	Auto bool `yaml:"auto"`
+}
+
+type LayoutConfig struct {
+	// ノードの幅 (0 の場合は 200)
+	NodeWidth float64 `yaml:"nodeWidth"`
+	// 横に並ぶノードの間隔 (0 の場合は 50)
+	SiblingGap float64 `yaml:"siblingGap"`
+	// 親子のノードの縦方向の間隔 (0 の場合は 150)
+	LevelHeight float64 `yaml:"levelHeight"`
+}
+
+type OIDCConfig struct {
+	// ログイン完了後にリダイレクトするフロントエンドのURL（空の場合はJSONを返す）
+	SuccessRedirectURL string `yaml:"successRedirectURL"`
+	// プロバイダー名 -> プロバイダー設定（プロバイダー名は users.provider に保存される）
+	Providers map[string]OIDCProviderConfig `yaml:"providers"`
+}
+
+type OIDCProviderConfig struct {
+	// OIDC Discovery に使用する Issuer のURL (例: https://accounts.google.com)
+	Issuer       string   `yaml:"issuer"`
+	ClientID     string   `yaml:"clientID"`
+	ClientSecret string   `yaml:"clientSecret"`
+	RedirectURL  string   `yaml:"redirectURL"`
+	Scopes       []string `yaml:"scopes"`
+}
+
 // 指定されたパスから設定ファイルを読み込む処理
 func LoadConfig(path string) (*Config, error) {
 	config := &Config{}
@@ -21,7 +21,7 @@ database:
   dbname: "testdb"
 jwt:
   secret: "testsecret"
-  expiration: 24h
+  expiration: 15m
 `
 
 	// 不正なYAMLファイルの内容
@@ -62,7 +62,7 @@ database:
 				},
 				JWT: JWTConfig{
 					Secret:     "testsecret",
-					Expiration: 24 * time.Hour,
+					Expiration: 15 * time.Minute,
 				},
 			},
 			wantErr: false,
@@ -108,3 +108,43 @@ database:
 		})
 	}
 }
+
+func TestJWTConfig_Keys(t *testing.T) {
+	cfg := JWTConfig{
+		Secret:       "legacy-secret",
+		CurrentKeyID: "2024-02",
+		Keys: map[string]string{
+			"2024-01": "old-secret",
+			"2024-02": "new-secret",
+		},
+	}
+
+	kid, key := cfg.SigningKey()
+	assert.Equal(t, "2024-02", kid)
+	assert.Equal(t, []byte("new-secret"), key)
+
+	tests := []struct {
+		name    string
+		kid     string
+		want    []byte
+		wantErr bool
+	}{
+		{name: "正常系: 旧鍵で署名されたトークンを検証できること", kid: "2024-01", want: []byte("old-secret")},
+		{name: "正常系: 鍵IDのないトークンは secret で検証されること", kid: "", want: []byte("legacy-secret")},
+		{name: "異常系: 不明な鍵IDの場合エラーになること", kid: "unknown", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cfg.VerificationKey(tt.kid)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerificationKey() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	kid, key = JWTConfig{Secret: "legacy-secret"}.SigningKey()
+	assert.Equal(t, "", kid)
+	assert.Equal(t, []byte("legacy-secret"), key)
+}
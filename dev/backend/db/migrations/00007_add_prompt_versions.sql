@@ -0,0 +1,28 @@
+-- +goose Up
+-- 1. プロジェクトの出力言語
+ALTER TABLE projects
+ADD COLUMN language VARCHAR(10) NULL COMMENT 'LLMの出力言語' AFTER title;
+
+-- 2. フォーク時のコンテキスト生成に使用したプロンプトのバージョン
+ALTER TABLE chats
+ADD COLUMN context_prompt_version VARCHAR(255) NULL COMMENT 'コンテキスト生成に使用したプロンプトのバージョン' AFTER context_summary;
+
+-- 3. 要約・メッセージ生成に使用したプロンプトのバージョン
+ALTER TABLE messages
+ADD COLUMN summary_prompt_version VARCHAR(255) NULL COMMENT '要約生成に使用したプロンプトのバージョン' AFTER context_summary;
+
+ALTER TABLE messages
+ADD COLUMN prompt_version VARCHAR(255) NULL COMMENT 'メッセージ生成に使用したプロンプトのバージョン' AFTER summary_prompt_version;
+
+-- +goose Down
+ALTER TABLE messages
+DROP COLUMN prompt_version;
+
+ALTER TABLE messages
+DROP COLUMN summary_prompt_version;
+
+ALTER TABLE chats
+DROP COLUMN context_prompt_version;
+
+ALTER TABLE projects
+DROP COLUMN language;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- ユーザーの優先言語
+ALTER TABLE users
+ADD COLUMN language VARCHAR(10) NULL COMMENT 'ユーザーの優先言語' AFTER avatar_url;
+
+-- +goose Down
+ALTER TABLE users
+DROP COLUMN language;
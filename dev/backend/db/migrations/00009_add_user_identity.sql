@@ -0,0 +1,11 @@
+-- +goose Up
+-- OIDCプロバイダーごとにサブジェクトを一意にする
+-- google_id には各プロバイダーの sub クレームを保存する
+ALTER TABLE users
+DROP INDEX uk_google_id,
+ADD UNIQUE KEY uk_provider_google_id (provider, google_id);
+
+-- +goose Down
+ALTER TABLE users
+DROP INDEX uk_provider_google_id,
+ADD UNIQUE KEY uk_google_id (google_id);
@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE sessions (
+    uuid VARCHAR(255) NOT NULL COMMENT 'UUID',
+    user_uuid VARCHAR(255) NOT NULL COMMENT 'usersテーブルのUUID',
+    refresh_token_hash CHAR(64) NOT NULL COMMENT '現在のリフレッシュトークンのSHA-256',
+    previous_token_hash CHAR(64) NULL COMMENT 'ローテーション前のリフレッシュトークンのSHA-256（再利用検知用）',
+    user_agent VARCHAR(512) NULL COMMENT 'ログイン時のUser-Agent',
+    ip_address VARCHAR(45) NULL COMMENT 'ログイン時のIPアドレス',
+    expires_at TIMESTAMP NOT NULL COMMENT 'リフレッシュトークンの有効期限',
+    last_used_at TIMESTAMP NOT NULL COMMENT '最終使用日時',
+    revoked_at TIMESTAMP NULL COMMENT '失効日時',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (uuid),
+    UNIQUE KEY uk_sessions_refresh_token_hash (refresh_token_hash),
+    KEY idx_sessions_previous_token_hash (previous_token_hash),
+    KEY idx_sessions_user_uuid (user_uuid),
+    CONSTRAINT fk_sessions_user FOREIGN KEY (user_uuid) REFERENCES users(uuid) ON DELETE CASCADE
+) COMMENT='ログインセッション管理テーブル';
+
+-- +goose Down
+DROP TABLE sessions;
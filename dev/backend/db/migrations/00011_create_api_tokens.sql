@@ -0,0 +1,39 @@
+-- +goose Up
+CREATE TABLE api_tokens (
+    uuid VARCHAR(255) NOT NULL COMMENT 'UUID',
+    user_uuid VARCHAR(255) NOT NULL COMMENT 'usersテーブルのUUID',
+    name VARCHAR(255) NOT NULL COMMENT 'トークンの名前',
+    token_hash CHAR(64) NOT NULL COMMENT 'トークンのSHA-256',
+    token_prefix VARCHAR(16) NOT NULL COMMENT '識別用のトークン先頭文字列',
+    scope VARCHAR(50) NOT NULL DEFAULT 'read' COMMENT 'read or write',
+    project_uuid VARCHAR(255) NULL COMMENT 'アクセスを許可するプロジェクト（NULLの場合は全プロジェクト）',
+    expires_at TIMESTAMP NULL COMMENT '有効期限（NULLの場合は無期限）',
+    last_used_at TIMESTAMP NULL COMMENT '最終使用日時',
+    revoked_at TIMESTAMP NULL COMMENT '失効日時',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (uuid),
+    UNIQUE KEY uk_api_tokens_token_hash (token_hash),
+    KEY idx_api_tokens_user_uuid (user_uuid),
+    CONSTRAINT fk_api_tokens_user FOREIGN KEY (user_uuid) REFERENCES users(uuid) ON DELETE CASCADE,
+    CONSTRAINT fk_api_tokens_project FOREIGN KEY (project_uuid) REFERENCES projects(uuid) ON DELETE CASCADE
+) COMMENT='APIトークン管理テーブル';
+
+CREATE TABLE api_token_audit_logs (
+    uuid VARCHAR(255) NOT NULL COMMENT 'UUID',
+    api_token_uuid VARCHAR(255) NOT NULL COMMENT 'api_tokensテーブルのUUID',
+    user_uuid VARCHAR(255) NOT NULL COMMENT 'usersテーブルのUUID',
+    event VARCHAR(50) NOT NULL COMMENT 'created, revoked, used or denied',
+    method VARCHAR(10) NULL COMMENT 'HTTPメソッド',
+    path VARCHAR(2048) NULL COMMENT 'リクエストパス',
+    status_code INT NULL COMMENT 'レスポンスのステータスコード',
+    ip_address VARCHAR(45) NULL COMMENT 'リクエスト元のIPアドレス',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (uuid),
+    KEY idx_api_token_audit_logs_token (api_token_uuid, created_at),
+    CONSTRAINT fk_api_token_audit_logs_token FOREIGN KEY (api_token_uuid) REFERENCES api_tokens(uuid) ON DELETE CASCADE
+) COMMENT='APIトークン監査ログテーブル';
+
+-- +goose Down
+DROP TABLE api_token_audit_logs;
+DROP TABLE api_tokens;
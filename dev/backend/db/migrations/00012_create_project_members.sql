@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE project_members (
+    project_uuid VARCHAR(255) NOT NULL COMMENT 'projectsテーブルのUUID',
+    user_uuid VARCHAR(255) NOT NULL COMMENT '招待されたユーザーのUUID',
+    role VARCHAR(50) NOT NULL DEFAULT 'viewer' COMMENT 'viewer, editor or owner',
+    status VARCHAR(50) NOT NULL DEFAULT 'pending' COMMENT 'pending or accepted',
+    invited_by_user_uuid VARCHAR(255) NOT NULL COMMENT '招待したユーザーのUUID',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (project_uuid, user_uuid),
+    KEY idx_project_members_user (user_uuid, status),
+    CONSTRAINT fk_project_members_project FOREIGN KEY (project_uuid) REFERENCES projects(uuid) ON DELETE CASCADE,
+    CONSTRAINT fk_project_members_user FOREIGN KEY (user_uuid) REFERENCES users(uuid) ON DELETE CASCADE
+) COMMENT='プロジェクト共有メンバー管理テーブル';
+
+-- +goose Down
+DROP TABLE project_members;
@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE share_links (
+    uuid VARCHAR(255) NOT NULL COMMENT 'UUID',
+    project_uuid VARCHAR(255) NOT NULL COMMENT '公開するプロジェクトのUUID',
+    root_chat_uuid VARCHAR(255) NULL COMMENT '公開する部分木の起点チャット（NULLの場合はプロジェクト全体）',
+    token_hash CHAR(64) NOT NULL COMMENT 'トークンのSHA-256',
+    token_prefix VARCHAR(16) NOT NULL COMMENT '識別用のトークン先頭文字列',
+    created_by_user_uuid VARCHAR(255) NOT NULL COMMENT '作成したユーザーのUUID',
+    expires_at TIMESTAMP NULL COMMENT '有効期限（NULLの場合は無期限）',
+    revoked_at TIMESTAMP NULL COMMENT '失効日時',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (uuid),
+    UNIQUE KEY uk_share_links_token_hash (token_hash),
+    KEY idx_share_links_project_uuid (project_uuid),
+    CONSTRAINT fk_share_links_project FOREIGN KEY (project_uuid) REFERENCES projects(uuid) ON DELETE CASCADE,
+    CONSTRAINT fk_share_links_root_chat FOREIGN KEY (root_chat_uuid) REFERENCES chats(uuid) ON DELETE CASCADE
+) COMMENT='読み取り専用の共有リンク管理テーブル';
+
+-- +goose Down
+DROP TABLE share_links;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- 複数メンバーが同じチャットで同時に回答を生成しないためのロック
+ALTER TABLE chats
+ADD COLUMN generation_locked_until DATETIME NULL COMMENT '回答生成中のロックの有効期限' AFTER position_y;
+
+-- +goose Down
+ALTER TABLE chats
+DROP COLUMN generation_locked_until;
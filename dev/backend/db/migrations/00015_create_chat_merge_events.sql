@@ -0,0 +1,27 @@
+-- +goose Up
+-- マージの取り消しでマージレポートを物理削除せずに残すための論理削除
+ALTER TABLE messages
+ADD COLUMN deleted_at DATETIME NULL COMMENT '論理削除日時' AFTER updated_at,
+ADD KEY idx_messages_deleted_at (deleted_at);
+
+CREATE TABLE chat_merge_events (
+    uuid VARCHAR(255) NOT NULL COMMENT 'UUID',
+    chat_uuid VARCHAR(255) NOT NULL COMMENT 'マージ・マージ取り消しされた子チャットのUUID',
+    parent_chat_uuid VARCHAR(255) NOT NULL COMMENT 'マージ先の親チャットのUUID',
+    report_message_uuid VARCHAR(255) NOT NULL COMMENT 'マージレポートのメッセージUUID',
+    action VARCHAR(50) NOT NULL COMMENT 'merged or unmerged',
+    previous_status ENUM('open', 'merged', 'closed') NOT NULL COMMENT '操作前の子チャットのステータス',
+    forced BOOLEAN NOT NULL DEFAULT FALSE COMMENT 'レポートを参照した回答があっても強制的に取り消したか',
+    user_uuid VARCHAR(255) NULL COMMENT '操作したユーザーのUUID',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (uuid),
+    KEY idx_chat_merge_events_chat (chat_uuid, created_at),
+    CONSTRAINT fk_chat_merge_events_chat FOREIGN KEY (chat_uuid) REFERENCES chats(uuid) ON DELETE CASCADE,
+    CONSTRAINT fk_chat_merge_events_parent FOREIGN KEY (parent_chat_uuid) REFERENCES chats(uuid) ON DELETE CASCADE
+) COMMENT='チャットのマージ履歴テーブル';
+
+-- +goose Down
+DROP TABLE chat_merge_events;
+ALTER TABLE messages
+DROP KEY idx_messages_deleted_at,
+DROP COLUMN deleted_at;
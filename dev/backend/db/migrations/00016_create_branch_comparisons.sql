@@ -0,0 +1,25 @@
+-- +goose Up
+CREATE TABLE branch_comparisons (
+    uuid VARCHAR(255) NOT NULL COMMENT 'UUID',
+    project_uuid VARCHAR(255) NOT NULL COMMENT 'プロジェクトのUUID',
+    chat_uuid VARCHAR(255) NOT NULL COMMENT '比較したブランチのUUID',
+    other_chat_uuid VARCHAR(255) NOT NULL COMMENT '比較相手のブランチのUUID',
+    anchor_chat_uuid VARCHAR(255) NOT NULL COMMENT '共通の祖先メッセージを含むチャットのUUID',
+    anchor_message_uuid VARCHAR(255) NOT NULL COMMENT 'メモを紐付ける共通の祖先メッセージのUUID',
+    positions TEXT NOT NULL COMMENT 'ブランチごとの立場 (JSON)',
+    agreements TEXT NOT NULL COMMENT '一致している点 (JSON)',
+    disagreements TEXT NOT NULL COMMENT '相違している点 (JSON)',
+    recommendation TEXT NOT NULL COMMENT '推奨',
+    prompt_version VARCHAR(255) NULL COMMENT '生成に使用したプロンプトのバージョン',
+    user_uuid VARCHAR(255) NULL COMMENT '比較したユーザーのUUID',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (uuid),
+    KEY idx_branch_comparisons_anchor_chat (anchor_chat_uuid, created_at),
+    CONSTRAINT fk_branch_comparisons_project FOREIGN KEY (project_uuid) REFERENCES projects(uuid) ON DELETE CASCADE,
+    CONSTRAINT fk_branch_comparisons_chat FOREIGN KEY (chat_uuid) REFERENCES chats(uuid) ON DELETE CASCADE,
+    CONSTRAINT fk_branch_comparisons_other_chat FOREIGN KEY (other_chat_uuid) REFERENCES chats(uuid) ON DELETE CASCADE,
+    CONSTRAINT fk_branch_comparisons_anchor_message FOREIGN KEY (anchor_message_uuid) REFERENCES messages(uuid) ON DELETE CASCADE
+) COMMENT='ブランチの比較結果 (共通の祖先メッセージのメモ) テーブル';
+
+-- +goose Down
+DROP TABLE branch_comparisons;
@@ -0,0 +1,16 @@
+-- +goose Up
+-- 別のブランチから取り込んだ (cherry-pick した) メッセージの取り込み元
+ALTER TABLE messages
+ADD COLUMN origin_message_uuid VARCHAR(255) NULL COMMENT '取り込み元のメッセージUUID' AFTER source_chat_uuid,
+ADD COLUMN origin_chat_uuid VARCHAR(255) NULL COMMENT '取り込み元のチャットUUID' AFTER origin_message_uuid,
+ADD KEY idx_messages_origin_message (origin_message_uuid),
+ADD CONSTRAINT fk_messages_origin_message FOREIGN KEY (origin_message_uuid) REFERENCES messages(uuid) ON DELETE SET NULL,
+ADD CONSTRAINT fk_messages_origin_chat FOREIGN KEY (origin_chat_uuid) REFERENCES chats(uuid) ON DELETE SET NULL;
+
+-- +goose Down
+ALTER TABLE messages
+DROP FOREIGN KEY fk_messages_origin_chat,
+DROP FOREIGN KEY fk_messages_origin_message,
+DROP KEY idx_messages_origin_message,
+DROP COLUMN origin_chat_uuid,
+DROP COLUMN origin_message_uuid;
@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE fork_suggestions (
+    uuid VARCHAR(255) NOT NULL COMMENT 'UUID',
+    chat_uuid VARCHAR(255) NOT NULL COMMENT 'チャットのUUID',
+    message_uuid VARCHAR(255) NOT NULL COMMENT '候補を抽出したメッセージのUUID',
+    selected_text TEXT NOT NULL COMMENT '候補の話題を表す本文の部分',
+    range_start INT NOT NULL COMMENT '本文での開始位置 (文字単位)',
+    range_end INT NOT NULL COMMENT '本文での終了位置 (文字単位、終了位置を含まない)',
+    suggested_title VARCHAR(255) NOT NULL COMMENT 'タイトル案',
+    generated_context TEXT NOT NULL COMMENT '新しいチャットの冒頭に設定するコンテキスト',
+    prompt_version VARCHAR(255) NULL COMMENT '生成に使用したプロンプトのバージョン',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (uuid),
+    KEY idx_fork_suggestions_message (message_uuid, range_start),
+    CONSTRAINT fk_fork_suggestions_chat FOREIGN KEY (chat_uuid) REFERENCES chats(uuid) ON DELETE CASCADE,
+    CONSTRAINT fk_fork_suggestions_message FOREIGN KEY (message_uuid) REFERENCES messages(uuid) ON DELETE CASCADE
+) COMMENT='長い回答から抽出したフォーク候補テーブル';
+
+-- +goose Down
+DROP TABLE fork_suggestions;
@@ -0,0 +1,17 @@
+-- +goose Up
+CREATE TABLE tree_node_states (
+    node_id VARCHAR(255) NOT NULL COMMENT 'ノードのメッセージのUUID',
+    project_uuid VARCHAR(255) NOT NULL COMMENT 'プロジェクトのUUID',
+    position_x DOUBLE NULL COMMENT 'ユーザーが移動したX座標 (NULL の場合は自動レイアウト)',
+    position_y DOUBLE NULL COMMENT 'ユーザーが移動したY座標 (NULL の場合は自動レイアウト)',
+    collapsed BOOLEAN NOT NULL DEFAULT FALSE COMMENT '子ノードを折りたたんでいるか',
+    version INT NOT NULL DEFAULT 0 COMMENT '楽観的排他制御のためのバージョン',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (node_id),
+    KEY idx_tree_node_states_project (project_uuid),
+    CONSTRAINT fk_tree_node_states_project FOREIGN KEY (project_uuid) REFERENCES projects(uuid) ON DELETE CASCADE,
+    CONSTRAINT fk_tree_node_states_message FOREIGN KEY (node_id) REFERENCES messages(uuid) ON DELETE CASCADE
+) COMMENT='プロジェクトツリーのノードの表示状態テーブル';
+
+-- +goose Down
+DROP TABLE tree_node_states;
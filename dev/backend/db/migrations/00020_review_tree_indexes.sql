@@ -0,0 +1,10 @@
+-- +goose Up
+-- プロジェクト単位でツリーを一括取得するクエリ用のインデックス
+-- チャットはプロジェクト内で作成順に取得するため (project_uuid, created_at) の複合インデックスにする
+ALTER TABLE chats ADD KEY idx_chats_project_created (project_uuid, created_at);
+-- メッセージはチャットを JOIN したうえで作成順に並べるため (chat_uuid, created_at) の複合インデックスにする
+ALTER TABLE messages ADD KEY idx_messages_chat_created (chat_uuid, created_at);
+
+-- +goose Down
+ALTER TABLE messages DROP KEY idx_messages_chat_created;
+ALTER TABLE chats DROP KEY idx_chats_project_created;
@@ -0,0 +1,19 @@
+-- +goose Up
+-- ノードの位置は取得時にツリーの構造から計算するため、保存していた位置を削除する
+-- (ユーザーが移動した位置は tree_node_states に保存する)
+ALTER TABLE messages
+DROP COLUMN position_y,
+DROP COLUMN position_x;
+
+ALTER TABLE chats
+DROP COLUMN position_y,
+DROP COLUMN position_x;
+
+-- +goose Down
+ALTER TABLE chats
+ADD COLUMN position_x FLOAT DEFAULT 0 AFTER context_summary,
+ADD COLUMN position_y FLOAT DEFAULT 0 AFTER position_x;
+
+ALTER TABLE messages
+ADD COLUMN position_x FLOAT NOT NULL DEFAULT 0 AFTER context_summary,
+ADD COLUMN position_y FLOAT NOT NULL DEFAULT 0 AFTER position_x;
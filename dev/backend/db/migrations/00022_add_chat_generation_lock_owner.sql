@@ -0,0 +1,8 @@
+-- +goose Up
+-- ロックを取得した生成だけが延長・解放できるように、取得した生成を識別する値を保存する
+ALTER TABLE chats
+ADD COLUMN generation_lock_owner VARCHAR(255) NULL COMMENT '回答生成のロックを取得した生成の識別子' AFTER generation_locked_until;
+
+-- +goose Down
+ALTER TABLE chats
+DROP COLUMN generation_lock_owner;
@@ -0,0 +1,13 @@
+-- +goose Up
+-- 取り込んだメッセージから取り込み元のメッセージへのエッジは、ツリーの親子関係と区別できないため削除する
+-- 取り込み元は messages.origin_message_uuid / origin_chat_uuid に記録している
+DELETE edges FROM edges
+JOIN messages ON messages.uuid = edges.source_message_uuid
+WHERE messages.origin_message_uuid IS NOT NULL
+  AND edges.target_message_uuid = messages.origin_message_uuid;
+
+-- +goose Down
+INSERT INTO edges (uuid, chat_uuid, source_message_uuid, target_message_uuid)
+SELECT UUID(), messages.chat_uuid, messages.uuid, messages.origin_message_uuid
+FROM messages
+WHERE messages.origin_message_uuid IS NOT NULL;
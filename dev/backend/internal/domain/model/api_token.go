@@ -0,0 +1,65 @@
+package model
+
+import "time"
+
+// APIトークンのスコープ
+const (
+	// GET・HEAD のみ許可する (GET でも回答を生成する処理は許可しない)
+	APITokenScopeRead = "read"
+	// すべてのメソッドを許可する
+	APITokenScopeWrite = "write"
+)
+
+// APIトークンの監査ログのイベント
+const (
+	APITokenEventCreated = "created"
+	APITokenEventRevoked = "revoked"
+	APITokenEventUsed    = "used"
+	APITokenEventDenied  = "denied"
+)
+
+// スクリプトやCLIから Authorization: Bearer で使用するユーザー発行のトークン
+type APIToken struct {
+	UUID     string
+	UserUUID string
+	Name     string
+	// 一覧で識別するためのトークンの先頭文字列 (トークン本体はハッシュのみ保存する)
+	Prefix string
+	Scope  string
+	// アクセスを許可するプロジェクト (空の場合は全プロジェクト)
+	ProjectUUID string
+	// 有効期限 (nil の場合は無期限)
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// APIトークン作成時の入力
+type CreateAPITokenParams struct {
+	Name        string
+	Scope       string
+	ProjectUUID string
+	// 有効期限 (nil の場合は無期限)
+	ExpiresAt *time.Time
+}
+
+// APIトークンでアクセスしようとしているリクエストの情報
+type APITokenAccess struct {
+	Method      string
+	ProjectUUID string
+	ChatUUID    string
+}
+
+// APIトークンの監査ログ
+type APITokenAuditLog struct {
+	UUID         string
+	APITokenUUID string
+	UserUUID     string
+	Event        string
+	Method       string
+	Path         string
+	StatusCode   int
+	IPAddress    string
+	CreatedAt    time.Time
+}
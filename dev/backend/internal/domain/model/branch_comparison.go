@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// 2つのブランチを比較した結果
+// 保存した場合は共通の祖先メッセージに紐づくメモになる
+type BranchComparison struct {
+	// 保存していない場合は空
+	UUID          string
+	ProjectUUID   string
+	ChatUUID      string
+	OtherChatUUID string
+	// 2つのブランチの共通の祖先メッセージ (別々のルートから始まる場合は nil)
+	AnchorChatUUID    *string
+	AnchorMessageUUID *string
+	Positions         []BranchPosition
+	Agreements        []string
+	Disagreements     []string
+	Recommendation    string
+	PromptVersion     string
+	UserUUID          *string
+	CreatedAt         time.Time
+}
+
+// 比較したブランチごとの立場
+type BranchPosition struct {
+	ChatUUID string `json:"chat_uuid"`
+	Title    string `json:"title"`
+	Position string `json:"position"`
+}
+
+type CompareBranchesParams struct {
+	UserUUID      string
+	OtherChatUUID string
+	// 比較結果を共通の祖先メッセージのメモとして保存する
+	SaveNote bool
+}
@@ -2,6 +2,39 @@ package model
 
 import "time"
 
+// チャットのステータス
+const (
+	ChatStatusOpen   = "open"
+	ChatStatusClosed = "closed"
+	ChatStatusMerged = "merged"
+)
+
+// 通常の操作で許可されているステータスの遷移
+// merged から open への遷移はマージの取り消しでのみ行う
+var chatStatusTransitions = map[string][]string{
+	ChatStatusOpen:   {ChatStatusClosed, ChatStatusMerged},
+	ChatStatusClosed: {ChatStatusOpen},
+}
+
+// 定義されているステータスか判定する処理
+func IsValidChatStatus(status string) bool {
+	switch status {
+	case ChatStatusOpen, ChatStatusClosed, ChatStatusMerged:
+		return true
+	}
+	return false
+}
+
+// 通常の操作でステータスを遷移できるか判定する処理
+func CanTransitionChatStatus(from, to string) bool {
+	for _, allowed := range chatStatusTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
 type Chat struct {
 	UUID                 string
 	ProjectUUID          string
@@ -11,17 +44,48 @@ type Chat struct {
 	Title                string
 	Status               string
 	ContextSummary       string
-	PositionX            float64
-	PositionY            float64
-	CreatedAt            time.Time
-	UpdatedAt            time.Time
+	ContextPromptVersion string
+	// 回答の生成中はロックの有効期限が入る
+	GenerationLockedUntil *time.Time
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+}
+
+// メッセージの追加などの書き込みができるかを判定する処理
+func (c *Chat) IsWritable() bool {
+	return c.Status == ChatStatusOpen
+}
+
+// 指定時刻に回答の生成中かどうかを判定する処理
+func (c *Chat) IsGenerating(now time.Time) bool {
+	return c.GenerationLockedUntil != nil && now.Before(*c.GenerationLockedUntil)
 }
 
 type MergePreview struct {
 	SuggestedSummary string
+	PromptVersion    string
+	// マージ先のチャット (ルートのチャットの場合は空)
+	TargetChatUUID string
+	// フォーク後のマージ先の進み具合 (マージ先がない場合は nil)
+	Divergence *MergeDivergence
+}
+
+// フォークした時点からマージ先のチャットがどれだけ進んだか
+type MergeDivergence struct {
+	// マージ先でブランチの起点となったメッセージ
+	AnchorMessageUUID string
+	// 起点より後に追加されたメッセージ数 (マージレポートを含む)
+	NewMessages int
+	// 起点より後に追加された AI の回答数
+	NewAssistantMessages int
+	// 起点より後に他のブランチからマージされたレポート数
+	MergedReports int
+	// マージ先の議論が進んでおり、レポートとの調整が必要かどうか
+	Stale bool
 }
 
 // usecase から呼び出されるのでここに配置する
+// TargetMessageUUID を省略するとチャットの先頭から、SelectedText を省略するとメッセージ全体からフォークする
 type ForkPreviewRequest struct {
 	TargetMessageUUID string `json:"target_message_uuid"`
 	SelectedText      string `json:"selected_text"`
@@ -32,24 +96,111 @@ type ForkPreviewRequest struct {
 type ForkPreviewResponse struct {
 	SuggestedTitle   string `json:"suggested_title"`
 	GeneratedContext string `json:"generated_context"`
+	PromptVersion    string `json:"prompt_version"`
 }
 
 type ForkChatParams struct {
+	// 省略した場合はチャットの先頭のメッセージからフォークする
 	TargetMessageUUID string
 	ParentChatUUID    string
-	SelectedText      string
-	RangeStart        int
-	RangeEnd          int
-	Title             string
-	ContextSummary    string
+	// 省略した場合は範囲を選択せずにメッセージ全体からフォークする
+	SelectedText   string
+	RangeStart     int
+	RangeEnd       int
+	Title          string
+	ContextSummary string
+	PromptVersion  string // プレビュー生成に使用したプロンプトのバージョン
+}
+
+// 1つのメッセージから複数のブランチをまとめてフォークするパラメータ
+type BatchForkChatParams struct {
+	ParentChatUUID string
+	// 省略した場合はチャットの先頭のメッセージからフォークする
+	TargetMessageUUID string
+	Forks             []BatchForkItem
+	// フォークしたチャットの最初の回答をバックグラウンドで生成する
+	GenerateFirstAnswers bool
+}
+
+// まとめてフォークするブランチごとの選択範囲・タイトル・コンテキスト
+type BatchForkItem struct {
+	// 省略した場合は範囲を選択せずにメッセージ全体からフォークする
+	SelectedText   string
+	RangeStart     int
+	RangeEnd       int
+	Title          string
+	ContextSummary string
+	PromptVersion  string // プレビュー生成に使用したプロンプトのバージョン
+}
+
+// フォークしたチャットの最初の回答の生成タスク
+type FirstAnswerTask struct {
+	ChatUUID string `json:"chat_uuid"`
 }
 
 type MergeChatParams struct {
+	UserUUID string
+	// マージ先のチャット (子チャットの祖先であれば直接の親でなくてもよい)
 	ParentChatUUID string
 	SummaryContent string
+	PromptVersion  string // プレビュー生成に使用したプロンプトのバージョン
+	// 子孫のブランチも含めて部分木ごとマージする
+	IncludeDescendants bool
+	// マージ後にレポートの結論を会話に統合する回答を生成する
+	Acknowledge bool
 }
 
 type MergeChatResult struct {
 	ReportMessageID string
 	SummaryContent  string
+	TargetChatUUID  string
+	// マージ済みにしたチャット (部分木ごとマージした場合は子孫のチャットも含む)
+	MergedChatUUIDs []string
+	// レポートの結論を会話に統合した回答のメッセージID
+	AcknowledgmentMessageID string
+}
+
+type UnmergeChatParams struct {
+	UserUUID string
+	// マージレポートを文脈に含めて生成された回答があっても取り消す
+	Force bool
+}
+
+type UnmergeChatResult struct {
+	ChatUUID string
+	// マージ前に戻した子チャットのステータス
+	Status            string
+	ReportMessageUUID string
+	// マージ前のステータスに戻したチャット (部分木ごとマージした場合は子孫のチャットも含む)
+	RestoredChatUUIDs []string
+}
+
+type RebaseChatParams struct {
+	// 付け替え先の起点となるメッセージ (同じプロジェクト内の別のチャットのメッセージでもよい)
+	SourceMessageUUID string
+	// 省略した場合は範囲を選択せずにメッセージ全体を起点にする
+	SelectedText string
+	RangeStart   int
+	RangeEnd     int
+	// 新しい起点からフォーク時の文脈の要約を生成し直す
+	RegenerateContext bool
+}
+
+type RebaseChatResult struct {
+	ChatUUID             string
+	ParentChatUUID       string
+	SourceMessageUUID    string
+	ContextSummary       string
+	ContextPromptVersion string
+}
+
+type CherryPickParams struct {
+	// 取り込むメッセージ (同じプロジェクト内の別のチャットのメッセージ)
+	MessageUUIDs []string
+}
+
+type CherryPickResult struct {
+	ChatUUID string
+	// 取り込んで作成したメッセージ (取り込み元の作成日時の順)
+	Messages []*Message
 }
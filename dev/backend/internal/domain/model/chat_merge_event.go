@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// マージ履歴の操作の種類
+const (
+	ChatMergeActionMerged   = "merged"
+	ChatMergeActionUnmerged = "unmerged"
+)
+
+// チャットのマージ・マージ取り消しの履歴
+type ChatMergeEvent struct {
+	UUID              string
+	ChatUUID          string
+	ParentChatUUID    string
+	ReportMessageUUID string
+	Action            string
+	// 操作前の子チャットのステータス (マージ取り消し時に元に戻すために使う)
+	PreviousStatus string
+	// レポートを参照した回答があっても強制的に取り消したか
+	Forced    bool
+	UserUUID  *string
+	CreatedAt time.Time
+}
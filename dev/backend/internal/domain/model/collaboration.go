@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// チャットの閲覧者に配信するイベントの種類
+const (
+	ChatEventMessage            = "message"
+	ChatEventMessageRemoved     = "message_removed"
+	ChatEventPresence           = "presence"
+	ChatEventGenerationStarted  = "generation_started"
+	ChatEventGenerationFinished = "generation_finished"
+)
+
+// チャットを閲覧中のユーザー
+type ChatPresence struct {
+	ProjectUUID string
+	ChatUUID    string
+	UserUUID    string
+	UserName    string
+	// 入力中かどうか (一定時間更新がない場合は入力中でなくなる)
+	Typing   bool
+	JoinedAt time.Time
+}
+
+// チャットの閲覧者に配信するイベント
+type ChatEvent struct {
+	Type     string
+	ChatUUID string
+	// Type が message の場合に新しいメッセージ、message_removed の場合に削除されたメッセージが入る
+	Message *Message
+	// Type が presence の場合にチャットの閲覧者一覧が入る
+	Presence []*ChatPresence
+}
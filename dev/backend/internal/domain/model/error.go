@@ -0,0 +1,87 @@
+package model
+
+import "errors"
+
+// ハンドラー層でHTTPステータスコードに変換するためのドメインエラー
+var (
+	// 対応していない出力言語が指定された
+	ErrUnsupportedLanguage = errors.New("unsupported language")
+	// 設定されていないOIDCプロバイダーが指定された
+	ErrUnknownProvider = errors.New("unknown provider")
+	// OIDCログインの state が不正または期限切れ
+	ErrInvalidOIDCState = errors.New("invalid oidc state")
+	// ゲスト以外のユーザーに別のアカウントを紐付けようとした
+	ErrAlreadyLinked = errors.New("account already linked")
+	// ゲスト以外のユーザーにユーザーUUIDだけでログインしようとした
+	ErrGuestLoginDenied = errors.New("guest login denied")
+	// リフレッシュトークンが不正・期限切れ・失効済み
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	// 指定されたセッションが存在しない、または他のユーザーのセッション
+	ErrSessionNotFound = errors.New("session not found")
+	// アクセストークンのセッションが失効済み・期限切れ (ログアウト後など)
+	ErrSessionRevoked = errors.New("session revoked")
+	// 指定されたプロジェクトが存在しない、またはアクセスできない
+	ErrProjectNotFound = errors.New("project not found")
+	// APIトークンが不正・期限切れ・失効済み
+	ErrInvalidAPIToken = errors.New("invalid api token")
+	// APIトークンの名前・スコープ・有効期限が不正
+	ErrInvalidAPITokenParams = errors.New("invalid api token params")
+	// 指定されたAPIトークンが存在しない、または他のユーザーのトークン
+	ErrAPITokenNotFound = errors.New("api token not found")
+	// APIトークンのスコープでは許可されていない操作
+	ErrAPITokenScopeDenied = errors.New("api token scope denied")
+	// プロジェクトでのロールでは許可されていない操作
+	ErrProjectRoleDenied = errors.New("project role denied")
+	// 定義されていないロールが指定された
+	ErrInvalidProjectRole = errors.New("invalid project role")
+	// 指定されたユーザーが存在しない
+	ErrUserNotFound = errors.New("user not found")
+	// 指定されたメンバーまたは招待が存在しない
+	ErrProjectMemberNotFound = errors.New("project member not found")
+	// 既にメンバー・招待済み、またはプロジェクトの作成者を招待しようとした
+	ErrProjectMemberExists = errors.New("project member already exists")
+	// 共有リンクが存在しない・期限切れ・失効済み
+	ErrShareLinkNotFound = errors.New("share link not found")
+	// 共有リンクの起点チャット・有効期限が不正
+	ErrInvalidShareLinkParams = errors.New("invalid share link params")
+	// 共有リンクで公開されていないチャット
+	ErrChatNotShared = errors.New("chat not shared")
+	// 他のメンバーがチャットの回答を生成中
+	ErrChatGenerationInProgress = errors.New("chat generation in progress")
+	// マージ先が子チャットの祖先ではない
+	ErrInvalidMergeTarget = errors.New("invalid merge target")
+	// マージされていないチャットのマージを取り消そうとした
+	ErrChatNotMerged = errors.New("chat not merged")
+	// マージレポートを文脈に含めて生成された回答がある
+	ErrMergeReportInUse = errors.New("merge report in use")
+	// 許可されていないチャットのステータスの遷移
+	ErrInvalidChatStatusTransition = errors.New("invalid chat status transition")
+	// open 以外のチャットにメッセージを追加しようとした
+	ErrChatNotOpen = errors.New("chat not open")
+	// ブランチの付け替え先が不正 (別のプロジェクト、自身や子孫のメッセージなど)
+	ErrInvalidRebaseTarget = errors.New("invalid rebase target")
+	// 比較するブランチが不正 (同じチャット、別のプロジェクトなど)
+	ErrInvalidComparisonTarget = errors.New("invalid comparison target")
+	// 比較したブランチに共通の祖先メッセージがない
+	ErrNoCommonAncestor = errors.New("no common ancestor")
+	// 取り込むメッセージが不正 (別のプロジェクト、マージレポート、取り込み先のチャットのメッセージなど)
+	ErrInvalidCherryPick = errors.New("invalid cherry-pick")
+	// フォーク候補を抽出するメッセージが不正 (別のチャット、AIの回答以外など)
+	ErrInvalidForkSuggestionTarget = errors.New("invalid fork suggestion target")
+	// まとめてフォークするブランチの指定が不正 (0件、上限超過など)
+	ErrInvalidBatchFork = errors.New("invalid batch fork")
+	// 最初の回答を生成しようとしたチャットで既に会話が始まっている
+	ErrChatAlreadyStarted = errors.New("chat already started")
+	// フォーク元のメッセージが不正 (別のチャットのメッセージ、存在しないメッセージなど)
+	ErrInvalidForkTarget = errors.New("invalid fork target")
+	// フォークの選択範囲がフォーク元のメッセージの本文と一致しない
+	ErrInvalidForkSelection = errors.New("invalid fork selection")
+	// 表示状態を更新するノードがプロジェクトのツリーにない、または位置が不正
+	ErrInvalidTreeNode = errors.New("invalid tree node")
+	// ノードの表示状態が取得した後に他の操作で更新されている
+	ErrTreeNodeConflict = errors.New("tree node conflict")
+	// ツリーの取得条件が不正 (起点のチャットがプロジェクトにない、階層数が負など)
+	ErrInvalidTreeQuery = errors.New("invalid tree query")
+	// 指定したノードがプロジェクトのツリーにない
+	ErrTreeNodeNotFound = errors.New("tree node not found")
+)
@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// 長い回答から抽出したフォーク候補
+// 範囲はメッセージ本文の文字 (rune) 単位のオフセットで、本文と照合済みのもののみ保存する
+type ForkSuggestion struct {
+	UUID             string
+	ChatUUID         string
+	MessageUUID      string
+	SelectedText     string
+	RangeStart       int
+	RangeEnd         int
+	SuggestedTitle   string
+	GeneratedContext string
+	PromptVersion    string
+	CreatedAt        time.Time
+}
+
+// フォークプレビュー・フォークにそのまま使用できるリクエストに変換する処理
+func (s *ForkSuggestion) PreviewRequest() ForkPreviewRequest {
+	return ForkPreviewRequest{
+		TargetMessageUUID: s.MessageUUID,
+		SelectedText:      s.SelectedText,
+		RangeStart:        s.RangeStart,
+		RangeEnd:          s.RangeEnd,
+	}
+}
+
+// フォーク候補の抽出タスク
+type ForkSuggestionTask struct {
+	ChatUUID    string `json:"chat_uuid"`
+	MessageUUID string `json:"message_uuid"`
+}
@@ -7,19 +7,23 @@ type Fork struct {
 	SelectedText string
 	RangeStart   int
 	RangeEnd     int
+	// 範囲を選択せずにメッセージ全体からフォークした
+	WholeMessage bool
 }
 
 type Message struct {
-	UUID              string
-	ChatUUID          string
-	ParentMessageUUID *string // 追加
-	Role              string  // user or assistant
-	Content           string
-	ContextSummary    *string
-	SourceChatUUID    *string
-	PositionX         float64
-	PositionY         float64
-	Forks             []Fork
-	MergeReports      []*Message
-	CreatedAt         time.Time
+	UUID                 string
+	ChatUUID             string
+	ParentMessageUUID    *string // 追加
+	Role                 string  // user or assistant
+	Content              string
+	ContextSummary       *string
+	SummaryPromptVersion *string // 要約生成に使用したプロンプトのバージョン
+	PromptVersion        *string // メッセージ生成に使用したプロンプトのバージョン
+	SourceChatUUID       *string
+	OriginMessageUUID    *string // 別のブランチから取り込んだメッセージの取り込み元
+	OriginChatUUID       *string
+	Forks                []Fork
+	MergeReports         []*Message
+	CreatedAt            time.Time
 }
@@ -6,6 +6,7 @@ type Project struct {
 	UUID      string
 	UserUUID  string
 	Title     string
+	Language  string // LLMの出力言語（空の場合は既定の言語を使用）
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -20,6 +21,30 @@ type ProjectNode struct {
 	ChatUUID string
 	Data     ProjectNodeData
 	Position ProjectNodePosition
+	// ユーザーが子ノードを折りたたんでいるか
+	Collapsed bool
+	// ユーザーが移動した位置で表示しているか (false の場合は自動レイアウトの位置)
+	Pinned bool
+	// 表示状態の楽観的排他制御のためのバージョン (更新するときにそのまま送り返す)
+	Version int
+	// ノードが属するチャットのタイトル
+	Title string
+	// 要約のみを返す指定で本文を省略したか (全文はノード単位で取得する)
+	Truncated bool
+	// 階層数の制限で読み込んでいない子チャットがあるか
+	HasMoreChildren bool
+}
+
+// プロジェクトツリーの取得条件
+type ProjectTreeQuery struct {
+	// ツリーの起点にするチャット (空の場合はルートチャット)
+	ChatUUID string
+	// 起点のチャットから辿るフォークの階層数 (nil の場合は制限しない、0 の場合は起点のチャットのみ)
+	MaxDepth *int
+	// ツリーに含めないチャットのステータス (該当するチャットから分岐したチャットも含めない)
+	ExcludeStatuses []string
+	// true の場合はノードの本文を先頭の一部に省略する
+	SummaryOnly bool
 }
 
 type ProjectNodeData struct {
@@ -0,0 +1,74 @@
+package model
+
+import "time"
+
+// プロジェクトでのロール (owner > editor > viewer の順に権限が強い)
+const (
+	ProjectRoleViewer = "viewer"
+	ProjectRoleEditor = "editor"
+	ProjectRoleOwner  = "owner"
+)
+
+// 招待の状態
+const (
+	ProjectMemberStatusPending  = "pending"
+	ProjectMemberStatusAccepted = "accepted"
+)
+
+var projectRoleRanks = map[string]int{
+	ProjectRoleViewer: 1,
+	ProjectRoleEditor: 2,
+	ProjectRoleOwner:  3,
+}
+
+// 定義されたロールかどうかを判定する処理
+func IsValidProjectRole(role string) bool {
+	_, ok := projectRoleRanks[role]
+	return ok
+}
+
+// role が required 以上の権限を持つかどうかを判定する処理
+func HasProjectRole(role string, required string) bool {
+	rank, ok := projectRoleRanks[role]
+	return ok && rank >= projectRoleRanks[required]
+}
+
+type ProjectMember struct {
+	ProjectUUID string
+	UserUUID    string
+	UserName    string
+	// ユーザーのアバター画像のURL (未設定の場合は空文字)
+	UserAvatarURL string
+	Role          string
+	Status        string
+	// 招待したユーザー (プロジェクトの作成者の場合は空文字)
+	InvitedByUserUUID string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// 他のユーザーから共有されたプロジェクト (招待中のものを含む)
+type SharedProject struct {
+	Project           *Project
+	Role              string
+	Status            string
+	InvitedByUserUUID string
+	InvitedAt         time.Time
+}
+
+// プロジェクト一覧 (自分が作成したものと共有されたものを分けて返す)
+type ProjectList struct {
+	Owned  []*Project
+	Shared []*SharedProject
+}
+
+// ロールを判定する対象のプロジェクト (チャットのみ指定された場合はチャットからプロジェクトを特定する)
+type ProjectAccess struct {
+	ProjectUUID string
+	ChatUUID    string
+}
+
+type InviteProjectMemberParams struct {
+	UserUUID string
+	Role     string
+}
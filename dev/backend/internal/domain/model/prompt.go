@@ -0,0 +1,86 @@
+package model
+
+// プロンプトテンプレート名
+const (
+	// StreamMessage で要約を踏まえて回答させる前置き
+	PromptStreamSummaryContext = "stream_summary_context"
+	// GenerateForkPreview で要約を踏まえさせる前置き
+	PromptForkSummaryContext = "fork_summary_context"
+	// フォークプレビュー生成の指示
+	PromptForkPreview = "fork_preview"
+	// マージプレビュー生成の指示
+	PromptMergePreview = "merge_preview"
+	// SummaryWorker でこれまでの要約を渡す前置き
+	PromptSummaryBase = "summary_base"
+	// SummaryWorker の要約指示
+	PromptSummaryInstruction = "summary_instruction"
+	// 会話履歴に含まれるマージレポートの枠付け
+	PromptMergeReportContext = "merge_report_context"
+	// 2つのブランチの比較の指示
+	PromptBranchComparison = "branch_comparison"
+	// 会話履歴に含まれる別のブランチから取り込んだメッセージの枠付け
+	PromptCherryPickContext = "cherry_pick_context"
+	// 長い回答からフォーク候補を抽出する指示
+	PromptForkSuggestion = "fork_suggestion"
+)
+
+// マージレポートで引用する起点メッセージの最大文字数
+const mergeReportAnchorLength = 200
+
+// マージレポートをプロンプトに埋め込むためのテンプレートデータを作成する処理
+// messages には起点メッセージを探すためにチャットの全メッセージを渡す
+// acknowledge を指定すると、レポートの結論を会話に統合する回答を求める
+func MergeReportPromptData(report *Message, messages []*Message, acknowledge bool) map[string]string {
+	// テンプレートは未定義のキーをエラーにするので、空でも全てのキーを設定する
+	data := map[string]string{
+		"Report":      report.Content,
+		"Anchor":      "",
+		"Acknowledge": "",
+	}
+	if acknowledge {
+		data["Acknowledge"] = "true"
+	}
+	if report.ParentMessageUUID == nil {
+		return data
+	}
+	for _, msg := range messages {
+		if msg.UUID == *report.ParentMessageUUID {
+			anchor := []rune(msg.Content)
+			if len(anchor) > mergeReportAnchorLength {
+				data["Anchor"] = string(anchor[:mergeReportAnchorLength]) + "…"
+			} else {
+				data["Anchor"] = msg.Content
+			}
+			break
+		}
+	}
+	return data
+}
+
+// 出力言語
+const (
+	LanguageJapanese = "ja"
+	LanguageEnglish  = "en"
+)
+
+// 対応している出力言語か判定する処理
+func IsSupportedLanguage(language string) bool {
+	switch language {
+	case LanguageJapanese, LanguageEnglish:
+		return true
+	}
+	return false
+}
+
+// レンダリング済みのプロンプト
+type RenderedPrompt struct {
+	Text string
+	// 使用したテンプレートのバージョン (例: "fork_preview/ja/v1")
+	Version string
+}
+
+// サマリ生成タスクのペイロード
+type SummaryTask struct {
+	ChatUUID string `json:"chat_uuid"`
+	Language string `json:"language"`
+}
@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// ログインセッション（リフレッシュトークン単位で管理する）
+type Session struct {
+	UUID      string
+	UserUUID  string
+	UserAgent string
+	IPAddress string
+	// リフレッシュトークンの有効期限
+	ExpiresAt  time.Time
+	LastUsedAt time.Time
+	CreatedAt  time.Time
+	// 失効日時 (有効なセッションの場合は nil)
+	RevokedAt *time.Time
+}
+
+// 指定時刻にセッションが有効か (失効・期限切れでないか) を判定する処理
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// セッションを開始したクライアントの情報
+type SessionClient struct {
+	UserAgent string
+	IPAddress string
+}
+
+// ログイン・トークン更新時に発行するトークン
+type AuthTokens struct {
+	// 短命のアクセストークン (JWT)
+	AccessToken          string
+	AccessTokenExpiresAt time.Time
+	// アクセストークンの再発行に使用するトークン (使用するたびにローテーションする)
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+	SessionUUID           string
+}
@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// 認証なしでプロジェクトツリーを閲覧できる読み取り専用の共有リンク
+type ShareLink struct {
+	UUID        string
+	ProjectUUID string
+	// 公開する部分木の起点チャット (空文字の場合はプロジェクト全体)
+	RootChatUUID      string
+	Prefix            string
+	CreatedByUserUUID string
+	ExpiresAt         *time.Time
+	RevokedAt         *time.Time
+	CreatedAt         time.Time
+}
+
+type CreateShareLinkParams struct {
+	RootChatUUID string
+	ExpiresAt    *time.Time
+}
+
+// 共有リンクで公開するプロジェクトの概要
+type SharedView struct {
+	ProjectTitle string
+	// 閲覧を開始するチャット
+	RootChatUUID string
+	ExpiresAt    *time.Time
+}
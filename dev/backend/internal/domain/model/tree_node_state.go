@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// プロジェクトツリーのノードごとにユーザーが変更した表示状態
+// ノードIDはツリーのノードのID (回答のメッセージのUUID、回答がない場合はユーザーメッセージのUUID)
+type TreeNodeState struct {
+	NodeID      string
+	ProjectUUID string
+	// ユーザーがドラッグして移動した位置 (nil の場合は自動レイアウトの位置を使う)
+	Position  *ProjectNodePosition
+	Collapsed bool
+	// 楽観的排他制御のためのバージョン (保存するたびに 1 増える。保存していないノードは 0)
+	Version   int
+	UpdatedAt time.Time
+}
+
+// ノードの位置の更新内容
+type TreeNodePositionUpdate struct {
+	NodeID   string
+	Position ProjectNodePosition
+	// true の場合は移動した位置を破棄して自動レイアウトの位置に戻す
+	Reset bool
+	// クライアントがツリーを取得したときのノードのバージョン
+	Version int
+}
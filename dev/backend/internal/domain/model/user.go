@@ -1,6 +1,33 @@
 package model
 
+// ゲストユーザーのプロバイダー名
+const ProviderGuest = "guest"
+
 type User struct {
 	UUID string
 	Name string
+	// 優先言語 (未設定の場合は空文字)
+	Language string
+	// ログインに使用したプロバイダー (guest またはOIDCプロバイダー名)
+	Provider string
+	// OIDCプロバイダーのサブジェクト (ゲストの場合は空文字)
+	Subject   string
+	AvatarURL string
+}
+
+// OIDCプロバイダーから取得したユーザー情報
+type OIDCIdentity struct {
+	Provider  string
+	Subject   string
+	Email     string
+	Name      string
+	AvatarURL string
+}
+
+// OIDCログイン開始時に発行する認可リクエスト
+type OIDCAuthRequest struct {
+	// リダイレクト先の認可エンドポイントのURL
+	AuthURL string
+	// state・nonce・code_verifier を保持する署名付きトークン (コールバックまでクッキーで保持する)
+	FlowToken string
 }
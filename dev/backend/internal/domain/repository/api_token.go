@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"backend/internal/domain/model"
+	"context"
+	"time"
+)
+
+type APITokenRepository interface {
+	// APIトークンを作成する処理 (トークンはハッシュのみ保存する)
+	Create(ctx context.Context, token *model.APIToken, tokenHash string) error
+	// トークンのハッシュに一致するAPIトークンを検索する処理 (見つからない場合は nil を返す)
+	FindByHash(ctx context.Context, tokenHash string) (*model.APIToken, error)
+	// 指定されたユーザーの失効していないAPIトークンを作成日時の新しい順に取得する処理
+	FindActiveByUserUUID(ctx context.Context, userUUID string) ([]*model.APIToken, error)
+	// 指定されたユーザーのAPIトークンを失効させ、失効できたかどうかを返す処理
+	Revoke(ctx context.Context, userUUID string, tokenUUID string, revokedAt time.Time) (bool, error)
+	// APIトークンの最終使用日時を更新する処理
+	UpdateLastUsedAt(ctx context.Context, tokenUUID string, usedAt time.Time) error
+	// 監査ログを記録する処理
+	CreateAuditLog(ctx context.Context, log *model.APITokenAuditLog) error
+	// 指定されたユーザーのAPIトークンの監査ログを新しい順に取得する処理
+	FindAuditLogs(ctx context.Context, userUUID string, tokenUUID string, limit int) ([]*model.APITokenAuditLog, error)
+}
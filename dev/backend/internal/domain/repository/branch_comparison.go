@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"backend/internal/domain/model"
+	"context"
+)
+
+type BranchComparisonRepository interface {
+	// ブランチの比較結果を保存する処理
+	Create(ctx context.Context, comparison *model.BranchComparison) error
+	// チャットのメッセージに紐づく比較結果を新しい順に取得する処理
+	FindByAnchorChatUUID(ctx context.Context, anchorChatUUID string) ([]*model.BranchComparison, error)
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"backend/internal/domain/model"
 	"context"
+	"time"
 )
 
 type ChatRepository interface {
@@ -12,9 +13,25 @@ type ChatRepository interface {
 	FindByID(ctx context.Context, uuid string) (*model.Chat, error)
 	// チャットのステータスを更新する処理
 	UpdateStatus(ctx context.Context, chatUUID string, status string) error
-	// プロジェクト内で最も古いチャットを取得する処理
+	// チャットのステータスが from の場合だけ to に更新し、更新できたかどうかを返す処理
+	TransitionStatus(ctx context.Context, chatUUID string, from string, to string) (bool, error)
 	// プロジェクト内で最も古いチャットを取得する処理
 	FindOldestByProjectUUID(ctx context.Context, projectUUID string) (*model.Chat, error)
+	// プロジェクト内のすべてのチャットを作成順に取得する処理
+	FindAllByProjectUUID(ctx context.Context, projectUUID string) ([]*model.Chat, error)
+	// 指定したチャットから直接フォークされたチャットを取得する処理
+	FindByParentUUID(ctx context.Context, parentChatUUID string) ([]*model.Chat, error)
 	// プロジェクト内のチャット数を取得する処理
 	CountByProjectUUID(ctx context.Context, projectUUID string) (int64, error)
+	// 回答生成のロックを取得し、取得できたかどうかを返す処理 (期限切れのロックは取得し直せる)
+	// owner はロックを取得した生成を識別する値で、延長と解放のときに同じ値を渡す
+	AcquireGenerationLock(ctx context.Context, chatUUID string, owner string, now time.Time, until time.Time) (bool, error)
+	// 回答生成のロックの有効期限を延長し、延長できたかどうかを返す処理 (別の生成に取得し直された場合は false)
+	ExtendGenerationLock(ctx context.Context, chatUUID string, owner string, until time.Time) (bool, error)
+	// 自分が取得した回答生成のロックを解放する処理
+	ReleaseGenerationLock(ctx context.Context, chatUUID string, owner string) error
+	// ブランチの親チャット・起点メッセージ・選択範囲を付け替える処理
+	UpdateParent(ctx context.Context, chatUUID string, parentChatUUID string, sourceMessageUUID string, messageSelectionUUID *string) error
+	// フォークした理由のコンテキストと生成に使用したプロンプトのバージョンを更新する処理
+	UpdateContextSummary(ctx context.Context, chatUUID string, summary string, promptVersion string) error
 }
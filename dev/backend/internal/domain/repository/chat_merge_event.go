@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"backend/internal/domain/model"
+	"context"
+)
+
+type ChatMergeEventRepository interface {
+	// マージ履歴を作成する処理
+	Create(ctx context.Context, event *model.ChatMergeEvent) error
+	// 子チャットのマージ履歴を古い順に取得する処理
+	FindByChatUUID(ctx context.Context, chatUUID string) ([]*model.ChatMergeEvent, error)
+	// 同じマージレポートでマージされたチャットの履歴を取得する処理
+	FindByReportMessageUUID(ctx context.Context, reportMessageUUID string) ([]*model.ChatMergeEvent, error)
+	// 子チャットの指定した操作の最新の履歴を取得する処理
+	FindLatestByChatUUID(ctx context.Context, chatUUID string, action string) (*model.ChatMergeEvent, error)
+}
@@ -7,5 +7,9 @@ import (
 
 type EdgeRepository interface {
 	FindEdgesByChatID(ctx context.Context, chatUUID string) ([]*model.Edge, error)
+	// プロジェクト内のすべてのチャットのエッジを取得する処理
+	FindEdgesByProjectUUID(ctx context.Context, projectUUID string) ([]*model.Edge, error)
 	Create(ctx context.Context, edge *model.Edge) error
+	// エッジの接続先のメッセージを付け替える処理
+	UpdateTarget(ctx context.Context, edgeUUID string, targetMessageUUID string) error
 }
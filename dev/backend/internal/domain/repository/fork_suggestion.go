@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"backend/internal/domain/model"
+	"context"
+)
+
+type ForkSuggestionRepository interface {
+	// メッセージのフォーク候補を置き換える処理 (以前に抽出した候補は削除する)
+	ReplaceByMessageUUID(ctx context.Context, messageUUID string, suggestions []*model.ForkSuggestion) error
+	// メッセージのフォーク候補を本文での位置順に取得する処理
+	FindByMessageUUID(ctx context.Context, messageUUID string) ([]*model.ForkSuggestion, error)
+}
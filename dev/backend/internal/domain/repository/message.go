@@ -10,10 +10,18 @@ type MessageRepository interface {
 	Create(ctx context.Context, message *model.Message) error
 	// チャットIDに紐づくメッセージを取得する処理
 	FindMessagesByChatID(ctx context.Context, chatUUID string) ([]*model.Message, error)
+	// プロジェクト内のすべてのチャットのメッセージをフォークと合わせて作成順に取得する処理
+	FindMessagesByProjectUUID(ctx context.Context, projectUUID string) ([]*model.Message, error)
+	// プロジェクト内のすべてのメッセージを、本文を除いたツリーの構造 (UUID・チャット・ロール・フォーク) だけ作成順に取得する処理
+	FindMessageStructureByProjectUUID(ctx context.Context, projectUUID string) ([]*model.Message, error)
 	FindByID(ctx context.Context, uuid string) (*model.Message, error)
-	// メッセージのコンテキストサマリを更新する処理
-	UpdateContextSummary(ctx context.Context, messageUUID string, summary string) error
+	// メッセージのコンテキストサマリと生成に使用したプロンプトのバージョンを更新する処理
+	UpdateContextSummary(ctx context.Context, messageUUID string, summary string, promptVersion string) error
 	// 指定されたチャットIDの中で、コンテキストサマリを持つ最新のメッセージを取得する処理
 	FindLatestMessageWithSummary(ctx context.Context, chatUUID string) (*model.Message, error)
 	FindLatestMessageByRole(ctx context.Context, chatUUID string, role string) (*model.Message, error)
+	// メッセージを論理削除する処理 (削除したメッセージは取得処理の対象外になる)
+	Delete(ctx context.Context, uuid string) error
+	// メッセージの本文を更新する処理
+	UpdateContent(ctx context.Context, messageUUID string, content string) error
 }
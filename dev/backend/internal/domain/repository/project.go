@@ -10,4 +10,10 @@ type ProjectRepository interface {
 	FindAllByUserUUID(ctx context.Context, userUUID string) ([]*model.Project, error)
 	// プロジェクトを作成する処理
 	Create(ctx context.Context, project *model.Project) error
+	// UUIDでプロジェクトを取得する処理
+	FindByUUID(ctx context.Context, projectUUID string) (*model.Project, error)
+	// プロジェクトの出力言語を更新する処理（空文字の場合は未設定に戻す）
+	UpdateLanguage(ctx context.Context, projectUUID string, language string) error
+	// 指定したユーザーの全プロジェクトの所有者を別のユーザーに付け替える処理
+	ReassignUser(ctx context.Context, fromUserUUID string, toUserUUID string) error
 }
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"backend/internal/domain/model"
+	"context"
+)
+
+type ProjectMemberRepository interface {
+	// メンバー（招待）を作成する処理
+	Create(ctx context.Context, member *model.ProjectMember) error
+	// プロジェクトとユーザーでメンバーを取得する処理 (見つからない場合は nil を返す)
+	Find(ctx context.Context, projectUUID string, userUUID string) (*model.ProjectMember, error)
+	// プロジェクトのメンバー一覧を招待日時の古い順に取得する処理 (招待中のものを含む)
+	FindByProjectUUID(ctx context.Context, projectUUID string) ([]*model.ProjectMember, error)
+	// ユーザーのメンバー（招待）をすべての状態で取得する処理
+	FindByUserUUID(ctx context.Context, userUUID string) ([]*model.ProjectMember, error)
+	// ユーザーに共有されたプロジェクトを指定した状態で取得する処理
+	FindSharedByUserUUID(ctx context.Context, userUUID string, status string) ([]*model.SharedProject, error)
+	// メンバーのロールを更新する処理
+	UpdateRole(ctx context.Context, projectUUID string, userUUID string, role string) error
+	// 招待中のメンバーを承認済みにし、更新できたかどうかを返す処理
+	Accept(ctx context.Context, projectUUID string, userUUID string) (bool, error)
+	// メンバー（招待）を削除し、削除できたかどうかを返す処理
+	Delete(ctx context.Context, projectUUID string, userUUID string) (bool, error)
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"backend/internal/domain/model"
+	"context"
+	"time"
+)
+
+type SessionRepository interface {
+	// セッションを作成する処理 (リフレッシュトークンはハッシュのみ保存する)
+	Create(ctx context.Context, session *model.Session, refreshTokenHash string) error
+	// UUIDでセッションを検索する処理 (見つからない場合は nil を返す)
+	FindByUUID(ctx context.Context, sessionUUID string) (*model.Session, error)
+	// 現在のリフレッシュトークンのハッシュに一致するセッションを検索する処理 (見つからない場合は nil を返す)
+	FindByRefreshTokenHash(ctx context.Context, tokenHash string) (*model.Session, error)
+	// ローテーション前のリフレッシュトークンのハッシュに一致するセッションを検索する処理 (見つからない場合は nil を返す)
+	FindByPreviousTokenHash(ctx context.Context, tokenHash string) (*model.Session, error)
+	// 指定されたユーザーの有効なセッションを最終使用日時の新しい順に取得する処理
+	FindActiveByUserUUID(ctx context.Context, userUUID string, now time.Time) ([]*model.Session, error)
+	// リフレッシュトークンをローテーションする処理
+	// 現在のハッシュが oldHash と一致する有効なセッションのみ更新し、更新できたかどうかを返す
+	Rotate(ctx context.Context, sessionUUID string, oldHash string, newHash string, expiresAt time.Time, usedAt time.Time) (bool, error)
+	// 指定されたユーザーのセッションを失効させ、失効できたかどうかを返す処理
+	Revoke(ctx context.Context, userUUID string, sessionUUID string, revokedAt time.Time) (bool, error)
+}
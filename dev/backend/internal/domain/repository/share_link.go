@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"backend/internal/domain/model"
+	"context"
+	"time"
+)
+
+type ShareLinkRepository interface {
+	// 共有リンクを作成する処理 (トークンはハッシュのみ保存する)
+	Create(ctx context.Context, link *model.ShareLink, tokenHash string) error
+	// トークンのハッシュに一致する共有リンクを検索する処理 (見つからない場合は nil を返す)
+	FindByHash(ctx context.Context, tokenHash string) (*model.ShareLink, error)
+	// プロジェクトの失効していない共有リンクを作成日時の新しい順に取得する処理
+	FindActiveByProjectUUID(ctx context.Context, projectUUID string) ([]*model.ShareLink, error)
+	// プロジェクトの共有リンクを失効させ、失効できたかどうかを返す処理
+	Revoke(ctx context.Context, projectUUID string, linkUUID string, revokedAt time.Time) (bool, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"backend/internal/domain/model"
+	"context"
+)
+
+type TreeNodeStateRepository interface {
+	// プロジェクトのノードの表示状態を取得する処理
+	FindByProjectUUID(ctx context.Context, projectUUID string) ([]*model.TreeNodeState, error)
+	// プロジェクトの1つのノードの表示状態を取得する処理 (保存されていない場合は nil を返す)
+	FindByNodeID(ctx context.Context, projectUUID string, nodeID string) (*model.TreeNodeState, error)
+	// 保存されているバージョンが expectedVersion と一致する場合だけノードの表示状態を保存する処理
+	// expectedVersion が 0 の場合はまだ保存されていないときだけ作成する。保存できなかった場合は false を返す
+	SaveIfVersion(ctx context.Context, state *model.TreeNodeState, expectedVersion int) (bool, error)
+}
@@ -10,4 +10,14 @@ type UserRepository interface {
 	Create(ctx context.Context, user *model.User) error
 	// 指定されたUUIDのユーザーを検索する処理
 	FindByUUID(ctx context.Context, uuid string) (*model.User, error)
+	// 指定されたプロバイダーとサブジェクトのユーザーを検索する処理 (見つからない場合は nil を返す)
+	FindByProviderSubject(ctx context.Context, provider string, subject string) (*model.User, error)
+	// ユーザーの表示名とアイコン画像を更新する処理
+	UpdateProfile(ctx context.Context, uuid string, name string, avatarURL string) error
+	// ゲストユーザーにOIDCプロバイダーのアカウントを紐付ける処理
+	LinkIdentity(ctx context.Context, uuid string, provider string, subject string, name string, avatarURL string) error
+	// ユーザーを削除する処理
+	Delete(ctx context.Context, uuid string) error
+	// ユーザーの優先言語を更新する処理
+	UpdateLanguage(ctx context.Context, uuid string, language string) error
 }
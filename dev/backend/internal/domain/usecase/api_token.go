@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"backend/internal/domain/model"
+	"context"
+)
+
+type APITokenUsecase interface {
+	// APIトークンを発行する処理 (トークン本体は発行時にのみ返す)
+	CreateToken(ctx context.Context, userUUID string, params model.CreateAPITokenParams) (*model.APIToken, string, error)
+	// ユーザーの失効していないAPIトークン一覧を取得する処理
+	ListTokens(ctx context.Context, userUUID string) ([]*model.APIToken, error)
+	// ユーザーのAPIトークンを失効させる処理
+	RevokeToken(ctx context.Context, userUUID string, tokenUUID string) error
+	// ユーザーのAPIトークンの監査ログを取得する処理
+	ListAuditLogs(ctx context.Context, userUUID string, tokenUUID string) ([]*model.APITokenAuditLog, error)
+	// Authorization ヘッダーのトークンを検証し、最終使用日時を更新する処理
+	Authenticate(ctx context.Context, rawToken string) (*model.APIToken, error)
+	// APIトークンのスコープでリクエストが許可されているか検証する処理
+	Authorize(ctx context.Context, token *model.APIToken, access model.APITokenAccess) error
+	// APIトークンの使用を監査ログに記録する処理
+	RecordUsage(ctx context.Context, log *model.APITokenAuditLog) error
+}
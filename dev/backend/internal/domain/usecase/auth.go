@@ -7,7 +7,23 @@ import (
 
 type AuthUsecase interface {
 	// ゲストユーザーのサインアップ処理
-	GuestSignup(ctx context.Context) (*model.User, string, error)
+	GuestSignup(ctx context.Context, client model.SessionClient) (*model.User, *model.AuthTokens, error)
 	// ゲストユーザーのログイン処理
-	GuestLogin(ctx context.Context, userUUID string) (string, error)
+	GuestLogin(ctx context.Context, userUUID string, client model.SessionClient) (*model.User, *model.AuthTokens, error)
+	// OIDCログインを開始し、認可エンドポイントのURLを発行する処理
+	StartOIDCLogin(ctx context.Context, providerName string) (*model.OIDCAuthRequest, error)
+	// ログイン中のゲストユーザーにOIDCアカウントを紐付けるフローを開始する処理
+	StartOIDCLink(ctx context.Context, providerName string, userUUID string) (*model.OIDCAuthRequest, error)
+	// OIDCプロバイダーからのコールバックを検証し、ユーザーを作成または紐付けてログインする処理
+	OIDCLogin(ctx context.Context, providerName string, code string, state string, flowToken string, client model.SessionClient) (*model.User, *model.AuthTokens, error)
+	// リフレッシュトークンを使用してアクセストークンを再発行する処理
+	RefreshSession(ctx context.Context, refreshToken string, client model.SessionClient) (*model.AuthTokens, error)
+	// リフレッシュトークンのセッションを失効させてログアウトする処理
+	Logout(ctx context.Context, refreshToken string) error
+	// ユーザーの有効なセッション一覧を取得する処理
+	ListSessions(ctx context.Context, userUUID string) ([]*model.Session, error)
+	// ユーザーのセッションを指定して失効させる処理
+	RevokeSession(ctx context.Context, userUUID string, sessionUUID string) error
+	// アクセストークンのセッションが失効していないことを確認する処理
+	ValidateSession(ctx context.Context, userUUID string, sessionUUID string) error
 }
@@ -18,16 +18,34 @@ type ChatUsecase interface {
 	SendMessage(ctx context.Context, chatUUID string, content string) (*model.Message, error)
 	// メッセージをストリーミング送信する
 	StreamMessage(ctx context.Context, chatUUID string, outputChan chan<- string) error
+	// 別のブランチのメッセージをチャットに取り込む
+	CherryPickMessages(ctx context.Context, chatUUID string, params model.CherryPickParams) (*model.CherryPickResult, error)
 	// フォークプレビューを生成する
 	GenerateForkPreview(ctx context.Context, chatUUID string, req model.ForkPreviewRequest) (*model.ForkPreviewResponse, error)
+	// メッセージからフォーク候補を抽出して保存する
+	SuggestForks(ctx context.Context, chatUUID string, messageUUID string) ([]*model.ForkSuggestion, error)
+	// メッセージのフォーク候補を取得する
+	GetForkSuggestions(ctx context.Context, chatUUID string, messageUUID string) ([]*model.ForkSuggestion, error)
 	// チャットをフォークする
 	ForkChat(ctx context.Context, params model.ForkChatParams) (string, error)
-	// マージプレビューを生成する
-	GetMergePreview(ctx context.Context, chatUUID string) (*model.MergePreview, error)
+	// 1つのメッセージから複数のブランチをまとめてフォークする
+	BatchForkChat(ctx context.Context, params model.BatchForkChatParams) ([]string, error)
+	// ブランチを別のメッセージに付け替える
+	RebaseChat(ctx context.Context, chatUUID string, params model.RebaseChatParams) (*model.RebaseChatResult, error)
+	// マージプレビューを生成する (targetChatUUID が空の場合は直接の親チャットをマージ先とする)
+	GetMergePreview(ctx context.Context, chatUUID string, targetChatUUID string) (*model.MergePreview, error)
 	// チャットをマージする
 	MergeChat(ctx context.Context, chatUUID string, params model.MergeChatParams) (*model.MergeChatResult, error)
-	// チャットをクローズする
-	CloseChat(ctx context.Context, chatUUID string) (string, error)
+	// チャットのマージを取り消す
+	UnmergeChat(ctx context.Context, chatUUID string, params model.UnmergeChatParams) (*model.UnmergeChatResult, error)
+	// 2つのブランチを比較する (SaveNote を指定すると共通の祖先メッセージのメモとして保存する)
+	CompareBranches(ctx context.Context, chatUUID string, params model.CompareBranchesParams) (*model.BranchComparison, error)
+	// チャットのメッセージに紐づく比較結果のメモを取得する
+	GetBranchComparisons(ctx context.Context, chatUUID string) ([]*model.BranchComparison, error)
+	// チャットのマージ履歴を取得する
+	GetMergeHistory(ctx context.Context, chatUUID string) ([]*model.ChatMergeEvent, error)
+	// チャットをクローズする (cascade を指定すると子孫のブランチもクローズし、クローズしたチャットを返す)
+	CloseChat(ctx context.Context, chatUUID string, cascade bool) ([]string, error)
 	// チャットをオープンする
 	OpenChat(ctx context.Context, chatUUID string) (string, error)
 }
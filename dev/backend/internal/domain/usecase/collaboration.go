@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"backend/internal/domain/model"
+	"context"
+)
+
+// ChatEventHub はチャットの閲覧者の管理とイベントの配信を行うインターフェース
+type ChatEventHub interface {
+	// チャットのイベントを購読し、閲覧者として登録する (戻り値の関数で購読を解除する)
+	Subscribe(viewer model.ChatPresence) (<-chan model.ChatEvent, func())
+	// チャットの閲覧者にイベントを配信する
+	Publish(event model.ChatEvent)
+	// 閲覧者の入力中の状態を更新し、閲覧中だったかどうかを返す
+	SetTyping(chatUUID string, userUUID string, typing bool) bool
+	// チャットの閲覧者一覧を返す
+	ChatPresence(chatUUID string) []*model.ChatPresence
+	// プロジェクト内のチャットの閲覧者一覧を返す
+	ProjectPresence(projectUUID string) []*model.ChatPresence
+}
+
+type CollaborationUsecase interface {
+	// チャットのイベントを購読する処理 (戻り値の関数で購読を解除する)
+	Watch(ctx context.Context, chatUUID string, userUUID string) (<-chan model.ChatEvent, func(), error)
+	// 入力中の状態を更新する処理
+	SetTyping(ctx context.Context, chatUUID string, userUUID string, typing bool) error
+	// チャットの閲覧者一覧を取得する処理
+	GetChatPresence(ctx context.Context, chatUUID string) ([]*model.ChatPresence, error)
+	// プロジェクト内で誰がどのチャットを閲覧しているかを取得する処理
+	GetProjectPresence(ctx context.Context, projectUUID string) ([]*model.ChatPresence, error)
+}
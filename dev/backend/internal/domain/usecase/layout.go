@@ -0,0 +1,12 @@
+package usecase
+
+import "backend/internal/domain/model"
+
+// TreeLayouter はプロジェクトツリーのノードの配置を計算するインターフェース
+// ノードの位置は保存せず、ツリーを読み込むたびに全ノードの位置を構造から計算し直す
+// (フォーク作成時に空いている位置を確保して保存していた方式は、この計算で置き換えた)
+type TreeLayouter interface {
+	// ノードとエッジの親子関係からすべてのノードの位置を計算し、ツリーのノードの Position を更新する
+	// 前回の計算から変わっていない部分木は計算結果を再利用するが、結果は最初から計算した場合と同じになる
+	Layout(tree *model.ProjectTree)
+}
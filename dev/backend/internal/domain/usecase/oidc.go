@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"backend/internal/domain/model"
+	"context"
+)
+
+type OIDCProvider interface {
+	// PKCE付きの認可エンドポイントのURLを生成する処理
+	AuthCodeURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error)
+	// 認可コードをトークンに交換し、IDトークンを検証してユーザー情報を返す処理
+	Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*model.OIDCIdentity, error)
+}
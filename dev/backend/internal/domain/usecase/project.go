@@ -6,12 +6,21 @@ import (
 )
 
 type ProjectUsecase interface {
-	// プロジェクト取得処理
-	GetProjects(ctx context.Context, userUUID string) ([]*model.Project, error)
+	// プロジェクト取得処理 (作成したものと共有されたものを分けて返す)
+	GetProjects(ctx context.Context, userUUID string) (*model.ProjectList, error)
 	// プロジェクト作成処理
 	CreateProject(ctx context.Context, userUUID, initialMessage string) (*model.Project, *model.Chat, *model.Message, error)
 	// プロジェクトの親チャット取得処理
 	GetParentChat(ctx context.Context, projectUUID string) (*model.Chat, error)
-	// プロジェクトツリー取得処理
-	GetProjectTree(ctx context.Context, projectUUID string) (*model.ProjectTree, error)
+	// プロジェクトツリー取得処理 (取得条件で起点・階層数・ステータス・本文の省略を指定できる)
+	GetProjectTree(ctx context.Context, projectUUID string, query model.ProjectTreeQuery) (*model.ProjectTree, error)
+	// ツリーのノードを1件、本文を省略せずに取得する処理
+	GetProjectTreeNode(ctx context.Context, projectUUID string, nodeID string) (*model.ProjectNode, error)
+	// ノードの位置の一括更新処理 (Reset を指定したノードは自動レイアウトの位置に戻す)
+	// 他の操作で更新されたノードがある場合は ErrTreeNodeConflict を返し、どのノードも更新しない
+	UpdateTreeNodePositions(ctx context.Context, projectUUID string, updates []model.TreeNodePositionUpdate) ([]*model.TreeNodeState, error)
+	// ノードの折りたたみ状態の更新処理
+	SetTreeNodeCollapsed(ctx context.Context, projectUUID string, nodeID string, collapsed bool, version int) (*model.TreeNodeState, error)
+	// プロジェクトの出力言語更新処理（空文字の場合は未設定に戻す）
+	UpdateLanguage(ctx context.Context, projectUUID string, language string) error
 }
@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"backend/internal/domain/model"
+	"context"
+)
+
+type ProjectMemberUsecase interface {
+	// プロジェクトでのユーザーのロールを取得する処理 (アクセスできない場合は ErrProjectNotFound を返す)
+	ResolveRole(ctx context.Context, userUUID string, access model.ProjectAccess) (string, error)
+	// プロジェクトのメンバー一覧を取得する処理 (作成者を先頭に含む)
+	ListMembers(ctx context.Context, projectUUID string) ([]*model.ProjectMember, error)
+	// ユーザーをプロジェクトに招待する処理
+	InviteMember(ctx context.Context, inviterUUID string, projectUUID string, params model.InviteProjectMemberParams) (*model.ProjectMember, error)
+	// メンバーのロールを変更する処理
+	UpdateMemberRole(ctx context.Context, projectUUID string, memberUUID string, role string) (*model.ProjectMember, error)
+	// メンバーを削除する処理 (オーナー以外は自分自身のみ削除できる)
+	RemoveMember(ctx context.Context, userUUID string, projectUUID string, memberUUID string) error
+	// ログイン中のユーザー宛ての招待一覧を取得する処理
+	ListInvitations(ctx context.Context, userUUID string) ([]*model.SharedProject, error)
+	// 招待を承認する処理
+	AcceptInvitation(ctx context.Context, userUUID string, projectUUID string) error
+	// 招待を辞退する処理
+	DeclineInvitation(ctx context.Context, userUUID string, projectUUID string) error
+}
@@ -0,0 +1,12 @@
+package usecase
+
+import "backend/internal/domain/model"
+
+// PromptRenderer はプロンプトテンプレートのレンダリングを行うインターフェース
+type PromptRenderer interface {
+	// 指定された言語のテンプレートをレンダリングする
+	// 言語のテンプレートが存在しない場合は既定の言語にフォールバックする
+	Render(name string, language string, data any) (*model.RenderedPrompt, error)
+	// 既定の出力言語を返す
+	DefaultLanguage() string
+}
@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"backend/internal/domain/model"
+	"context"
+)
+
+type ShareLinkUsecase interface {
+	// 共有リンクを発行する処理 (平文のトークンは発行時のみ返す)
+	CreateLink(ctx context.Context, userUUID string, projectUUID string, params model.CreateShareLinkParams) (*model.ShareLink, string, error)
+	// プロジェクトの有効な共有リンク一覧を取得する処理
+	ListLinks(ctx context.Context, projectUUID string) ([]*model.ShareLink, error)
+	// 共有リンクを失効させる処理
+	RevokeLink(ctx context.Context, projectUUID string, linkUUID string) error
+	// 共有リンクで公開しているプロジェクトの概要を取得する処理
+	GetSharedView(ctx context.Context, rawToken string) (*model.SharedView, error)
+	// 共有リンクで公開している範囲のツリーを取得する処理
+	GetSharedTree(ctx context.Context, rawToken string) (*model.ProjectTree, error)
+	// 共有リンクで公開している範囲のチャットのメッセージを取得する処理
+	GetSharedMessages(ctx context.Context, rawToken string, chatUUID string) ([]*model.Message, error)
+}
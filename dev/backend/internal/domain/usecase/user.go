@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"backend/internal/domain/model"
+	"context"
+)
+
+type UserUsecase interface {
+	// ログイン中のユーザーのプロフィールを取得する処理
+	GetProfile(ctx context.Context, userUUID string) (*model.User, error)
+	// ユーザーの優先言語を更新する処理
+	UpdateLanguage(ctx context.Context, userUUID string, language string) (*model.User, error)
+}
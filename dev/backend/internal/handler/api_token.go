@@ -0,0 +1,196 @@
+package handler
+
+import (
+	domainModel "backend/internal/domain/model"
+	"backend/internal/domain/usecase"
+	"backend/internal/handler/model"
+	"backend/internal/i18n"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type apiTokenHandler struct {
+	apiTokenUsecase usecase.APITokenUsecase
+}
+
+// apiTokenHandlerの新しいインスタンスを作成する処理
+func NewAPITokenHandler(apiTokenUsecase usecase.APITokenUsecase) *apiTokenHandler {
+	return &apiTokenHandler{
+		apiTokenUsecase: apiTokenUsecase,
+	}
+}
+
+// ドメインモデルのAPIトークンをレスポンスに変換する処理
+func toAPITokenResponse(token *domainModel.APIToken) model.APITokenResponse {
+	return model.APITokenResponse{
+		UUID:        token.UUID,
+		Name:        token.Name,
+		Prefix:      token.Prefix,
+		Scope:       token.Scope,
+		ProjectUUID: token.ProjectUUID,
+		ExpiresAt:   token.ExpiresAt,
+		LastUsedAt:  token.LastUsedAt,
+		CreatedAt:   token.CreatedAt,
+	}
+}
+
+// APIトークンを発行する処理
+func (h *apiTokenHandler) CreateToken(c echo.Context) error {
+	ctx := c.Request().Context()
+	userUUID, ok := c.Get("user_uuid").(string)
+	if !ok {
+		slog.WarnContext(ctx, "ユーザーUUIDの取得に失敗")
+		return c.JSON(http.StatusUnauthorized, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyUserUUIDNotFound),
+		})
+	}
+
+	var req model.CreateAPITokenRequest
+	if err := c.Bind(&req); err != nil {
+		slog.WarnContext(ctx, "リクエストボディのバインドに失敗", "error", err)
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyInvalidRequestBody),
+		})
+	}
+
+	token, rawToken, err := h.apiTokenUsecase.CreateToken(ctx, userUUID, domainModel.CreateAPITokenParams{
+		Name:        req.Name,
+		Scope:       req.Scope,
+		ProjectUUID: req.ProjectUUID,
+		ExpiresAt:   req.ExpiresAt,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domainModel.ErrInvalidAPITokenParams):
+			slog.WarnContext(ctx, "APIトークンの発行パラメータが不正です", "error", err)
+			return c.JSON(http.StatusBadRequest, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyInvalidAPITokenParams),
+			})
+		case errors.Is(err, domainModel.ErrProjectNotFound):
+			slog.WarnContext(ctx, "プロジェクトが見つかりません", "project_uuid", req.ProjectUUID)
+			return c.JSON(http.StatusNotFound, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyProjectNotFound),
+			})
+		}
+		slog.ErrorContext(ctx, "APIトークンの発行に失敗", "error", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
+	}
+
+	slog.InfoContext(ctx, "APIトークンの発行に成功", "user_uuid", userUUID, "api_token_uuid", token.UUID)
+	return c.JSON(http.StatusCreated, model.CreateAPITokenResponse{
+		APITokenResponse: toAPITokenResponse(token),
+		Token:            rawToken,
+	})
+}
+
+// APIトークン一覧を取得する処理
+func (h *apiTokenHandler) ListTokens(c echo.Context) error {
+	ctx := c.Request().Context()
+	userUUID, ok := c.Get("user_uuid").(string)
+	if !ok {
+		slog.WarnContext(ctx, "ユーザーUUIDの取得に失敗")
+		return c.JSON(http.StatusUnauthorized, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyUserUUIDNotFound),
+		})
+	}
+
+	tokens, err := h.apiTokenUsecase.ListTokens(ctx, userUUID)
+	if err != nil {
+		slog.ErrorContext(ctx, "APIトークン一覧の取得に失敗", "error", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
+	}
+
+	res := make([]model.APITokenResponse, len(tokens))
+	for i, token := range tokens {
+		res[i] = toAPITokenResponse(token)
+	}
+
+	slog.InfoContext(ctx, "APIトークン一覧の取得に成功", "user_uuid", userUUID, "count", len(res))
+	return c.JSON(http.StatusOK, res)
+}
+
+// APIトークンを失効させる処理
+func (h *apiTokenHandler) RevokeToken(c echo.Context) error {
+	ctx := c.Request().Context()
+	userUUID, ok := c.Get("user_uuid").(string)
+	if !ok {
+		slog.WarnContext(ctx, "ユーザーUUIDの取得に失敗")
+		return c.JSON(http.StatusUnauthorized, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyUserUUIDNotFound),
+		})
+	}
+	tokenUUID := c.Param("token_uuid")
+
+	if err := h.apiTokenUsecase.RevokeToken(ctx, userUUID, tokenUUID); err != nil {
+		if errors.Is(err, domainModel.ErrAPITokenNotFound) {
+			slog.WarnContext(ctx, "APIトークンが見つかりません", "api_token_uuid", tokenUUID)
+			return c.JSON(http.StatusNotFound, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyAPITokenNotFound),
+			})
+		}
+		slog.ErrorContext(ctx, "APIトークンの失効に失敗", "error", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
+	}
+
+	slog.InfoContext(ctx, "APIトークンの失効に成功", "user_uuid", userUUID, "api_token_uuid", tokenUUID)
+	return c.JSON(http.StatusOK, model.Response{
+		Status: "ok",
+	})
+}
+
+// APIトークンの監査ログを取得する処理
+func (h *apiTokenHandler) ListAuditLogs(c echo.Context) error {
+	ctx := c.Request().Context()
+	userUUID, ok := c.Get("user_uuid").(string)
+	if !ok {
+		slog.WarnContext(ctx, "ユーザーUUIDの取得に失敗")
+		return c.JSON(http.StatusUnauthorized, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyUserUUIDNotFound),
+		})
+	}
+	tokenUUID := c.Param("token_uuid")
+
+	logs, err := h.apiTokenUsecase.ListAuditLogs(ctx, userUUID, tokenUUID)
+	if err != nil {
+		slog.ErrorContext(ctx, "APIトークンの監査ログの取得に失敗", "error", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
+	}
+
+	res := make([]model.APITokenAuditLogResponse, len(logs))
+	for i, log := range logs {
+		res[i] = model.APITokenAuditLogResponse{
+			Event:      log.Event,
+			Method:     log.Method,
+			Path:       log.Path,
+			StatusCode: log.StatusCode,
+			IPAddress:  log.IPAddress,
+			CreatedAt:  log.CreatedAt,
+		}
+	}
+
+	slog.InfoContext(ctx, "APIトークンの監査ログの取得に成功", "user_uuid", userUUID, "api_token_uuid", tokenUUID, "count", len(res))
+	return c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"backend/internal/domain/model"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// モックの定義
+type mockAPITokenUsecase struct {
+	mock.Mock
+}
+
+func (m *mockAPITokenUsecase) CreateToken(ctx context.Context, userUUID string, params model.CreateAPITokenParams) (*model.APIToken, string, error) {
+	args := m.Called(ctx, userUUID, params)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*model.APIToken), args.String(1), args.Error(2)
+}
+
+func (m *mockAPITokenUsecase) ListTokens(ctx context.Context, userUUID string) ([]*model.APIToken, error) {
+	args := m.Called(ctx, userUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.APIToken), args.Error(1)
+}
+
+func (m *mockAPITokenUsecase) RevokeToken(ctx context.Context, userUUID string, tokenUUID string) error {
+	args := m.Called(ctx, userUUID, tokenUUID)
+	return args.Error(0)
+}
+
+func (m *mockAPITokenUsecase) ListAuditLogs(ctx context.Context, userUUID string, tokenUUID string) ([]*model.APITokenAuditLog, error) {
+	args := m.Called(ctx, userUUID, tokenUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.APITokenAuditLog), args.Error(1)
+}
+
+func (m *mockAPITokenUsecase) Authenticate(ctx context.Context, rawToken string) (*model.APIToken, error) {
+	args := m.Called(ctx, rawToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.APIToken), args.Error(1)
+}
+
+func (m *mockAPITokenUsecase) Authorize(ctx context.Context, token *model.APIToken, access model.APITokenAccess) error {
+	args := m.Called(ctx, token, access)
+	return args.Error(0)
+}
+
+func (m *mockAPITokenUsecase) RecordUsage(ctx context.Context, log *model.APITokenAuditLog) error {
+	args := m.Called(ctx, log)
+	return args.Error(0)
+}
+
+func TestAPITokenHandler_CreateToken(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(m *mockAPITokenUsecase)
+		wantStatus     int
+		wantBodySubstr string
+	}{
+		{
+			name: "正常系: APIトークンが発行され平文のトークンが返されること",
+			body: `{"name":"ci","scope":"write","project_uuid":"project-uuid"}`,
+			setupMock: func(m *mockAPITokenUsecase) {
+				m.On("CreateToken", mock.Anything, "user-uuid", model.CreateAPITokenParams{Name: "ci", Scope: "write", ProjectUUID: "project-uuid"}).
+					Return(&model.APIToken{UUID: "token-uuid", Name: "ci", Prefix: "cbt_abcdefgh", Scope: "write", ProjectUUID: "project-uuid"}, "cbt_secret", nil)
+			},
+			wantStatus:     http.StatusCreated,
+			wantBodySubstr: `"token":"cbt_secret"`,
+		},
+		{
+			name: "異常系: パラメータが不正な場合400エラー",
+			body: `{"name":"","scope":"admin"}`,
+			setupMock: func(m *mockAPITokenUsecase) {
+				m.On("CreateToken", mock.Anything, "user-uuid", mock.Anything).Return(nil, "", fmt.Errorf("%w: scope", model.ErrInvalidAPITokenParams))
+			},
+			wantStatus:     http.StatusBadRequest,
+			wantBodySubstr: "APIトークンの名前・スコープ・有効期限が正しくありません",
+		},
+		{
+			name: "異常系: プロジェクトが見つからない場合404エラー",
+			body: `{"name":"ci","project_uuid":"other-project-uuid"}`,
+			setupMock: func(m *mockAPITokenUsecase) {
+				m.On("CreateToken", mock.Anything, "user-uuid", mock.Anything).Return(nil, "", fmt.Errorf("%w: other-project-uuid", model.ErrProjectNotFound))
+			},
+			wantStatus:     http.StatusNotFound,
+			wantBodySubstr: "プロジェクトが見つかりません",
+		},
+		{
+			name: "異常系: リクエストボディが不正な場合400エラー",
+			body: `invalid-json`,
+			setupMock: func(m *mockAPITokenUsecase) {
+				// 呼び出されない
+			},
+			wantStatus:     http.StatusBadRequest,
+			wantBodySubstr: "リクエストボディの形式が正しくありません",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/users/me/tokens", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_uuid", "user-uuid")
+
+			mockUsecase := new(mockAPITokenUsecase)
+			tt.setupMock(mockUsecase)
+
+			h := NewAPITokenHandler(mockUsecase)
+			_ = h.CreateToken(c)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBodySubstr)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAPITokenHandler_RevokeToken(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(m *mockAPITokenUsecase)
+		wantStatus     int
+		wantBodySubstr string
+	}{
+		{
+			name: "正常系: APIトークンが失効できること",
+			setupMock: func(m *mockAPITokenUsecase) {
+				m.On("RevokeToken", mock.Anything, "user-uuid", "token-uuid").Return(nil)
+			},
+			wantStatus:     http.StatusOK,
+			wantBodySubstr: `"status":"ok"`,
+		},
+		{
+			name: "異常系: APIトークンが見つからない場合404エラー",
+			setupMock: func(m *mockAPITokenUsecase) {
+				m.On("RevokeToken", mock.Anything, "user-uuid", "token-uuid").Return(fmt.Errorf("%w: token-uuid", model.ErrAPITokenNotFound))
+			},
+			wantStatus:     http.StatusNotFound,
+			wantBodySubstr: "APIトークンが見つかりません",
+		},
+		{
+			name: "異常系: Usecaseでエラーが発生した場合500エラー",
+			setupMock: func(m *mockAPITokenUsecase) {
+				m.On("RevokeToken", mock.Anything, "user-uuid", "token-uuid").Return(errors.New("usecase error"))
+			},
+			wantStatus:     http.StatusInternalServerError,
+			wantBodySubstr: "usecase error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, "/api/users/me/tokens/token-uuid", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_uuid", "user-uuid")
+			c.SetParamNames("token_uuid")
+			c.SetParamValues("token-uuid")
+
+			mockUsecase := new(mockAPITokenUsecase)
+			tt.setupMock(mockUsecase)
+
+			h := NewAPITokenHandler(mockUsecase)
+			_ = h.RevokeToken(c)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBodySubstr)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
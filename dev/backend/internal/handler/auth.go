@@ -2,14 +2,30 @@ package handler
 
 import (
 	"backend/config"
+	domainModel "backend/internal/domain/model"
 	"backend/internal/domain/usecase"
 	"backend/internal/handler/model"
+	"backend/internal/i18n"
+	"errors"
 	"log/slog"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 )
 
+const (
+	// アクセストークンを保持するクッキー名
+	accessTokenCookieName = "jwt_token"
+	// リフレッシュトークンを保持するクッキー名
+	refreshTokenCookieName = "refresh_token"
+	// リフレッシュトークンのクッキーを送信するパス
+	refreshTokenCookiePath = "/api/auth"
+	// OIDCログインのフロートークンを保持するクッキー名
+	oidcFlowCookieName = "oidc_flow"
+)
+
 type AuthHandler struct {
 	authUsecase usecase.AuthUsecase
 	cfg         *config.Config
@@ -26,7 +42,7 @@ func NewAuthHandler(authUsecase usecase.AuthUsecase, cfg *config.Config) *AuthHa
 // ゲストサインアップリクエストの処理
 func (h *AuthHandler) Signup(c echo.Context) error {
 	ctx := c.Request().Context()
-	user, token, err := h.authUsecase.GuestSignup(ctx)
+	user, tokens, err := h.authUsecase.GuestSignup(ctx, sessionClient(c))
 	if err != nil {
 		slog.ErrorContext(ctx, "ゲストサインアップに失敗", "error", err)
 		return c.JSON(http.StatusInternalServerError, model.Response{
@@ -35,16 +51,13 @@ func (h *AuthHandler) Signup(c echo.Context) error {
 		})
 	}
 
+	h.setAuthCookies(c, tokens)
+
 	slog.InfoContext(ctx, "ゲストサインアップに成功", "user_uuid", user.UUID)
 	return c.JSON(http.StatusOK, model.SignupResponse{
-		Token: token,
-		User: struct {
-			UUID string `json:"uuid"`
-			Name string `json:"name"`
-		}{
-			UUID: user.UUID,
-			Name: user.Name,
-		},
+		Token:     tokens.AccessToken,
+		ExpiresAt: tokens.AccessTokenExpiresAt,
+		User:      toUserResponse(user),
 	})
 }
 
@@ -55,7 +68,7 @@ func (h *AuthHandler) Login(c echo.Context) error {
 		slog.WarnContext(c.Request().Context(), "ログインリクエストのバインドに失敗", "error", err)
 		return c.JSON(http.StatusBadRequest, model.Response{
 			Status:  "error",
-			Message: "invalid request",
+			Message: localize(c, i18n.KeyInvalidRequest),
 		})
 	}
 
@@ -63,12 +76,19 @@ func (h *AuthHandler) Login(c echo.Context) error {
 		slog.WarnContext(c.Request().Context(), "ユーザーUUIDが指定されていません")
 		return c.JSON(http.StatusBadRequest, model.Response{
 			Status:  "error",
-			Message: "user_uuid is required",
+			Message: localize(c, i18n.KeyUserUUIDRequired),
 		})
 	}
 
 	ctx := c.Request().Context()
-	token, err := h.authUsecase.GuestLogin(ctx, req.UserUUID)
+	user, tokens, err := h.authUsecase.GuestLogin(ctx, req.UserUUID, sessionClient(c))
+	if errors.Is(err, domainModel.ErrGuestLoginDenied) {
+		slog.WarnContext(ctx, "ゲスト以外のユーザーへのゲストログインを拒否", "user_uuid", req.UserUUID)
+		return c.JSON(http.StatusUnauthorized, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyGuestLoginDenied),
+		})
+	}
 	if err != nil {
 		slog.ErrorContext(ctx, "ゲストログインに失敗", "error", err)
 		return c.JSON(http.StatusInternalServerError, model.Response{
@@ -77,18 +97,347 @@ func (h *AuthHandler) Login(c echo.Context) error {
 		})
 	}
 
-	cookie := new(http.Cookie)
-	cookie.Name = "jwt_token"
-	cookie.Value = token
-	cookie.HttpOnly = false
-	// cookie.Secure = true
-	cookie.SameSite = http.SameSiteLaxMode
-	cookie.Path = "/"
-	cookie.MaxAge = 36000
-	c.SetCookie(cookie)
+	h.setAuthCookies(c, tokens)
 
 	slog.InfoContext(ctx, "ゲストログインに成功", "user_uuid", req.UserUUID)
 	return c.JSON(http.StatusOK, model.LoginResponse{
-		Token: token,
+		Token:     tokens.AccessToken,
+		ExpiresAt: tokens.AccessTokenExpiresAt,
+		User:      toUserResponse(user),
+	})
+}
+
+// OIDCプロバイダーの認可エンドポイントへリダイレクトする処理
+func (h *AuthHandler) OIDCLogin(c echo.Context) error {
+	ctx := c.Request().Context()
+	providerName := c.Param("provider")
+
+	authReq, err := h.authUsecase.StartOIDCLogin(ctx, providerName)
+	if err != nil {
+		if errors.Is(err, domainModel.ErrUnknownProvider) {
+			slog.WarnContext(ctx, "設定されていないOIDCプロバイダーが指定されました", "provider", providerName)
+			return c.JSON(http.StatusNotFound, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyUnknownProvider),
+			})
+		}
+		slog.ErrorContext(ctx, "OIDCログインの開始に失敗", "error", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
+	}
+
+	slog.InfoContext(ctx, "OIDCプロバイダーへリダイレクト", "provider", providerName)
+	return h.redirectToOIDCProvider(c, authReq)
+}
+
+// ログイン中のゲストユーザーにOIDCアカウントを紐付けるため、OIDCプロバイダーへリダイレクトする処理
+// コールバックはログインと共通で、紐付け先のユーザーはフロートークンから判別する
+func (h *AuthHandler) OIDCLink(c echo.Context) error {
+	ctx := c.Request().Context()
+	providerName := c.Param("provider")
+	userUUID, ok := c.Get("user_uuid").(string)
+	if !ok {
+		slog.WarnContext(ctx, "ユーザーUUIDの取得に失敗")
+		return c.JSON(http.StatusUnauthorized, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyUserUUIDNotFound),
+		})
+	}
+
+	authReq, err := h.authUsecase.StartOIDCLink(ctx, providerName, userUUID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainModel.ErrUnknownProvider):
+			slog.WarnContext(ctx, "設定されていないOIDCプロバイダーが指定されました", "provider", providerName)
+			return c.JSON(http.StatusNotFound, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyUnknownProvider),
+			})
+		case errors.Is(err, domainModel.ErrAlreadyLinked):
+			slog.WarnContext(ctx, "既にアカウントが紐付けられたユーザーです", "user_uuid", userUUID)
+			return c.JSON(http.StatusConflict, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyAlreadyLinked),
+			})
+		}
+		slog.ErrorContext(ctx, "OIDCアカウント紐付けの開始に失敗", "error", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
+	}
+
+	slog.InfoContext(ctx, "アカウント紐付けのためOIDCプロバイダーへリダイレクト", "provider", providerName, "user_uuid", userUUID)
+	return h.redirectToOIDCProvider(c, authReq)
+}
+
+// フロートークンをクッキーに保存し、認可エンドポイントへリダイレクトする処理
+// state・nonce・code_verifier はコールバックまでクッキーで保持する
+func (h *AuthHandler) redirectToOIDCProvider(c echo.Context, authReq *domainModel.OIDCAuthRequest) error {
+	c.SetCookie(h.newOIDCFlowCookie(authReq.FlowToken, 600))
+	return c.Redirect(http.StatusFound, authReq.AuthURL)
+}
+
+// OIDCログインのフロートークンのクッキーを作成する処理
+// プロバイダーからのリダイレクトで送信させるため SameSite は常に Lax とする
+func (h *AuthHandler) newOIDCFlowCookie(flowToken string, maxAge int) *http.Cookie {
+	cookie := h.newCookie(oidcFlowCookieName, flowToken, "/api/auth/oidc", maxAge, true)
+	cookie.SameSite = http.SameSiteLaxMode
+	return cookie
+}
+
+// OIDCプロバイダーからのコールバックを受け取りログインする処理
+func (h *AuthHandler) OIDCCallback(c echo.Context) error {
+	ctx := c.Request().Context()
+	providerName := c.Param("provider")
+
+	if idpErr := c.QueryParam("error"); idpErr != "" {
+		slog.WarnContext(ctx, "OIDCプロバイダーで認可が拒否されました", "provider", providerName, "error", idpErr)
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyOIDCAuthorizationDenied),
+		})
+	}
+
+	flowCookie, err := c.Cookie(oidcFlowCookieName)
+	if err != nil {
+		slog.WarnContext(ctx, "OIDCフロークッキーが見つかりません", "error", err)
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyInvalidOIDCState),
+		})
+	}
+
+	// フロートークンは一度きりで使用する
+	c.SetCookie(h.newOIDCFlowCookie("", -1))
+
+	user, tokens, err := h.authUsecase.OIDCLogin(ctx, providerName, c.QueryParam("code"), c.QueryParam("state"), flowCookie.Value, sessionClient(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, domainModel.ErrUnknownProvider):
+			slog.WarnContext(ctx, "設定されていないOIDCプロバイダーが指定されました", "provider", providerName)
+			return c.JSON(http.StatusNotFound, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyUnknownProvider),
+			})
+		case errors.Is(err, domainModel.ErrInvalidOIDCState):
+			slog.WarnContext(ctx, "OIDCログインのstateが不正です", "error", err)
+			return c.JSON(http.StatusBadRequest, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyInvalidOIDCState),
+			})
+		case errors.Is(err, domainModel.ErrAlreadyLinked):
+			slog.WarnContext(ctx, "既にアカウントが紐付けられたユーザーです", "error", err)
+			return c.JSON(http.StatusConflict, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyAlreadyLinked),
+			})
+		}
+		slog.ErrorContext(ctx, "OIDCログインに失敗", "error", err)
+		return c.JSON(http.StatusUnauthorized, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
+	}
+
+	h.setAuthCookies(c, tokens)
+
+	slog.InfoContext(ctx, "OIDCログインに成功", "provider", providerName, "user_uuid", user.UUID)
+	if h.cfg.OIDC.SuccessRedirectURL != "" {
+		return c.Redirect(http.StatusFound, h.cfg.OIDC.SuccessRedirectURL)
+	}
+	return c.JSON(http.StatusOK, model.LoginResponse{
+		Token:     tokens.AccessToken,
+		ExpiresAt: tokens.AccessTokenExpiresAt,
+		User:      toUserResponse(user),
+	})
+}
+
+// リフレッシュトークンを使用してアクセストークンを再発行する処理
+func (h *AuthHandler) Refresh(c echo.Context) error {
+	ctx := c.Request().Context()
+	refreshToken := ""
+	if cookie, err := c.Cookie(refreshTokenCookieName); err == nil {
+		refreshToken = cookie.Value
+	}
+
+	tokens, err := h.authUsecase.RefreshSession(ctx, refreshToken, sessionClient(c))
+	if err != nil {
+		if errors.Is(err, domainModel.ErrInvalidRefreshToken) {
+			slog.WarnContext(ctx, "無効なリフレッシュトークンです")
+			h.clearAuthCookies(c)
+			return c.JSON(http.StatusUnauthorized, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyRefreshTokenInvalid),
+			})
+		}
+		slog.ErrorContext(ctx, "トークンの再発行に失敗", "error", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
+	}
+
+	h.setAuthCookies(c, tokens)
+
+	slog.InfoContext(ctx, "トークンの再発行に成功", "session_uuid", tokens.SessionUUID)
+	return c.JSON(http.StatusOK, model.RefreshResponse{
+		Token:     tokens.AccessToken,
+		ExpiresAt: tokens.AccessTokenExpiresAt,
+	})
+}
+
+// リフレッシュトークンを失効させ、認証クッキーを削除する処理
+func (h *AuthHandler) Logout(c echo.Context) error {
+	ctx := c.Request().Context()
+	refreshToken := ""
+	if cookie, err := c.Cookie(refreshTokenCookieName); err == nil {
+		refreshToken = cookie.Value
+	}
+
+	if err := h.authUsecase.Logout(ctx, refreshToken); err != nil {
+		slog.ErrorContext(ctx, "ログアウトに失敗", "error", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
+	}
+
+	h.clearAuthCookies(c)
+
+	slog.InfoContext(ctx, "ログアウトに成功")
+	return c.JSON(http.StatusOK, model.Response{
+		Status: "ok",
+	})
+}
+
+// ログイン中のユーザーの有効なセッション一覧を取得する処理
+func (h *AuthHandler) ListSessions(c echo.Context) error {
+	ctx := c.Request().Context()
+	userUUID, ok := c.Get("user_uuid").(string)
+	if !ok {
+		slog.WarnContext(ctx, "ユーザーUUIDの取得に失敗")
+		return c.JSON(http.StatusUnauthorized, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyUserUUIDNotFound),
+		})
+	}
+	currentSessionUUID, _ := c.Get("session_uuid").(string)
+
+	sessions, err := h.authUsecase.ListSessions(ctx, userUUID)
+	if err != nil {
+		slog.ErrorContext(ctx, "セッション一覧の取得に失敗", "error", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
+	}
+
+	res := make([]*model.SessionResponse, len(sessions))
+	for i, s := range sessions {
+		res[i] = &model.SessionResponse{
+			UUID:       s.UUID,
+			UserAgent:  s.UserAgent,
+			IPAddress:  s.IPAddress,
+			CreatedAt:  s.CreatedAt,
+			LastUsedAt: s.LastUsedAt,
+			ExpiresAt:  s.ExpiresAt,
+			Current:    s.UUID == currentSessionUUID,
+		}
+	}
+
+	slog.InfoContext(ctx, "セッション一覧の取得に成功", "user_uuid", userUUID, "count", len(res))
+	return c.JSON(http.StatusOK, res)
+}
+
+// ログイン中のユーザーのセッションを指定して失効させる処理
+func (h *AuthHandler) RevokeSession(c echo.Context) error {
+	ctx := c.Request().Context()
+	userUUID, ok := c.Get("user_uuid").(string)
+	if !ok {
+		slog.WarnContext(ctx, "ユーザーUUIDの取得に失敗")
+		return c.JSON(http.StatusUnauthorized, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyUserUUIDNotFound),
+		})
+	}
+	sessionUUID := c.Param("session_uuid")
+
+	if err := h.authUsecase.RevokeSession(ctx, userUUID, sessionUUID); err != nil {
+		if errors.Is(err, domainModel.ErrSessionNotFound) {
+			slog.WarnContext(ctx, "セッションが見つかりません", "session_uuid", sessionUUID)
+			return c.JSON(http.StatusNotFound, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeySessionNotFound),
+			})
+		}
+		slog.ErrorContext(ctx, "セッションの失効に失敗", "error", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
+	}
+
+	slog.InfoContext(ctx, "セッションの失効に成功", "user_uuid", userUUID, "session_uuid", sessionUUID)
+	return c.JSON(http.StatusOK, model.Response{
+		Status: "ok",
 	})
 }
+
+// リクエストからセッションを開始したクライアントの情報を取得する処理
+func sessionClient(c echo.Context) domainModel.SessionClient {
+	return domainModel.SessionClient{
+		UserAgent: c.Request().UserAgent(),
+		IPAddress: c.RealIP(),
+	}
+}
+
+// アクセストークンとリフレッシュトークンをクッキーに設定する処理
+// リフレッシュトークンは JavaScript から参照させないため常に HttpOnly とする
+func (h *AuthHandler) setAuthCookies(c echo.Context, tokens *domainModel.AuthTokens) {
+	c.SetCookie(h.newCookie(accessTokenCookieName, tokens.AccessToken, "/", maxAgeUntil(tokens.AccessTokenExpiresAt), h.cfg.Cookie.HttpOnly))
+	c.SetCookie(h.newCookie(refreshTokenCookieName, tokens.RefreshToken, refreshTokenCookiePath, maxAgeUntil(tokens.RefreshTokenExpiresAt), true))
+}
+
+// アクセストークンとリフレッシュトークンのクッキーを削除する処理
+func (h *AuthHandler) clearAuthCookies(c echo.Context) {
+	c.SetCookie(h.newCookie(accessTokenCookieName, "", "/", -1, h.cfg.Cookie.HttpOnly))
+	c.SetCookie(h.newCookie(refreshTokenCookieName, "", refreshTokenCookiePath, -1, true))
+}
+
+// 設定の Secure・Domain・SameSite 属性を適用したクッキーを作成する処理
+func (h *AuthHandler) newCookie(name string, value string, path string, maxAge int, httpOnly bool) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Domain:   h.cfg.Cookie.Domain,
+		MaxAge:   maxAge,
+		Secure:   h.cfg.Cookie.Secure,
+		HttpOnly: httpOnly,
+		SameSite: parseSameSite(h.cfg.Cookie.SameSite),
+	}
+}
+
+// 設定値の SameSite 属性を変換する処理 (未設定・不明な値の場合は Lax)
+func parseSameSite(sameSite string) http.SameSite {
+	switch strings.ToLower(sameSite) {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteLaxMode
+	}
+}
+
+// 指定された日時までの秒数をクッキーの MaxAge として返す処理
+func maxAgeUntil(expiresAt time.Time) int {
+	maxAge := int(time.Until(expiresAt).Seconds())
+	if maxAge <= 0 {
+		return -1
+	}
+	return maxAge
+}
@@ -7,9 +7,11 @@ import (
 	"context"
 
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -21,17 +23,86 @@ type mockAuthUsecase struct {
 	mock.Mock
 }
 
-func (m *mockAuthUsecase) GuestSignup(ctx context.Context) (*model.User, string, error) {
+func (m *mockAuthUsecase) GuestSignup(ctx context.Context, client model.SessionClient) (*model.User, *model.AuthTokens, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
-		return nil, args.String(1), args.Error(2)
+		return nil, nil, args.Error(2)
 	}
-	return args.Get(0).(*model.User), args.String(1), args.Error(2)
+	return args.Get(0).(*model.User), args.Get(1).(*model.AuthTokens), args.Error(2)
 }
 
-func (m *mockAuthUsecase) GuestLogin(ctx context.Context, userUUID string) (string, error) {
+func (m *mockAuthUsecase) GuestLogin(ctx context.Context, userUUID string, client model.SessionClient) (*model.User, *model.AuthTokens, error) {
 	args := m.Called(ctx, userUUID)
-	return args.String(0), args.Error(1)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*model.User), args.Get(1).(*model.AuthTokens), args.Error(2)
+}
+
+func (m *mockAuthUsecase) StartOIDCLogin(ctx context.Context, providerName string) (*model.OIDCAuthRequest, error) {
+	args := m.Called(ctx, providerName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.OIDCAuthRequest), args.Error(1)
+}
+
+func (m *mockAuthUsecase) StartOIDCLink(ctx context.Context, providerName string, userUUID string) (*model.OIDCAuthRequest, error) {
+	args := m.Called(ctx, providerName, userUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.OIDCAuthRequest), args.Error(1)
+}
+
+func (m *mockAuthUsecase) OIDCLogin(ctx context.Context, providerName string, code string, state string, flowToken string, client model.SessionClient) (*model.User, *model.AuthTokens, error) {
+	args := m.Called(ctx, providerName, code, state, flowToken)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*model.User), args.Get(1).(*model.AuthTokens), args.Error(2)
+}
+
+func (m *mockAuthUsecase) RefreshSession(ctx context.Context, refreshToken string, client model.SessionClient) (*model.AuthTokens, error) {
+	args := m.Called(ctx, refreshToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.AuthTokens), args.Error(1)
+}
+
+func (m *mockAuthUsecase) Logout(ctx context.Context, refreshToken string) error {
+	args := m.Called(ctx, refreshToken)
+	return args.Error(0)
+}
+
+func (m *mockAuthUsecase) ListSessions(ctx context.Context, userUUID string) ([]*model.Session, error) {
+	args := m.Called(ctx, userUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Session), args.Error(1)
+}
+
+func (m *mockAuthUsecase) RevokeSession(ctx context.Context, userUUID string, sessionUUID string) error {
+	args := m.Called(ctx, userUUID, sessionUUID)
+	return args.Error(0)
+}
+
+func (m *mockAuthUsecase) ValidateSession(ctx context.Context, userUUID string, sessionUUID string) error {
+	args := m.Called(ctx, userUUID, sessionUUID)
+	return args.Error(0)
+}
+
+// テスト用の発行済みトークンを作成する処理
+func newTestTokens() *model.AuthTokens {
+	return &model.AuthTokens{
+		AccessToken:           "test-token",
+		AccessTokenExpiresAt:  time.Now().Add(15 * time.Minute),
+		RefreshToken:          "test-refresh-token",
+		RefreshTokenExpiresAt: time.Now().Add(24 * time.Hour),
+		SessionUUID:           "session-uuid",
+	}
 }
 
 func TestAuthHandler_Signup(t *testing.T) {
@@ -49,7 +120,7 @@ func TestAuthHandler_Signup(t *testing.T) {
 				m.On("GuestSignup", mock.Anything).Return(&model.User{
 					UUID: "test-uuid",
 					Name: "Guest-test",
-				}, "test-token", nil)
+				}, newTestTokens(), nil)
 			},
 			wantStatus: http.StatusOK,
 			wantBody:   `"token":"test-token"`,
@@ -57,7 +128,7 @@ func TestAuthHandler_Signup(t *testing.T) {
 		{
 			name: "異常系: Usecaseでエラーが発生した場合500になること",
 			setupMock: func(m *mockAuthUsecase) {
-				m.On("GuestSignup", mock.Anything).Return(nil, "", errors.New("internal error"))
+				m.On("GuestSignup", mock.Anything).Return(nil, nil, errors.New("internal error"))
 			},
 			wantStatus: http.StatusInternalServerError,
 			wantBody:   `"status":"error"`,
@@ -92,6 +163,7 @@ func TestAuthHandler_Login(t *testing.T) {
 	tests := []struct {
 		name       string
 		reqBody    string
+		setupCtx   func(c echo.Context)
 		setupMock  func(m *mockAuthUsecase)
 		wantStatus int
 		wantBody   string
@@ -100,10 +172,24 @@ func TestAuthHandler_Login(t *testing.T) {
 			name:    "正常系: ログインが成功すること",
 			reqBody: `{"user_uuid": "test-uuid"}`,
 			setupMock: func(m *mockAuthUsecase) {
-				m.On("GuestLogin", mock.Anything, "test-uuid").Return("test-token", nil)
+				m.On("GuestLogin", mock.Anything, "test-uuid").Return(&model.User{
+					UUID:     "test-uuid",
+					Name:     "Guest-test",
+					Language: "en",
+				}, newTestTokens(), nil)
 			},
 			wantStatus: http.StatusOK,
-			wantBody:   `"token":"test-token"`,
+			wantBody:   `"user":{"uuid":"test-uuid","name":"Guest-test","language":"en","provider":"","avatar_url":""}`,
+		},
+		{
+			name:    "正常系: 優先言語が英語の場合は英語のエラーメッセージが返ること",
+			reqBody: `{"user_uuid": ""}`,
+			setupCtx: func(c echo.Context) {
+				c.Set("language", "en")
+			},
+			setupMock:  func(m *mockAuthUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `"message":"user_uuid is required"`,
 		},
 		{
 			name:       "異常系: リクエストボディが不正な場合400になること",
@@ -117,13 +203,22 @@ func TestAuthHandler_Login(t *testing.T) {
 			reqBody:    `{"user_uuid": ""}`,
 			setupMock:  func(m *mockAuthUsecase) {},
 			wantStatus: http.StatusBadRequest,
-			wantBody:   `"message":"user_uuid is required"`,
+			wantBody:   `"message":"user_uuidは必須です"`,
+		},
+		{
+			name:    "異常系: ゲスト以外のユーザーのUUIDでログインしようとした場合401になること",
+			reqBody: `{"user_uuid": "oidc-uuid"}`,
+			setupMock: func(m *mockAuthUsecase) {
+				m.On("GuestLogin", mock.Anything, "oidc-uuid").Return(nil, nil, fmt.Errorf("%w: google", model.ErrGuestLoginDenied))
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantBody:   `"message":"ゲストユーザー以外はユーザーUUIDでログインできません"`,
 		},
 		{
 			name:    "異常系: Usecaseでエラーが発生した場合500になること",
 			reqBody: `{"user_uuid": "test-uuid"}`,
 			setupMock: func(m *mockAuthUsecase) {
-				m.On("GuestLogin", mock.Anything, "test-uuid").Return("", errors.New("internal error"))
+				m.On("GuestLogin", mock.Anything, "test-uuid").Return(nil, nil, errors.New("internal error"))
 			},
 			wantStatus: http.StatusInternalServerError,
 			wantBody:   `"status":"error"`,
@@ -137,6 +232,9 @@ func TestAuthHandler_Login(t *testing.T) {
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
+			if tt.setupCtx != nil {
+				tt.setupCtx(c)
+			}
 
 			mockUsecase := new(mockAuthUsecase)
 			tt.setupMock(mockUsecase)
@@ -152,3 +250,423 @@ func TestAuthHandler_Login(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthHandler_OIDCLogin(t *testing.T) {
+	cfg := &config.Config{}
+
+	tests := []struct {
+		name         string
+		provider     string
+		setupMock    func(m *mockAuthUsecase)
+		wantStatus   int
+		wantLocation string
+		wantCookie   bool
+	}{
+		{
+			name:     "正常系: 認可エンドポイントへリダイレクトされること",
+			provider: "google",
+			setupMock: func(m *mockAuthUsecase) {
+				m.On("StartOIDCLogin", mock.Anything, "google").Return(&model.OIDCAuthRequest{
+					AuthURL:   "https://idp.example.com/authorize?state=abc",
+					FlowToken: "flow-token",
+				}, nil)
+			},
+			wantStatus:   http.StatusFound,
+			wantLocation: "https://idp.example.com/authorize?state=abc",
+			wantCookie:   true,
+		},
+		{
+			name:     "異常系: 設定されていないプロバイダーの場合404になること",
+			provider: "unknown",
+			setupMock: func(m *mockAuthUsecase) {
+				m.On("StartOIDCLogin", mock.Anything, "unknown").Return(nil, fmt.Errorf("%w: unknown", model.ErrUnknownProvider))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/auth/oidc/"+tt.provider+"/login", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("provider")
+			c.SetParamValues(tt.provider)
+
+			mockUsecase := new(mockAuthUsecase)
+			tt.setupMock(mockUsecase)
+
+			h := NewAuthHandler(mockUsecase, cfg)
+			assert.NoError(t, h.OIDCLogin(c))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantLocation, rec.Header().Get(echo.HeaderLocation))
+			if tt.wantCookie {
+				cookie := rec.Result().Cookies()[0]
+				assert.Equal(t, "oidc_flow", cookie.Name)
+				assert.Equal(t, "flow-token", cookie.Value)
+				assert.True(t, cookie.HttpOnly)
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthHandler_OIDCLink(t *testing.T) {
+	cfg := &config.Config{}
+
+	tests := []struct {
+		name         string
+		userUUID     string
+		setupMock    func(m *mockAuthUsecase)
+		wantStatus   int
+		wantLocation string
+	}{
+		{
+			name:     "正常系: 認可エンドポイントへリダイレクトされること",
+			userUUID: "guest-user",
+			setupMock: func(m *mockAuthUsecase) {
+				m.On("StartOIDCLink", mock.Anything, "google", "guest-user").Return(&model.OIDCAuthRequest{
+					AuthURL:   "https://idp.example.com/authorize?state=abc",
+					FlowToken: "flow-token",
+				}, nil)
+			},
+			wantStatus:   http.StatusFound,
+			wantLocation: "https://idp.example.com/authorize?state=abc",
+		},
+		{
+			name:     "異常系: 既にアカウントが紐付けられている場合409になること",
+			userUUID: "linked-user",
+			setupMock: func(m *mockAuthUsecase) {
+				m.On("StartOIDCLink", mock.Anything, "google", "linked-user").Return(nil, fmt.Errorf("%w: google", model.ErrAlreadyLinked))
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "異常系: ユーザーUUIDが取得できない場合401になること",
+			setupMock:  func(m *mockAuthUsecase) {},
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/auth/oidc/google/link", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("provider")
+			c.SetParamValues("google")
+			if tt.userUUID != "" {
+				c.Set("user_uuid", tt.userUUID)
+			}
+
+			mockUsecase := new(mockAuthUsecase)
+			tt.setupMock(mockUsecase)
+
+			h := NewAuthHandler(mockUsecase, cfg)
+			assert.NoError(t, h.OIDCLink(c))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantLocation, rec.Header().Get(echo.HeaderLocation))
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthHandler_OIDCCallback(t *testing.T) {
+	tests := []struct {
+		name         string
+		cfg          *config.Config
+		query        string
+		flowCookie   string
+		setupMock    func(m *mockAuthUsecase)
+		wantStatus   int
+		wantBody     string
+		wantLocation string
+	}{
+		{
+			name:       "正常系: ログインしてJSONが返ること",
+			cfg:        &config.Config{},
+			query:      "?code=code&state=state",
+			flowCookie: "flow-token",
+			setupMock: func(m *mockAuthUsecase) {
+				m.On("OIDCLogin", mock.Anything, "google", "code", "state", "flow-token").Return(&model.User{
+					UUID:      "user-uuid",
+					Name:      "Test User",
+					AvatarURL: "https://example.com/avatar.png",
+				}, newTestTokens(), nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `"avatar_url":"https://example.com/avatar.png"`,
+		},
+		{
+			name:       "正常系: リダイレクト先が設定されている場合はリダイレクトされること",
+			cfg:        &config.Config{OIDC: config.OIDCConfig{SuccessRedirectURL: "http://localhost:5173"}},
+			query:      "?code=code&state=state",
+			flowCookie: "flow-token",
+			setupMock: func(m *mockAuthUsecase) {
+				m.On("OIDCLogin", mock.Anything, "google", "code", "state", "flow-token").Return(&model.User{UUID: "user-uuid"}, newTestTokens(), nil)
+			},
+			wantStatus:   http.StatusFound,
+			wantLocation: "http://localhost:5173",
+		},
+		{
+			name:       "異常系: フロークッキーがない場合400になること",
+			cfg:        &config.Config{},
+			query:      "?code=code&state=state",
+			setupMock:  func(m *mockAuthUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `"status":"error"`,
+		},
+		{
+			name:       "異常系: IdPで認可が拒否された場合400になること",
+			cfg:        &config.Config{},
+			query:      "?error=access_denied&state=state",
+			flowCookie: "flow-token",
+			setupMock:  func(m *mockAuthUsecase) {},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `"status":"error"`,
+		},
+		{
+			name:       "異常系: stateが不正な場合400になること",
+			cfg:        &config.Config{},
+			query:      "?code=code&state=tampered",
+			flowCookie: "flow-token",
+			setupMock: func(m *mockAuthUsecase) {
+				m.On("OIDCLogin", mock.Anything, "google", "code", "tampered", "flow-token").Return(nil, nil, fmt.Errorf("%w: mismatch", model.ErrInvalidOIDCState))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `"status":"error"`,
+		},
+		{
+			name:       "異常系: 認可コードの交換に失敗した場合401になること",
+			cfg:        &config.Config{},
+			query:      "?code=code&state=state",
+			flowCookie: "flow-token",
+			setupMock: func(m *mockAuthUsecase) {
+				m.On("OIDCLogin", mock.Anything, "google", "code", "state", "flow-token").Return(nil, nil, errors.New("exchange error"))
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantBody:   "exchange error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/auth/oidc/google/callback"+tt.query, nil)
+			if tt.flowCookie != "" {
+				req.AddCookie(&http.Cookie{Name: "oidc_flow", Value: tt.flowCookie})
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("provider")
+			c.SetParamValues("google")
+
+			mockUsecase := new(mockAuthUsecase)
+			tt.setupMock(mockUsecase)
+
+			h := NewAuthHandler(mockUsecase, tt.cfg)
+			assert.NoError(t, h.OIDCCallback(c))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBody)
+			assert.Equal(t, tt.wantLocation, rec.Header().Get(echo.HeaderLocation))
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthHandler_Refresh(t *testing.T) {
+	cfg := &config.Config{Cookie: config.CookieConfig{Secure: true, HttpOnly: true}}
+
+	tests := []struct {
+		name         string
+		refreshToken string
+		setupMock    func(m *mockAuthUsecase)
+		wantStatus   int
+		wantBody     string
+	}{
+		{
+			name:         "正常系: トークンが再発行されクッキーが更新されること",
+			refreshToken: "old-refresh-token",
+			setupMock: func(m *mockAuthUsecase) {
+				m.On("RefreshSession", mock.Anything, "old-refresh-token").Return(newTestTokens(), nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `"token":"test-token"`,
+		},
+		{
+			name:         "異常系: 無効なリフレッシュトークンの場合401になること",
+			refreshToken: "reused-refresh-token",
+			setupMock: func(m *mockAuthUsecase) {
+				m.On("RefreshSession", mock.Anything, "reused-refresh-token").Return(nil, model.ErrInvalidRefreshToken)
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "異常系: リフレッシュトークンのクッキーがない場合401になること",
+			setupMock: func(m *mockAuthUsecase) {
+				m.On("RefreshSession", mock.Anything, "").Return(nil, model.ErrInvalidRefreshToken)
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/refresh", nil)
+			if tt.refreshToken != "" {
+				req.AddCookie(&http.Cookie{Name: "refresh_token", Value: tt.refreshToken})
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			mockUsecase := new(mockAuthUsecase)
+			tt.setupMock(mockUsecase)
+
+			h := NewAuthHandler(mockUsecase, cfg)
+			assert.NoError(t, h.Refresh(c))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBody)
+
+			cookies := map[string]*http.Cookie{}
+			for _, cookie := range rec.Result().Cookies() {
+				cookies[cookie.Name] = cookie
+			}
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, "test-token", cookies["jwt_token"].Value)
+				assert.True(t, cookies["jwt_token"].HttpOnly)
+				assert.True(t, cookies["jwt_token"].Secure)
+				assert.Equal(t, "test-refresh-token", cookies["refresh_token"].Value)
+				assert.Equal(t, "/api/auth", cookies["refresh_token"].Path)
+				assert.True(t, cookies["refresh_token"].HttpOnly)
+			} else {
+				// 無効なトークンの場合はクッキーが削除されること
+				assert.Equal(t, "", cookies["refresh_token"].Value)
+				assert.Less(t, cookies["refresh_token"].MaxAge, 0)
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthHandler_Logout(t *testing.T) {
+	tests := []struct {
+		name       string
+		setupMock  func(m *mockAuthUsecase)
+		wantStatus int
+	}{
+		{
+			name: "正常系: セッションが失効されクッキーが削除されること",
+			setupMock: func(m *mockAuthUsecase) {
+				m.On("Logout", mock.Anything, "refresh-token").Return(nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "異常系: 失効に失敗した場合500になること",
+			setupMock: func(m *mockAuthUsecase) {
+				m.On("Logout", mock.Anything, "refresh-token").Return(errors.New("db error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/logout", nil)
+			req.AddCookie(&http.Cookie{Name: "refresh_token", Value: "refresh-token"})
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			mockUsecase := new(mockAuthUsecase)
+			tt.setupMock(mockUsecase)
+
+			h := NewAuthHandler(mockUsecase, &config.Config{})
+			assert.NoError(t, h.Logout(c))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Len(t, rec.Result().Cookies(), 2)
+				for _, cookie := range rec.Result().Cookies() {
+					assert.Less(t, cookie.MaxAge, 0)
+				}
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthHandler_ListSessions(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/sessions", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user_uuid", "user-uuid")
+	c.Set("session_uuid", "session-2")
+
+	mockUsecase := new(mockAuthUsecase)
+	mockUsecase.On("ListSessions", mock.Anything, "user-uuid").Return([]*model.Session{
+		{UUID: "session-1", UserAgent: "agent-1"},
+		{UUID: "session-2", UserAgent: "agent-2"},
+	}, nil)
+
+	h := NewAuthHandler(mockUsecase, &config.Config{})
+	assert.NoError(t, h.ListSessions(c))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"uuid":"session-1","user_agent":"agent-1"`)
+	assert.Contains(t, rec.Body.String(), `"current":true`)
+	assert.Contains(t, rec.Body.String(), `"current":false`)
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestAuthHandler_RevokeSession(t *testing.T) {
+	tests := []struct {
+		name       string
+		setupMock  func(m *mockAuthUsecase)
+		wantStatus int
+	}{
+		{
+			name: "正常系: セッションが失効されること",
+			setupMock: func(m *mockAuthUsecase) {
+				m.On("RevokeSession", mock.Anything, "user-uuid", "session-uuid").Return(nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "異常系: セッションが存在しない場合404になること",
+			setupMock: func(m *mockAuthUsecase) {
+				m.On("RevokeSession", mock.Anything, "user-uuid", "session-uuid").Return(fmt.Errorf("%w: session-uuid", model.ErrSessionNotFound))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, "/api/auth/sessions/session-uuid", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("session_uuid")
+			c.SetParamValues("session-uuid")
+			c.Set("user_uuid", "user-uuid")
+
+			mockUsecase := new(mockAuthUsecase)
+			tt.setupMock(mockUsecase)
+
+			h := NewAuthHandler(mockUsecase, &config.Config{})
+			assert.NoError(t, h.RevokeSession(c))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
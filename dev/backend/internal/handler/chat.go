@@ -4,7 +4,9 @@ import (
 	domainModel "backend/internal/domain/model"
 	"backend/internal/domain/usecase"
 	"backend/internal/handler/model"
+	"backend/internal/i18n"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -75,6 +77,16 @@ func (h *chatHandler) FirstStreamChat(c echo.Context) error {
 			c.Response().Flush()
 
 		case err := <-errChan:
+			if errors.Is(err, domainModel.ErrChatGenerationInProgress) {
+				slog.WarnContext(ctx, "他のメンバーが回答を生成中です", "chat_uuid", chatUUID)
+				writeStreamError(c, enc, localize(c, i18n.KeyChatGenerationInProgress))
+				return nil
+			}
+			if errors.Is(err, domainModel.ErrChatNotOpen) {
+				slog.WarnContext(ctx, "open 以外のチャットには回答を生成できません", "chat_uuid", chatUUID)
+				writeStreamError(c, enc, localize(c, i18n.KeyChatNotOpen))
+				return nil
+			}
 			if err != nil {
 				slog.ErrorContext(ctx, "StreamChat エラー発生", "error", err)
 				// エラーをクライアントに通知（必要であれば）
@@ -144,6 +156,16 @@ func (h *chatHandler) StreamMessage(c echo.Context) error {
 			c.Response().Flush()
 
 		case err := <-errChan:
+			if errors.Is(err, domainModel.ErrChatGenerationInProgress) {
+				slog.WarnContext(ctx, "他のメンバーが回答を生成中です", "chat_uuid", chatUUID)
+				writeStreamError(c, enc, localize(c, i18n.KeyChatGenerationInProgress))
+				return nil
+			}
+			if errors.Is(err, domainModel.ErrChatNotOpen) {
+				slog.WarnContext(ctx, "open 以外のチャットには回答を生成できません", "chat_uuid", chatUUID)
+				writeStreamError(c, enc, localize(c, i18n.KeyChatNotOpen))
+				return nil
+			}
 			if err != nil {
 				slog.ErrorContext(ctx, "StreamMessage エラー発生", "error", err)
 				// エラーをクライアントに通知（必要であれば）
@@ -221,19 +243,33 @@ func (h *chatHandler) SendMessage(c echo.Context) error {
 		slog.ErrorContext(ctx, "リクエストボディのバインドエラー", "error", err)
 		return c.JSON(http.StatusBadRequest, model.Response{
 			Status:  "error",
-			Message: "リクエストボディのバインドに失敗しました",
+			Message: localize(c, i18n.KeyBindRequestBodyFailed),
 		})
 	}
 
 	if req.Content == "" {
 		return c.JSON(http.StatusBadRequest, model.Response{
 			Status:  "error",
-			Message: "content が空です",
+			Message: localize(c, i18n.KeyContentEmpty),
 		})
 	}
 
 	message, err := h.chatUsecase.SendMessage(ctx, chatUUID, req.Content)
 	if err != nil {
+		if errors.Is(err, domainModel.ErrChatGenerationInProgress) {
+			slog.WarnContext(ctx, "回答の生成中のためメッセージを送信できません", "chat_uuid", chatUUID)
+			return c.JSON(http.StatusConflict, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyChatGenerationInProgress),
+			})
+		}
+		if errors.Is(err, domainModel.ErrChatNotOpen) {
+			slog.WarnContext(ctx, "open 以外のチャットにはメッセージを送信できません", "chat_uuid", chatUUID)
+			return c.JSON(http.StatusConflict, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyChatNotOpen),
+			})
+		}
 		slog.ErrorContext(ctx, "SendMessage エラー", "error", err)
 		return c.JSON(http.StatusInternalServerError, model.Response{
 			Status:  "error",
@@ -255,32 +291,141 @@ func (h *chatHandler) SendMessage(c echo.Context) error {
 	return c.JSON(http.StatusOK, res)
 }
 
-// フォークプレビューを生成する
-func (h *chatHandler) GenerateForkPreview(c echo.Context) error {
+// 別のブランチのメッセージをチャットに取り込む
+func (h *chatHandler) CherryPickMessages(c echo.Context) error {
 	chatUUID := c.Param("chat_uuid")
 	ctx := c.Request().Context()
 
-	var req domainModel.ForkPreviewRequest
+	slog.InfoContext(ctx, "CherryPickMessages リクエスト受信", "chat_uuid", chatUUID)
+
+	var req model.CherryPickRequest
 	if err := c.Bind(&req); err != nil {
 		slog.ErrorContext(ctx, "リクエストボディのバインドエラー", "error", err)
 		return c.JSON(http.StatusBadRequest, model.Response{
 			Status:  "error",
-			Message: "リクエストボディのバインドに失敗しました",
+			Message: localize(c, i18n.KeyBindRequestBodyFailed),
 		})
 	}
 
-	res, err := h.chatUsecase.GenerateForkPreview(ctx, chatUUID, req)
+	result, err := h.chatUsecase.CherryPickMessages(ctx, chatUUID, domainModel.CherryPickParams{
+		MessageUUIDs: req.MessageUUIDs,
+	})
 	if err != nil {
-		slog.ErrorContext(ctx, "GenerateForkPreview エラー", "error", err)
+		if errors.Is(err, domainModel.ErrInvalidCherryPick) {
+			slog.WarnContext(ctx, "不正な取り込み対象のメッセージ", "chat_uuid", chatUUID, "error", err)
+			return c.JSON(http.StatusBadRequest, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyInvalidCherryPick),
+			})
+		}
+		if errors.Is(err, domainModel.ErrChatGenerationInProgress) {
+			slog.WarnContext(ctx, "回答の生成中のためメッセージを取り込めません", "chat_uuid", chatUUID)
+			return c.JSON(http.StatusConflict, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyChatGenerationInProgress),
+			})
+		}
+		if errors.Is(err, domainModel.ErrChatNotOpen) {
+			slog.WarnContext(ctx, "open 以外のチャットにはメッセージを取り込めません", "chat_uuid", chatUUID)
+			return c.JSON(http.StatusConflict, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyChatNotOpen),
+			})
+		}
+		slog.ErrorContext(ctx, "CherryPickMessages エラー", "error", err)
 		return c.JSON(http.StatusInternalServerError, model.Response{
 			Status:  "error",
 			Message: err.Error(),
 		})
 	}
 
+	messages := make([]model.MessageResponse, len(result.Messages))
+	for i, m := range result.Messages {
+		messages[i] = mapMessageToResponse(m)
+	}
+	return c.JSON(http.StatusOK, model.CherryPickResponse{
+		ChatUUID: result.ChatUUID,
+		Messages: messages,
+	})
+}
+
+// フォークプレビューを生成する
+func (h *chatHandler) GenerateForkPreview(c echo.Context) error {
+	chatUUID := c.Param("chat_uuid")
+	ctx := c.Request().Context()
+
+	var req domainModel.ForkPreviewRequest
+	if err := c.Bind(&req); err != nil {
+		slog.ErrorContext(ctx, "リクエストボディのバインドエラー", "error", err)
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyBindRequestBodyFailed),
+		})
+	}
+
+	res, err := h.chatUsecase.GenerateForkPreview(ctx, chatUUID, req)
+	if err != nil {
+		return writeForkError(c, "GenerateForkPreview", err)
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// メッセージからフォーク候補を抽出する
+func (h *chatHandler) SuggestForks(c echo.Context) error {
+	chatUUID := c.Param("chat_uuid")
+	messageUUID := c.Param("message_uuid")
+	ctx := c.Request().Context()
+
+	slog.InfoContext(ctx, "SuggestForks リクエスト受信", "chat_uuid", chatUUID, "message_uuid", messageUUID)
+
+	suggestions, err := h.chatUsecase.SuggestForks(ctx, chatUUID, messageUUID)
+	if err != nil {
+		return writeForkSuggestionError(c, "SuggestForks", err)
+	}
+
+	res := make([]model.ForkSuggestionResponse, len(suggestions))
+	for i, suggestion := range suggestions {
+		res[i] = mapForkSuggestionToResponse(suggestion)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// メッセージのフォーク候補を取得する
+func (h *chatHandler) GetForkSuggestions(c echo.Context) error {
+	chatUUID := c.Param("chat_uuid")
+	messageUUID := c.Param("message_uuid")
+	ctx := c.Request().Context()
+
+	suggestions, err := h.chatUsecase.GetForkSuggestions(ctx, chatUUID, messageUUID)
+	if err != nil {
+		return writeForkSuggestionError(c, "GetForkSuggestions", err)
+	}
+
+	res := make([]model.ForkSuggestionResponse, len(suggestions))
+	for i, suggestion := range suggestions {
+		res[i] = mapForkSuggestionToResponse(suggestion)
+	}
 	return c.JSON(http.StatusOK, res)
 }
 
+// フォーク候補のエラーをレスポンスに変換する処理
+func writeForkSuggestionError(c echo.Context, operation string, err error) error {
+	ctx := c.Request().Context()
+	if errors.Is(err, domainModel.ErrInvalidForkSuggestionTarget) {
+		slog.WarnContext(ctx, "フォーク候補を抽出するメッセージが不正です", "error", err)
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyInvalidSuggestTarget),
+		})
+	}
+	slog.ErrorContext(ctx, operation+" エラー", "error", err)
+	return c.JSON(http.StatusInternalServerError, model.Response{
+		Status:  "error",
+		Message: err.Error(),
+	})
+}
+
 // フォークチャットを生成する
 func (h *chatHandler) ForkChat(c echo.Context) error {
 	chatUUID := c.Param("chat_uuid")
@@ -295,7 +440,7 @@ func (h *chatHandler) ForkChat(c echo.Context) error {
 	// パスパラメータとリクエストボディの整合性チェック
 	if req.ParentChatUUID != "" && req.ParentChatUUID != chatUUID {
 		slog.WarnContext(ctx, "パスパラメータのチャットIDとリクエストボディの親チャットIDが一致しない", "path", chatUUID, "body", req.ParentChatUUID)
-		return c.JSON(http.StatusBadRequest, model.Response{Status: "error", Message: "親チャットIDと一致しない"})
+		return c.JSON(http.StatusBadRequest, model.Response{Status: "error", Message: localize(c, i18n.KeyParentChatMismatch)})
 	}
 	// リクエストボディに親チャットIDがない場合はパスパラメータを使用
 	if req.ParentChatUUID == "" {
@@ -310,22 +455,99 @@ func (h *chatHandler) ForkChat(c echo.Context) error {
 		RangeEnd:          req.RangeEnd,
 		Title:             req.Title,
 		ContextSummary:    req.ContextSummary,
+		PromptVersion:     req.PromptVersion,
 	}
 
 	newChatID, err := h.chatUsecase.ForkChat(ctx, params)
 	if err != nil {
-		slog.ErrorContext(ctx, "フォークチャットの生成に失敗", "error", err)
-		return c.JSON(http.StatusInternalServerError, model.Response{Status: "error", Message: err.Error()})
+		return writeForkError(c, "ForkChat", err)
 	}
 
 	res := model.ForkChatResponse{
 		NewChatID: newChatID,
-		Message:   "子チャットを作成しました",
+		Message:   localize(c, i18n.KeyForkCreated),
 	}
 
 	return c.JSON(http.StatusOK, res)
 }
 
+// 1つのメッセージから複数のブランチをまとめてフォークする
+func (h *chatHandler) BatchForkChat(c echo.Context) error {
+	chatUUID := c.Param("chat_uuid")
+	ctx := c.Request().Context()
+
+	slog.InfoContext(ctx, "BatchForkChat リクエスト受信", "chat_uuid", chatUUID)
+
+	var req model.BatchForkChatRequest
+	if err := c.Bind(&req); err != nil {
+		slog.ErrorContext(ctx, "リクエストボディのバインドエラー", "error", err)
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyBindRequestBodyFailed),
+		})
+	}
+
+	params := domainModel.BatchForkChatParams{
+		ParentChatUUID:       chatUUID,
+		TargetMessageUUID:    req.TargetMessageUUID,
+		Forks:                make([]domainModel.BatchForkItem, 0, len(req.Forks)),
+		GenerateFirstAnswers: req.GenerateFirstAnswers,
+	}
+	for _, fork := range req.Forks {
+		params.Forks = append(params.Forks, domainModel.BatchForkItem{
+			SelectedText:   fork.SelectedText,
+			RangeStart:     fork.RangeStart,
+			RangeEnd:       fork.RangeEnd,
+			Title:          fork.Title,
+			ContextSummary: fork.ContextSummary,
+			PromptVersion:  fork.PromptVersion,
+		})
+	}
+
+	newChatIDs, err := h.chatUsecase.BatchForkChat(ctx, params)
+	if err != nil {
+		return writeForkError(c, "BatchForkChat", err)
+	}
+
+	return c.JSON(http.StatusOK, model.BatchForkChatResponse{
+		NewChatIDs: newChatIDs,
+		Message:    localize(c, i18n.KeyForkCreated),
+	})
+}
+
+// フォークのエラーをレスポンスに変換する処理
+// フォーク元のメッセージ・選択範囲・ブランチの指定が不正な場合は 400、フォーク元のチャットが open でない場合は 409 にする
+func writeForkError(c echo.Context, operation string, err error) error {
+	ctx := c.Request().Context()
+	if errors.Is(err, domainModel.ErrChatNotOpen) {
+		slog.WarnContext(ctx, "open 以外のチャットからはフォークできません", "operation", operation, "error", err)
+		return c.JSON(http.StatusConflict, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyForkSourceNotOpen),
+		})
+	}
+	var key i18n.Key
+	switch {
+	case errors.Is(err, domainModel.ErrInvalidForkTarget):
+		key = i18n.KeyInvalidForkTarget
+	case errors.Is(err, domainModel.ErrInvalidForkSelection):
+		key = i18n.KeyInvalidForkSelection
+	case errors.Is(err, domainModel.ErrInvalidBatchFork):
+		key = i18n.KeyInvalidBatchFork
+	default:
+		slog.ErrorContext(ctx, operation+" エラー", "error", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
+	}
+	slog.WarnContext(ctx, "フォークの指定が不正です", "operation", operation, "error", err)
+	return c.JSON(http.StatusBadRequest, model.Response{
+		Status:  "error",
+		Message: localize(c, key),
+	})
+}
+
 // マージプレビューを生成する
 func (h *chatHandler) GetMergePreview(c echo.Context) error {
 	chatUUID := c.Param("chat_uuid")
@@ -333,8 +555,24 @@ func (h *chatHandler) GetMergePreview(c echo.Context) error {
 
 	slog.InfoContext(ctx, "GetMergePreview リクエスト受信", "chat_uuid", chatUUID)
 
-	preview, err := h.chatUsecase.GetMergePreview(ctx, chatUUID)
+	var req model.MergePreviewRequest
+	if err := c.Bind(&req); err != nil {
+		slog.ErrorContext(ctx, "リクエストボディのバインドエラー", "error", err)
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyBindRequestBodyFailed),
+		})
+	}
+
+	preview, err := h.chatUsecase.GetMergePreview(ctx, chatUUID, req.ParentChatUUID)
 	if err != nil {
+		if errors.Is(err, domainModel.ErrInvalidMergeTarget) {
+			slog.WarnContext(ctx, "マージ先が祖先のチャットではありません", "chat_uuid", chatUUID, "parent_chat_uuid", req.ParentChatUUID)
+			return c.JSON(http.StatusBadRequest, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyInvalidMergeTarget),
+			})
+		}
 		slog.ErrorContext(ctx, "GetMergePreview エラー", "error", err)
 		return c.JSON(http.StatusInternalServerError, model.Response{
 			Status:  "error",
@@ -344,6 +582,18 @@ func (h *chatHandler) GetMergePreview(c echo.Context) error {
 
 	res := model.MergePreviewResponse{
 		SuggestedSummary: preview.SuggestedSummary,
+		PromptVersion:    preview.PromptVersion,
+		TargetChatUUID:   preview.TargetChatUUID,
+	}
+	// ルートのチャットなどマージ先がない場合は null を返す
+	if d := preview.Divergence; d != nil {
+		res.Divergence = &model.MergeDivergenceResponse{
+			AnchorMessageUUID:    d.AnchorMessageUUID,
+			NewMessages:          d.NewMessages,
+			NewAssistantMessages: d.NewAssistantMessages,
+			MergedReports:        d.MergedReports,
+			Stale:                d.Stale,
+		}
 	}
 
 	return c.JSON(http.StatusOK, res)
@@ -361,17 +611,46 @@ func (h *chatHandler) MergeChat(c echo.Context) error {
 		slog.ErrorContext(ctx, "リクエストボディのバインドエラー", "error", err)
 		return c.JSON(http.StatusBadRequest, model.Response{
 			Status:  "error",
-			Message: "リクエストボディのバインドに失敗しました",
+			Message: localize(c, i18n.KeyBindRequestBodyFailed),
 		})
 	}
 
+	// 履歴に操作したユーザーを残す (認証済みのルートなので通常は取得できる)
+	userUUID, _ := c.Get("user_uuid").(string)
+
 	params := domainModel.MergeChatParams{
+		UserUUID:       userUUID,
 		ParentChatUUID: req.ParentChatUUID,
 		SummaryContent: req.SummaryContent,
+		PromptVersion:  req.PromptVersion,
+		// 子孫のブランチも含めて部分木ごとマージする
+		IncludeDescendants: req.IncludeDescendants,
+		Acknowledge:        req.Acknowledge,
 	}
 
 	result, err := h.chatUsecase.MergeChat(ctx, chatUUID, params)
 	if err != nil {
+		if errors.Is(err, domainModel.ErrInvalidMergeTarget) {
+			slog.WarnContext(ctx, "マージ先が祖先のチャットではありません", "chat_uuid", chatUUID, "parent_chat_uuid", req.ParentChatUUID)
+			return c.JSON(http.StatusBadRequest, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyInvalidMergeTarget),
+			})
+		}
+		if errors.Is(err, domainModel.ErrInvalidChatStatusTransition) {
+			slog.WarnContext(ctx, "open 以外のチャットはマージできません", "chat_uuid", chatUUID)
+			return c.JSON(http.StatusConflict, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyInvalidStatusTransition),
+			})
+		}
+		if errors.Is(err, domainModel.ErrChatNotOpen) {
+			slog.WarnContext(ctx, "open 以外のチャットにはマージできません", "chat_uuid", chatUUID, "parent_chat_uuid", req.ParentChatUUID)
+			return c.JSON(http.StatusConflict, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyChatNotOpen),
+			})
+		}
 		slog.ErrorContext(ctx, "MergeChat エラー", "error", err)
 		return c.JSON(http.StatusInternalServerError, model.Response{
 			Status:  "error",
@@ -382,8 +661,218 @@ func (h *chatHandler) MergeChat(c echo.Context) error {
 	res := model.MergeChatResponse{
 		ReportMessageID: result.ReportMessageID,
 		SummaryContent:  result.SummaryContent,
+		TargetChatUUID:  result.TargetChatUUID,
+		MergedChatUUIDs: result.MergedChatUUIDs,
+		// 回答の生成を指定しなかった場合は空になる
+		AcknowledgmentMessageID: result.AcknowledgmentMessageID,
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// チャットのマージを取り消す
+func (h *chatHandler) UnmergeChat(c echo.Context) error {
+	chatUUID := c.Param("chat_uuid")
+	ctx := c.Request().Context()
+
+	slog.InfoContext(ctx, "UnmergeChat リクエスト受信", "chat_uuid", chatUUID)
+
+	var req model.UnmergeChatRequest
+	if err := c.Bind(&req); err != nil {
+		slog.ErrorContext(ctx, "リクエストボディのバインドエラー", "error", err)
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyBindRequestBodyFailed),
+		})
+	}
+
+	userUUID, _ := c.Get("user_uuid").(string)
+
+	result, err := h.chatUsecase.UnmergeChat(ctx, chatUUID, domainModel.UnmergeChatParams{
+		UserUUID: userUUID,
+		Force:    req.Force,
+	})
+	if err != nil {
+		if errors.Is(err, domainModel.ErrChatNotMerged) {
+			slog.WarnContext(ctx, "マージされていないチャットのマージ取り消し", "chat_uuid", chatUUID)
+			return c.JSON(http.StatusConflict, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyChatNotMerged),
+			})
+		}
+		if errors.Is(err, domainModel.ErrMergeReportInUse) {
+			slog.WarnContext(ctx, "マージレポートを参照した回答があるため取り消せません", "chat_uuid", chatUUID)
+			return c.JSON(http.StatusConflict, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyMergeReportInUse),
+			})
+		}
+		slog.ErrorContext(ctx, "UnmergeChat エラー", "error", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, model.UnmergeChatResponse{
+		ChatUUID:          result.ChatUUID,
+		Status:            result.Status,
+		ReportMessageUUID: result.ReportMessageUUID,
+		RestoredChatUUIDs: result.RestoredChatUUIDs,
+	})
+}
+
+// ブランチを別のメッセージに付け替える
+func (h *chatHandler) RebaseChat(c echo.Context) error {
+	chatUUID := c.Param("chat_uuid")
+	ctx := c.Request().Context()
+
+	slog.InfoContext(ctx, "RebaseChat リクエスト受信", "chat_uuid", chatUUID)
+
+	var req model.RebaseChatRequest
+	if err := c.Bind(&req); err != nil {
+		slog.ErrorContext(ctx, "リクエストボディのバインドエラー", "error", err)
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyBindRequestBodyFailed),
+		})
+	}
+
+	result, err := h.chatUsecase.RebaseChat(ctx, chatUUID, domainModel.RebaseChatParams{
+		SourceMessageUUID: req.SourceMessageUUID,
+		SelectedText:      req.SelectedText,
+		RangeStart:        req.RangeStart,
+		RangeEnd:          req.RangeEnd,
+		RegenerateContext: req.RegenerateContext,
+	})
+	if err != nil {
+		if errors.Is(err, domainModel.ErrInvalidRebaseTarget) {
+			slog.WarnContext(ctx, "不正なブランチの付け替え先", "chat_uuid", chatUUID, "source_message_uuid", req.SourceMessageUUID, "error", err)
+			return c.JSON(http.StatusBadRequest, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyInvalidRebaseTarget),
+			})
+		}
+		if errors.Is(err, domainModel.ErrInvalidForkSelection) {
+			slog.WarnContext(ctx, "付け替え先のメッセージにない選択範囲", "chat_uuid", chatUUID, "source_message_uuid", req.SourceMessageUUID, "error", err)
+			return c.JSON(http.StatusBadRequest, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyInvalidForkSelection),
+			})
+		}
+		slog.ErrorContext(ctx, "RebaseChat エラー", "error", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, model.RebaseChatResponse{
+		ChatUUID:             result.ChatUUID,
+		ParentChatUUID:       result.ParentChatUUID,
+		SourceMessageUUID:    result.SourceMessageUUID,
+		ContextSummary:       result.ContextSummary,
+		ContextPromptVersion: result.ContextPromptVersion,
+	})
+}
+
+// チャットのマージ履歴を取得する
+func (h *chatHandler) GetMergeHistory(c echo.Context) error {
+	chatUUID := c.Param("chat_uuid")
+	ctx := c.Request().Context()
+
+	events, err := h.chatUsecase.GetMergeHistory(ctx, chatUUID)
+	if err != nil {
+		slog.ErrorContext(ctx, "GetMergeHistory エラー", "error", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
+	}
+
+	res := make([]model.ChatMergeEventResponse, len(events))
+	for i, e := range events {
+		res[i] = model.ChatMergeEventResponse{
+			UUID:              e.UUID,
+			ChatUUID:          e.ChatUUID,
+			ParentChatUUID:    e.ParentChatUUID,
+			ReportMessageUUID: e.ReportMessageUUID,
+			Action:            e.Action,
+			PreviousStatus:    e.PreviousStatus,
+			Forced:            e.Forced,
+			UserUUID:          e.UserUUID,
+			CreatedAt:         e.CreatedAt,
+		}
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// 2つのブランチを比較する
+func (h *chatHandler) CompareBranches(c echo.Context) error {
+	chatUUID := c.Param("chat_uuid")
+	ctx := c.Request().Context()
+
+	slog.InfoContext(ctx, "CompareBranches リクエスト受信", "chat_uuid", chatUUID)
+
+	var req model.CompareBranchesRequest
+	if err := c.Bind(&req); err != nil {
+		slog.ErrorContext(ctx, "リクエストボディのバインドエラー", "error", err)
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyBindRequestBodyFailed),
+		})
+	}
+
+	userUUID, _ := c.Get("user_uuid").(string)
+
+	comparison, err := h.chatUsecase.CompareBranches(ctx, chatUUID, domainModel.CompareBranchesParams{
+		UserUUID:      userUUID,
+		OtherChatUUID: req.OtherChatUUID,
+		SaveNote:      req.SaveNote,
+	})
+	if err != nil {
+		if errors.Is(err, domainModel.ErrInvalidComparisonTarget) {
+			slog.WarnContext(ctx, "不正な比較対象のブランチ", "chat_uuid", chatUUID, "other_chat_uuid", req.OtherChatUUID, "error", err)
+			return c.JSON(http.StatusBadRequest, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyInvalidComparisonTarget),
+			})
+		}
+		if errors.Is(err, domainModel.ErrNoCommonAncestor) {
+			slog.WarnContext(ctx, "共通の祖先メッセージがないため比較結果を保存できません", "chat_uuid", chatUUID, "other_chat_uuid", req.OtherChatUUID)
+			return c.JSON(http.StatusBadRequest, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyNoCommonAncestor),
+			})
+		}
+		slog.ErrorContext(ctx, "CompareBranches エラー", "error", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
 	}
 
+	return c.JSON(http.StatusOK, mapBranchComparisonToResponse(comparison))
+}
+
+// チャットのメッセージに紐づく比較結果のメモを取得する
+func (h *chatHandler) GetBranchComparisons(c echo.Context) error {
+	chatUUID := c.Param("chat_uuid")
+	ctx := c.Request().Context()
+
+	comparisons, err := h.chatUsecase.GetBranchComparisons(ctx, chatUUID)
+	if err != nil {
+		slog.ErrorContext(ctx, "GetBranchComparisons エラー", "error", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
+	}
+
+	res := make([]model.BranchComparisonResponse, len(comparisons))
+	for i, comparison := range comparisons {
+		res[i] = mapBranchComparisonToResponse(comparison)
+	}
 	return c.JSON(http.StatusOK, res)
 }
 
@@ -394,8 +883,24 @@ func (h *chatHandler) CloseChat(c echo.Context) error {
 
 	slog.InfoContext(ctx, "CloseChat リクエスト受信", "chat_uuid", chatUUID)
 
-	uuid, err := h.chatUsecase.CloseChat(ctx, chatUUID)
+	var req model.CloseChatRequest
+	if err := c.Bind(&req); err != nil {
+		slog.ErrorContext(ctx, "リクエストボディのバインドエラー", "error", err)
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyBindRequestBodyFailed),
+		})
+	}
+
+	closedChatUUIDs, err := h.chatUsecase.CloseChat(ctx, chatUUID, req.Cascade)
 	if err != nil {
+		if errors.Is(err, domainModel.ErrInvalidChatStatusTransition) {
+			slog.WarnContext(ctx, "チャットをクローズできないステータスです", "chat_uuid", chatUUID)
+			return c.JSON(http.StatusConflict, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyInvalidStatusTransition),
+			})
+		}
 		slog.ErrorContext(ctx, "CloseChat エラー", "error", err)
 		return c.JSON(http.StatusInternalServerError, model.Response{
 			Status:  "error",
@@ -404,7 +909,8 @@ func (h *chatHandler) CloseChat(c echo.Context) error {
 	}
 
 	res := model.CloseChatResponse{
-		ChatUUID: uuid,
+		ChatUUID:        chatUUID,
+		ClosedChatUUIDs: closedChatUUIDs,
 	}
 
 	return c.JSON(http.StatusOK, res)
@@ -419,6 +925,13 @@ func (h *chatHandler) OpenChat(c echo.Context) error {
 
 	uuid, err := h.chatUsecase.OpenChat(ctx, chatUUID)
 	if err != nil {
+		if errors.Is(err, domainModel.ErrInvalidChatStatusTransition) {
+			slog.WarnContext(ctx, "チャットを再開できないステータスです", "chat_uuid", chatUUID)
+			return c.JSON(http.StatusConflict, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyInvalidStatusTransition),
+			})
+		}
 		slog.ErrorContext(ctx, "OpenChat エラー", "error", err)
 		return c.JSON(http.StatusInternalServerError, model.Response{
 			Status:  "error",
@@ -441,6 +954,7 @@ func mapMessageToResponse(m *domainModel.Message) model.MessageResponse {
 			SelectedText: f.SelectedText,
 			RangeStart:   f.RangeStart,
 			RangeEnd:     f.RangeEnd,
+			WholeMessage: f.WholeMessage,
 		}
 	}
 
@@ -450,11 +964,65 @@ func mapMessageToResponse(m *domainModel.Message) model.MessageResponse {
 	}
 
 	return model.MessageResponse{
-		UUID:           m.UUID,
-		Role:           m.Role,
-		Content:        m.Content,
-		Forks:          forks,
-		SourceChatUUID: m.SourceChatUUID,
-		MergeReports:   mergeReports,
+		UUID:              m.UUID,
+		Role:              m.Role,
+		Content:           m.Content,
+		Forks:             forks,
+		SourceChatUUID:    m.SourceChatUUID,
+		OriginMessageUUID: m.OriginMessageUUID,
+		OriginChatUUID:    m.OriginChatUUID,
+		MergeReports:      mergeReports,
+	}
+}
+
+func mapForkSuggestionToResponse(suggestion *domainModel.ForkSuggestion) model.ForkSuggestionResponse {
+	req := suggestion.PreviewRequest()
+	return model.ForkSuggestionResponse{
+		UUID:              suggestion.UUID,
+		TargetMessageUUID: req.TargetMessageUUID,
+		SelectedText:      req.SelectedText,
+		RangeStart:        req.RangeStart,
+		RangeEnd:          req.RangeEnd,
+		SuggestedTitle:    suggestion.SuggestedTitle,
+		GeneratedContext:  suggestion.GeneratedContext,
+		PromptVersion:     suggestion.PromptVersion,
+		CreatedAt:         suggestion.CreatedAt,
+	}
+}
+
+func mapBranchComparisonToResponse(comparison *domainModel.BranchComparison) model.BranchComparisonResponse {
+	positions := make([]model.BranchPositionResponse, len(comparison.Positions))
+	for i, p := range comparison.Positions {
+		positions[i] = model.BranchPositionResponse{
+			ChatUUID: p.ChatUUID,
+			Title:    p.Title,
+			Position: p.Position,
+		}
+	}
+	return model.BranchComparisonResponse{
+		UUID:              comparison.UUID,
+		ChatUUID:          comparison.ChatUUID,
+		OtherChatUUID:     comparison.OtherChatUUID,
+		AnchorChatUUID:    comparison.AnchorChatUUID,
+		AnchorMessageUUID: comparison.AnchorMessageUUID,
+		Positions:         positions,
+		Agreements:        comparison.Agreements,
+		Disagreements:     comparison.Disagreements,
+		Recommendation:    comparison.Recommendation,
+		PromptVersion:     comparison.PromptVersion,
+		UserUUID:          comparison.UserUUID,
+		CreatedAt:         comparison.CreatedAt,
+	}
+}
+
+// ストリームのエラーをイベントとして送信する処理 (SSEのヘッダー送信後はステータスコードで通知できないため)
+func writeStreamError(c echo.Context, enc *json.Encoder, message string) {
+	data := map[string]string{
+		"status":  "error",
+		"message": message,
 	}
+	fmt.Fprintf(c.Response(), "data: ")
+	enc.Encode(data)
+	fmt.Fprintf(c.Response(), "\n\n")
+	c.Response().Flush()
 }
@@ -7,10 +7,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -77,13 +79,37 @@ func (m *MockChatUsecase) GenerateForkPreview(ctx context.Context, chatUUID stri
 	return args.Get(0).(*model.ForkPreviewResponse), args.Error(1)
 }
 
+func (m *MockChatUsecase) SuggestForks(ctx context.Context, chatUUID string, messageUUID string) ([]*model.ForkSuggestion, error) {
+	args := m.Called(ctx, chatUUID, messageUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.ForkSuggestion), args.Error(1)
+}
+
+func (m *MockChatUsecase) GetForkSuggestions(ctx context.Context, chatUUID string, messageUUID string) ([]*model.ForkSuggestion, error) {
+	args := m.Called(ctx, chatUUID, messageUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.ForkSuggestion), args.Error(1)
+}
+
 func (m *MockChatUsecase) ForkChat(ctx context.Context, params model.ForkChatParams) (string, error) {
 	args := m.Called(ctx, params)
 	return args.String(0), args.Error(1)
 }
 
-func (m *MockChatUsecase) GetMergePreview(ctx context.Context, chatUUID string) (*model.MergePreview, error) {
-	args := m.Called(ctx, chatUUID)
+func (m *MockChatUsecase) BatchForkChat(ctx context.Context, params model.BatchForkChatParams) ([]string, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockChatUsecase) GetMergePreview(ctx context.Context, chatUUID string, targetChatUUID string) (*model.MergePreview, error) {
+	args := m.Called(ctx, chatUUID, targetChatUUID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -98,9 +124,60 @@ func (m *MockChatUsecase) MergeChat(ctx context.Context, chatUUID string, params
 	return args.Get(0).(*model.MergeChatResult), args.Error(1)
 }
 
-func (m *MockChatUsecase) CloseChat(ctx context.Context, chatUUID string) (string, error) {
+func (m *MockChatUsecase) UnmergeChat(ctx context.Context, chatUUID string, params model.UnmergeChatParams) (*model.UnmergeChatResult, error) {
+	args := m.Called(ctx, chatUUID, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.UnmergeChatResult), args.Error(1)
+}
+
+func (m *MockChatUsecase) RebaseChat(ctx context.Context, chatUUID string, params model.RebaseChatParams) (*model.RebaseChatResult, error) {
+	args := m.Called(ctx, chatUUID, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.RebaseChatResult), args.Error(1)
+}
+
+func (m *MockChatUsecase) CompareBranches(ctx context.Context, chatUUID string, params model.CompareBranchesParams) (*model.BranchComparison, error) {
+	args := m.Called(ctx, chatUUID, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.BranchComparison), args.Error(1)
+}
+
+func (m *MockChatUsecase) GetBranchComparisons(ctx context.Context, chatUUID string) ([]*model.BranchComparison, error) {
 	args := m.Called(ctx, chatUUID)
-	return args.String(0), args.Error(1)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.BranchComparison), args.Error(1)
+}
+
+func (m *MockChatUsecase) CherryPickMessages(ctx context.Context, chatUUID string, params model.CherryPickParams) (*model.CherryPickResult, error) {
+	args := m.Called(ctx, chatUUID, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CherryPickResult), args.Error(1)
+}
+
+func (m *MockChatUsecase) GetMergeHistory(ctx context.Context, chatUUID string) ([]*model.ChatMergeEvent, error) {
+	args := m.Called(ctx, chatUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.ChatMergeEvent), args.Error(1)
+}
+
+func (m *MockChatUsecase) CloseChat(ctx context.Context, chatUUID string, cascade bool) ([]string, error) {
+	args := m.Called(ctx, chatUUID, cascade)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
 }
 
 func TestChatHandler_FirstStreamChat(t *testing.T) {
@@ -291,7 +368,7 @@ func TestChatHandler_GetMessages(t *testing.T) {
 				}, nil)
 			},
 			wantStatus: http.StatusOK,
-			wantBody:   `[{"uuid":"msg-1","role":"user","content":"hello","forks":[{"chat_uuid":"child-chat","selected_text":"hello","range_start":0,"range_end":5}],"merge_reports":[]}]`,
+			wantBody:   `[{"uuid":"msg-1","role":"user","content":"hello","forks":[{"chat_uuid":"child-chat","selected_text":"hello","range_start":0,"range_end":5,"whole_message":false}],"merge_reports":[]}]`,
 		},
 		{
 			name: "異常系: Usecaseがエラーを返した場合",
@@ -387,6 +464,30 @@ func TestChatHandler_SendMessage(t *testing.T) {
 			wantStatus: http.StatusBadRequest,
 			wantBody:   `{"status":"error","message":"content が空です"}`,
 		},
+		{
+			name: "異常系: 他のメンバーが回答を生成中の場合409エラー",
+			args: args{
+				chatUUID: "chat-uuid",
+				body:     `{"content": "hello"}`,
+			},
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("SendMessage", mock.Anything, "chat-uuid", "hello").Return(nil, model.ErrChatGenerationInProgress)
+			},
+			wantStatus: http.StatusConflict,
+			wantBody:   `{"status":"error","message":"他のメンバーが回答を生成中です。完了してから再度お試しください"}`,
+		},
+		{
+			name: "異常系: クローズされたチャットの場合409エラー",
+			args: args{
+				chatUUID: "chat-uuid",
+				body:     `{"content": "hello"}`,
+			},
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("SendMessage", mock.Anything, "chat-uuid", "hello").Return(nil, fmt.Errorf("%w: chat-uuid", model.ErrChatNotOpen))
+			},
+			wantStatus: http.StatusConflict,
+			wantBody:   `{"status":"error","message":"クローズまたはマージ済みのチャットにはメッセージを追加できません。チャットを再開してからお試しください"}`,
+		},
 		{
 			name: "異常系: Usecaseがエラーを返した場合",
 			args: args{
@@ -529,10 +630,11 @@ func TestChatHandler_GenerateForkPreview(t *testing.T) {
 				}).Return(&model.ForkPreviewResponse{
 					SuggestedTitle:   "Suggested Title",
 					GeneratedContext: "Generated Context",
+					PromptVersion:    "fork_preview/ja/v1",
 				}, nil)
 			},
 			wantStatus: http.StatusOK,
-			wantBody:   `{"suggested_title":"Suggested Title","generated_context":"Generated Context"}`,
+			wantBody:   `{"suggested_title":"Suggested Title","generated_context":"Generated Context","prompt_version":"fork_preview/ja/v1"}`,
 		},
 		{
 			name: "異常系: リクエストボディが不正な場合",
@@ -558,6 +660,30 @@ func TestChatHandler_GenerateForkPreview(t *testing.T) {
 			wantStatus: http.StatusInternalServerError,
 			wantBody:   `{"status":"error","message":"genai error"}`,
 		},
+		{
+			name: "異常系: フォーク元のメッセージがこのチャットにない場合400",
+			args: args{
+				chatUUID: "chat-uuid",
+				body:     `{"target_message_uuid": "other-msg"}`,
+			},
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("GenerateForkPreview", mock.Anything, "chat-uuid", mock.Anything).Return(nil, fmt.Errorf("%w: target message not found in chat", model.ErrInvalidForkTarget))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"status":"error","message":"フォーク元のメッセージが不正です (このチャットのユーザー・AIのメッセージを指定してください)"}`,
+		},
+		{
+			name: "異常系: 選択した文章がメッセージにない場合400",
+			args: args{
+				chatUUID: "chat-uuid",
+				body:     `{"target_message_uuid": "msg-uuid", "selected_text": "missing", "range_start": 0, "range_end": 7}`,
+			},
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("GenerateForkPreview", mock.Anything, "chat-uuid", mock.Anything).Return(nil, model.ErrInvalidForkSelection)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"status":"error","message":"選択した文章がフォーク元のメッセージに見つかりません"}`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -689,12 +815,112 @@ func TestChatHandler_ForkChat(t *testing.T) {
 	}
 }
 
+func TestChatHandler_BatchForkChat(t *testing.T) {
+	type mocks struct {
+		chatUsecase *MockChatUsecase
+	}
+	tests := []struct {
+		name       string
+		body       string
+		setupMock  func(m *mocks)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "正常系: 複数のブランチをまとめてフォークできること",
+			body: `{"target_message_uuid":"msg-uuid","forks":[{"selected_text":"a","range_start":0,"range_end":1,"title":"A","context_summary":"Summary A"},{"title":"B","context_summary":"Summary B"}],"generate_first_answers":true}`,
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("BatchForkChat", mock.Anything, model.BatchForkChatParams{
+					ParentChatUUID:    "parent-chat-uuid",
+					TargetMessageUUID: "msg-uuid",
+					Forks: []model.BatchForkItem{
+						{SelectedText: "a", RangeStart: 0, RangeEnd: 1, Title: "A", ContextSummary: "Summary A"},
+						{Title: "B", ContextSummary: "Summary B"},
+					},
+					GenerateFirstAnswers: true,
+				}).Return([]string{"chat-a", "chat-b"}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"new_chat_ids":["chat-a","chat-b"],"message":"子チャットを作成しました"}`,
+		},
+		{
+			name: "異常系: ブランチの指定が不正な場合400",
+			body: `{"target_message_uuid":"msg-uuid","forks":[]}`,
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("BatchForkChat", mock.Anything, mock.Anything).Return(nil, model.ErrInvalidBatchFork)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"status":"error","message":"まとめてフォークするブランチの指定が不正です (1件以上10件以下のブランチを指定してください)"}`,
+		},
+		{
+			name: "異常系: 選択した文章がメッセージにない場合400",
+			body: `{"target_message_uuid":"msg-uuid","forks":[{"selected_text":"missing","title":"A"}]}`,
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("BatchForkChat", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("1件目のブランチ: %w", model.ErrInvalidForkSelection))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"status":"error","message":"選択した文章がフォーク元のメッセージに見つかりません"}`,
+		},
+		{
+			name: "異常系: open 以外のチャットからフォークする場合409",
+			body: `{"target_message_uuid":"msg-uuid","forks":[{"title":"A"}]}`,
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("BatchForkChat", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w: parent-chat-uuid", model.ErrChatNotOpen))
+			},
+			wantStatus: http.StatusConflict,
+			wantBody:   `{"status":"error","message":"クローズまたはマージ済みのチャットからはフォークできません。チャットを再開してからお試しください"}`,
+		},
+		{
+			name:       "異常系: リクエストボディが不正な場合400",
+			body:       `{"forks":"invalid"}`,
+			setupMock:  func(m *mocks) {},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"status":"error","message":"リクエストボディのバインドに失敗しました"}`,
+		},
+		{
+			name: "異常系: Usecaseエラー",
+			body: `{"forks":[{"title":"A"}]}`,
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("BatchForkChat", mock.Anything, mock.Anything).Return(nil, errors.New("usecase error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `{"status":"error","message":"usecase error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mocks{
+				chatUsecase: &MockChatUsecase{},
+			}
+			tt.setupMock(m)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/chats/parent-chat-uuid/fork/batch", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/api/chats/:chat_uuid/fork/batch")
+			c.SetParamNames("chat_uuid")
+			c.SetParamValues("parent-chat-uuid")
+
+			h := NewChatHandler(m.chatUsecase)
+			err := h.BatchForkChat(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+			m.chatUsecase.AssertExpectations(t)
+		})
+	}
+}
+
 func TestChatHandler_GetMergePreview(t *testing.T) {
 	type mocks struct {
 		chatUsecase *MockChatUsecase
 	}
 	type args struct {
 		chatUUID string
+		body     string
 	}
 	tests := []struct {
 		name       string
@@ -709,12 +935,48 @@ func TestChatHandler_GetMergePreview(t *testing.T) {
 				chatUUID: "chat-uuid",
 			},
 			setupMock: func(m *mocks) {
-				m.chatUsecase.On("GetMergePreview", mock.Anything, "chat-uuid").Return(&model.MergePreview{
+				m.chatUsecase.On("GetMergePreview", mock.Anything, "chat-uuid", "").Return(&model.MergePreview{
+					SuggestedSummary: "summary",
+					PromptVersion:    "merge_preview/ja/v1",
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"suggested_summary":"summary","prompt_version":"merge_preview/ja/v1","target_chat_uuid":"","divergence":null}`,
+		},
+		{
+			name: "正常系: マージ先を指定するとフォーク後の進み具合が返ること",
+			args: args{
+				chatUUID: "chat-uuid",
+				body:     `{"parent_chat_uuid":"grandparent-uuid"}`,
+			},
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("GetMergePreview", mock.Anything, "chat-uuid", "grandparent-uuid").Return(&model.MergePreview{
 					SuggestedSummary: "summary",
+					PromptVersion:    "merge_preview/ja/v2",
+					TargetChatUUID:   "grandparent-uuid",
+					Divergence: &model.MergeDivergence{
+						AnchorMessageUUID:    "anchor-uuid",
+						NewMessages:          3,
+						NewAssistantMessages: 1,
+						MergedReports:        1,
+						Stale:                true,
+					},
 				}, nil)
 			},
 			wantStatus: http.StatusOK,
-			wantBody:   `{"suggested_summary":"summary"}`,
+			wantBody:   `{"suggested_summary":"summary","prompt_version":"merge_preview/ja/v2","target_chat_uuid":"grandparent-uuid","divergence":{"anchor_message_uuid":"anchor-uuid","new_messages":3,"new_assistant_messages":1,"merged_reports":1,"stale":true}}`,
+		},
+		{
+			name: "異常系: マージ先が祖先のチャットでない場合は400になること",
+			args: args{
+				chatUUID: "chat-uuid",
+				body:     `{"parent_chat_uuid":"other-uuid"}`,
+			},
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("GetMergePreview", mock.Anything, "chat-uuid", "other-uuid").Return(nil, fmt.Errorf("%w: other-uuid", model.ErrInvalidMergeTarget))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"status":"error","message":"マージ先には子チャットの祖先のチャットを指定してください"}`,
 		},
 		{
 			name: "異常系: Usecaseがエラーを返した場合",
@@ -722,7 +984,7 @@ func TestChatHandler_GetMergePreview(t *testing.T) {
 				chatUUID: "error-uuid",
 			},
 			setupMock: func(m *mocks) {
-				m.chatUsecase.On("GetMergePreview", mock.Anything, "error-uuid").Return(nil, errors.New("usecase error"))
+				m.chatUsecase.On("GetMergePreview", mock.Anything, "error-uuid", "").Return(nil, errors.New("usecase error"))
 			},
 			wantStatus: http.StatusInternalServerError,
 			wantBody:   `{"status":"error","message":"usecase error"}`,
@@ -731,7 +993,8 @@ func TestChatHandler_GetMergePreview(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			e := echo.New()
-			req := httptest.NewRequest(http.MethodGet, "/api/chats/"+tt.args.chatUUID+"/merge/preview", nil)
+			req := httptest.NewRequest(http.MethodPost, "/api/chats/"+tt.args.chatUUID+"/merge/preview", strings.NewReader(tt.args.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 			c.SetPath("/api/chats/:chat_uuid/merge/preview")
@@ -781,10 +1044,42 @@ func TestChatHandler_MergeChat(t *testing.T) {
 				}).Return(&model.MergeChatResult{
 					ReportMessageID: "report-msg-id",
 					SummaryContent:  "summary",
+					TargetChatUUID:  "parent-chat-uuid",
+					MergedChatUUIDs: []string{"child-chat-uuid"},
 				}, nil)
 			},
 			wantStatus: http.StatusOK,
-			wantBody:   `{"report_message_id":"report-msg-id","summary_content":"summary"}`,
+			wantBody:   `{"report_message_id":"report-msg-id","summary_content":"summary","target_chat_uuid":"parent-chat-uuid","merged_chat_uuids":["child-chat-uuid"],"acknowledgment_message_id":""}`,
+		},
+		{
+			name: "異常系: マージ先が祖先のチャットではない場合は400を返すこと",
+			args: args{
+				chatUUID: "child-chat-uuid",
+				body:     `{"parent_chat_uuid": "sibling-chat-uuid", "summary_content": "summary", "include_descendants": true}`,
+			},
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("MergeChat", mock.Anything, "child-chat-uuid", model.MergeChatParams{
+					ParentChatUUID:     "sibling-chat-uuid",
+					SummaryContent:     "summary",
+					IncludeDescendants: true,
+				}).Return(nil, fmt.Errorf("%w: sibling-chat-uuid", model.ErrInvalidMergeTarget))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"status":"error","message":"マージ先には子チャットの祖先のチャットを指定してください"}`,
+		},
+		{
+			name: "異常系: マージ先がクローズされている場合は409を返すこと",
+			args: args{
+				chatUUID: "child-chat-uuid",
+				body:     `{"summary_content": "summary"}`,
+			},
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("MergeChat", mock.Anything, "child-chat-uuid", model.MergeChatParams{
+					SummaryContent: "summary",
+				}).Return(nil, fmt.Errorf("%w: parent-chat-uuid", model.ErrChatNotOpen))
+			},
+			wantStatus: http.StatusConflict,
+			wantBody:   `{"status":"error","message":"クローズまたはマージ済みのチャットにはメッセージを追加できません。チャットを再開してからお試しください"}`,
 		},
 		{
 			name: "異常系: リクエストボディが不正な場合",
@@ -837,12 +1132,13 @@ func TestChatHandler_MergeChat(t *testing.T) {
 	}
 }
 
-func TestChatHandler_CloseChat(t *testing.T) {
+func TestChatHandler_UnmergeChat(t *testing.T) {
 	type mocks struct {
 		chatUsecase *MockChatUsecase
 	}
 	type args struct {
 		chatUUID string
+		body     string
 	}
 	tests := []struct {
 		name       string
@@ -852,23 +1148,57 @@ func TestChatHandler_CloseChat(t *testing.T) {
 		wantBody   string
 	}{
 		{
-			name: "正常系: チャットクローズ成功",
+			name: "正常系: マージ取り消しが成功すること",
 			args: args{
-				chatUUID: "chat-uuid",
+				chatUUID: "child-chat-uuid",
+				body:     `{"force": true}`,
 			},
 			setupMock: func(m *mocks) {
-				m.chatUsecase.On("CloseChat", mock.Anything, "chat-uuid").Return("chat-uuid", nil)
+				m.chatUsecase.On("UnmergeChat", mock.Anything, "child-chat-uuid", model.UnmergeChatParams{
+					UserUUID: "user-uuid",
+					Force:    true,
+				}).Return(&model.UnmergeChatResult{
+					ChatUUID:          "child-chat-uuid",
+					Status:            "open",
+					ReportMessageUUID: "report-msg-id",
+					RestoredChatUUIDs: []string{"child-chat-uuid"},
+				}, nil)
 			},
 			wantStatus: http.StatusOK,
-			wantBody:   `{"chat_uuid":"chat-uuid"}`,
+			wantBody:   `{"chat_uuid":"child-chat-uuid","status":"open","report_message_uuid":"report-msg-id","restored_chat_uuids":["child-chat-uuid"]}`,
 		},
 		{
-			name: "異常系: Usecaseエラー",
+			name: "異常系: マージされていないチャットの場合は409を返すこと",
 			args: args{
-				chatUUID: "error-uuid",
+				chatUUID: "child-chat-uuid",
+				body:     `{}`,
+			},
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("UnmergeChat", mock.Anything, "child-chat-uuid", mock.Anything).Return(nil, fmt.Errorf("%w: child-chat-uuid", model.ErrChatNotMerged))
+			},
+			wantStatus: http.StatusConflict,
+			wantBody:   `{"status":"error","message":"このチャットはマージされていません"}`,
+		},
+		{
+			name: "異常系: マージレポートを参照した回答がある場合は409を返すこと",
+			args: args{
+				chatUUID: "child-chat-uuid",
+				body:     `{}`,
+			},
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("UnmergeChat", mock.Anything, "child-chat-uuid", mock.Anything).Return(nil, fmt.Errorf("%w: report-msg-id", model.ErrMergeReportInUse))
+			},
+			wantStatus: http.StatusConflict,
+			wantBody:   `{"status":"error","message":"マージ後に親チャットで回答が生成されているため取り消せません。強制的に取り消す場合は force を指定してください"}`,
+		},
+		{
+			name: "異常系: Usecaseがエラーを返した場合",
+			args: args{
+				chatUUID: "child-chat-uuid",
+				body:     `{}`,
 			},
 			setupMock: func(m *mocks) {
-				m.chatUsecase.On("CloseChat", mock.Anything, "error-uuid").Return("", errors.New("usecase error"))
+				m.chatUsecase.On("UnmergeChat", mock.Anything, "child-chat-uuid", mock.Anything).Return(nil, errors.New("usecase error"))
 			},
 			wantStatus: http.StatusInternalServerError,
 			wantBody:   `{"status":"error","message":"usecase error"}`,
@@ -877,12 +1207,14 @@ func TestChatHandler_CloseChat(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			e := echo.New()
-			req := httptest.NewRequest(http.MethodPost, "/api/chats/"+tt.args.chatUUID+"/close", nil)
+			req := httptest.NewRequest(http.MethodPost, "/api/chats/"+tt.args.chatUUID+"/unmerge", strings.NewReader(tt.args.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
-			c.SetPath("/api/chats/:chat_uuid/close")
+			c.SetPath("/api/chats/:chat_uuid/unmerge")
 			c.SetParamNames("chat_uuid")
 			c.SetParamValues(tt.args.chatUUID)
+			c.Set("user_uuid", "user-uuid")
 
 			m := &mocks{
 				chatUsecase: &MockChatUsecase{},
@@ -890,7 +1222,7 @@ func TestChatHandler_CloseChat(t *testing.T) {
 			tt.setupMock(m)
 
 			h := NewChatHandler(m.chatUsecase)
-			err := h.CloseChat(c)
+			err := h.UnmergeChat(c)
 
 			assert.NoError(t, err)
 			assert.Equal(t, tt.wantStatus, rec.Code)
@@ -899,17 +1231,13 @@ func TestChatHandler_CloseChat(t *testing.T) {
 	}
 }
 
-func (m *MockChatUsecase) OpenChat(ctx context.Context, chatUUID string) (string, error) {
-	args := m.Called(ctx, chatUUID)
-	return args.String(0), args.Error(1)
-}
-
-func TestChatHandler_OpenChat(t *testing.T) {
+func TestChatHandler_RebaseChat(t *testing.T) {
 	type mocks struct {
 		chatUsecase *MockChatUsecase
 	}
 	type args struct {
 		chatUUID string
+		body     string
 	}
 	tests := []struct {
 		name       string
@@ -919,28 +1247,521 @@ func TestChatHandler_OpenChat(t *testing.T) {
 		wantBody   string
 	}{
 		{
-			name: "正常系: チャットオープンが成功すること",
+			name: "正常系: ブランチの付け替えが成功すること",
 			args: args{
-				chatUUID: "chat-uuid",
+				chatUUID: "child-chat-uuid",
+				body:     `{"source_message_uuid": "msg-uuid", "selected_text": "topic", "range_start": 1, "range_end": 6, "regenerate_context": true}`,
 			},
 			setupMock: func(m *mocks) {
-				m.chatUsecase.On("OpenChat", mock.Anything, "chat-uuid").Return("chat-uuid", nil)
+				m.chatUsecase.On("RebaseChat", mock.Anything, "child-chat-uuid", model.RebaseChatParams{
+					SourceMessageUUID: "msg-uuid",
+					SelectedText:      "topic",
+					RangeStart:        1,
+					RangeEnd:          6,
+					RegenerateContext: true,
+				}).Return(&model.RebaseChatResult{
+					ChatUUID:             "child-chat-uuid",
+					ParentChatUUID:       "other-chat-uuid",
+					SourceMessageUUID:    "msg-uuid",
+					ContextSummary:       "新しい文脈",
+					ContextPromptVersion: "fork_preview/ja/v2",
+				}, nil)
 			},
 			wantStatus: http.StatusOK,
-			wantBody:   `{"chat_uuid":"chat-uuid"}`,
+			wantBody:   `{"chat_uuid":"child-chat-uuid","parent_chat_uuid":"other-chat-uuid","source_message_uuid":"msg-uuid","context_summary":"新しい文脈","context_prompt_version":"fork_preview/ja/v2"}`,
+		},
+		{
+			name: "異常系: 不正な付け替え先の場合は400を返すこと",
+			args: args{
+				chatUUID: "child-chat-uuid",
+				body:     `{"source_message_uuid": "descendant-msg-uuid"}`,
+			},
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("RebaseChat", mock.Anything, "child-chat-uuid", mock.Anything).Return(nil, fmt.Errorf("%w: 子孫のチャットのメッセージには付け替えられません", model.ErrInvalidRebaseTarget))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"status":"error","message":"ブランチの付け替え先が不正です (同じプロジェクトの、自身や子孫以外のチャットのメッセージを指定してください。ルートやマージ済みのチャットは移動できません)"}`,
+		},
+		{
+			name: "異常系: 選択した文章が付け替え先のメッセージにない場合は400を返すこと",
+			args: args{
+				chatUUID: "child-chat-uuid",
+				body:     `{"source_message_uuid": "msg-uuid", "selected_text": "missing", "range_start": 0, "range_end": 7}`,
+			},
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("RebaseChat", mock.Anything, "child-chat-uuid", mock.Anything).Return(nil, fmt.Errorf("%w: 選択した文章がフォーク元のメッセージにありません", model.ErrInvalidForkSelection))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"status":"error","message":"選択した文章がフォーク元のメッセージに見つかりません"}`,
 		},
 		{
 			name: "異常系: Usecaseがエラーを返した場合",
 			args: args{
-				chatUUID: "error-uuid",
+				chatUUID: "child-chat-uuid",
+				body:     `{"source_message_uuid": "msg-uuid"}`,
 			},
 			setupMock: func(m *mocks) {
-				m.chatUsecase.On("OpenChat", mock.Anything, "error-uuid").Return("", errors.New("usecase error"))
+				m.chatUsecase.On("RebaseChat", mock.Anything, "child-chat-uuid", mock.Anything).Return(nil, errors.New("usecase error"))
 			},
 			wantStatus: http.StatusInternalServerError,
 			wantBody:   `{"status":"error","message":"usecase error"}`,
 		},
 	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/chats/"+tt.args.chatUUID+"/rebase", strings.NewReader(tt.args.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/api/chats/:chat_uuid/rebase")
+			c.SetParamNames("chat_uuid")
+			c.SetParamValues(tt.args.chatUUID)
+
+			m := &mocks{
+				chatUsecase: &MockChatUsecase{},
+			}
+			tt.setupMock(m)
+
+			h := NewChatHandler(m.chatUsecase)
+			err := h.RebaseChat(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestChatHandler_SuggestForks(t *testing.T) {
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		method     string
+		setupMock  func(m *MockChatUsecase)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:   "正常系: フォーク候補をフォークプレビューのリクエストと同じ形で返すこと",
+			method: http.MethodPost,
+			setupMock: func(m *MockChatUsecase) {
+				m.On("SuggestForks", mock.Anything, "chat-uuid", "msg-uuid").Return([]*model.ForkSuggestion{
+					{UUID: "suggestion-1", ChatUUID: "chat-uuid", MessageUUID: "msg-uuid", SelectedText: "性能について", RangeStart: 2, RangeEnd: 8, SuggestedTitle: "性能", GeneratedContext: "性能を深掘り", PromptVersion: "fork_suggestion/ja/v1", CreatedAt: createdAt},
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `[{"uuid":"suggestion-1","target_message_uuid":"msg-uuid","selected_text":"性能について","range_start":2,"range_end":8,"suggested_title":"性能","generated_context":"性能を深掘り","prompt_version":"fork_suggestion/ja/v1","created_at":"2025-01-01T10:00:00Z"}]`,
+		},
+		{
+			name:   "正常系: 抽出済みのフォーク候補を取得すること",
+			method: http.MethodGet,
+			setupMock: func(m *MockChatUsecase) {
+				m.On("GetForkSuggestions", mock.Anything, "chat-uuid", "msg-uuid").Return([]*model.ForkSuggestion{}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `[]`,
+		},
+		{
+			name:   "異常系: 不正なメッセージの場合は400を返すこと",
+			method: http.MethodPost,
+			setupMock: func(m *MockChatUsecase) {
+				m.On("SuggestForks", mock.Anything, "chat-uuid", "msg-uuid").Return(nil, fmt.Errorf("%w: AIの回答ではありません", model.ErrInvalidForkSuggestionTarget))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"status":"error","message":"フォーク候補を抽出するメッセージが不正です (このチャットのAIの回答を指定してください)"}`,
+		},
+		{
+			name:   "異常系: Usecaseがエラーを返した場合",
+			method: http.MethodPost,
+			setupMock: func(m *MockChatUsecase) {
+				m.On("SuggestForks", mock.Anything, "chat-uuid", "msg-uuid").Return(nil, errors.New("usecase error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `{"status":"error","message":"usecase error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(tt.method, "/api/chats/chat-uuid/messages/msg-uuid/fork-suggestions", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/api/chats/:chat_uuid/messages/:message_uuid/fork-suggestions")
+			c.SetParamNames("chat_uuid", "message_uuid")
+			c.SetParamValues("chat-uuid", "msg-uuid")
+
+			m := &MockChatUsecase{}
+			tt.setupMock(m)
+
+			h := NewChatHandler(m)
+			var err error
+			if tt.method == http.MethodGet {
+				err = h.GetForkSuggestions(c)
+			} else {
+				err = h.SuggestForks(c)
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestChatHandler_CherryPickMessages(t *testing.T) {
+	type mocks struct {
+		chatUsecase *MockChatUsecase
+	}
+	type args struct {
+		chatUUID string
+		body     string
+	}
+	originMessageUUID := "sibling-answer-uuid"
+	originChatUUID := "sibling-chat-uuid"
+	tests := []struct {
+		name       string
+		args       args
+		setupMock  func(m *mocks)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "正常系: メッセージの取り込みが成功すること",
+			args: args{
+				chatUUID: "chat-uuid",
+				body:     `{"message_uuids": ["sibling-answer-uuid"]}`,
+			},
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("CherryPickMessages", mock.Anything, "chat-uuid", model.CherryPickParams{
+					MessageUUIDs: []string{"sibling-answer-uuid"},
+				}).Return(&model.CherryPickResult{
+					ChatUUID: "chat-uuid",
+					Messages: []*model.Message{
+						{UUID: "copy-uuid", Role: "assistant", Content: "良い回答", OriginMessageUUID: &originMessageUUID, OriginChatUUID: &originChatUUID},
+					},
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"chat_uuid":"chat-uuid","messages":[{"uuid":"copy-uuid","role":"assistant","content":"良い回答","forks":[],"origin_message_uuid":"sibling-answer-uuid","origin_chat_uuid":"sibling-chat-uuid","merge_reports":[]}]}`,
+		},
+		{
+			name: "異常系: 不正なメッセージの場合は400を返すこと",
+			args: args{
+				chatUUID: "chat-uuid",
+				body:     `{"message_uuids": ["report-uuid"]}`,
+			},
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("CherryPickMessages", mock.Anything, "chat-uuid", mock.Anything).Return(nil, fmt.Errorf("%w: マージレポートは取り込めません", model.ErrInvalidCherryPick))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"status":"error","message":"取り込むメッセージが不正です (同じプロジェクトの別のチャットのユーザー・AIのメッセージを指定してください)"}`,
+		},
+		{
+			name: "異常系: 回答の生成中の場合は409を返すこと",
+			args: args{
+				chatUUID: "chat-uuid",
+				body:     `{"message_uuids": ["sibling-answer-uuid"]}`,
+			},
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("CherryPickMessages", mock.Anything, "chat-uuid", mock.Anything).Return(nil, model.ErrChatGenerationInProgress)
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name: "異常系: Usecaseがエラーを返した場合",
+			args: args{
+				chatUUID: "chat-uuid",
+				body:     `{"message_uuids": ["sibling-answer-uuid"]}`,
+			},
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("CherryPickMessages", mock.Anything, "chat-uuid", mock.Anything).Return(nil, errors.New("usecase error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `{"status":"error","message":"usecase error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/chats/"+tt.args.chatUUID+"/cherry-pick", strings.NewReader(tt.args.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/api/chats/:chat_uuid/cherry-pick")
+			c.SetParamNames("chat_uuid")
+			c.SetParamValues(tt.args.chatUUID)
+
+			m := &mocks{
+				chatUsecase: &MockChatUsecase{},
+			}
+			tt.setupMock(m)
+
+			h := NewChatHandler(m.chatUsecase)
+			err := h.CherryPickMessages(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestChatHandler_CompareBranches(t *testing.T) {
+	type mocks struct {
+		chatUsecase *MockChatUsecase
+	}
+	type args struct {
+		chatUUID string
+		body     string
+	}
+	anchorChat := "root-chat"
+	anchorMessage := "root-msg"
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		args       args
+		setupMock  func(m *mocks)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "正常系: ブランチの比較結果を返すこと",
+			args: args{
+				chatUUID: "branch-a",
+				body:     `{"other_chat_uuid": "branch-b", "save_note": true}`,
+			},
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("CompareBranches", mock.Anything, "branch-a", model.CompareBranchesParams{
+					UserUUID:      "user-uuid",
+					OtherChatUUID: "branch-b",
+					SaveNote:      true,
+				}).Return(&model.BranchComparison{
+					UUID:              "comparison-uuid",
+					ChatUUID:          "branch-a",
+					OtherChatUUID:     "branch-b",
+					AnchorChatUUID:    &anchorChat,
+					AnchorMessageUUID: &anchorMessage,
+					Positions: []model.BranchPosition{
+						{ChatUUID: "branch-a", Title: "案A", Position: "Aを採用"},
+						{ChatUUID: "branch-b", Title: "案B", Position: "Bを採用"},
+					},
+					Agreements:     []string{"目的は同じ"},
+					Disagreements:  []string{},
+					Recommendation: "Aを推奨",
+					PromptVersion:  "branch_comparison/ja/v1",
+					CreatedAt:      createdAt,
+				}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"uuid":"comparison-uuid","chat_uuid":"branch-a","other_chat_uuid":"branch-b","anchor_chat_uuid":"root-chat","anchor_message_uuid":"root-msg","positions":[{"chat_uuid":"branch-a","title":"案A","position":"Aを採用"},{"chat_uuid":"branch-b","title":"案B","position":"Bを採用"}],"agreements":["目的は同じ"],"disagreements":[],"recommendation":"Aを推奨","prompt_version":"branch_comparison/ja/v1","user_uuid":null,"created_at":"2025-01-01T10:00:00Z"}`,
+		},
+		{
+			name: "異常系: 不正な比較対象の場合は400を返すこと",
+			args: args{
+				chatUUID: "branch-a",
+				body:     `{"other_chat_uuid": "branch-a"}`,
+			},
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("CompareBranches", mock.Anything, "branch-a", mock.Anything).Return(nil, fmt.Errorf("%w: 同じチャット", model.ErrInvalidComparisonTarget))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"status":"error","message":"比較するブランチが不正です (同じプロジェクトの別のチャットを指定してください)"}`,
+		},
+		{
+			name: "異常系: 共通の祖先がなく保存できない場合は400を返すこと",
+			args: args{
+				chatUUID: "branch-a",
+				body:     `{"other_chat_uuid": "other-root", "save_note": true}`,
+			},
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("CompareBranches", mock.Anything, "branch-a", mock.Anything).Return(nil, fmt.Errorf("%w: branch-a と other-root", model.ErrNoCommonAncestor))
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"status":"error","message":"共通の祖先メッセージがないため、比較結果をメモとして保存できません"}`,
+		},
+		{
+			name: "異常系: Usecaseがエラーを返した場合",
+			args: args{
+				chatUUID: "branch-a",
+				body:     `{"other_chat_uuid": "branch-b"}`,
+			},
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("CompareBranches", mock.Anything, "branch-a", mock.Anything).Return(nil, errors.New("usecase error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `{"status":"error","message":"usecase error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/chats/"+tt.args.chatUUID+"/compare", strings.NewReader(tt.args.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/api/chats/:chat_uuid/compare")
+			c.SetParamNames("chat_uuid")
+			c.SetParamValues(tt.args.chatUUID)
+			c.Set("user_uuid", "user-uuid")
+
+			m := &mocks{
+				chatUsecase: &MockChatUsecase{},
+			}
+			tt.setupMock(m)
+
+			h := NewChatHandler(m.chatUsecase)
+			err := h.CompareBranches(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestChatHandler_CloseChat(t *testing.T) {
+	type mocks struct {
+		chatUsecase *MockChatUsecase
+	}
+	type args struct {
+		chatUUID string
+		body     string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		setupMock  func(m *mocks)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "正常系: チャットクローズ成功",
+			args: args{
+				chatUUID: "chat-uuid",
+			},
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("CloseChat", mock.Anything, "chat-uuid", false).Return([]string{"chat-uuid"}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"chat_uuid":"chat-uuid","closed_chat_uuids":["chat-uuid"]}`,
+		},
+		{
+			name: "正常系: cascade を指定すると子孫のブランチもクローズされること",
+			args: args{
+				chatUUID: "chat-uuid",
+				body:     `{"cascade":true}`,
+			},
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("CloseChat", mock.Anything, "chat-uuid", true).Return([]string{"chat-uuid", "child-uuid"}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"chat_uuid":"chat-uuid","closed_chat_uuids":["chat-uuid","child-uuid"]}`,
+		},
+		{
+			name: "異常系: マージ済みのチャットはクローズできず409になること",
+			args: args{
+				chatUUID: "merged-uuid",
+			},
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("CloseChat", mock.Anything, "merged-uuid", false).Return(nil, fmt.Errorf("%w: merged から closed には遷移できません", model.ErrInvalidChatStatusTransition))
+			},
+			wantStatus: http.StatusConflict,
+			wantBody:   `{"status":"error","message":"このチャットはそのステータスに変更できません (マージ済みのチャットはマージを取り消して再開してください)"}`,
+		},
+		{
+			name: "異常系: Usecaseエラー",
+			args: args{
+				chatUUID: "error-uuid",
+			},
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("CloseChat", mock.Anything, "error-uuid", false).Return(nil, errors.New("usecase error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `{"status":"error","message":"usecase error"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/chats/"+tt.args.chatUUID+"/close", strings.NewReader(tt.args.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/api/chats/:chat_uuid/close")
+			c.SetParamNames("chat_uuid")
+			c.SetParamValues(tt.args.chatUUID)
+
+			m := &mocks{
+				chatUsecase: &MockChatUsecase{},
+			}
+			tt.setupMock(m)
+
+			h := NewChatHandler(m.chatUsecase)
+			err := h.CloseChat(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func (m *MockChatUsecase) OpenChat(ctx context.Context, chatUUID string) (string, error) {
+	args := m.Called(ctx, chatUUID)
+	return args.String(0), args.Error(1)
+}
+
+func TestChatHandler_OpenChat(t *testing.T) {
+	type mocks struct {
+		chatUsecase *MockChatUsecase
+	}
+	type args struct {
+		chatUUID string
+	}
+	tests := []struct {
+		name       string
+		args       args
+		setupMock  func(m *mocks)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "正常系: チャットオープンが成功すること",
+			args: args{
+				chatUUID: "chat-uuid",
+			},
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("OpenChat", mock.Anything, "chat-uuid").Return("chat-uuid", nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"chat_uuid":"chat-uuid"}`,
+		},
+		{
+			name: "異常系: Usecaseがエラーを返した場合",
+			args: args{
+				chatUUID: "error-uuid",
+			},
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("OpenChat", mock.Anything, "error-uuid").Return("", errors.New("usecase error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `{"status":"error","message":"usecase error"}`,
+		},
+		{
+			name: "異常系: マージ済みのチャットは再開できず409になること",
+			args: args{
+				chatUUID: "merged-uuid",
+			},
+			setupMock: func(m *mocks) {
+				m.chatUsecase.On("OpenChat", mock.Anything, "merged-uuid").Return("", fmt.Errorf("%w: merged から open には遷移できません", model.ErrInvalidChatStatusTransition))
+			},
+			wantStatus: http.StatusConflict,
+			wantBody:   `{"status":"error","message":"このチャットはそのステータスに変更できません (マージ済みのチャットはマージを取り消して再開してください)"}`,
+		},
+	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			e := echo.New()
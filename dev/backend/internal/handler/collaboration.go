@@ -0,0 +1,188 @@
+package handler
+
+import (
+	domainModel "backend/internal/domain/model"
+	"backend/internal/domain/usecase"
+	"backend/internal/handler/model"
+	"backend/internal/i18n"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 接続を維持するためにコメントを送信する間隔
+const chatEventKeepAliveInterval = 30 * time.Second
+
+type collaborationHandler struct {
+	collaborationUsecase usecase.CollaborationUsecase
+}
+
+// collaborationHandlerの新しいインスタンスを作成する処理
+func NewCollaborationHandler(collaborationUsecase usecase.CollaborationUsecase) *collaborationHandler {
+	return &collaborationHandler{
+		collaborationUsecase: collaborationUsecase,
+	}
+}
+
+// ドメインモデルの閲覧者一覧をレスポンスに変換する処理
+func toChatPresenceResponses(presence []*domainModel.ChatPresence) []model.ChatPresenceResponse {
+	res := make([]model.ChatPresenceResponse, len(presence))
+	for i, p := range presence {
+		res[i] = model.ChatPresenceResponse{
+			ChatUUID: p.ChatUUID,
+			UserUUID: p.UserUUID,
+			UserName: p.UserName,
+			Typing:   p.Typing,
+			JoinedAt: p.JoinedAt,
+		}
+	}
+	return res
+}
+
+// ドメインモデルのイベントをレスポンスに変換する処理
+func toChatEventResponse(event domainModel.ChatEvent) model.ChatEventResponse {
+	res := model.ChatEventResponse{
+		Type:     event.Type,
+		ChatUUID: event.ChatUUID,
+	}
+	if event.Message != nil {
+		message := mapMessageToResponse(event.Message)
+		res.Message = &message
+	}
+	if event.Type == domainModel.ChatEventPresence {
+		res.Presence = toChatPresenceResponses(event.Presence)
+	}
+	return res
+}
+
+// チャットのイベント (新しいメッセージ・閲覧者・回答の生成状況) を SSE で配信する処理
+// 接続している間は閲覧者として他のメンバーに表示される
+func (h *collaborationHandler) StreamEvents(c echo.Context) error {
+	ctx := c.Request().Context()
+	userUUID, ok := c.Get("user_uuid").(string)
+	if !ok {
+		slog.WarnContext(ctx, "ユーザーUUIDの取得に失敗")
+		return c.JSON(http.StatusUnauthorized, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyUserUUIDNotFound),
+		})
+	}
+	chatUUID := c.Param("chat_uuid")
+
+	events, unsubscribe, err := h.collaborationUsecase.Watch(ctx, chatUUID, userUUID)
+	if err != nil {
+		slog.ErrorContext(ctx, "チャットイベントの購読に失敗", "error", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
+	}
+	defer unsubscribe()
+
+	// SSE ヘッダーの設定
+	c.Response().Header().Set(echo.HeaderContentType, "text/event-stream")
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-cache")
+	c.Response().Header().Set(echo.HeaderConnection, "keep-alive")
+	c.Response().WriteHeader(http.StatusOK)
+	c.Response().Flush()
+
+	slog.InfoContext(ctx, "チャットイベントの配信を開始", "chat_uuid", chatUUID, "user_uuid", userUUID)
+	enc := json.NewEncoder(c.Response())
+	ticker := time.NewTicker(chatEventKeepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			fmt.Fprintf(c.Response(), "data: ")
+			enc.Encode(toChatEventResponse(event))
+			fmt.Fprintf(c.Response(), "\n\n")
+			c.Response().Flush()
+
+		case <-ticker.C:
+			fmt.Fprintf(c.Response(), ": keep-alive\n\n")
+			c.Response().Flush()
+
+		case <-ctx.Done():
+			// クライアント切断
+			slog.InfoContext(ctx, "チャットイベントの配信を終了", "chat_uuid", chatUUID, "user_uuid", userUUID)
+			return nil
+		}
+	}
+}
+
+// 入力中の状態を更新する処理
+func (h *collaborationHandler) SetTyping(c echo.Context) error {
+	ctx := c.Request().Context()
+	userUUID, ok := c.Get("user_uuid").(string)
+	if !ok {
+		slog.WarnContext(ctx, "ユーザーUUIDの取得に失敗")
+		return c.JSON(http.StatusUnauthorized, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyUserUUIDNotFound),
+		})
+	}
+	chatUUID := c.Param("chat_uuid")
+
+	var req model.SetTypingRequest
+	if err := c.Bind(&req); err != nil {
+		slog.WarnContext(ctx, "リクエストボディのバインドに失敗", "error", err)
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyInvalidRequestBody),
+		})
+	}
+
+	if err := h.collaborationUsecase.SetTyping(ctx, chatUUID, userUUID, req.Typing); err != nil {
+		slog.ErrorContext(ctx, "入力中の状態の更新に失敗", "error", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, model.Response{
+		Status: "ok",
+	})
+}
+
+// チャットの閲覧者一覧を取得する処理
+func (h *collaborationHandler) GetChatPresence(c echo.Context) error {
+	ctx := c.Request().Context()
+	chatUUID := c.Param("chat_uuid")
+
+	presence, err := h.collaborationUsecase.GetChatPresence(ctx, chatUUID)
+	if err != nil {
+		slog.ErrorContext(ctx, "チャットの閲覧者一覧の取得に失敗", "error", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, toChatPresenceResponses(presence))
+}
+
+// プロジェクト内で誰がどのチャットを閲覧しているかを取得する処理
+func (h *collaborationHandler) GetProjectPresence(c echo.Context) error {
+	ctx := c.Request().Context()
+	projectUUID := c.Param("project_uuid")
+
+	presence, err := h.collaborationUsecase.GetProjectPresence(ctx, projectUUID)
+	if err != nil {
+		slog.ErrorContext(ctx, "プロジェクトの閲覧者一覧の取得に失敗", "error", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, toChatPresenceResponses(presence))
+}
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"backend/internal/domain/model"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// モックの定義
+type mockCollaborationUsecase struct {
+	mock.Mock
+}
+
+func (m *mockCollaborationUsecase) Watch(ctx context.Context, chatUUID string, userUUID string) (<-chan model.ChatEvent, func(), error) {
+	args := m.Called(ctx, chatUUID, userUUID)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(<-chan model.ChatEvent), args.Get(1).(func()), args.Error(2)
+}
+
+func (m *mockCollaborationUsecase) SetTyping(ctx context.Context, chatUUID string, userUUID string, typing bool) error {
+	args := m.Called(ctx, chatUUID, userUUID, typing)
+	return args.Error(0)
+}
+
+func (m *mockCollaborationUsecase) GetChatPresence(ctx context.Context, chatUUID string) ([]*model.ChatPresence, error) {
+	args := m.Called(ctx, chatUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.ChatPresence), args.Error(1)
+}
+
+func (m *mockCollaborationUsecase) GetProjectPresence(ctx context.Context, projectUUID string) ([]*model.ChatPresence, error) {
+	args := m.Called(ctx, projectUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.ChatPresence), args.Error(1)
+}
+
+func TestCollaborationHandler_StreamEvents(t *testing.T) {
+	t.Run("正常系: 購読したイベントがSSEで配信され、終了時に購読が解除されること", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/chats/chat-uuid/events", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user_uuid", "user-uuid")
+		c.SetParamNames("chat_uuid")
+		c.SetParamValues("chat-uuid")
+
+		events := make(chan model.ChatEvent, 2)
+		events <- model.ChatEvent{Type: model.ChatEventPresence, ChatUUID: "chat-uuid", Presence: []*model.ChatPresence{{ChatUUID: "chat-uuid", UserUUID: "user-uuid", UserName: "alice"}}}
+		events <- model.ChatEvent{Type: model.ChatEventMessage, ChatUUID: "chat-uuid", Message: &model.Message{UUID: "msg-uuid", Role: "user", Content: "hello"}}
+		close(events)
+		unsubscribed := false
+
+		mockUsecase := new(mockCollaborationUsecase)
+		mockUsecase.On("Watch", mock.Anything, "chat-uuid", "user-uuid").Return((<-chan model.ChatEvent)(events), func() { unsubscribed = true }, nil)
+
+		h := NewCollaborationHandler(mockUsecase)
+		err := h.StreamEvents(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/event-stream", rec.Header().Get(echo.HeaderContentType))
+		body := rec.Body.String()
+		assert.Contains(t, body, `"type":"presence"`)
+		assert.Contains(t, body, `"user_name":"alice"`)
+		assert.Contains(t, body, `"type":"message"`)
+		assert.Contains(t, body, `"content":"hello"`)
+		assert.True(t, unsubscribed)
+	})
+}
+
+func TestCollaborationHandler_SetTyping(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(m *mockCollaborationUsecase)
+		wantStatus     int
+		wantBodySubstr string
+	}{
+		{
+			name: "正常系: 入力中の状態が更新できること",
+			body: `{"typing":true}`,
+			setupMock: func(m *mockCollaborationUsecase) {
+				m.On("SetTyping", mock.Anything, "chat-uuid", "user-uuid", true).Return(nil)
+			},
+			wantStatus:     http.StatusOK,
+			wantBodySubstr: `"status":"ok"`,
+		},
+		{
+			name: "異常系: リクエストボディが不正な場合400エラー",
+			body: `invalid-json`,
+			setupMock: func(m *mockCollaborationUsecase) {
+				// 呼び出されない
+			},
+			wantStatus:     http.StatusBadRequest,
+			wantBodySubstr: "リクエストボディの形式が正しくありません",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/chats/chat-uuid/typing", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_uuid", "user-uuid")
+			c.SetParamNames("chat_uuid")
+			c.SetParamValues("chat-uuid")
+
+			mockUsecase := new(mockCollaborationUsecase)
+			tt.setupMock(mockUsecase)
+
+			h := NewCollaborationHandler(mockUsecase)
+			_ = h.SetTyping(c)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBodySubstr)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCollaborationHandler_GetProjectPresence(t *testing.T) {
+	t.Run("正常系: プロジェクト内の閲覧者一覧が取得できること", func(t *testing.T) {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/projects/project-uuid/presence", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("project_uuid")
+		c.SetParamValues("project-uuid")
+
+		mockUsecase := new(mockCollaborationUsecase)
+		mockUsecase.On("GetProjectPresence", mock.Anything, "project-uuid").Return([]*model.ChatPresence{
+			{ProjectUUID: "project-uuid", ChatUUID: "chat-uuid", UserUUID: "user-uuid", UserName: "alice", Typing: true, JoinedAt: time.Now()},
+		}, nil)
+
+		h := NewCollaborationHandler(mockUsecase)
+		_ = h.GetProjectPresence(c)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"chat_uuid":"chat-uuid"`)
+		assert.Contains(t, rec.Body.String(), `"typing":true`)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"backend/internal/handler/model"
+	"backend/internal/i18n"
 
 	"github.com/labstack/echo/v4"
 	"gorm.io/gorm"
@@ -26,3 +27,10 @@ func (h *handler) HealthCheck(c echo.Context) error {
 		Status: "ok",
 	})
 }
+
+// コンテキストに設定されたレスポンスの言語でメッセージを返す処理
+// 言語が設定されていない場合は既定の言語 (日本語) になる
+func localize(c echo.Context, key i18n.Key) string {
+	language, _ := c.Get("language").(string)
+	return i18n.Message(language, key)
+}
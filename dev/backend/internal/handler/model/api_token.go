@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+type CreateAPITokenRequest struct {
+	Name string `json:"name"`
+	// read または write (省略時は read)
+	Scope string `json:"scope"`
+	// アクセスを許可するプロジェクト (省略時は全プロジェクト)
+	ProjectUUID string `json:"project_uuid"`
+	// 有効期限 (省略時は無期限)
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type APITokenResponse struct {
+	UUID        string     `json:"uuid"`
+	Name        string     `json:"name"`
+	Prefix      string     `json:"prefix"`
+	Scope       string     `json:"scope"`
+	ProjectUUID string     `json:"project_uuid"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type CreateAPITokenResponse struct {
+	APITokenResponse
+	// 発行したトークン (この応答でのみ返す)
+	Token string `json:"token"`
+}
+
+type APITokenAuditLogResponse struct {
+	Event      string    `json:"event"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	StatusCode int       `json:"status_code"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+}
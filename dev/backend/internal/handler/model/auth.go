@@ -1,11 +1,11 @@
 package model
 
+import "time"
+
 type SignupResponse struct {
-	Token string `json:"token"`
-	User  struct {
-		UUID string `json:"uuid"`
-		Name string `json:"name"`
-	} `json:"user"`
+	Token     string       `json:"token"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	User      UserResponse `json:"user"`
 }
 
 type LoginRequest struct {
@@ -13,5 +13,23 @@ type LoginRequest struct {
 }
 
 type LoginResponse struct {
-	Token string `json:"token"`
+	Token     string       `json:"token"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	User      UserResponse `json:"user"`
+}
+
+type RefreshResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type SessionResponse struct {
+	UUID       string    `json:"uuid"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	// リクエスト中のアクセストークンのセッションかどうか
+	Current bool `json:"current"`
 }
@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 type GetChatResponse struct {
 	UUID           string  `json:"uuid"`
 	ProjectUUID    string  `json:"project_uuid"`
@@ -10,12 +12,14 @@ type GetChatResponse struct {
 }
 
 type MessageResponse struct {
-	UUID           string            `json:"uuid"`
-	Role           string            `json:"role"`
-	Content        string            `json:"content"`
-	Forks          []ForkResponse    `json:"forks"`
-	SourceChatUUID *string           `json:"source_chat_uuid,omitempty"`
-	MergeReports   []MessageResponse `json:"merge_reports"`
+	UUID              string            `json:"uuid"`
+	Role              string            `json:"role"`
+	Content           string            `json:"content"`
+	Forks             []ForkResponse    `json:"forks"`
+	SourceChatUUID    *string           `json:"source_chat_uuid,omitempty"`
+	OriginMessageUUID *string           `json:"origin_message_uuid,omitempty"` // 別のブランチから取り込んだメッセージの取り込み元
+	OriginChatUUID    *string           `json:"origin_chat_uuid,omitempty"`
+	MergeReports      []MessageResponse `json:"merge_reports"`
 }
 
 type ForkPreviewResponse struct {
@@ -23,8 +27,25 @@ type ForkPreviewResponse struct {
 	GeneratedContext string `json:"generated_context"`
 }
 
+type MergePreviewRequest struct {
+	// マージ先にする子チャットの祖先のチャット (省略した場合は直接の親チャット)
+	ParentChatUUID string `json:"parent_chat_uuid"`
+}
+
 type MergePreviewResponse struct {
-	SuggestedSummary string `json:"suggested_summary"`
+	SuggestedSummary string                   `json:"suggested_summary"`
+	PromptVersion    string                   `json:"prompt_version"`
+	TargetChatUUID   string                   `json:"target_chat_uuid"`
+	Divergence       *MergeDivergenceResponse `json:"divergence"`
+}
+
+// フォーク後のマージ先の進み具合
+type MergeDivergenceResponse struct {
+	AnchorMessageUUID    string `json:"anchor_message_uuid"`
+	NewMessages          int    `json:"new_messages"`
+	NewAssistantMessages int    `json:"new_assistant_messages"`
+	MergedReports        int    `json:"merged_reports"`
+	Stale                bool   `json:"stale"`
 }
 
 type ForkResponse struct {
@@ -32,12 +53,15 @@ type ForkResponse struct {
 	SelectedText string `json:"selected_text"`
 	RangeStart   int    `json:"range_start"`
 	RangeEnd     int    `json:"range_end"`
+	// 範囲を選択せずにメッセージ全体からフォークした
+	WholeMessage bool `json:"whole_message"`
 }
 
 type SendMessageRequest struct {
 	Content string `json:"content"`
 }
 
+// target_message_uuid を省略するとチャットの先頭から、selected_text を省略するとメッセージ全体からフォークする
 type ForkChatRequest struct {
 	TargetMessageUUID string `json:"target_message_uuid"`
 	ParentChatUUID    string `json:"parent_chat_uuid"`
@@ -46,6 +70,7 @@ type ForkChatRequest struct {
 	RangeEnd          int    `json:"range_end"`
 	Title             string `json:"title"`
 	ContextSummary    string `json:"context_summary"`
+	PromptVersion     string `json:"prompt_version"`
 }
 
 type ForkChatResponse struct {
@@ -53,18 +78,148 @@ type ForkChatResponse struct {
 	Message   string `json:"message"`
 }
 
+type BatchForkChatRequest struct {
+	// 省略した場合はチャットの先頭のメッセージからフォークする
+	TargetMessageUUID    string                 `json:"target_message_uuid"`
+	Forks                []BatchForkItemRequest `json:"forks"`
+	GenerateFirstAnswers bool                   `json:"generate_first_answers"`
+}
+
+type BatchForkItemRequest struct {
+	SelectedText   string `json:"selected_text"`
+	RangeStart     int    `json:"range_start"`
+	RangeEnd       int    `json:"range_end"`
+	Title          string `json:"title"`
+	ContextSummary string `json:"context_summary"`
+	PromptVersion  string `json:"prompt_version"`
+}
+
+type BatchForkChatResponse struct {
+	NewChatIDs []string `json:"new_chat_ids"`
+	Message    string   `json:"message"`
+}
+
 type MergeChatRequest struct {
-	ParentChatUUID string `json:"parent_chat_uuid"`
-	SummaryContent string `json:"summary_content"`
+	// 子チャットの祖先のチャット (省略した場合は直接の親チャット)
+	ParentChatUUID     string `json:"parent_chat_uuid"`
+	SummaryContent     string `json:"summary_content"`
+	PromptVersion      string `json:"prompt_version"`
+	IncludeDescendants bool   `json:"include_descendants"`
+	// マージ後にレポートの結論を会話に統合する回答を生成する
+	Acknowledge bool `json:"acknowledge"`
 }
 
 type MergeChatResponse struct {
-	ReportMessageID string `json:"report_message_id"`
-	SummaryContent  string `json:"summary_content"`
+	ReportMessageID string   `json:"report_message_id"`
+	SummaryContent  string   `json:"summary_content"`
+	TargetChatUUID  string   `json:"target_chat_uuid"`
+	MergedChatUUIDs []string `json:"merged_chat_uuids"`
+	// 回答を生成しなかった場合は空文字
+	AcknowledgmentMessageID string `json:"acknowledgment_message_id"`
 }
 
-type CloseChatResponse struct {
+type UnmergeChatRequest struct {
+	// マージレポートを文脈に含めて生成された回答があっても取り消す
+	Force bool `json:"force"`
+}
+
+type UnmergeChatResponse struct {
+	ChatUUID          string   `json:"chat_uuid"`
+	Status            string   `json:"status"`
+	ReportMessageUUID string   `json:"report_message_uuid"`
+	RestoredChatUUIDs []string `json:"restored_chat_uuids"`
+}
+
+type RebaseChatRequest struct {
+	SourceMessageUUID string `json:"source_message_uuid"`
+	// 省略した場合は範囲を選択せずにメッセージ全体を起点にする
+	SelectedText string `json:"selected_text"`
+	RangeStart   int    `json:"range_start"`
+	RangeEnd     int    `json:"range_end"`
+	// 新しい起点からフォーク時の文脈を生成し直す
+	RegenerateContext bool `json:"regenerate_context"`
+}
+
+type RebaseChatResponse struct {
+	ChatUUID             string `json:"chat_uuid"`
+	ParentChatUUID       string `json:"parent_chat_uuid"`
+	SourceMessageUUID    string `json:"source_message_uuid"`
+	ContextSummary       string `json:"context_summary"`
+	ContextPromptVersion string `json:"context_prompt_version"`
+}
+
+type ChatMergeEventResponse struct {
+	UUID              string    `json:"uuid"`
+	ChatUUID          string    `json:"chat_uuid"`
+	ParentChatUUID    string    `json:"parent_chat_uuid"`
+	ReportMessageUUID string    `json:"report_message_uuid"`
+	Action            string    `json:"action"`
+	PreviousStatus    string    `json:"previous_status"`
+	Forced            bool      `json:"forced"`
+	UserUUID          *string   `json:"user_uuid"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// ForkPreviewRequest と同じ項目を持ち、そのままフォークプレビュー・フォークに使用できる
+type ForkSuggestionResponse struct {
+	UUID              string `json:"uuid"`
+	TargetMessageUUID string `json:"target_message_uuid"`
+	SelectedText      string `json:"selected_text"`
+	// 本文での文字単位の位置 (range_end は含まない)
+	RangeStart       int       `json:"range_start"`
+	RangeEnd         int       `json:"range_end"`
+	SuggestedTitle   string    `json:"suggested_title"`
+	GeneratedContext string    `json:"generated_context"`
+	PromptVersion    string    `json:"prompt_version"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type CherryPickRequest struct {
+	// 取り込むメッセージ (同じプロジェクト内の別のチャットのメッセージ)
+	MessageUUIDs []string `json:"message_uuids"`
+}
+
+type CherryPickResponse struct {
+	ChatUUID string            `json:"chat_uuid"`
+	Messages []MessageResponse `json:"messages"`
+}
+
+type CompareBranchesRequest struct {
+	OtherChatUUID string `json:"other_chat_uuid"`
+	// 比較結果を共通の祖先メッセージのメモとして保存する
+	SaveNote bool `json:"save_note"`
+}
+
+type BranchPositionResponse struct {
 	ChatUUID string `json:"chat_uuid"`
+	Title    string `json:"title"`
+	Position string `json:"position"`
+}
+
+type BranchComparisonResponse struct {
+	// 保存していない場合は空
+	UUID              string                   `json:"uuid"`
+	ChatUUID          string                   `json:"chat_uuid"`
+	OtherChatUUID     string                   `json:"other_chat_uuid"`
+	AnchorChatUUID    *string                  `json:"anchor_chat_uuid"`
+	AnchorMessageUUID *string                  `json:"anchor_message_uuid"`
+	Positions         []BranchPositionResponse `json:"positions"`
+	Agreements        []string                 `json:"agreements"`
+	Disagreements     []string                 `json:"disagreements"`
+	Recommendation    string                   `json:"recommendation"`
+	PromptVersion     string                   `json:"prompt_version"`
+	UserUUID          *string                  `json:"user_uuid"`
+	CreatedAt         time.Time                `json:"created_at"`
+}
+
+type CloseChatRequest struct {
+	// 子孫のブランチのうち open のものもまとめてクローズする
+	Cascade bool `json:"cascade"`
+}
+
+type CloseChatResponse struct {
+	ChatUUID        string   `json:"chat_uuid"`
+	ClosedChatUUIDs []string `json:"closed_chat_uuids"`
 }
 
 type OpenChatResponse struct {
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+type ChatPresenceResponse struct {
+	ChatUUID string    `json:"chat_uuid"`
+	UserUUID string    `json:"user_uuid"`
+	UserName string    `json:"user_name"`
+	Typing   bool      `json:"typing"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+type SetTypingRequest struct {
+	Typing bool `json:"typing"`
+}
+
+// チャットの閲覧者に SSE で配信するイベント
+type ChatEventResponse struct {
+	// message・message_removed・presence・generation_started・generation_finished のいずれか
+	Type     string                 `json:"type"`
+	ChatUUID string                 `json:"chat_uuid"`
+	Message  *MessageResponse       `json:"message,omitempty"`
+	Presence []ChatPresenceResponse `json:"presence,omitempty"`
+}
@@ -5,9 +5,21 @@ import "time"
 type ProjectResponse struct {
 	UUID      string    `json:"uuid"`
 	Title     string    `json:"title"`
+	Language  string    `json:"language"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+type SharedProjectResponse struct {
+	ProjectResponse
+	Role          string `json:"role"`
+	OwnerUserUUID string `json:"owner_user_uuid"`
+}
+
+type GetProjectsResponse struct {
+	Owned  []ProjectResponse       `json:"owned"`
+	Shared []SharedProjectResponse `json:"shared"`
+}
+
 type CreateProjectRequest struct {
 	InitialMessage string `json:"initial_message"`
 }
@@ -28,16 +40,35 @@ type GetParentChatResponse struct {
 	ChatUUID string `json:"chat_uuid"`
 }
 
+type GetProjectTreeRequest struct {
+	// ツリーの起点にするチャット (省略した場合はルートチャット)
+	ChatUUID string `query:"chat_uuid"`
+	// 起点のチャットから辿るフォークの階層数 (省略した場合は制限しない)
+	Depth *int `query:"depth"`
+	// ツリーに含めないチャットのステータス (カンマ区切りまたは複数指定)
+	ExcludeStatus []string `query:"exclude_status"`
+	// true の場合はノードの本文を先頭の一部に省略する
+	Summary bool `query:"summary"`
+}
+
 type GetProjectTreeResponse struct {
 	Nodes []ProjectNode `json:"nodes"`
 	Edges []ProjectEdge `json:"edges"`
 }
 
 type ProjectNode struct {
-	ID       string              `json:"id"`
-	ChatUUID string              `json:"chat_uuid"`
-	Data     ProjectNodeData     `json:"data"`
-	Position ProjectNodePosition `json:"position"`
+	ID        string              `json:"id"`
+	ChatUUID  string              `json:"chat_uuid"`
+	Data      ProjectNodeData     `json:"data"`
+	Position  ProjectNodePosition `json:"position"`
+	Collapsed bool                `json:"collapsed"`
+	Pinned    bool                `json:"pinned"`
+	Version   int                 `json:"version"`
+	Title     string              `json:"title"`
+	// 本文を省略している場合は true (全文はノード単位で取得する)
+	Truncated bool `json:"truncated"`
+	// 階層数の制限で読み込んでいない子チャットがある場合は true
+	HasMoreChildren bool `json:"has_more_children"`
 }
 
 type ProjectNodeData struct {
@@ -55,3 +86,44 @@ type ProjectEdge struct {
 	Source string `json:"source"`
 	Target string `json:"target"`
 }
+
+type UpdateTreeNodePositionsRequest struct {
+	Positions []TreeNodePositionRequest `json:"positions"`
+}
+
+type TreeNodePositionRequest struct {
+	NodeID string  `json:"node_id"`
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	// true の場合は移動した位置を破棄して自動レイアウトの位置に戻す
+	Reset bool `json:"reset"`
+	// ツリーを取得したときのノードのバージョン
+	Version int `json:"version"`
+}
+
+type UpdateTreeNodePositionsResponse struct {
+	Nodes []TreeNodeStateResponse `json:"nodes"`
+}
+
+type SetTreeNodeCollapsedRequest struct {
+	Collapsed bool `json:"collapsed"`
+	// ツリーを取得したときのノードのバージョン
+	Version int `json:"version"`
+}
+
+type TreeNodeStateResponse struct {
+	NodeID string `json:"node_id"`
+	// 自動レイアウトの位置で表示する場合は null
+	Position  *ProjectNodePosition `json:"position"`
+	Collapsed bool                 `json:"collapsed"`
+	Version   int                  `json:"version"`
+}
+
+type UpdateProjectLanguageRequest struct {
+	Language string `json:"language"`
+}
+
+type UpdateProjectLanguageResponse struct {
+	ProjectUUID string `json:"project_uuid"`
+	Language    string `json:"language"`
+}
@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+type InviteProjectMemberRequest struct {
+	UserUUID string `json:"user_uuid"`
+	Role     string `json:"role"`
+}
+
+type UpdateProjectMemberRoleRequest struct {
+	Role string `json:"role"`
+}
+
+// ユーザーUUIDはゲストユーザーのログインに使えるため、プロジェクトのオーナーにだけ返す
+type ProjectMemberResponse struct {
+	UserUUID          string    `json:"user_uuid,omitempty"`
+	UserName          string    `json:"user_name"`
+	UserAvatarURL     string    `json:"user_avatar_url"`
+	Role              string    `json:"role"`
+	Status            string    `json:"status"`
+	InvitedByUserUUID string    `json:"invited_by_user_uuid,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+type ProjectInvitationResponse struct {
+	ProjectUUID       string    `json:"project_uuid"`
+	ProjectTitle      string    `json:"project_title"`
+	Role              string    `json:"role"`
+	InvitedByUserUUID string    `json:"invited_by_user_uuid"`
+	InvitedAt         time.Time `json:"invited_at"`
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+type CreateShareLinkRequest struct {
+	// 公開する部分木の起点チャット (省略時はプロジェクト全体)
+	RootChatUUID string `json:"root_chat_uuid"`
+	// 有効期限 (省略時は無期限)
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type ShareLinkResponse struct {
+	UUID         string     `json:"uuid"`
+	Prefix       string     `json:"prefix"`
+	RootChatUUID string     `json:"root_chat_uuid"`
+	ExpiresAt    *time.Time `json:"expires_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type CreateShareLinkResponse struct {
+	ShareLinkResponse
+	// 発行したトークン (この応答でのみ返す)
+	Token string `json:"token"`
+}
+
+type SharedViewResponse struct {
+	ProjectTitle string     `json:"project_title"`
+	RootChatUUID string     `json:"root_chat_uuid"`
+	ExpiresAt    *time.Time `json:"expires_at"`
+}
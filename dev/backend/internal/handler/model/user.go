@@ -0,0 +1,13 @@
+package model
+
+type UserResponse struct {
+	UUID      string `json:"uuid"`
+	Name      string `json:"name"`
+	Language  string `json:"language"`
+	Provider  string `json:"provider"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+type UpdateUserLanguageRequest struct {
+	Language string `json:"language"`
+}
@@ -1,10 +1,14 @@
 package handler
 
 import (
+	domainModel "backend/internal/domain/model"
 	"backend/internal/domain/usecase"
 	"backend/internal/handler/model"
+	"backend/internal/i18n"
+	"errors"
 	"log/slog"
 	"net/http"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 )
@@ -20,6 +24,16 @@ func NewProjectHandler(projectUsecase usecase.ProjectUsecase) *projectHandler {
 	}
 }
 
+// ドメインモデルのプロジェクトをレスポンスに変換する処理
+func toProjectResponse(project *domainModel.Project) model.ProjectResponse {
+	return model.ProjectResponse{
+		UUID:      project.UUID,
+		Title:     project.Title,
+		Language:  project.Language,
+		UpdatedAt: project.UpdatedAt,
+	}
+}
+
 // プロジェクト一覧を取得する処理
 func (h *projectHandler) GetProjects(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -28,7 +42,7 @@ func (h *projectHandler) GetProjects(c echo.Context) error {
 		slog.WarnContext(ctx, "ユーザーUUIDの取得に失敗")
 		return c.JSON(http.StatusUnauthorized, model.Response{
 			Status:  "error",
-			Message: "ユーザーUUIDの取得に失敗しました",
+			Message: localize(c, i18n.KeyUserUUIDNotFound),
 		})
 	}
 
@@ -41,16 +55,22 @@ func (h *projectHandler) GetProjects(c echo.Context) error {
 		})
 	}
 
-	res := make([]*model.ProjectResponse, len(projects))
-	for i, p := range projects {
-		res[i] = &model.ProjectResponse{
-			UUID:      p.UUID,
-			Title:     p.Title,
-			UpdatedAt: p.UpdatedAt,
+	res := model.GetProjectsResponse{
+		Owned:  make([]model.ProjectResponse, len(projects.Owned)),
+		Shared: make([]model.SharedProjectResponse, len(projects.Shared)),
+	}
+	for i, p := range projects.Owned {
+		res.Owned[i] = toProjectResponse(p)
+	}
+	for i, s := range projects.Shared {
+		res.Shared[i] = model.SharedProjectResponse{
+			ProjectResponse: toProjectResponse(s.Project),
+			Role:            s.Role,
+			OwnerUserUUID:   s.Project.UserUUID,
 		}
 	}
 
-	slog.InfoContext(ctx, "プロジェクト一覧の取得に成功", "user_uuid", userUUID, "count", len(res))
+	slog.InfoContext(ctx, "プロジェクト一覧の取得に成功", "user_uuid", userUUID, "owned", len(res.Owned), "shared", len(res.Shared))
 	return c.JSON(http.StatusOK, res)
 }
 
@@ -62,7 +82,7 @@ func (h *projectHandler) CreateProject(c echo.Context) error {
 		slog.WarnContext(ctx, "ユーザーUUIDの取得に失敗")
 		return c.JSON(http.StatusUnauthorized, model.Response{
 			Status:  "error",
-			Message: "ユーザーUUIDの取得に失敗しました",
+			Message: localize(c, i18n.KeyUserUUIDNotFound),
 		})
 	}
 
@@ -71,14 +91,14 @@ func (h *projectHandler) CreateProject(c echo.Context) error {
 		slog.WarnContext(ctx, "リクエストボディのパースに失敗", "error", err)
 		return c.JSON(http.StatusBadRequest, model.Response{
 			Status:  "error",
-			Message: "リクエストボディの形式が正しくありません",
+			Message: localize(c, i18n.KeyInvalidRequestBody),
 		})
 	}
 
 	if req.InitialMessage == "" {
 		return c.JSON(http.StatusBadRequest, model.Response{
 			Status:  "error",
-			Message: "initial_messageは必須です",
+			Message: localize(c, i18n.KeyInitialMessageRequired),
 		})
 	}
 
@@ -113,7 +133,7 @@ func (h *projectHandler) GetParentChat(c echo.Context) error {
 		slog.WarnContext(ctx, "project_uuidが指定されていません")
 		return c.JSON(http.StatusBadRequest, model.Response{
 			Status:  "error",
-			Message: "project_uuidは必須です",
+			Message: localize(c, i18n.KeyProjectUUIDRequired),
 		})
 	}
 
@@ -142,11 +162,39 @@ func (h *projectHandler) GetProjectTree(c echo.Context) error {
 		slog.WarnContext(ctx, "project_uuidが指定されていません")
 		return c.JSON(http.StatusBadRequest, model.Response{
 			Status:  "error",
-			Message: "project_uuidは必須です",
+			Message: localize(c, i18n.KeyProjectUUIDRequired),
+		})
+	}
+
+	var req model.GetProjectTreeRequest
+	if err := c.Bind(&req); err != nil {
+		slog.WarnContext(ctx, "クエリパラメータのパースに失敗", "error", err)
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyInvalidTreeQuery),
 		})
 	}
+	query := domainModel.ProjectTreeQuery{
+		ChatUUID:    req.ChatUUID,
+		MaxDepth:    req.Depth,
+		SummaryOnly: req.Summary,
+	}
+	for _, status := range req.ExcludeStatus {
+		for _, part := range strings.Split(status, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				query.ExcludeStatuses = append(query.ExcludeStatuses, part)
+			}
+		}
+	}
 
-	tree, err := h.projectUsecase.GetProjectTree(ctx, projectUUID)
+	tree, err := h.projectUsecase.GetProjectTree(ctx, projectUUID, query)
+	if errors.Is(err, domainModel.ErrInvalidTreeQuery) {
+		slog.WarnContext(ctx, "ツリーの取得条件が不正です", "error", err)
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyInvalidTreeQuery),
+		})
+	}
 	if err != nil {
 		slog.ErrorContext(ctx, "プロジェクトツリーの取得に失敗", "error", err)
 		return c.JSON(http.StatusInternalServerError, model.Response{
@@ -155,20 +203,208 @@ func (h *projectHandler) GetProjectTree(c echo.Context) error {
 		})
 	}
 
+	res := mapProjectTreeToResponse(tree)
+
+	slog.InfoContext(ctx, "プロジェクトツリーの取得に成功", "project_uuid", projectUUID, "nodes", len(res.Nodes), "edges", len(res.Edges))
+	return c.JSON(http.StatusOK, res)
+}
+
+// ツリーのノード取得処理 (要約のみで取得したノードの全文を取得する)
+func (h *projectHandler) GetProjectTreeNode(c echo.Context) error {
+	ctx := c.Request().Context()
+	projectUUID := c.Param("project_uuid")
+	if projectUUID == "" {
+		slog.WarnContext(ctx, "project_uuidが指定されていません")
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyProjectUUIDRequired),
+		})
+	}
+	nodeID := c.Param("node_id")
+
+	node, err := h.projectUsecase.GetProjectTreeNode(ctx, projectUUID, nodeID)
+	if errors.Is(err, domainModel.ErrTreeNodeNotFound) {
+		slog.WarnContext(ctx, "ノードがプロジェクトのツリーにありません", "project_uuid", projectUUID, "node_id", nodeID)
+		return c.JSON(http.StatusNotFound, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyTreeNodeNotFound),
+		})
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "ノードの取得に失敗", "error", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
+	}
+
+	slog.InfoContext(ctx, "ノードの取得に成功", "project_uuid", projectUUID, "node_id", nodeID)
+	return c.JSON(http.StatusOK, toProjectNodeResponse(*node))
+}
+
+// ノードの位置の一括更新処理
+func (h *projectHandler) UpdateTreeNodePositions(c echo.Context) error {
+	ctx := c.Request().Context()
+	projectUUID := c.Param("project_uuid")
+	if projectUUID == "" {
+		slog.WarnContext(ctx, "project_uuidが指定されていません")
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyProjectUUIDRequired),
+		})
+	}
+
+	var req model.UpdateTreeNodePositionsRequest
+	if err := c.Bind(&req); err != nil {
+		slog.WarnContext(ctx, "リクエストボディのパースに失敗", "error", err)
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyInvalidRequestBody),
+		})
+	}
+
+	updates := make([]domainModel.TreeNodePositionUpdate, len(req.Positions))
+	for i, p := range req.Positions {
+		updates[i] = domainModel.TreeNodePositionUpdate{
+			NodeID:   p.NodeID,
+			Position: domainModel.ProjectNodePosition{X: p.X, Y: p.Y},
+			Reset:    p.Reset,
+			Version:  p.Version,
+		}
+	}
+
+	states, err := h.projectUsecase.UpdateTreeNodePositions(ctx, projectUUID, updates)
+	if err != nil {
+		return writeTreeNodeStateError(c, "ノード位置の更新", err)
+	}
+
+	res := model.UpdateTreeNodePositionsResponse{
+		Nodes: make([]model.TreeNodeStateResponse, len(states)),
+	}
+	for i, state := range states {
+		res.Nodes[i] = toTreeNodeStateResponse(state)
+	}
+
+	slog.InfoContext(ctx, "ノード位置の更新に成功", "project_uuid", projectUUID, "count", len(res.Nodes))
+	return c.JSON(http.StatusOK, res)
+}
+
+// ノードの折りたたみ状態の更新処理
+func (h *projectHandler) SetTreeNodeCollapsed(c echo.Context) error {
+	ctx := c.Request().Context()
+	projectUUID := c.Param("project_uuid")
+	if projectUUID == "" {
+		slog.WarnContext(ctx, "project_uuidが指定されていません")
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyProjectUUIDRequired),
+		})
+	}
+	nodeID := c.Param("node_id")
+
+	var req model.SetTreeNodeCollapsedRequest
+	if err := c.Bind(&req); err != nil {
+		slog.WarnContext(ctx, "リクエストボディのパースに失敗", "error", err)
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyInvalidRequestBody),
+		})
+	}
+
+	state, err := h.projectUsecase.SetTreeNodeCollapsed(ctx, projectUUID, nodeID, req.Collapsed, req.Version)
+	if err != nil {
+		return writeTreeNodeStateError(c, "ノード折りたたみ状態の更新", err)
+	}
+
+	slog.InfoContext(ctx, "ノード折りたたみ状態の更新に成功", "project_uuid", projectUUID, "node_id", nodeID, "collapsed", req.Collapsed)
+	return c.JSON(http.StatusOK, toTreeNodeStateResponse(state))
+}
+
+// ノードの表示状態の更新エラーをレスポンスに変換する処理
+func writeTreeNodeStateError(c echo.Context, operation string, err error) error {
+	ctx := c.Request().Context()
+	switch {
+	case errors.Is(err, domainModel.ErrInvalidTreeNode):
+		slog.WarnContext(ctx, "ノードの指定が不正です", "operation", operation, "error", err)
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyInvalidTreeNode),
+		})
+	case errors.Is(err, domainModel.ErrTreeNodeConflict):
+		slog.WarnContext(ctx, "ノードが他の操作で更新されています", "operation", operation, "error", err)
+		return c.JSON(http.StatusConflict, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyTreeNodeConflict),
+		})
+	}
+	slog.ErrorContext(ctx, operation+"に失敗", "error", err)
+	return c.JSON(http.StatusInternalServerError, model.Response{
+		Status:  "error",
+		Message: err.Error(),
+	})
+}
+
+// ドメインモデルのノードの表示状態をレスポンスに変換する処理
+func toTreeNodeStateResponse(state *domainModel.TreeNodeState) model.TreeNodeStateResponse {
+	res := model.TreeNodeStateResponse{
+		NodeID:    state.NodeID,
+		Collapsed: state.Collapsed,
+		Version:   state.Version,
+	}
+	if state.Position != nil {
+		res.Position = &model.ProjectNodePosition{X: state.Position.X, Y: state.Position.Y}
+	}
+	return res
+}
+
+// プロジェクトの出力言語更新処理
+func (h *projectHandler) UpdateLanguage(c echo.Context) error {
+	ctx := c.Request().Context()
+	projectUUID := c.Param("project_uuid")
+	if projectUUID == "" {
+		slog.WarnContext(ctx, "project_uuidが指定されていません")
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyProjectUUIDRequired),
+		})
+	}
+
+	var req model.UpdateProjectLanguageRequest
+	if err := c.Bind(&req); err != nil {
+		slog.WarnContext(ctx, "リクエストボディのパースに失敗", "error", err)
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyInvalidRequestBody),
+		})
+	}
+
+	if err := h.projectUsecase.UpdateLanguage(ctx, projectUUID, req.Language); err != nil {
+		if errors.Is(err, domainModel.ErrUnsupportedLanguage) {
+			slog.WarnContext(ctx, "対応していない出力言語が指定されました", "language", req.Language)
+			return c.JSON(http.StatusBadRequest, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyUnsupportedLanguage),
+			})
+		}
+		slog.ErrorContext(ctx, "プロジェクト出力言語の更新に失敗", "error", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
+	}
+
+	slog.InfoContext(ctx, "プロジェクト出力言語の更新に成功", "project_uuid", projectUUID, "language", req.Language)
+	return c.JSON(http.StatusOK, model.UpdateProjectLanguageResponse{
+		ProjectUUID: projectUUID,
+		Language:    req.Language,
+	})
+}
+
+// ドメインモデルのプロジェクトツリーをレスポンスに変換する処理
+func mapProjectTreeToResponse(tree *domainModel.ProjectTree) model.GetProjectTreeResponse {
 	nodes := make([]model.ProjectNode, len(tree.Nodes))
 	for i, n := range tree.Nodes {
-		nodes[i] = model.ProjectNode{
-			ID:       n.ID,
-			ChatUUID: n.ChatUUID,
-			Data: model.ProjectNodeData{
-				UserMessage: n.Data.UserMessage,
-				Assistant:   n.Data.Assistant,
-			},
-			Position: model.ProjectNodePosition{
-				X: n.Position.X,
-				Y: n.Position.Y,
-			},
-		}
+		nodes[i] = toProjectNodeResponse(n)
 	}
 
 	edges := make([]model.ProjectEdge, len(tree.Edges))
@@ -180,11 +416,30 @@ func (h *projectHandler) GetProjectTree(c echo.Context) error {
 		}
 	}
 
-	res := model.GetProjectTreeResponse{
+	return model.GetProjectTreeResponse{
 		Nodes: nodes,
 		Edges: edges,
 	}
+}
 
-	slog.InfoContext(ctx, "プロジェクトツリーの取得に成功", "project_uuid", projectUUID, "nodes", len(nodes), "edges", len(edges))
-	return c.JSON(http.StatusOK, res)
+// ドメインモデルのノードをレスポンスに変換する処理
+func toProjectNodeResponse(n domainModel.ProjectNode) model.ProjectNode {
+	return model.ProjectNode{
+		ID:       n.ID,
+		ChatUUID: n.ChatUUID,
+		Data: model.ProjectNodeData{
+			UserMessage: n.Data.UserMessage,
+			Assistant:   n.Data.Assistant,
+		},
+		Position: model.ProjectNodePosition{
+			X: n.Position.X,
+			Y: n.Position.Y,
+		},
+		Collapsed:       n.Collapsed,
+		Pinned:          n.Pinned,
+		Version:         n.Version,
+		Title:           n.Title,
+		Truncated:       n.Truncated,
+		HasMoreChildren: n.HasMoreChildren,
+	}
 }
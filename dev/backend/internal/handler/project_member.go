@@ -0,0 +1,278 @@
+package handler
+
+import (
+	domainModel "backend/internal/domain/model"
+	"backend/internal/domain/usecase"
+	"backend/internal/handler/model"
+	"backend/internal/i18n"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type projectMemberHandler struct {
+	projectMemberUsecase usecase.ProjectMemberUsecase
+}
+
+// projectMemberHandlerの新しいインスタンスを作成する処理
+func NewProjectMemberHandler(projectMemberUsecase usecase.ProjectMemberUsecase) *projectMemberHandler {
+	return &projectMemberHandler{
+		projectMemberUsecase: projectMemberUsecase,
+	}
+}
+
+// ドメインモデルのメンバーをレスポンスに変換する処理
+func toProjectMemberResponse(member *domainModel.ProjectMember) model.ProjectMemberResponse {
+	return model.ProjectMemberResponse{
+		UserUUID:          member.UserUUID,
+		UserName:          member.UserName,
+		UserAvatarURL:     member.UserAvatarURL,
+		Role:              member.Role,
+		Status:            member.Status,
+		InvitedByUserUUID: member.InvitedByUserUUID,
+		CreatedAt:         member.CreatedAt,
+	}
+}
+
+// メンバー管理のドメインエラーをレスポンスに変換する処理
+func projectMemberErrorResponse(c echo.Context, err error) error {
+	ctx := c.Request().Context()
+	switch {
+	case errors.Is(err, domainModel.ErrInvalidProjectRole):
+		slog.WarnContext(ctx, "不正なロールが指定されました", "error", err)
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyInvalidProjectRole),
+		})
+	case errors.Is(err, domainModel.ErrProjectNotFound):
+		slog.WarnContext(ctx, "プロジェクトが見つかりません", "error", err)
+		return c.JSON(http.StatusNotFound, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyProjectNotFound),
+		})
+	case errors.Is(err, domainModel.ErrUserNotFound):
+		slog.WarnContext(ctx, "招待するユーザーが見つかりません", "error", err)
+		return c.JSON(http.StatusNotFound, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyUserNotFound),
+		})
+	case errors.Is(err, domainModel.ErrProjectMemberNotFound):
+		slog.WarnContext(ctx, "メンバーまたは招待が見つかりません", "error", err)
+		return c.JSON(http.StatusNotFound, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyProjectMemberNotFound),
+		})
+	case errors.Is(err, domainModel.ErrProjectMemberExists):
+		slog.WarnContext(ctx, "既にメンバーか招待済みです", "error", err)
+		return c.JSON(http.StatusConflict, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyProjectMemberExists),
+		})
+	case errors.Is(err, domainModel.ErrProjectRoleDenied):
+		slog.WarnContext(ctx, "プロジェクトのロールが不足しています", "error", err)
+		return c.JSON(http.StatusForbidden, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyProjectRoleDenied),
+		})
+	}
+	slog.ErrorContext(ctx, "プロジェクトメンバーの操作に失敗", "error", err)
+	return c.JSON(http.StatusInternalServerError, model.Response{
+		Status:  "error",
+		Message: err.Error(),
+	})
+}
+
+// プロジェクトのメンバー一覧を取得する処理
+func (h *projectMemberHandler) ListMembers(c echo.Context) error {
+	ctx := c.Request().Context()
+	projectUUID := c.Param("project_uuid")
+
+	members, err := h.projectMemberUsecase.ListMembers(ctx, projectUUID)
+	if err != nil {
+		return projectMemberErrorResponse(c, err)
+	}
+
+	// オーナー以外には表示に使う項目だけを返す
+	role, _ := c.Get("project_role").(string)
+	canManage := domainModel.HasProjectRole(role, domainModel.ProjectRoleOwner)
+	res := make([]model.ProjectMemberResponse, len(members))
+	for i, member := range members {
+		res[i] = toProjectMemberResponse(member)
+		if !canManage {
+			res[i].UserUUID = ""
+			res[i].InvitedByUserUUID = ""
+		}
+	}
+
+	slog.InfoContext(ctx, "プロジェクトメンバー一覧の取得に成功", "project_uuid", projectUUID, "count", len(res))
+	return c.JSON(http.StatusOK, res)
+}
+
+// ユーザーをプロジェクトに招待する処理
+func (h *projectMemberHandler) InviteMember(c echo.Context) error {
+	ctx := c.Request().Context()
+	userUUID, ok := c.Get("user_uuid").(string)
+	if !ok {
+		slog.WarnContext(ctx, "ユーザーUUIDの取得に失敗")
+		return c.JSON(http.StatusUnauthorized, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyUserUUIDNotFound),
+		})
+	}
+	projectUUID := c.Param("project_uuid")
+
+	var req model.InviteProjectMemberRequest
+	if err := c.Bind(&req); err != nil {
+		slog.WarnContext(ctx, "リクエストボディのパースに失敗", "error", err)
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyInvalidRequestBody),
+		})
+	}
+	if req.UserUUID == "" {
+		slog.WarnContext(ctx, "招待するユーザーUUIDが指定されていません")
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyUserUUIDRequired),
+		})
+	}
+
+	member, err := h.projectMemberUsecase.InviteMember(ctx, userUUID, projectUUID, domainModel.InviteProjectMemberParams{
+		UserUUID: req.UserUUID,
+		Role:     req.Role,
+	})
+	if err != nil {
+		return projectMemberErrorResponse(c, err)
+	}
+
+	slog.InfoContext(ctx, "プロジェクトへの招待に成功", "project_uuid", projectUUID, "user_uuid", member.UserUUID)
+	return c.JSON(http.StatusCreated, toProjectMemberResponse(member))
+}
+
+// メンバーのロールを変更する処理
+func (h *projectMemberHandler) UpdateMemberRole(c echo.Context) error {
+	ctx := c.Request().Context()
+	projectUUID := c.Param("project_uuid")
+	memberUUID := c.Param("user_uuid")
+
+	var req model.UpdateProjectMemberRoleRequest
+	if err := c.Bind(&req); err != nil {
+		slog.WarnContext(ctx, "リクエストボディのパースに失敗", "error", err)
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyInvalidRequestBody),
+		})
+	}
+
+	member, err := h.projectMemberUsecase.UpdateMemberRole(ctx, projectUUID, memberUUID, req.Role)
+	if err != nil {
+		return projectMemberErrorResponse(c, err)
+	}
+
+	slog.InfoContext(ctx, "プロジェクトメンバーのロール変更に成功", "project_uuid", projectUUID, "user_uuid", memberUUID, "role", member.Role)
+	return c.JSON(http.StatusOK, toProjectMemberResponse(member))
+}
+
+// メンバーを削除する処理 (自分自身を指定した場合はプロジェクトから抜ける)
+func (h *projectMemberHandler) RemoveMember(c echo.Context) error {
+	ctx := c.Request().Context()
+	userUUID, ok := c.Get("user_uuid").(string)
+	if !ok {
+		slog.WarnContext(ctx, "ユーザーUUIDの取得に失敗")
+		return c.JSON(http.StatusUnauthorized, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyUserUUIDNotFound),
+		})
+	}
+	projectUUID := c.Param("project_uuid")
+	memberUUID := c.Param("user_uuid")
+
+	if err := h.projectMemberUsecase.RemoveMember(ctx, userUUID, projectUUID, memberUUID); err != nil {
+		return projectMemberErrorResponse(c, err)
+	}
+
+	slog.InfoContext(ctx, "プロジェクトメンバーの削除に成功", "project_uuid", projectUUID, "user_uuid", memberUUID)
+	return c.JSON(http.StatusOK, model.Response{
+		Status: "ok",
+	})
+}
+
+// ログイン中のユーザー宛ての招待一覧を取得する処理
+func (h *projectMemberHandler) ListInvitations(c echo.Context) error {
+	ctx := c.Request().Context()
+	userUUID, ok := c.Get("user_uuid").(string)
+	if !ok {
+		slog.WarnContext(ctx, "ユーザーUUIDの取得に失敗")
+		return c.JSON(http.StatusUnauthorized, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyUserUUIDNotFound),
+		})
+	}
+
+	invitations, err := h.projectMemberUsecase.ListInvitations(ctx, userUUID)
+	if err != nil {
+		return projectMemberErrorResponse(c, err)
+	}
+
+	res := make([]model.ProjectInvitationResponse, len(invitations))
+	for i, invitation := range invitations {
+		res[i] = model.ProjectInvitationResponse{
+			ProjectUUID:       invitation.Project.UUID,
+			ProjectTitle:      invitation.Project.Title,
+			Role:              invitation.Role,
+			InvitedByUserUUID: invitation.InvitedByUserUUID,
+			InvitedAt:         invitation.InvitedAt,
+		}
+	}
+
+	slog.InfoContext(ctx, "プロジェクト招待一覧の取得に成功", "user_uuid", userUUID, "count", len(res))
+	return c.JSON(http.StatusOK, res)
+}
+
+// 招待を承認する処理
+func (h *projectMemberHandler) AcceptInvitation(c echo.Context) error {
+	ctx := c.Request().Context()
+	userUUID, ok := c.Get("user_uuid").(string)
+	if !ok {
+		slog.WarnContext(ctx, "ユーザーUUIDの取得に失敗")
+		return c.JSON(http.StatusUnauthorized, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyUserUUIDNotFound),
+		})
+	}
+	projectUUID := c.Param("project_uuid")
+
+	if err := h.projectMemberUsecase.AcceptInvitation(ctx, userUUID, projectUUID); err != nil {
+		return projectMemberErrorResponse(c, err)
+	}
+
+	slog.InfoContext(ctx, "プロジェクト招待の承認に成功", "user_uuid", userUUID, "project_uuid", projectUUID)
+	return c.JSON(http.StatusOK, model.Response{
+		Status: "ok",
+	})
+}
+
+// 招待を辞退する処理
+func (h *projectMemberHandler) DeclineInvitation(c echo.Context) error {
+	ctx := c.Request().Context()
+	userUUID, ok := c.Get("user_uuid").(string)
+	if !ok {
+		slog.WarnContext(ctx, "ユーザーUUIDの取得に失敗")
+		return c.JSON(http.StatusUnauthorized, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyUserUUIDNotFound),
+		})
+	}
+	projectUUID := c.Param("project_uuid")
+
+	if err := h.projectMemberUsecase.DeclineInvitation(ctx, userUUID, projectUUID); err != nil {
+		return projectMemberErrorResponse(c, err)
+	}
+
+	slog.InfoContext(ctx, "プロジェクト招待の辞退に成功", "user_uuid", userUUID, "project_uuid", projectUUID)
+	return c.JSON(http.StatusOK, model.Response{
+		Status: "ok",
+	})
+}
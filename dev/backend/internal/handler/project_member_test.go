@@ -0,0 +1,305 @@
+package handler
+
+import (
+	"backend/internal/domain/model"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// モックの定義
+type mockProjectMemberUsecase struct {
+	mock.Mock
+}
+
+func (m *mockProjectMemberUsecase) ResolveRole(ctx context.Context, userUUID string, access model.ProjectAccess) (string, error) {
+	args := m.Called(ctx, userUUID, access)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockProjectMemberUsecase) ListMembers(ctx context.Context, projectUUID string) ([]*model.ProjectMember, error) {
+	args := m.Called(ctx, projectUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.ProjectMember), args.Error(1)
+}
+
+func (m *mockProjectMemberUsecase) InviteMember(ctx context.Context, inviterUUID string, projectUUID string, params model.InviteProjectMemberParams) (*model.ProjectMember, error) {
+	args := m.Called(ctx, inviterUUID, projectUUID, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ProjectMember), args.Error(1)
+}
+
+func (m *mockProjectMemberUsecase) UpdateMemberRole(ctx context.Context, projectUUID string, memberUUID string, role string) (*model.ProjectMember, error) {
+	args := m.Called(ctx, projectUUID, memberUUID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ProjectMember), args.Error(1)
+}
+
+func (m *mockProjectMemberUsecase) RemoveMember(ctx context.Context, userUUID string, projectUUID string, memberUUID string) error {
+	args := m.Called(ctx, userUUID, projectUUID, memberUUID)
+	return args.Error(0)
+}
+
+func (m *mockProjectMemberUsecase) ListInvitations(ctx context.Context, userUUID string) ([]*model.SharedProject, error) {
+	args := m.Called(ctx, userUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.SharedProject), args.Error(1)
+}
+
+func (m *mockProjectMemberUsecase) AcceptInvitation(ctx context.Context, userUUID string, projectUUID string) error {
+	args := m.Called(ctx, userUUID, projectUUID)
+	return args.Error(0)
+}
+
+func (m *mockProjectMemberUsecase) DeclineInvitation(ctx context.Context, userUUID string, projectUUID string) error {
+	args := m.Called(ctx, userUUID, projectUUID)
+	return args.Error(0)
+}
+
+func TestProjectMemberHandler_ListMembers(t *testing.T) {
+	members := []*model.ProjectMember{
+		{ProjectUUID: "project-1", UserUUID: "owner-1", UserName: "Owner", Role: model.ProjectRoleOwner, Status: model.ProjectMemberStatusAccepted},
+		{ProjectUUID: "project-1", UserUUID: "user-1", UserName: "Alice", UserAvatarURL: "https://example.com/alice.png", Role: model.ProjectRoleViewer, Status: model.ProjectMemberStatusAccepted, InvitedByUserUUID: "owner-1"},
+	}
+	tests := []struct {
+		name        string
+		role        string
+		wantUUIDs   bool
+		wantBodySub string
+	}{
+		{name: "正常系: オーナーにはユーザーUUIDを返すこと", role: model.ProjectRoleOwner, wantUUIDs: true, wantBodySub: `"user_avatar_url":"https://example.com/alice.png"`},
+		{name: "正常系: 閲覧者にはユーザーUUIDを返さないこと", role: model.ProjectRoleViewer, wantUUIDs: false, wantBodySub: `"user_name":"Alice"`},
+		{name: "正常系: 編集者にはユーザーUUIDを返さないこと", role: model.ProjectRoleEditor, wantUUIDs: false, wantBodySub: `"role":"viewer"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/projects/project-1/members", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_uuid", "user-1")
+			c.Set("project_role", tt.role)
+			c.SetParamNames("project_uuid")
+			c.SetParamValues("project-1")
+
+			mockUsecase := new(mockProjectMemberUsecase)
+			mockUsecase.On("ListMembers", mock.Anything, "project-1").Return(members, nil)
+
+			h := NewProjectMemberHandler(mockUsecase)
+			_ = h.ListMembers(c)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBodySub)
+			if tt.wantUUIDs {
+				assert.Contains(t, rec.Body.String(), `"user_uuid":"user-1"`)
+				assert.Contains(t, rec.Body.String(), `"invited_by_user_uuid":"owner-1"`)
+			} else {
+				assert.NotContains(t, rec.Body.String(), "user_uuid")
+				assert.NotContains(t, rec.Body.String(), "owner-1")
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProjectMemberHandler_InviteMember(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(m *mockProjectMemberUsecase)
+		wantStatus     int
+		wantBodySubstr string
+	}{
+		{
+			name: "正常系: ユーザーを招待できること",
+			body: `{"user_uuid":"user-1","role":"editor"}`,
+			setupMock: func(m *mockProjectMemberUsecase) {
+				m.On("InviteMember", mock.Anything, "owner-1", "project-1", model.InviteProjectMemberParams{UserUUID: "user-1", Role: "editor"}).
+					Return(&model.ProjectMember{ProjectUUID: "project-1", UserUUID: "user-1", UserName: "Alice", Role: "editor", Status: model.ProjectMemberStatusPending, InvitedByUserUUID: "owner-1", CreatedAt: time.Now()}, nil)
+			},
+			wantStatus:     http.StatusCreated,
+			wantBodySubstr: `"status":"pending"`,
+		},
+		{
+			name: "異常系: user_uuidが指定されていない場合400エラー",
+			body: `{"role":"editor"}`,
+			setupMock: func(m *mockProjectMemberUsecase) {
+				// 呼び出されない
+			},
+			wantStatus:     http.StatusBadRequest,
+			wantBodySubstr: "user_uuidは必須です",
+		},
+		{
+			name: "異常系: 不正なロールの場合400エラー",
+			body: `{"user_uuid":"user-1","role":"admin"}`,
+			setupMock: func(m *mockProjectMemberUsecase) {
+				m.On("InviteMember", mock.Anything, "owner-1", "project-1", mock.Anything).Return(nil, fmt.Errorf("%w: admin", model.ErrInvalidProjectRole))
+			},
+			wantStatus:     http.StatusBadRequest,
+			wantBodySubstr: "ロールは viewer・editor・owner のいずれかを指定してください",
+		},
+		{
+			name: "異常系: 存在しないユーザーの場合404エラー",
+			body: `{"user_uuid":"unknown"}`,
+			setupMock: func(m *mockProjectMemberUsecase) {
+				m.On("InviteMember", mock.Anything, "owner-1", "project-1", mock.Anything).Return(nil, fmt.Errorf("%w: unknown", model.ErrUserNotFound))
+			},
+			wantStatus:     http.StatusNotFound,
+			wantBodySubstr: "ユーザーが見つかりません",
+		},
+		{
+			name: "異常系: 既に招待済みの場合409エラー",
+			body: `{"user_uuid":"user-1"}`,
+			setupMock: func(m *mockProjectMemberUsecase) {
+				m.On("InviteMember", mock.Anything, "owner-1", "project-1", mock.Anything).Return(nil, fmt.Errorf("%w: user-1", model.ErrProjectMemberExists))
+			},
+			wantStatus:     http.StatusConflict,
+			wantBodySubstr: "このユーザーは既にメンバーか招待済みです",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/projects/project-1/members", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_uuid", "owner-1")
+			c.SetParamNames("project_uuid")
+			c.SetParamValues("project-1")
+
+			mockUsecase := new(mockProjectMemberUsecase)
+			tt.setupMock(mockUsecase)
+
+			h := NewProjectMemberHandler(mockUsecase)
+			_ = h.InviteMember(c)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBodySubstr)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProjectMemberHandler_RemoveMember(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(m *mockProjectMemberUsecase)
+		wantStatus     int
+		wantBodySubstr string
+	}{
+		{
+			name: "正常系: メンバーを削除できること",
+			setupMock: func(m *mockProjectMemberUsecase) {
+				m.On("RemoveMember", mock.Anything, "user-2", "project-1", "user-1").Return(nil)
+			},
+			wantStatus:     http.StatusOK,
+			wantBodySubstr: `"status":"ok"`,
+		},
+		{
+			name: "異常系: オーナー以外が他のメンバーを削除しようとした場合403エラー",
+			setupMock: func(m *mockProjectMemberUsecase) {
+				m.On("RemoveMember", mock.Anything, "user-2", "project-1", "user-1").Return(fmt.Errorf("%w: owner only", model.ErrProjectRoleDenied))
+			},
+			wantStatus:     http.StatusForbidden,
+			wantBodySubstr: "このプロジェクトでの権限では許可されていない操作です",
+		},
+		{
+			name: "異常系: Usecaseでエラーが発生した場合500エラー",
+			setupMock: func(m *mockProjectMemberUsecase) {
+				m.On("RemoveMember", mock.Anything, "user-2", "project-1", "user-1").Return(errors.New("usecase error"))
+			},
+			wantStatus:     http.StatusInternalServerError,
+			wantBodySubstr: "usecase error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodDelete, "/api/projects/project-1/members/user-1", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_uuid", "user-2")
+			c.SetParamNames("project_uuid", "user_uuid")
+			c.SetParamValues("project-1", "user-1")
+
+			mockUsecase := new(mockProjectMemberUsecase)
+			tt.setupMock(mockUsecase)
+
+			h := NewProjectMemberHandler(mockUsecase)
+			_ = h.RemoveMember(c)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBodySubstr)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProjectMemberHandler_AcceptInvitation(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(m *mockProjectMemberUsecase)
+		wantStatus     int
+		wantBodySubstr string
+	}{
+		{
+			name: "正常系: 招待を承認できること",
+			setupMock: func(m *mockProjectMemberUsecase) {
+				m.On("AcceptInvitation", mock.Anything, "user-1", "project-1").Return(nil)
+			},
+			wantStatus:     http.StatusOK,
+			wantBodySubstr: `"status":"ok"`,
+		},
+		{
+			name: "異常系: 招待が存在しない場合404エラー",
+			setupMock: func(m *mockProjectMemberUsecase) {
+				m.On("AcceptInvitation", mock.Anything, "user-1", "project-1").Return(fmt.Errorf("%w: project-1", model.ErrProjectMemberNotFound))
+			},
+			wantStatus:     http.StatusNotFound,
+			wantBodySubstr: "メンバーまたは招待が見つかりません",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/users/me/invitations/project-1/accept", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_uuid", "user-1")
+			c.SetParamNames("project_uuid")
+			c.SetParamValues("project-1")
+
+			mockUsecase := new(mockProjectMemberUsecase)
+			tt.setupMock(mockUsecase)
+
+			h := NewProjectMemberHandler(mockUsecase)
+			_ = h.AcceptInvitation(c)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBodySubstr)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
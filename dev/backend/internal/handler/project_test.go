@@ -6,6 +6,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -22,12 +23,12 @@ type mockProjectUsecase struct {
 	mock.Mock
 }
 
-func (m *mockProjectUsecase) GetProjects(ctx context.Context, userUUID string) ([]*model.Project, error) {
+func (m *mockProjectUsecase) GetProjects(ctx context.Context, userUUID string) (*model.ProjectList, error) {
 	args := m.Called(ctx, userUUID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*model.Project), args.Error(1)
+	return args.Get(0).(*model.ProjectList), args.Error(1)
 }
 
 func (m *mockProjectUsecase) CreateProject(ctx context.Context, userUUID, initialMessage string) (*model.Project, *model.Chat, *model.Message, error) {
@@ -46,14 +47,43 @@ func (m *mockProjectUsecase) GetParentChat(ctx context.Context, projectUUID stri
 	return args.Get(0).(*model.Chat), args.Error(1)
 }
 
-func (m *mockProjectUsecase) GetProjectTree(ctx context.Context, projectUUID string) (*model.ProjectTree, error) {
-	args := m.Called(ctx, projectUUID)
+func (m *mockProjectUsecase) GetProjectTree(ctx context.Context, projectUUID string, query model.ProjectTreeQuery) (*model.ProjectTree, error) {
+	args := m.Called(ctx, projectUUID, query)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.ProjectTree), args.Error(1)
 }
 
+func (m *mockProjectUsecase) GetProjectTreeNode(ctx context.Context, projectUUID, nodeID string) (*model.ProjectNode, error) {
+	args := m.Called(ctx, projectUUID, nodeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ProjectNode), args.Error(1)
+}
+
+func (m *mockProjectUsecase) UpdateLanguage(ctx context.Context, projectUUID, language string) error {
+	args := m.Called(ctx, projectUUID, language)
+	return args.Error(0)
+}
+
+func (m *mockProjectUsecase) UpdateTreeNodePositions(ctx context.Context, projectUUID string, updates []model.TreeNodePositionUpdate) ([]*model.TreeNodeState, error) {
+	args := m.Called(ctx, projectUUID, updates)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.TreeNodeState), args.Error(1)
+}
+
+func (m *mockProjectUsecase) SetTreeNodeCollapsed(ctx context.Context, projectUUID string, nodeID string, collapsed bool, version int) (*model.TreeNodeState, error) {
+	args := m.Called(ctx, projectUUID, nodeID, collapsed, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.TreeNodeState), args.Error(1)
+}
+
 func TestProjectHandler_GetProjects(t *testing.T) {
 	type args struct {
 		userUUID interface{} // コンテキストにセットする値
@@ -71,14 +101,33 @@ func TestProjectHandler_GetProjects(t *testing.T) {
 				userUUID: "user-1",
 			},
 			setupMock: func(m *mockProjectUsecase) {
-				projects := []*model.Project{
-					{UUID: "p1", UserUUID: "user-1", Title: "Project 1", UpdatedAt: time.Now()},
+				projects := &model.ProjectList{
+					Owned: []*model.Project{
+						{UUID: "p1", UserUUID: "user-1", Title: "Project 1", UpdatedAt: time.Now()},
+					},
 				}
 				m.On("GetProjects", mock.Anything, "user-1").Return(projects, nil)
 			},
 			wantStatus:     http.StatusOK,
 			wantBodySubstr: "Project 1",
 		},
+		{
+			name: "正常系: 共有されたプロジェクトがロールと共に別に返されること",
+			args: args{
+				userUUID: "user-1",
+			},
+			setupMock: func(m *mockProjectUsecase) {
+				projects := &model.ProjectList{
+					Owned: []*model.Project{},
+					Shared: []*model.SharedProject{
+						{Project: &model.Project{UUID: "p2", UserUUID: "user-2", Title: "Project 2"}, Role: model.ProjectRoleViewer},
+					},
+				}
+				m.On("GetProjects", mock.Anything, "user-1").Return(projects, nil)
+			},
+			wantStatus:     http.StatusOK,
+			wantBodySubstr: `"owned":[],"shared":[{"uuid":"p2","title":"Project 2","language":"","updated_at":"0001-01-01T00:00:00Z","role":"viewer","owner_user_uuid":"user-2"}]`,
+		},
 		{
 			name: "異常系: ユーザーUUIDがコンテキストにない場合401エラー",
 			args: args{
@@ -135,10 +184,10 @@ func TestProjectHandler_GetProjects(t *testing.T) {
 
 			// レスポンスボディの構造チェック (正常系のみ)
 			if tt.wantStatus == http.StatusOK {
-				var res []*handlerModel.ProjectResponse
+				var res handlerModel.GetProjectsResponse
 				err := json.Unmarshal(rec.Body.Bytes(), &res)
 				assert.NoError(t, err)
-				assert.NotEmpty(t, res)
+				assert.NotZero(t, len(res.Owned)+len(res.Shared))
 			}
 
 			mockUsecase.AssertExpectations(t)
@@ -333,8 +382,10 @@ func TestProjectHandler_GetParentChat(t *testing.T) {
 }
 
 func TestProjectHandler_GetProjectTree(t *testing.T) {
+	depth := 1
 	type args struct {
 		projectUUID string
+		query       string
 	}
 	tests := []struct {
 		name           string
@@ -357,11 +408,48 @@ func TestProjectHandler_GetProjectTree(t *testing.T) {
 						{ID: "edge-1"},
 					},
 				}
-				m.On("GetProjectTree", mock.Anything, "project-uuid").Return(tree, nil)
+				m.On("GetProjectTree", mock.Anything, "project-uuid", model.ProjectTreeQuery{}).Return(tree, nil)
 			},
 			wantStatus:     http.StatusOK,
 			wantBodySubstr: "node-1",
 		},
+		{
+			name: "正常系: クエリパラメータで取得条件を指定できること",
+			args: args{
+				projectUUID: "project-uuid",
+				query:       "?chat_uuid=chat-1&depth=1&exclude_status=closed,merged&summary=true",
+			},
+			setupMock: func(m *mockProjectUsecase) {
+				tree := &model.ProjectTree{
+					Nodes: []model.ProjectNode{
+						{ID: "node-1", Title: "タイトル", Truncated: true, HasMoreChildren: true},
+					},
+					Edges: []model.ProjectEdge{},
+				}
+				m.On("GetProjectTree", mock.Anything, "project-uuid", model.ProjectTreeQuery{
+					ChatUUID:        "chat-1",
+					MaxDepth:        &depth,
+					ExcludeStatuses: []string{model.ChatStatusClosed, model.ChatStatusMerged},
+					SummaryOnly:     true,
+				}).Return(tree, nil)
+			},
+			wantStatus:     http.StatusOK,
+			wantBodySubstr: `"has_more_children":true`,
+		},
+		{
+			name: "正常系: 除外するステータスを複数指定できること",
+			args: args{
+				projectUUID: "project-uuid",
+				query:       "?exclude_status=closed&exclude_status=merged",
+			},
+			setupMock: func(m *mockProjectUsecase) {
+				m.On("GetProjectTree", mock.Anything, "project-uuid", model.ProjectTreeQuery{
+					ExcludeStatuses: []string{model.ChatStatusClosed, model.ChatStatusMerged},
+				}).Return(&model.ProjectTree{}, nil)
+			},
+			wantStatus:     http.StatusOK,
+			wantBodySubstr: "nodes",
+		},
 		{
 			name: "異常系: project_uuidが空の場合400エラー",
 			args: args{
@@ -373,13 +461,38 @@ func TestProjectHandler_GetProjectTree(t *testing.T) {
 			wantStatus:     http.StatusBadRequest,
 			wantBodySubstr: "project_uuidは必須です",
 		},
+		{
+			name: "異常系: 階層数が数値でない場合400エラー",
+			args: args{
+				projectUUID: "project-uuid",
+				query:       "?depth=abc",
+			},
+			setupMock: func(m *mockProjectUsecase) {
+				// 呼び出されない
+			},
+			wantStatus:     http.StatusBadRequest,
+			wantBodySubstr: "ツリーの取得条件が不正です",
+		},
+		{
+			name: "異常系: 取得条件が不正な場合400エラー",
+			args: args{
+				projectUUID: "project-uuid",
+				query:       "?chat_uuid=other-chat",
+			},
+			setupMock: func(m *mockProjectUsecase) {
+				m.On("GetProjectTree", mock.Anything, "project-uuid", model.ProjectTreeQuery{ChatUUID: "other-chat"}).
+					Return(nil, fmt.Errorf("%w: プロジェクトに存在しないチャットです", model.ErrInvalidTreeQuery))
+			},
+			wantStatus:     http.StatusBadRequest,
+			wantBodySubstr: "ツリーの取得条件が不正です",
+		},
 		{
 			name: "異常系: Usecaseでエラーが発生した場合500エラー",
 			args: args{
 				projectUUID: "project-error",
 			},
 			setupMock: func(m *mockProjectUsecase) {
-				m.On("GetProjectTree", mock.Anything, "project-error").Return(nil, errors.New("usecase error"))
+				m.On("GetProjectTree", mock.Anything, "project-error", model.ProjectTreeQuery{}).Return(nil, errors.New("usecase error"))
 			},
 			wantStatus:     http.StatusInternalServerError,
 			wantBodySubstr: "usecase error",
@@ -390,7 +503,7 @@ func TestProjectHandler_GetProjectTree(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Echoのセットアップ
 			e := echo.New()
-			req := httptest.NewRequest(http.MethodGet, "/api/projects/"+tt.args.projectUUID+"/tree", nil)
+			req := httptest.NewRequest(http.MethodGet, "/api/projects/"+tt.args.projectUUID+"/tree"+tt.args.query, nil)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 			c.SetParamNames("project_uuid")
@@ -414,3 +527,344 @@ func TestProjectHandler_GetProjectTree(t *testing.T) {
 		})
 	}
 }
+
+func TestProjectHandler_GetProjectTreeNode(t *testing.T) {
+	userMessage := "質問の全文"
+	tests := []struct {
+		name           string
+		nodeID         string
+		setupMock      func(m *mockProjectUsecase)
+		wantStatus     int
+		wantBodySubstr string
+	}{
+		{
+			name:   "正常系: ノードの全文が取得できること",
+			nodeID: "node-1",
+			setupMock: func(m *mockProjectUsecase) {
+				m.On("GetProjectTreeNode", mock.Anything, "project-uuid", "node-1").Return(&model.ProjectNode{
+					ID:       "node-1",
+					ChatUUID: "chat-1",
+					Data:     model.ProjectNodeData{UserMessage: &userMessage, Assistant: "回答の全文"},
+				}, nil)
+			},
+			wantStatus:     http.StatusOK,
+			wantBodySubstr: "回答の全文",
+		},
+		{
+			name:   "異常系: ノードがツリーにない場合404エラー",
+			nodeID: "missing",
+			setupMock: func(m *mockProjectUsecase) {
+				m.On("GetProjectTreeNode", mock.Anything, "project-uuid", "missing").
+					Return(nil, fmt.Errorf("%w: node_id: missing", model.ErrTreeNodeNotFound))
+			},
+			wantStatus:     http.StatusNotFound,
+			wantBodySubstr: "指定したノードがプロジェクトのツリーにありません",
+		},
+		{
+			name:   "異常系: Usecaseでエラーが発生した場合500エラー",
+			nodeID: "node-1",
+			setupMock: func(m *mockProjectUsecase) {
+				m.On("GetProjectTreeNode", mock.Anything, "project-uuid", "node-1").Return(nil, errors.New("usecase error"))
+			},
+			wantStatus:     http.StatusInternalServerError,
+			wantBodySubstr: "usecase error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Echoのセットアップ
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/projects/project-uuid/tree/nodes/"+tt.nodeID, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("project_uuid", "node_id")
+			c.SetParamValues("project-uuid", tt.nodeID)
+
+			// モックのセットアップ
+			mockUsecase := new(mockProjectUsecase)
+			tt.setupMock(mockUsecase)
+
+			h := NewProjectHandler(mockUsecase)
+			_ = h.GetProjectTreeNode(c)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBodySubstr)
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProjectHandler_UpdateLanguage(t *testing.T) {
+	type args struct {
+		projectUUID string
+		body        string
+	}
+	tests := []struct {
+		name           string
+		args           args
+		setupMock      func(m *mockProjectUsecase)
+		wantStatus     int
+		wantBodySubstr string
+	}{
+		{
+			name: "正常系: 出力言語が更新できること",
+			args: args{
+				projectUUID: "project-uuid",
+				body:        `{"language":"en"}`,
+			},
+			setupMock: func(m *mockProjectUsecase) {
+				m.On("UpdateLanguage", mock.Anything, "project-uuid", "en").Return(nil)
+			},
+			wantStatus:     http.StatusOK,
+			wantBodySubstr: `"language":"en"`,
+		},
+		{
+			name: "異常系: project_uuidが空の場合400エラー",
+			args: args{
+				projectUUID: "",
+				body:        `{"language":"en"}`,
+			},
+			setupMock: func(m *mockProjectUsecase) {
+				// 呼び出されない
+			},
+			wantStatus:     http.StatusBadRequest,
+			wantBodySubstr: "project_uuidは必須です",
+		},
+		{
+			name: "異常系: 対応していない言語の場合400エラー",
+			args: args{
+				projectUUID: "project-uuid",
+				body:        `{"language":"fr"}`,
+			},
+			setupMock: func(m *mockProjectUsecase) {
+				m.On("UpdateLanguage", mock.Anything, "project-uuid", "fr").Return(fmt.Errorf("出力言語の検証に失敗: %w", model.ErrUnsupportedLanguage))
+			},
+			wantStatus:     http.StatusBadRequest,
+			wantBodySubstr: "対応していない言語です",
+		},
+		{
+			name: "異常系: Usecaseでエラーが発生した場合500エラー",
+			args: args{
+				projectUUID: "project-uuid",
+				body:        `{"language":"ja"}`,
+			},
+			setupMock: func(m *mockProjectUsecase) {
+				m.On("UpdateLanguage", mock.Anything, "project-uuid", "ja").Return(errors.New("usecase error"))
+			},
+			wantStatus:     http.StatusInternalServerError,
+			wantBodySubstr: "usecase error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Echoのセットアップ
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPut, "/api/projects/"+tt.args.projectUUID+"/language", strings.NewReader(tt.args.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("project_uuid")
+			c.SetParamValues(tt.args.projectUUID)
+
+			// モックのセットアップ
+			mockUsecase := new(mockProjectUsecase)
+			tt.setupMock(mockUsecase)
+
+			h := NewProjectHandler(mockUsecase)
+			_ = h.UpdateLanguage(c)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBodySubstr)
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProjectHandler_UpdateTreeNodePositions(t *testing.T) {
+	type args struct {
+		projectUUID string
+		body        string
+	}
+	tests := []struct {
+		name           string
+		args           args
+		setupMock      func(m *mockProjectUsecase)
+		wantStatus     int
+		wantBodySubstr string
+	}{
+		{
+			name: "正常系: ノードの位置をまとめて更新できること",
+			args: args{
+				projectUUID: "project-uuid",
+				body:        `{"positions":[{"node_id":"node-1","x":10,"y":20,"version":1},{"node_id":"node-2","reset":true,"version":2}]}`,
+			},
+			setupMock: func(m *mockProjectUsecase) {
+				m.On("UpdateTreeNodePositions", mock.Anything, "project-uuid", []model.TreeNodePositionUpdate{
+					{NodeID: "node-1", Position: model.ProjectNodePosition{X: 10, Y: 20}, Version: 1},
+					{NodeID: "node-2", Reset: true, Version: 2},
+				}).Return([]*model.TreeNodeState{
+					{NodeID: "node-1", Position: &model.ProjectNodePosition{X: 10, Y: 20}, Version: 2},
+					{NodeID: "node-2", Version: 3},
+				}, nil)
+			},
+			wantStatus:     http.StatusOK,
+			wantBodySubstr: `{"nodes":[{"node_id":"node-1","position":{"x":10,"y":20},"collapsed":false,"version":2},{"node_id":"node-2","position":null,"collapsed":false,"version":3}]}`,
+		},
+		{
+			name: "異常系: リクエストボディが不正な場合400エラー",
+			args: args{
+				projectUUID: "project-uuid",
+				body:        `{"positions":"invalid"}`,
+			},
+			setupMock: func(m *mockProjectUsecase) {
+				// 呼び出されない
+			},
+			wantStatus:     http.StatusBadRequest,
+			wantBodySubstr: "error",
+		},
+		{
+			name: "異常系: ツリーにないノードを指定した場合400エラー",
+			args: args{
+				projectUUID: "project-uuid",
+				body:        `{"positions":[{"node_id":"other","x":10,"y":20}]}`,
+			},
+			setupMock: func(m *mockProjectUsecase) {
+				m.On("UpdateTreeNodePositions", mock.Anything, "project-uuid", mock.Anything).Return(nil, fmt.Errorf("%w: node_id: other", model.ErrInvalidTreeNode))
+			},
+			wantStatus:     http.StatusBadRequest,
+			wantBodySubstr: "指定したノードがプロジェクトのツリーにないか、位置が不正です",
+		},
+		{
+			name: "異常系: 他の操作で更新されていた場合409エラー",
+			args: args{
+				projectUUID: "project-uuid",
+				body:        `{"positions":[{"node_id":"node-1","x":10,"y":20,"version":1}]}`,
+			},
+			setupMock: func(m *mockProjectUsecase) {
+				m.On("UpdateTreeNodePositions", mock.Anything, "project-uuid", mock.Anything).Return(nil, fmt.Errorf("%w: node_id: node-1", model.ErrTreeNodeConflict))
+			},
+			wantStatus:     http.StatusConflict,
+			wantBodySubstr: "ノードの配置が他の操作で更新されています",
+		},
+		{
+			name: "異常系: Usecaseでエラーが発生した場合500エラー",
+			args: args{
+				projectUUID: "project-uuid",
+				body:        `{"positions":[{"node_id":"node-1","x":10,"y":20}]}`,
+			},
+			setupMock: func(m *mockProjectUsecase) {
+				m.On("UpdateTreeNodePositions", mock.Anything, "project-uuid", mock.Anything).Return(nil, errors.New("usecase error"))
+			},
+			wantStatus:     http.StatusInternalServerError,
+			wantBodySubstr: "usecase error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Echoのセットアップ
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPut, "/api/projects/"+tt.args.projectUUID+"/tree/positions", strings.NewReader(tt.args.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("project_uuid")
+			c.SetParamValues(tt.args.projectUUID)
+
+			// モックのセットアップ
+			mockUsecase := new(mockProjectUsecase)
+			tt.setupMock(mockUsecase)
+
+			h := NewProjectHandler(mockUsecase)
+			_ = h.UpdateTreeNodePositions(c)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBodySubstr)
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProjectHandler_SetTreeNodeCollapsed(t *testing.T) {
+	type args struct {
+		nodeID string
+		body   string
+	}
+	tests := []struct {
+		name           string
+		args           args
+		setupMock      func(m *mockProjectUsecase)
+		wantStatus     int
+		wantBodySubstr string
+	}{
+		{
+			name: "正常系: ノードを折りたたみできること",
+			args: args{
+				nodeID: "node-1",
+				body:   `{"collapsed":true,"version":0}`,
+			},
+			setupMock: func(m *mockProjectUsecase) {
+				m.On("SetTreeNodeCollapsed", mock.Anything, "project-uuid", "node-1", true, 0).
+					Return(&model.TreeNodeState{NodeID: "node-1", Collapsed: true, Version: 1}, nil)
+			},
+			wantStatus:     http.StatusOK,
+			wantBodySubstr: `{"node_id":"node-1","position":null,"collapsed":true,"version":1}`,
+		},
+		{
+			name: "異常系: 他の操作で更新されていた場合409エラー",
+			args: args{
+				nodeID: "node-1",
+				body:   `{"collapsed":false,"version":1}`,
+			},
+			setupMock: func(m *mockProjectUsecase) {
+				m.On("SetTreeNodeCollapsed", mock.Anything, "project-uuid", "node-1", false, 1).
+					Return(nil, fmt.Errorf("%w: node_id: node-1", model.ErrTreeNodeConflict))
+			},
+			wantStatus:     http.StatusConflict,
+			wantBodySubstr: "ノードの配置が他の操作で更新されています",
+		},
+		{
+			name: "異常系: ツリーにないノードを指定した場合400エラー",
+			args: args{
+				nodeID: "other",
+				body:   `{"collapsed":true}`,
+			},
+			setupMock: func(m *mockProjectUsecase) {
+				m.On("SetTreeNodeCollapsed", mock.Anything, "project-uuid", "other", true, 0).
+					Return(nil, fmt.Errorf("%w: node_id: other", model.ErrInvalidTreeNode))
+			},
+			wantStatus:     http.StatusBadRequest,
+			wantBodySubstr: "指定したノードがプロジェクトのツリーにないか、位置が不正です",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Echoのセットアップ
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPut, "/api/projects/project-uuid/tree/nodes/"+tt.args.nodeID+"/collapsed", strings.NewReader(tt.args.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("project_uuid", "node_id")
+			c.SetParamValues("project-uuid", tt.args.nodeID)
+
+			// モックのセットアップ
+			mockUsecase := new(mockProjectUsecase)
+			tt.setupMock(mockUsecase)
+
+			h := NewProjectHandler(mockUsecase)
+			_ = h.SetTreeNodeCollapsed(c)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBodySubstr)
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
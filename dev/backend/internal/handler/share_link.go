@@ -0,0 +1,207 @@
+package handler
+
+import (
+	domainModel "backend/internal/domain/model"
+	"backend/internal/domain/usecase"
+	"backend/internal/handler/model"
+	"backend/internal/i18n"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type shareLinkHandler struct {
+	shareLinkUsecase usecase.ShareLinkUsecase
+}
+
+// shareLinkHandlerの新しいインスタンスを作成する処理
+func NewShareLinkHandler(shareLinkUsecase usecase.ShareLinkUsecase) *shareLinkHandler {
+	return &shareLinkHandler{
+		shareLinkUsecase: shareLinkUsecase,
+	}
+}
+
+// ドメインモデルの共有リンクをレスポンスに変換する処理
+func toShareLinkResponse(link *domainModel.ShareLink) model.ShareLinkResponse {
+	return model.ShareLinkResponse{
+		UUID:         link.UUID,
+		Prefix:       link.Prefix,
+		RootChatUUID: link.RootChatUUID,
+		ExpiresAt:    link.ExpiresAt,
+		CreatedAt:    link.CreatedAt,
+	}
+}
+
+// 共有リンクを発行する処理
+func (h *shareLinkHandler) CreateLink(c echo.Context) error {
+	ctx := c.Request().Context()
+	userUUID, ok := c.Get("user_uuid").(string)
+	if !ok {
+		slog.WarnContext(ctx, "ユーザーUUIDの取得に失敗")
+		return c.JSON(http.StatusUnauthorized, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyUserUUIDNotFound),
+		})
+	}
+	projectUUID := c.Param("project_uuid")
+
+	var req model.CreateShareLinkRequest
+	if err := c.Bind(&req); err != nil {
+		slog.WarnContext(ctx, "リクエストボディのバインドに失敗", "error", err)
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyInvalidRequestBody),
+		})
+	}
+
+	link, rawToken, err := h.shareLinkUsecase.CreateLink(ctx, userUUID, projectUUID, domainModel.CreateShareLinkParams{
+		RootChatUUID: req.RootChatUUID,
+		ExpiresAt:    req.ExpiresAt,
+	})
+	if err != nil {
+		if errors.Is(err, domainModel.ErrInvalidShareLinkParams) {
+			slog.WarnContext(ctx, "共有リンクの発行パラメータが不正です", "error", err)
+			return c.JSON(http.StatusBadRequest, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyInvalidShareLinkParams),
+			})
+		}
+		slog.ErrorContext(ctx, "共有リンクの発行に失敗", "error", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
+	}
+
+	slog.InfoContext(ctx, "共有リンクの発行に成功", "project_uuid", projectUUID, "share_link_uuid", link.UUID)
+	return c.JSON(http.StatusCreated, model.CreateShareLinkResponse{
+		ShareLinkResponse: toShareLinkResponse(link),
+		Token:             rawToken,
+	})
+}
+
+// プロジェクトの共有リンク一覧を取得する処理
+func (h *shareLinkHandler) ListLinks(c echo.Context) error {
+	ctx := c.Request().Context()
+	projectUUID := c.Param("project_uuid")
+
+	links, err := h.shareLinkUsecase.ListLinks(ctx, projectUUID)
+	if err != nil {
+		slog.ErrorContext(ctx, "共有リンク一覧の取得に失敗", "error", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
+	}
+
+	res := make([]model.ShareLinkResponse, len(links))
+	for i, link := range links {
+		res[i] = toShareLinkResponse(link)
+	}
+
+	slog.InfoContext(ctx, "共有リンク一覧の取得に成功", "project_uuid", projectUUID, "count", len(res))
+	return c.JSON(http.StatusOK, res)
+}
+
+// 共有リンクを失効させる処理
+func (h *shareLinkHandler) RevokeLink(c echo.Context) error {
+	ctx := c.Request().Context()
+	projectUUID := c.Param("project_uuid")
+	linkUUID := c.Param("link_uuid")
+
+	if err := h.shareLinkUsecase.RevokeLink(ctx, projectUUID, linkUUID); err != nil {
+		if errors.Is(err, domainModel.ErrShareLinkNotFound) {
+			slog.WarnContext(ctx, "共有リンクが見つかりません", "share_link_uuid", linkUUID)
+			return c.JSON(http.StatusNotFound, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyShareLinkNotFound),
+			})
+		}
+		slog.ErrorContext(ctx, "共有リンクの失効に失敗", "error", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
+	}
+
+	slog.InfoContext(ctx, "共有リンクの失効に成功", "project_uuid", projectUUID, "share_link_uuid", linkUUID)
+	return c.JSON(http.StatusOK, model.Response{
+		Status: "ok",
+	})
+}
+
+// 共有リンクで公開しているプロジェクトの概要を取得する処理 (認証不要)
+func (h *shareLinkHandler) GetSharedView(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	view, err := h.shareLinkUsecase.GetSharedView(ctx, c.Param("token"))
+	if err != nil {
+		return sharedErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, model.SharedViewResponse{
+		ProjectTitle: view.ProjectTitle,
+		RootChatUUID: view.RootChatUUID,
+		ExpiresAt:    view.ExpiresAt,
+	})
+}
+
+// 共有リンクで公開しているツリーを取得する処理 (認証不要)
+func (h *shareLinkHandler) GetSharedTree(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	tree, err := h.shareLinkUsecase.GetSharedTree(ctx, c.Param("token"))
+	if err != nil {
+		return sharedErrorResponse(c, err)
+	}
+
+	res := mapProjectTreeToResponse(tree)
+	slog.InfoContext(ctx, "共有ツリーの取得に成功", "nodes", len(res.Nodes), "edges", len(res.Edges))
+	return c.JSON(http.StatusOK, res)
+}
+
+// 共有リンクで公開しているチャットのメッセージを取得する処理 (認証不要)
+func (h *shareLinkHandler) GetSharedMessages(c echo.Context) error {
+	ctx := c.Request().Context()
+	chatUUID := c.Param("chat_uuid")
+
+	messages, err := h.shareLinkUsecase.GetSharedMessages(ctx, c.Param("token"), chatUUID)
+	if err != nil {
+		return sharedErrorResponse(c, err)
+	}
+
+	res := make([]model.MessageResponse, len(messages))
+	for i, m := range messages {
+		res[i] = mapMessageToResponse(m)
+	}
+
+	slog.InfoContext(ctx, "共有メッセージの取得に成功", "chat_uuid", chatUUID, "count", len(res))
+	return c.JSON(http.StatusOK, res)
+}
+
+// 共有リンクの閲覧時のエラーをレスポンスに変換する処理
+// 無効なリンクと公開範囲外のチャットは、存在を推測されないよう 404 を返す
+func sharedErrorResponse(c echo.Context, err error) error {
+	ctx := c.Request().Context()
+	switch {
+	case errors.Is(err, domainModel.ErrShareLinkNotFound):
+		slog.WarnContext(ctx, "無効な共有リンクです")
+		return c.JSON(http.StatusNotFound, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyShareLinkNotFound),
+		})
+	case errors.Is(err, domainModel.ErrChatNotShared):
+		slog.WarnContext(ctx, "共有されていないチャットです", "chat_uuid", c.Param("chat_uuid"))
+		return c.JSON(http.StatusNotFound, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyChatNotShared),
+		})
+	}
+	slog.ErrorContext(ctx, "共有リンクの閲覧に失敗", "error", err)
+	return c.JSON(http.StatusInternalServerError, model.Response{
+		Status:  "error",
+		Message: err.Error(),
+	})
+}
@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"backend/internal/domain/model"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// モックの定義
+type mockShareLinkUsecase struct {
+	mock.Mock
+}
+
+func (m *mockShareLinkUsecase) CreateLink(ctx context.Context, userUUID string, projectUUID string, params model.CreateShareLinkParams) (*model.ShareLink, string, error) {
+	args := m.Called(ctx, userUUID, projectUUID, params)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*model.ShareLink), args.String(1), args.Error(2)
+}
+
+func (m *mockShareLinkUsecase) ListLinks(ctx context.Context, projectUUID string) ([]*model.ShareLink, error) {
+	args := m.Called(ctx, projectUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.ShareLink), args.Error(1)
+}
+
+func (m *mockShareLinkUsecase) RevokeLink(ctx context.Context, projectUUID string, linkUUID string) error {
+	args := m.Called(ctx, projectUUID, linkUUID)
+	return args.Error(0)
+}
+
+func (m *mockShareLinkUsecase) GetSharedView(ctx context.Context, rawToken string) (*model.SharedView, error) {
+	args := m.Called(ctx, rawToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.SharedView), args.Error(1)
+}
+
+func (m *mockShareLinkUsecase) GetSharedTree(ctx context.Context, rawToken string) (*model.ProjectTree, error) {
+	args := m.Called(ctx, rawToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ProjectTree), args.Error(1)
+}
+
+func (m *mockShareLinkUsecase) GetSharedMessages(ctx context.Context, rawToken string, chatUUID string) ([]*model.Message, error) {
+	args := m.Called(ctx, rawToken, chatUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Message), args.Error(1)
+}
+
+func TestShareLinkHandler_CreateLink(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(m *mockShareLinkUsecase)
+		wantStatus     int
+		wantBodySubstr string
+	}{
+		{
+			name: "正常系: 共有リンクが発行され平文のトークンが返されること",
+			body: `{"root_chat_uuid":"chat-uuid"}`,
+			setupMock: func(m *mockShareLinkUsecase) {
+				m.On("CreateLink", mock.Anything, "user-uuid", "project-uuid", model.CreateShareLinkParams{RootChatUUID: "chat-uuid"}).
+					Return(&model.ShareLink{UUID: "link-uuid", Prefix: "cbs_abcdefgh", RootChatUUID: "chat-uuid"}, "cbs_secret", nil)
+			},
+			wantStatus:     http.StatusCreated,
+			wantBodySubstr: `"token":"cbs_secret"`,
+		},
+		{
+			name: "異常系: パラメータが不正な場合400エラー",
+			body: `{"root_chat_uuid":"other-chat-uuid"}`,
+			setupMock: func(m *mockShareLinkUsecase) {
+				m.On("CreateLink", mock.Anything, "user-uuid", "project-uuid", mock.Anything).Return(nil, "", fmt.Errorf("%w: other-chat-uuid", model.ErrInvalidShareLinkParams))
+			},
+			wantStatus:     http.StatusBadRequest,
+			wantBodySubstr: "共有するチャットまたは有効期限が正しくありません",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/projects/project-uuid/share-links", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_uuid", "user-uuid")
+			c.SetParamNames("project_uuid")
+			c.SetParamValues("project-uuid")
+
+			mockUsecase := new(mockShareLinkUsecase)
+			tt.setupMock(mockUsecase)
+
+			h := NewShareLinkHandler(mockUsecase)
+			_ = h.CreateLink(c)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBodySubstr)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestShareLinkHandler_GetSharedMessages(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(m *mockShareLinkUsecase)
+		wantStatus     int
+		wantBodySubstr string
+	}{
+		{
+			name: "正常系: 共有されたチャットのメッセージが取得できること",
+			setupMock: func(m *mockShareLinkUsecase) {
+				m.On("GetSharedMessages", mock.Anything, "cbs_secret", "chat-uuid").Return([]*model.Message{
+					{UUID: "msg-1", Role: "user", Content: "question"},
+				}, nil)
+			},
+			wantStatus:     http.StatusOK,
+			wantBodySubstr: `"content":"question"`,
+		},
+		{
+			name: "異常系: 無効な共有リンクの場合404エラー",
+			setupMock: func(m *mockShareLinkUsecase) {
+				m.On("GetSharedMessages", mock.Anything, "cbs_secret", "chat-uuid").Return(nil, model.ErrShareLinkNotFound)
+			},
+			wantStatus:     http.StatusNotFound,
+			wantBodySubstr: "共有リンクが見つからないか、有効期限が切れています",
+		},
+		{
+			name: "異常系: 公開範囲外のチャットの場合404エラー",
+			setupMock: func(m *mockShareLinkUsecase) {
+				m.On("GetSharedMessages", mock.Anything, "cbs_secret", "chat-uuid").Return(nil, fmt.Errorf("%w: chat-uuid", model.ErrChatNotShared))
+			},
+			wantStatus:     http.StatusNotFound,
+			wantBodySubstr: "このチャットは共有されていません",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/share/cbs_secret/chats/chat-uuid/messages", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("token", "chat_uuid")
+			c.SetParamValues("cbs_secret", "chat-uuid")
+
+			mockUsecase := new(mockShareLinkUsecase)
+			tt.setupMock(mockUsecase)
+
+			h := NewShareLinkHandler(mockUsecase)
+			_ = h.GetSharedMessages(c)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBodySubstr)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	domainModel "backend/internal/domain/model"
+	"backend/internal/domain/usecase"
+	"backend/internal/handler/model"
+	"backend/internal/i18n"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type userHandler struct {
+	userUsecase usecase.UserUsecase
+}
+
+// userHandlerの新しいインスタンスを作成する処理
+func NewUserHandler(userUsecase usecase.UserUsecase) *userHandler {
+	return &userHandler{
+		userUsecase: userUsecase,
+	}
+}
+
+// ドメインモデルをレスポンスに変換する処理
+func toUserResponse(user *domainModel.User) model.UserResponse {
+	return model.UserResponse{
+		UUID:      user.UUID,
+		Name:      user.Name,
+		Language:  user.Language,
+		Provider:  user.Provider,
+		AvatarURL: user.AvatarURL,
+	}
+}
+
+// ログイン中のユーザーのプロフィールを取得する処理
+func (h *userHandler) GetProfile(c echo.Context) error {
+	ctx := c.Request().Context()
+	userUUID, ok := c.Get("user_uuid").(string)
+	if !ok {
+		slog.WarnContext(ctx, "ユーザーUUIDの取得に失敗")
+		return c.JSON(http.StatusUnauthorized, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyUserUUIDNotFound),
+		})
+	}
+
+	user, err := h.userUsecase.GetProfile(ctx, userUUID)
+	if err != nil {
+		slog.ErrorContext(ctx, "プロフィールの取得に失敗", "error", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
+	}
+
+	slog.InfoContext(ctx, "プロフィールの取得に成功", "user_uuid", userUUID)
+	return c.JSON(http.StatusOK, toUserResponse(user))
+}
+
+// ログイン中のユーザーの優先言語を更新する処理
+func (h *userHandler) UpdateLanguage(c echo.Context) error {
+	ctx := c.Request().Context()
+	userUUID, ok := c.Get("user_uuid").(string)
+	if !ok {
+		slog.WarnContext(ctx, "ユーザーUUIDの取得に失敗")
+		return c.JSON(http.StatusUnauthorized, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyUserUUIDNotFound),
+		})
+	}
+
+	var req model.UpdateUserLanguageRequest
+	if err := c.Bind(&req); err != nil {
+		slog.WarnContext(ctx, "リクエストボディのパースに失敗", "error", err)
+		return c.JSON(http.StatusBadRequest, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyInvalidRequestBody),
+		})
+	}
+
+	user, err := h.userUsecase.UpdateLanguage(ctx, userUUID, req.Language)
+	if err != nil {
+		if errors.Is(err, domainModel.ErrUnsupportedLanguage) {
+			slog.WarnContext(ctx, "対応していない言語が指定されました", "language", req.Language)
+			return c.JSON(http.StatusBadRequest, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyUnsupportedLanguage),
+			})
+		}
+		slog.ErrorContext(ctx, "ユーザー優先言語の更新に失敗", "error", err)
+		return c.JSON(http.StatusInternalServerError, model.Response{
+			Status:  "error",
+			Message: err.Error(),
+		})
+	}
+
+	slog.InfoContext(ctx, "ユーザー優先言語の更新に成功", "user_uuid", userUUID, "language", user.Language)
+	return c.JSON(http.StatusOK, toUserResponse(user))
+}
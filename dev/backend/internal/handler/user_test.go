@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"backend/internal/domain/model"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// モックの定義
+type mockUserUsecase struct {
+	mock.Mock
+}
+
+func (m *mockUserUsecase) GetProfile(ctx context.Context, userUUID string) (*model.User, error) {
+	args := m.Called(ctx, userUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (m *mockUserUsecase) UpdateLanguage(ctx context.Context, userUUID string, language string) (*model.User, error) {
+	args := m.Called(ctx, userUUID, language)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.User), args.Error(1)
+}
+
+func TestUserHandler_GetProfile(t *testing.T) {
+	tests := []struct {
+		name           string
+		userUUID       interface{}
+		setupMock      func(m *mockUserUsecase)
+		wantStatus     int
+		wantBodySubstr string
+	}{
+		{
+			name:     "正常系: プロフィールが取得できること",
+			userUUID: "user-uuid",
+			setupMock: func(m *mockUserUsecase) {
+				m.On("GetProfile", mock.Anything, "user-uuid").Return(&model.User{UUID: "user-uuid", Name: "Guest", Language: "en", Provider: "guest"}, nil)
+			},
+			wantStatus:     http.StatusOK,
+			wantBodySubstr: `{"uuid":"user-uuid","name":"Guest","language":"en","provider":"guest","avatar_url":""}`,
+		},
+		{
+			name:     "異常系: ユーザーUUIDがコンテキストにない場合401エラー",
+			userUUID: nil,
+			setupMock: func(m *mockUserUsecase) {
+				// 呼び出されない
+			},
+			wantStatus:     http.StatusUnauthorized,
+			wantBodySubstr: "ユーザーUUIDの取得に失敗しました",
+		},
+		{
+			name:     "異常系: Usecaseでエラーが発生した場合500エラー",
+			userUUID: "user-uuid",
+			setupMock: func(m *mockUserUsecase) {
+				m.On("GetProfile", mock.Anything, "user-uuid").Return(nil, errors.New("usecase error"))
+			},
+			wantStatus:     http.StatusInternalServerError,
+			wantBodySubstr: "usecase error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/users/me", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			if tt.userUUID != nil {
+				c.Set("user_uuid", tt.userUUID)
+			}
+
+			mockUsecase := new(mockUserUsecase)
+			tt.setupMock(mockUsecase)
+
+			h := NewUserHandler(mockUsecase)
+			_ = h.GetProfile(c)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBodySubstr)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserHandler_UpdateLanguage(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		language       string // コンテキストに設定するレスポンスの言語
+		setupMock      func(m *mockUserUsecase)
+		wantStatus     int
+		wantBodySubstr string
+	}{
+		{
+			name: "正常系: 優先言語が更新できること",
+			body: `{"language":"en"}`,
+			setupMock: func(m *mockUserUsecase) {
+				m.On("UpdateLanguage", mock.Anything, "user-uuid", "en").Return(&model.User{UUID: "user-uuid", Name: "Guest", Language: "en"}, nil)
+			},
+			wantStatus:     http.StatusOK,
+			wantBodySubstr: `"language":"en"`,
+		},
+		{
+			name:     "異常系: 対応していない言語の場合はレスポンスの言語で400エラー",
+			body:     `{"language":"fr"}`,
+			language: "en",
+			setupMock: func(m *mockUserUsecase) {
+				m.On("UpdateLanguage", mock.Anything, "user-uuid", "fr").Return(nil, fmt.Errorf("%w: fr", model.ErrUnsupportedLanguage))
+			},
+			wantStatus:     http.StatusBadRequest,
+			wantBodySubstr: "unsupported language",
+		},
+		{
+			name: "異常系: リクエストボディが不正な場合400エラー",
+			body: `invalid-json`,
+			setupMock: func(m *mockUserUsecase) {
+				// 呼び出されない
+			},
+			wantStatus:     http.StatusBadRequest,
+			wantBodySubstr: "リクエストボディの形式が正しくありません",
+		},
+		{
+			name: "異常系: Usecaseでエラーが発生した場合500エラー",
+			body: `{"language":"ja"}`,
+			setupMock: func(m *mockUserUsecase) {
+				m.On("UpdateLanguage", mock.Anything, "user-uuid", "ja").Return(nil, errors.New("usecase error"))
+			},
+			wantStatus:     http.StatusInternalServerError,
+			wantBodySubstr: "usecase error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPut, "/api/users/me/language", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_uuid", "user-uuid")
+			if tt.language != "" {
+				c.Set("language", tt.language)
+			}
+
+			mockUsecase := new(mockUserUsecase)
+			tt.setupMock(mockUsecase)
+
+			h := NewUserHandler(mockUsecase)
+			_ = h.UpdateLanguage(c)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBodySubstr)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package i18n
+
+import (
+	"backend/internal/domain/model"
+	"strings"
+)
+
+// APIレスポンスで返すメッセージのキー
+type Key string
+
+const (
+	// 認証
+	KeyTokenNotFound       Key = "token_not_found"
+	KeyTokenInvalid        Key = "token_invalid"
+	KeyTokenClaimsInvalid  Key = "token_claims_invalid"
+	KeyTokenUserUUIDAbsent Key = "token_user_uuid_absent"
+	KeyUserUUIDNotFound    Key = "user_uuid_not_found"
+	KeyUserUUIDRequired    Key = "user_uuid_required"
+
+	// OIDC
+	KeyUnknownProvider         Key = "unknown_provider"
+	KeyInvalidOIDCState        Key = "invalid_oidc_state"
+	KeyOIDCAuthorizationDenied Key = "oidc_authorization_denied"
+	KeyAlreadyLinked           Key = "already_linked"
+	KeyGuestLoginDenied        Key = "guest_login_denied"
+	KeyRefreshTokenInvalid     Key = "refresh_token_invalid"
+	KeySessionNotFound         Key = "session_not_found"
+	KeySessionRevoked          Key = "session_revoked"
+	KeyAPITokenInvalid         Key = "api_token_invalid"
+	KeyAPITokenScopeDenied     Key = "api_token_scope_denied"
+	KeyAPITokenNotAllowed      Key = "api_token_not_allowed"
+	KeyAPITokenNotFound        Key = "api_token_not_found"
+	KeyInvalidAPITokenParams   Key = "invalid_api_token_params"
+	KeyProjectNotFound         Key = "project_not_found"
+
+	// プロジェクト共有
+	KeyProjectRoleDenied     Key = "project_role_denied"
+	KeyInvalidProjectRole    Key = "invalid_project_role"
+	KeyUserNotFound          Key = "user_not_found"
+	KeyProjectMemberNotFound Key = "project_member_not_found"
+	KeyProjectMemberExists   Key = "project_member_exists"
+
+	// 共有リンク
+	KeyShareLinkNotFound      Key = "share_link_not_found"
+	KeyInvalidShareLinkParams Key = "invalid_share_link_params"
+	KeyChatNotShared          Key = "chat_not_shared"
+
+	// 共同編集
+	KeyChatGenerationInProgress Key = "chat_generation_in_progress"
+	KeyInvalidMergeTarget       Key = "invalid_merge_target"
+	KeyChatNotMerged            Key = "chat_not_merged"
+	KeyMergeReportInUse         Key = "merge_report_in_use"
+	KeyInvalidStatusTransition  Key = "invalid_chat_status_transition"
+	KeyChatNotOpen              Key = "chat_not_open"
+	KeyInvalidRebaseTarget      Key = "invalid_rebase_target"
+	KeyInvalidComparisonTarget  Key = "invalid_comparison_target"
+	KeyNoCommonAncestor         Key = "no_common_ancestor"
+	KeyInvalidCherryPick        Key = "invalid_cherry_pick"
+	KeyInvalidSuggestTarget     Key = "invalid_suggest_target"
+	KeyInvalidBatchFork         Key = "invalid_batch_fork"
+	KeyInvalidForkTarget        Key = "invalid_fork_target"
+	KeyInvalidForkSelection     Key = "invalid_fork_selection"
+	KeyForkSourceNotOpen        Key = "fork_source_not_open"
+	KeyInvalidTreeNode          Key = "invalid_tree_node"
+	KeyTreeNodeConflict         Key = "tree_node_conflict"
+	KeyInvalidTreeQuery         Key = "invalid_tree_query"
+	KeyTreeNodeNotFound         Key = "tree_node_not_found"
+
+	// リクエスト
+	KeyInvalidRequest         Key = "invalid_request"
+	KeyInvalidRequestBody     Key = "invalid_request_body"
+	KeyBindRequestBodyFailed  Key = "bind_request_body_failed"
+	KeyProjectUUIDRequired    Key = "project_uuid_required"
+	KeyInitialMessageRequired Key = "initial_message_required"
+	KeyContentEmpty           Key = "content_empty"
+	KeyParentChatMismatch     Key = "parent_chat_mismatch"
+	KeyUnsupportedLanguage    Key = "unsupported_language"
+
+	// 成功時
+	KeyForkCreated Key = "fork_created"
+)
+
+// 言語 -> キー -> メッセージ
+var messages = map[string]map[Key]string{
+	model.LanguageJapanese: {
+		KeyTokenNotFound:            "JWTトークンが見つかりません",
+		KeyTokenInvalid:             "無効なJWTトークンです",
+		KeyTokenClaimsInvalid:       "無効なトークンクレームです",
+		KeyTokenUserUUIDAbsent:      "トークンにユーザーUUIDが含まれていません",
+		KeyUserUUIDNotFound:         "ユーザーUUIDの取得に失敗しました",
+		KeyUserUUIDRequired:         "user_uuidは必須です",
+		KeyUnknownProvider:          "対応していないログインプロバイダーです",
+		KeyInvalidOIDCState:         "ログインの有効期限が切れたか、不正なリクエストです",
+		KeyOIDCAuthorizationDenied:  "ログインプロバイダーで認可が拒否されました",
+		KeyAlreadyLinked:            "このユーザーは既にアカウントが紐付けられています",
+		KeyGuestLoginDenied:         "ゲストユーザー以外はユーザーUUIDでログインできません",
+		KeyRefreshTokenInvalid:      "セッションの有効期限が切れました。再度ログインしてください",
+		KeySessionNotFound:          "セッションが見つかりません",
+		KeySessionRevoked:           "ログアウト済みのセッションです。再度ログインしてください",
+		KeyAPITokenInvalid:          "無効なAPIトークンです",
+		KeyAPITokenScopeDenied:      "APIトークンのスコープでは許可されていない操作です",
+		KeyAPITokenNotAllowed:       "APIトークンでは利用できない機能です",
+		KeyAPITokenNotFound:         "APIトークンが見つかりません",
+		KeyInvalidAPITokenParams:    "APIトークンの名前・スコープ・有効期限が正しくありません",
+		KeyProjectNotFound:          "プロジェクトが見つかりません",
+		KeyProjectRoleDenied:        "このプロジェクトでの権限では許可されていない操作です",
+		KeyInvalidProjectRole:       "ロールは viewer・editor・owner のいずれかを指定してください",
+		KeyUserNotFound:             "ユーザーが見つかりません",
+		KeyProjectMemberNotFound:    "メンバーまたは招待が見つかりません",
+		KeyProjectMemberExists:      "このユーザーは既にメンバーか招待済みです",
+		KeyShareLinkNotFound:        "共有リンクが見つからないか、有効期限が切れています",
+		KeyInvalidShareLinkParams:   "共有するチャットまたは有効期限が正しくありません",
+		KeyChatNotShared:            "このチャットは共有されていません",
+		KeyChatGenerationInProgress: "他のメンバーが回答を生成中です。完了してから再度お試しください",
+		KeyInvalidMergeTarget:       "マージ先には子チャットの祖先のチャットを指定してください",
+		KeyChatNotMerged:            "このチャットはマージされていません",
+		KeyMergeReportInUse:         "マージ後に親チャットで回答が生成されているため取り消せません。強制的に取り消す場合は force を指定してください",
+		KeyInvalidStatusTransition:  "このチャットはそのステータスに変更できません (マージ済みのチャットはマージを取り消して再開してください)",
+		KeyChatNotOpen:              "クローズまたはマージ済みのチャットにはメッセージを追加できません。チャットを再開してからお試しください",
+		KeyInvalidRebaseTarget:      "ブランチの付け替え先が不正です (同じプロジェクトの、自身や子孫以外のチャットのメッセージを指定してください。ルートやマージ済みのチャットは移動できません)",
+		KeyInvalidComparisonTarget:  "比較するブランチが不正です (同じプロジェクトの別のチャットを指定してください)",
+		KeyNoCommonAncestor:         "共通の祖先メッセージがないため、比較結果をメモとして保存できません",
+		KeyInvalidCherryPick:        "取り込むメッセージが不正です (同じプロジェクトの別のチャットのユーザー・AIのメッセージを指定してください)",
+		KeyInvalidSuggestTarget:     "フォーク候補を抽出するメッセージが不正です (このチャットのAIの回答を指定してください)",
+		KeyInvalidBatchFork:         "まとめてフォークするブランチの指定が不正です (1件以上10件以下のブランチを指定してください)",
+		KeyInvalidForkTarget:        "フォーク元のメッセージが不正です (このチャットのユーザー・AIのメッセージを指定してください)",
+		KeyInvalidForkSelection:     "選択した文章がフォーク元のメッセージに見つかりません",
+		KeyForkSourceNotOpen:        "クローズまたはマージ済みのチャットからはフォークできません。チャットを再開してからお試しください",
+		KeyInvalidTreeNode:          "指定したノードがプロジェクトのツリーにないか、位置が不正です",
+		KeyTreeNodeConflict:         "ノードの配置が他の操作で更新されています。ツリーを再読み込みしてください",
+		KeyInvalidTreeQuery:         "ツリーの取得条件が不正です",
+		KeyTreeNodeNotFound:         "指定したノードがプロジェクトのツリーにありません",
+		KeyInvalidRequest:           "リクエストが正しくありません",
+		KeyInvalidRequestBody:       "リクエストボディの形式が正しくありません",
+		KeyBindRequestBodyFailed:    "リクエストボディのバインドに失敗しました",
+		KeyProjectUUIDRequired:      "project_uuidは必須です",
+		KeyInitialMessageRequired:   "initial_messageは必須です",
+		KeyContentEmpty:             "content が空です",
+		KeyParentChatMismatch:       "親チャットIDと一致しない",
+		KeyUnsupportedLanguage:      "対応していない言語です",
+		KeyForkCreated:              "子チャットを作成しました",
+	},
+	model.LanguageEnglish: {
+		KeyTokenNotFound:            "JWT token not found",
+		KeyTokenInvalid:             "invalid JWT token",
+		KeyTokenClaimsInvalid:       "invalid token claims",
+		KeyTokenUserUUIDAbsent:      "token does not contain a user UUID",
+		KeyUserUUIDNotFound:         "failed to get user UUID",
+		KeyUserUUIDRequired:         "user_uuid is required",
+		KeyUnknownProvider:          "unsupported login provider",
+		KeyInvalidOIDCState:         "login session expired or invalid request",
+		KeyOIDCAuthorizationDenied:  "authorization was denied by the login provider",
+		KeyAlreadyLinked:            "this user is already linked to an account",
+		KeyGuestLoginDenied:         "only guest users can log in with a user UUID",
+		KeyRefreshTokenInvalid:      "session expired, please log in again",
+		KeySessionNotFound:          "session not found",
+		KeySessionRevoked:           "this session has been logged out, please log in again",
+		KeyAPITokenInvalid:          "invalid API token",
+		KeyAPITokenScopeDenied:      "this operation is not allowed by the API token scope",
+		KeyAPITokenNotAllowed:       "this feature is not available with API tokens",
+		KeyAPITokenNotFound:         "API token not found",
+		KeyInvalidAPITokenParams:    "invalid API token name, scope or expiry",
+		KeyProjectNotFound:          "project not found",
+		KeyProjectRoleDenied:        "your role in this project does not allow this operation",
+		KeyInvalidProjectRole:       "role must be one of viewer, editor or owner",
+		KeyUserNotFound:             "user not found",
+		KeyProjectMemberNotFound:    "member or invitation not found",
+		KeyProjectMemberExists:      "this user is already a member or has been invited",
+		KeyShareLinkNotFound:        "share link not found or expired",
+		KeyInvalidShareLinkParams:   "invalid shared chat or expiry",
+		KeyChatNotShared:            "this chat is not shared",
+		KeyChatGenerationInProgress: "another member is generating a response, please try again when it finishes",
+		KeyInvalidMergeTarget:       "the merge target must be an ancestor of the chat",
+		KeyChatNotMerged:            "this chat has not been merged",
+		KeyMergeReportInUse:         "the parent chat has responses generated after the merge, specify force to unmerge anyway",
+		KeyInvalidStatusTransition:  "this chat cannot be changed to that status (use unmerge to reopen a merged chat)",
+		KeyChatNotOpen:              "this chat is closed or merged, reopen it before adding messages",
+		KeyInvalidRebaseTarget:      "invalid rebase target (choose a message in the same project outside this chat and its descendants; root and merged chats cannot be moved)",
+		KeyInvalidComparisonTarget:  "invalid comparison target (choose another chat in the same project)",
+		KeyNoCommonAncestor:         "the branches have no common ancestor message, so the comparison cannot be saved as a note",
+		KeyInvalidCherryPick:        "invalid messages to copy (choose user or AI messages from another chat in the same project)",
+		KeyInvalidSuggestTarget:     "invalid message for fork suggestions (choose an AI answer in this chat)",
+		KeyInvalidBatchFork:         "invalid branches for batch fork (specify between 1 and 10 branches)",
+		KeyInvalidForkTarget:        "invalid message to fork from (choose a user or AI message in this chat)",
+		KeyInvalidForkSelection:     "the selected text was not found in the message to fork from",
+		KeyForkSourceNotOpen:        "this chat is closed or merged, reopen it before forking",
+		KeyInvalidTreeNode:          "the node is not in the project tree or its position is invalid",
+		KeyTreeNodeConflict:         "the node was updated by another operation; reload the tree",
+		KeyInvalidTreeQuery:         "the tree query is invalid",
+		KeyTreeNodeNotFound:         "the node is not in the project tree",
+		KeyInvalidRequest:           "invalid request",
+		KeyInvalidRequestBody:       "invalid request body",
+		KeyBindRequestBodyFailed:    "failed to bind request body",
+		KeyProjectUUIDRequired:      "project_uuid is required",
+		KeyInitialMessageRequired:   "initial_message is required",
+		KeyContentEmpty:             "content is empty",
+		KeyParentChatMismatch:       "parent chat ID does not match",
+		KeyUnsupportedLanguage:      "unsupported language",
+		KeyForkCreated:              "child chat created",
+	},
+}
+
+// 指定された言語のメッセージを返す処理
+// 対応していない言語の場合は日本語にフォールバックする
+func Message(language string, key Key) string {
+	if msg, ok := messages[language][key]; ok {
+		return msg
+	}
+	if msg, ok := messages[model.LanguageJapanese][key]; ok {
+		return msg
+	}
+	return string(key)
+}
+
+// Accept-Language ヘッダーから対応している言語を選択する処理
+// 対応している言語が含まれていない場合は空文字を返す
+func ParseAcceptLanguage(header string) string {
+	for _, part := range strings.Split(header, ",") {
+		tag := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		primary := strings.ToLower(strings.SplitN(tag, "-", 2)[0])
+		if model.IsSupportedLanguage(primary) {
+			return primary
+		}
+	}
+	return ""
+}
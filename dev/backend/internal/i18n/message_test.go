@@ -0,0 +1,82 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		key      Key
+		want     string
+	}{
+		{
+			name:     "正常系: 日本語のメッセージが返ること",
+			language: "ja",
+			key:      KeyProjectUUIDRequired,
+			want:     "project_uuidは必須です",
+		},
+		{
+			name:     "正常系: 英語のメッセージが返ること",
+			language: "en",
+			key:      KeyProjectUUIDRequired,
+			want:     "project_uuid is required",
+		},
+		{
+			name:     "正常系: 未対応の言語は日本語にフォールバックすること",
+			language: "fr",
+			key:      KeyContentEmpty,
+			want:     "content が空です",
+		},
+		{
+			name:     "正常系: 未定義のキーはキー自体が返ること",
+			language: "en",
+			key:      Key("unknown_key"),
+			want:     "unknown_key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Message(tt.language, tt.key))
+		})
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{
+			name:   "正常系: 地域付きの言語タグから言語が選択されること",
+			header: "en-US,en;q=0.9",
+			want:   "en",
+		},
+		{
+			name:   "正常系: 未対応の言語を飛ばして対応している言語が選択されること",
+			header: "fr-FR, ja;q=0.8, en;q=0.5",
+			want:   "ja",
+		},
+		{
+			name:   "正常系: 対応している言語がない場合は空文字になること",
+			header: "fr, de",
+			want:   "",
+		},
+		{
+			name:   "正常系: ヘッダーが空の場合は空文字になること",
+			header: "",
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseAcceptLanguage(tt.header))
+		})
+	}
+}
@@ -0,0 +1,271 @@
+package layout
+
+import (
+	"backend/config"
+	"backend/internal/domain/model"
+	"backend/internal/domain/usecase"
+	"encoding/binary"
+	"hash/fnv"
+	"sort"
+	"sync"
+)
+
+const (
+	// 既定の間隔 (フォークしたチャットは 250 間隔、回答は 150 間隔で並ぶ)
+	defaultNodeWidth   = 200.0
+	defaultSiblingGap  = 50.0
+	defaultLevelHeight = 150.0
+	// 計算結果を保持するツリーの最大数 (超えた場合は古いものから破棄する)
+	maxCachedTrees = 256
+)
+
+// 部分木の配置の計算結果
+// 位置はすべて部分木のルートのノードの中心からの相対位置
+type subtree struct {
+	// ノードIDと子の部分木の構造から計算した値 (構造が変わっていなければ計算結果を再利用する)
+	signature uint64
+	// 子ノードの横方向の位置
+	childOffsets []float64
+	// 深さごとの部分木の左端・右端のノードの中心の位置 (0 番目はルートのノード自身)
+	left  []float64
+	right []float64
+}
+
+// Reingold–Tilford の tidy tree によるレイアウト
+// 親ノードを両端の子の中央に置き、隣り合う部分木は各深さで重ならない最小の間隔まで詰めて配置する
+// 位置は保存しないため、ノードが重ならないことはフォーク時の位置の確保ではなくこの配置で保証する
+type tidyTree struct {
+	// 隣り合うノードの中心の最小間隔
+	separation  float64
+	levelHeight float64
+
+	mu sync.Mutex
+	// ルートのノードID -> ノードID -> 部分木の計算結果
+	// プロセス内のメモリにのみ保持するため、再起動で失われ、複数インスタンス間でも共有されない
+	// 計算を省くためだけのもので、キャッシュがなくても同じ構造からは同じ位置を計算する
+	cache map[string]map[string]*subtree
+	// キャッシュに追加した順のルートのノードID
+	order []string
+}
+
+// TreeLayouter の新しいインスタンスを作成する処理
+func NewTidyTree(cfg config.LayoutConfig) usecase.TreeLayouter {
+	nodeWidth := cfg.NodeWidth
+	if nodeWidth <= 0 {
+		nodeWidth = defaultNodeWidth
+	}
+	siblingGap := cfg.SiblingGap
+	if siblingGap <= 0 {
+		siblingGap = defaultSiblingGap
+	}
+	levelHeight := cfg.LevelHeight
+	if levelHeight <= 0 {
+		levelHeight = defaultLevelHeight
+	}
+	return &tidyTree{
+		separation:  nodeWidth + siblingGap,
+		levelHeight: levelHeight,
+		cache:       make(map[string]map[string]*subtree),
+	}
+}
+
+// ノードとエッジの親子関係からすべてのノードの位置を計算する処理
+// 呼び出しのたびにツリー全体の位置を決め直す (キャッシュは部分木の計算を省くためだけに使う)
+// エッジは新しいノード (Source) から親のノード (Target) に向かう
+// 親のないノードが複数ある場合は、それぞれの部分木を左から順に並べる
+func (t *tidyTree) Layout(tree *model.ProjectTree) {
+	if tree == nil || len(tree.Nodes) == 0 {
+		return
+	}
+
+	roots, children := buildForest(tree)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// フォーク・削除・付け替えで構造が変わった部分木とその祖先だけを計算し直す
+	// 前回の計算結果がない場合 (再起動後や別のインスタンス) はツリー全体を計算する
+	cacheKey := roots[0]
+	prev := t.cache[cacheKey]
+	next := make(map[string]*subtree, len(tree.Nodes))
+	rootLayouts := make([]*subtree, len(roots))
+	for i, root := range roots {
+		rootLayouts[i] = t.layoutSubtree(root, children, prev, next)
+	}
+	t.store(cacheKey, next)
+
+	// ルートの部分木を横に並べ、上から順に絶対位置を決める
+	forest := t.placeChildren(0, rootLayouts)
+	positions := make(map[string]model.ProjectNodePosition, len(tree.Nodes))
+	minX := 0.0
+	for i, root := range roots {
+		assignPositions(root, forest.childOffsets[i], 0, t.levelHeight, children, next, positions)
+	}
+	for _, pos := range positions {
+		minX = min(minX, pos.X)
+	}
+	for i := range tree.Nodes {
+		pos := positions[tree.Nodes[i].ID]
+		tree.Nodes[i].Position = model.ProjectNodePosition{X: pos.X - minX, Y: pos.Y}
+	}
+}
+
+// ノードとエッジからルートのノードと子ノードの一覧を作る処理
+// 子ノードは同じチャットの続きを先頭にし、フォークしたチャットはノードの順に並べる
+// エッジが循環している場合は、辿れなかったノードを新たなルートとして扱う
+func buildForest(tree *model.ProjectTree) ([]string, map[string][]string) {
+	chatUUIDs := make(map[string]string, len(tree.Nodes))
+	order := make(map[string]int, len(tree.Nodes))
+	for i, node := range tree.Nodes {
+		chatUUIDs[node.ID] = node.ChatUUID
+		order[node.ID] = i
+	}
+
+	parents := make(map[string]string, len(tree.Edges))
+	for _, edge := range tree.Edges {
+		if edge.Source == edge.Target {
+			continue
+		}
+		if _, ok := chatUUIDs[edge.Source]; !ok {
+			continue
+		}
+		if _, ok := chatUUIDs[edge.Target]; !ok {
+			continue
+		}
+		if _, ok := parents[edge.Source]; !ok {
+			parents[edge.Source] = edge.Target
+		}
+	}
+
+	candidates := make(map[string][]string, len(tree.Nodes))
+	for _, node := range tree.Nodes {
+		if parent, ok := parents[node.ID]; ok {
+			candidates[parent] = append(candidates[parent], node.ID)
+		}
+	}
+	for parent, kids := range candidates {
+		sort.SliceStable(kids, func(i, j int) bool {
+			iSame := chatUUIDs[kids[i]] == chatUUIDs[parent]
+			jSame := chatUUIDs[kids[j]] == chatUUIDs[parent]
+			if iSame != jSame {
+				return iSame
+			}
+			return order[kids[i]] < order[kids[j]]
+		})
+	}
+
+	// ルートから辿れたノードだけを子として残す (循環している部分は辿らない)
+	visited := make(map[string]bool, len(tree.Nodes))
+	children := make(map[string][]string, len(candidates))
+	var visit func(id string)
+	visit = func(id string) {
+		visited[id] = true
+		for _, kid := range candidates[id] {
+			if visited[kid] {
+				continue
+			}
+			children[id] = append(children[id], kid)
+			visit(kid)
+		}
+	}
+	roots := []string{}
+	for _, node := range tree.Nodes {
+		if _, ok := parents[node.ID]; !ok {
+			roots = append(roots, node.ID)
+			visit(node.ID)
+		}
+	}
+	for _, node := range tree.Nodes {
+		if !visited[node.ID] {
+			roots = append(roots, node.ID)
+			visit(node.ID)
+		}
+	}
+	return roots, children
+}
+
+// 部分木の配置を子から順に計算する処理
+// 構造が前回と同じ部分木は前回の計算結果を使う
+func (t *tidyTree) layoutSubtree(id string, children map[string][]string, prev, next map[string]*subtree) *subtree {
+	kids := children[id]
+	kidLayouts := make([]*subtree, len(kids))
+	h := fnv.New64a()
+	h.Write([]byte(id))
+	for i, kid := range kids {
+		kidLayouts[i] = t.layoutSubtree(kid, children, prev, next)
+		binary.Write(h, binary.LittleEndian, kidLayouts[i].signature)
+	}
+	signature := h.Sum64()
+
+	if cached, ok := prev[id]; ok && cached.signature == signature {
+		next[id] = cached
+		return cached
+	}
+	s := t.placeChildren(signature, kidLayouts)
+	next[id] = s
+	return s
+}
+
+// 子の部分木を左から順に並べ、親ノードを両端の子の中央に置く処理
+func (t *tidyTree) placeChildren(signature uint64, kids []*subtree) *subtree {
+	s := &subtree{signature: signature, left: []float64{0}, right: []float64{0}}
+	if len(kids) == 0 {
+		return s
+	}
+
+	// 既に配置した部分木の深さごとの左端・右端 (先頭の子の中心を 0 とする)
+	var left, right []float64
+	offsets := make([]float64, len(kids))
+	for i, kid := range kids {
+		offset := 0.0
+		if i > 0 {
+			// 共通する深さのすべてで、既に配置した部分木の右端から間隔を空ける
+			offset = right[0] + t.separation - kid.left[0]
+			for d := 1; d < len(kid.left) && d < len(right); d++ {
+				offset = max(offset, right[d]+t.separation-kid.left[d])
+			}
+		}
+		offsets[i] = offset
+		for d := range kid.left {
+			if d < len(left) {
+				left[d] = min(left[d], kid.left[d]+offset)
+				right[d] = max(right[d], kid.right[d]+offset)
+			} else {
+				left = append(left, kid.left[d]+offset)
+				right = append(right, kid.right[d]+offset)
+			}
+		}
+	}
+
+	center := (offsets[0] + offsets[len(offsets)-1]) / 2
+	s.childOffsets = make([]float64, len(offsets))
+	for i, offset := range offsets {
+		s.childOffsets[i] = offset - center
+	}
+	for d := range left {
+		s.left = append(s.left, left[d]-center)
+		s.right = append(s.right, right[d]-center)
+	}
+	return s
+}
+
+// 部分木の相対位置から絶対位置を決める処理
+func assignPositions(id string, x float64, depth int, levelHeight float64, children map[string][]string, layouts map[string]*subtree, positions map[string]model.ProjectNodePosition) {
+	positions[id] = model.ProjectNodePosition{X: x, Y: float64(depth) * levelHeight}
+	s := layouts[id]
+	for i, kid := range children[id] {
+		assignPositions(kid, x+s.childOffsets[i], depth+1, levelHeight, children, layouts, positions)
+	}
+}
+
+// 計算結果を保存する処理 (上限を超えた場合は古いツリーから破棄する)
+func (t *tidyTree) store(key string, layouts map[string]*subtree) {
+	if _, ok := t.cache[key]; !ok {
+		t.order = append(t.order, key)
+	}
+	t.cache[key] = layouts
+	for len(t.order) > maxCachedTrees {
+		delete(t.cache, t.order[0])
+		t.order = t.order[1:]
+	}
+}
@@ -0,0 +1,278 @@
+package layout
+
+import (
+	"backend/config"
+	"backend/internal/domain/model"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// テスト用のツリーを作成する処理
+// edges は 子ノードID -> 親ノードID、chats は ノードID -> チャットUUID (省略した場合は "chat")
+func newTree(ids []string, edges [][2]string, chats map[string]string) *model.ProjectTree {
+	tree := &model.ProjectTree{}
+	for _, id := range ids {
+		chatUUID := chats[id]
+		if chatUUID == "" {
+			chatUUID = "chat"
+		}
+		tree.Nodes = append(tree.Nodes, model.ProjectNode{ID: id, ChatUUID: chatUUID})
+	}
+	for _, edge := range edges {
+		tree.Edges = append(tree.Edges, model.ProjectEdge{ID: edge[0] + "-" + edge[1], Source: edge[0], Target: edge[1]})
+	}
+	return tree
+}
+
+func positionsOf(tree *model.ProjectTree) map[string]model.ProjectNodePosition {
+	positions := make(map[string]model.ProjectNodePosition, len(tree.Nodes))
+	for _, node := range tree.Nodes {
+		positions[node.ID] = node.Position
+	}
+	return positions
+}
+
+func TestTidyTree_Layout(t *testing.T) {
+	tests := []struct {
+		name  string
+		cfg   config.LayoutConfig
+		tree  *model.ProjectTree
+		want  map[string]model.ProjectNodePosition
+		check func(t *testing.T, positions map[string]model.ProjectNodePosition)
+	}{
+		{
+			name: "正常系: 一本道の会話は縦に並ぶこと",
+			tree: newTree([]string{"a", "b", "c"}, [][2]string{{"b", "a"}, {"c", "b"}}, nil),
+			want: map[string]model.ProjectNodePosition{
+				"a": {X: 0, Y: 0},
+				"b": {X: 0, Y: 150},
+				"c": {X: 0, Y: 300},
+			},
+		},
+		{
+			name: "正常系: 親ノードは両端の子の中央に置かれること",
+			tree: newTree([]string{"root", "left", "right"}, [][2]string{{"left", "root"}, {"right", "root"}}, nil),
+			want: map[string]model.ProjectNodePosition{
+				"root":  {X: 125, Y: 0},
+				"left":  {X: 0, Y: 150},
+				"right": {X: 250, Y: 150},
+			},
+		},
+		{
+			name: "正常系: 同じチャットの続きをフォークより左に置くこと",
+			tree: newTree(
+				[]string{"root", "fork", "next"},
+				[][2]string{{"fork", "root"}, {"next", "root"}},
+				map[string]string{"root": "main", "next": "main", "fork": "child"},
+			),
+			want: map[string]model.ProjectNodePosition{
+				"root": {X: 125, Y: 0},
+				"next": {X: 0, Y: 150},
+				"fork": {X: 250, Y: 150},
+			},
+		},
+		{
+			name: "正常系: 深い階層で部分木が重ならないよう間隔を空けること",
+			tree: newTree(
+				[]string{"root", "a", "b", "a1", "a2", "b1"},
+				[][2]string{{"a", "root"}, {"b", "root"}, {"a1", "a"}, {"a2", "a"}, {"b1", "b"}},
+				nil,
+			),
+			want: map[string]model.ProjectNodePosition{
+				"a1":   {X: 0, Y: 300},
+				"a2":   {X: 250, Y: 300},
+				"b1":   {X: 500, Y: 300},
+				"a":    {X: 125, Y: 150},
+				"b":    {X: 500, Y: 150},
+				"root": {X: 312.5, Y: 0},
+			},
+		},
+		{
+			name: "正常系: 設定した間隔で配置すること",
+			cfg:  config.LayoutConfig{NodeWidth: 100, SiblingGap: 20, LevelHeight: 80},
+			tree: newTree([]string{"root", "left", "right"}, [][2]string{{"left", "root"}, {"right", "root"}}, nil),
+			want: map[string]model.ProjectNodePosition{
+				"root":  {X: 60, Y: 0},
+				"left":  {X: 0, Y: 80},
+				"right": {X: 120, Y: 80},
+			},
+		},
+		{
+			name: "正常系: 親のないノードが複数ある場合は横に並べること",
+			tree: newTree([]string{"a", "b", "a1"}, [][2]string{{"a1", "a"}}, nil),
+			want: map[string]model.ProjectNodePosition{
+				"a":  {X: 0, Y: 0},
+				"a1": {X: 0, Y: 150},
+				"b":  {X: 250, Y: 0},
+			},
+		},
+		{
+			name: "正常系: エッジが循環していても配置できること",
+			tree: newTree([]string{"a", "b"}, [][2]string{{"a", "b"}, {"b", "a"}}, nil),
+			check: func(t *testing.T, positions map[string]model.ProjectNodePosition) {
+				assert.Len(t, positions, 2)
+				assert.NotEqual(t, positions["a"], positions["b"])
+			},
+		},
+		{
+			name: "正常系: ツリーにないノードへのエッジは無視すること",
+			tree: newTree([]string{"a", "b"}, [][2]string{{"b", "a"}, {"a", "merge-report"}}, nil),
+			want: map[string]model.ProjectNodePosition{
+				"a": {X: 0, Y: 0},
+				"b": {X: 0, Y: 150},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			NewTidyTree(tt.cfg).Layout(tt.tree)
+			positions := positionsOf(tt.tree)
+			if tt.want != nil {
+				assert.Equal(t, tt.want, positions)
+			}
+			if tt.check != nil {
+				tt.check(t, positions)
+			}
+		})
+	}
+}
+
+func TestTidyTree_LayoutIncremental(t *testing.T) {
+	ids := []string{"root", "a", "b", "a1", "b1"}
+	edges := [][2]string{{"a", "root"}, {"b", "root"}, {"a1", "a"}, {"b1", "b"}}
+
+	layouter := NewTidyTree(config.LayoutConfig{}).(*tidyTree)
+	layouter.Layout(newTree(ids, edges, nil))
+	before := layouter.cache["root"]
+
+	tests := []struct {
+		name  string
+		ids   []string
+		edges [][2]string
+		// 前回の計算結果を再利用するノード
+		reused []string
+		// 計算し直すノード
+		recomputed []string
+	}{
+		{
+			name:       "正常系: フォークを追加した場合は追加した部分木の祖先だけを計算し直すこと",
+			ids:        append(append([]string{}, ids...), "b2"),
+			edges:      append(append([][2]string{}, edges...), [2]string{"b2", "b"}),
+			reused:     []string{"a", "a1", "b1"},
+			recomputed: []string{"root", "b"},
+		},
+		{
+			name:       "正常系: ノードを削除した場合は削除したノードの祖先だけを計算し直すこと",
+			ids:        []string{"root", "a", "b", "a1"},
+			edges:      [][2]string{{"a", "root"}, {"b", "root"}, {"a1", "a"}},
+			reused:     []string{"a", "a1"},
+			recomputed: []string{"root", "b"},
+		},
+		{
+			name:       "正常系: 部分木を付け替えた場合は移動元と移動先の祖先だけを計算し直すこと",
+			ids:        ids,
+			edges:      [][2]string{{"a", "root"}, {"b", "root"}, {"a1", "a"}, {"b1", "a"}},
+			reused:     []string{"a1", "b1"},
+			recomputed: []string{"root", "a", "b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layouter.cache["root"] = before
+
+			tree := newTree(tt.ids, tt.edges, nil)
+			layouter.Layout(tree)
+			after := layouter.cache["root"]
+
+			for _, id := range tt.reused {
+				assert.Same(t, before[id], after[id], id)
+			}
+			for _, id := range tt.recomputed {
+				assert.NotSame(t, before[id], after[id], id)
+			}
+			assert.Len(t, after, len(tt.ids))
+
+			// 計算結果を再利用しても、最初から計算した場合と同じ位置になること
+			fresh := newTree(tt.ids, tt.edges, nil)
+			NewTidyTree(config.LayoutConfig{}).Layout(fresh)
+			assert.Equal(t, positionsOf(fresh), positionsOf(tree))
+		})
+	}
+}
+
+func TestTidyTree_LayoutInvalidatesMutatedTree(t *testing.T) {
+	ids := []string{"root", "a", "b", "a1", "b1"}
+	edges := [][2]string{{"a", "root"}, {"b", "root"}, {"a1", "a"}, {"b1", "b"}}
+
+	tests := []struct {
+		name  string
+		ids   []string
+		edges [][2]string
+		// 前回の計算結果を使わずに計算し直すノード
+		invalidated []string
+		// キャッシュから取り除かれるノード
+		removed []string
+	}{
+		{
+			name:        "正常系: 兄弟の順序が変わった場合は親の計算結果を使わないこと",
+			ids:         []string{"root", "b", "a", "b1", "a1"},
+			edges:       edges,
+			invalidated: []string{"root"},
+		},
+		{
+			name:        "正常系: ノードが別のノードに置き換わった場合は祖先の計算結果を使わないこと",
+			ids:         []string{"root", "a", "b", "a2", "b1"},
+			edges:       [][2]string{{"a", "root"}, {"b", "root"}, {"a2", "a"}, {"b1", "b"}},
+			invalidated: []string{"root", "a"},
+			removed:     []string{"a1"},
+		},
+		{
+			name:        "正常系: 子を持たないノードに子が追加された場合はそのノードと祖先の計算結果を使わないこと",
+			ids:         append(append([]string{}, ids...), "a1x"),
+			edges:       append(append([][2]string{}, edges...), [2]string{"a1x", "a1"}),
+			invalidated: []string{"root", "a", "a1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layouter := NewTidyTree(config.LayoutConfig{}).(*tidyTree)
+			layouter.Layout(newTree(ids, edges, nil))
+			before := layouter.cache["root"]
+
+			tree := newTree(tt.ids, tt.edges, nil)
+			layouter.Layout(tree)
+			after := layouter.cache["root"]
+
+			for _, id := range tt.invalidated {
+				assert.NotSame(t, before[id], after[id], id)
+				assert.NotEqual(t, before[id].signature, after[id].signature, id)
+			}
+			for _, id := range tt.removed {
+				assert.NotContains(t, after, id)
+			}
+
+			// キャッシュがあっても、変更後の構造を最初から計算した場合と同じ位置になり、ノードが重ならないこと
+			fresh := newTree(tt.ids, tt.edges, nil)
+			NewTidyTree(config.LayoutConfig{}).Layout(fresh)
+			assert.Equal(t, positionsOf(fresh), positionsOf(tree))
+			for i, a := range tree.Nodes {
+				for _, b := range tree.Nodes[i+1:] {
+					if a.Position.Y == b.Position.Y {
+						assert.GreaterOrEqual(t, math.Abs(a.Position.X-b.Position.X), layouter.separation, "%s と %s", a.ID, b.ID)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestTidyTree_CacheLimit(t *testing.T) {
+	layouter := NewTidyTree(config.LayoutConfig{}).(*tidyTree)
+	for i := 0; i < maxCachedTrees+10; i++ {
+		layouter.Layout(newTree([]string{string(rune('A' + i))}, nil, nil))
+	}
+	assert.Len(t, layouter.cache, maxCachedTrees)
+	assert.Len(t, layouter.order, maxCachedTrees)
+}
@@ -0,0 +1,269 @@
+package oidc
+
+import (
+	"backend/config"
+	"backend/internal/domain/model"
+	"backend/internal/domain/usecase"
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// OIDC Discovery のレスポンス
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// JWKS のレスポンス
+type jsonWebKeySet struct {
+	Keys []struct {
+		Kid string `json:"kid"`
+		Kty string `json:"kty"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+// トークンエンドポイントのレスポンス
+type tokenResponse struct {
+	IDToken string `json:"id_token"`
+}
+
+// IDトークンのクレーム
+type idTokenClaims struct {
+	Nonce   string `json:"nonce"`
+	Email   string `json:"email"`
+	Name    string `json:"name"`
+	Picture string `json:"picture"`
+	jwt.RegisteredClaims
+}
+
+type provider struct {
+	name       string
+	cfg        config.OIDCProviderConfig
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	discovery *discoveryDocument
+	keys      map[string]*rsa.PublicKey
+}
+
+// OIDCプロバイダーの新しいインスタンスを作成する処理
+// Discovery はログイン時に初めて行うため、起動時にIdPへの通信は発生しない
+func NewProvider(name string, cfg config.OIDCProviderConfig, httpClient *http.Client) usecase.OIDCProvider {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "email", "profile"}
+	}
+	return &provider{
+		name:       name,
+		cfg:        cfg,
+		httpClient: httpClient,
+	}
+}
+
+// PKCE付きの認可エンドポイントのURLを生成する処理
+func (p *provider) AuthCodeURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error) {
+	doc, err := p.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(doc.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("認可エンドポイントの解析に失敗: %w", err)
+	}
+	q := authURL.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", p.cfg.ClientID)
+	q.Set("redirect_uri", p.cfg.RedirectURL)
+	q.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", codeChallenge)
+	q.Set("code_challenge_method", "S256")
+	authURL.RawQuery = q.Encode()
+	return authURL.String(), nil
+}
+
+// 認可コードをトークンに交換し、IDトークンを検証してユーザー情報を返す処理
+func (p *provider) Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*model.OIDCIdentity, error) {
+	doc, err := p.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("client_id", p.cfg.ClientID)
+	form.Set("code_verifier", codeVerifier)
+	if p.cfg.ClientSecret != "" {
+		form.Set("client_secret", p.cfg.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, doc.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("トークンリクエストの作成に失敗: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var token tokenResponse
+	if err := p.doJSON(req, &token); err != nil {
+		return nil, fmt.Errorf("トークンの取得に失敗: %w", err)
+	}
+	if token.IDToken == "" {
+		return nil, errors.New("トークンレスポンスにIDトークンが含まれていません")
+	}
+
+	claims, err := p.verifyIDToken(ctx, doc, token.IDToken)
+	if err != nil {
+		return nil, fmt.Errorf("IDトークンの検証に失敗: %w", err)
+	}
+	if claims.Nonce != nonce {
+		return nil, errors.New("IDトークンのnonceが一致しません")
+	}
+
+	return &model.OIDCIdentity{
+		Provider:  p.name,
+		Subject:   claims.Subject,
+		Email:     claims.Email,
+		Name:      claims.Name,
+		AvatarURL: claims.Picture,
+	}, nil
+}
+
+// IDトークンの署名・発行者・対象者・有効期限を検証する処理
+func (p *provider) verifyIDToken(ctx context.Context, doc *discoveryDocument, rawIDToken string) (*idTokenClaims, error) {
+	claims := &idTokenClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.getKey(ctx, doc, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256"}),
+		jwt.WithIssuer(doc.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("IDトークンにsubが含まれていません")
+	}
+	return claims, nil
+}
+
+// Discovery ドキュメントを取得する処理 (取得結果はキャッシュする)
+func (p *provider) getDiscovery(ctx context.Context) (*discoveryDocument, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	endpoint := strings.TrimSuffix(p.cfg.Issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Discoveryリクエストの作成に失敗: %w", err)
+	}
+
+	var doc discoveryDocument
+	if err := p.doJSON(req, &doc); err != nil {
+		return nil, fmt.Errorf("Discoveryドキュメントの取得に失敗: %w", err)
+	}
+	if doc.Issuer != p.cfg.Issuer {
+		return nil, fmt.Errorf("Discoveryドキュメントのissuerが一致しません (want: %s, got: %s)", p.cfg.Issuer, doc.Issuer)
+	}
+
+	p.discovery = &doc
+	return p.discovery, nil
+}
+
+// 署名検証用の公開鍵を取得する処理
+// 未知の kid の場合はキーローテーションを考慮して JWKS を取得し直す
+func (p *provider) getKey(ctx context.Context, doc *discoveryDocument, kid string) (*rsa.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, doc.JWKSURI, nil)
+	if err != nil {
+		return nil, fmt.Errorf("JWKSリクエストの作成に失敗: %w", err)
+	}
+	var set jsonWebKeySet
+	if err := p.doJSON(req, &set); err != nil {
+		return nil, fmt.Errorf("JWKSの取得に失敗: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		key, err := parseRSAPublicKey(k.N, k.E)
+		if err != nil {
+			return nil, fmt.Errorf("公開鍵の解析に失敗 (kid: %s): %w", k.Kid, err)
+		}
+		keys[k.Kid] = key
+	}
+	p.keys = keys
+
+	key, ok := p.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("署名に使用された鍵が見つかりません (kid: %s)", kid)
+	}
+	return key, nil
+}
+
+// HTTPリクエストを送信し、JSONレスポンスをデコードする処理
+func (p *provider) doJSON(req *http.Request, v any) error {
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// JWK の n, e から RSA 公開鍵を復元する処理
+func parseRSAPublicKey(n string, e string) (*rsa.PublicKey, error) {
+	nBytes, err := base64.RawURLEncoding.DecodeString(n)
+	if err != nil {
+		return nil, err
+	}
+	eBytes, err := base64.RawURLEncoding.DecodeString(e)
+	if err != nil {
+		return nil, err
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(nBytes),
+		E: int(new(big.Int).SetBytes(eBytes).Int64()),
+	}, nil
+}
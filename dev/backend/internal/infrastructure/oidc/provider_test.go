@@ -0,0 +1,131 @@
+package oidc
+
+import (
+	"backend/config"
+	"backend/internal/domain/model"
+	"backend/internal/infrastructure/oidc/oidctest"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// code_verifier から S256 の code_challenge を計算する
+func challengeOf(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	idp := oidctest.NewServer(t)
+	p := NewProvider("mock", config.OIDCProviderConfig{
+		Issuer:      idp.URL,
+		ClientID:    idp.ClientID,
+		RedirectURL: "http://localhost/callback",
+	}, nil)
+
+	got, err := p.AuthCodeURL(context.Background(), "state", "nonce", "challenge")
+	assert.NoError(t, err)
+
+	u, err := url.Parse(got)
+	assert.NoError(t, err)
+	assert.Equal(t, idp.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	assert.Equal(t, "code", u.Query().Get("response_type"))
+	assert.Equal(t, idp.ClientID, u.Query().Get("client_id"))
+	assert.Equal(t, "openid email profile", u.Query().Get("scope"))
+	assert.Equal(t, "state", u.Query().Get("state"))
+	assert.Equal(t, "nonce", u.Query().Get("nonce"))
+	assert.Equal(t, "challenge", u.Query().Get("code_challenge"))
+	assert.Equal(t, "S256", u.Query().Get("code_challenge_method"))
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	const verifier = "test-code-verifier-0123456789-0123456789-abc"
+
+	tests := []struct {
+		name         string
+		clientID     string
+		verifier     string
+		exchangeWith string // Exchange に渡す nonce
+		want         *model.OIDCIdentity
+		wantErr      bool
+	}{
+		{
+			name:         "正常系: 認可コードからユーザー情報が取得できること",
+			verifier:     verifier,
+			exchangeWith: "nonce",
+			want: &model.OIDCIdentity{
+				Provider:  "mock",
+				Subject:   "test-subject",
+				Email:     "test@example.com",
+				Name:      "Test User",
+				AvatarURL: "https://example.com/avatar.png",
+			},
+			wantErr: false,
+		},
+		{
+			name:         "異常系: code_verifier が一致しない場合エラーになること",
+			verifier:     "wrong-verifier",
+			exchangeWith: "nonce",
+			wantErr:      true,
+		},
+		{
+			name:         "異常系: nonce が一致しない場合エラーになること",
+			verifier:     verifier,
+			exchangeWith: "other-nonce",
+			wantErr:      true,
+		},
+		{
+			name:         "異常系: 認可時とクライアントIDが一致しない場合エラーになること",
+			clientID:     "other-client-id",
+			verifier:     verifier,
+			exchangeWith: "nonce",
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idp := oidctest.NewServer(t)
+			p := NewProvider("mock", config.OIDCProviderConfig{
+				Issuer:      idp.URL,
+				ClientID:    idp.ClientID,
+				RedirectURL: "http://localhost/callback",
+			}, nil)
+
+			authURL, err := p.AuthCodeURL(context.Background(), "state", "nonce", challengeOf(verifier))
+			if err != nil {
+				t.Fatalf("failed to build auth url: %v", err)
+			}
+			code, state := idp.Authorize(t, authURL)
+			assert.Equal(t, "state", state)
+
+			if tt.clientID != "" {
+				// 別のクライアントとしてトークンを交換する
+				p.(*provider).cfg.ClientID = tt.clientID
+			}
+
+			got, err := p.Exchange(context.Background(), code, tt.verifier, tt.exchangeWith)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("provider.Exchange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestProvider_Discovery(t *testing.T) {
+	idp := oidctest.NewServer(t)
+	// Issuer が Discovery ドキュメントと一致しない場合はエラーになる
+	p := NewProvider("mock", config.OIDCProviderConfig{
+		Issuer:   idp.URL + "/",
+		ClientID: idp.ClientID,
+	}, nil)
+
+	_, err := p.AuthCodeURL(context.Background(), "state", "nonce", "challenge")
+	assert.Error(t, err)
+}
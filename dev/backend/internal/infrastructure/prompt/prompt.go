@@ -0,0 +1,141 @@
+package prompt
+
+import (
+	"backend/internal/domain/model"
+	"backend/internal/domain/usecase"
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// 組み込みのプロンプトテンプレート
+// templates/<言語>/<テンプレート名>.v<バージョン>.tmpl の形式で配置する
+//
+//go:embed templates
+var embeddedTemplates embed.FS
+
+var templateFileName = regexp.MustCompile(`^([a-z0-9_]+)\.v([0-9]+)\.tmpl$`)
+
+type templateEntry struct {
+	version int
+	tmpl    *template.Template
+}
+
+type renderer struct {
+	// 言語 -> テンプレート名 -> テンプレート
+	templates       map[string]map[string]templateEntry
+	defaultLanguage string
+}
+
+// 組み込みテンプレートと上書き用ディレクトリのテンプレートを読み込む処理
+// dir が空の場合は組み込みテンプレートのみを使用する
+// 同じ言語・テンプレート名の場合はバージョンが大きいものを採用し、同じバージョンであれば上書き用ディレクトリを優先する
+func NewRenderer(dir string, defaultLanguage string) (usecase.PromptRenderer, error) {
+	if defaultLanguage == "" {
+		defaultLanguage = model.LanguageJapanese
+	}
+
+	r := &renderer{
+		templates:       make(map[string]map[string]templateEntry),
+		defaultLanguage: defaultLanguage,
+	}
+
+	embedded, err := fs.Sub(embeddedTemplates, "templates")
+	if err != nil {
+		return nil, fmt.Errorf("組み込みテンプレートの取得に失敗: %w", err)
+	}
+	if err := r.load(embedded); err != nil {
+		return nil, fmt.Errorf("組み込みテンプレートの読み込みに失敗: %w", err)
+	}
+
+	if dir != "" {
+		if err := r.load(os.DirFS(dir)); err != nil {
+			return nil, fmt.Errorf("テンプレートディレクトリの読み込みに失敗 (dir: %s): %w", dir, err)
+		}
+	}
+
+	if _, ok := r.templates[defaultLanguage]; !ok {
+		return nil, fmt.Errorf("既定の言語のテンプレートが存在しません (language: %s)", defaultLanguage)
+	}
+
+	return r, nil
+}
+
+// ファイルシステムからテンプレートを読み込む処理
+func (r *renderer) load(fsys fs.FS) error {
+	return fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		language := path.Dir(p)
+		matches := templateFileName.FindStringSubmatch(path.Base(p))
+		if language == "." || strings.Contains(language, "/") || matches == nil {
+			// 規約外のファイルは無視する
+			return nil
+		}
+
+		name := matches[1]
+		version, err := strconv.Atoi(matches[2])
+		if err != nil {
+			return fmt.Errorf("バージョンの解析に失敗 (file: %s): %w", p, err)
+		}
+
+		if current, ok := r.templates[language][name]; ok && current.version > version {
+			return nil
+		}
+
+		content, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return fmt.Errorf("テンプレートの読み込みに失敗 (file: %s): %w", p, err)
+		}
+
+		tmpl, err := template.New(name).Option("missingkey=error").Parse(string(content))
+		if err != nil {
+			return fmt.Errorf("テンプレートの解析に失敗 (file: %s): %w", p, err)
+		}
+
+		if _, ok := r.templates[language]; !ok {
+			r.templates[language] = make(map[string]templateEntry)
+		}
+		r.templates[language][name] = templateEntry{version: version, tmpl: tmpl}
+		return nil
+	})
+}
+
+// 指定された言語のテンプレートをレンダリングする処理
+func (r *renderer) Render(name string, language string, data any) (*model.RenderedPrompt, error) {
+	entry, ok := r.templates[language][name]
+	if !ok {
+		language = r.defaultLanguage
+		entry, ok = r.templates[language][name]
+	}
+	if !ok {
+		return nil, fmt.Errorf("テンプレートが存在しません (name: %s)", name)
+	}
+
+	var buf bytes.Buffer
+	if err := entry.tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("テンプレートのレンダリングに失敗 (name: %s): %w", name, err)
+	}
+
+	return &model.RenderedPrompt{
+		Text:    strings.TrimSpace(buf.String()),
+		Version: fmt.Sprintf("%s/%s/v%d", name, language, entry.version),
+	}, nil
+}
+
+// 既定の出力言語を返す処理
+func (r *renderer) DefaultLanguage() string {
+	return r.defaultLanguage
+}
@@ -0,0 +1,323 @@
+package prompt
+
+import (
+	"backend/internal/domain/model"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderer_Render(t *testing.T) {
+	type args struct {
+		name     string
+		language string
+		data     any
+	}
+	tests := []struct {
+		name      string
+		setupDir  func(t *testing.T) string
+		args      args
+		wantErr   bool
+		assertion func(t *testing.T, got *model.RenderedPrompt)
+	}{
+		{
+			name:     "正常系: 組み込みの日本語テンプレートがレンダリングできること",
+			setupDir: func(t *testing.T) string { return "" },
+			args: args{
+				name:     model.PromptStreamSummaryContext,
+				language: model.LanguageJapanese,
+				data:     map[string]string{"Summary": "要約"},
+			},
+			wantErr: false,
+			assertion: func(t *testing.T, got *model.RenderedPrompt) {
+				assert.Equal(t, "以下の会話の要約を踏まえて回答してください:\n要約", got.Text)
+				assert.Equal(t, "stream_summary_context/ja/v1", got.Version)
+			},
+		},
+		{
+			name:     "正常系: 英語テンプレートが選択されること",
+			setupDir: func(t *testing.T) string { return "" },
+			args: args{
+				name:     model.PromptSummaryBase,
+				language: model.LanguageEnglish,
+				data:     map[string]string{"Summary": "summary"},
+			},
+			wantErr: false,
+			assertion: func(t *testing.T, got *model.RenderedPrompt) {
+				assert.Equal(t, "Summary of the conversation so far:\nsummary", got.Text)
+				assert.Equal(t, "summary_base/en/v1", got.Version)
+			},
+		},
+		{
+			name:     "正常系: マージレポートの起点と統合の指示が埋め込まれること",
+			setupDir: func(t *testing.T) string { return "" },
+			args: args{
+				name:     model.PromptMergeReportContext,
+				language: model.LanguageEnglish,
+				data:     map[string]string{"Report": "conclusion", "Anchor": "answer", "Acknowledge": "true"},
+			},
+			wantErr: false,
+			assertion: func(t *testing.T, got *model.RenderedPrompt) {
+				assert.Contains(t, got.Text, "## Response the branch started from\nanswer")
+				assert.Contains(t, got.Text, "## Merge report\nconclusion")
+				assert.Contains(t, got.Text, "Integrate the conclusion")
+				assert.Equal(t, "merge_report_context/en/v1", got.Version)
+			},
+		},
+		{
+			name:     "正常系: 起点がないマージレポートは引用を省略すること",
+			setupDir: func(t *testing.T) string { return "" },
+			args: args{
+				name:     model.PromptMergeReportContext,
+				language: model.LanguageJapanese,
+				data:     map[string]string{"Report": "結論", "Anchor": "", "Acknowledge": ""},
+			},
+			wantErr: false,
+			assertion: func(t *testing.T, got *model.RenderedPrompt) {
+				assert.NotContains(t, got.Text, "ブランチの起点となった回答")
+				assert.NotContains(t, got.Text, "統合し")
+				assert.Contains(t, got.Text, "## マージレポート\n結論")
+			},
+		},
+		{
+			name:     "正常系: 親チャットが進んでいる場合はマージプレビューに調整の項目が含まれること",
+			setupDir: func(t *testing.T) string { return "" },
+			args: args{
+				name:     model.PromptMergePreview,
+				language: model.LanguageJapanese,
+				data:     map[string]string{"ContextSummary": "", "LatestSummary": "", "LatestAssistant": "結論", "ParentProgress": "親の回答"},
+			},
+			wantErr: false,
+			assertion: func(t *testing.T, got *model.RenderedPrompt) {
+				assert.Contains(t, got.Text, "親の回答")
+				assert.Contains(t, got.Text, "## 親チャットとの調整")
+				assert.Equal(t, "merge_preview/ja/v2", got.Version)
+			},
+		},
+		{
+			name:     "正常系: 親チャットが進んでいない場合は調整の項目を省略すること",
+			setupDir: func(t *testing.T) string { return "" },
+			args: args{
+				name:     model.PromptMergePreview,
+				language: model.LanguageEnglish,
+				data:     map[string]string{"ContextSummary": "", "LatestSummary": "", "LatestAssistant": "conclusion", "ParentProgress": ""},
+			},
+			wantErr: false,
+			assertion: func(t *testing.T, got *model.RenderedPrompt) {
+				assert.NotContains(t, got.Text, "Reconciliation")
+				assert.NotContains(t, got.Text, "since the fork")
+				assert.True(t, strings.HasSuffix(got.Text, "(describe the conclusion)"))
+			},
+		},
+		{
+			name:     "正常系: 範囲を選択しないフォークはメッセージ全体を起点にすること",
+			setupDir: func(t *testing.T) string { return "" },
+			args: args{
+				name:     model.PromptForkPreview,
+				language: model.LanguageJapanese,
+				data:     map[string]any{"SelectedText": "", "RangeStart": 0, "RangeEnd": 0},
+			},
+			wantErr: false,
+			assertion: func(t *testing.T, got *model.RenderedPrompt) {
+				assert.True(t, strings.HasPrefix(got.Text, "ユーザーは上記の会話の最後のメッセージ全体を起点に"))
+				assert.NotContains(t, got.Text, "選択範囲")
+				assert.Equal(t, "fork_preview/ja/v2", got.Version)
+			},
+		},
+		{
+			name:     "正常系: 範囲を選択したフォークは選択範囲を含めること",
+			setupDir: func(t *testing.T) string { return "" },
+			args: args{
+				name:     model.PromptForkPreview,
+				language: model.LanguageEnglish,
+				data:     map[string]any{"SelectedText": "topic", "RangeStart": 3, "RangeEnd": 8},
+			},
+			wantErr: false,
+			assertion: func(t *testing.T, got *model.RenderedPrompt) {
+				assert.Contains(t, got.Text, "Selection: \"topic\"\n(Range: characters 3 to 8)")
+				assert.Contains(t, got.Text, "selected topic")
+			},
+		},
+		{
+			name:     "正常系: ブランチの比較に両方のブランチの情報が埋め込まれること",
+			setupDir: func(t *testing.T) string { return "" },
+			args: args{
+				name:     model.PromptBranchComparison,
+				language: model.LanguageJapanese,
+				data: map[string]string{
+					"Anchor": "", "ChatTitle": "案A", "ChatContext": "Aを検討", "ChatSummary": "", "ChatMessages": "Aの回答",
+					"OtherTitle": "案B", "OtherContext": "", "OtherSummary": "Bの要約", "OtherMessages": "Bの回答",
+				},
+			},
+			wantErr: false,
+			assertion: func(t *testing.T, got *model.RenderedPrompt) {
+				assert.Contains(t, got.Text, "## ブランチA: 案A\n### 分岐した理由 (文脈)\nAを検討")
+				assert.Contains(t, got.Text, "## ブランチB: 案B")
+				assert.Contains(t, got.Text, "Bの要約")
+				assert.NotContains(t, got.Text, "分岐の起点となったメッセージ")
+				assert.Contains(t, got.Text, `"recommendation"`)
+				assert.Equal(t, "branch_comparison/ja/v1", got.Version)
+			},
+		},
+		{
+			name:     "正常系: 取り込んだAIの回答が取り込み元の説明付きで埋め込まれること",
+			setupDir: func(t *testing.T) string { return "" },
+			args: args{
+				name:     model.PromptCherryPickContext,
+				language: model.LanguageEnglish,
+				data:     map[string]string{"Role": "assistant", "Content": "copied answer"},
+			},
+			wantErr: false,
+			assertion: func(t *testing.T, got *model.RenderedPrompt) {
+				assert.Contains(t, got.Text, "AI answer copied into this conversation from another branch")
+				assert.Contains(t, got.Text, "copied answer")
+				assert.Equal(t, "cherry_pick_context/en/v1", got.Version)
+			},
+		},
+		{
+			name:     "正常系: フォーク候補の抽出に最大件数と本文からの抜き出しの指示が埋め込まれること",
+			setupDir: func(t *testing.T) string { return "" },
+			args: args{
+				name:     model.PromptForkSuggestion,
+				language: model.LanguageJapanese,
+				data:     map[string]any{"MaxSuggestions": 5},
+			},
+			wantErr: false,
+			assertion: func(t *testing.T, got *model.RenderedPrompt) {
+				assert.Contains(t, got.Text, "最大5件")
+				assert.Contains(t, got.Text, "一字一句そのまま")
+				assert.Contains(t, got.Text, `"suggestions"`)
+				assert.Equal(t, "fork_suggestion/ja/v1", got.Version)
+			},
+		},
+		{
+			name:     "正常系: 未対応の言語は既定の言語にフォールバックすること",
+			setupDir: func(t *testing.T) string { return "" },
+			args: args{
+				name:     model.PromptSummaryInstruction,
+				language: "fr",
+				data:     nil,
+			},
+			wantErr: false,
+			assertion: func(t *testing.T, got *model.RenderedPrompt) {
+				assert.Equal(t, "summary_instruction/ja/v1", got.Version)
+			},
+		},
+		{
+			name: "正常系: 上書き用ディレクトリの新しいバージョンが優先されること",
+			setupDir: func(t *testing.T) string {
+				dir := t.TempDir()
+				if err := os.MkdirAll(filepath.Join(dir, "ja"), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(dir, "ja", "summary_base.v2.tmpl"), []byte("カスタム: {{.Summary}}\n"), 0644); err != nil {
+					t.Fatal(err)
+				}
+				return dir
+			},
+			args: args{
+				name:     model.PromptSummaryBase,
+				language: model.LanguageJapanese,
+				data:     map[string]string{"Summary": "要約"},
+			},
+			wantErr: false,
+			assertion: func(t *testing.T, got *model.RenderedPrompt) {
+				assert.Equal(t, "カスタム: 要約", got.Text)
+				assert.Equal(t, "summary_base/ja/v2", got.Version)
+			},
+		},
+		{
+			name: "正常系: 上書き用ディレクトリの古いバージョンは無視されること",
+			setupDir: func(t *testing.T) string {
+				dir := t.TempDir()
+				if err := os.MkdirAll(filepath.Join(dir, "ja"), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(dir, "ja", "summary_base.v0.tmpl"), []byte("古い: {{.Summary}}"), 0644); err != nil {
+					t.Fatal(err)
+				}
+				return dir
+			},
+			args: args{
+				name:     model.PromptSummaryBase,
+				language: model.LanguageJapanese,
+				data:     map[string]string{"Summary": "要約"},
+			},
+			wantErr: false,
+			assertion: func(t *testing.T, got *model.RenderedPrompt) {
+				assert.Equal(t, "summary_base/ja/v1", got.Version)
+			},
+		},
+		{
+			name:     "異常系: 存在しないテンプレートの場合エラーになること",
+			setupDir: func(t *testing.T) string { return "" },
+			args: args{
+				name:     "unknown",
+				language: model.LanguageJapanese,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewRenderer(tt.setupDir(t), model.LanguageJapanese)
+			if err != nil {
+				t.Fatalf("failed to create renderer: %v", err)
+			}
+
+			got, err := r.Render(tt.args.name, tt.args.language, tt.args.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("renderer.Render() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.assertion != nil {
+				tt.assertion(t, got)
+			}
+		})
+	}
+}
+
+func TestNewRenderer(t *testing.T) {
+	tests := []struct {
+		name            string
+		dir             string
+		defaultLanguage string
+		wantErr         bool
+	}{
+		{
+			name:            "正常系: 既定の言語が未指定の場合は日本語になること",
+			dir:             "",
+			defaultLanguage: "",
+			wantErr:         false,
+		},
+		{
+			name:            "異常系: 既定の言語のテンプレートが存在しない場合エラーになること",
+			dir:             "",
+			defaultLanguage: "fr",
+			wantErr:         true,
+		},
+		{
+			name:            "異常系: 上書き用ディレクトリが存在しない場合エラーになること",
+			dir:             "/non/existent/dir",
+			defaultLanguage: model.LanguageJapanese,
+			wantErr:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewRenderer(tt.dir, tt.defaultLanguage)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewRenderer() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && tt.defaultLanguage == "" {
+				assert.Equal(t, model.LanguageJapanese, r.DefaultLanguage())
+			}
+		})
+	}
+}
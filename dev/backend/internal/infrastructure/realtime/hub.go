@@ -0,0 +1,190 @@
+package realtime
+
+import (
+	"backend/internal/domain/model"
+	"backend/internal/domain/usecase"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// 購読者ごとのイベントのバッファ数 (溢れたイベントはその購読者には配信しない)
+	subscriberBufferSize = 32
+	// 入力中の状態を保持する時間 (クライアントは入力中に定期的に更新する)
+	typingTTL = 5 * time.Second
+)
+
+type subscriber struct {
+	viewer      model.ChatPresence
+	events      chan model.ChatEvent
+	typingUntil time.Time
+}
+
+// プロセス内で閲覧者とイベントを管理するハブ
+// 同じユーザーが複数のタブで閲覧している場合も閲覧者としては一人として扱う
+type hub struct {
+	mu sync.Mutex
+	// チャットUUID -> 購読者
+	chats map[string]map[*subscriber]struct{}
+	now   func() time.Time
+}
+
+// ChatEventHub の新しいインスタンスを作成する処理
+func NewHub() usecase.ChatEventHub {
+	return &hub{
+		chats: make(map[string]map[*subscriber]struct{}),
+		now:   time.Now,
+	}
+}
+
+// チャットのイベントを購読し、閲覧者として登録する処理
+func (h *hub) Subscribe(viewer model.ChatPresence) (<-chan model.ChatEvent, func()) {
+	sub := &subscriber{
+		viewer: viewer,
+		events: make(chan model.ChatEvent, subscriberBufferSize),
+	}
+	sub.viewer.JoinedAt = h.now()
+
+	h.mu.Lock()
+	subs, ok := h.chats[viewer.ChatUUID]
+	if !ok {
+		subs = make(map[*subscriber]struct{})
+		h.chats[viewer.ChatUUID] = subs
+	}
+	subs[sub] = struct{}{}
+	h.broadcastPresenceLocked(viewer.ChatUUID)
+	h.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			delete(h.chats[viewer.ChatUUID], sub)
+			if len(h.chats[viewer.ChatUUID]) == 0 {
+				delete(h.chats, viewer.ChatUUID)
+			}
+			close(sub.events)
+			h.broadcastPresenceLocked(viewer.ChatUUID)
+		})
+	}
+	return sub.events, unsubscribe
+}
+
+// チャットの閲覧者にイベントを配信する処理
+func (h *hub) Publish(event model.ChatEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.publishLocked(event)
+}
+
+// 閲覧者の入力中の状態を更新する処理
+// 状態が変わった場合のみ閲覧者一覧を配信する
+func (h *hub) SetTyping(chatUUID string, userUUID string, typing bool) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+	found := false
+	changed := false
+	for sub := range h.chats[chatUUID] {
+		if sub.viewer.UserUUID != userUUID {
+			continue
+		}
+		found = true
+		wasTyping := now.Before(sub.typingUntil)
+		if typing {
+			sub.typingUntil = now.Add(typingTTL)
+		} else {
+			sub.typingUntil = time.Time{}
+		}
+		if wasTyping != typing {
+			changed = true
+		}
+	}
+	if changed {
+		h.broadcastPresenceLocked(chatUUID)
+	}
+	return found
+}
+
+// チャットの閲覧者一覧を返す処理
+func (h *hub) ChatPresence(chatUUID string) []*model.ChatPresence {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.presenceLocked(chatUUID)
+}
+
+// プロジェクト内のチャットの閲覧者一覧を返す処理
+func (h *hub) ProjectPresence(projectUUID string) []*model.ChatPresence {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	presence := []*model.ChatPresence{}
+	for chatUUID, subs := range h.chats {
+		for sub := range subs {
+			if sub.viewer.ProjectUUID == projectUUID {
+				presence = append(presence, h.presenceLocked(chatUUID)...)
+				break
+			}
+		}
+	}
+	sort.Slice(presence, func(i, j int) bool {
+		if presence[i].ChatUUID != presence[j].ChatUUID {
+			return presence[i].ChatUUID < presence[j].ChatUUID
+		}
+		return presence[i].JoinedAt.Before(presence[j].JoinedAt)
+	})
+	return presence
+}
+
+// チャットの閲覧者をユーザーごとにまとめる処理 (呼び出し元でロックを取得すること)
+func (h *hub) presenceLocked(chatUUID string) []*model.ChatPresence {
+	now := h.now()
+	byUser := make(map[string]*model.ChatPresence)
+	for sub := range h.chats[chatUUID] {
+		typing := now.Before(sub.typingUntil)
+		if p, ok := byUser[sub.viewer.UserUUID]; ok {
+			p.Typing = p.Typing || typing
+			if sub.viewer.JoinedAt.Before(p.JoinedAt) {
+				p.JoinedAt = sub.viewer.JoinedAt
+			}
+			continue
+		}
+		p := sub.viewer
+		p.Typing = typing
+		byUser[sub.viewer.UserUUID] = &p
+	}
+
+	presence := make([]*model.ChatPresence, 0, len(byUser))
+	for _, p := range byUser {
+		presence = append(presence, p)
+	}
+	sort.Slice(presence, func(i, j int) bool {
+		return presence[i].JoinedAt.Before(presence[j].JoinedAt)
+	})
+	return presence
+}
+
+// 閲覧者一覧をチャットの閲覧者に配信する処理 (呼び出し元でロックを取得すること)
+func (h *hub) broadcastPresenceLocked(chatUUID string) {
+	h.publishLocked(model.ChatEvent{
+		Type:     model.ChatEventPresence,
+		ChatUUID: chatUUID,
+		Presence: h.presenceLocked(chatUUID),
+	})
+}
+
+// イベントを配信する処理 (呼び出し元でロックを取得すること)
+// 受信が追いつかない購読者のためにハブ全体を止めないよう、バッファが溢れた場合は破棄する
+func (h *hub) publishLocked(event model.ChatEvent) {
+	for sub := range h.chats[event.ChatUUID] {
+		select {
+		case sub.events <- event:
+		default:
+			slog.Warn("イベントのバッファが溢れたため破棄しました", "chat_uuid", event.ChatUUID, "user_uuid", sub.viewer.UserUUID, "type", event.Type)
+		}
+	}
+}
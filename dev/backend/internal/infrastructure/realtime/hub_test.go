@@ -0,0 +1,130 @@
+package realtime
+
+import (
+	"backend/internal/domain/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 次のイベントを受信する処理 (受信できない場合はテストを失敗させる)
+func receive(t *testing.T, events <-chan model.ChatEvent) model.ChatEvent {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	default:
+		t.Fatal("イベントを受信できませんでした")
+		return model.ChatEvent{}
+	}
+}
+
+func TestHub_Subscribe(t *testing.T) {
+	t.Run("正常系: 参加・退出時に閲覧者一覧が配信されること", func(t *testing.T) {
+		h := NewHub()
+		alice, unsubscribeAlice := h.Subscribe(model.ChatPresence{ProjectUUID: "project-uuid", ChatUUID: "chat-uuid", UserUUID: "alice"})
+		assert.Len(t, receive(t, alice).Presence, 1)
+
+		bob, unsubscribeBob := h.Subscribe(model.ChatPresence{ProjectUUID: "project-uuid", ChatUUID: "chat-uuid", UserUUID: "bob"})
+		assert.Len(t, receive(t, alice).Presence, 2)
+		assert.Len(t, receive(t, bob).Presence, 2)
+
+		unsubscribeBob()
+		unsubscribeBob() // 二重に解除しても問題ないこと
+		event := receive(t, alice)
+		assert.Equal(t, model.ChatEventPresence, event.Type)
+		if !assert.Len(t, event.Presence, 1) {
+			return
+		}
+		assert.Equal(t, "alice", event.Presence[0].UserUUID)
+
+		unsubscribeAlice()
+		assert.Empty(t, h.ChatPresence("chat-uuid"))
+	})
+
+	t.Run("正常系: 同じユーザーの複数の接続は一人として扱われること", func(t *testing.T) {
+		h := NewHub()
+		_, unsubscribe1 := h.Subscribe(model.ChatPresence{ProjectUUID: "project-uuid", ChatUUID: "chat-uuid", UserUUID: "alice"})
+		defer unsubscribe1()
+		_, unsubscribe2 := h.Subscribe(model.ChatPresence{ProjectUUID: "project-uuid", ChatUUID: "chat-uuid", UserUUID: "alice"})
+		defer unsubscribe2()
+
+		assert.Len(t, h.ChatPresence("chat-uuid"), 1)
+	})
+}
+
+func TestHub_Publish(t *testing.T) {
+	t.Run("正常系: 同じチャットの閲覧者にのみ配信されること", func(t *testing.T) {
+		h := NewHub()
+		viewer, unsubscribeViewer := h.Subscribe(model.ChatPresence{ProjectUUID: "project-uuid", ChatUUID: "chat-uuid", UserUUID: "alice"})
+		defer unsubscribeViewer()
+		other, unsubscribeOther := h.Subscribe(model.ChatPresence{ProjectUUID: "project-uuid", ChatUUID: "other-chat-uuid", UserUUID: "bob"})
+		defer unsubscribeOther()
+		receive(t, viewer)
+		receive(t, other)
+
+		h.Publish(model.ChatEvent{Type: model.ChatEventMessage, ChatUUID: "chat-uuid", Message: &model.Message{UUID: "msg-uuid"}})
+
+		event := receive(t, viewer)
+		assert.Equal(t, model.ChatEventMessage, event.Type)
+		assert.Equal(t, "msg-uuid", event.Message.UUID)
+		assert.Empty(t, other)
+	})
+
+	t.Run("正常系: バッファが溢れても配信が止まらないこと", func(t *testing.T) {
+		h := NewHub()
+		_, unsubscribe := h.Subscribe(model.ChatPresence{ProjectUUID: "project-uuid", ChatUUID: "chat-uuid", UserUUID: "alice"})
+		defer unsubscribe()
+
+		for i := 0; i < subscriberBufferSize*2; i++ {
+			h.Publish(model.ChatEvent{Type: model.ChatEventMessage, ChatUUID: "chat-uuid"})
+		}
+	})
+}
+
+func TestHub_SetTyping(t *testing.T) {
+	t.Run("正常系: 入力中の状態が変わった場合に閲覧者一覧が配信されること", func(t *testing.T) {
+		h := NewHub()
+		alice, unsubscribe := h.Subscribe(model.ChatPresence{ProjectUUID: "project-uuid", ChatUUID: "chat-uuid", UserUUID: "alice"})
+		defer unsubscribe()
+		receive(t, alice)
+
+		assert.True(t, h.SetTyping("chat-uuid", "alice", true))
+		event := receive(t, alice)
+		if !assert.Len(t, event.Presence, 1) {
+			return
+		}
+		assert.True(t, event.Presence[0].Typing)
+
+		// 状態が変わらない場合は配信しない
+		assert.True(t, h.SetTyping("chat-uuid", "alice", true))
+		assert.Empty(t, alice)
+
+		assert.True(t, h.SetTyping("chat-uuid", "alice", false))
+		assert.False(t, receive(t, alice).Presence[0].Typing)
+	})
+
+	t.Run("異常系: 閲覧していないユーザーの場合falseを返すこと", func(t *testing.T) {
+		h := NewHub()
+		assert.False(t, h.SetTyping("chat-uuid", "alice", true))
+	})
+}
+
+func TestHub_ProjectPresence(t *testing.T) {
+	t.Run("正常系: プロジェクト内のチャットの閲覧者のみ返すこと", func(t *testing.T) {
+		h := NewHub()
+		_, unsubscribe1 := h.Subscribe(model.ChatPresence{ProjectUUID: "project-uuid", ChatUUID: "chat-1", UserUUID: "alice"})
+		defer unsubscribe1()
+		_, unsubscribe2 := h.Subscribe(model.ChatPresence{ProjectUUID: "project-uuid", ChatUUID: "chat-2", UserUUID: "bob"})
+		defer unsubscribe2()
+		_, unsubscribe3 := h.Subscribe(model.ChatPresence{ProjectUUID: "other-project-uuid", ChatUUID: "chat-3", UserUUID: "carol"})
+		defer unsubscribe3()
+
+		presence := h.ProjectPresence("project-uuid")
+		if !assert.Len(t, presence, 2) {
+			return
+		}
+		assert.Equal(t, "chat-1", presence[0].ChatUUID)
+		assert.Equal(t, "chat-2", presence[1].ChatUUID)
+	})
+}
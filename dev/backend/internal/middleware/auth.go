@@ -2,33 +2,48 @@ package middleware
 
 import (
 	"backend/config"
+	domainModel "backend/internal/domain/model"
+	"backend/internal/domain/usecase"
 	"backend/internal/handler/model"
+	"backend/internal/i18n"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strings"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
 )
 
 type AuthMiddleware struct {
-	cfg *config.Config
+	cfg             *config.Config
+	authUsecase     usecase.AuthUsecase
+	apiTokenUsecase usecase.APITokenUsecase
 }
 
-func NewAuthMiddleware(cfg *config.Config) *AuthMiddleware {
+// apiTokenUsecase が nil の場合は Authorization ヘッダーのAPIトークンを受け付けない
+func NewAuthMiddleware(cfg *config.Config, authUsecase usecase.AuthUsecase, apiTokenUsecase usecase.APITokenUsecase) *AuthMiddleware {
 	return &AuthMiddleware{
-		cfg: cfg,
+		cfg:             cfg,
+		authUsecase:     authUsecase,
+		apiTokenUsecase: apiTokenUsecase,
 	}
 }
 
+// クッキーのアクセストークン、または Authorization ヘッダーのAPIトークンで認証する処理
 func (m *AuthMiddleware) Authenticate(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
+		if rawToken, ok := bearerToken(c); ok && m.apiTokenUsecase != nil {
+			return m.authenticateAPIToken(c, rawToken, next)
+		}
+
 		cookie, err := c.Cookie("jwt_token")
 		if err != nil {
 			slog.WarnContext(c.Request().Context(), "JWTトークンが見つかりません", "error", err)
 			return c.JSON(http.StatusUnauthorized, model.Response{
 				Status:  "error",
-				Message: "JWTトークンが見つかりません",
+				Message: localize(c, i18n.KeyTokenNotFound),
 			})
 		}
 
@@ -37,14 +52,16 @@ func (m *AuthMiddleware) Authenticate(next echo.HandlerFunc) echo.HandlerFunc {
 			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 			}
-			return []byte(m.cfg.JWT.Secret), nil
+			// 鍵のローテーションに対応するため、ヘッダーの kid で検証する鍵を選択する
+			kid, _ := token.Header["kid"].(string)
+			return m.cfg.JWT.VerificationKey(kid)
 		})
 
 		if err != nil || !token.Valid {
 			slog.WarnContext(c.Request().Context(), "無効なJWTトークンです", "error", err)
 			return c.JSON(http.StatusUnauthorized, model.Response{
 				Status:  "error",
-				Message: "無効なJWTトークンです",
+				Message: localize(c, i18n.KeyTokenInvalid),
 			})
 		}
 
@@ -53,7 +70,7 @@ func (m *AuthMiddleware) Authenticate(next echo.HandlerFunc) echo.HandlerFunc {
 			slog.WarnContext(c.Request().Context(), "無効なトークンクレームです")
 			return c.JSON(http.StatusUnauthorized, model.Response{
 				Status:  "error",
-				Message: "無効なトークンクレームです",
+				Message: localize(c, i18n.KeyTokenClaimsInvalid),
 			})
 		}
 
@@ -62,12 +79,147 @@ func (m *AuthMiddleware) Authenticate(next echo.HandlerFunc) echo.HandlerFunc {
 			slog.WarnContext(c.Request().Context(), "トークンにユーザーUUIDが含まれていません")
 			return c.JSON(http.StatusUnauthorized, model.Response{
 				Status:  "error",
-				Message: "トークンにユーザーUUIDが含まれていません",
+				Message: localize(c, i18n.KeyTokenUserUUIDAbsent),
+			})
+		}
+
+		// ログアウト・失効したセッションのトークンを拒否するため、sid のないトークンは受け付けない
+		sessionUUID, ok := claims["sid"].(string)
+		if !ok {
+			slog.WarnContext(c.Request().Context(), "トークンにセッションIDが含まれていません", "user_uuid", userUUID)
+			return c.JSON(http.StatusUnauthorized, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyTokenClaimsInvalid),
+			})
+		}
+		if err := m.authUsecase.ValidateSession(c.Request().Context(), userUUID, sessionUUID); err != nil {
+			if errors.Is(err, domainModel.ErrSessionRevoked) || errors.Is(err, domainModel.ErrSessionNotFound) {
+				slog.WarnContext(c.Request().Context(), "失効したセッションのトークンです", "user_uuid", userUUID, "session_uuid", sessionUUID)
+				return c.JSON(http.StatusUnauthorized, model.Response{
+					Status:  "error",
+					Message: localize(c, i18n.KeySessionRevoked),
+				})
+			}
+			slog.ErrorContext(c.Request().Context(), "セッションの確認に失敗", "session_uuid", sessionUUID, "error", err)
+			return c.JSON(http.StatusInternalServerError, model.Response{
+				Status:  "error",
+				Message: err.Error(),
 			})
 		}
 
 		c.Set("user_uuid", userUUID)
+		c.Set("session_uuid", sessionUUID)
+
+		return next(c)
+	}
+}
+
+// ブラウザのセッションでのみ許可する処理（APIトークンの管理など）の前に適用する処理
+func (m *AuthMiddleware) RequireSession(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if _, ok := c.Get("api_token_uuid").(string); ok {
+			slog.WarnContext(c.Request().Context(), "APIトークンでは利用できない機能です", "path", c.Path())
+			return c.JSON(http.StatusForbidden, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyAPITokenNotAllowed),
+			})
+		}
+		return next(c)
+	}
+}
 
+// GET でもデータを書き込む処理（回答の生成など）の前に適用する処理
+// 読み取り専用のAPIトークンでは利用できないようにする（クッキーで認証された場合はそのまま通す）
+func (m *AuthMiddleware) RequireWriteScope(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if scope, ok := c.Get("api_token_scope").(string); ok && scope != domainModel.APITokenScopeWrite {
+			slog.WarnContext(c.Request().Context(), "読み取り専用のAPIトークンでは利用できない機能です", "path", c.Path())
+			// 監査ログには拒否として記録する
+			c.Set("api_token_scope_denied", true)
+			return c.JSON(http.StatusForbidden, model.Response{
+				Status:  "error",
+				Message: localize(c, i18n.KeyAPITokenScopeDenied),
+			})
+		}
 		return next(c)
 	}
 }
+
+// APIトークンで認証し、スコープを検証してリクエストを監査ログに記録する処理
+func (m *AuthMiddleware) authenticateAPIToken(c echo.Context, rawToken string, next echo.HandlerFunc) error {
+	ctx := c.Request().Context()
+	token, err := m.apiTokenUsecase.Authenticate(ctx, rawToken)
+	if err != nil {
+		if errors.Is(err, domainModel.ErrInvalidAPIToken) {
+			slog.WarnContext(ctx, "無効なAPIトークンです")
+		} else {
+			slog.ErrorContext(ctx, "APIトークンの検証に失敗", "error", err)
+		}
+		return c.JSON(http.StatusUnauthorized, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyAPITokenInvalid),
+		})
+	}
+
+	c.Set("user_uuid", token.UserUUID)
+	c.Set("api_token_uuid", token.UUID)
+	c.Set("api_token_scope", token.Scope)
+
+	access := domainModel.APITokenAccess{
+		Method:      c.Request().Method,
+		ProjectUUID: c.Param("project_uuid"),
+		ChatUUID:    c.Param("chat_uuid"),
+	}
+	if err := m.apiTokenUsecase.Authorize(ctx, token, access); err != nil {
+		slog.WarnContext(ctx, "APIトークンのスコープ外のリクエストです", "api_token_uuid", token.UUID, "error", err)
+		m.recordAPITokenUsage(c, token, domainModel.APITokenEventDenied, http.StatusForbidden)
+		return c.JSON(http.StatusForbidden, model.Response{
+			Status:  "error",
+			Message: localize(c, i18n.KeyAPITokenScopeDenied),
+		})
+	}
+
+	err = next(c)
+	status := c.Response().Status
+	if err != nil {
+		var httpErr *echo.HTTPError
+		if errors.As(err, &httpErr) {
+			status = httpErr.Code
+		} else {
+			status = http.StatusInternalServerError
+		}
+	}
+	event := domainModel.APITokenEventUsed
+	if denied, _ := c.Get("api_token_scope_denied").(bool); denied {
+		event = domainModel.APITokenEventDenied
+	}
+	m.recordAPITokenUsage(c, token, event, status)
+	return err
+}
+
+// APIトークンの使用を監査ログに記録する処理 (記録に失敗してもレスポンスには影響させない)
+func (m *AuthMiddleware) recordAPITokenUsage(c echo.Context, token *domainModel.APIToken, event string, status int) {
+	ctx := c.Request().Context()
+	err := m.apiTokenUsecase.RecordUsage(ctx, &domainModel.APITokenAuditLog{
+		APITokenUUID: token.UUID,
+		UserUUID:     token.UserUUID,
+		Event:        event,
+		Method:       c.Request().Method,
+		Path:         c.Request().URL.Path,
+		StatusCode:   status,
+		IPAddress:    c.RealIP(),
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "APIトークンの監査ログの記録に失敗", "api_token_uuid", token.UUID, "error", err)
+	}
+}
+
+// Authorization ヘッダーから Bearer トークンを取り出す処理
+func bearerToken(c echo.Context) (string, bool) {
+	header := c.Request().Header.Get(echo.HeaderAuthorization)
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
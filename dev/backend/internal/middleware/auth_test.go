@@ -2,6 +2,9 @@ package middleware
 
 import (
 	"backend/config"
+	"backend/internal/domain/model"
+	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -10,14 +13,122 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
+type mockAPITokenUsecase struct {
+	mock.Mock
+}
+
+func (m *mockAPITokenUsecase) CreateToken(ctx context.Context, userUUID string, params model.CreateAPITokenParams) (*model.APIToken, string, error) {
+	args := m.Called(ctx, userUUID, params)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*model.APIToken), args.String(1), args.Error(2)
+}
+
+func (m *mockAPITokenUsecase) ListTokens(ctx context.Context, userUUID string) ([]*model.APIToken, error) {
+	args := m.Called(ctx, userUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.APIToken), args.Error(1)
+}
+
+func (m *mockAPITokenUsecase) RevokeToken(ctx context.Context, userUUID string, tokenUUID string) error {
+	args := m.Called(ctx, userUUID, tokenUUID)
+	return args.Error(0)
+}
+
+func (m *mockAPITokenUsecase) ListAuditLogs(ctx context.Context, userUUID string, tokenUUID string) ([]*model.APITokenAuditLog, error) {
+	args := m.Called(ctx, userUUID, tokenUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.APITokenAuditLog), args.Error(1)
+}
+
+func (m *mockAPITokenUsecase) Authenticate(ctx context.Context, rawToken string) (*model.APIToken, error) {
+	args := m.Called(ctx, rawToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.APIToken), args.Error(1)
+}
+
+func (m *mockAPITokenUsecase) Authorize(ctx context.Context, token *model.APIToken, access model.APITokenAccess) error {
+	args := m.Called(ctx, token, access)
+	return args.Error(0)
+}
+
+func (m *mockAPITokenUsecase) RecordUsage(ctx context.Context, log *model.APITokenAuditLog) error {
+	args := m.Called(ctx, log)
+	return args.Error(0)
+}
+
+type mockAuthUsecase struct {
+	mock.Mock
+}
+
+func (m *mockAuthUsecase) GuestSignup(ctx context.Context, client model.SessionClient) (*model.User, *model.AuthTokens, error) {
+	args := m.Called(ctx, client)
+	return nil, nil, args.Error(2)
+}
+
+func (m *mockAuthUsecase) GuestLogin(ctx context.Context, userUUID string, client model.SessionClient) (*model.User, *model.AuthTokens, error) {
+	args := m.Called(ctx, userUUID, client)
+	return nil, nil, args.Error(2)
+}
+
+func (m *mockAuthUsecase) StartOIDCLogin(ctx context.Context, providerName string) (*model.OIDCAuthRequest, error) {
+	args := m.Called(ctx, providerName)
+	return nil, args.Error(1)
+}
+
+func (m *mockAuthUsecase) StartOIDCLink(ctx context.Context, providerName string, userUUID string) (*model.OIDCAuthRequest, error) {
+	args := m.Called(ctx, providerName, userUUID)
+	return nil, args.Error(1)
+}
+
+func (m *mockAuthUsecase) OIDCLogin(ctx context.Context, providerName string, code string, state string, flowToken string, client model.SessionClient) (*model.User, *model.AuthTokens, error) {
+	args := m.Called(ctx, providerName, code, state, flowToken, client)
+	return nil, nil, args.Error(2)
+}
+
+func (m *mockAuthUsecase) RefreshSession(ctx context.Context, refreshToken string, client model.SessionClient) (*model.AuthTokens, error) {
+	args := m.Called(ctx, refreshToken, client)
+	return nil, args.Error(1)
+}
+
+func (m *mockAuthUsecase) Logout(ctx context.Context, refreshToken string) error {
+	args := m.Called(ctx, refreshToken)
+	return args.Error(0)
+}
+
+func (m *mockAuthUsecase) ListSessions(ctx context.Context, userUUID string) ([]*model.Session, error) {
+	args := m.Called(ctx, userUUID)
+	return nil, args.Error(1)
+}
+
+func (m *mockAuthUsecase) RevokeSession(ctx context.Context, userUUID string, sessionUUID string) error {
+	args := m.Called(ctx, userUUID, sessionUUID)
+	return args.Error(0)
+}
+
+func (m *mockAuthUsecase) ValidateSession(ctx context.Context, userUUID string, sessionUUID string) error {
+	args := m.Called(ctx, userUUID, sessionUUID)
+	return args.Error(0)
+}
+
 func TestAuthMiddleware_Authenticate(t *testing.T) {
 	// テスト用の設定
 	cfg := &config.Config{
 		JWT: config.JWTConfig{
-			Secret:     "test-secret",
-			Expiration: time.Hour,
+			Secret:       "test-secret",
+			Expiration:   time.Hour,
+			CurrentKeyID: "key-2",
+			Keys:         map[string]string{"key-1": "old-secret", "key-2": "new-secret"},
 		},
 	}
 
@@ -27,23 +138,81 @@ func TestAuthMiddleware_Authenticate(t *testing.T) {
 		tokenString, _ := token.SignedString([]byte(secret))
 		return tokenString
 	}
+	// 鍵IDを指定したトークン生成ヘルパー
+	createTokenWithKid := func(kid string, secret string, claims jwt.MapClaims) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		token.Header["kid"] = kid
+		tokenString, _ := token.SignedString([]byte(secret))
+		return tokenString
+	}
 
 	tests := []struct {
-		name       string
-		setupReq   func(req *http.Request)
-		wantStatus int
+		name            string
+		setupReq        func(req *http.Request)
+		wantStatus      int
+		wantSessionUUID string
 	}{
 		{
 			name: "正常系: 有効なトークンの場合リクエストが通ること",
 			setupReq: func(req *http.Request) {
 				token := createToken(cfg.JWT.Secret, jwt.MapClaims{
 					"user_uuid": "test-user-uuid",
+					"sid":       "session-uuid",
 					"exp":       time.Now().Add(time.Hour).Unix(),
 				})
 				req.AddCookie(&http.Cookie{Name: "jwt_token", Value: token})
 			},
 			wantStatus: http.StatusOK,
 		},
+		{
+			name: "正常系: 現在の鍵IDで署名されたトークンの場合セッションIDが設定されること",
+			setupReq: func(req *http.Request) {
+				token := createTokenWithKid("key-2", "new-secret", jwt.MapClaims{
+					"user_uuid": "test-user-uuid",
+					"sid":       "session-uuid",
+					"exp":       time.Now().Add(time.Hour).Unix(),
+				})
+				req.AddCookie(&http.Cookie{Name: "jwt_token", Value: token})
+			},
+			wantStatus:      http.StatusOK,
+			wantSessionUUID: "session-uuid",
+		},
+		{
+			name: "正常系: ローテーション前の鍵IDで署名されたトークンも検証できること",
+			setupReq: func(req *http.Request) {
+				token := createTokenWithKid("key-1", "old-secret", jwt.MapClaims{
+					"user_uuid": "test-user-uuid",
+					"sid":       "session-uuid",
+					"exp":       time.Now().Add(time.Hour).Unix(),
+				})
+				req.AddCookie(&http.Cookie{Name: "jwt_token", Value: token})
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "異常系: 不明な鍵IDのトークンの場合401エラー",
+			setupReq: func(req *http.Request) {
+				token := createTokenWithKid("unknown", "new-secret", jwt.MapClaims{
+					"user_uuid": "test-user-uuid",
+					"sid":       "session-uuid",
+					"exp":       time.Now().Add(time.Hour).Unix(),
+				})
+				req.AddCookie(&http.Cookie{Name: "jwt_token", Value: token})
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "異常系: 鍵IDと異なる鍵で署名されたトークンの場合401エラー",
+			setupReq: func(req *http.Request) {
+				token := createTokenWithKid("key-2", "old-secret", jwt.MapClaims{
+					"user_uuid": "test-user-uuid",
+					"sid":       "session-uuid",
+					"exp":       time.Now().Add(time.Hour).Unix(),
+				})
+				req.AddCookie(&http.Cookie{Name: "jwt_token", Value: token})
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
 		{
 			name: "異常系: トークンがない場合401エラー",
 			setupReq: func(req *http.Request) {
@@ -56,6 +225,7 @@ func TestAuthMiddleware_Authenticate(t *testing.T) {
 			setupReq: func(req *http.Request) {
 				token := createToken("wrong-secret", jwt.MapClaims{
 					"user_uuid": "test-user-uuid",
+					"sid":       "session-uuid",
 					"exp":       time.Now().Add(time.Hour).Unix(),
 				})
 				req.AddCookie(&http.Cookie{Name: "jwt_token", Value: token})
@@ -67,12 +237,24 @@ func TestAuthMiddleware_Authenticate(t *testing.T) {
 			setupReq: func(req *http.Request) {
 				token := createToken(cfg.JWT.Secret, jwt.MapClaims{
 					"user_uuid": "test-user-uuid",
+					"sid":       "session-uuid",
 					"exp":       time.Now().Add(-time.Hour).Unix(), // 過去の時間
 				})
 				req.AddCookie(&http.Cookie{Name: "jwt_token", Value: token})
 			},
 			wantStatus: http.StatusUnauthorized,
 		},
+		{
+			name: "異常系: セッションIDが含まれていないトークンの場合401エラー",
+			setupReq: func(req *http.Request) {
+				token := createToken(cfg.JWT.Secret, jwt.MapClaims{
+					"user_uuid": "test-user-uuid",
+					"exp":       time.Now().Add(time.Hour).Unix(),
+				})
+				req.AddCookie(&http.Cookie{Name: "jwt_token", Value: token})
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
 		{
 			name: "異常系: user_uuidが含まれていないトークンの場合401エラー",
 			setupReq: func(req *http.Request) {
@@ -93,7 +275,9 @@ func TestAuthMiddleware_Authenticate(t *testing.T) {
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 
-			m := NewAuthMiddleware(cfg)
+			authUsecase := new(mockAuthUsecase)
+			authUsecase.On("ValidateSession", mock.Anything, "test-user-uuid", "session-uuid").Return(nil).Maybe()
+			m := NewAuthMiddleware(cfg, authUsecase, nil)
 			h := m.Authenticate(func(c echo.Context) error {
 				return c.String(http.StatusOK, "success")
 			})
@@ -104,6 +288,216 @@ func TestAuthMiddleware_Authenticate(t *testing.T) {
 			}
 
 			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantSessionUUID != "" {
+				assert.Equal(t, tt.wantSessionUUID, c.Get("session_uuid"))
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_AuthenticateAfterLogout(t *testing.T) {
+	cfg := &config.Config{JWT: config.JWTConfig{Secret: "test-secret", Expiration: 15 * time.Minute}}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_uuid": "test-user-uuid",
+		"sid":       "session-uuid",
+		"exp":       time.Now().Add(15 * time.Minute).Unix(),
+	})
+	tokenString, _ := token.SignedString([]byte(cfg.JWT.Secret))
+
+	authUsecase := new(mockAuthUsecase)
+	// ログアウト前はセッションが有効で、ログアウト後は失効している
+	authUsecase.On("ValidateSession", mock.Anything, "test-user-uuid", "session-uuid").Return(nil).Once()
+	authUsecase.On("ValidateSession", mock.Anything, "test-user-uuid", "session-uuid").Return(fmt.Errorf("%w: session-uuid", model.ErrSessionRevoked)).Once()
+
+	m := NewAuthMiddleware(cfg, authUsecase, nil)
+	h := m.Authenticate(func(c echo.Context) error {
+		return c.String(http.StatusOK, "success")
+	})
+	request := func() *httptest.ResponseRecorder {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.AddCookie(&http.Cookie{Name: "jwt_token", Value: tokenString})
+		rec := httptest.NewRecorder()
+		assert.NoError(t, h(e.NewContext(req, rec)))
+		return rec
+	}
+
+	assert.Equal(t, http.StatusOK, request().Code)
+	// アクセストークンの有効期限内でも、ログアウトしたセッションのトークンは拒否すること
+	rec := request()
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "ログアウト済みのセッションです")
+	authUsecase.AssertExpectations(t)
+}
+
+func TestAuthMiddleware_AuthenticateAPIToken(t *testing.T) {
+	cfg := &config.Config{JWT: config.JWTConfig{Secret: "test-secret"}}
+	token := &model.APIToken{UUID: "token-uuid", UserUUID: "test-user-uuid", Scope: model.APITokenScopeRead, ProjectUUID: "project-uuid"}
+
+	tests := []struct {
+		name       string
+		method     string
+		authHeader string
+		setupMock  func(m *mockAPITokenUsecase)
+		wantStatus int
+		wantEvent  string
+	}{
+		{
+			name:       "正常系: 有効なAPIトークンの場合リクエストが通り使用が記録されること",
+			method:     http.MethodGet,
+			authHeader: "Bearer cbt_valid",
+			setupMock: func(m *mockAPITokenUsecase) {
+				m.On("Authenticate", mock.Anything, "cbt_valid").Return(token, nil)
+				m.On("Authorize", mock.Anything, token, model.APITokenAccess{Method: http.MethodGet, ProjectUUID: "project-uuid"}).Return(nil)
+			},
+			wantStatus: http.StatusOK,
+			wantEvent:  model.APITokenEventUsed,
+		},
+		{
+			name:       "異常系: スコープ外のリクエストの場合403エラーになり拒否が記録されること",
+			method:     http.MethodPut,
+			authHeader: "Bearer cbt_valid",
+			setupMock: func(m *mockAPITokenUsecase) {
+				m.On("Authenticate", mock.Anything, "cbt_valid").Return(token, nil)
+				m.On("Authorize", mock.Anything, token, mock.Anything).Return(fmt.Errorf("%w: read only", model.ErrAPITokenScopeDenied))
+			},
+			wantStatus: http.StatusForbidden,
+			wantEvent:  model.APITokenEventDenied,
+		},
+		{
+			name:       "異常系: 無効なAPIトークンの場合401エラー",
+			method:     http.MethodGet,
+			authHeader: "Bearer cbt_invalid",
+			setupMock: func(m *mockAPITokenUsecase) {
+				m.On("Authenticate", mock.Anything, "cbt_invalid").Return(nil, model.ErrInvalidAPIToken)
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(tt.method, "/api/projects/project-uuid/tree", nil)
+			req.Header.Set(echo.HeaderAuthorization, tt.authHeader)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("project_uuid")
+			c.SetParamValues("project-uuid")
+
+			mockUsecase := new(mockAPITokenUsecase)
+			tt.setupMock(mockUsecase)
+			if tt.wantEvent != "" {
+				mockUsecase.On("RecordUsage", mock.Anything, mock.MatchedBy(func(log *model.APITokenAuditLog) bool {
+					return log.Event == tt.wantEvent && log.APITokenUUID == "token-uuid" && log.StatusCode == tt.wantStatus && log.Path == "/api/projects/project-uuid/tree"
+				})).Return(nil)
+			}
+
+			m := NewAuthMiddleware(cfg, nil, mockUsecase)
+			h := m.Authenticate(func(c echo.Context) error {
+				assert.Equal(t, "test-user-uuid", c.Get("user_uuid"))
+				return c.String(http.StatusOK, "success")
+			})
+			assert.NoError(t, h(c))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthMiddleware_RequireWriteScope(t *testing.T) {
+	cfg := &config.Config{JWT: config.JWTConfig{Secret: "test-secret"}}
+	readToken := &model.APIToken{UUID: "token-uuid", UserUUID: "test-user-uuid", Scope: model.APITokenScopeRead}
+	writeToken := &model.APIToken{UUID: "token-uuid", UserUUID: "test-user-uuid", Scope: model.APITokenScopeWrite}
+
+	tests := []struct {
+		name       string
+		path       string
+		token      *model.APIToken
+		wantStatus int
+		wantEvent  string
+	}{
+		{
+			name:       "異常系: 読み取り専用のトークンでは回答の生成ができず403エラーになること",
+			path:       "/api/chats/chat-uuid/messages/stream",
+			token:      readToken,
+			wantStatus: http.StatusForbidden,
+			wantEvent:  model.APITokenEventDenied,
+		},
+		{
+			name:       "異常系: 読み取り専用のトークンでは最初の回答の生成ができず403エラーになること",
+			path:       "/api/chats/chat-uuid/stream",
+			token:      readToken,
+			wantStatus: http.StatusForbidden,
+			wantEvent:  model.APITokenEventDenied,
+		},
+		{
+			name:       "正常系: 書き込みのトークンでは回答を生成できること",
+			path:       "/api/chats/chat-uuid/messages/stream",
+			token:      writeToken,
+			wantStatus: http.StatusOK,
+			wantEvent:  model.APITokenEventUsed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(mockAPITokenUsecase)
+			mockUsecase.On("Authenticate", mock.Anything, "cbt_token").Return(tt.token, nil)
+			mockUsecase.On("Authorize", mock.Anything, tt.token, mock.Anything).Return(nil)
+			mockUsecase.On("RecordUsage", mock.Anything, mock.MatchedBy(func(log *model.APITokenAuditLog) bool {
+				return log.Event == tt.wantEvent && log.StatusCode == tt.wantStatus
+			})).Return(nil)
+
+			// ルーターと同じく、グループで認証し、回答を生成するルートに書き込みのスコープを要求する
+			m := NewAuthMiddleware(cfg, nil, mockUsecase)
+			e := echo.New()
+			chats := e.Group("/api/chats")
+			chats.Use(m.Authenticate)
+			generate := func(c echo.Context) error {
+				return c.String(http.StatusOK, "generated")
+			}
+			chats.GET("/:chat_uuid/messages/stream", generate, m.RequireWriteScope)
+			chats.GET("/:chat_uuid/stream", generate, m.RequireWriteScope)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set(echo.HeaderAuthorization, "Bearer cbt_token")
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthMiddleware_RequireSession(t *testing.T) {
+	tests := []struct {
+		name         string
+		apiTokenUUID string
+		wantStatus   int
+	}{
+		{name: "正常系: クッキーで認証された場合リクエストが通ること", wantStatus: http.StatusOK},
+		{name: "異常系: APIトークンで認証された場合403エラー", apiTokenUUID: "token-uuid", wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/users/me/tokens", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			if tt.apiTokenUUID != "" {
+				c.Set("api_token_uuid", tt.apiTokenUUID)
+			}
+
+			m := NewAuthMiddleware(&config.Config{}, nil, nil)
+			h := m.RequireSession(func(c echo.Context) error {
+				return c.String(http.StatusOK, "success")
+			})
+			assert.NoError(t, h(c))
+			assert.Equal(t, tt.wantStatus, rec.Code)
 		})
 	}
 }
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"backend/internal/domain/model"
+	"backend/internal/domain/repository"
+	"backend/internal/i18n"
+	"log/slog"
+
+	"github.com/labstack/echo/v4"
+)
+
+type LanguageMiddleware struct {
+	userRepo        repository.UserRepository
+	defaultLanguage string
+}
+
+func NewLanguageMiddleware(userRepo repository.UserRepository, defaultLanguage string) *LanguageMiddleware {
+	if defaultLanguage == "" {
+		defaultLanguage = model.LanguageJapanese
+	}
+	return &LanguageMiddleware{
+		userRepo:        userRepo,
+		defaultLanguage: defaultLanguage,
+	}
+}
+
+// Accept-Language ヘッダーからレスポンスの言語を決定する処理
+// 全てのリクエストに適用し、認証前のエラーメッセージにも使用する
+func (m *LanguageMiddleware) Detect(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		language := i18n.ParseAcceptLanguage(c.Request().Header.Get("Accept-Language"))
+		if language == "" {
+			language = m.defaultLanguage
+		}
+		c.Set("language", language)
+		return next(c)
+	}
+}
+
+// 認証済みユーザーの優先言語でレスポンスの言語を上書きする処理
+// Authenticate の後に適用する
+func (m *LanguageMiddleware) ApplyUserPreference(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userUUID, ok := c.Get("user_uuid").(string)
+		if !ok {
+			return next(c)
+		}
+
+		user, err := m.userRepo.FindByUUID(c.Request().Context(), userUUID)
+		if err != nil {
+			// 言語の決定に失敗してもリクエスト自体は継続する
+			slog.WarnContext(c.Request().Context(), "ユーザーの優先言語の取得に失敗", "user_uuid", userUUID, "error", err)
+			return next(c)
+		}
+		if user.Language != "" {
+			c.Set("language", user.Language)
+		}
+		return next(c)
+	}
+}
+
+// コンテキストに設定されたレスポンスの言語でメッセージを返す処理
+func localize(c echo.Context, key i18n.Key) string {
+	language, _ := c.Get("language").(string)
+	return i18n.Message(language, key)
+}
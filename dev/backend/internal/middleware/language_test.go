@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"backend/internal/domain/model"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// モックの定義
+type mockUserRepository struct {
+	mock.Mock
+}
+
+func (m *mockUserRepository) Create(ctx context.Context, user *model.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *mockUserRepository) FindByUUID(ctx context.Context, uuid string) (*model.User, error) {
+	args := m.Called(ctx, uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (m *mockUserRepository) FindByProviderSubject(ctx context.Context, provider string, subject string) (*model.User, error) {
+	args := m.Called(ctx, provider, subject)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (m *mockUserRepository) UpdateProfile(ctx context.Context, uuid string, name string, avatarURL string) error {
+	args := m.Called(ctx, uuid, name, avatarURL)
+	return args.Error(0)
+}
+
+func (m *mockUserRepository) UpdateLanguage(ctx context.Context, uuid string, language string) error {
+	args := m.Called(ctx, uuid, language)
+	return args.Error(0)
+}
+
+func (m *mockUserRepository) LinkIdentity(ctx context.Context, uuid string, provider string, subject string, name string, avatarURL string) error {
+	args := m.Called(ctx, uuid, provider, subject, name, avatarURL)
+	return args.Error(0)
+}
+
+func (m *mockUserRepository) Delete(ctx context.Context, uuid string) error {
+	args := m.Called(ctx, uuid)
+	return args.Error(0)
+}
+
+func TestLanguageMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		userUUID       string
+		setupMock      func(m *mockUserRepository)
+		want           string
+	}{
+		{
+			name:      "正常系: 指定がない場合は既定の言語になること",
+			setupMock: func(m *mockUserRepository) {},
+			want:      "ja",
+		},
+		{
+			name:           "正常系: Accept-Language ヘッダーの言語が使われること",
+			acceptLanguage: "en-US,en;q=0.9",
+			setupMock:      func(m *mockUserRepository) {},
+			want:           "en",
+		},
+		{
+			name:           "正常系: ユーザーの優先言語がヘッダーより優先されること",
+			acceptLanguage: "ja",
+			userUUID:       "user-uuid",
+			setupMock: func(m *mockUserRepository) {
+				m.On("FindByUUID", mock.Anything, "user-uuid").Return(&model.User{UUID: "user-uuid", Language: "en"}, nil)
+			},
+			want: "en",
+		},
+		{
+			name:           "正常系: ユーザーの優先言語が未設定の場合はヘッダーの言語が使われること",
+			acceptLanguage: "en",
+			userUUID:       "user-uuid",
+			setupMock: func(m *mockUserRepository) {
+				m.On("FindByUUID", mock.Anything, "user-uuid").Return(&model.User{UUID: "user-uuid"}, nil)
+			},
+			want: "en",
+		},
+		{
+			name:           "異常系: ユーザー取得に失敗してもリクエストは継続すること",
+			acceptLanguage: "en",
+			userUUID:       "user-uuid",
+			setupMock: func(m *mockUserRepository) {
+				m.On("FindByUUID", mock.Anything, "user-uuid").Return(nil, errors.New("db error"))
+			},
+			want: "en",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			mockRepo := new(mockUserRepository)
+			tt.setupMock(mockRepo)
+
+			m := NewLanguageMiddleware(mockRepo, "")
+			var got string
+			h := m.Detect(func(c echo.Context) error {
+				if tt.userUUID != "" {
+					c.Set("user_uuid", tt.userUUID)
+				}
+				return m.ApplyUserPreference(func(c echo.Context) error {
+					got, _ = c.Get("language").(string)
+					return c.String(http.StatusOK, "success")
+				})(c)
+			})
+
+			assert.NoError(t, h(c))
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.want, got)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	domainModel "backend/internal/domain/model"
+	"backend/internal/domain/usecase"
+	"backend/internal/handler/model"
+	"backend/internal/i18n"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type ProjectAccessMiddleware struct {
+	projectMemberUsecase usecase.ProjectMemberUsecase
+}
+
+// ProjectAccessMiddleware の新しいインスタンスを作成する処理
+func NewProjectAccessMiddleware(projectMemberUsecase usecase.ProjectMemberUsecase) *ProjectAccessMiddleware {
+	return &ProjectAccessMiddleware{
+		projectMemberUsecase: projectMemberUsecase,
+	}
+}
+
+// パスの project_uuid または chat_uuid からプロジェクトを特定し、
+// ログイン中のユーザーが required 以上のロールを持つ場合のみ通す処理
+func (m *ProjectAccessMiddleware) RequireRole(required string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+			userUUID, ok := c.Get("user_uuid").(string)
+			if !ok {
+				slog.WarnContext(ctx, "ユーザーUUIDの取得に失敗")
+				return c.JSON(http.StatusUnauthorized, model.Response{
+					Status:  "error",
+					Message: localize(c, i18n.KeyUserUUIDNotFound),
+				})
+			}
+
+			access := domainModel.ProjectAccess{
+				ProjectUUID: c.Param("project_uuid"),
+				ChatUUID:    c.Param("chat_uuid"),
+			}
+			role, err := m.projectMemberUsecase.ResolveRole(ctx, userUUID, access)
+			if err != nil {
+				if errors.Is(err, domainModel.ErrProjectNotFound) {
+					// 共有されていないプロジェクトの存在を知られないように 404 を返す
+					slog.WarnContext(ctx, "アクセスできないプロジェクトです", "user_uuid", userUUID, "error", err)
+					return c.JSON(http.StatusNotFound, model.Response{
+						Status:  "error",
+						Message: localize(c, i18n.KeyProjectNotFound),
+					})
+				}
+				slog.ErrorContext(ctx, "プロジェクトのロールの取得に失敗", "error", err)
+				return c.JSON(http.StatusInternalServerError, model.Response{
+					Status:  "error",
+					Message: err.Error(),
+				})
+			}
+
+			if !domainModel.HasProjectRole(role, required) {
+				slog.WarnContext(ctx, "プロジェクトのロールが不足しています", "user_uuid", userUUID, "role", role, "required", required)
+				return c.JSON(http.StatusForbidden, model.Response{
+					Status:  "error",
+					Message: localize(c, i18n.KeyProjectRoleDenied),
+				})
+			}
+
+			c.Set("project_role", role)
+			return next(c)
+		}
+	}
+}
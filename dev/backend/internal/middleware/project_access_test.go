@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"backend/internal/domain/model"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockProjectMemberUsecase struct {
+	mock.Mock
+}
+
+func (m *mockProjectMemberUsecase) ResolveRole(ctx context.Context, userUUID string, access model.ProjectAccess) (string, error) {
+	args := m.Called(ctx, userUUID, access)
+	return args.String(0), args.Error(1)
+}
+
+func (m *mockProjectMemberUsecase) ListMembers(ctx context.Context, projectUUID string) ([]*model.ProjectMember, error) {
+	args := m.Called(ctx, projectUUID)
+	return nil, args.Error(1)
+}
+
+func (m *mockProjectMemberUsecase) InviteMember(ctx context.Context, inviterUUID string, projectUUID string, params model.InviteProjectMemberParams) (*model.ProjectMember, error) {
+	args := m.Called(ctx, inviterUUID, projectUUID, params)
+	return nil, args.Error(1)
+}
+
+func (m *mockProjectMemberUsecase) UpdateMemberRole(ctx context.Context, projectUUID string, memberUUID string, role string) (*model.ProjectMember, error) {
+	args := m.Called(ctx, projectUUID, memberUUID, role)
+	return nil, args.Error(1)
+}
+
+func (m *mockProjectMemberUsecase) RemoveMember(ctx context.Context, userUUID string, projectUUID string, memberUUID string) error {
+	args := m.Called(ctx, userUUID, projectUUID, memberUUID)
+	return args.Error(0)
+}
+
+func (m *mockProjectMemberUsecase) ListInvitations(ctx context.Context, userUUID string) ([]*model.SharedProject, error) {
+	args := m.Called(ctx, userUUID)
+	return nil, args.Error(1)
+}
+
+func (m *mockProjectMemberUsecase) AcceptInvitation(ctx context.Context, userUUID string, projectUUID string) error {
+	args := m.Called(ctx, userUUID, projectUUID)
+	return args.Error(0)
+}
+
+func (m *mockProjectMemberUsecase) DeclineInvitation(ctx context.Context, userUUID string, projectUUID string) error {
+	args := m.Called(ctx, userUUID, projectUUID)
+	return args.Error(0)
+}
+
+func TestProjectAccessMiddleware_RequireRole(t *testing.T) {
+	tests := []struct {
+		name       string
+		required   string
+		setupMock  func(m *mockProjectMemberUsecase)
+		wantStatus int
+	}{
+		{
+			name:     "正常系: viewer は閲覧できること",
+			required: model.ProjectRoleViewer,
+			setupMock: func(m *mockProjectMemberUsecase) {
+				m.On("ResolveRole", mock.Anything, "user-1", model.ProjectAccess{ChatUUID: "chat-1"}).Return(model.ProjectRoleViewer, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:     "正常系: owner は編集できること",
+			required: model.ProjectRoleEditor,
+			setupMock: func(m *mockProjectMemberUsecase) {
+				m.On("ResolveRole", mock.Anything, "user-1", model.ProjectAccess{ChatUUID: "chat-1"}).Return(model.ProjectRoleOwner, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:     "異常系: viewer が編集しようとした場合403エラー",
+			required: model.ProjectRoleEditor,
+			setupMock: func(m *mockProjectMemberUsecase) {
+				m.On("ResolveRole", mock.Anything, "user-1", model.ProjectAccess{ChatUUID: "chat-1"}).Return(model.ProjectRoleViewer, nil)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:     "異常系: 共有されていないプロジェクトの場合404エラー",
+			required: model.ProjectRoleViewer,
+			setupMock: func(m *mockProjectMemberUsecase) {
+				m.On("ResolveRole", mock.Anything, "user-1", model.ProjectAccess{ChatUUID: "chat-1"}).Return("", fmt.Errorf("%w: project-1", model.ErrProjectNotFound))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:     "異常系: ロールの取得に失敗した場合500エラー",
+			required: model.ProjectRoleViewer,
+			setupMock: func(m *mockProjectMemberUsecase) {
+				m.On("ResolveRole", mock.Anything, "user-1", model.ProjectAccess{ChatUUID: "chat-1"}).Return("", errors.New("db error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/chats/chat-1/messages", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user_uuid", "user-1")
+			c.SetParamNames("chat_uuid")
+			c.SetParamValues("chat-1")
+
+			mockUsecase := new(mockProjectMemberUsecase)
+			tt.setupMock(mockUsecase)
+
+			m := NewProjectAccessMiddleware(mockUsecase)
+			h := m.RequireRole(tt.required)(func(c echo.Context) error {
+				return c.String(http.StatusOK, "success")
+			})
+			assert.NoError(t, h(c))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"backend/internal/domain/model"
+	"backend/internal/domain/repository"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type apiTokenORM struct {
+	UUID        string     `gorm:"primaryKey;column:uuid;size:255"`
+	UserUUID    string     `gorm:"column:user_uuid;size:255"`
+	Name        string     `gorm:"column:name;size:255"`
+	TokenHash   string     `gorm:"column:token_hash;size:64;uniqueIndex"`
+	TokenPrefix string     `gorm:"column:token_prefix;size:16"`
+	Scope       string     `gorm:"column:scope;size:50;default:read"`
+	ProjectUUID *string    `gorm:"column:project_uuid;size:255"`
+	ExpiresAt   *time.Time `gorm:"column:expires_at"`
+	LastUsedAt  *time.Time `gorm:"column:last_used_at"`
+	RevokedAt   *time.Time `gorm:"column:revoked_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at"`
+}
+
+func (apiTokenORM) TableName() string {
+	return "api_tokens"
+}
+
+func (o *apiTokenORM) toDomain() *model.APIToken {
+	token := &model.APIToken{
+		UUID:       o.UUID,
+		UserUUID:   o.UserUUID,
+		Name:       o.Name,
+		Prefix:     o.TokenPrefix,
+		Scope:      o.Scope,
+		ExpiresAt:  o.ExpiresAt,
+		LastUsedAt: o.LastUsedAt,
+		RevokedAt:  o.RevokedAt,
+		CreatedAt:  o.CreatedAt,
+	}
+	if o.ProjectUUID != nil {
+		token.ProjectUUID = *o.ProjectUUID
+	}
+	return token
+}
+
+type apiTokenAuditLogORM struct {
+	UUID         string    `gorm:"primaryKey;column:uuid;size:255"`
+	APITokenUUID string    `gorm:"column:api_token_uuid;size:255"`
+	UserUUID     string    `gorm:"column:user_uuid;size:255"`
+	Event        string    `gorm:"column:event;size:50"`
+	Method       *string   `gorm:"column:method;size:10"`
+	Path         *string   `gorm:"column:path;size:2048"`
+	StatusCode   *int      `gorm:"column:status_code"`
+	IPAddress    *string   `gorm:"column:ip_address;size:45"`
+	CreatedAt    time.Time `gorm:"column:created_at"`
+}
+
+func (apiTokenAuditLogORM) TableName() string {
+	return "api_token_audit_logs"
+}
+
+type apiTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewAPITokenRepository(db *gorm.DB) repository.APITokenRepository {
+	return &apiTokenRepository{db: db}
+}
+
+// APIトークンを作成する
+func (r *apiTokenRepository) Create(ctx context.Context, token *model.APIToken, tokenHash string) error {
+	slog.DebugContext(ctx, "APIトークン作成処理を開始", "api_token_uuid", token.UUID, "user_uuid", token.UserUUID)
+	orm := apiTokenORM{
+		UUID:        token.UUID,
+		UserUUID:    token.UserUUID,
+		Name:        token.Name,
+		TokenHash:   tokenHash,
+		TokenPrefix: token.Prefix,
+		Scope:       token.Scope,
+		ExpiresAt:   token.ExpiresAt,
+		CreatedAt:   token.CreatedAt,
+	}
+	if token.ProjectUUID != "" {
+		orm.ProjectUUID = &token.ProjectUUID
+	}
+	return getDB(ctx, r.db).WithContext(ctx).Create(&orm).Error
+}
+
+// トークンのハッシュに一致するAPIトークンを取得する
+func (r *apiTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*model.APIToken, error) {
+	slog.DebugContext(ctx, "APIトークン取得処理を開始")
+	var orm apiTokenORM
+	err := getDB(ctx, r.db).WithContext(ctx).Where("token_hash = ?", tokenHash).First(&orm).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return orm.toDomain(), nil
+}
+
+// 指定されたユーザーの失効していないAPIトークンを取得する
+func (r *apiTokenRepository) FindActiveByUserUUID(ctx context.Context, userUUID string) ([]*model.APIToken, error) {
+	slog.DebugContext(ctx, "APIトークン一覧取得処理を開始", "user_uuid", userUUID)
+	var orms []apiTokenORM
+	err := getDB(ctx, r.db).WithContext(ctx).
+		Where("user_uuid = ? AND revoked_at IS NULL", userUUID).
+		Order("created_at desc").
+		Find(&orms).Error
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make([]*model.APIToken, 0, len(orms))
+	for i := range orms {
+		tokens = append(tokens, orms[i].toDomain())
+	}
+	return tokens, nil
+}
+
+// 指定されたユーザーのAPIトークンを失効させる
+func (r *apiTokenRepository) Revoke(ctx context.Context, userUUID string, tokenUUID string, revokedAt time.Time) (bool, error) {
+	slog.DebugContext(ctx, "APIトークン失効処理を開始", "user_uuid", userUUID, "api_token_uuid", tokenUUID)
+	result := getDB(ctx, r.db).WithContext(ctx).Model(&apiTokenORM{}).
+		Where("uuid = ? AND user_uuid = ? AND revoked_at IS NULL", tokenUUID, userUUID).
+		Update("revoked_at", revokedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// APIトークンの最終使用日時を更新する
+func (r *apiTokenRepository) UpdateLastUsedAt(ctx context.Context, tokenUUID string, usedAt time.Time) error {
+	slog.DebugContext(ctx, "APIトークン最終使用日時更新処理を開始", "api_token_uuid", tokenUUID)
+	return getDB(ctx, r.db).WithContext(ctx).Model(&apiTokenORM{}).
+		Where("uuid = ?", tokenUUID).
+		Update("last_used_at", usedAt).Error
+}
+
+// 監査ログを記録する
+func (r *apiTokenRepository) CreateAuditLog(ctx context.Context, log *model.APITokenAuditLog) error {
+	slog.DebugContext(ctx, "APIトークン監査ログ記録処理を開始", "api_token_uuid", log.APITokenUUID, "event", log.Event)
+	orm := apiTokenAuditLogORM{
+		UUID:         log.UUID,
+		APITokenUUID: log.APITokenUUID,
+		UserUUID:     log.UserUUID,
+		Event:        log.Event,
+		CreatedAt:    log.CreatedAt,
+	}
+	if log.Method != "" {
+		orm.Method = &log.Method
+	}
+	if log.Path != "" {
+		orm.Path = &log.Path
+	}
+	if log.StatusCode != 0 {
+		orm.StatusCode = &log.StatusCode
+	}
+	if log.IPAddress != "" {
+		orm.IPAddress = &log.IPAddress
+	}
+	return getDB(ctx, r.db).WithContext(ctx).Create(&orm).Error
+}
+
+// 指定されたユーザーのAPIトークンの監査ログを取得する
+func (r *apiTokenRepository) FindAuditLogs(ctx context.Context, userUUID string, tokenUUID string, limit int) ([]*model.APITokenAuditLog, error) {
+	slog.DebugContext(ctx, "APIトークン監査ログ取得処理を開始", "user_uuid", userUUID, "api_token_uuid", tokenUUID)
+	var orms []apiTokenAuditLogORM
+	err := getDB(ctx, r.db).WithContext(ctx).
+		Where("api_token_uuid = ? AND user_uuid = ?", tokenUUID, userUUID).
+		Order("created_at desc").
+		Limit(limit).
+		Find(&orms).Error
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make([]*model.APITokenAuditLog, 0, len(orms))
+	for _, orm := range orms {
+		log := &model.APITokenAuditLog{
+			UUID:         orm.UUID,
+			APITokenUUID: orm.APITokenUUID,
+			UserUUID:     orm.UserUUID,
+			Event:        orm.Event,
+			CreatedAt:    orm.CreatedAt,
+		}
+		if orm.Method != nil {
+			log.Method = *orm.Method
+		}
+		if orm.Path != nil {
+			log.Path = *orm.Path
+		}
+		if orm.StatusCode != nil {
+			log.StatusCode = *orm.StatusCode
+		}
+		if orm.IPAddress != nil {
+			log.IPAddress = *orm.IPAddress
+		}
+		logs = append(logs, log)
+	}
+	return logs, nil
+}
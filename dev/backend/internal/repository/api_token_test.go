@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"backend/internal/domain/model"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// テスト用のAPIトークンリポジトリを作成する処理
+func setupAPITokenRepository(t *testing.T) (*gorm.DB, *apiTokenRepository) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	if err := db.AutoMigrate(&apiTokenORM{}, &apiTokenAuditLogORM{}); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+	return db, &apiTokenRepository{db: db}
+}
+
+func TestAPITokenRepository_CreateAndFindByHash(t *testing.T) {
+	_, r := setupAPITokenRepository(t)
+	now := time.Now().Truncate(time.Second)
+	expiresAt := now.Add(time.Hour)
+
+	err := r.Create(context.Background(), &model.APIToken{
+		UUID:        "token-1",
+		UserUUID:    "user-1",
+		Name:        "CLI",
+		Prefix:      "cbt_abcd",
+		Scope:       model.APITokenScopeRead,
+		ProjectUUID: "project-1",
+		ExpiresAt:   &expiresAt,
+		CreatedAt:   now,
+	}, "hash-1")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		hash     string
+		wantUUID string
+	}{
+		{name: "正常系: ハッシュに一致するトークンが取得できること", hash: "hash-1", wantUUID: "token-1"},
+		{name: "正常系: 一致しない場合は nil が返ること", hash: "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.FindByHash(context.Background(), tt.hash)
+			assert.NoError(t, err)
+			if tt.wantUUID == "" {
+				assert.Nil(t, got)
+				return
+			}
+			assert.Equal(t, tt.wantUUID, got.UUID)
+			assert.Equal(t, "project-1", got.ProjectUUID)
+			assert.Equal(t, model.APITokenScopeRead, got.Scope)
+			assert.Nil(t, got.LastUsedAt)
+		})
+	}
+}
+
+func TestAPITokenRepository_RevokeAndFindActive(t *testing.T) {
+	db, r := setupAPITokenRepository(t)
+	now := time.Now()
+	db.Create(&apiTokenORM{UUID: "token-1", UserUUID: "user-1", Name: "old", TokenHash: "hash-1", Scope: "read", CreatedAt: now.Add(-time.Hour)})
+	db.Create(&apiTokenORM{UUID: "token-2", UserUUID: "user-1", Name: "new", TokenHash: "hash-2", Scope: "write", CreatedAt: now})
+	db.Create(&apiTokenORM{UUID: "token-3", UserUUID: "user-2", Name: "other", TokenHash: "hash-3", Scope: "read", CreatedAt: now})
+
+	tests := []struct {
+		name      string
+		userUUID  string
+		tokenUUID string
+		want      bool
+	}{
+		{name: "異常系: 他のユーザーのトークンは失効できないこと", userUUID: "user-2", tokenUUID: "token-1", want: false},
+		{name: "正常系: 自分のトークンを失効できること", userUUID: "user-1", tokenUUID: "token-1", want: true},
+		{name: "異常系: 失効済みのトークンは再度失効できないこと", userUUID: "user-1", tokenUUID: "token-1", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.Revoke(context.Background(), tt.userUUID, tt.tokenUUID, now)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	active, err := r.FindActiveByUserUUID(context.Background(), "user-1")
+	assert.NoError(t, err)
+	assert.Len(t, active, 1)
+	assert.Equal(t, "token-2", active[0].UUID)
+
+	assert.NoError(t, r.UpdateLastUsedAt(context.Background(), "token-2", now))
+	got, err := r.FindByHash(context.Background(), "hash-2")
+	assert.NoError(t, err)
+	assert.NotNil(t, got.LastUsedAt)
+}
+
+func TestAPITokenRepository_AuditLogs(t *testing.T) {
+	_, r := setupAPITokenRepository(t)
+	now := time.Now()
+
+	logs := []*model.APITokenAuditLog{
+		{UUID: "log-1", APITokenUUID: "token-1", UserUUID: "user-1", Event: model.APITokenEventCreated, CreatedAt: now.Add(-time.Minute)},
+		{UUID: "log-2", APITokenUUID: "token-1", UserUUID: "user-1", Event: model.APITokenEventUsed, Method: "GET", Path: "/api/projects", StatusCode: 200, IPAddress: "127.0.0.1", CreatedAt: now},
+		{UUID: "log-3", APITokenUUID: "token-2", UserUUID: "user-1", Event: model.APITokenEventCreated, CreatedAt: now},
+	}
+	for _, log := range logs {
+		assert.NoError(t, r.CreateAuditLog(context.Background(), log))
+	}
+
+	got, err := r.FindAuditLogs(context.Background(), "user-1", "token-1", 10)
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+	assert.Equal(t, "log-2", got[0].UUID)
+	assert.Equal(t, "GET", got[0].Method)
+	assert.Equal(t, 200, got[0].StatusCode)
+	assert.Equal(t, "", got[1].Method)
+
+	other, err := r.FindAuditLogs(context.Background(), "user-2", "token-1", 10)
+	assert.NoError(t, err)
+	assert.Empty(t, other)
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"backend/internal/domain/model"
+	"backend/internal/domain/repository"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type branchComparisonORM struct {
+	UUID              string    `gorm:"primaryKey;column:uuid;size:255"`
+	ProjectUUID       string    `gorm:"column:project_uuid;size:255"`
+	ChatUUID          string    `gorm:"column:chat_uuid;size:255"`
+	OtherChatUUID     string    `gorm:"column:other_chat_uuid;size:255"`
+	AnchorChatUUID    string    `gorm:"column:anchor_chat_uuid;size:255"`
+	AnchorMessageUUID string    `gorm:"column:anchor_message_uuid;size:255"`
+	Positions         string    `gorm:"column:positions;type:text"`
+	Agreements        string    `gorm:"column:agreements;type:text"`
+	Disagreements     string    `gorm:"column:disagreements;type:text"`
+	Recommendation    string    `gorm:"column:recommendation;type:text"`
+	PromptVersion     *string   `gorm:"column:prompt_version;size:255"`
+	UserUUID          *string   `gorm:"column:user_uuid;size:255"`
+	CreatedAt         time.Time `gorm:"column:created_at"`
+}
+
+func (branchComparisonORM) TableName() string {
+	return "branch_comparisons"
+}
+
+func (o *branchComparisonORM) toDomain() (*model.BranchComparison, error) {
+	comparison := &model.BranchComparison{
+		UUID:              o.UUID,
+		ProjectUUID:       o.ProjectUUID,
+		ChatUUID:          o.ChatUUID,
+		OtherChatUUID:     o.OtherChatUUID,
+		AnchorChatUUID:    &o.AnchorChatUUID,
+		AnchorMessageUUID: &o.AnchorMessageUUID,
+		Recommendation:    o.Recommendation,
+		UserUUID:          o.UserUUID,
+		CreatedAt:         o.CreatedAt,
+	}
+	if o.PromptVersion != nil {
+		comparison.PromptVersion = *o.PromptVersion
+	}
+	if err := json.Unmarshal([]byte(o.Positions), &comparison.Positions); err != nil {
+		return nil, fmt.Errorf("立場のデコードに失敗 (uuid: %s): %w", o.UUID, err)
+	}
+	if err := json.Unmarshal([]byte(o.Agreements), &comparison.Agreements); err != nil {
+		return nil, fmt.Errorf("一致点のデコードに失敗 (uuid: %s): %w", o.UUID, err)
+	}
+	if err := json.Unmarshal([]byte(o.Disagreements), &comparison.Disagreements); err != nil {
+		return nil, fmt.Errorf("相違点のデコードに失敗 (uuid: %s): %w", o.UUID, err)
+	}
+	return comparison, nil
+}
+
+type branchComparisonRepository struct {
+	db *gorm.DB
+}
+
+func NewBranchComparisonRepository(db *gorm.DB) repository.BranchComparisonRepository {
+	return &branchComparisonRepository{db: db}
+}
+
+// ブランチの比較結果を保存する
+// 共通の祖先メッセージに紐づくメモとして保存するため、祖先メッセージは必須
+func (r *branchComparisonRepository) Create(ctx context.Context, comparison *model.BranchComparison) error {
+	slog.DebugContext(ctx, "ブランチ比較結果の保存処理を開始", "chat_uuid", comparison.ChatUUID, "other_chat_uuid", comparison.OtherChatUUID)
+	if comparison.AnchorChatUUID == nil || comparison.AnchorMessageUUID == nil {
+		return fmt.Errorf("共通の祖先メッセージが指定されていません")
+	}
+	positions, err := json.Marshal(comparison.Positions)
+	if err != nil {
+		return fmt.Errorf("立場のエンコードに失敗: %w", err)
+	}
+	agreements, err := json.Marshal(comparison.Agreements)
+	if err != nil {
+		return fmt.Errorf("一致点のエンコードに失敗: %w", err)
+	}
+	disagreements, err := json.Marshal(comparison.Disagreements)
+	if err != nil {
+		return fmt.Errorf("相違点のエンコードに失敗: %w", err)
+	}
+	orm := branchComparisonORM{
+		UUID:              comparison.UUID,
+		ProjectUUID:       comparison.ProjectUUID,
+		ChatUUID:          comparison.ChatUUID,
+		OtherChatUUID:     comparison.OtherChatUUID,
+		AnchorChatUUID:    *comparison.AnchorChatUUID,
+		AnchorMessageUUID: *comparison.AnchorMessageUUID,
+		Positions:         string(positions),
+		Agreements:        string(agreements),
+		Disagreements:     string(disagreements),
+		Recommendation:    comparison.Recommendation,
+		UserUUID:          comparison.UserUUID,
+		CreatedAt:         comparison.CreatedAt,
+	}
+	if comparison.PromptVersion != "" {
+		orm.PromptVersion = &comparison.PromptVersion
+	}
+	return getDB(ctx, r.db).WithContext(ctx).Create(&orm).Error
+}
+
+// チャットのメッセージに紐づく比較結果を新しい順に取得する
+func (r *branchComparisonRepository) FindByAnchorChatUUID(ctx context.Context, anchorChatUUID string) ([]*model.BranchComparison, error) {
+	slog.DebugContext(ctx, "ブランチ比較結果の取得処理を開始", "anchor_chat_uuid", anchorChatUUID)
+	var orms []branchComparisonORM
+	if err := getDB(ctx, r.db).WithContext(ctx).
+		Where("anchor_chat_uuid = ?", anchorChatUUID).
+		Order("created_at desc").
+		Find(&orms).Error; err != nil {
+		return nil, err
+	}
+	comparisons := make([]*model.BranchComparison, 0, len(orms))
+	for i := range orms {
+		comparison, err := orms[i].toDomain()
+		if err != nil {
+			return nil, err
+		}
+		comparisons = append(comparisons, comparison)
+	}
+	return comparisons, nil
+}
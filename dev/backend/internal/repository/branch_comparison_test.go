@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"backend/internal/domain/model"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// テスト用のブランチ比較結果リポジトリを作成する処理
+func setupBranchComparisonRepository(t *testing.T) *branchComparisonRepository {
+	t.Helper()
+	// インメモリDBのセットアップ
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	// マイグレーション
+	if err := db.AutoMigrate(&branchComparisonORM{}); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+	return &branchComparisonRepository{db: db}
+}
+
+func TestBranchComparisonRepository_FindByAnchorChatUUID(t *testing.T) {
+	r := setupBranchComparisonRepository(t)
+	ctx := context.Background()
+	base := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	anchorChat := "root-chat"
+	anchorMessage := "msg-1"
+	otherAnchorChat := "other-root"
+	comparisons := []*model.BranchComparison{
+		{
+			UUID: "comparison-1", ProjectUUID: "project-1", ChatUUID: "chat-a", OtherChatUUID: "chat-b",
+			AnchorChatUUID: &anchorChat, AnchorMessageUUID: &anchorMessage,
+			Positions: []model.BranchPosition{
+				{ChatUUID: "chat-a", Title: "案A", Position: "Aを採用する"},
+				{ChatUUID: "chat-b", Title: "案B", Position: "Bを採用する"},
+			},
+			Agreements:     []string{"目的は同じ"},
+			Disagreements:  []string{"手段が異なる"},
+			Recommendation: "Aを推奨",
+			PromptVersion:  "branch_comparison/ja/v1",
+			CreatedAt:      base,
+		},
+		{
+			UUID: "comparison-2", ProjectUUID: "project-1", ChatUUID: "chat-a", OtherChatUUID: "chat-c",
+			AnchorChatUUID: &anchorChat, AnchorMessageUUID: &anchorMessage,
+			Positions: []model.BranchPosition{}, Agreements: []string{}, Disagreements: []string{},
+			CreatedAt: base.Add(time.Minute),
+		},
+		{
+			UUID: "comparison-3", ProjectUUID: "project-1", ChatUUID: "chat-x", OtherChatUUID: "chat-y",
+			AnchorChatUUID: &otherAnchorChat, AnchorMessageUUID: &anchorMessage,
+			CreatedAt: base,
+		},
+	}
+	for _, c := range comparisons {
+		assert.NoError(t, r.Create(ctx, c))
+	}
+
+	got, err := r.FindByAnchorChatUUID(ctx, anchorChat)
+	assert.NoError(t, err)
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, "comparison-2", got[0].UUID)
+		assert.Equal(t, "comparison-1", got[1].UUID)
+		assert.Equal(t, comparisons[0].Positions, got[1].Positions)
+		assert.Equal(t, []string{"目的は同じ"}, got[1].Agreements)
+		assert.Equal(t, []string{"手段が異なる"}, got[1].Disagreements)
+		assert.Equal(t, "Aを推奨", got[1].Recommendation)
+		assert.Equal(t, "branch_comparison/ja/v1", got[1].PromptVersion)
+		assert.Equal(t, "msg-1", *got[1].AnchorMessageUUID)
+	}
+
+	got, err = r.FindByAnchorChatUUID(ctx, "unknown")
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestBranchComparisonRepository_Create(t *testing.T) {
+	r := setupBranchComparisonRepository(t)
+
+	// 共通の祖先メッセージがない比較結果はメモとして保存できない
+	err := r.Create(context.Background(), &model.BranchComparison{UUID: "comparison-1", ChatUUID: "chat-a", OtherChatUUID: "chat-b"})
+	assert.Error(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"backend/internal/domain/model"
 	"backend/internal/domain/repository"
 	"context"
+	"fmt"
 	"log/slog"
 	"time"
 
@@ -12,26 +13,54 @@ import (
 )
 
 type chatORM struct {
-	UUID                 string    `gorm:"primaryKey;column:uuid;size:255"`
-	ProjectUUID          string    `gorm:"column:project_uuid;size:255"`
-	ParentChatUUID       *string   `gorm:"column:parent_chat_uuid;size:255"`
-	SourceMessageUUID    *string   `gorm:"column:source_message_uuid;size:255"`
-	MessageSelectionUUID *string   `gorm:"column:message_selection_uuid;size:255"`
-	Title                string    `gorm:"column:title;size:255"`
-	Status               string    `gorm:"column:status;size:50"`
-	ContextSummary       *string   `gorm:"column:context_summary;type:text"`
-	PositionX            float64   `gorm:"column:position_x"`
-	PositionY            float64   `gorm:"column:position_y"`
-	CreatedID            string    `gorm:"column:created_id;size:255"`
-	CreatedAt            time.Time `gorm:"column:created_at"`
-	UpdatedAt            time.Time `gorm:"column:updated_at"`
-	UpdatedID            *string   `gorm:"column:updated_id;size:255"`
+	UUID                  string     `gorm:"primaryKey;column:uuid;size:255"`
+	ProjectUUID           string     `gorm:"column:project_uuid;size:255"`
+	ParentChatUUID        *string    `gorm:"column:parent_chat_uuid;size:255"`
+	SourceMessageUUID     *string    `gorm:"column:source_message_uuid;size:255"`
+	MessageSelectionUUID  *string    `gorm:"column:message_selection_uuid;size:255"`
+	Title                 string     `gorm:"column:title;size:255"`
+	Status                string     `gorm:"column:status;size:50"`
+	ContextSummary        *string    `gorm:"column:context_summary;type:text"`
+	ContextPromptVersion  *string    `gorm:"column:context_prompt_version;size:255"`
+	GenerationLockedUntil *time.Time `gorm:"column:generation_locked_until"`
+	GenerationLockOwner   *string    `gorm:"column:generation_lock_owner;size:255"`
+	CreatedID             string     `gorm:"column:created_id;size:255"`
+	CreatedAt             time.Time  `gorm:"column:created_at"`
+	UpdatedAt             time.Time  `gorm:"column:updated_at"`
+	UpdatedID             *string    `gorm:"column:updated_id;size:255"`
 }
 
 func (chatORM) TableName() string {
 	return "chats"
 }
 
+func (o *chatORM) toDomain() *model.Chat {
+	var contextSummary string
+	if o.ContextSummary != nil {
+		contextSummary = *o.ContextSummary
+	}
+
+	var contextPromptVersion string
+	if o.ContextPromptVersion != nil {
+		contextPromptVersion = *o.ContextPromptVersion
+	}
+
+	return &model.Chat{
+		UUID:                  o.UUID,
+		ProjectUUID:           o.ProjectUUID,
+		ParentUUID:            o.ParentChatUUID,
+		SourceMessageUUID:     o.SourceMessageUUID,
+		MessageSelectionUUID:  o.MessageSelectionUUID,
+		Title:                 o.Title,
+		Status:                o.Status,
+		ContextSummary:        contextSummary,
+		ContextPromptVersion:  contextPromptVersion,
+		GenerationLockedUntil: o.GenerationLockedUntil,
+		CreatedAt:             o.CreatedAt,
+		UpdatedAt:             o.UpdatedAt,
+	}
+}
+
 type chatRepository struct {
 	db *gorm.DB
 }
@@ -49,6 +78,11 @@ func (r *chatRepository) Create(ctx context.Context, chat *model.Chat) error {
 		contextSummary = &chat.ContextSummary
 	}
 
+	var contextPromptVersion *string
+	if chat.ContextPromptVersion != "" {
+		contextPromptVersion = &chat.ContextPromptVersion
+	}
+
 	orm := chatORM{
 		UUID:                 chat.UUID,
 		ProjectUUID:          chat.ProjectUUID,
@@ -58,8 +92,7 @@ func (r *chatRepository) Create(ctx context.Context, chat *model.Chat) error {
 		Title:                chat.Title,
 		Status:               chat.Status,
 		ContextSummary:       contextSummary,
-		PositionX:            chat.PositionX,
-		PositionY:            chat.PositionY,
+		ContextPromptVersion: contextPromptVersion,
 		CreatedID:            uuid.New().String(),
 		CreatedAt:            chat.CreatedAt,
 		UpdatedAt:            chat.UpdatedAt,
@@ -77,34 +110,37 @@ func (r *chatRepository) FindByID(ctx context.Context, uuid string) (*model.Chat
 		return nil, err
 	}
 
-	var contextSummary string
-	if orm.ContextSummary != nil {
-		contextSummary = *orm.ContextSummary
-	}
-
-	return &model.Chat{
-		UUID:                 orm.UUID,
-		ProjectUUID:          orm.ProjectUUID,
-		ParentUUID:           orm.ParentChatUUID,
-		SourceMessageUUID:    orm.SourceMessageUUID,
-		MessageSelectionUUID: orm.MessageSelectionUUID,
-		Title:                orm.Title,
-		Status:               orm.Status,
-		ContextSummary:       contextSummary,
-		PositionX:            orm.PositionX,
-		PositionY:            orm.PositionY,
-		CreatedAt:            orm.CreatedAt,
-		UpdatedAt:            orm.UpdatedAt,
-	}, nil
+	return orm.toDomain(), nil
 }
 
 // チャットのステータスを更新する
 func (r *chatRepository) UpdateStatus(ctx context.Context, chatUUID string, status string) error {
 	slog.DebugContext(ctx, "チャットステータス更新処理を開始", "chat_uuid", chatUUID, "status", status)
+	// 遷移の可否は usecase で判定するが、定義されていない値は保存しない
+	if !model.IsValidChatStatus(status) {
+		return fmt.Errorf("%w: 定義されていないステータスです: %s", model.ErrInvalidChatStatusTransition, status)
+	}
 	db := getDB(ctx, r.db)
 	return db.WithContext(ctx).Model(&chatORM{}).Where("uuid = ?", chatUUID).Update("status", status).Error
 }
 
+// チャットのステータスが from の場合だけ to に更新する
+// 確認から更新までの間に別のリクエストがステータスを変えた場合は更新せずに false を返す
+func (r *chatRepository) TransitionStatus(ctx context.Context, chatUUID string, from string, to string) (bool, error) {
+	slog.DebugContext(ctx, "チャットステータス遷移処理を開始", "chat_uuid", chatUUID, "from", from, "to", to)
+	if !model.IsValidChatStatus(to) {
+		return false, fmt.Errorf("%w: 定義されていないステータスです: %s", model.ErrInvalidChatStatusTransition, to)
+	}
+	db := getDB(ctx, r.db)
+	result := db.WithContext(ctx).Model(&chatORM{}).
+		Where("uuid = ? AND status = ?", chatUUID, from).
+		Update("status", to)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
 // プロジェクト内で最も古いチャットを取得する処理
 func (r *chatRepository) FindOldestByProjectUUID(ctx context.Context, projectUUID string) (*model.Chat, error) {
 	slog.DebugContext(ctx, "プロジェクト内最古チャット取得処理を開始", "project_uuid", projectUUID)
@@ -114,25 +150,37 @@ func (r *chatRepository) FindOldestByProjectUUID(ctx context.Context, projectUUI
 		return nil, err
 	}
 
-	var contextSummary string
-	if orm.ContextSummary != nil {
-		contextSummary = *orm.ContextSummary
+	return orm.toDomain(), nil
+}
+
+// プロジェクト内のすべてのチャットを作成順に取得する処理
+func (r *chatRepository) FindAllByProjectUUID(ctx context.Context, projectUUID string) ([]*model.Chat, error) {
+	slog.DebugContext(ctx, "プロジェクト内チャット一括取得処理を開始", "project_uuid", projectUUID)
+	var orms []chatORM
+	db := getDB(ctx, r.db)
+	if err := db.WithContext(ctx).Where("project_uuid = ?", projectUUID).Order("created_at ASC").Find(&orms).Error; err != nil {
+		return nil, err
+	}
+	chats := make([]*model.Chat, 0, len(orms))
+	for i := range orms {
+		chats = append(chats, orms[i].toDomain())
 	}
+	return chats, nil
+}
 
-	return &model.Chat{
-		UUID:                 orm.UUID,
-		ProjectUUID:          orm.ProjectUUID,
-		ParentUUID:           orm.ParentChatUUID,
-		SourceMessageUUID:    orm.SourceMessageUUID,
-		MessageSelectionUUID: orm.MessageSelectionUUID,
-		Title:                orm.Title,
-		Status:               orm.Status,
-		ContextSummary:       contextSummary,
-		PositionX:            orm.PositionX,
-		PositionY:            orm.PositionY,
-		CreatedAt:            orm.CreatedAt,
-		UpdatedAt:            orm.UpdatedAt,
-	}, nil
+// 指定したチャットから直接フォークされたチャットを作成順に取得する処理
+func (r *chatRepository) FindByParentUUID(ctx context.Context, parentChatUUID string) ([]*model.Chat, error) {
+	slog.DebugContext(ctx, "子チャット一覧取得処理を開始", "parent_chat_uuid", parentChatUUID)
+	var orms []chatORM
+	db := getDB(ctx, r.db)
+	if err := db.WithContext(ctx).Where("parent_chat_uuid = ?", parentChatUUID).Order("created_at ASC").Find(&orms).Error; err != nil {
+		return nil, err
+	}
+	chats := make([]*model.Chat, 0, len(orms))
+	for i := range orms {
+		chats = append(chats, orms[i].toDomain())
+	}
+	return chats, nil
 }
 
 // プロジェクト内のチャット数を取得する
@@ -145,3 +193,68 @@ func (r *chatRepository) CountByProjectUUID(ctx context.Context, projectUUID str
 	}
 	return count, nil
 }
+
+// 回答生成のロックを取得する処理
+// ロックがないか期限切れの場合のみ更新するため、同時に呼び出されても一方だけが取得できる
+func (r *chatRepository) AcquireGenerationLock(ctx context.Context, chatUUID string, owner string, now time.Time, until time.Time) (bool, error) {
+	slog.DebugContext(ctx, "回答生成ロック取得処理を開始", "chat_uuid", chatUUID)
+	db := getDB(ctx, r.db)
+	result := db.WithContext(ctx).Model(&chatORM{}).
+		Where("uuid = ? AND (generation_locked_until IS NULL OR generation_locked_until <= ?)", chatUUID, now).
+		Updates(map[string]interface{}{
+			"generation_locked_until": until,
+			"generation_lock_owner":   owner,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// 回答生成のロックの有効期限を延長する処理
+// 期限切れの後に別の生成がロックを取得している場合は延長せずに false を返す
+func (r *chatRepository) ExtendGenerationLock(ctx context.Context, chatUUID string, owner string, until time.Time) (bool, error) {
+	slog.DebugContext(ctx, "回答生成ロック延長処理を開始", "chat_uuid", chatUUID)
+	db := getDB(ctx, r.db)
+	result := db.WithContext(ctx).Model(&chatORM{}).
+		Where("uuid = ? AND generation_lock_owner = ?", chatUUID, owner).
+		Update("generation_locked_until", until)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// 回答生成のロックを解放する処理
+// 自分が取得したロックだけを解放し、期限切れの後に別の生成が取得したロックは残す
+func (r *chatRepository) ReleaseGenerationLock(ctx context.Context, chatUUID string, owner string) error {
+	slog.DebugContext(ctx, "回答生成ロック解放処理を開始", "chat_uuid", chatUUID)
+	db := getDB(ctx, r.db)
+	return db.WithContext(ctx).Model(&chatORM{}).
+		Where("uuid = ? AND generation_lock_owner = ?", chatUUID, owner).
+		Updates(map[string]interface{}{
+			"generation_locked_until": nil,
+			"generation_lock_owner":   nil,
+		}).Error
+}
+
+// ブランチの親チャット・起点メッセージ・選択範囲を付け替える処理
+func (r *chatRepository) UpdateParent(ctx context.Context, chatUUID string, parentChatUUID string, sourceMessageUUID string, messageSelectionUUID *string) error {
+	slog.DebugContext(ctx, "チャットの親の付け替え処理を開始", "chat_uuid", chatUUID, "parent_chat_uuid", parentChatUUID, "source_message_uuid", sourceMessageUUID)
+	db := getDB(ctx, r.db)
+	return db.WithContext(ctx).Model(&chatORM{}).Where("uuid = ?", chatUUID).Updates(map[string]interface{}{
+		"parent_chat_uuid":       parentChatUUID,
+		"source_message_uuid":    sourceMessageUUID,
+		"message_selection_uuid": messageSelectionUUID,
+	}).Error
+}
+
+// フォークした理由のコンテキストと生成に使用したプロンプトのバージョンを更新する処理
+func (r *chatRepository) UpdateContextSummary(ctx context.Context, chatUUID string, summary string, promptVersion string) error {
+	slog.DebugContext(ctx, "チャットのコンテキスト更新処理を開始", "chat_uuid", chatUUID, "prompt_version", promptVersion)
+	db := getDB(ctx, r.db)
+	return db.WithContext(ctx).Model(&chatORM{}).Where("uuid = ?", chatUUID).Updates(map[string]interface{}{
+		"context_summary":        summary,
+		"context_prompt_version": promptVersion,
+	}).Error
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"backend/internal/domain/model"
+	"backend/internal/domain/repository"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type chatMergeEventORM struct {
+	UUID              string    `gorm:"primaryKey;column:uuid;size:255"`
+	ChatUUID          string    `gorm:"column:chat_uuid;size:255"`
+	ParentChatUUID    string    `gorm:"column:parent_chat_uuid;size:255"`
+	ReportMessageUUID string    `gorm:"column:report_message_uuid;size:255"`
+	Action            string    `gorm:"column:action;size:50"`
+	PreviousStatus    string    `gorm:"column:previous_status;size:50"`
+	Forced            bool      `gorm:"column:forced"`
+	UserUUID          *string   `gorm:"column:user_uuid;size:255"`
+	CreatedAt         time.Time `gorm:"column:created_at"`
+}
+
+func (chatMergeEventORM) TableName() string {
+	return "chat_merge_events"
+}
+
+func (o *chatMergeEventORM) toDomain() *model.ChatMergeEvent {
+	return &model.ChatMergeEvent{
+		UUID:              o.UUID,
+		ChatUUID:          o.ChatUUID,
+		ParentChatUUID:    o.ParentChatUUID,
+		ReportMessageUUID: o.ReportMessageUUID,
+		Action:            o.Action,
+		PreviousStatus:    o.PreviousStatus,
+		Forced:            o.Forced,
+		UserUUID:          o.UserUUID,
+		CreatedAt:         o.CreatedAt,
+	}
+}
+
+type chatMergeEventRepository struct {
+	db *gorm.DB
+}
+
+func NewChatMergeEventRepository(db *gorm.DB) repository.ChatMergeEventRepository {
+	return &chatMergeEventRepository{db: db}
+}
+
+// マージ履歴を作成する
+func (r *chatMergeEventRepository) Create(ctx context.Context, event *model.ChatMergeEvent) error {
+	slog.DebugContext(ctx, "マージ履歴作成処理を開始", "chat_uuid", event.ChatUUID, "action", event.Action)
+	orm := chatMergeEventORM{
+		UUID:              event.UUID,
+		ChatUUID:          event.ChatUUID,
+		ParentChatUUID:    event.ParentChatUUID,
+		ReportMessageUUID: event.ReportMessageUUID,
+		Action:            event.Action,
+		PreviousStatus:    event.PreviousStatus,
+		Forced:            event.Forced,
+		UserUUID:          event.UserUUID,
+		CreatedAt:         event.CreatedAt,
+	}
+	return getDB(ctx, r.db).WithContext(ctx).Create(&orm).Error
+}
+
+// 子チャットのマージ履歴を古い順に取得する
+func (r *chatMergeEventRepository) FindByChatUUID(ctx context.Context, chatUUID string) ([]*model.ChatMergeEvent, error) {
+	slog.DebugContext(ctx, "マージ履歴取得処理を開始", "chat_uuid", chatUUID)
+	var orms []chatMergeEventORM
+	if err := getDB(ctx, r.db).WithContext(ctx).
+		Where("chat_uuid = ?", chatUUID).
+		Order("created_at asc").
+		Find(&orms).Error; err != nil {
+		return nil, err
+	}
+	events := make([]*model.ChatMergeEvent, 0, len(orms))
+	for i := range orms {
+		events = append(events, orms[i].toDomain())
+	}
+	return events, nil
+}
+
+// 同じマージレポートでマージされたチャットの履歴を古い順に取得する
+func (r *chatMergeEventRepository) FindByReportMessageUUID(ctx context.Context, reportMessageUUID string) ([]*model.ChatMergeEvent, error) {
+	slog.DebugContext(ctx, "マージレポートの履歴取得処理を開始", "report_message_uuid", reportMessageUUID)
+	var orms []chatMergeEventORM
+	if err := getDB(ctx, r.db).WithContext(ctx).
+		Where("report_message_uuid = ?", reportMessageUUID).
+		Order("created_at asc").
+		Find(&orms).Error; err != nil {
+		return nil, err
+	}
+	events := make([]*model.ChatMergeEvent, 0, len(orms))
+	for i := range orms {
+		events = append(events, orms[i].toDomain())
+	}
+	return events, nil
+}
+
+// 子チャットの指定した操作の最新の履歴を取得する
+func (r *chatMergeEventRepository) FindLatestByChatUUID(ctx context.Context, chatUUID string, action string) (*model.ChatMergeEvent, error) {
+	slog.DebugContext(ctx, "最新マージ履歴取得処理を開始", "chat_uuid", chatUUID, "action", action)
+	var orm chatMergeEventORM
+	err := getDB(ctx, r.db).WithContext(ctx).
+		Where("chat_uuid = ? AND action = ?", chatUUID, action).
+		Order("created_at desc").
+		First(&orm).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return orm.toDomain(), nil
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"backend/internal/domain/model"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// テスト用のマージ履歴リポジトリを作成する処理
+func setupChatMergeEventRepository(t *testing.T) *chatMergeEventRepository {
+	t.Helper()
+	// インメモリDBのセットアップ
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	// マイグレーション
+	if err := db.AutoMigrate(&chatMergeEventORM{}); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+	return &chatMergeEventRepository{db: db}
+}
+
+func TestChatMergeEventRepository_FindByChatUUID(t *testing.T) {
+	r := setupChatMergeEventRepository(t)
+	ctx := context.Background()
+	base := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	userUUID := "user-1"
+	events := []*model.ChatMergeEvent{
+		{UUID: "event-1", ChatUUID: "child-1", ParentChatUUID: "parent-1", ReportMessageUUID: "report-1", Action: model.ChatMergeActionMerged, PreviousStatus: "open", UserUUID: &userUUID, CreatedAt: base},
+		{UUID: "event-2", ChatUUID: "child-1", ParentChatUUID: "parent-1", ReportMessageUUID: "report-1", Action: model.ChatMergeActionUnmerged, PreviousStatus: "merged", Forced: true, CreatedAt: base.Add(time.Minute)},
+		{UUID: "event-3", ChatUUID: "child-1", ParentChatUUID: "parent-1", ReportMessageUUID: "report-2", Action: model.ChatMergeActionMerged, PreviousStatus: "closed", CreatedAt: base.Add(2 * time.Minute)},
+		{UUID: "event-4", ChatUUID: "child-2", ParentChatUUID: "parent-1", ReportMessageUUID: "report-3", Action: model.ChatMergeActionMerged, PreviousStatus: "open", CreatedAt: base},
+	}
+	for _, e := range events {
+		assert.NoError(t, r.Create(ctx, e))
+	}
+
+	got, err := r.FindByChatUUID(ctx, "child-1")
+	assert.NoError(t, err)
+	if assert.Len(t, got, 3) {
+		assert.Equal(t, "event-1", got[0].UUID)
+		assert.Equal(t, "user-1", *got[0].UserUUID)
+		assert.Equal(t, "event-2", got[1].UUID)
+		assert.True(t, got[1].Forced)
+		assert.Equal(t, "event-3", got[2].UUID)
+	}
+
+	got, err = r.FindByChatUUID(ctx, "unknown")
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestChatMergeEventRepository_FindByReportMessageUUID(t *testing.T) {
+	r := setupChatMergeEventRepository(t)
+	ctx := context.Background()
+	base := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	// 部分木ごとのマージでは複数のチャットが同じレポートを参照する
+	assert.NoError(t, r.Create(ctx, &model.ChatMergeEvent{UUID: "event-1", ChatUUID: "child-1", ParentChatUUID: "root", ReportMessageUUID: "report-1", Action: model.ChatMergeActionMerged, PreviousStatus: "open", CreatedAt: base}))
+	assert.NoError(t, r.Create(ctx, &model.ChatMergeEvent{UUID: "event-2", ChatUUID: "grandchild-1", ParentChatUUID: "root", ReportMessageUUID: "report-1", Action: model.ChatMergeActionMerged, PreviousStatus: "closed", CreatedAt: base.Add(time.Second)}))
+	assert.NoError(t, r.Create(ctx, &model.ChatMergeEvent{UUID: "event-3", ChatUUID: "child-2", ParentChatUUID: "root", ReportMessageUUID: "report-2", Action: model.ChatMergeActionMerged, PreviousStatus: "open", CreatedAt: base}))
+
+	got, err := r.FindByReportMessageUUID(ctx, "report-1")
+	assert.NoError(t, err)
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, "child-1", got[0].ChatUUID)
+		assert.Equal(t, "grandchild-1", got[1].ChatUUID)
+	}
+}
+
+func TestChatMergeEventRepository_FindLatestByChatUUID(t *testing.T) {
+	r := setupChatMergeEventRepository(t)
+	ctx := context.Background()
+	base := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	assert.NoError(t, r.Create(ctx, &model.ChatMergeEvent{UUID: "event-1", ChatUUID: "child-1", ParentChatUUID: "parent-1", ReportMessageUUID: "report-1", Action: model.ChatMergeActionMerged, PreviousStatus: "open", CreatedAt: base}))
+	assert.NoError(t, r.Create(ctx, &model.ChatMergeEvent{UUID: "event-2", ChatUUID: "child-1", ParentChatUUID: "parent-1", ReportMessageUUID: "report-2", Action: model.ChatMergeActionMerged, PreviousStatus: "closed", CreatedAt: base.Add(time.Minute)}))
+
+	tests := []struct {
+		name     string
+		chatUUID string
+		action   string
+		wantUUID string
+	}{
+		{name: "正常系: 最新の履歴が取得できること", chatUUID: "child-1", action: model.ChatMergeActionMerged, wantUUID: "event-2"},
+		{name: "正常系: 該当する操作の履歴がない場合は nil が返ること", chatUUID: "child-1", action: model.ChatMergeActionUnmerged},
+		{name: "正常系: 履歴がないチャットは nil が返ること", chatUUID: "child-2", action: model.ChatMergeActionMerged},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.FindLatestByChatUUID(ctx, tt.chatUUID, tt.action)
+			assert.NoError(t, err)
+			if tt.wantUUID == "" {
+				assert.Nil(t, got)
+				return
+			}
+			assert.Equal(t, tt.wantUUID, got.UUID)
+		})
+	}
+}
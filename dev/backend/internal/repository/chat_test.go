@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
 )
 
@@ -206,6 +207,24 @@ func TestChatRepository_UpdateStatus(t *testing.T) {
 			setupData: func(db *gorm.DB) {},
 			wantErr:   false,
 		},
+		{
+			name: "異常系: 定義されていないステータスは保存しないこと",
+			args: args{
+				chatUUID: "chat-uuid",
+				status:   "archived",
+			},
+			setupData: func(db *gorm.DB) {
+				db.Create(&chatORM{
+					UUID:        "chat-uuid",
+					ProjectUUID: "project-uuid",
+					Title:       "test chat",
+					Status:      "open",
+					CreatedAt:   time.Now(),
+					UpdatedAt:   time.Now(),
+				})
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -243,6 +262,73 @@ func TestChatRepository_UpdateStatus(t *testing.T) {
 	}
 }
 
+func TestChatRepository_TransitionStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		from       string
+		to         string
+		want       bool
+		wantStatus string
+		wantErr    bool
+	}{
+		{
+			name:       "正常系: 現在のステータスが一致する場合は更新されること",
+			from:       "open",
+			to:         "merged",
+			want:       true,
+			wantStatus: "merged",
+		},
+		{
+			name:       "正常系: 現在のステータスが一致しない場合は更新されないこと",
+			from:       "closed",
+			to:         "open",
+			want:       false,
+			wantStatus: "open",
+		},
+		{
+			name:       "異常系: 定義されていないステータスは保存しないこと",
+			from:       "open",
+			to:         "archived",
+			wantStatus: "open",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// インメモリDBのセットアップ
+			db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+			if err != nil {
+				t.Fatalf("failed to connect database: %v", err)
+			}
+			// マイグレーション
+			if err := db.AutoMigrate(&chatORM{}); err != nil {
+				t.Fatalf("failed to migrate database: %v", err)
+			}
+			db.Create(&chatORM{
+				UUID:        "chat-uuid",
+				ProjectUUID: "project-uuid",
+				Title:       "test chat",
+				Status:      "open",
+				CreatedAt:   time.Now(),
+				UpdatedAt:   time.Now(),
+			})
+
+			r := NewChatRepository(db)
+			got, err := r.TransitionStatus(context.Background(), "chat-uuid", tt.from, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("chatRepository.TransitionStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+
+			var orm chatORM
+			if err := db.Where("uuid = ?", "chat-uuid").First(&orm).Error; err != nil {
+				t.Fatalf("failed to fetch chat: %v", err)
+			}
+			assert.Equal(t, tt.wantStatus, orm.Status)
+		})
+	}
+}
+
 func TestChatRepository_FindOldestByProjectUUID(t *testing.T) {
 	type args struct {
 		projectUUID string
@@ -445,3 +531,386 @@ func TestChatRepository_CountByProjectUUID(t *testing.T) {
 		})
 	}
 }
+
+func TestChatRepository_FindAllByProjectUUID(t *testing.T) {
+	base := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		project   string
+		setupData func(db *gorm.DB)
+		wantUUIDs []string
+		wantErr   bool
+	}{
+		{
+			name:    "正常系: プロジェクト内のチャットが作成順に取得できること",
+			project: "project-uuid",
+			setupData: func(db *gorm.DB) {
+				db.Create(&chatORM{UUID: "child", ProjectUUID: "project-uuid", CreatedAt: base.Add(time.Minute)})
+				db.Create(&chatORM{UUID: "root", ProjectUUID: "project-uuid", CreatedAt: base})
+				db.Create(&chatORM{UUID: "other", ProjectUUID: "other-project", CreatedAt: base})
+			},
+			wantUUIDs: []string{"root", "child"},
+		},
+		{
+			name:      "正常系: チャットがない場合は空になること",
+			project:   "project-uuid",
+			setupData: func(db *gorm.DB) {},
+			wantUUIDs: []string{},
+		},
+		{
+			name:    "異常系: DBエラーが発生した場合エラーになること",
+			project: "project-uuid",
+			setupData: func(db *gorm.DB) {
+				sqlDB, _ := db.DB()
+				sqlDB.Close()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// インメモリDBのセットアップ
+			db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+			if err != nil {
+				t.Fatalf("failed to connect database: %v", err)
+			}
+			// マイグレーション
+			if err := db.AutoMigrate(&chatORM{}); err != nil {
+				t.Fatalf("failed to migrate database: %v", err)
+			}
+			tt.setupData(db)
+
+			r := NewChatRepository(db)
+			got, err := r.FindAllByProjectUUID(context.Background(), tt.project)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("chatRepository.FindAllByProjectUUID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			gotUUIDs := make([]string, 0, len(got))
+			for _, c := range got {
+				gotUUIDs = append(gotUUIDs, c.UUID)
+			}
+			assert.Equal(t, tt.wantUUIDs, gotUUIDs)
+		})
+	}
+}
+
+func TestChatRepository_FindByParentUUID(t *testing.T) {
+	parentUUID := "parent-chat"
+	otherUUID := "other-chat"
+	base := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		parent    string
+		setupData func(db *gorm.DB)
+		wantUUIDs []string
+		wantErr   bool
+	}{
+		{
+			name:   "正常系: 直接フォークされたチャットが作成順に取得できること",
+			parent: parentUUID,
+			setupData: func(db *gorm.DB) {
+				db.Create(&chatORM{UUID: "child-2", ProjectUUID: "project-uuid", ParentChatUUID: &parentUUID, CreatedAt: base.Add(time.Minute)})
+				db.Create(&chatORM{UUID: "child-1", ProjectUUID: "project-uuid", ParentChatUUID: &parentUUID, CreatedAt: base})
+				db.Create(&chatORM{UUID: "child-other", ProjectUUID: "project-uuid", ParentChatUUID: &otherUUID, CreatedAt: base})
+			},
+			wantUUIDs: []string{"child-1", "child-2"},
+		},
+		{
+			name:      "正常系: 子チャットがない場合は空になること",
+			parent:    parentUUID,
+			setupData: func(db *gorm.DB) {},
+			wantUUIDs: []string{},
+		},
+		{
+			name:   "異常系: DBエラーが発生した場合エラーになること",
+			parent: parentUUID,
+			setupData: func(db *gorm.DB) {
+				sqlDB, _ := db.DB()
+				sqlDB.Close()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// インメモリDBのセットアップ
+			db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+			if err != nil {
+				t.Fatalf("failed to connect database: %v", err)
+			}
+			// マイグレーション
+			if err := db.AutoMigrate(&chatORM{}); err != nil {
+				t.Fatalf("failed to migrate database: %v", err)
+			}
+			tt.setupData(db)
+
+			r := NewChatRepository(db)
+			got, err := r.FindByParentUUID(context.Background(), tt.parent)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("chatRepository.FindByParentUUID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			gotUUIDs := make([]string, 0, len(got))
+			for _, c := range got {
+				gotUUIDs = append(gotUUIDs, c.UUID)
+			}
+			if len(gotUUIDs) != len(tt.wantUUIDs) {
+				t.Fatalf("chatRepository.FindByParentUUID() = %v, want %v", gotUUIDs, tt.wantUUIDs)
+			}
+			for i := range gotUUIDs {
+				if gotUUIDs[i] != tt.wantUUIDs[i] {
+					t.Errorf("chatRepository.FindByParentUUID() = %v, want %v", gotUUIDs, tt.wantUUIDs)
+				}
+			}
+		})
+	}
+}
+
+func TestChatRepository_AcquireGenerationLock(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Minute)
+
+	tests := []struct {
+		name        string
+		lockedUntil *time.Time
+		want        bool
+	}{
+		{
+			name:        "正常系: ロックがない場合取得できること",
+			lockedUntil: nil,
+			want:        true,
+		},
+		{
+			name:        "正常系: 期限切れのロックは取得し直せること",
+			lockedUntil: &past,
+			want:        true,
+		},
+		{
+			name:        "異常系: 他の生成がロック中の場合取得できないこと",
+			lockedUntil: &future,
+			want:        false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupGenerationLockDB(t, tt.lockedUntil, now)
+
+			r := NewChatRepository(db)
+			until := now.Add(5 * time.Minute)
+			got, err := r.AcquireGenerationLock(context.Background(), "chat-uuid", "owner-a", now, until)
+			if err != nil {
+				t.Fatalf("chatRepository.AcquireGenerationLock() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("chatRepository.AcquireGenerationLock() = %v, want %v", got, tt.want)
+			}
+
+			chat, err := r.FindByID(context.Background(), "chat-uuid")
+			if err != nil {
+				t.Fatalf("failed to fetch chat: %v", err)
+			}
+			if tt.want != chat.GenerationLockedUntil.Equal(until) {
+				t.Errorf("generation_locked_until = %v, acquired %v", chat.GenerationLockedUntil, tt.want)
+			}
+
+			// 自分が取得したロックだけが解放され、解放後は別の生成が取得できる
+			if err := r.ReleaseGenerationLock(context.Background(), "chat-uuid", "owner-a"); err != nil {
+				t.Fatalf("chatRepository.ReleaseGenerationLock() error = %v", err)
+			}
+			got, err = r.AcquireGenerationLock(context.Background(), "chat-uuid", "owner-b", now, until)
+			if err != nil || got != tt.want {
+				t.Errorf("chatRepository.AcquireGenerationLock() after release = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestChatRepository_ExtendGenerationLock(t *testing.T) {
+	now := time.Now()
+	until := now.Add(5 * time.Minute)
+	extended := now.Add(10 * time.Minute)
+
+	tests := []struct {
+		name      string
+		owner     string
+		want      bool
+		wantUntil time.Time
+	}{
+		{
+			name:      "正常系: 自分が取得したロックは延長できること",
+			owner:     "owner-a",
+			want:      true,
+			wantUntil: extended,
+		},
+		{
+			name:      "異常系: 別の生成が取得したロックは延長できないこと",
+			owner:     "owner-b",
+			want:      false,
+			wantUntil: until,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewChatRepository(setupGenerationLockDB(t, nil, now))
+			ctx := context.Background()
+			if ok, err := r.AcquireGenerationLock(ctx, "chat-uuid", "owner-a", now, until); err != nil || !ok {
+				t.Fatalf("chatRepository.AcquireGenerationLock() = %v, %v", ok, err)
+			}
+
+			got, err := r.ExtendGenerationLock(ctx, "chat-uuid", tt.owner, extended)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			chat, err := r.FindByID(ctx, "chat-uuid")
+			if err != nil {
+				t.Fatalf("failed to fetch chat: %v", err)
+			}
+			assert.True(t, chat.GenerationLockedUntil.Equal(tt.wantUntil), "generation_locked_until = %v", chat.GenerationLockedUntil)
+
+			// 別の生成の解放ではロックが残ること
+			assert.NoError(t, r.ReleaseGenerationLock(ctx, "chat-uuid", "owner-b"))
+			chat, err = r.FindByID(ctx, "chat-uuid")
+			if err != nil {
+				t.Fatalf("failed to fetch chat: %v", err)
+			}
+			assert.True(t, chat.IsGenerating(now))
+		})
+	}
+}
+
+// 回答生成ロックのテスト用のチャットを作成する処理
+func setupGenerationLockDB(t *testing.T, lockedUntil *time.Time, now time.Time) *gorm.DB {
+	t.Helper()
+	// インメモリDBのセットアップ
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	// マイグレーション
+	if err := db.AutoMigrate(&chatORM{}); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+	chat := &chatORM{
+		UUID:                  "chat-uuid",
+		ProjectUUID:           "project-uuid",
+		Title:                 "test chat",
+		Status:                "open",
+		GenerationLockedUntil: lockedUntil,
+		CreatedAt:             now,
+		UpdatedAt:             now,
+	}
+	if lockedUntil != nil {
+		// 別の生成が取得したロック
+		chat.GenerationLockOwner = strPtr("other-owner")
+	}
+	db.Create(chat)
+	return db
+}
+
+func TestChatRepository_UpdateParent(t *testing.T) {
+	selectionUUID := "selection-uuid"
+	tests := []struct {
+		name                 string
+		messageSelectionUUID *string
+	}{
+		{
+			name:                 "正常系: 親チャットと起点メッセージと選択範囲が付け替えられること",
+			messageSelectionUUID: &selectionUUID,
+		},
+		{
+			name:                 "正常系: 選択範囲を指定しない場合は元の選択範囲が外れること",
+			messageSelectionUUID: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// インメモリDBのセットアップ
+			db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+			if err != nil {
+				t.Fatalf("failed to connect database: %v", err)
+			}
+			// マイグレーション
+			if err := db.AutoMigrate(&chatORM{}); err != nil {
+				t.Fatalf("failed to migrate database: %v", err)
+			}
+			oldParent := "old-parent-uuid"
+			oldSource := "old-msg-uuid"
+			oldSelection := "old-selection-uuid"
+			db.Create(&chatORM{
+				UUID:                 "chat-uuid",
+				ProjectUUID:          "project-uuid",
+				ParentChatUUID:       &oldParent,
+				SourceMessageUUID:    &oldSource,
+				MessageSelectionUUID: &oldSelection,
+				Title:                "test chat",
+				Status:               "open",
+				CreatedAt:            time.Now(),
+				UpdatedAt:            time.Now(),
+			})
+
+			r := NewChatRepository(db)
+			if err := r.UpdateParent(context.Background(), "chat-uuid", "new-parent-uuid", "new-msg-uuid", tt.messageSelectionUUID); err != nil {
+				t.Fatalf("chatRepository.UpdateParent() error = %v", err)
+			}
+
+			chat, err := r.FindByID(context.Background(), "chat-uuid")
+			if err != nil {
+				t.Fatalf("failed to fetch chat: %v", err)
+			}
+			if chat.ParentUUID == nil || *chat.ParentUUID != "new-parent-uuid" {
+				t.Errorf("parent_chat_uuid = %v, want %v", chat.ParentUUID, "new-parent-uuid")
+			}
+			if chat.SourceMessageUUID == nil || *chat.SourceMessageUUID != "new-msg-uuid" {
+				t.Errorf("source_message_uuid = %v, want %v", chat.SourceMessageUUID, "new-msg-uuid")
+			}
+			if (chat.MessageSelectionUUID == nil) != (tt.messageSelectionUUID == nil) ||
+				(chat.MessageSelectionUUID != nil && *chat.MessageSelectionUUID != *tt.messageSelectionUUID) {
+				t.Errorf("message_selection_uuid = %v, want %v", chat.MessageSelectionUUID, tt.messageSelectionUUID)
+			}
+		})
+	}
+}
+
+func TestChatRepository_UpdateContextSummary(t *testing.T) {
+	// インメモリDBのセットアップ
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	// マイグレーション
+	if err := db.AutoMigrate(&chatORM{}); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+	db.Create(&chatORM{
+		UUID:        "chat-uuid",
+		ProjectUUID: "project-uuid",
+		Title:       "test chat",
+		Status:      "open",
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	})
+
+	r := NewChatRepository(db)
+	if err := r.UpdateContextSummary(context.Background(), "chat-uuid", "新しい文脈", "fork_preview/ja/v2"); err != nil {
+		t.Fatalf("chatRepository.UpdateContextSummary() error = %v", err)
+	}
+
+	chat, err := r.FindByID(context.Background(), "chat-uuid")
+	if err != nil {
+		t.Fatalf("failed to fetch chat: %v", err)
+	}
+	if chat.ContextSummary != "新しい文脈" {
+		t.Errorf("context_summary = %v, want %v", chat.ContextSummary, "新しい文脈")
+	}
+	if chat.ContextPromptVersion != "fork_preview/ja/v2" {
+		t.Errorf("context_prompt_version = %v, want %v", chat.ContextPromptVersion, "fork_preview/ja/v2")
+	}
+}
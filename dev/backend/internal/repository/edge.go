@@ -49,6 +49,33 @@ func (r *edgeRepository) FindEdgesByChatID(ctx context.Context, chatUUID string)
 	return edges, nil
 }
 
+// プロジェクト内のすべてのチャットのエッジを取得する
+// ツリーの構築結果が取得のたびに変わらないように、チャットとエッジの元のメッセージの作成順に並べる
+func (r *edgeRepository) FindEdgesByProjectUUID(ctx context.Context, projectUUID string) ([]*model.Edge, error) {
+	slog.DebugContext(ctx, "プロジェクト内エッジ一括取得処理を開始", "project_uuid", projectUUID)
+	var orms []edgeORM
+	db := getDB(ctx, r.db)
+	if err := db.WithContext(ctx).
+		Joins("JOIN chats ON chats.uuid = edges.chat_uuid").
+		Joins("JOIN messages ON messages.uuid = edges.source_message_uuid").
+		Where("chats.project_uuid = ?", projectUUID).
+		Order("chats.created_at ASC, messages.created_at ASC, edges.uuid ASC").
+		Find(&orms).Error; err != nil {
+		return nil, err
+	}
+
+	edges := make([]*model.Edge, 0, len(orms))
+	for _, orm := range orms {
+		edges = append(edges, &model.Edge{
+			UUID:              orm.UUID,
+			ChatUUID:          orm.ChatUUID,
+			SourceMessageUUID: orm.SourceMessageUUID,
+			TargetMessageUUID: orm.TargetMessageUUID,
+		})
+	}
+	return edges, nil
+}
+
 // エッジを作成する
 func (r *edgeRepository) Create(ctx context.Context, edge *model.Edge) error {
 	slog.DebugContext(ctx, "エッジ作成処理を開始", "edge_uuid", edge.UUID)
@@ -64,3 +91,10 @@ func (r *edgeRepository) Create(ctx context.Context, edge *model.Edge) error {
 	}
 	return nil
 }
+
+// エッジの接続先のメッセージを付け替える
+func (r *edgeRepository) UpdateTarget(ctx context.Context, edgeUUID string, targetMessageUUID string) error {
+	slog.DebugContext(ctx, "エッジの接続先更新処理を開始", "edge_uuid", edgeUUID, "target_message_uuid", targetMessageUUID)
+	db := getDB(ctx, r.db)
+	return db.WithContext(ctx).Model(&edgeORM{}).Where("uuid = ?", edgeUUID).Update("target_message_uuid", targetMessageUUID).Error
+}
@@ -3,8 +3,10 @@ package repository
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
 )
 
@@ -114,3 +116,113 @@ func TestEdgeRepository_FindEdgesByChatID(t *testing.T) {
 		})
 	}
 }
+
+func TestEdgeRepository_FindEdgesByProjectUUID(t *testing.T) {
+	base := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		setupData func(db *gorm.DB)
+		wantUUIDs []string
+		wantErr   bool
+	}{
+		{
+			name: "正常系: プロジェクト内のすべてのチャットのエッジが取得できること",
+			setupData: func(db *gorm.DB) {
+				db.Create(&chatORM{UUID: "root-chat", ProjectUUID: "project-uuid", CreatedAt: base})
+				db.Create(&chatORM{UUID: "child-chat", ProjectUUID: "project-uuid", CreatedAt: base.Add(time.Minute)})
+				db.Create(&chatORM{UUID: "other-chat", ProjectUUID: "other-project", CreatedAt: base})
+				db.Create(&messageORM{UUID: "msg-2", ChatUUID: "root-chat", Role: "assistant", CreatedAt: base})
+				db.Create(&messageORM{UUID: "msg-3", ChatUUID: "child-chat", Role: "assistant", CreatedAt: base.Add(time.Minute)})
+				db.Create(&messageORM{UUID: "msg-5", ChatUUID: "other-chat", Role: "assistant", CreatedAt: base})
+				db.Create(&edgeORM{UUID: "edge-2", ChatUUID: "child-chat", SourceMessageUUID: "msg-3", TargetMessageUUID: "msg-2"})
+				db.Create(&edgeORM{UUID: "edge-1", ChatUUID: "root-chat", SourceMessageUUID: "msg-2", TargetMessageUUID: "msg-1"})
+				db.Create(&edgeORM{UUID: "edge-other", ChatUUID: "other-chat", SourceMessageUUID: "msg-5", TargetMessageUUID: "msg-4"})
+			},
+			wantUUIDs: []string{"edge-1", "edge-2"},
+		},
+		{
+			name: "正常系: 同じチャットのエッジは元のメッセージの作成順に並ぶこと",
+			setupData: func(db *gorm.DB) {
+				db.Create(&chatORM{UUID: "root-chat", ProjectUUID: "project-uuid", CreatedAt: base})
+				db.Create(&messageORM{UUID: "msg-b", ChatUUID: "root-chat", Role: "assistant", CreatedAt: base.Add(2 * time.Second)})
+				db.Create(&messageORM{UUID: "msg-a", ChatUUID: "root-chat", Role: "assistant", CreatedAt: base.Add(time.Second)})
+				db.Create(&edgeORM{UUID: "edge-z", ChatUUID: "root-chat", SourceMessageUUID: "msg-a", TargetMessageUUID: "msg-0"})
+				db.Create(&edgeORM{UUID: "edge-y", ChatUUID: "root-chat", SourceMessageUUID: "msg-b", TargetMessageUUID: "msg-a"})
+			},
+			wantUUIDs: []string{"edge-z", "edge-y"},
+		},
+		{
+			name:      "正常系: エッジが存在しない場合は空のリストが返ること",
+			setupData: func(db *gorm.DB) {},
+			wantUUIDs: []string{},
+		},
+		{
+			name: "異常系: DBエラーが発生した場合エラーになること",
+			setupData: func(db *gorm.DB) {
+				sqlDB, _ := db.DB()
+				sqlDB.Close()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// インメモリDBのセットアップ
+			db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+			if err != nil {
+				t.Fatalf("failed to connect database: %v", err)
+			}
+			// マイグレーション
+			if err := db.AutoMigrate(&edgeORM{}, &chatORM{}, &messageORM{}); err != nil {
+				t.Fatalf("failed to migrate database: %v", err)
+			}
+			tt.setupData(db)
+
+			r := NewEdgeRepository(db)
+			got, err := r.FindEdgesByProjectUUID(context.Background(), "project-uuid")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("edgeRepository.FindEdgesByProjectUUID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			gotUUIDs := make([]string, 0, len(got))
+			for _, edge := range got {
+				gotUUIDs = append(gotUUIDs, edge.UUID)
+			}
+			assert.Equal(t, tt.wantUUIDs, gotUUIDs)
+		})
+	}
+}
+
+func TestEdgeRepository_UpdateTarget(t *testing.T) {
+	// インメモリDBのセットアップ
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	// マイグレーション
+	if err := db.AutoMigrate(&edgeORM{}); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+	db.Create(&edgeORM{
+		UUID:              "edge-1",
+		ChatUUID:          "chat-uuid",
+		SourceMessageUUID: "msg-1",
+		TargetMessageUUID: "msg-2",
+	})
+
+	r := NewEdgeRepository(db)
+	if err := r.UpdateTarget(context.Background(), "edge-1", "msg-3"); err != nil {
+		t.Fatalf("edgeRepository.UpdateTarget() error = %v", err)
+	}
+
+	got, err := r.FindEdgesByChatID(context.Background(), "chat-uuid")
+	if err != nil {
+		t.Fatalf("failed to fetch edges: %v", err)
+	}
+	if len(got) != 1 || got[0].SourceMessageUUID != "msg-1" || got[0].TargetMessageUUID != "msg-3" {
+		t.Errorf("edgeRepository.UpdateTarget() got = %+v, want target msg-3", got)
+	}
+}
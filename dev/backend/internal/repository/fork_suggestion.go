@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"backend/internal/domain/model"
+	"backend/internal/domain/repository"
+	"context"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type forkSuggestionORM struct {
+	UUID             string    `gorm:"primaryKey;column:uuid;size:255"`
+	ChatUUID         string    `gorm:"column:chat_uuid;size:255"`
+	MessageUUID      string    `gorm:"column:message_uuid;size:255"`
+	SelectedText     string    `gorm:"column:selected_text;type:text"`
+	RangeStart       int       `gorm:"column:range_start"`
+	RangeEnd         int       `gorm:"column:range_end"`
+	SuggestedTitle   string    `gorm:"column:suggested_title;size:255"`
+	GeneratedContext string    `gorm:"column:generated_context;type:text"`
+	PromptVersion    *string   `gorm:"column:prompt_version;size:255"`
+	CreatedAt        time.Time `gorm:"column:created_at"`
+}
+
+func (forkSuggestionORM) TableName() string {
+	return "fork_suggestions"
+}
+
+func (o *forkSuggestionORM) toDomain() *model.ForkSuggestion {
+	suggestion := &model.ForkSuggestion{
+		UUID:             o.UUID,
+		ChatUUID:         o.ChatUUID,
+		MessageUUID:      o.MessageUUID,
+		SelectedText:     o.SelectedText,
+		RangeStart:       o.RangeStart,
+		RangeEnd:         o.RangeEnd,
+		SuggestedTitle:   o.SuggestedTitle,
+		GeneratedContext: o.GeneratedContext,
+		CreatedAt:        o.CreatedAt,
+	}
+	if o.PromptVersion != nil {
+		suggestion.PromptVersion = *o.PromptVersion
+	}
+	return suggestion
+}
+
+type forkSuggestionRepository struct {
+	db *gorm.DB
+}
+
+func NewForkSuggestionRepository(db *gorm.DB) repository.ForkSuggestionRepository {
+	return &forkSuggestionRepository{db: db}
+}
+
+// メッセージのフォーク候補を置き換える
+// 呼び出し元のトランザクション内で実行し、削除と作成をまとめて反映する
+func (r *forkSuggestionRepository) ReplaceByMessageUUID(ctx context.Context, messageUUID string, suggestions []*model.ForkSuggestion) error {
+	slog.DebugContext(ctx, "フォーク候補の置き換え処理を開始", "message_uuid", messageUUID, "count", len(suggestions))
+	db := getDB(ctx, r.db).WithContext(ctx)
+	if err := db.Where("message_uuid = ?", messageUUID).Delete(&forkSuggestionORM{}).Error; err != nil {
+		return err
+	}
+	if len(suggestions) == 0 {
+		return nil
+	}
+	orms := make([]forkSuggestionORM, len(suggestions))
+	for i, s := range suggestions {
+		orms[i] = forkSuggestionORM{
+			UUID:             s.UUID,
+			ChatUUID:         s.ChatUUID,
+			MessageUUID:      messageUUID,
+			SelectedText:     s.SelectedText,
+			RangeStart:       s.RangeStart,
+			RangeEnd:         s.RangeEnd,
+			SuggestedTitle:   s.SuggestedTitle,
+			GeneratedContext: s.GeneratedContext,
+			CreatedAt:        s.CreatedAt,
+		}
+		if s.PromptVersion != "" {
+			orms[i].PromptVersion = &s.PromptVersion
+		}
+	}
+	return db.Create(&orms).Error
+}
+
+// メッセージのフォーク候補を本文での位置順に取得する
+func (r *forkSuggestionRepository) FindByMessageUUID(ctx context.Context, messageUUID string) ([]*model.ForkSuggestion, error) {
+	slog.DebugContext(ctx, "フォーク候補の取得処理を開始", "message_uuid", messageUUID)
+	var orms []forkSuggestionORM
+	if err := getDB(ctx, r.db).WithContext(ctx).
+		Where("message_uuid = ?", messageUUID).
+		Order("range_start asc").
+		Find(&orms).Error; err != nil {
+		return nil, err
+	}
+	suggestions := make([]*model.ForkSuggestion, len(orms))
+	for i := range orms {
+		suggestions[i] = orms[i].toDomain()
+	}
+	return suggestions, nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"backend/internal/domain/model"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// テスト用のフォーク候補リポジトリを作成する処理
+func setupForkSuggestionRepository(t *testing.T) *forkSuggestionRepository {
+	t.Helper()
+	// インメモリDBのセットアップ
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	// マイグレーション
+	if err := db.AutoMigrate(&forkSuggestionORM{}); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+	return &forkSuggestionRepository{db: db}
+}
+
+func TestForkSuggestionRepository_ReplaceByMessageUUID(t *testing.T) {
+	r := setupForkSuggestionRepository(t)
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	first := []*model.ForkSuggestion{
+		{UUID: "old-1", ChatUUID: "chat-1", SelectedText: "古い候補", RangeStart: 0, RangeEnd: 4, SuggestedTitle: "古い", CreatedAt: now},
+	}
+	if err := r.ReplaceByMessageUUID(ctx, "msg-1", first); err != nil {
+		t.Fatalf("forkSuggestionRepository.ReplaceByMessageUUID() error = %v", err)
+	}
+	other := []*model.ForkSuggestion{
+		{UUID: "other-1", ChatUUID: "chat-1", SelectedText: "別の回答", RangeStart: 0, RangeEnd: 4, CreatedAt: now},
+	}
+	if err := r.ReplaceByMessageUUID(ctx, "msg-2", other); err != nil {
+		t.Fatalf("forkSuggestionRepository.ReplaceByMessageUUID() error = %v", err)
+	}
+
+	// 再抽出した候補で置き換え、他のメッセージの候補は残ること
+	second := []*model.ForkSuggestion{
+		{UUID: "new-2", ChatUUID: "chat-1", SelectedText: "後半の話題", RangeStart: 20, RangeEnd: 25, SuggestedTitle: "後半", GeneratedContext: "後半を深掘り", PromptVersion: "fork_suggestion/ja/v1", CreatedAt: now},
+		{UUID: "new-1", ChatUUID: "chat-1", SelectedText: "前半の話題", RangeStart: 3, RangeEnd: 8, SuggestedTitle: "前半", GeneratedContext: "前半を深掘り", CreatedAt: now},
+	}
+	if err := r.ReplaceByMessageUUID(ctx, "msg-1", second); err != nil {
+		t.Fatalf("forkSuggestionRepository.ReplaceByMessageUUID() error = %v", err)
+	}
+
+	got, err := r.FindByMessageUUID(ctx, "msg-1")
+	assert.NoError(t, err)
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, "new-1", got[0].UUID)
+		assert.Equal(t, "msg-1", got[0].MessageUUID)
+		assert.Equal(t, 3, got[0].RangeStart)
+		assert.Equal(t, "", got[0].PromptVersion)
+		assert.Equal(t, "new-2", got[1].UUID)
+		assert.Equal(t, "後半を深掘り", got[1].GeneratedContext)
+		assert.Equal(t, "fork_suggestion/ja/v1", got[1].PromptVersion)
+	}
+
+	others, err := r.FindByMessageUUID(ctx, "msg-2")
+	assert.NoError(t, err)
+	assert.Len(t, others, 1)
+
+	// 候補が空の場合は削除のみ行うこと
+	assert.NoError(t, r.ReplaceByMessageUUID(ctx, "msg-1", nil))
+	got, err = r.FindByMessageUUID(ctx, "msg-1")
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
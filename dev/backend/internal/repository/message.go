@@ -18,14 +18,18 @@ type messageORM struct {
 	Role                 string    `gorm:"size:50"`
 	Content              string    `gorm:"type:longtext"`
 	ContextSummary       *string   `gorm:"column:context_summary;type:text"`
+	SummaryPromptVersion *string   `gorm:"column:summary_prompt_version;size:255"`
+	PromptVersion        *string   `gorm:"column:prompt_version;size:255"`
 	SourceChatUUID       *string   `gorm:"column:source_chat_uuid;size:255"`
+	OriginMessageUUID    *string   `gorm:"column:origin_message_uuid;size:255"`
+	OriginChatUUID       *string   `gorm:"column:origin_chat_uuid;size:255"`
 	MessageSelectionUUID *string   `gorm:"column:message_selection_uuid;size:255"`
-	PositionX            float64   `gorm:"column:position_x;default:0"`
-	PositionY            float64   `gorm:"column:position_y;default:0"`
 	CreatedID            string    `gorm:"column:created_id;size:255"`
 	CreatedAt            time.Time `gorm:"column:created_at"`
 	UpdatedAt            time.Time `gorm:"column:updated_at"`
 	UpdatedID            *string   `gorm:"column:updated_id;size:255"`
+	// マージを取り消したマージレポートは論理削除して履歴に残す
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index"`
 }
 
 func (messageORM) TableName() string {
@@ -50,8 +54,9 @@ func (r *messageRepository) Create(ctx context.Context, message *model.Message)
 		Role:              message.Role,
 		Content:           message.Content,
 		SourceChatUUID:    message.SourceChatUUID,
-		PositionX:         message.PositionX,
-		PositionY:         message.PositionY,
+		OriginMessageUUID: message.OriginMessageUUID,
+		OriginChatUUID:    message.OriginChatUUID,
+		PromptVersion:     message.PromptVersion,
 		CreatedID:         uuid.New().String(),
 		CreatedAt:         message.CreatedAt,
 	}
@@ -77,20 +82,68 @@ func (r *messageRepository) FindMessagesByChatID(ctx context.Context, chatUUID s
 		messageUUIDs[i] = orm.UUID
 	}
 
+	forksMap, err := findForks(db.WithContext(ctx).Where("chats.source_message_uuid IN ?", messageUUIDs))
+	if err != nil {
+		return nil, err
+	}
+	return toMessagesWithForks(orms, forksMap), nil
+}
+
+// プロジェクト内のすべてのチャットのメッセージをフォークと合わせて取得する
+// チャットの数によらず、メッセージとフォークの2回のクエリで取得する
+func (r *messageRepository) FindMessagesByProjectUUID(ctx context.Context, projectUUID string) ([]*model.Message, error) {
+	slog.DebugContext(ctx, "プロジェクト内メッセージ一括取得処理を開始", "project_uuid", projectUUID)
+	return r.findProjectMessages(ctx, projectUUID, "messages.*")
+}
+
+// プロジェクト内のすべてのメッセージを、本文を除いてフォークと合わせて取得する
+// ノードの位置の計算にはツリーの構造だけが必要なため、本文や要約は読み込まない
+func (r *messageRepository) FindMessageStructureByProjectUUID(ctx context.Context, projectUUID string) ([]*model.Message, error) {
+	slog.DebugContext(ctx, "プロジェクト内メッセージ構造取得処理を開始", "project_uuid", projectUUID)
+	return r.findProjectMessages(ctx, projectUUID, "messages.uuid, messages.chat_uuid, messages.role, messages.created_at")
+}
+
+// プロジェクト内のメッセージを指定したカラムだけフォークと合わせて取得する処理
+func (r *messageRepository) findProjectMessages(ctx context.Context, projectUUID string, columns string) ([]*model.Message, error) {
+	var orms []messageORM
+	db := getDB(ctx, r.db)
+	if err := db.WithContext(ctx).
+		Select(columns).
+		Joins("JOIN chats ON chats.uuid = messages.chat_uuid").
+		Where("chats.project_uuid = ?", projectUUID).
+		Order("messages.created_at asc").
+		Find(&orms).Error; err != nil {
+		return nil, err
+	}
+
+	if len(orms) == 0 {
+		return []*model.Message{}, nil
+	}
+
+	forksMap, err := findForks(db.WithContext(ctx).Where("chats.project_uuid = ? AND chats.source_message_uuid IS NOT NULL", projectUUID))
+	if err != nil {
+		return nil, err
+	}
+	return toMessagesWithForks(orms, forksMap), nil
+}
+
+// 条件に一致するフォークしたチャットを起点メッセージのUUIDごとに取得する処理
+func findForks(db *gorm.DB) (map[string][]model.Fork, error) {
 	type forkResult struct {
 		ChatUUID          string
 		SourceMessageUUID string
-		SelectedText      string
-		RangeStart        int
-		RangeEnd          int
+		SelectedText      *string
+		RangeStart        *int
+		RangeEnd          *int
 	}
 
 	var forkResults []forkResult
-	// chats テーブルと message_selections テーブルを結合して、指定されたメッセージUUIDのフォックスを取得する
-	err := db.WithContext(ctx).Table("chats").
+	// chats テーブルと message_selections テーブルを結合して、フォークしたチャットと選択範囲を取得する
+	// 範囲を選択せずにフォークしたチャットも含めるため外部結合にする
+	err := db.Table("chats").
 		Select("chats.uuid as chat_uuid, chats.source_message_uuid, ms.selected_text, ms.range_start, ms.range_end").
-		Joins("JOIN message_selections ms ON chats.message_selection_uuid = ms.uuid").
-		Where("chats.source_message_uuid IN ?", messageUUIDs).
+		Joins("LEFT JOIN message_selections ms ON chats.message_selection_uuid = ms.uuid").
+		Order("chats.created_at asc").
 		Scan(&forkResults).Error
 	if err != nil {
 		return nil, err
@@ -98,14 +151,26 @@ func (r *messageRepository) FindMessagesByChatID(ctx context.Context, chatUUID s
 
 	forksMap := make(map[string][]model.Fork)
 	for _, res := range forkResults {
-		forksMap[res.SourceMessageUUID] = append(forksMap[res.SourceMessageUUID], model.Fork{
+		fork := model.Fork{
 			ChatUUID:     res.ChatUUID,
-			SelectedText: res.SelectedText,
-			RangeStart:   res.RangeStart,
-			RangeEnd:     res.RangeEnd,
-		})
+			WholeMessage: res.SelectedText == nil,
+		}
+		if res.SelectedText != nil {
+			fork.SelectedText = *res.SelectedText
+		}
+		if res.RangeStart != nil {
+			fork.RangeStart = *res.RangeStart
+		}
+		if res.RangeEnd != nil {
+			fork.RangeEnd = *res.RangeEnd
+		}
+		forksMap[res.SourceMessageUUID] = append(forksMap[res.SourceMessageUUID], fork)
 	}
+	return forksMap, nil
+}
 
+// メッセージのORMにフォークを合わせてドメインモデルに変換する処理
+func toMessagesWithForks(orms []messageORM, forksMap map[string][]model.Fork) []*model.Message {
 	var messages []*model.Message
 	for _, orm := range orms {
 		messages = append(messages, &model.Message{
@@ -114,21 +179,25 @@ func (r *messageRepository) FindMessagesByChatID(ctx context.Context, chatUUID s
 			ParentMessageUUID: orm.ParentMessageUUID,
 			Role:              orm.Role,
 			Content:           orm.Content,
+			PromptVersion:     orm.PromptVersion,
 			SourceChatUUID:    orm.SourceChatUUID,
-			PositionX:         orm.PositionX,
-			PositionY:         orm.PositionY,
+			OriginMessageUUID: orm.OriginMessageUUID,
+			OriginChatUUID:    orm.OriginChatUUID,
 			Forks:             forksMap[orm.UUID],
 			CreatedAt:         orm.CreatedAt,
 		})
 	}
-	return messages, nil
+	return messages
 }
 
-// メッセージのコンテキストサマリを更新する
-func (r *messageRepository) UpdateContextSummary(ctx context.Context, messageUUID string, summary string) error {
-	slog.DebugContext(ctx, "コンテキストサマリ更新処理を開始", "message_uuid", messageUUID)
+// メッセージのコンテキストサマリと生成に使用したプロンプトのバージョンを更新する
+func (r *messageRepository) UpdateContextSummary(ctx context.Context, messageUUID string, summary string, promptVersion string) error {
+	slog.DebugContext(ctx, "コンテキストサマリ更新処理を開始", "message_uuid", messageUUID, "prompt_version", promptVersion)
 	db := getDB(ctx, r.db)
-	return db.WithContext(ctx).Model(&messageORM{}).Where("uuid = ?", messageUUID).Update("context_summary", summary).Error
+	return db.WithContext(ctx).Model(&messageORM{}).Where("uuid = ?", messageUUID).Updates(map[string]interface{}{
+		"context_summary":        summary,
+		"summary_prompt_version": promptVersion,
+	}).Error
 }
 
 // 指定されたチャットIDの中で、コンテキストサマリを持つ最新のメッセージを取得する
@@ -151,16 +220,18 @@ func (r *messageRepository) FindLatestMessageWithSummary(ctx context.Context, ch
 	}
 
 	return &model.Message{
-		UUID:              orm.UUID,
-		ChatUUID:          orm.ChatUUID,
-		ParentMessageUUID: orm.ParentMessageUUID,
-		Role:              orm.Role,
-		Content:           orm.Content,
-		ContextSummary:    orm.ContextSummary,
-		SourceChatUUID:    orm.SourceChatUUID,
-		PositionX:         orm.PositionX,
-		PositionY:         orm.PositionY,
-		CreatedAt:         orm.CreatedAt,
+		UUID:                 orm.UUID,
+		ChatUUID:             orm.ChatUUID,
+		ParentMessageUUID:    orm.ParentMessageUUID,
+		Role:                 orm.Role,
+		Content:              orm.Content,
+		ContextSummary:       orm.ContextSummary,
+		SummaryPromptVersion: orm.SummaryPromptVersion,
+		PromptVersion:        orm.PromptVersion,
+		SourceChatUUID:       orm.SourceChatUUID,
+		OriginMessageUUID:    orm.OriginMessageUUID,
+		OriginChatUUID:       orm.OriginChatUUID,
+		CreatedAt:            orm.CreatedAt,
 	}, nil
 }
 
@@ -183,16 +254,18 @@ func (r *messageRepository) FindLatestMessageByRole(ctx context.Context, chatUUI
 	}
 
 	return &model.Message{
-		UUID:              orm.UUID,
-		ChatUUID:          orm.ChatUUID,
-		ParentMessageUUID: orm.ParentMessageUUID,
-		Role:              orm.Role,
-		Content:           orm.Content,
-		ContextSummary:    orm.ContextSummary,
-		SourceChatUUID:    orm.SourceChatUUID,
-		PositionX:         orm.PositionX,
-		PositionY:         orm.PositionY,
-		CreatedAt:         orm.CreatedAt,
+		UUID:                 orm.UUID,
+		ChatUUID:             orm.ChatUUID,
+		ParentMessageUUID:    orm.ParentMessageUUID,
+		Role:                 orm.Role,
+		Content:              orm.Content,
+		ContextSummary:       orm.ContextSummary,
+		SummaryPromptVersion: orm.SummaryPromptVersion,
+		PromptVersion:        orm.PromptVersion,
+		SourceChatUUID:       orm.SourceChatUUID,
+		OriginMessageUUID:    orm.OriginMessageUUID,
+		OriginChatUUID:       orm.OriginChatUUID,
+		CreatedAt:            orm.CreatedAt,
 	}, nil
 }
 
@@ -211,15 +284,31 @@ func (r *messageRepository) FindByID(ctx context.Context, uuid string) (*model.M
 	}
 
 	return &model.Message{
-		UUID:              orm.UUID,
-		ChatUUID:          orm.ChatUUID,
-		ParentMessageUUID: orm.ParentMessageUUID,
-		Role:              orm.Role,
-		Content:           orm.Content,
-		ContextSummary:    orm.ContextSummary,
-		SourceChatUUID:    orm.SourceChatUUID,
-		PositionX:         orm.PositionX,
-		PositionY:         orm.PositionY,
-		CreatedAt:         orm.CreatedAt,
+		UUID:                 orm.UUID,
+		ChatUUID:             orm.ChatUUID,
+		ParentMessageUUID:    orm.ParentMessageUUID,
+		Role:                 orm.Role,
+		Content:              orm.Content,
+		ContextSummary:       orm.ContextSummary,
+		SummaryPromptVersion: orm.SummaryPromptVersion,
+		PromptVersion:        orm.PromptVersion,
+		SourceChatUUID:       orm.SourceChatUUID,
+		OriginMessageUUID:    orm.OriginMessageUUID,
+		OriginChatUUID:       orm.OriginChatUUID,
+		CreatedAt:            orm.CreatedAt,
 	}, nil
 }
+
+// 指定されたUUIDのメッセージを論理削除する
+func (r *messageRepository) Delete(ctx context.Context, uuid string) error {
+	slog.DebugContext(ctx, "メッセージ削除処理を開始", "uuid", uuid)
+	db := getDB(ctx, r.db)
+	return db.WithContext(ctx).Where("uuid = ?", uuid).Delete(&messageORM{}).Error
+}
+
+// メッセージの本文を更新する
+func (r *messageRepository) UpdateContent(ctx context.Context, messageUUID string, content string) error {
+	slog.DebugContext(ctx, "メッセージ本文更新処理を開始", "message_uuid", messageUUID)
+	db := getDB(ctx, r.db)
+	return db.WithContext(ctx).Model(&messageORM{}).Where("uuid = ?", messageUUID).Update("content", content).Error
+}
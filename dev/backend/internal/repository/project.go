@@ -15,6 +15,7 @@ type projectORM struct {
 	UUID      string    `gorm:"primaryKey;column:uuid;size:36"`
 	UserUUID  string    `gorm:"column:user_uuid;size:36"`
 	Title     string    `gorm:"column:title;size:255"`
+	Language  *string   `gorm:"column:language;size:10"`
 	CreatedID string    `gorm:"column:created_id;size:255"`
 	CreatedAt time.Time `gorm:"column:created_at"`
 	UpdatedAt time.Time `gorm:"column:updated_at"`
@@ -27,10 +28,16 @@ func (projectORM) TableName() string {
 
 // projectORMをドメインモデルに変換する処理
 func (orm *projectORM) toDomain() *model.Project {
+	var language string
+	if orm.Language != nil {
+		language = *orm.Language
+	}
+
 	return &model.Project{
 		UUID:      orm.UUID,
 		UserUUID:  orm.UserUUID,
 		Title:     orm.Title,
+		Language:  language,
 		CreatedAt: orm.CreatedAt,
 		UpdatedAt: orm.UpdatedAt,
 	}
@@ -66,10 +73,16 @@ func (r *projectRepository) FindAllByUserUUID(ctx context.Context, userUUID stri
 // プロジェクトを作成する処理
 func (r *projectRepository) Create(ctx context.Context, project *model.Project) error {
 	slog.DebugContext(ctx, "プロジェクト作成処理を開始", "project_uuid", project.UUID)
+	var language *string
+	if project.Language != "" {
+		language = &project.Language
+	}
+
 	orm := projectORM{
 		UUID:      project.UUID,
 		UserUUID:  project.UserUUID,
 		Title:     project.Title,
+		Language:  language,
 		CreatedID: project.UserUUID,
 		CreatedAt: project.CreatedAt,
 		UpdatedAt: project.UpdatedAt,
@@ -77,3 +90,32 @@ func (r *projectRepository) Create(ctx context.Context, project *model.Project)
 	db := getDB(ctx, r.db)
 	return db.WithContext(ctx).Create(&orm).Error
 }
+
+// 指定されたUUIDのプロジェクトを取得する処理
+func (r *projectRepository) FindByUUID(ctx context.Context, projectUUID string) (*model.Project, error) {
+	slog.DebugContext(ctx, "プロジェクト取得処理を開始", "project_uuid", projectUUID)
+	var orm projectORM
+	db := getDB(ctx, r.db)
+	if err := db.WithContext(ctx).Where("uuid = ?", projectUUID).First(&orm).Error; err != nil {
+		return nil, err
+	}
+	return orm.toDomain(), nil
+}
+
+// プロジェクトの出力言語を更新する処理
+func (r *projectRepository) UpdateLanguage(ctx context.Context, projectUUID string, language string) error {
+	slog.DebugContext(ctx, "プロジェクト出力言語更新処理を開始", "project_uuid", projectUUID, "language", language)
+	var value *string
+	if language != "" {
+		value = &language
+	}
+	db := getDB(ctx, r.db)
+	return db.WithContext(ctx).Model(&projectORM{}).Where("uuid = ?", projectUUID).Update("language", value).Error
+}
+
+// 指定したユーザーの全プロジェクトの所有者を別のユーザーに付け替える処理
+func (r *projectRepository) ReassignUser(ctx context.Context, fromUserUUID string, toUserUUID string) error {
+	slog.DebugContext(ctx, "プロジェクト所有者付け替え処理を開始", "from_user_uuid", fromUserUUID, "to_user_uuid", toUserUUID)
+	db := getDB(ctx, r.db)
+	return db.WithContext(ctx).Model(&projectORM{}).Where("user_uuid = ?", fromUserUUID).Update("user_uuid", toUserUUID).Error
+}
@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"backend/internal/domain/model"
+	"backend/internal/domain/repository"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// project_memberのORMモデル
+type projectMemberORM struct {
+	ProjectUUID       string    `gorm:"primaryKey;column:project_uuid;size:255"`
+	UserUUID          string    `gorm:"primaryKey;column:user_uuid;size:255"`
+	Role              string    `gorm:"column:role;size:50;default:viewer"`
+	Status            string    `gorm:"column:status;size:50;default:pending"`
+	InvitedByUserUUID string    `gorm:"column:invited_by_user_uuid;size:255"`
+	CreatedAt         time.Time `gorm:"column:created_at"`
+	UpdatedAt         time.Time `gorm:"column:updated_at"`
+}
+
+// projectMemberORMのテーブル名
+func (projectMemberORM) TableName() string {
+	return "project_members"
+}
+
+// projectMemberORMをドメインモデルに変換する処理
+func (orm *projectMemberORM) toDomain() *model.ProjectMember {
+	return &model.ProjectMember{
+		ProjectUUID:       orm.ProjectUUID,
+		UserUUID:          orm.UserUUID,
+		Role:              orm.Role,
+		Status:            orm.Status,
+		InvitedByUserUUID: orm.InvitedByUserUUID,
+		CreatedAt:         orm.CreatedAt,
+		UpdatedAt:         orm.UpdatedAt,
+	}
+}
+
+// メンバー一覧取得時にユーザー名を結合した行
+type projectMemberWithUserRow struct {
+	ProjectUUID       string    `gorm:"column:project_uuid"`
+	UserUUID          string    `gorm:"column:user_uuid"`
+	UserName          *string   `gorm:"column:user_name"`
+	UserAvatarURL     *string   `gorm:"column:user_avatar_url"`
+	Role              string    `gorm:"column:role"`
+	Status            string    `gorm:"column:status"`
+	InvitedByUserUUID string    `gorm:"column:invited_by_user_uuid"`
+	CreatedAt         time.Time `gorm:"column:created_at"`
+	UpdatedAt         time.Time `gorm:"column:updated_at"`
+}
+
+// 共有プロジェクト取得時にプロジェクトを結合した行
+type sharedProjectRow struct {
+	ProjectUUID       string    `gorm:"column:project_uuid"`
+	OwnerUUID         string    `gorm:"column:owner_uuid"`
+	Title             string    `gorm:"column:title"`
+	Language          *string   `gorm:"column:language"`
+	ProjectCreatedAt  time.Time `gorm:"column:project_created_at"`
+	ProjectUpdatedAt  time.Time `gorm:"column:project_updated_at"`
+	MemberRole        string    `gorm:"column:member_role"`
+	MemberStatus      string    `gorm:"column:member_status"`
+	InvitedByUserUUID string    `gorm:"column:invited_by_user_uuid"`
+	InvitedAt         time.Time `gorm:"column:invited_at"`
+}
+
+type projectMemberRepository struct {
+	db *gorm.DB
+}
+
+// projectMemberRepositoryの新しいインスタンスを作成する処理
+func NewProjectMemberRepository(db *gorm.DB) repository.ProjectMemberRepository {
+	return &projectMemberRepository{db: db}
+}
+
+// メンバー（招待）を作成する処理
+func (r *projectMemberRepository) Create(ctx context.Context, member *model.ProjectMember) error {
+	slog.DebugContext(ctx, "プロジェクトメンバー作成処理を開始", "project_uuid", member.ProjectUUID, "user_uuid", member.UserUUID)
+	orm := projectMemberORM{
+		ProjectUUID:       member.ProjectUUID,
+		UserUUID:          member.UserUUID,
+		Role:              member.Role,
+		Status:            member.Status,
+		InvitedByUserUUID: member.InvitedByUserUUID,
+		CreatedAt:         member.CreatedAt,
+		UpdatedAt:         member.UpdatedAt,
+	}
+	return getDB(ctx, r.db).WithContext(ctx).Create(&orm).Error
+}
+
+// プロジェクトとユーザーでメンバーを取得する処理
+func (r *projectMemberRepository) Find(ctx context.Context, projectUUID string, userUUID string) (*model.ProjectMember, error) {
+	slog.DebugContext(ctx, "プロジェクトメンバー取得処理を開始", "project_uuid", projectUUID, "user_uuid", userUUID)
+	var orm projectMemberORM
+	err := getDB(ctx, r.db).WithContext(ctx).
+		Where("project_uuid = ? AND user_uuid = ?", projectUUID, userUUID).
+		First(&orm).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return orm.toDomain(), nil
+}
+
+// ユーザーのメンバー（招待）をすべての状態で取得する処理
+func (r *projectMemberRepository) FindByUserUUID(ctx context.Context, userUUID string) ([]*model.ProjectMember, error) {
+	slog.DebugContext(ctx, "ユーザーのプロジェクトメンバー取得処理を開始", "user_uuid", userUUID)
+	var orms []projectMemberORM
+	err := getDB(ctx, r.db).WithContext(ctx).
+		Where("user_uuid = ?", userUUID).
+		Order("created_at asc").
+		Find(&orms).Error
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*model.ProjectMember, 0, len(orms))
+	for i := range orms {
+		members = append(members, orms[i].toDomain())
+	}
+	return members, nil
+}
+
+// プロジェクトのメンバー一覧を取得する処理
+func (r *projectMemberRepository) FindByProjectUUID(ctx context.Context, projectUUID string) ([]*model.ProjectMember, error) {
+	slog.DebugContext(ctx, "プロジェクトメンバー一覧取得処理を開始", "project_uuid", projectUUID)
+	var rows []projectMemberWithUserRow
+	err := getDB(ctx, r.db).WithContext(ctx).
+		Table("project_members").
+		Select("project_members.*, users.name AS user_name, users.avatar_url AS user_avatar_url").
+		Joins("LEFT JOIN users ON users.uuid = project_members.user_uuid").
+		Where("project_members.project_uuid = ?", projectUUID).
+		Order("project_members.created_at asc").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*model.ProjectMember, 0, len(rows))
+	for _, row := range rows {
+		member := (&projectMemberORM{
+			ProjectUUID:       row.ProjectUUID,
+			UserUUID:          row.UserUUID,
+			Role:              row.Role,
+			Status:            row.Status,
+			InvitedByUserUUID: row.InvitedByUserUUID,
+			CreatedAt:         row.CreatedAt,
+			UpdatedAt:         row.UpdatedAt,
+		}).toDomain()
+		if row.UserName != nil {
+			member.UserName = *row.UserName
+		}
+		if row.UserAvatarURL != nil {
+			member.UserAvatarURL = *row.UserAvatarURL
+		}
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+// ユーザーに共有されたプロジェクトを指定した状態で取得する処理
+func (r *projectMemberRepository) FindSharedByUserUUID(ctx context.Context, userUUID string, status string) ([]*model.SharedProject, error) {
+	slog.DebugContext(ctx, "共有プロジェクト一覧取得処理を開始", "user_uuid", userUUID, "status", status)
+	var rows []sharedProjectRow
+	err := getDB(ctx, r.db).WithContext(ctx).
+		Table("project_members").
+		Select("projects.uuid AS project_uuid, projects.user_uuid AS owner_uuid, projects.title, projects.language, projects.created_at AS project_created_at, projects.updated_at AS project_updated_at, "+
+			"project_members.role AS member_role, project_members.status AS member_status, project_members.invited_by_user_uuid, project_members.created_at AS invited_at").
+		Joins("JOIN projects ON projects.uuid = project_members.project_uuid").
+		Where("project_members.user_uuid = ? AND project_members.status = ?", userUUID, status).
+		Order("projects.updated_at desc").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	projects := make([]*model.SharedProject, 0, len(rows))
+	for i := range rows {
+		projects = append(projects, &model.SharedProject{
+			Project: (&projectORM{
+				UUID:      rows[i].ProjectUUID,
+				UserUUID:  rows[i].OwnerUUID,
+				Title:     rows[i].Title,
+				Language:  rows[i].Language,
+				CreatedAt: rows[i].ProjectCreatedAt,
+				UpdatedAt: rows[i].ProjectUpdatedAt,
+			}).toDomain(),
+			Role:              rows[i].MemberRole,
+			Status:            rows[i].MemberStatus,
+			InvitedByUserUUID: rows[i].InvitedByUserUUID,
+			InvitedAt:         rows[i].InvitedAt,
+		})
+	}
+	return projects, nil
+}
+
+// メンバーのロールを更新する処理
+func (r *projectMemberRepository) UpdateRole(ctx context.Context, projectUUID string, userUUID string, role string) error {
+	slog.DebugContext(ctx, "プロジェクトメンバーのロール更新処理を開始", "project_uuid", projectUUID, "user_uuid", userUUID, "role", role)
+	return getDB(ctx, r.db).WithContext(ctx).Model(&projectMemberORM{}).
+		Where("project_uuid = ? AND user_uuid = ?", projectUUID, userUUID).
+		Update("role", role).Error
+}
+
+// 招待中のメンバーを承認済みにする処理
+func (r *projectMemberRepository) Accept(ctx context.Context, projectUUID string, userUUID string) (bool, error) {
+	slog.DebugContext(ctx, "プロジェクト招待承認処理を開始", "project_uuid", projectUUID, "user_uuid", userUUID)
+	result := getDB(ctx, r.db).WithContext(ctx).Model(&projectMemberORM{}).
+		Where("project_uuid = ? AND user_uuid = ? AND status = ?", projectUUID, userUUID, model.ProjectMemberStatusPending).
+		Update("status", model.ProjectMemberStatusAccepted)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// メンバー（招待）を削除する処理
+func (r *projectMemberRepository) Delete(ctx context.Context, projectUUID string, userUUID string) (bool, error) {
+	slog.DebugContext(ctx, "プロジェクトメンバー削除処理を開始", "project_uuid", projectUUID, "user_uuid", userUUID)
+	result := getDB(ctx, r.db).WithContext(ctx).
+		Where("project_uuid = ? AND user_uuid = ?", projectUUID, userUUID).
+		Delete(&projectMemberORM{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"backend/internal/domain/model"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// テスト用のプロジェクトメンバーリポジトリを作成する処理
+func setupProjectMemberRepository(t *testing.T) (*gorm.DB, *projectMemberRepository) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	if err := db.AutoMigrate(&userORM{}, &projectORM{}, &projectMemberORM{}); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+	now := time.Now()
+	db.Create(&userORM{ID: "owner-1", Name: "Owner"})
+	db.Create(&userORM{ID: "user-1", Name: "Alice", AvatarURL: strPtr("https://example.com/alice.png")})
+	db.Create(&userORM{ID: "user-2", Name: "Bob"})
+	db.Create(&projectORM{UUID: "project-1", UserUUID: "owner-1", Title: "Project 1", CreatedAt: now, UpdatedAt: now})
+	db.Create(&projectORM{UUID: "project-2", UserUUID: "owner-1", Title: "Project 2", CreatedAt: now, UpdatedAt: now.Add(time.Minute)})
+	return db, &projectMemberRepository{db: db}
+}
+
+// テスト用のメンバーを作成する処理
+func createTestProjectMember(t *testing.T, r *projectMemberRepository, projectUUID, userUUID, role, status string, createdAt time.Time) {
+	t.Helper()
+	err := r.Create(context.Background(), &model.ProjectMember{
+		ProjectUUID:       projectUUID,
+		UserUUID:          userUUID,
+		Role:              role,
+		Status:            status,
+		InvitedByUserUUID: "owner-1",
+		CreatedAt:         createdAt,
+		UpdatedAt:         createdAt,
+	})
+	assert.NoError(t, err)
+}
+
+func TestProjectMemberRepository_Find(t *testing.T) {
+	_, r := setupProjectMemberRepository(t)
+	createTestProjectMember(t, r, "project-1", "user-1", model.ProjectRoleEditor, model.ProjectMemberStatusAccepted, time.Now())
+
+	tests := []struct {
+		name     string
+		userUUID string
+		wantRole string
+	}{
+		{name: "正常系: メンバーが取得できること", userUUID: "user-1", wantRole: model.ProjectRoleEditor},
+		{name: "正常系: メンバーでない場合は nil が返ること", userUUID: "user-2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.Find(context.Background(), "project-1", tt.userUUID)
+			assert.NoError(t, err)
+			if tt.wantRole == "" {
+				assert.Nil(t, got)
+				return
+			}
+			assert.Equal(t, tt.wantRole, got.Role)
+			assert.Equal(t, "owner-1", got.InvitedByUserUUID)
+		})
+	}
+}
+
+func TestProjectMemberRepository_FindByProjectUUID(t *testing.T) {
+	_, r := setupProjectMemberRepository(t)
+	now := time.Now()
+	createTestProjectMember(t, r, "project-1", "user-2", model.ProjectRoleViewer, model.ProjectMemberStatusPending, now.Add(time.Minute))
+	createTestProjectMember(t, r, "project-1", "user-1", model.ProjectRoleEditor, model.ProjectMemberStatusAccepted, now)
+	createTestProjectMember(t, r, "project-2", "user-1", model.ProjectRoleViewer, model.ProjectMemberStatusAccepted, now)
+
+	members, err := r.FindByProjectUUID(context.Background(), "project-1")
+	assert.NoError(t, err)
+	if assert.Len(t, members, 2) {
+		assert.Equal(t, "user-1", members[0].UserUUID)
+		assert.Equal(t, "Alice", members[0].UserName)
+		assert.Equal(t, "https://example.com/alice.png", members[0].UserAvatarURL)
+		assert.Empty(t, members[1].UserAvatarURL)
+		assert.Equal(t, "user-2", members[1].UserUUID)
+		assert.Equal(t, model.ProjectMemberStatusPending, members[1].Status)
+	}
+}
+
+func TestProjectMemberRepository_FindByUserUUID(t *testing.T) {
+	_, r := setupProjectMemberRepository(t)
+	now := time.Now()
+	createTestProjectMember(t, r, "project-2", "user-1", model.ProjectRoleViewer, model.ProjectMemberStatusPending, now.Add(time.Minute))
+	createTestProjectMember(t, r, "project-1", "user-1", model.ProjectRoleEditor, model.ProjectMemberStatusAccepted, now)
+	createTestProjectMember(t, r, "project-1", "user-2", model.ProjectRoleViewer, model.ProjectMemberStatusAccepted, now)
+
+	// 正常系: 招待中を含むユーザーのメンバーが招待日時の古い順に取得できること
+	members, err := r.FindByUserUUID(context.Background(), "user-1")
+	assert.NoError(t, err)
+	if assert.Len(t, members, 2) {
+		assert.Equal(t, "project-1", members[0].ProjectUUID)
+		assert.Equal(t, model.ProjectRoleEditor, members[0].Role)
+		assert.Equal(t, "project-2", members[1].ProjectUUID)
+		assert.Equal(t, model.ProjectMemberStatusPending, members[1].Status)
+	}
+}
+
+func TestProjectMemberRepository_FindSharedByUserUUID(t *testing.T) {
+	_, r := setupProjectMemberRepository(t)
+	now := time.Now()
+	createTestProjectMember(t, r, "project-1", "user-1", model.ProjectRoleEditor, model.ProjectMemberStatusAccepted, now)
+	createTestProjectMember(t, r, "project-2", "user-1", model.ProjectRoleViewer, model.ProjectMemberStatusPending, now)
+
+	tests := []struct {
+		name        string
+		status      string
+		wantProject string
+		wantRole    string
+	}{
+		{name: "正常系: 承認済みの共有プロジェクトが取得できること", status: model.ProjectMemberStatusAccepted, wantProject: "project-1", wantRole: model.ProjectRoleEditor},
+		{name: "正常系: 招待中のプロジェクトが取得できること", status: model.ProjectMemberStatusPending, wantProject: "project-2", wantRole: model.ProjectRoleViewer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.FindSharedByUserUUID(context.Background(), "user-1", tt.status)
+			assert.NoError(t, err)
+			if assert.Len(t, got, 1) {
+				assert.Equal(t, tt.wantProject, got[0].Project.UUID)
+				assert.Equal(t, "owner-1", got[0].Project.UserUUID)
+				assert.Equal(t, tt.wantRole, got[0].Role)
+				assert.Equal(t, tt.status, got[0].Status)
+			}
+		})
+	}
+}
+
+func TestProjectMemberRepository_AcceptUpdateRoleDelete(t *testing.T) {
+	_, r := setupProjectMemberRepository(t)
+	ctx := context.Background()
+	createTestProjectMember(t, r, "project-1", "user-1", model.ProjectRoleViewer, model.ProjectMemberStatusPending, time.Now())
+
+	accepted, err := r.Accept(ctx, "project-1", "user-1")
+	assert.NoError(t, err)
+	assert.True(t, accepted)
+
+	// 承認済みの招待は再度承認できない
+	accepted, err = r.Accept(ctx, "project-1", "user-1")
+	assert.NoError(t, err)
+	assert.False(t, accepted)
+
+	err = r.UpdateRole(ctx, "project-1", "user-1", model.ProjectRoleEditor)
+	assert.NoError(t, err)
+
+	member, err := r.Find(ctx, "project-1", "user-1")
+	assert.NoError(t, err)
+	assert.Equal(t, model.ProjectRoleEditor, member.Role)
+	assert.Equal(t, model.ProjectMemberStatusAccepted, member.Status)
+
+	deleted, err := r.Delete(ctx, "project-1", "user-1")
+	assert.NoError(t, err)
+	assert.True(t, deleted)
+
+	deleted, err = r.Delete(ctx, "project-1", "user-1")
+	assert.NoError(t, err)
+	assert.False(t, deleted)
+}
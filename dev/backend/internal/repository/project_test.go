@@ -163,3 +163,36 @@ func TestProjectRepository_Create(t *testing.T) {
 		})
 	}
 }
+
+func TestProjectRepository_ReassignUser(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	if err := db.AutoMigrate(&projectORM{}); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+
+	projects := []projectORM{
+		{UUID: "p1", UserUUID: "guest-user", Title: "Project 1", UpdatedAt: time.Now()},
+		{UUID: "p2", UserUUID: "guest-user", Title: "Project 2", UpdatedAt: time.Now()},
+		{UUID: "p3", UserUUID: "other-user", Title: "Project 3", UpdatedAt: time.Now()},
+	}
+	db.Create(&projects)
+
+	r := NewProjectRepository(db)
+	err = r.ReassignUser(context.Background(), "guest-user", "google-user")
+	assert.NoError(t, err)
+
+	got, err := r.FindAllByUserUUID(context.Background(), "google-user")
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+
+	remaining, err := r.FindAllByUserUUID(context.Background(), "guest-user")
+	assert.NoError(t, err)
+	assert.Empty(t, remaining)
+
+	other, err := r.FindAllByUserUUID(context.Background(), "other-user")
+	assert.NoError(t, err)
+	assert.Len(t, other, 1)
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"backend/internal/domain/model"
+	"backend/internal/domain/repository"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type sessionORM struct {
+	UUID              string     `gorm:"primaryKey;column:uuid;size:255"`
+	UserUUID          string     `gorm:"column:user_uuid;size:255"`
+	RefreshTokenHash  string     `gorm:"column:refresh_token_hash;size:64;uniqueIndex"`
+	PreviousTokenHash *string    `gorm:"column:previous_token_hash;size:64"`
+	UserAgent         *string    `gorm:"column:user_agent;size:512"`
+	IPAddress         *string    `gorm:"column:ip_address;size:45"`
+	ExpiresAt         time.Time  `gorm:"column:expires_at"`
+	LastUsedAt        time.Time  `gorm:"column:last_used_at"`
+	RevokedAt         *time.Time `gorm:"column:revoked_at"`
+	CreatedAt         time.Time  `gorm:"column:created_at"`
+	UpdatedAt         time.Time  `gorm:"column:updated_at"`
+}
+
+func (sessionORM) TableName() string {
+	return "sessions"
+}
+
+func (o *sessionORM) toDomain() *model.Session {
+	session := &model.Session{
+		UUID:       o.UUID,
+		UserUUID:   o.UserUUID,
+		ExpiresAt:  o.ExpiresAt,
+		LastUsedAt: o.LastUsedAt,
+		CreatedAt:  o.CreatedAt,
+		RevokedAt:  o.RevokedAt,
+	}
+	if o.UserAgent != nil {
+		session.UserAgent = *o.UserAgent
+	}
+	if o.IPAddress != nil {
+		session.IPAddress = *o.IPAddress
+	}
+	return session
+}
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) repository.SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+// セッションを作成する
+func (r *sessionRepository) Create(ctx context.Context, session *model.Session, refreshTokenHash string) error {
+	slog.DebugContext(ctx, "セッション作成処理を開始", "session_uuid", session.UUID, "user_uuid", session.UserUUID)
+	orm := sessionORM{
+		UUID:             session.UUID,
+		UserUUID:         session.UserUUID,
+		RefreshTokenHash: refreshTokenHash,
+		ExpiresAt:        session.ExpiresAt,
+		LastUsedAt:       session.LastUsedAt,
+		CreatedAt:        session.CreatedAt,
+	}
+	if session.UserAgent != "" {
+		orm.UserAgent = &session.UserAgent
+	}
+	if session.IPAddress != "" {
+		orm.IPAddress = &session.IPAddress
+	}
+	return getDB(ctx, r.db).WithContext(ctx).Create(&orm).Error
+}
+
+// 現在のリフレッシュトークンのハッシュに一致するセッションを取得する
+func (r *sessionRepository) FindByRefreshTokenHash(ctx context.Context, tokenHash string) (*model.Session, error) {
+	slog.DebugContext(ctx, "セッション取得処理を開始")
+	return r.findOne(ctx, "refresh_token_hash = ?", tokenHash)
+}
+
+// UUIDでセッションを取得する
+func (r *sessionRepository) FindByUUID(ctx context.Context, sessionUUID string) (*model.Session, error) {
+	slog.DebugContext(ctx, "セッション取得処理を開始", "session_uuid", sessionUUID)
+	return r.findOne(ctx, "uuid = ?", sessionUUID)
+}
+
+// ローテーション前のリフレッシュトークンのハッシュに一致するセッションを取得する
+func (r *sessionRepository) FindByPreviousTokenHash(ctx context.Context, tokenHash string) (*model.Session, error) {
+	slog.DebugContext(ctx, "ローテーション前トークンのセッション取得処理を開始")
+	return r.findOne(ctx, "previous_token_hash = ?", tokenHash)
+}
+
+func (r *sessionRepository) findOne(ctx context.Context, query string, args ...interface{}) (*model.Session, error) {
+	var orm sessionORM
+	err := getDB(ctx, r.db).WithContext(ctx).Where(query, args...).First(&orm).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return orm.toDomain(), nil
+}
+
+// 指定されたユーザーの有効なセッションを取得する
+func (r *sessionRepository) FindActiveByUserUUID(ctx context.Context, userUUID string, now time.Time) ([]*model.Session, error) {
+	slog.DebugContext(ctx, "有効なセッション一覧取得処理を開始", "user_uuid", userUUID)
+	var orms []sessionORM
+	err := getDB(ctx, r.db).WithContext(ctx).
+		Where("user_uuid = ? AND revoked_at IS NULL AND expires_at > ?", userUUID, now).
+		Order("last_used_at desc").
+		Find(&orms).Error
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*model.Session, 0, len(orms))
+	for i := range orms {
+		sessions = append(sessions, orms[i].toDomain())
+	}
+	return sessions, nil
+}
+
+// リフレッシュトークンをローテーションする
+// 同じトークンで同時に更新された場合に二重発行しないよう、現在のハッシュを条件に更新する
+func (r *sessionRepository) Rotate(ctx context.Context, sessionUUID string, oldHash string, newHash string, expiresAt time.Time, usedAt time.Time) (bool, error) {
+	slog.DebugContext(ctx, "リフレッシュトークンのローテーション処理を開始", "session_uuid", sessionUUID)
+	result := getDB(ctx, r.db).WithContext(ctx).Model(&sessionORM{}).
+		Where("uuid = ? AND refresh_token_hash = ? AND revoked_at IS NULL", sessionUUID, oldHash).
+		Updates(map[string]interface{}{
+			"refresh_token_hash":  newHash,
+			"previous_token_hash": oldHash,
+			"expires_at":          expiresAt,
+			"last_used_at":        usedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// 指定されたユーザーのセッションを失効させる
+func (r *sessionRepository) Revoke(ctx context.Context, userUUID string, sessionUUID string, revokedAt time.Time) (bool, error) {
+	slog.DebugContext(ctx, "セッション失効処理を開始", "user_uuid", userUUID, "session_uuid", sessionUUID)
+	result := getDB(ctx, r.db).WithContext(ctx).Model(&sessionORM{}).
+		Where("uuid = ? AND user_uuid = ? AND revoked_at IS NULL", sessionUUID, userUUID).
+		Update("revoked_at", revokedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
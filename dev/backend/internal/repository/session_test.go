@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"backend/internal/domain/model"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// テスト用のセッションリポジトリを作成する処理
+func setupSessionRepository(t *testing.T) (*gorm.DB, *sessionRepository) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	if err := db.AutoMigrate(&sessionORM{}); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+	return db, &sessionRepository{db: db}
+}
+
+func TestSessionRepository_CreateAndFind(t *testing.T) {
+	_, r := setupSessionRepository(t)
+	now := time.Now().Truncate(time.Second)
+
+	err := r.Create(context.Background(), &model.Session{
+		UUID:       "session-1",
+		UserUUID:   "user-1",
+		UserAgent:  "test-agent",
+		IPAddress:  "127.0.0.1",
+		ExpiresAt:  now.Add(time.Hour),
+		LastUsedAt: now,
+		CreatedAt:  now,
+	}, "hash-1")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		hash     string
+		wantUUID string
+	}{
+		{name: "正常系: ハッシュに一致するセッションが取得できること", hash: "hash-1", wantUUID: "session-1"},
+		{name: "正常系: 一致しない場合は nil が返ること", hash: "unknown", wantUUID: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.FindByRefreshTokenHash(context.Background(), tt.hash)
+			assert.NoError(t, err)
+			if tt.wantUUID == "" {
+				assert.Nil(t, got)
+				return
+			}
+			assert.Equal(t, tt.wantUUID, got.UUID)
+			assert.Equal(t, "test-agent", got.UserAgent)
+			assert.Equal(t, "127.0.0.1", got.IPAddress)
+		})
+	}
+}
+
+func TestSessionRepository_FindByUUID(t *testing.T) {
+	_, r := setupSessionRepository(t)
+	now := time.Now().Truncate(time.Second)
+	err := r.Create(context.Background(), &model.Session{
+		UUID:       "session-1",
+		UserUUID:   "user-1",
+		ExpiresAt:  now.Add(time.Hour),
+		LastUsedAt: now,
+		CreatedAt:  now,
+	}, "hash-1")
+	assert.NoError(t, err)
+
+	got, err := r.FindByUUID(context.Background(), "session-1")
+	assert.NoError(t, err)
+	if assert.NotNil(t, got) {
+		assert.Equal(t, "user-1", got.UserUUID)
+	}
+
+	got, err = r.FindByUUID(context.Background(), "unknown")
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestSessionRepository_Rotate(t *testing.T) {
+	db, r := setupSessionRepository(t)
+	now := time.Now()
+	db.Create(&sessionORM{UUID: "session-1", UserUUID: "user-1", RefreshTokenHash: "hash-1", ExpiresAt: now.Add(time.Hour), LastUsedAt: now})
+
+	tests := []struct {
+		name    string
+		oldHash string
+		newHash string
+		want    bool
+	}{
+		{name: "正常系: 現在のハッシュが一致する場合ローテーションされること", oldHash: "hash-1", newHash: "hash-2", want: true},
+		{name: "異常系: ローテーション済みのハッシュでは更新されないこと", oldHash: "hash-1", newHash: "hash-3", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.Rotate(context.Background(), "session-1", tt.oldHash, tt.newHash, now.Add(2*time.Hour), now)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	current, err := r.FindByRefreshTokenHash(context.Background(), "hash-2")
+	assert.NoError(t, err)
+	assert.Equal(t, "session-1", current.UUID)
+	previous, err := r.FindByPreviousTokenHash(context.Background(), "hash-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "session-1", previous.UUID)
+}
+
+func TestSessionRepository_RevokeAndFindActive(t *testing.T) {
+	db, r := setupSessionRepository(t)
+	now := time.Now()
+	db.Create(&sessionORM{UUID: "session-1", UserUUID: "user-1", RefreshTokenHash: "hash-1", ExpiresAt: now.Add(time.Hour), LastUsedAt: now})
+	db.Create(&sessionORM{UUID: "session-2", UserUUID: "user-1", RefreshTokenHash: "hash-2", ExpiresAt: now.Add(time.Hour), LastUsedAt: now.Add(time.Minute)})
+	db.Create(&sessionORM{UUID: "session-expired", UserUUID: "user-1", RefreshTokenHash: "hash-3", ExpiresAt: now.Add(-time.Hour), LastUsedAt: now})
+
+	tests := []struct {
+		name        string
+		userUUID    string
+		sessionUUID string
+		want        bool
+	}{
+		{name: "異常系: 他のユーザーのセッションは失効できないこと", userUUID: "user-2", sessionUUID: "session-1", want: false},
+		{name: "正常系: 自分のセッションを失効できること", userUUID: "user-1", sessionUUID: "session-1", want: true},
+		{name: "異常系: 失効済みのセッションは再度失効できないこと", userUUID: "user-1", sessionUUID: "session-1", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.Revoke(context.Background(), tt.userUUID, tt.sessionUUID, now)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	active, err := r.FindActiveByUserUUID(context.Background(), "user-1", now)
+	assert.NoError(t, err)
+	assert.Len(t, active, 1)
+	assert.Equal(t, "session-2", active[0].UUID)
+
+	// ローテーションは失効済みのセッションには適用されないこと
+	rotated, err := r.Rotate(context.Background(), "session-1", "hash-1", "hash-4", now.Add(time.Hour), now)
+	assert.NoError(t, err)
+	assert.False(t, rotated)
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"backend/internal/domain/model"
+	"backend/internal/domain/repository"
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type shareLinkORM struct {
+	UUID              string     `gorm:"primaryKey;column:uuid;size:255"`
+	ProjectUUID       string     `gorm:"column:project_uuid;size:255"`
+	RootChatUUID      *string    `gorm:"column:root_chat_uuid;size:255"`
+	TokenHash         string     `gorm:"column:token_hash;size:64;uniqueIndex"`
+	TokenPrefix       string     `gorm:"column:token_prefix;size:16"`
+	CreatedByUserUUID string     `gorm:"column:created_by_user_uuid;size:255"`
+	ExpiresAt         *time.Time `gorm:"column:expires_at"`
+	RevokedAt         *time.Time `gorm:"column:revoked_at"`
+	CreatedAt         time.Time  `gorm:"column:created_at"`
+	UpdatedAt         time.Time  `gorm:"column:updated_at"`
+}
+
+func (shareLinkORM) TableName() string {
+	return "share_links"
+}
+
+func (o *shareLinkORM) toDomain() *model.ShareLink {
+	link := &model.ShareLink{
+		UUID:              o.UUID,
+		ProjectUUID:       o.ProjectUUID,
+		Prefix:            o.TokenPrefix,
+		CreatedByUserUUID: o.CreatedByUserUUID,
+		ExpiresAt:         o.ExpiresAt,
+		RevokedAt:         o.RevokedAt,
+		CreatedAt:         o.CreatedAt,
+	}
+	if o.RootChatUUID != nil {
+		link.RootChatUUID = *o.RootChatUUID
+	}
+	return link
+}
+
+type shareLinkRepository struct {
+	db *gorm.DB
+}
+
+func NewShareLinkRepository(db *gorm.DB) repository.ShareLinkRepository {
+	return &shareLinkRepository{db: db}
+}
+
+// 共有リンクを作成する
+func (r *shareLinkRepository) Create(ctx context.Context, link *model.ShareLink, tokenHash string) error {
+	slog.DebugContext(ctx, "共有リンク作成処理を開始", "share_link_uuid", link.UUID, "project_uuid", link.ProjectUUID)
+	orm := shareLinkORM{
+		UUID:              link.UUID,
+		ProjectUUID:       link.ProjectUUID,
+		TokenHash:         tokenHash,
+		TokenPrefix:       link.Prefix,
+		CreatedByUserUUID: link.CreatedByUserUUID,
+		ExpiresAt:         link.ExpiresAt,
+		CreatedAt:         link.CreatedAt,
+	}
+	if link.RootChatUUID != "" {
+		orm.RootChatUUID = &link.RootChatUUID
+	}
+	return getDB(ctx, r.db).WithContext(ctx).Create(&orm).Error
+}
+
+// トークンのハッシュに一致する共有リンクを取得する
+func (r *shareLinkRepository) FindByHash(ctx context.Context, tokenHash string) (*model.ShareLink, error) {
+	slog.DebugContext(ctx, "共有リンク取得処理を開始")
+	var orm shareLinkORM
+	err := getDB(ctx, r.db).WithContext(ctx).Where("token_hash = ?", tokenHash).First(&orm).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return orm.toDomain(), nil
+}
+
+// プロジェクトの失効していない共有リンクを取得する
+func (r *shareLinkRepository) FindActiveByProjectUUID(ctx context.Context, projectUUID string) ([]*model.ShareLink, error) {
+	slog.DebugContext(ctx, "共有リンク一覧取得処理を開始", "project_uuid", projectUUID)
+	var orms []shareLinkORM
+	err := getDB(ctx, r.db).WithContext(ctx).
+		Where("project_uuid = ? AND revoked_at IS NULL", projectUUID).
+		Order("created_at desc").
+		Find(&orms).Error
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]*model.ShareLink, 0, len(orms))
+	for i := range orms {
+		links = append(links, orms[i].toDomain())
+	}
+	return links, nil
+}
+
+// プロジェクトの共有リンクを失効させる
+func (r *shareLinkRepository) Revoke(ctx context.Context, projectUUID string, linkUUID string, revokedAt time.Time) (bool, error) {
+	slog.DebugContext(ctx, "共有リンク失効処理を開始", "project_uuid", projectUUID, "share_link_uuid", linkUUID)
+	result := getDB(ctx, r.db).WithContext(ctx).Model(&shareLinkORM{}).
+		Where("uuid = ? AND project_uuid = ? AND revoked_at IS NULL", linkUUID, projectUUID).
+		Update("revoked_at", revokedAt)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"backend/internal/domain/model"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// テスト用の共有リンクリポジトリを作成する処理
+func setupShareLinkRepository(t *testing.T) (*gorm.DB, *shareLinkRepository) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	if err := db.AutoMigrate(&shareLinkORM{}); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+	return db, &shareLinkRepository{db: db}
+}
+
+func TestShareLinkRepository_CreateAndFindByHash(t *testing.T) {
+	_, r := setupShareLinkRepository(t)
+	now := time.Now().Truncate(time.Second)
+	expiresAt := now.Add(time.Hour)
+
+	err := r.Create(context.Background(), &model.ShareLink{
+		UUID:              "link-1",
+		ProjectUUID:       "project-1",
+		RootChatUUID:      "chat-2",
+		Prefix:            "cbs_abcd",
+		CreatedByUserUUID: "user-1",
+		ExpiresAt:         &expiresAt,
+		CreatedAt:         now,
+	}, "hash-1")
+	assert.NoError(t, err)
+	err = r.Create(context.Background(), &model.ShareLink{
+		UUID:              "link-2",
+		ProjectUUID:       "project-1",
+		Prefix:            "cbs_efgh",
+		CreatedByUserUUID: "user-1",
+		CreatedAt:         now,
+	}, "hash-2")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		hash         string
+		wantUUID     string
+		wantRootChat string
+	}{
+		{name: "正常系: 部分木の共有リンクが取得できること", hash: "hash-1", wantUUID: "link-1", wantRootChat: "chat-2"},
+		{name: "正常系: プロジェクト全体の共有リンクは起点チャットが空になること", hash: "hash-2", wantUUID: "link-2"},
+		{name: "正常系: 一致しない場合は nil が返ること", hash: "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.FindByHash(context.Background(), tt.hash)
+			assert.NoError(t, err)
+			if tt.wantUUID == "" {
+				assert.Nil(t, got)
+				return
+			}
+			assert.Equal(t, tt.wantUUID, got.UUID)
+			assert.Equal(t, tt.wantRootChat, got.RootChatUUID)
+		})
+	}
+}
+
+func TestShareLinkRepository_Revoke(t *testing.T) {
+	_, r := setupShareLinkRepository(t)
+	ctx := context.Background()
+	now := time.Now()
+	for _, uuid := range []string{"link-1", "link-2"} {
+		err := r.Create(ctx, &model.ShareLink{UUID: uuid, ProjectUUID: "project-1", Prefix: "cbs_", CreatedByUserUUID: "user-1", CreatedAt: now}, "hash-"+uuid)
+		assert.NoError(t, err)
+	}
+
+	// 別のプロジェクトの共有リンクは失効できない
+	revoked, err := r.Revoke(ctx, "project-2", "link-1", now)
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	revoked, err = r.Revoke(ctx, "project-1", "link-1", now)
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+
+	revoked, err = r.Revoke(ctx, "project-1", "link-1", now)
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+
+	links, err := r.FindActiveByProjectUUID(ctx, "project-1")
+	assert.NoError(t, err)
+	if assert.Len(t, links, 1) {
+		assert.Equal(t, "link-2", links[0].UUID)
+	}
+}
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// setupLargeTreeDB は指定した数のチャットを持つプロジェクトツリーを生成する処理
+// 各チャットは質問と回答の2メッセージを持ち、2番目以降のチャットはそれ以前のチャットの回答から分岐する
+func setupLargeTreeDB(tb testing.TB, chatCount int) *gorm.DB {
+	tb.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		tb.Fatalf("failed to connect database: %v", err)
+	}
+	if err := db.AutoMigrate(&chatORM{}, &messageORM{}, &messageSelectionORM{}, &edgeORM{}); err != nil {
+		tb.Fatalf("failed to migrate database: %v", err)
+	}
+
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	chats := make([]chatORM, 0, chatCount)
+	messages := make([]messageORM, 0, chatCount*2)
+	edges := make([]edgeORM, 0, chatCount*2)
+	for i := 0; i < chatCount; i++ {
+		chatUUID := fmt.Sprintf("chat-%d", i)
+		userUUID := fmt.Sprintf("msg-%d-user", i)
+		assistantUUID := fmt.Sprintf("msg-%d-assistant", i)
+		createdAt := base.Add(time.Duration(i) * time.Minute)
+
+		chat := chatORM{UUID: chatUUID, ProjectUUID: "project-uuid", Status: "open", CreatedAt: createdAt}
+		if i > 0 {
+			// 親は (i-1)/2 番目のチャットとし、二分木状に分岐させる
+			parent := (i - 1) / 2
+			chat.ParentChatUUID = strPtr(fmt.Sprintf("chat-%d", parent))
+			chat.SourceMessageUUID = strPtr(fmt.Sprintf("msg-%d-assistant", parent))
+			edges = append(edges, edgeORM{
+				UUID:              fmt.Sprintf("edge-%d-fork", i),
+				ChatUUID:          chatUUID,
+				SourceMessageUUID: userUUID,
+				TargetMessageUUID: fmt.Sprintf("msg-%d-assistant", parent),
+			})
+		}
+		chats = append(chats, chat)
+		messages = append(messages,
+			messageORM{UUID: userUUID, ChatUUID: chatUUID, Role: "user", Content: "question", CreatedAt: createdAt},
+			messageORM{UUID: assistantUUID, ChatUUID: chatUUID, Role: "assistant", Content: "answer", CreatedAt: createdAt.Add(time.Second)},
+		)
+		edges = append(edges, edgeORM{
+			UUID:              fmt.Sprintf("edge-%d", i),
+			ChatUUID:          chatUUID,
+			SourceMessageUUID: assistantUUID,
+			TargetMessageUUID: userUUID,
+		})
+	}
+	if err := db.CreateInBatches(chats, 100).Error; err != nil {
+		tb.Fatalf("failed to seed chats: %v", err)
+	}
+	if err := db.CreateInBatches(messages, 100).Error; err != nil {
+		tb.Fatalf("failed to seed messages: %v", err)
+	}
+	if err := db.CreateInBatches(edges, 100).Error; err != nil {
+		tb.Fatalf("failed to seed edges: %v", err)
+	}
+	return db
+}
+
+// countQueries は SELECT クエリの実行回数を数えるコールバックを登録する処理
+// Find は Query、Scan は Row のコールバックを経由するため両方で数える
+func countQueries(tb testing.TB, db *gorm.DB) *int {
+	tb.Helper()
+	count := 0
+	increment := func(*gorm.DB) {
+		count++
+	}
+	if err := db.Callback().Query().Before("gorm:query").Register("test:count_queries", increment); err != nil {
+		tb.Fatalf("failed to register callback: %v", err)
+	}
+	if err := db.Callback().Row().Before("gorm:row").Register("test:count_rows", increment); err != nil {
+		tb.Fatalf("failed to register callback: %v", err)
+	}
+	return &count
+}
+
+// loadProjectTreeInBulk はプロジェクト単位の一括取得でツリーを読み込む処理
+func loadProjectTreeInBulk(ctx context.Context, db *gorm.DB) (int, int, int, error) {
+	chats, err := NewChatRepository(db).FindAllByProjectUUID(ctx, "project-uuid")
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	messages, err := NewMessageRepository(db).FindMessagesByProjectUUID(ctx, "project-uuid")
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	edges, err := NewEdgeRepository(db).FindEdgesByProjectUUID(ctx, "project-uuid")
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return len(chats), len(messages), len(edges), nil
+}
+
+// loadProjectTreePerChat はチャットごとに取得する従来の方法でツリーを読み込む処理
+func loadProjectTreePerChat(ctx context.Context, db *gorm.DB) (int, int, int, error) {
+	chatRepo := NewChatRepository(db)
+	messageRepo := NewMessageRepository(db)
+	edgeRepo := NewEdgeRepository(db)
+
+	chats, err := chatRepo.FindAllByProjectUUID(ctx, "project-uuid")
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	messageCount, edgeCount := 0, 0
+	for _, chat := range chats {
+		messages, err := messageRepo.FindMessagesByChatID(ctx, chat.UUID)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		edges, err := edgeRepo.FindEdgesByChatID(ctx, chat.UUID)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		messageCount += len(messages)
+		edgeCount += len(edges)
+	}
+	return len(chats), messageCount, edgeCount, nil
+}
+
+func TestProjectTreeLoad_QueryCount(t *testing.T) {
+	tests := []struct {
+		name      string
+		chatCount int
+	}{
+		{name: "正常系: 小さなツリーでも一定回数のクエリで取得できること", chatCount: 10},
+		{name: "正常系: 大きなツリーでもクエリ回数が増えないこと", chatCount: 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupLargeTreeDB(t, tt.chatCount)
+			count := countQueries(t, db)
+
+			chats, messages, edges, err := loadProjectTreeInBulk(context.Background(), db)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.chatCount, chats)
+			assert.Equal(t, tt.chatCount*2, messages)
+			assert.Equal(t, tt.chatCount*2-1, edges)
+			// チャット・メッセージ・フォーク・エッジの4クエリで取得できること
+			assert.Equal(t, 4, *count)
+		})
+	}
+}
+
+func BenchmarkProjectTreeLoad(b *testing.B) {
+	const chatCount = 1000
+	loaders := []struct {
+		name string
+		load func(ctx context.Context, db *gorm.DB) (int, int, int, error)
+	}{
+		{name: "bulk", load: loadProjectTreeInBulk},
+		{name: "per_chat", load: loadProjectTreePerChat},
+	}
+	for _, loader := range loaders {
+		b.Run(loader.name, func(b *testing.B) {
+			db := setupLargeTreeDB(b, chatCount)
+			ctx := context.Background()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, _, _, err := loader.load(ctx, db); err != nil {
+					b.Fatalf("failed to load tree: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"backend/internal/domain/model"
+	"backend/internal/domain/repository"
+	"context"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type treeNodeStateORM struct {
+	NodeID      string    `gorm:"primaryKey;column:node_id;size:255"`
+	ProjectUUID string    `gorm:"column:project_uuid;size:255;index"`
+	PositionX   *float64  `gorm:"column:position_x"`
+	PositionY   *float64  `gorm:"column:position_y"`
+	Collapsed   bool      `gorm:"column:collapsed"`
+	Version     int       `gorm:"column:version"`
+	UpdatedAt   time.Time `gorm:"column:updated_at"`
+}
+
+func (treeNodeStateORM) TableName() string {
+	return "tree_node_states"
+}
+
+func (o *treeNodeStateORM) toDomain() *model.TreeNodeState {
+	state := &model.TreeNodeState{
+		NodeID:      o.NodeID,
+		ProjectUUID: o.ProjectUUID,
+		Collapsed:   o.Collapsed,
+		Version:     o.Version,
+		UpdatedAt:   o.UpdatedAt,
+	}
+	if o.PositionX != nil && o.PositionY != nil {
+		state.Position = &model.ProjectNodePosition{X: *o.PositionX, Y: *o.PositionY}
+	}
+	return state
+}
+
+type treeNodeStateRepository struct {
+	db *gorm.DB
+}
+
+func NewTreeNodeStateRepository(db *gorm.DB) repository.TreeNodeStateRepository {
+	return &treeNodeStateRepository{db: db}
+}
+
+// プロジェクトのノードの表示状態を取得する
+func (r *treeNodeStateRepository) FindByProjectUUID(ctx context.Context, projectUUID string) ([]*model.TreeNodeState, error) {
+	slog.DebugContext(ctx, "ノード表示状態の取得処理を開始", "project_uuid", projectUUID)
+	var orms []treeNodeStateORM
+	if err := getDB(ctx, r.db).WithContext(ctx).
+		Where("project_uuid = ?", projectUUID).
+		Find(&orms).Error; err != nil {
+		return nil, err
+	}
+	states := make([]*model.TreeNodeState, len(orms))
+	for i := range orms {
+		states[i] = orms[i].toDomain()
+	}
+	return states, nil
+}
+
+// プロジェクトの1つのノードの表示状態を取得する
+func (r *treeNodeStateRepository) FindByNodeID(ctx context.Context, projectUUID string, nodeID string) (*model.TreeNodeState, error) {
+	slog.DebugContext(ctx, "ノード表示状態の取得処理を開始", "project_uuid", projectUUID, "node_id", nodeID)
+	var orm treeNodeStateORM
+	err := getDB(ctx, r.db).WithContext(ctx).
+		Where("node_id = ? AND project_uuid = ?", nodeID, projectUUID).
+		First(&orm).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return orm.toDomain(), nil
+}
+
+// 保存されているバージョンが一致する場合だけノードの表示状態を保存する
+// バージョンの確認と更新を1つの文で行うため、同時に更新されても一方だけが保存できる
+func (r *treeNodeStateRepository) SaveIfVersion(ctx context.Context, state *model.TreeNodeState, expectedVersion int) (bool, error) {
+	slog.DebugContext(ctx, "ノード表示状態の保存処理を開始", "node_id", state.NodeID, "expected_version", expectedVersion)
+	orm := treeNodeStateORM{
+		NodeID:      state.NodeID,
+		ProjectUUID: state.ProjectUUID,
+		Collapsed:   state.Collapsed,
+		Version:     state.Version,
+		UpdatedAt:   state.UpdatedAt,
+	}
+	if state.Position != nil {
+		orm.PositionX = &state.Position.X
+		orm.PositionY = &state.Position.Y
+	}
+
+	db := getDB(ctx, r.db).WithContext(ctx)
+	if expectedVersion == 0 {
+		// 既に保存されている場合は作成しない (他の操作が先に保存している)
+		result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&orm)
+		if result.Error != nil {
+			return false, result.Error
+		}
+		return result.RowsAffected > 0, nil
+	}
+
+	result := db.Model(&treeNodeStateORM{}).
+		Where("node_id = ? AND project_uuid = ? AND version = ?", state.NodeID, state.ProjectUUID, expectedVersion).
+		Updates(map[string]interface{}{
+			"position_x": orm.PositionX,
+			"position_y": orm.PositionY,
+			"collapsed":  orm.Collapsed,
+			"version":    orm.Version,
+			"updated_at": orm.UpdatedAt,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
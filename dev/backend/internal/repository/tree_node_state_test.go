@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"backend/internal/domain/model"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// テスト用のノード表示状態リポジトリを作成する処理
+func setupTreeNodeStateRepository(t *testing.T) *treeNodeStateRepository {
+	t.Helper()
+	// インメモリDBのセットアップ
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	// マイグレーション
+	if err := db.AutoMigrate(&treeNodeStateORM{}); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+	return &treeNodeStateRepository{db: db}
+}
+
+func TestTreeNodeStateRepository_SaveIfVersion(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	saved := &model.TreeNodeState{
+		NodeID: "msg-1", ProjectUUID: "project-1",
+		Position: &model.ProjectNodePosition{X: 10, Y: 20}, Collapsed: true, Version: 1, UpdatedAt: now,
+	}
+
+	tests := []struct {
+		name            string
+		state           *model.TreeNodeState
+		expectedVersion int
+		want            bool
+		wantState       *model.TreeNodeState
+	}{
+		{
+			name: "正常系: バージョンが一致する場合は更新できること",
+			state: &model.TreeNodeState{
+				NodeID: "msg-1", ProjectUUID: "project-1",
+				Position: &model.ProjectNodePosition{X: 30, Y: 40}, Collapsed: true, Version: 2, UpdatedAt: now,
+			},
+			expectedVersion: 1,
+			want:            true,
+			wantState: &model.TreeNodeState{
+				NodeID: "msg-1", ProjectUUID: "project-1",
+				Position: &model.ProjectNodePosition{X: 30, Y: 40}, Collapsed: true, Version: 2, UpdatedAt: now,
+			},
+		},
+		{
+			name: "正常系: 位置を破棄して自動レイアウトに戻せること",
+			state: &model.TreeNodeState{
+				NodeID: "msg-1", ProjectUUID: "project-1", Collapsed: true, Version: 2, UpdatedAt: now,
+			},
+			expectedVersion: 1,
+			want:            true,
+			wantState: &model.TreeNodeState{
+				NodeID: "msg-1", ProjectUUID: "project-1", Collapsed: true, Version: 2, UpdatedAt: now,
+			},
+		},
+		{
+			name: "正常系: 保存されていないノードは作成できること",
+			state: &model.TreeNodeState{
+				NodeID: "msg-2", ProjectUUID: "project-1", Collapsed: true, Version: 1, UpdatedAt: now,
+			},
+			expectedVersion: 0,
+			want:            true,
+			wantState: &model.TreeNodeState{
+				NodeID: "msg-2", ProjectUUID: "project-1", Collapsed: true, Version: 1, UpdatedAt: now,
+			},
+		},
+		{
+			name: "異常系: バージョンが一致しない場合は更新しないこと",
+			state: &model.TreeNodeState{
+				NodeID: "msg-1", ProjectUUID: "project-1",
+				Position: &model.ProjectNodePosition{X: 30, Y: 40}, Version: 3, UpdatedAt: now,
+			},
+			expectedVersion: 2,
+			want:            false,
+			wantState:       saved,
+		},
+		{
+			name: "異常系: 既に保存されているノードは作成しないこと",
+			state: &model.TreeNodeState{
+				NodeID: "msg-1", ProjectUUID: "project-1", Version: 1, UpdatedAt: now,
+			},
+			expectedVersion: 0,
+			want:            false,
+			wantState:       saved,
+		},
+		{
+			name: "異常系: 別のプロジェクトのノードは更新しないこと",
+			state: &model.TreeNodeState{
+				NodeID: "msg-1", ProjectUUID: "project-2", Version: 2, UpdatedAt: now,
+			},
+			expectedVersion: 1,
+			want:            false,
+			wantState:       saved,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := setupTreeNodeStateRepository(t)
+			ctx := context.Background()
+			if ok, err := r.SaveIfVersion(ctx, saved, 0); err != nil || !ok {
+				t.Fatalf("treeNodeStateRepository.SaveIfVersion() = %v, %v", ok, err)
+			}
+
+			got, err := r.SaveIfVersion(ctx, tt.state, tt.expectedVersion)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			states, err := r.FindByProjectUUID(ctx, "project-1")
+			assert.NoError(t, err)
+			var found *model.TreeNodeState
+			for _, s := range states {
+				if s.NodeID == tt.wantState.NodeID {
+					found = s
+				}
+			}
+			if assert.NotNil(t, found) {
+				found.UpdatedAt = found.UpdatedAt.UTC()
+				assert.Equal(t, tt.wantState, found)
+			}
+		})
+	}
+}
+
+func TestTreeNodeStateRepository_FindByProjectUUID(t *testing.T) {
+	r := setupTreeNodeStateRepository(t)
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	for _, s := range []*model.TreeNodeState{
+		{NodeID: "msg-1", ProjectUUID: "project-1", Position: &model.ProjectNodePosition{X: 1, Y: 2}, Version: 1, UpdatedAt: now},
+		{NodeID: "msg-2", ProjectUUID: "project-1", Collapsed: true, Version: 1, UpdatedAt: now},
+		{NodeID: "msg-3", ProjectUUID: "project-2", Version: 1, UpdatedAt: now},
+	} {
+		if _, err := r.SaveIfVersion(ctx, s, 0); err != nil {
+			t.Fatalf("treeNodeStateRepository.SaveIfVersion() error = %v", err)
+		}
+	}
+
+	got, err := r.FindByProjectUUID(ctx, "project-1")
+	assert.NoError(t, err)
+	ids := []string{}
+	for _, s := range got {
+		ids = append(ids, s.NodeID)
+	}
+	assert.ElementsMatch(t, []string{"msg-1", "msg-2"}, ids)
+
+	got, err = r.FindByProjectUUID(ctx, "unknown")
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestTreeNodeStateRepository_FindByNodeID(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	saved := &model.TreeNodeState{
+		NodeID: "msg-1", ProjectUUID: "project-1",
+		Position: &model.ProjectNodePosition{X: 1, Y: 2}, Collapsed: true, Version: 1, UpdatedAt: now,
+	}
+
+	tests := []struct {
+		name        string
+		projectUUID string
+		nodeID      string
+		want        *model.TreeNodeState
+	}{
+		{
+			name:        "正常系: 保存されているノードの表示状態を取得できること",
+			projectUUID: "project-1",
+			nodeID:      "msg-1",
+			want:        saved,
+		},
+		{
+			name:        "正常系: 保存されていないノードは nil を返すこと",
+			projectUUID: "project-1",
+			nodeID:      "msg-2",
+			want:        nil,
+		},
+		{
+			name:        "異常系: 別のプロジェクトのノードは取得しないこと",
+			projectUUID: "project-2",
+			nodeID:      "msg-1",
+			want:        nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := setupTreeNodeStateRepository(t)
+			ctx := context.Background()
+			if ok, err := r.SaveIfVersion(ctx, saved, 0); err != nil || !ok {
+				t.Fatalf("treeNodeStateRepository.SaveIfVersion() = %v, %v", ok, err)
+			}
+
+			got, err := r.FindByNodeID(ctx, tt.projectUUID, tt.nodeID)
+			assert.NoError(t, err)
+			if got != nil {
+				got.UpdatedAt = got.UpdatedAt.UTC()
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
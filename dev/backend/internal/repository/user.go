@@ -14,9 +14,13 @@ import (
 
 // userのORMモデル
 type userORM struct {
-	ID        string `gorm:"primaryKey;column:uuid;size:36"`
-	Name      string `gorm:"size:255"`
-	CreatedID string `gorm:"column:created_id;size:255"`
+	ID        string  `gorm:"primaryKey;column:uuid;size:36"`
+	Name      string  `gorm:"size:255"`
+	Language  *string `gorm:"size:10"`
+	Provider  string  `gorm:"size:50;default:guest"`
+	GoogleID  *string `gorm:"column:google_id;size:255"`
+	AvatarURL *string `gorm:"column:avatar_url"`
+	CreatedID string  `gorm:"column:created_id;size:255"`
 }
 
 // テーブル名を返す処理
@@ -26,19 +30,44 @@ func (userORM) TableName() string {
 
 // userORMをドメインモデルに変換する処理
 func (orm *userORM) toDomain() *model.User {
-	return &model.User{
-		UUID: orm.ID,
-		Name: orm.Name,
+	user := &model.User{
+		UUID:     orm.ID,
+		Name:     orm.Name,
+		Provider: orm.Provider,
 	}
+	if orm.Language != nil {
+		user.Language = *orm.Language
+	}
+	if orm.GoogleID != nil {
+		user.Subject = *orm.GoogleID
+	}
+	if orm.AvatarURL != nil {
+		user.AvatarURL = *orm.AvatarURL
+	}
+	return user
 }
 
 // ドメインモデルをuserORMに変換する処理
 func fromDomain(u *model.User) *userORM {
-	return &userORM{
+	orm := &userORM{
 		ID:        u.UUID,
 		Name:      u.Name,
+		Provider:  u.Provider,
 		CreatedID: uuid.NewString(),
 	}
+	if orm.Provider == "" {
+		orm.Provider = model.ProviderGuest
+	}
+	if u.Language != "" {
+		orm.Language = &u.Language
+	}
+	if u.Subject != "" {
+		orm.GoogleID = &u.Subject
+	}
+	if u.AvatarURL != "" {
+		orm.AvatarURL = &u.AvatarURL
+	}
+	return orm
 }
 
 type userRepository struct {
@@ -54,7 +83,7 @@ func NewUserRepository(db *gorm.DB) repository.UserRepository {
 func (r *userRepository) Create(ctx context.Context, user *model.User) error {
 	slog.DebugContext(ctx, "ユーザー作成処理を開始", "user_uuid", user.UUID)
 	orm := fromDomain(user)
-	err := r.db.WithContext(ctx).Create(orm).Error
+	err := getDB(ctx, r.db).WithContext(ctx).Create(orm).Error
 	if err != nil {
 		return err
 	}
@@ -65,7 +94,7 @@ func (r *userRepository) Create(ctx context.Context, user *model.User) error {
 func (r *userRepository) FindByUUID(ctx context.Context, uuid string) (*model.User, error) {
 	slog.DebugContext(ctx, "ユーザー検索処理を開始", "user_uuid", uuid)
 	var orm userORM
-	err := r.db.WithContext(ctx).First(&orm, "uuid = ?", uuid).Error
+	err := getDB(ctx, r.db).WithContext(ctx).First(&orm, "uuid = ?", uuid).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, gorm.ErrRecordNotFound
@@ -74,3 +103,77 @@ func (r *userRepository) FindByUUID(ctx context.Context, uuid string) (*model.Us
 	}
 	return orm.toDomain(), nil
 }
+
+// 指定されたプロバイダーとサブジェクトのユーザーを検索する処理
+// 見つからない場合は nil を返す
+func (r *userRepository) FindByProviderSubject(ctx context.Context, provider string, subject string) (*model.User, error) {
+	slog.DebugContext(ctx, "プロバイダー・サブジェクトによるユーザー検索処理を開始", "provider", provider)
+	var orm userORM
+	err := getDB(ctx, r.db).WithContext(ctx).First(&orm, "provider = ? AND google_id = ?", provider, subject).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil // 見つからない場合は nil を返す
+		}
+		return nil, err
+	}
+	return orm.toDomain(), nil
+}
+
+// ユーザーの表示名とアイコン画像を更新する処理
+func (r *userRepository) UpdateProfile(ctx context.Context, uuid string, name string, avatarURL string) error {
+	slog.DebugContext(ctx, "ユーザープロフィール更新処理を開始", "user_uuid", uuid)
+	var avatar *string
+	if avatarURL != "" {
+		avatar = &avatarURL
+	}
+	return getDB(ctx, r.db).WithContext(ctx).Model(&userORM{}).Where("uuid = ?", uuid).Updates(map[string]interface{}{
+		"name":       name,
+		"avatar_url": avatar,
+	}).Error
+}
+
+// ゲストユーザーにOIDCプロバイダーのアカウントを紐付ける処理
+func (r *userRepository) LinkIdentity(ctx context.Context, uuid string, provider string, subject string, name string, avatarURL string) error {
+	slog.DebugContext(ctx, "ユーザーアカウント連携処理を開始", "user_uuid", uuid, "provider", provider)
+	var avatar *string
+	if avatarURL != "" {
+		avatar = &avatarURL
+	}
+	result := getDB(ctx, r.db).WithContext(ctx).Model(&userORM{}).Where("uuid = ?", uuid).Updates(map[string]interface{}{
+		"provider":   provider,
+		"google_id":  subject,
+		"name":       name,
+		"avatar_url": avatar,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ユーザーを削除する処理
+func (r *userRepository) Delete(ctx context.Context, uuid string) error {
+	slog.DebugContext(ctx, "ユーザー削除処理を開始", "user_uuid", uuid)
+	return getDB(ctx, r.db).WithContext(ctx).Where("uuid = ?", uuid).Delete(&userORM{}).Error
+}
+
+// ユーザーの優先言語を更新する処理
+// 空文字の場合は未設定 (NULL) に戻す
+func (r *userRepository) UpdateLanguage(ctx context.Context, uuid string, language string) error {
+	slog.DebugContext(ctx, "ユーザー優先言語更新処理を開始", "user_uuid", uuid, "language", language)
+	var value *string
+	if language != "" {
+		value = &language
+	}
+	result := getDB(ctx, r.db).WithContext(ctx).Model(&userORM{}).Where("uuid = ?", uuid).Update("language", value)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
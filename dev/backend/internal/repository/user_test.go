@@ -93,8 +93,9 @@ func TestUserRepository_FindByID(t *testing.T) {
 				})
 			},
 			want: &model.User{
-				UUID: "test-uuid",
-				Name: "test-user",
+				UUID:     "test-uuid",
+				Name:     "test-user",
+				Provider: "guest",
 			},
 			wantErr: false,
 		},
@@ -146,3 +147,272 @@ func TestUserRepository_FindByID(t *testing.T) {
 		})
 	}
 }
+
+func TestUserRepository_UpdateLanguage(t *testing.T) {
+	type args struct {
+		id       string
+		language string
+	}
+	tests := []struct {
+		name      string
+		args      args
+		setupData func(db *gorm.DB)
+		want      *model.User
+		wantErr   bool
+	}{
+		{
+			name: "正常系: 優先言語が更新できること",
+			args: args{
+				id:       "test-uuid",
+				language: "en",
+			},
+			setupData: func(db *gorm.DB) {
+				db.Create(&userORM{
+					ID:        "test-uuid",
+					Name:      "test-user",
+					CreatedID: "test-uuid",
+				})
+			},
+			want: &model.User{
+				UUID:     "test-uuid",
+				Name:     "test-user",
+				Language: "en",
+				Provider: "guest",
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常系: 空文字の場合は未設定に戻ること",
+			args: args{
+				id:       "test-uuid",
+				language: "",
+			},
+			setupData: func(db *gorm.DB) {
+				language := "en"
+				db.Create(&userORM{
+					ID:        "test-uuid",
+					Name:      "test-user",
+					Language:  &language,
+					CreatedID: "test-uuid",
+				})
+			},
+			want: &model.User{
+				UUID:     "test-uuid",
+				Name:     "test-user",
+				Provider: "guest",
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常系: 存在しないユーザーの場合エラーになること",
+			args: args{
+				id:       "non-existent-uuid",
+				language: "en",
+			},
+			setupData: func(db *gorm.DB) {},
+			want:      nil,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// インメモリDBのセットアップ
+			db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+			if err != nil {
+				t.Fatalf("failed to connect database: %v", err)
+			}
+			// マイグレーション
+			if err := db.AutoMigrate(&userORM{}); err != nil {
+				t.Fatalf("failed to migrate database: %v", err)
+			}
+
+			// データセットアップ
+			tt.setupData(db)
+
+			r := NewUserRepository(db)
+			if err := r.UpdateLanguage(context.Background(), tt.args.id, tt.args.language); (err != nil) != tt.wantErr {
+				t.Errorf("userRepository.UpdateLanguage() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			got, err := r.FindByUUID(context.Background(), tt.args.id)
+			if err != nil {
+				t.Fatalf("failed to find user: %v", err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUserRepository_FindByProviderSubject(t *testing.T) {
+	type args struct {
+		provider string
+		subject  string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *model.User
+		wantErr bool
+	}{
+		{
+			name: "正常系: プロバイダーとサブジェクトが一致するユーザーを取得できること",
+			args: args{
+				provider: "google",
+				subject:  "google-sub",
+			},
+			want: &model.User{
+				UUID:      "google-user",
+				Name:      "Google User",
+				Provider:  "google",
+				Subject:   "google-sub",
+				AvatarURL: "https://example.com/avatar.png",
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常系: プロバイダーが異なる場合は nil が返ること",
+			args: args{
+				provider: "other",
+				subject:  "google-sub",
+			},
+			want:    nil,
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+			if err != nil {
+				t.Fatalf("failed to connect database: %v", err)
+			}
+			if err := db.AutoMigrate(&userORM{}); err != nil {
+				t.Fatalf("failed to migrate database: %v", err)
+			}
+
+			r := NewUserRepository(db)
+			if err := r.Create(context.Background(), &model.User{
+				UUID:      "google-user",
+				Name:      "Google User",
+				Provider:  "google",
+				Subject:   "google-sub",
+				AvatarURL: "https://example.com/avatar.png",
+			}); err != nil {
+				t.Fatalf("failed to create user: %v", err)
+			}
+
+			got, err := r.FindByProviderSubject(context.Background(), tt.args.provider, tt.args.subject)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("userRepository.FindByProviderSubject() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUserRepository_UpdateProfile(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	if err := db.AutoMigrate(&userORM{}); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+
+	r := NewUserRepository(db)
+	if err := r.Create(context.Background(), &model.User{UUID: "user-uuid", Name: "Old Name"}); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	err = r.UpdateProfile(context.Background(), "user-uuid", "New Name", "https://example.com/new.png")
+	assert.NoError(t, err)
+
+	got, err := r.FindByUUID(context.Background(), "user-uuid")
+	assert.NoError(t, err)
+	assert.Equal(t, "New Name", got.Name)
+	assert.Equal(t, "https://example.com/new.png", got.AvatarURL)
+}
+
+func TestUserRepository_LinkIdentity(t *testing.T) {
+	tests := []struct {
+		name     string
+		userUUID string
+		want     *model.User
+		wantErr  bool
+	}{
+		{
+			name:     "正常系: ゲストユーザーにアカウントが紐付けられること",
+			userUUID: "guest-user",
+			want: &model.User{
+				UUID:      "guest-user",
+				Name:      "Google User",
+				Language:  "en",
+				Provider:  "google",
+				Subject:   "google-sub",
+				AvatarURL: "https://example.com/avatar.png",
+			},
+			wantErr: false,
+		},
+		{
+			name:     "異常系: 存在しないユーザーの場合エラーになること",
+			userUUID: "non-existent",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+			if err != nil {
+				t.Fatalf("failed to connect database: %v", err)
+			}
+			if err := db.AutoMigrate(&userORM{}); err != nil {
+				t.Fatalf("failed to migrate database: %v", err)
+			}
+
+			r := NewUserRepository(db)
+			if err := r.Create(context.Background(), &model.User{UUID: "guest-user", Name: "Guest-1234", Language: "en"}); err != nil {
+				t.Fatalf("failed to create user: %v", err)
+			}
+
+			err = r.LinkIdentity(context.Background(), tt.userUUID, "google", "google-sub", "Google User", "https://example.com/avatar.png")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("userRepository.LinkIdentity() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			got, err := r.FindByUUID(context.Background(), tt.userUUID)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUserRepository_Delete(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	if err := db.AutoMigrate(&userORM{}); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+
+	r := NewUserRepository(db)
+	if err := r.Create(context.Background(), &model.User{UUID: "guest-user", Name: "Guest-1234"}); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	assert.NoError(t, r.Delete(context.Background(), "guest-user"))
+
+	_, err = r.FindByUUID(context.Background(), "guest-user")
+	assert.Error(t, err)
+}
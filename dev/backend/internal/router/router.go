@@ -2,7 +2,11 @@ package router
 
 import (
 	"backend/config"
+	domainModel "backend/internal/domain/model"
+	domainUsecase "backend/internal/domain/usecase"
 	"backend/internal/handler"
+	"backend/internal/infrastructure/layout"
+	"backend/internal/infrastructure/oidc"
 	internalMiddleware "backend/internal/middleware"
 	"backend/internal/repository"
 	"backend/internal/usecase"
@@ -18,7 +22,8 @@ import (
 )
 
 // アプリケーションのルーティングを初期化する処理
-func InitRoutes(e *echo.Echo, db *gorm.DB, cfg *config.Config, genaiClient *genai.Client, publisher message.Publisher) {
+// chatEventHub はバックグラウンドで生成した回答も同じ接続に通知できるよう Worker と共有する
+func InitRoutes(e *echo.Echo, db *gorm.DB, cfg *config.Config, genaiClient *genai.Client, publisher message.Publisher, promptRenderer domainUsecase.PromptRenderer, chatEventHub domainUsecase.ChatEventHub) {
 	// ミドルウェア
 	e.Use(middleware.RequestID())
 	e.Use(middleware.Recover())
@@ -62,26 +67,70 @@ func InitRoutes(e *echo.Echo, db *gorm.DB, cfg *config.Config, genaiClient *gena
 
 	// Auth の依存関係注入
 	userRepo := repository.NewUserRepository(db)
-	authUsecase := usecase.NewAuthUsecase(userRepo, cfg)
+	projectRepo := repository.NewProjectRepository(db)
+	projectMemberRepo := repository.NewProjectMemberRepository(db)
+	txManager := repository.NewTransactionManager(db)
+	sessionRepo := repository.NewSessionRepository(db)
+	oidcProviders := make(map[string]domainUsecase.OIDCProvider, len(cfg.OIDC.Providers))
+	for name, providerCfg := range cfg.OIDC.Providers {
+		if name == domainModel.ProviderGuest {
+			slog.Warn("ゲストと同じ名前のOIDCプロバイダーは使用できません", "provider", name)
+			continue
+		}
+		oidcProviders[name] = oidc.NewProvider(name, providerCfg, nil)
+	}
+	authUsecase := usecase.NewAuthUsecase(userRepo, projectRepo, projectMemberRepo, sessionRepo, txManager, cfg, oidcProviders)
 	authHandler := handler.NewAuthHandler(authUsecase, cfg)
 
+	// User の依存関係注入
+	userUsecase := usecase.NewUserUsecase(userRepo)
+	userHandler := handler.NewUserHandler(userUsecase)
+
 	// Project の依存関係注入
-	projectRepo := repository.NewProjectRepository(db)
 	chatRepo := repository.NewChatRepository(db)
 	messageRepo := repository.NewMessageRepository(db)
 	edgeRepo := repository.NewEdgeRepository(db)
-	txManager := repository.NewTransactionManager(db)
-	projectUsecase := usecase.NewProjectUsecase(projectRepo, chatRepo, messageRepo, edgeRepo, txManager)
+	treeNodeStateRepo := repository.NewTreeNodeStateRepository(db)
+	treeLayouter := layout.NewTidyTree(cfg.Layout)
+	projectUsecase := usecase.NewProjectUsecase(projectRepo, projectMemberRepo, chatRepo, messageRepo, edgeRepo, treeNodeStateRepo, txManager, treeLayouter)
 	projectHandler := handler.NewProjectHandler(projectUsecase)
 
+	// ProjectMember の依存関係注入
+	projectMemberUsecase := usecase.NewProjectMemberUsecase(projectMemberRepo, projectRepo, chatRepo, userRepo)
+	projectMemberHandler := handler.NewProjectMemberHandler(projectMemberUsecase)
+
 	// Chat の依存関係注入
 	genaiClientWrapper := usecase.NewGenAIClientWrapper(genaiClient)
 	messageSelectionRepo := repository.NewMessageSelectionRepository(db)
-	chatUsecase := usecase.NewChatUsecase(chatRepo, messageRepo, messageSelectionRepo, edgeRepo, txManager, genaiClientWrapper, publisher)
+	chatMergeEventRepo := repository.NewChatMergeEventRepository(db)
+	branchComparisonRepo := repository.NewBranchComparisonRepository(db)
+	forkSuggestionRepo := repository.NewForkSuggestionRepository(db)
+	chatUsecase := usecase.NewChatUsecase(chatRepo, messageRepo, messageSelectionRepo, edgeRepo, chatMergeEventRepo, branchComparisonRepo, forkSuggestionRepo, projectRepo, userRepo, txManager, genaiClientWrapper, publisher, promptRenderer, chatEventHub)
 	chatHandler := handler.NewChatHandler(chatUsecase)
 
+	// Collaboration の依存関係注入
+	collaborationUsecase := usecase.NewCollaborationUsecase(chatEventHub, chatRepo, userRepo)
+	collaborationHandler := handler.NewCollaborationHandler(collaborationUsecase)
+
+	// APIToken の依存関係注入
+	apiTokenRepo := repository.NewAPITokenRepository(db)
+	apiTokenUsecase := usecase.NewAPITokenUsecase(apiTokenRepo, projectRepo, chatRepo)
+	apiTokenHandler := handler.NewAPITokenHandler(apiTokenUsecase)
+
+	// ShareLink の依存関係注入
+	shareLinkRepo := repository.NewShareLinkRepository(db)
+	// 公開範囲の部分木だけで計算した配置がプロジェクトのツリーの計算結果と混ざらないように、レイアウトは別のインスタンスにする
+	shareLinkUsecase := usecase.NewShareLinkUsecase(shareLinkRepo, projectRepo, chatRepo, messageRepo, edgeRepo, layout.NewTidyTree(cfg.Layout))
+	shareLinkHandler := handler.NewShareLinkHandler(shareLinkUsecase)
+
 	// Middleware の初期化
-	authMiddleware := internalMiddleware.NewAuthMiddleware(cfg)
+	authMiddleware := internalMiddleware.NewAuthMiddleware(cfg, authUsecase, apiTokenUsecase)
+	languageMiddleware := internalMiddleware.NewLanguageMiddleware(userRepo, cfg.Prompt.DefaultLanguage)
+	projectAccessMiddleware := internalMiddleware.NewProjectAccessMiddleware(projectMemberUsecase)
+	canView := projectAccessMiddleware.RequireRole(domainModel.ProjectRoleViewer)
+	canEdit := projectAccessMiddleware.RequireRole(domainModel.ProjectRoleEditor)
+	canManage := projectAccessMiddleware.RequireRole(domainModel.ProjectRoleOwner)
+	e.Use(languageMiddleware.Detect)
 
 	e.GET("/health", h.HealthCheck)
 
@@ -92,47 +141,144 @@ func InitRoutes(e *echo.Echo, db *gorm.DB, cfg *config.Config, genaiClient *gena
 		auth_router.POST("/signup", authHandler.Signup)
 		// ゲストでログインする機能
 		auth_router.POST("/login", authHandler.Login)
+		// OIDCプロバイダーの認可エンドポイントへリダイレクトする機能
+		auth_router.GET("/oidc/:provider/login", authHandler.OIDCLogin)
+		// OIDCプロバイダーからのコールバックを受け取りログインする機能
+		auth_router.GET("/oidc/:provider/callback", authHandler.OIDCCallback)
+		// ログイン中のゲストユーザーにOIDCアカウントを紐付ける機能
+		auth_router.GET("/oidc/:provider/link", authHandler.OIDCLink, authMiddleware.Authenticate, authMiddleware.RequireSession)
+		// リフレッシュトークンでアクセストークンを再発行する機能
+		auth_router.POST("/refresh", authHandler.Refresh)
+		// ログアウトしてリフレッシュトークンを失効させる機能
+		auth_router.POST("/logout", authHandler.Logout)
+		// ログイン中のユーザーのセッション一覧を取得する機能
+		auth_router.GET("/sessions", authHandler.ListSessions, authMiddleware.Authenticate, authMiddleware.RequireSession, languageMiddleware.ApplyUserPreference)
+		// ログイン中のユーザーのセッションを失効させる機能
+		auth_router.DELETE("/sessions/:session_uuid", authHandler.RevokeSession, authMiddleware.Authenticate, authMiddleware.RequireSession, languageMiddleware.ApplyUserPreference)
+	}
+
+	// user関連
+	{
+		user_router := e.Group("/api/users")
+		user_router.Use(authMiddleware.Authenticate, languageMiddleware.ApplyUserPreference)
+		// ログイン中のユーザーのプロフィールを取得する
+		user_router.GET("/me", userHandler.GetProfile)
+		// ログイン中のユーザーの優先言語を設定する
+		user_router.PUT("/me/language", userHandler.UpdateLanguage)
+		// APIトークンを発行する (APIトークン自身では操作できない)
+		user_router.POST("/me/tokens", apiTokenHandler.CreateToken, authMiddleware.RequireSession)
+		// APIトークン一覧を取得する
+		user_router.GET("/me/tokens", apiTokenHandler.ListTokens, authMiddleware.RequireSession)
+		// APIトークンを失効させる
+		user_router.DELETE("/me/tokens/:token_uuid", apiTokenHandler.RevokeToken, authMiddleware.RequireSession)
+		// APIトークンの監査ログを取得する
+		user_router.GET("/me/tokens/:token_uuid/audit-logs", apiTokenHandler.ListAuditLogs, authMiddleware.RequireSession)
+		// ログイン中のユーザー宛てのプロジェクト招待一覧を取得する
+		user_router.GET("/me/invitations", projectMemberHandler.ListInvitations)
+		// プロジェクトへの招待を承認する
+		user_router.POST("/me/invitations/:project_uuid/accept", projectMemberHandler.AcceptInvitation)
+		// プロジェクトへの招待を辞退する
+		user_router.DELETE("/me/invitations/:project_uuid", projectMemberHandler.DeclineInvitation)
 	}
 
 	// project関連
 	{
 		project_router := e.Group("/api/projects")
-		project_router.Use(authMiddleware.Authenticate)
-		// ユーザーが過去に作成したプロジェクト一覧を取得する
+		project_router.Use(authMiddleware.Authenticate, languageMiddleware.ApplyUserPreference)
+		// ユーザーが過去に作成したプロジェクトと共有されたプロジェクトの一覧を取得する
 		project_router.GET("", projectHandler.GetProjects)
 		// 新しいプロジェクトを作成する
 		project_router.POST("", projectHandler.CreateProject)
 		// プロジェクトの親チャットのUUIDを取得する
-		project_router.GET("/:project_uuid", projectHandler.GetParentChat)
+		project_router.GET("/:project_uuid", projectHandler.GetParentChat, canView)
 		// プロジェクトのツリー構造を取得する
-		project_router.GET("/:project_uuid/tree", projectHandler.GetProjectTree)
+		project_router.GET("/:project_uuid/tree", projectHandler.GetProjectTree, canView)
+		// ツリーのノードを本文を省略せずに取得する
+		project_router.GET("/:project_uuid/tree/nodes/:node_id", projectHandler.GetProjectTreeNode, canView)
+		// ユーザーが移動したノードの位置をまとめて保存する
+		project_router.PUT("/:project_uuid/tree/positions", projectHandler.UpdateTreeNodePositions, canEdit)
+		// ノードの折りたたみ状態を保存する
+		project_router.PUT("/:project_uuid/tree/nodes/:node_id/collapsed", projectHandler.SetTreeNodeCollapsed, canEdit)
+		// プロジェクト内で誰がどのチャットを閲覧しているかを取得する
+		project_router.GET("/:project_uuid/presence", collaborationHandler.GetProjectPresence, canView)
+		// プロジェクトのLLM出力言語を設定する
+		project_router.PUT("/:project_uuid/language", projectHandler.UpdateLanguage, canEdit)
+		// プロジェクトのメンバー一覧を取得する
+		project_router.GET("/:project_uuid/members", projectMemberHandler.ListMembers, canView)
+		// ユーザーをプロジェクトに招待する
+		project_router.POST("/:project_uuid/members", projectMemberHandler.InviteMember, canManage)
+		// メンバーのロールを変更する
+		project_router.PUT("/:project_uuid/members/:user_uuid", projectMemberHandler.UpdateMemberRole, canManage)
+		// メンバーを削除する (自分自身はロールに関わらずプロジェクトから抜けられる)
+		project_router.DELETE("/:project_uuid/members/:user_uuid", projectMemberHandler.RemoveMember, canView)
+		// 読み取り専用の共有リンクを発行する
+		project_router.POST("/:project_uuid/share-links", shareLinkHandler.CreateLink, canManage)
+		// 有効な共有リンク一覧を取得する
+		project_router.GET("/:project_uuid/share-links", shareLinkHandler.ListLinks, canManage)
+		// 共有リンクを失効させる
+		project_router.DELETE("/:project_uuid/share-links/:link_uuid", shareLinkHandler.RevokeLink, canManage)
+	}
+
+	// 共有リンク関連 (認証不要・読み取り専用)
+	{
+		share_router := e.Group("/api/share")
+		// 共有されたプロジェクトの概要を取得する
+		share_router.GET("/:token", shareLinkHandler.GetSharedView)
+		// 共有された範囲のツリー構造を取得する
+		share_router.GET("/:token/tree", shareLinkHandler.GetSharedTree)
+		// 共有された範囲のチャットの会話履歴を取得する
+		share_router.GET("/:token/chats/:chat_uuid/messages", shareLinkHandler.GetSharedMessages)
 	}
 
 	// chat関連
 	{
 		chat_router := e.Group("/api/chats")
-		chat_router.Use(authMiddleware.Authenticate)
+		chat_router.Use(authMiddleware.Authenticate, languageMiddleware.ApplyUserPreference)
 		// 特定のチャットの基本情報を取得する機能
-		chat_router.GET("/:chat_uuid", chatHandler.GetChat)
+		chat_router.GET("/:chat_uuid", chatHandler.GetChat, canView)
 		// 特定のチャット内の会話履歴を取得する機能
-		chat_router.GET("/:chat_uuid/messages", chatHandler.GetMessages)
+		chat_router.GET("/:chat_uuid/messages", chatHandler.GetMessages, canView)
+		// 特定のチャットの新しいメッセージ・閲覧者・回答の生成状況をSSEで受け取る機能
+		chat_router.GET("/:chat_uuid/events", collaborationHandler.StreamEvents, canView)
+		// 特定のチャットの閲覧者一覧を取得する機能
+		chat_router.GET("/:chat_uuid/presence", collaborationHandler.GetChatPresence, canView)
+		// 特定のチャットで入力中であることを他の閲覧者に知らせる機能
+		chat_router.POST("/:chat_uuid/typing", collaborationHandler.SetTyping, canEdit)
 		// 特定のチャットにメッセージを送信する機能
-		chat_router.POST("/:chat_uuid/message", chatHandler.SendMessage)
+		chat_router.POST("/:chat_uuid/message", chatHandler.SendMessage, canEdit)
 		// 特定のチャットにLLMによる文章を生成する機能(POST /api/chats/:chat_uuid/message の後に必ず呼び出す)
-		chat_router.GET("/:chat_uuid/messages/stream", chatHandler.StreamMessage)
+		chat_router.GET("/:chat_uuid/messages/stream", chatHandler.StreamMessage, authMiddleware.RequireWriteScope, canEdit)
 		// 特定のチャットにLLMによる文章を生成する機能(初めてのチャット POST /api/projects の後に必ず呼び出す)
-		chat_router.GET("/:chat_uuid/stream", chatHandler.FirstStreamChat)
+		chat_router.GET("/:chat_uuid/stream", chatHandler.FirstStreamChat, authMiddleware.RequireWriteScope, canEdit)
 		// 子チャット開始モーダルで、ユーザーが親チャットの要約を選択した場合、APIが実行され、ユーザーに確認させるためのプレビューを取得する機能
-		chat_router.POST("/:chat_uuid/fork/preview", chatHandler.GenerateForkPreview)
+		chat_router.POST("/:chat_uuid/fork/preview", chatHandler.GenerateForkPreview, canEdit)
+		// AIの長い回答から、子チャットとして分岐させる話題の候補を抽出する機能
+		chat_router.POST("/:chat_uuid/messages/:message_uuid/fork-suggestions", chatHandler.SuggestForks, canEdit)
+		// 抽出済みのフォーク候補を取得する機能
+		chat_router.GET("/:chat_uuid/messages/:message_uuid/fork-suggestions", chatHandler.GetForkSuggestions, canView)
 		// 子チャットを生成する機能
-		chat_router.POST("/:chat_uuid/fork", chatHandler.ForkChat)
+		chat_router.POST("/:chat_uuid/fork", chatHandler.ForkChat, canEdit)
+		// 1つのメッセージから複数の子チャットをまとめて生成する機能
+		chat_router.POST("/:chat_uuid/fork/batch", chatHandler.BatchForkChat, canEdit)
+		// 別のブランチのメッセージを取り込み元を記録して取り込む機能
+		chat_router.POST("/:chat_uuid/cherry-pick", chatHandler.CherryPickMessages, canEdit)
 		// 親にマージボタンを押した際、AIに子チャットの議論の流れと結論を要約を作らせる機能
-		chat_router.POST("/:chat_uuid/merge/preview", chatHandler.GetMergePreview)
+		chat_router.POST("/:chat_uuid/merge/preview", chatHandler.GetMergePreview, canEdit)
 		// 子チャットを親チャットにマージする機能
-		chat_router.POST("/:chat_uuid/merge", chatHandler.MergeChat)
+		chat_router.POST("/:chat_uuid/merge", chatHandler.MergeChat, canEdit)
+		// 子チャットのマージを取り消し、親チャットのマージレポートを取り下げる機能
+		chat_router.POST("/:chat_uuid/unmerge", chatHandler.UnmergeChat, canEdit)
+		// ブランチを同じプロジェクト内の別のメッセージに付け替える機能
+		chat_router.POST("/:chat_uuid/rebase", chatHandler.RebaseChat, canEdit)
+		// 2つのブランチを比較し、共通の祖先メッセージのメモとして保存する機能
+		chat_router.POST("/:chat_uuid/compare", chatHandler.CompareBranches, canEdit)
+		// チャットのメッセージに紐づくブランチの比較結果を取得する機能
+		chat_router.GET("/:chat_uuid/comparisons", chatHandler.GetBranchComparisons, canView)
+		// 子チャットのマージ・マージ取り消しの履歴を取得する機能
+		chat_router.GET("/:chat_uuid/merge-history", chatHandler.GetMergeHistory, canView)
 		// チャットを閉じる機能
-		chat_router.POST("/:chat_uuid/close", chatHandler.CloseChat)
+		chat_router.POST("/:chat_uuid/close", chatHandler.CloseChat, canEdit)
 		// チャットを開く機能
-		chat_router.POST("/:chat_uuid/open", chatHandler.OpenChat)
+		chat_router.POST("/:chat_uuid/open", chatHandler.OpenChat, canEdit)
 	}
 }
@@ -2,6 +2,7 @@ package router
 
 import (
 	"backend/config"
+	"backend/internal/infrastructure/realtime"
 	"testing"
 
 	"github.com/labstack/echo/v4"
@@ -20,7 +21,7 @@ func TestInitRoutes(t *testing.T) {
 	}
 
 	// ルーティングの初期化
-	InitRoutes(e, db, cfg, nil, nil)
+	InitRoutes(e, db, cfg, nil, nil, nil, realtime.NewHub())
 
 	// 期待されるルートの定義
 	// 今後エンドポイントが増えた場合はここに追加する
@@ -44,6 +45,86 @@ func TestInitRoutes(t *testing.T) {
 			path:   "/api/auth/login",
 			name:   "Login",
 		},
+		{
+			method: "GET",
+			path:   "/api/auth/oidc/:provider/login",
+			name:   "OIDCLogin",
+		},
+		{
+			method: "GET",
+			path:   "/api/auth/oidc/:provider/callback",
+			name:   "OIDCCallback",
+		},
+		{
+			method: "GET",
+			path:   "/api/auth/oidc/:provider/link",
+			name:   "OIDCLink",
+		},
+		{
+			method: "POST",
+			path:   "/api/auth/refresh",
+			name:   "Refresh",
+		},
+		{
+			method: "POST",
+			path:   "/api/auth/logout",
+			name:   "Logout",
+		},
+		{
+			method: "GET",
+			path:   "/api/auth/sessions",
+			name:   "ListSessions",
+		},
+		{
+			method: "DELETE",
+			path:   "/api/auth/sessions/:session_uuid",
+			name:   "RevokeSession",
+		},
+		{
+			method: "GET",
+			path:   "/api/users/me",
+			name:   "GetProfile",
+		},
+		{
+			method: "PUT",
+			path:   "/api/users/me/language",
+			name:   "UpdateLanguage",
+		},
+		{
+			method: "POST",
+			path:   "/api/users/me/tokens",
+			name:   "CreateToken",
+		},
+		{
+			method: "GET",
+			path:   "/api/users/me/tokens",
+			name:   "ListTokens",
+		},
+		{
+			method: "DELETE",
+			path:   "/api/users/me/tokens/:token_uuid",
+			name:   "RevokeToken",
+		},
+		{
+			method: "GET",
+			path:   "/api/users/me/tokens/:token_uuid/audit-logs",
+			name:   "ListAuditLogs",
+		},
+		{
+			method: "GET",
+			path:   "/api/users/me/invitations",
+			name:   "ListInvitations",
+		},
+		{
+			method: "POST",
+			path:   "/api/users/me/invitations/:project_uuid/accept",
+			name:   "AcceptInvitation",
+		},
+		{
+			method: "DELETE",
+			path:   "/api/users/me/invitations/:project_uuid",
+			name:   "DeclineInvitation",
+		},
 		{
 			method: "GET",
 			path:   "/api/projects",
@@ -54,6 +135,81 @@ func TestInitRoutes(t *testing.T) {
 			path:   "/api/projects",
 			name:   "CreateProject",
 		},
+		{
+			method: "PUT",
+			path:   "/api/projects/:project_uuid/language",
+			name:   "UpdateLanguage",
+		},
+		{
+			method: "GET",
+			path:   "/api/projects/:project_uuid/tree/nodes/:node_id",
+			name:   "GetProjectTreeNode",
+		},
+		{
+			method: "PUT",
+			path:   "/api/projects/:project_uuid/tree/positions",
+			name:   "UpdateTreeNodePositions",
+		},
+		{
+			method: "PUT",
+			path:   "/api/projects/:project_uuid/tree/nodes/:node_id/collapsed",
+			name:   "SetTreeNodeCollapsed",
+		},
+		{
+			method: "GET",
+			path:   "/api/projects/:project_uuid/members",
+			name:   "ListMembers",
+		},
+		{
+			method: "POST",
+			path:   "/api/projects/:project_uuid/members",
+			name:   "InviteMember",
+		},
+		{
+			method: "PUT",
+			path:   "/api/projects/:project_uuid/members/:user_uuid",
+			name:   "UpdateMemberRole",
+		},
+		{
+			method: "DELETE",
+			path:   "/api/projects/:project_uuid/members/:user_uuid",
+			name:   "RemoveMember",
+		},
+		{
+			method: "GET",
+			path:   "/api/projects/:project_uuid/presence",
+			name:   "GetProjectPresence",
+		},
+		{
+			method: "POST",
+			path:   "/api/projects/:project_uuid/share-links",
+			name:   "CreateLink",
+		},
+		{
+			method: "GET",
+			path:   "/api/projects/:project_uuid/share-links",
+			name:   "ListLinks",
+		},
+		{
+			method: "DELETE",
+			path:   "/api/projects/:project_uuid/share-links/:link_uuid",
+			name:   "RevokeLink",
+		},
+		{
+			method: "GET",
+			path:   "/api/share/:token",
+			name:   "GetSharedView",
+		},
+		{
+			method: "GET",
+			path:   "/api/share/:token/tree",
+			name:   "GetSharedTree",
+		},
+		{
+			method: "GET",
+			path:   "/api/share/:token/chats/:chat_uuid/messages",
+			name:   "GetSharedMessages",
+		},
 		{
 			method: "GET",
 			path:   "/api/chats/:chat_uuid",
@@ -64,6 +220,21 @@ func TestInitRoutes(t *testing.T) {
 			path:   "/api/chats/:chat_uuid/messages",
 			name:   "GetMessages",
 		},
+		{
+			method: "GET",
+			path:   "/api/chats/:chat_uuid/events",
+			name:   "StreamEvents",
+		},
+		{
+			method: "GET",
+			path:   "/api/chats/:chat_uuid/presence",
+			name:   "GetChatPresence",
+		},
+		{
+			method: "POST",
+			path:   "/api/chats/:chat_uuid/typing",
+			name:   "SetTyping",
+		},
 		{
 			method: "POST",
 			path:   "/api/chats/:chat_uuid/message",
@@ -84,11 +255,31 @@ func TestInitRoutes(t *testing.T) {
 			path:   "/api/chats/:chat_uuid/fork/preview",
 			name:   "GenerateForkPreview",
 		},
+		{
+			method: "POST",
+			path:   "/api/chats/:chat_uuid/messages/:message_uuid/fork-suggestions",
+			name:   "SuggestForks",
+		},
+		{
+			method: "GET",
+			path:   "/api/chats/:chat_uuid/messages/:message_uuid/fork-suggestions",
+			name:   "GetForkSuggestions",
+		},
 		{
 			method: "POST",
 			path:   "/api/chats/:chat_uuid/fork",
 			name:   "ForkChat",
 		},
+		{
+			method: "POST",
+			path:   "/api/chats/:chat_uuid/fork/batch",
+			name:   "BatchForkChat",
+		},
+		{
+			method: "POST",
+			path:   "/api/chats/:chat_uuid/cherry-pick",
+			name:   "CherryPickMessages",
+		},
 		{
 			method: "POST",
 			path:   "/api/chats/:chat_uuid/merge/preview",
@@ -99,6 +290,31 @@ func TestInitRoutes(t *testing.T) {
 			path:   "/api/chats/:chat_uuid/merge",
 			name:   "MergeChat",
 		},
+		{
+			method: "POST",
+			path:   "/api/chats/:chat_uuid/unmerge",
+			name:   "UnmergeChat",
+		},
+		{
+			method: "POST",
+			path:   "/api/chats/:chat_uuid/rebase",
+			name:   "RebaseChat",
+		},
+		{
+			method: "POST",
+			path:   "/api/chats/:chat_uuid/compare",
+			name:   "CompareBranches",
+		},
+		{
+			method: "GET",
+			path:   "/api/chats/:chat_uuid/comparisons",
+			name:   "GetBranchComparisons",
+		},
+		{
+			method: "GET",
+			path:   "/api/chats/:chat_uuid/merge-history",
+			name:   "GetMergeHistory",
+		},
 		{
 			method: "POST",
 			path:   "/api/chats/:chat_uuid/close",
@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"backend/internal/domain/model"
+	"backend/internal/domain/repository"
+	"backend/internal/domain/usecase"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// APIトークンの接頭辞 (シークレットスキャン等で識別しやすくするため)
+	apiTokenPrefix = "cbt_"
+	// 一覧で識別するために保存するトークン先頭の文字数
+	apiTokenDisplayLength = 12
+	// APIトークン名の最大文字数
+	apiTokenNameMaxLength = 255
+	// 一度に取得する監査ログの件数
+	apiTokenAuditLogLimit = 100
+)
+
+type apiTokenUsecase struct {
+	apiTokenRepo repository.APITokenRepository
+	projectRepo  repository.ProjectRepository
+	chatRepo     repository.ChatRepository
+}
+
+// APITokenUsecase の新しいインスタンスを作成する処理
+func NewAPITokenUsecase(apiTokenRepo repository.APITokenRepository, projectRepo repository.ProjectRepository, chatRepo repository.ChatRepository) usecase.APITokenUsecase {
+	return &apiTokenUsecase{
+		apiTokenRepo: apiTokenRepo,
+		projectRepo:  projectRepo,
+		chatRepo:     chatRepo,
+	}
+}
+
+// APIトークンを発行する処理
+func (u *apiTokenUsecase) CreateToken(ctx context.Context, userUUID string, params model.CreateAPITokenParams) (*model.APIToken, string, error) {
+	slog.InfoContext(ctx, "APIトークン発行処理を開始", "user_uuid", userUUID, "scope", params.Scope, "project_uuid", params.ProjectUUID)
+	name := strings.TrimSpace(params.Name)
+	if name == "" || utf8.RuneCountInString(name) > apiTokenNameMaxLength {
+		return nil, "", fmt.Errorf("%w: 名前は1〜%d文字で指定してください", model.ErrInvalidAPITokenParams, apiTokenNameMaxLength)
+	}
+	scope := params.Scope
+	if scope == "" {
+		scope = model.APITokenScopeRead
+	}
+	if scope != model.APITokenScopeRead && scope != model.APITokenScopeWrite {
+		return nil, "", fmt.Errorf("%w: 対応していないスコープです: %s", model.ErrInvalidAPITokenParams, scope)
+	}
+	now := time.Now()
+	if params.ExpiresAt != nil && !params.ExpiresAt.After(now) {
+		return nil, "", fmt.Errorf("%w: 有効期限が過去の日時です", model.ErrInvalidAPITokenParams)
+	}
+	if params.ProjectUUID != "" {
+		project, err := u.projectRepo.FindByUUID(ctx, params.ProjectUUID)
+		if err != nil || project.UserUUID != userUUID {
+			return nil, "", fmt.Errorf("%w: %s", model.ErrProjectNotFound, params.ProjectUUID)
+		}
+	}
+
+	rawToken := apiTokenPrefix + randomToken()
+	token := &model.APIToken{
+		UUID:        uuid.New().String(),
+		UserUUID:    userUUID,
+		Name:        name,
+		Prefix:      rawToken[:apiTokenDisplayLength],
+		Scope:       scope,
+		ProjectUUID: params.ProjectUUID,
+		ExpiresAt:   params.ExpiresAt,
+		CreatedAt:   now,
+	}
+	if err := u.apiTokenRepo.Create(ctx, token, hashToken(rawToken)); err != nil {
+		return nil, "", fmt.Errorf("APIトークンの作成に失敗: %w", err)
+	}
+	u.recordEvent(ctx, token, model.APITokenEventCreated)
+
+	slog.InfoContext(ctx, "APIトークンを発行しました", "user_uuid", userUUID, "api_token_uuid", token.UUID)
+	return token, rawToken, nil
+}
+
+// ユーザーの失効していないAPIトークン一覧を取得する処理
+func (u *apiTokenUsecase) ListTokens(ctx context.Context, userUUID string) ([]*model.APIToken, error) {
+	slog.InfoContext(ctx, "APIトークン一覧取得処理を開始", "user_uuid", userUUID)
+	tokens, err := u.apiTokenRepo.FindActiveByUserUUID(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("APIトークン一覧の取得に失敗: %w", err)
+	}
+	return tokens, nil
+}
+
+// ユーザーのAPIトークンを失効させる処理
+func (u *apiTokenUsecase) RevokeToken(ctx context.Context, userUUID string, tokenUUID string) error {
+	slog.InfoContext(ctx, "APIトークン失効処理を開始", "user_uuid", userUUID, "api_token_uuid", tokenUUID)
+	revoked, err := u.apiTokenRepo.Revoke(ctx, userUUID, tokenUUID, time.Now())
+	if err != nil {
+		return fmt.Errorf("APIトークンの失効に失敗: %w", err)
+	}
+	if !revoked {
+		return fmt.Errorf("%w: %s", model.ErrAPITokenNotFound, tokenUUID)
+	}
+	u.recordEvent(ctx, &model.APIToken{UUID: tokenUUID, UserUUID: userUUID}, model.APITokenEventRevoked)
+
+	slog.InfoContext(ctx, "APIトークンを失効しました", "user_uuid", userUUID, "api_token_uuid", tokenUUID)
+	return nil
+}
+
+// ユーザーのAPIトークンの監査ログを取得する処理
+func (u *apiTokenUsecase) ListAuditLogs(ctx context.Context, userUUID string, tokenUUID string) ([]*model.APITokenAuditLog, error) {
+	slog.InfoContext(ctx, "APIトークン監査ログ取得処理を開始", "user_uuid", userUUID, "api_token_uuid", tokenUUID)
+	logs, err := u.apiTokenRepo.FindAuditLogs(ctx, userUUID, tokenUUID, apiTokenAuditLogLimit)
+	if err != nil {
+		return nil, fmt.Errorf("監査ログの取得に失敗: %w", err)
+	}
+	return logs, nil
+}
+
+// Authorization ヘッダーのトークンを検証する処理
+// 最終使用日時の更新に失敗してもリクエストは継続する
+func (u *apiTokenUsecase) Authenticate(ctx context.Context, rawToken string) (*model.APIToken, error) {
+	if !strings.HasPrefix(rawToken, apiTokenPrefix) {
+		return nil, model.ErrInvalidAPIToken
+	}
+	token, err := u.apiTokenRepo.FindByHash(ctx, hashToken(rawToken))
+	if err != nil {
+		return nil, fmt.Errorf("APIトークンの検索に失敗: %w", err)
+	}
+	now := time.Now()
+	if token == nil || token.RevokedAt != nil || (token.ExpiresAt != nil && !now.Before(*token.ExpiresAt)) {
+		return nil, model.ErrInvalidAPIToken
+	}
+
+	if err := u.apiTokenRepo.UpdateLastUsedAt(ctx, token.UUID, now); err != nil {
+		slog.WarnContext(ctx, "APIトークンの最終使用日時の更新に失敗", "api_token_uuid", token.UUID, "error", err)
+	} else {
+		token.LastUsedAt = &now
+	}
+	return token, nil
+}
+
+// APIトークンのスコープでリクエストが許可されているか検証する処理
+// プロジェクトを限定したトークンは、対象のプロジェクトが特定できないリクエストを許可しない
+func (u *apiTokenUsecase) Authorize(ctx context.Context, token *model.APIToken, access model.APITokenAccess) error {
+	if token.Scope != model.APITokenScopeWrite && !isReadOnlyMethod(access.Method) {
+		return fmt.Errorf("%w: 読み取り専用のトークンです", model.ErrAPITokenScopeDenied)
+	}
+	if token.ProjectUUID == "" {
+		return nil
+	}
+
+	projectUUID := access.ProjectUUID
+	if projectUUID == "" && access.ChatUUID != "" {
+		chat, err := u.chatRepo.FindByID(ctx, access.ChatUUID)
+		if err != nil {
+			return fmt.Errorf("%w: チャットのプロジェクトを特定できません", model.ErrAPITokenScopeDenied)
+		}
+		projectUUID = chat.ProjectUUID
+	}
+	if projectUUID != token.ProjectUUID {
+		return fmt.Errorf("%w: 許可されていないプロジェクトです", model.ErrAPITokenScopeDenied)
+	}
+	return nil
+}
+
+// APIトークンの使用を監査ログに記録する処理
+func (u *apiTokenUsecase) RecordUsage(ctx context.Context, log *model.APITokenAuditLog) error {
+	log.UUID = uuid.New().String()
+	log.CreatedAt = time.Now()
+	if err := u.apiTokenRepo.CreateAuditLog(ctx, log); err != nil {
+		return fmt.Errorf("監査ログの記録に失敗: %w", err)
+	}
+	return nil
+}
+
+// 発行・失効のイベントを監査ログに記録する処理 (記録に失敗しても処理は継続する)
+func (u *apiTokenUsecase) recordEvent(ctx context.Context, token *model.APIToken, event string) {
+	err := u.RecordUsage(ctx, &model.APITokenAuditLog{
+		APITokenUUID: token.UUID,
+		UserUUID:     token.UserUUID,
+		Event:        event,
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "APIトークンの監査ログの記録に失敗", "api_token_uuid", token.UUID, "event", event, "error", err)
+	}
+}
+
+// 読み取り専用のHTTPメソッドかどうかを判定する処理
+func isReadOnlyMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
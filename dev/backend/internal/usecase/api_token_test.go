@@ -0,0 +1,288 @@
+package usecase
+
+import (
+	"backend/internal/domain/model"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockAPITokenRepository struct {
+	mock.Mock
+}
+
+func (m *mockAPITokenRepository) Create(ctx context.Context, token *model.APIToken, tokenHash string) error {
+	args := m.Called(ctx, token, tokenHash)
+	return args.Error(0)
+}
+
+func (m *mockAPITokenRepository) FindByHash(ctx context.Context, tokenHash string) (*model.APIToken, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.APIToken), args.Error(1)
+}
+
+func (m *mockAPITokenRepository) FindActiveByUserUUID(ctx context.Context, userUUID string) ([]*model.APIToken, error) {
+	args := m.Called(ctx, userUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.APIToken), args.Error(1)
+}
+
+func (m *mockAPITokenRepository) Revoke(ctx context.Context, userUUID string, tokenUUID string, revokedAt time.Time) (bool, error) {
+	args := m.Called(ctx, userUUID, tokenUUID, revokedAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockAPITokenRepository) UpdateLastUsedAt(ctx context.Context, tokenUUID string, usedAt time.Time) error {
+	args := m.Called(ctx, tokenUUID, usedAt)
+	return args.Error(0)
+}
+
+func (m *mockAPITokenRepository) CreateAuditLog(ctx context.Context, log *model.APITokenAuditLog) error {
+	args := m.Called(ctx, log)
+	return args.Error(0)
+}
+
+func (m *mockAPITokenRepository) FindAuditLogs(ctx context.Context, userUUID string, tokenUUID string, limit int) ([]*model.APITokenAuditLog, error) {
+	args := m.Called(ctx, userUUID, tokenUUID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.APITokenAuditLog), args.Error(1)
+}
+
+func TestAPITokenUsecase_CreateToken(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		params    model.CreateAPITokenParams
+		setupMock func(apiTokenRepo *mockAPITokenRepository, projectRepo *mockProjectRepository)
+		wantScope string
+		wantErr   error
+	}{
+		{
+			name:   "正常系: スコープ未指定の場合読み取り専用で発行されること",
+			params: model.CreateAPITokenParams{Name: "ci", ExpiresAt: &future},
+			setupMock: func(apiTokenRepo *mockAPITokenRepository, projectRepo *mockProjectRepository) {
+				apiTokenRepo.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				apiTokenRepo.On("CreateAuditLog", mock.Anything, mock.MatchedBy(func(log *model.APITokenAuditLog) bool {
+					return log.Event == model.APITokenEventCreated
+				})).Return(nil)
+			},
+			wantScope: model.APITokenScopeRead,
+		},
+		{
+			name:   "正常系: 自分のプロジェクトに限定して発行できること",
+			params: model.CreateAPITokenParams{Name: "ci", Scope: model.APITokenScopeWrite, ProjectUUID: "project-uuid"},
+			setupMock: func(apiTokenRepo *mockAPITokenRepository, projectRepo *mockProjectRepository) {
+				projectRepo.On("FindByUUID", mock.Anything, "project-uuid").Return(&model.Project{UUID: "project-uuid", UserUUID: "user-uuid"}, nil)
+				apiTokenRepo.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				apiTokenRepo.On("CreateAuditLog", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantScope: model.APITokenScopeWrite,
+		},
+		{
+			name:      "異常系: 名前が空の場合エラー",
+			params:    model.CreateAPITokenParams{Name: " "},
+			setupMock: func(apiTokenRepo *mockAPITokenRepository, projectRepo *mockProjectRepository) {},
+			wantErr:   model.ErrInvalidAPITokenParams,
+		},
+		{
+			name:      "異常系: 対応していないスコープの場合エラー",
+			params:    model.CreateAPITokenParams{Name: "ci", Scope: "admin"},
+			setupMock: func(apiTokenRepo *mockAPITokenRepository, projectRepo *mockProjectRepository) {},
+			wantErr:   model.ErrInvalidAPITokenParams,
+		},
+		{
+			name:      "異常系: 有効期限が過去の場合エラー",
+			params:    model.CreateAPITokenParams{Name: "ci", ExpiresAt: &past},
+			setupMock: func(apiTokenRepo *mockAPITokenRepository, projectRepo *mockProjectRepository) {},
+			wantErr:   model.ErrInvalidAPITokenParams,
+		},
+		{
+			name:   "異常系: 他のユーザーのプロジェクトの場合エラー",
+			params: model.CreateAPITokenParams{Name: "ci", ProjectUUID: "project-uuid"},
+			setupMock: func(apiTokenRepo *mockAPITokenRepository, projectRepo *mockProjectRepository) {
+				projectRepo.On("FindByUUID", mock.Anything, "project-uuid").Return(&model.Project{UUID: "project-uuid", UserUUID: "other-user-uuid"}, nil)
+			},
+			wantErr: model.ErrProjectNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiTokenRepo := new(mockAPITokenRepository)
+			projectRepo := new(mockProjectRepository)
+			tt.setupMock(apiTokenRepo, projectRepo)
+
+			u := NewAPITokenUsecase(apiTokenRepo, projectRepo, new(mockChatRepository))
+			token, rawToken, err := u.CreateToken(context.Background(), "user-uuid", tt.params)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, token)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(rawToken, apiTokenPrefix))
+			assert.Equal(t, rawToken[:apiTokenDisplayLength], token.Prefix)
+			assert.Equal(t, tt.wantScope, token.Scope)
+			apiTokenRepo.AssertCalled(t, "Create", mock.Anything, token, hashToken(rawToken))
+			apiTokenRepo.AssertExpectations(t)
+			projectRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAPITokenUsecase_Authenticate(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	rawToken := apiTokenPrefix + "secret"
+
+	tests := []struct {
+		name      string
+		rawToken  string
+		setupMock func(apiTokenRepo *mockAPITokenRepository)
+		wantErr   error
+	}{
+		{
+			name:     "正常系: 有効なトークンの場合最終使用日時が更新されること",
+			rawToken: rawToken,
+			setupMock: func(apiTokenRepo *mockAPITokenRepository) {
+				apiTokenRepo.On("FindByHash", mock.Anything, hashToken(rawToken)).Return(&model.APIToken{UUID: "token-uuid"}, nil)
+				apiTokenRepo.On("UpdateLastUsedAt", mock.Anything, "token-uuid", mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:      "異常系: 接頭辞が異なる場合エラー",
+			rawToken:  "invalid",
+			setupMock: func(apiTokenRepo *mockAPITokenRepository) {},
+			wantErr:   model.ErrInvalidAPIToken,
+		},
+		{
+			name:     "異常系: 存在しないトークンの場合エラー",
+			rawToken: rawToken,
+			setupMock: func(apiTokenRepo *mockAPITokenRepository) {
+				apiTokenRepo.On("FindByHash", mock.Anything, hashToken(rawToken)).Return(nil, nil)
+			},
+			wantErr: model.ErrInvalidAPIToken,
+		},
+		{
+			name:     "異常系: 失効したトークンの場合エラー",
+			rawToken: rawToken,
+			setupMock: func(apiTokenRepo *mockAPITokenRepository) {
+				apiTokenRepo.On("FindByHash", mock.Anything, hashToken(rawToken)).Return(&model.APIToken{UUID: "token-uuid", RevokedAt: &past}, nil)
+			},
+			wantErr: model.ErrInvalidAPIToken,
+		},
+		{
+			name:     "異常系: 有効期限切れのトークンの場合エラー",
+			rawToken: rawToken,
+			setupMock: func(apiTokenRepo *mockAPITokenRepository) {
+				apiTokenRepo.On("FindByHash", mock.Anything, hashToken(rawToken)).Return(&model.APIToken{UUID: "token-uuid", ExpiresAt: &past}, nil)
+			},
+			wantErr: model.ErrInvalidAPIToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiTokenRepo := new(mockAPITokenRepository)
+			tt.setupMock(apiTokenRepo)
+
+			u := NewAPITokenUsecase(apiTokenRepo, new(mockProjectRepository), new(mockChatRepository))
+			token, err := u.Authenticate(context.Background(), tt.rawToken)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, token)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, token.LastUsedAt)
+			apiTokenRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAPITokenUsecase_Authorize(t *testing.T) {
+	tests := []struct {
+		name      string
+		token     *model.APIToken
+		access    model.APITokenAccess
+		setupMock func(chatRepo *mockChatRepository)
+		wantErr   error
+	}{
+		{
+			name:      "正常系: 読み取りスコープでGETが許可されること",
+			token:     &model.APIToken{Scope: model.APITokenScopeRead},
+			access:    model.APITokenAccess{Method: "GET"},
+			setupMock: func(chatRepo *mockChatRepository) {},
+		},
+		{
+			name:   "正常系: チャットからプロジェクトを特定して許可されること",
+			token:  &model.APIToken{Scope: model.APITokenScopeWrite, ProjectUUID: "project-uuid"},
+			access: model.APITokenAccess{Method: "POST", ChatUUID: "chat-uuid"},
+			setupMock: func(chatRepo *mockChatRepository) {
+				chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", ProjectUUID: "project-uuid"}, nil)
+			},
+		},
+		{
+			name:      "異常系: 読み取りスコープでPOSTの場合エラー",
+			token:     &model.APIToken{Scope: model.APITokenScopeRead},
+			access:    model.APITokenAccess{Method: "POST"},
+			setupMock: func(chatRepo *mockChatRepository) {},
+			wantErr:   model.ErrAPITokenScopeDenied,
+		},
+		{
+			name:      "異常系: 別のプロジェクトの場合エラー",
+			token:     &model.APIToken{Scope: model.APITokenScopeRead, ProjectUUID: "project-uuid"},
+			access:    model.APITokenAccess{Method: "GET", ProjectUUID: "other-project-uuid"},
+			setupMock: func(chatRepo *mockChatRepository) {},
+			wantErr:   model.ErrAPITokenScopeDenied,
+		},
+		{
+			name:      "異常系: プロジェクトを特定できないリクエストの場合エラー",
+			token:     &model.APIToken{Scope: model.APITokenScopeRead, ProjectUUID: "project-uuid"},
+			access:    model.APITokenAccess{Method: "GET"},
+			setupMock: func(chatRepo *mockChatRepository) {},
+			wantErr:   model.ErrAPITokenScopeDenied,
+		},
+		{
+			name:   "異常系: チャットが見つからない場合エラー",
+			token:  &model.APIToken{Scope: model.APITokenScopeRead, ProjectUUID: "project-uuid"},
+			access: model.APITokenAccess{Method: "GET", ChatUUID: "chat-uuid"},
+			setupMock: func(chatRepo *mockChatRepository) {
+				chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(nil, errors.New("not found"))
+			},
+			wantErr: model.ErrAPITokenScopeDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chatRepo := new(mockChatRepository)
+			tt.setupMock(chatRepo)
+
+			u := NewAPITokenUsecase(new(mockAPITokenRepository), new(mockProjectRepository), chatRepo)
+			err := u.Authorize(context.Background(), tt.token, tt.access)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			chatRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -6,6 +6,10 @@ import (
 	"backend/internal/domain/repository"
 	"backend/internal/domain/usecase"
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"log/slog"
 	"time"
@@ -14,65 +18,569 @@ import (
 	"github.com/google/uuid"
 )
 
+// OIDCログインの開始からコールバックまでの有効期限
+const oidcFlowExpiration = 10 * time.Minute
+
+// アクセストークンの有効期限が設定されていない場合の既定値
+// ログアウトしたセッションのトークンはミドルウェアで拒否するが、漏洩した場合に使える期間も短くする
+const defaultAccessExpiration = 15 * time.Minute
+
+// リフレッシュトークンの有効期限が設定されていない場合の既定値
+const defaultRefreshExpiration = 30 * 24 * time.Hour
+
 type authUsecase struct {
-	userRepo repository.UserRepository
-	cfg      *config.Config
+	userRepo           repository.UserRepository
+	projectRepo        repository.ProjectRepository
+	projectMemberRepo  repository.ProjectMemberRepository
+	sessionRepo        repository.SessionRepository
+	transactionManager repository.TransactionManager
+	cfg                *config.Config
+	oidcProviders      map[string]usecase.OIDCProvider
 }
 
 // AuthUsecase の新しいインスタンスを作成する処理
-func NewAuthUsecase(userRepo repository.UserRepository, cfg *config.Config) usecase.AuthUsecase {
+func NewAuthUsecase(userRepo repository.UserRepository, projectRepo repository.ProjectRepository, projectMemberRepo repository.ProjectMemberRepository, sessionRepo repository.SessionRepository, transactionManager repository.TransactionManager, cfg *config.Config, oidcProviders map[string]usecase.OIDCProvider) usecase.AuthUsecase {
 	return &authUsecase{
-		userRepo: userRepo,
-		cfg:      cfg,
+		userRepo:           userRepo,
+		projectRepo:        projectRepo,
+		projectMemberRepo:  projectMemberRepo,
+		sessionRepo:        sessionRepo,
+		transactionManager: transactionManager,
+		cfg:                cfg,
+		oidcProviders:      oidcProviders,
 	}
 }
 
 // ゲストユーザーのサインアップ処理
-func (u *authUsecase) GuestSignup(ctx context.Context) (*model.User, string, error) {
+func (u *authUsecase) GuestSignup(ctx context.Context, client model.SessionClient) (*model.User, *model.AuthTokens, error) {
 	slog.InfoContext(ctx, "ゲストサインアップ処理を開始")
 	// ランダムなユーザーを生成
 	userUUID := uuid.New().String()
 	user := &model.User{
-		UUID: userUUID,
-		Name: "Guest-" + userUUID[:8],
+		UUID:     userUUID,
+		Name:     "Guest-" + userUUID[:8],
+		Provider: model.ProviderGuest,
 	}
 
 	if err := u.userRepo.Create(ctx, user); err != nil {
-		return nil, "", fmt.Errorf("ユーザー作成に失敗: %w", err)
+		return nil, nil, fmt.Errorf("ユーザー作成に失敗: %w", err)
 	}
 
-	token, err := u.generateToken(user.UUID)
+	tokens, err := u.startSession(ctx, user.UUID, client)
 	if err != nil {
-		return nil, "", fmt.Errorf("トークン生成に失敗: %w", err)
+		return nil, nil, err
 	}
 
 	slog.InfoContext(ctx, "ゲストユーザーを作成しました", "user_uuid", user.UUID)
-	return user, token, nil
+	return user, tokens, nil
 }
 
 // ゲストユーザーのログイン処理
-func (u *authUsecase) GuestLogin(ctx context.Context, userUUID string) (string, error) {
+func (u *authUsecase) GuestLogin(ctx context.Context, userUUID string, client model.SessionClient) (*model.User, *model.AuthTokens, error) {
 	slog.InfoContext(ctx, "ゲストログイン処理を開始", "user_uuid", userUUID)
 	user, err := u.userRepo.FindByUUID(ctx, userUUID)
 	if err != nil {
-		return "", fmt.Errorf("ユーザー検索に失敗: %w", err)
+		return nil, nil, fmt.Errorf("ユーザー検索に失敗: %w", err)
+	}
+	// OIDCでログインするユーザーや、OIDCアカウントを紐付けたユーザーはUUIDだけではログインさせない
+	if user.Provider != model.ProviderGuest {
+		return nil, nil, fmt.Errorf("%w: %s", model.ErrGuestLoginDenied, user.Provider)
 	}
 
-	token, err := u.generateToken(user.UUID)
+	tokens, err := u.startSession(ctx, user.UUID, client)
 	if err != nil {
-		return "", fmt.Errorf("トークン生成に失敗: %w", err)
+		return nil, nil, err
 	}
 
-	return token, nil
+	return user, tokens, nil
+}
+
+// OIDCログインを開始し、認可エンドポイントのURLを発行する処理
+func (u *authUsecase) StartOIDCLogin(ctx context.Context, providerName string) (*model.OIDCAuthRequest, error) {
+	slog.InfoContext(ctx, "OIDCログイン開始処理を開始", "provider", providerName)
+	return u.startOIDCFlow(ctx, providerName, "")
+}
+
+// ログイン中のゲストユーザーにOIDCアカウントを紐付けるフローを開始する処理
+// 紐付け先のユーザーUUIDはフロートークンに格納し、コールバック時に参照する
+func (u *authUsecase) StartOIDCLink(ctx context.Context, providerName string, userUUID string) (*model.OIDCAuthRequest, error) {
+	slog.InfoContext(ctx, "OIDCアカウント紐付け開始処理を開始", "provider", providerName, "user_uuid", userUUID)
+	user, err := u.userRepo.FindByUUID(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("ユーザー検索に失敗: %w", err)
+	}
+	if user.Provider != model.ProviderGuest {
+		return nil, fmt.Errorf("%w: %s", model.ErrAlreadyLinked, user.Provider)
+	}
+	return u.startOIDCFlow(ctx, providerName, userUUID)
 }
 
-// 指定されたユーザーUUIDのJWTトークンを生成する処理
-func (u *authUsecase) generateToken(userUUID string) (string, error) {
+// 認可エンドポイントのURLとフロートークンを発行する処理
+// state・nonce・code_verifier は署名付きトークンに格納し、コールバック時に検証する
+func (u *authUsecase) startOIDCFlow(ctx context.Context, providerName string, linkUserUUID string) (*model.OIDCAuthRequest, error) {
+	provider, ok := u.oidcProviders[providerName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", model.ErrUnknownProvider, providerName)
+	}
+
+	state := randomToken()
+	nonce := randomToken()
+	codeVerifier := randomToken()
+
+	authURL, err := provider.AuthCodeURL(ctx, state, nonce, codeChallengeS256(codeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("認可URLの生成に失敗: %w", err)
+	}
+
 	claims := jwt.MapClaims{
+		"typ":           "oidc_flow",
+		"provider":      providerName,
+		"state":         state,
+		"nonce":         nonce,
+		"code_verifier": codeVerifier,
+		"exp":           time.Now().Add(oidcFlowExpiration).Unix(),
+	}
+	if linkUserUUID != "" {
+		claims["link_user_uuid"] = linkUserUUID
+	}
+	flowToken, err := u.signToken(claims)
+	if err != nil {
+		return nil, fmt.Errorf("フロートークンの生成に失敗: %w", err)
+	}
+
+	return &model.OIDCAuthRequest{
+		AuthURL:   authURL,
+		FlowToken: flowToken,
+	}, nil
+}
+
+// OIDCプロバイダーからのコールバックを検証し、ユーザーを作成または紐付けてログインする処理
+func (u *authUsecase) OIDCLogin(ctx context.Context, providerName string, code string, state string, flowToken string, client model.SessionClient) (*model.User, *model.AuthTokens, error) {
+	slog.InfoContext(ctx, "OIDCログイン処理を開始", "provider", providerName)
+	provider, ok := u.oidcProviders[providerName]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s", model.ErrUnknownProvider, providerName)
+	}
+
+	claims, err := u.parseFlowToken(flowToken)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", model.ErrInvalidOIDCState, err)
+	}
+	if claims["provider"] != providerName || claims["state"] != state || state == "" {
+		return nil, nil, fmt.Errorf("%w: stateが一致しません", model.ErrInvalidOIDCState)
+	}
+	codeVerifier, _ := claims["code_verifier"].(string)
+	nonce, _ := claims["nonce"].(string)
+
+	identity, err := provider.Exchange(ctx, code, codeVerifier, nonce)
+	if err != nil {
+		return nil, nil, fmt.Errorf("認可コードの交換に失敗: %w", err)
+	}
+
+	var user *model.User
+	if linkUserUUID, _ := claims["link_user_uuid"].(string); linkUserUUID != "" {
+		user, err = u.linkOIDCUser(ctx, linkUserUUID, identity)
+	} else {
+		user, err = u.findOrCreateOIDCUser(ctx, identity)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tokens, err := u.startSession(ctx, user.UUID, client)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	slog.InfoContext(ctx, "OIDCログインに成功", "provider", providerName, "user_uuid", user.UUID)
+	return user, tokens, nil
+}
+
+// OIDCのユーザー情報に対応するユーザーを取得し、存在しなければ作成する処理
+// 既存ユーザーの場合は表示名とアイコン画像をプロバイダーの情報で更新する
+func (u *authUsecase) findOrCreateOIDCUser(ctx context.Context, identity *model.OIDCIdentity) (*model.User, error) {
+	user, err := u.userRepo.FindByProviderSubject(ctx, identity.Provider, identity.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("ユーザー検索に失敗: %w", err)
+	}
+
+	name := identity.Name
+	if name == "" {
+		name = identity.Email
+	}
+
+	if user != nil {
+		if name != "" && (user.Name != name || user.AvatarURL != identity.AvatarURL) {
+			if err := u.userRepo.UpdateProfile(ctx, user.UUID, name, identity.AvatarURL); err != nil {
+				return nil, fmt.Errorf("プロフィール更新に失敗: %w", err)
+			}
+			user.Name = name
+			user.AvatarURL = identity.AvatarURL
+		}
+		return user, nil
+	}
+
+	userUUID := uuid.New().String()
+	if name == "" {
+		name = "User-" + userUUID[:8]
+	}
+	user = &model.User{
+		UUID:      userUUID,
+		Name:      name,
+		Provider:  identity.Provider,
+		Subject:   identity.Subject,
+		AvatarURL: identity.AvatarURL,
+	}
+	if err := u.userRepo.Create(ctx, user); err != nil {
+		return nil, fmt.Errorf("ユーザー作成に失敗: %w", err)
+	}
+
+	slog.InfoContext(ctx, "OIDCユーザーを作成しました", "provider", identity.Provider, "user_uuid", user.UUID)
+	return user, nil
+}
+
+// ゲストユーザーにOIDCのユーザー情報を紐付ける処理
+// 未登録のアカウントであればゲストユーザーをそのまま昇格させ、
+// 登録済みのアカウントであればゲストユーザーのプロジェクトと共有されたプロジェクトのメンバーを移管してゲストユーザーを削除する
+func (u *authUsecase) linkOIDCUser(ctx context.Context, guestUUID string, identity *model.OIDCIdentity) (*model.User, error) {
+	var linked *model.User
+	err := u.transactionManager.Do(ctx, func(ctx context.Context) error {
+		guest, err := u.userRepo.FindByUUID(ctx, guestUUID)
+		if err != nil {
+			return fmt.Errorf("ユーザー検索に失敗: %w", err)
+		}
+		if guest.Provider != model.ProviderGuest {
+			return fmt.Errorf("%w: %s", model.ErrAlreadyLinked, guest.Provider)
+		}
+
+		existing, err := u.userRepo.FindByProviderSubject(ctx, identity.Provider, identity.Subject)
+		if err != nil {
+			return fmt.Errorf("ユーザー検索に失敗: %w", err)
+		}
+
+		if existing == nil {
+			name := identity.Name
+			if name == "" {
+				name = identity.Email
+			}
+			if name == "" {
+				name = guest.Name
+			}
+			if err := u.userRepo.LinkIdentity(ctx, guest.UUID, identity.Provider, identity.Subject, name, identity.AvatarURL); err != nil {
+				return fmt.Errorf("アカウントの紐付けに失敗: %w", err)
+			}
+			guest.Name = name
+			guest.Provider = identity.Provider
+			guest.Subject = identity.Subject
+			guest.AvatarURL = identity.AvatarURL
+			linked = guest
+			slog.InfoContext(ctx, "ゲストユーザーにアカウントを紐付けました", "provider", identity.Provider, "user_uuid", guest.UUID)
+			return nil
+		}
+
+		// ゲストユーザーの削除でメンバーの行も削除されるため、削除する前に移管する
+		owned, err := u.projectRepo.FindAllByUserUUID(ctx, guest.UUID)
+		if err != nil {
+			return fmt.Errorf("プロジェクト一覧の取得に失敗: %w", err)
+		}
+		if err := u.projectRepo.ReassignUser(ctx, guest.UUID, existing.UUID); err != nil {
+			return fmt.Errorf("プロジェクトの移管に失敗: %w", err)
+		}
+		if err := u.mergeProjectMembers(ctx, guest.UUID, existing.UUID, owned); err != nil {
+			return err
+		}
+		if err := u.userRepo.Delete(ctx, guest.UUID); err != nil {
+			return fmt.Errorf("ゲストユーザーの削除に失敗: %w", err)
+		}
+		linked = existing
+		slog.InfoContext(ctx, "ゲストユーザーを既存アカウントに統合しました", "provider", identity.Provider, "guest_user_uuid", guest.UUID, "user_uuid", existing.UUID)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return linked, nil
+}
+
+// ゲストユーザーのプロジェクトのメンバーを統合先のユーザーに移管する処理
+// 統合先のユーザーも同じプロジェクトのメンバーの場合は強いロールを残し、どちらかが承認済みであれば承認済みにする
+// 統合先のユーザーが作成者になったプロジェクト (owned) では作成者が owner になるため、メンバーの行は残さない
+func (u *authUsecase) mergeProjectMembers(ctx context.Context, fromUserUUID string, toUserUUID string, owned []*model.Project) error {
+	for _, project := range owned {
+		if _, err := u.projectMemberRepo.Delete(ctx, project.UUID, toUserUUID); err != nil {
+			return fmt.Errorf("プロジェクトメンバーの削除に失敗: %w", err)
+		}
+	}
+
+	members, err := u.projectMemberRepo.FindByUserUUID(ctx, fromUserUUID)
+	if err != nil {
+		return fmt.Errorf("プロジェクトメンバーの取得に失敗: %w", err)
+	}
+	for _, member := range members {
+		if _, err := u.projectMemberRepo.Delete(ctx, member.ProjectUUID, fromUserUUID); err != nil {
+			return fmt.Errorf("プロジェクトメンバーの削除に失敗: %w", err)
+		}
+
+		project, err := u.projectRepo.FindByUUID(ctx, member.ProjectUUID)
+		if err != nil {
+			return fmt.Errorf("プロジェクトの取得に失敗: %w", err)
+		}
+		if project.UserUUID == toUserUUID {
+			continue
+		}
+
+		current, err := u.projectMemberRepo.Find(ctx, member.ProjectUUID, toUserUUID)
+		if err != nil {
+			return fmt.Errorf("プロジェクトメンバーの取得に失敗: %w", err)
+		}
+		if current == nil {
+			moved := *member
+			moved.UserUUID = toUserUUID
+			if err := u.projectMemberRepo.Create(ctx, &moved); err != nil {
+				return fmt.Errorf("プロジェクトメンバーの移管に失敗: %w", err)
+			}
+			continue
+		}
+		if member.Role != current.Role && model.HasProjectRole(member.Role, current.Role) {
+			if err := u.projectMemberRepo.UpdateRole(ctx, member.ProjectUUID, toUserUUID, member.Role); err != nil {
+				return fmt.Errorf("プロジェクトメンバーのロール更新に失敗: %w", err)
+			}
+		}
+		if member.Status == model.ProjectMemberStatusAccepted && current.Status != model.ProjectMemberStatusAccepted {
+			if _, err := u.projectMemberRepo.Accept(ctx, member.ProjectUUID, toUserUUID); err != nil {
+				return fmt.Errorf("プロジェクトメンバーの承認に失敗: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// OIDCログインのフロートークンを検証してクレームを返す処理
+func (u *authUsecase) parseFlowToken(flowToken string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(flowToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return u.cfg.JWT.VerificationKey(kid)
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, err
+	}
+	if claims["typ"] != "oidc_flow" {
+		return nil, fmt.Errorf("フロートークンではありません")
+	}
+	return claims, nil
+}
+
+// 推測困難なランダム文字列を生成する処理 (state・nonce・code_verifier に使用)
+func randomToken() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// PKCE の code_challenge (S256) を計算する処理
+func codeChallengeS256(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// リフレッシュトークンを使用してアクセストークンを再発行する処理
+// リフレッシュトークンは使用するたびにローテーションし、ローテーション済みのトークンが
+// 再利用された場合は漏洩とみなしてセッションごと失効させる
+func (u *authUsecase) RefreshSession(ctx context.Context, refreshToken string, client model.SessionClient) (*model.AuthTokens, error) {
+	slog.InfoContext(ctx, "トークン再発行処理を開始")
+	if refreshToken == "" {
+		return nil, model.ErrInvalidRefreshToken
+	}
+	tokenHash := hashToken(refreshToken)
+	now := time.Now()
+
+	session, err := u.sessionRepo.FindByRefreshTokenHash(ctx, tokenHash)
+	if err != nil {
+		return nil, fmt.Errorf("セッション検索に失敗: %w", err)
+	}
+	if session == nil {
+		reused, err := u.sessionRepo.FindByPreviousTokenHash(ctx, tokenHash)
+		if err != nil {
+			return nil, fmt.Errorf("セッション検索に失敗: %w", err)
+		}
+		if reused != nil {
+			slog.WarnContext(ctx, "ローテーション済みのリフレッシュトークンが再利用されたためセッションを失効します", "session_uuid", reused.UUID, "user_uuid", reused.UserUUID)
+			if _, err := u.sessionRepo.Revoke(ctx, reused.UserUUID, reused.UUID, now); err != nil {
+				return nil, fmt.Errorf("セッションの失効に失敗: %w", err)
+			}
+		}
+		return nil, model.ErrInvalidRefreshToken
+	}
+	if !session.IsActive(now) {
+		return nil, model.ErrInvalidRefreshToken
+	}
+
+	newRefreshToken := randomToken()
+	refreshExpiresAt := now.Add(u.refreshExpiration())
+	rotated, err := u.sessionRepo.Rotate(ctx, session.UUID, tokenHash, hashToken(newRefreshToken), refreshExpiresAt, now)
+	if err != nil {
+		return nil, fmt.Errorf("リフレッシュトークンのローテーションに失敗: %w", err)
+	}
+	if !rotated {
+		// 同じトークンで同時に再発行された場合
+		return nil, model.ErrInvalidRefreshToken
+	}
+
+	accessToken, accessExpiresAt, err := u.generateAccessToken(session.UserUUID, session.UUID, now)
+	if err != nil {
+		return nil, fmt.Errorf("トークン生成に失敗: %w", err)
+	}
+
+	slog.InfoContext(ctx, "トークンを再発行しました", "session_uuid", session.UUID, "user_uuid", session.UserUUID)
+	return &model.AuthTokens{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessExpiresAt,
+		RefreshToken:          newRefreshToken,
+		RefreshTokenExpiresAt: refreshExpiresAt,
+		SessionUUID:           session.UUID,
+	}, nil
+}
+
+// リフレッシュトークンのセッションを失効させてログアウトする処理
+// 既に失効している場合や該当するセッションがない場合も成功とする
+func (u *authUsecase) Logout(ctx context.Context, refreshToken string) error {
+	slog.InfoContext(ctx, "ログアウト処理を開始")
+	if refreshToken == "" {
+		return nil
+	}
+
+	session, err := u.sessionRepo.FindByRefreshTokenHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		return fmt.Errorf("セッション検索に失敗: %w", err)
+	}
+	if session == nil {
+		return nil
+	}
+
+	if _, err := u.sessionRepo.Revoke(ctx, session.UserUUID, session.UUID, time.Now()); err != nil {
+		return fmt.Errorf("セッションの失効に失敗: %w", err)
+	}
+
+	slog.InfoContext(ctx, "ログアウトしました", "session_uuid", session.UUID, "user_uuid", session.UserUUID)
+	return nil
+}
+
+// ユーザーの有効なセッション一覧を取得する処理
+func (u *authUsecase) ListSessions(ctx context.Context, userUUID string) ([]*model.Session, error) {
+	slog.InfoContext(ctx, "セッション一覧取得処理を開始", "user_uuid", userUUID)
+	sessions, err := u.sessionRepo.FindActiveByUserUUID(ctx, userUUID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("セッション一覧の取得に失敗: %w", err)
+	}
+	return sessions, nil
+}
+
+// ユーザーのセッションを指定して失効させる処理
+func (u *authUsecase) RevokeSession(ctx context.Context, userUUID string, sessionUUID string) error {
+	slog.InfoContext(ctx, "セッション失効処理を開始", "user_uuid", userUUID, "session_uuid", sessionUUID)
+	revoked, err := u.sessionRepo.Revoke(ctx, userUUID, sessionUUID, time.Now())
+	if err != nil {
+		return fmt.Errorf("セッションの失効に失敗: %w", err)
+	}
+	if !revoked {
+		return fmt.Errorf("%w: %s", model.ErrSessionNotFound, sessionUUID)
+	}
+	slog.InfoContext(ctx, "セッションを失効しました", "user_uuid", userUUID, "session_uuid", sessionUUID)
+	return nil
+}
+
+// アクセストークンのセッションが失効していないことを確認する処理
+// ログアウト・セッションの失効後は、アクセストークンの有効期限内でも拒否する
+func (u *authUsecase) ValidateSession(ctx context.Context, userUUID string, sessionUUID string) error {
+	session, err := u.sessionRepo.FindByUUID(ctx, sessionUUID)
+	if err != nil {
+		return fmt.Errorf("セッション検索に失敗: %w", err)
+	}
+	if session == nil || session.UserUUID != userUUID {
+		return fmt.Errorf("%w: %s", model.ErrSessionNotFound, sessionUUID)
+	}
+	if !session.IsActive(time.Now()) {
+		return fmt.Errorf("%w: %s", model.ErrSessionRevoked, sessionUUID)
+	}
+	return nil
+}
+
+// 新しいセッションを作成し、アクセストークンとリフレッシュトークンを発行する処理
+func (u *authUsecase) startSession(ctx context.Context, userUUID string, client model.SessionClient) (*model.AuthTokens, error) {
+	now := time.Now()
+	refreshToken := randomToken()
+	session := &model.Session{
+		UUID:       uuid.New().String(),
+		UserUUID:   userUUID,
+		UserAgent:  client.UserAgent,
+		IPAddress:  client.IPAddress,
+		ExpiresAt:  now.Add(u.refreshExpiration()),
+		LastUsedAt: now,
+		CreatedAt:  now,
+	}
+	if err := u.sessionRepo.Create(ctx, session, hashToken(refreshToken)); err != nil {
+		return nil, fmt.Errorf("セッション作成に失敗: %w", err)
+	}
+
+	accessToken, accessExpiresAt, err := u.generateAccessToken(userUUID, session.UUID, now)
+	if err != nil {
+		return nil, fmt.Errorf("トークン生成に失敗: %w", err)
+	}
+
+	return &model.AuthTokens{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  accessExpiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: session.ExpiresAt,
+		SessionUUID:           session.UUID,
+	}, nil
+}
+
+// 指定されたユーザーUUIDとセッションのアクセストークンを生成する処理
+func (u *authUsecase) generateAccessToken(userUUID string, sessionUUID string, now time.Time) (string, time.Time, error) {
+	expiresAt := now.Add(u.accessExpiration())
+	token, err := u.signToken(jwt.MapClaims{
 		"user_uuid": userUUID,
-		"exp":       time.Now().Add(u.cfg.JWT.Expiration).Unix(),
+		"sid":       sessionUUID,
+		"jti":       uuid.New().String(),
+		"iat":       now.Unix(),
+		"exp":       expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, err
 	}
+	return token, expiresAt, nil
+}
 
+// 現在の署名鍵でトークンに署名する処理 (鍵IDはヘッダーの kid に設定する)
+func (u *authUsecase) signToken(claims jwt.MapClaims) (string, error) {
+	kid, key := u.cfg.JWT.SigningKey()
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(u.cfg.JWT.Secret))
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	return token.SignedString(key)
+}
+
+// アクセストークンの有効期限を返す処理
+func (u *authUsecase) accessExpiration() time.Duration {
+	if u.cfg.JWT.Expiration > 0 {
+		return u.cfg.JWT.Expiration
+	}
+	return defaultAccessExpiration
+}
+
+// リフレッシュトークンの有効期限を返す処理
+func (u *authUsecase) refreshExpiration() time.Duration {
+	if u.cfg.JWT.RefreshExpiration > 0 {
+		return u.cfg.JWT.RefreshExpiration
+	}
+	return defaultRefreshExpiration
+}
+
+// リフレッシュトークンを保存用にハッシュ化する処理
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
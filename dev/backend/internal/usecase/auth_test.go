@@ -3,11 +3,15 @@ package usecase
 import (
 	"backend/config"
 	"backend/internal/domain/model"
+	"backend/internal/domain/usecase"
+	"backend/internal/infrastructure/oidc"
+	"backend/internal/infrastructure/oidc/oidctest"
 	"context"
 	"errors"
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -30,6 +34,92 @@ func (m *mockUserRepository) FindByUUID(ctx context.Context, uuid string) (*mode
 	return args.Get(0).(*model.User), args.Error(1)
 }
 
+func (m *mockUserRepository) FindByProviderSubject(ctx context.Context, provider string, subject string) (*model.User, error) {
+	args := m.Called(ctx, provider, subject)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.User), args.Error(1)
+}
+
+func (m *mockUserRepository) UpdateProfile(ctx context.Context, uuid string, name string, avatarURL string) error {
+	args := m.Called(ctx, uuid, name, avatarURL)
+	return args.Error(0)
+}
+
+func (m *mockUserRepository) UpdateLanguage(ctx context.Context, uuid string, language string) error {
+	args := m.Called(ctx, uuid, language)
+	return args.Error(0)
+}
+
+func (m *mockUserRepository) LinkIdentity(ctx context.Context, uuid string, provider string, subject string, name string, avatarURL string) error {
+	args := m.Called(ctx, uuid, provider, subject, name, avatarURL)
+	return args.Error(0)
+}
+
+func (m *mockUserRepository) Delete(ctx context.Context, uuid string) error {
+	args := m.Called(ctx, uuid)
+	return args.Error(0)
+}
+
+type mockSessionRepository struct {
+	mock.Mock
+}
+
+// セッション作成を常に成功させるモックを作成する処理
+func newMockSessionRepository() *mockSessionRepository {
+	m := new(mockSessionRepository)
+	m.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	return m
+}
+
+func (m *mockSessionRepository) Create(ctx context.Context, session *model.Session, refreshTokenHash string) error {
+	args := m.Called(ctx, session, refreshTokenHash)
+	return args.Error(0)
+}
+
+func (m *mockSessionRepository) FindByUUID(ctx context.Context, sessionUUID string) (*model.Session, error) {
+	args := m.Called(ctx, sessionUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Session), args.Error(1)
+}
+
+func (m *mockSessionRepository) FindByRefreshTokenHash(ctx context.Context, tokenHash string) (*model.Session, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Session), args.Error(1)
+}
+
+func (m *mockSessionRepository) FindByPreviousTokenHash(ctx context.Context, tokenHash string) (*model.Session, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Session), args.Error(1)
+}
+
+func (m *mockSessionRepository) FindActiveByUserUUID(ctx context.Context, userUUID string, now time.Time) ([]*model.Session, error) {
+	args := m.Called(ctx, userUUID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Session), args.Error(1)
+}
+
+func (m *mockSessionRepository) Rotate(ctx context.Context, sessionUUID string, oldHash string, newHash string, expiresAt time.Time, usedAt time.Time) (bool, error) {
+	args := m.Called(ctx, sessionUUID, oldHash, newHash, expiresAt, usedAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockSessionRepository) Revoke(ctx context.Context, userUUID string, sessionUUID string, revokedAt time.Time) (bool, error) {
+	args := m.Called(ctx, userUUID, sessionUUID, revokedAt)
+	return args.Bool(0), args.Error(1)
+}
+
 func TestAuthUsecase_GuestSignup(t *testing.T) {
 	// テスト用の設定
 	cfg := &config.Config{
@@ -73,8 +163,8 @@ func TestAuthUsecase_GuestSignup(t *testing.T) {
 			mockRepo := new(mockUserRepository)
 			tt.setupMock(mockRepo)
 
-			u := NewAuthUsecase(mockRepo, cfg)
-			user, token, err := u.GuestSignup(context.Background())
+			u := NewAuthUsecase(mockRepo, nil, nil, newMockSessionRepository(), nil, cfg, nil)
+			user, tokens, err := u.GuestSignup(context.Background(), model.SessionClient{})
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("authUsecase.GuestSignup() error = %v, wantErr %v", err, tt.wantErr)
@@ -85,7 +175,7 @@ func TestAuthUsecase_GuestSignup(t *testing.T) {
 				assert.NotEmpty(t, user.UUID)
 			}
 			if tt.wantToken {
-				assert.NotEmpty(t, token)
+				assert.NotEmpty(t, tokens.AccessToken)
 			}
 			mockRepo.AssertExpectations(t)
 		})
@@ -106,19 +196,51 @@ func TestAuthUsecase_GuestLogin(t *testing.T) {
 		setupMock func(m *mockUserRepository)
 		wantToken bool
 		wantErr   bool
+		wantErrIs error
 	}{
 		{
 			name:     "正常系: 存在するユーザーでログインできること",
 			userUUID: "test-user-id",
 			setupMock: func(m *mockUserRepository) {
 				m.On("FindByUUID", mock.Anything, "test-user-id").Return(&model.User{
-					UUID: "test-user-id",
-					Name: "Test User",
+					UUID:     "test-user-id",
+					Name:     "Test User",
+					Language: "en",
+					Provider: model.ProviderGuest,
 				}, nil)
 			},
 			wantToken: true,
 			wantErr:   false,
 		},
+		{
+			name:     "異常系: OIDCのユーザーにはUUIDでログインできないこと",
+			userUUID: "oidc-user-id",
+			setupMock: func(m *mockUserRepository) {
+				m.On("FindByUUID", mock.Anything, "oidc-user-id").Return(&model.User{
+					UUID:     "oidc-user-id",
+					Name:     "OIDC User",
+					Provider: "google",
+				}, nil)
+			},
+			wantToken: false,
+			wantErr:   true,
+			wantErrIs: model.ErrGuestLoginDenied,
+		},
+		{
+			name:     "異常系: OIDCアカウントを紐付けたユーザーにはUUIDでログインできないこと",
+			userUUID: "linked-user-id",
+			setupMock: func(m *mockUserRepository) {
+				m.On("FindByUUID", mock.Anything, "linked-user-id").Return(&model.User{
+					UUID:     "linked-user-id",
+					Name:     "Guest-linked",
+					Provider: "github",
+					Subject:  "github-sub",
+				}, nil)
+			},
+			wantToken: false,
+			wantErr:   true,
+			wantErrIs: model.ErrGuestLoginDenied,
+		},
 		{
 			name:     "異常系: 存在しないユーザーの場合エラーになること",
 			userUUID: "non-existent-id",
@@ -135,17 +257,482 @@ func TestAuthUsecase_GuestLogin(t *testing.T) {
 			mockRepo := new(mockUserRepository)
 			tt.setupMock(mockRepo)
 
-			u := NewAuthUsecase(mockRepo, cfg)
-			token, err := u.GuestLogin(context.Background(), tt.userUUID)
+			u := NewAuthUsecase(mockRepo, nil, nil, newMockSessionRepository(), nil, cfg, nil)
+			user, tokens, err := u.GuestLogin(context.Background(), tt.userUUID, model.SessionClient{})
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("authUsecase.GuestLogin() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				assert.Nil(t, tokens)
+			}
 			if tt.wantToken {
-				assert.NotEmpty(t, token)
+				assert.NotEmpty(t, tokens.AccessToken)
+				assert.Equal(t, "en", user.Language)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthUsecase_OIDCLogin(t *testing.T) {
+	cfg := &config.Config{
+		JWT: config.JWTConfig{
+			Secret:     "test-secret",
+			Expiration: time.Hour,
+		},
+	}
+
+	tests := []struct {
+		name string
+		// コールバックに渡す state を書き換える (空の場合は発行された state をそのまま使う)
+		overrideState string
+		setupMock     func(m *mockUserRepository)
+		wantName      string
+		wantErr       error
+	}{
+		{
+			name: "正常系: 初回ログインの場合ユーザーが作成されること",
+			setupMock: func(m *mockUserRepository) {
+				m.On("FindByProviderSubject", mock.Anything, "mock", "test-subject").Return(nil, nil)
+				m.On("Create", mock.Anything, mock.MatchedBy(func(u *model.User) bool {
+					return u.Provider == "mock" && u.Subject == "test-subject" && u.Name == "Test User" && u.AvatarURL == "https://example.com/avatar.png"
+				})).Return(nil)
+			},
+			wantName: "Test User",
+		},
+		{
+			name: "正常系: 既存ユーザーの場合プロフィールが更新されること",
+			setupMock: func(m *mockUserRepository) {
+				m.On("FindByProviderSubject", mock.Anything, "mock", "test-subject").Return(&model.User{
+					UUID:     "existing-user",
+					Name:     "Old Name",
+					Provider: "mock",
+					Subject:  "test-subject",
+				}, nil)
+				m.On("UpdateProfile", mock.Anything, "existing-user", "Test User", "https://example.com/avatar.png").Return(nil)
+			},
+			wantName: "Test User",
+		},
+		{
+			name:          "異常系: state が一致しない場合エラーになること",
+			overrideState: "tampered-state",
+			setupMock:     func(m *mockUserRepository) {},
+			wantErr:       model.ErrInvalidOIDCState,
+		},
+		{
+			name: "異常系: ユーザー作成に失敗した場合エラーになること",
+			setupMock: func(m *mockUserRepository) {
+				m.On("FindByProviderSubject", mock.Anything, "mock", "test-subject").Return(nil, nil)
+				m.On("Create", mock.Anything, mock.Anything).Return(errors.New("db error"))
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idp := oidctest.NewServer(t)
+			provider := oidc.NewProvider("mock", config.OIDCProviderConfig{
+				Issuer:      idp.URL,
+				ClientID:    idp.ClientID,
+				RedirectURL: "http://localhost/api/auth/oidc/mock/callback",
+			}, nil)
+
+			mockRepo := new(mockUserRepository)
+			tt.setupMock(mockRepo)
+			u := NewAuthUsecase(mockRepo, nil, nil, newMockSessionRepository(), nil, cfg, map[string]usecase.OIDCProvider{"mock": provider})
+
+			authReq, err := u.StartOIDCLogin(context.Background(), "mock")
+			if err != nil {
+				t.Fatalf("failed to start oidc login: %v", err)
+			}
+			code, state := idp.Authorize(t, authReq.AuthURL)
+			if tt.overrideState != "" {
+				state = tt.overrideState
+			}
+
+			user, tokens, err := u.OIDCLogin(context.Background(), "mock", code, state, authReq.FlowToken, model.SessionClient{})
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				if errors.Is(tt.wantErr, model.ErrInvalidOIDCState) {
+					assert.ErrorIs(t, err, model.ErrInvalidOIDCState)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotEmpty(t, tokens.AccessToken)
+			assert.Equal(t, tt.wantName, user.Name)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthUsecase_StartOIDCLogin(t *testing.T) {
+	cfg := &config.Config{JWT: config.JWTConfig{Secret: "test-secret"}}
+	u := NewAuthUsecase(new(mockUserRepository), nil, nil, nil, nil, cfg, nil)
+
+	_, err := u.StartOIDCLogin(context.Background(), "unknown")
+	assert.ErrorIs(t, err, model.ErrUnknownProvider)
+}
+
+func TestAuthUsecase_OIDCLink(t *testing.T) {
+	cfg := &config.Config{
+		JWT: config.JWTConfig{
+			Secret:     "test-secret",
+			Expiration: time.Hour,
+		},
+	}
+	tests := []struct {
+		name      string
+		setupMock func(m *mockUserRepository, mProject *mockProjectRepository, mMember *mockProjectMemberRepository)
+		wantUUID  string
+		wantErr   bool
+	}{
+		{
+			name: "正常系: 未登録のアカウントの場合ゲストユーザーに紐付けられること",
+			setupMock: func(m *mockUserRepository, mProject *mockProjectRepository, mMember *mockProjectMemberRepository) {
+				m.On("FindByProviderSubject", mock.Anything, "mock", "test-subject").Return(nil, nil)
+				m.On("LinkIdentity", mock.Anything, "guest-user", "mock", "test-subject", "Test User", "https://example.com/avatar.png").Return(nil)
+			},
+			wantUUID: "guest-user",
+		},
+		{
+			name: "正常系: 登録済みのアカウントの場合プロジェクトが移管されゲストユーザーが削除されること",
+			setupMock: func(m *mockUserRepository, mProject *mockProjectRepository, mMember *mockProjectMemberRepository) {
+				m.On("FindByProviderSubject", mock.Anything, "mock", "test-subject").Return(&model.User{
+					UUID:     "existing-user",
+					Name:     "Test User",
+					Provider: "mock",
+					Subject:  "test-subject",
+				}, nil)
+				mProject.On("FindAllByUserUUID", mock.Anything, "guest-user").Return([]*model.Project{}, nil)
+				mProject.On("ReassignUser", mock.Anything, "guest-user", "existing-user").Return(nil)
+				mMember.On("FindByUserUUID", mock.Anything, "guest-user").Return([]*model.ProjectMember{}, nil)
+				m.On("Delete", mock.Anything, "guest-user").Return(nil)
+			},
+			wantUUID: "existing-user",
+		},
+		{
+			name: "正常系: ゲストユーザーが共有されたプロジェクトのメンバーは削除前に既存アカウントに移管され、強いロールが残ること",
+			setupMock: func(m *mockUserRepository, mProject *mockProjectRepository, mMember *mockProjectMemberRepository) {
+				m.On("FindByProviderSubject", mock.Anything, "mock", "test-subject").Return(&model.User{UUID: "existing-user", Provider: "mock"}, nil)
+				// ゲストユーザーが作成したプロジェクトでは、移管後に既存アカウントが作成者になるためメンバーの行を削除する
+				mProject.On("FindAllByUserUUID", mock.Anything, "guest-user").Return([]*model.Project{{UUID: "guest-project", UserUUID: "guest-user"}}, nil)
+				mProject.On("ReassignUser", mock.Anything, "guest-user", "existing-user").Return(nil)
+				mMember.On("Delete", mock.Anything, "guest-project", "existing-user").Return(true, nil)
+				mMember.On("FindByUserUUID", mock.Anything, "guest-user").Return([]*model.ProjectMember{
+					{ProjectUUID: "other-project", UserUUID: "guest-user", Role: model.ProjectRoleEditor, Status: model.ProjectMemberStatusAccepted, InvitedByUserUUID: "other-user"},
+					{ProjectUUID: "shared-project", UserUUID: "guest-user", Role: model.ProjectRoleEditor, Status: model.ProjectMemberStatusAccepted, InvitedByUserUUID: "other-user"},
+					{ProjectUUID: "existing-project", UserUUID: "guest-user", Role: model.ProjectRoleViewer, Status: model.ProjectMemberStatusAccepted, InvitedByUserUUID: "existing-user"},
+				}, nil)
+				mMember.On("Delete", mock.Anything, mock.Anything, "guest-user").Return(true, nil).Times(3)
+				// 既存アカウントがメンバーでないプロジェクトはゲストユーザーのロールのまま移管する
+				mProject.On("FindByUUID", mock.Anything, "other-project").Return(&model.Project{UUID: "other-project", UserUUID: "other-user"}, nil)
+				mMember.On("Find", mock.Anything, "other-project", "existing-user").Return(nil, nil)
+				mMember.On("Create", mock.Anything, &model.ProjectMember{
+					ProjectUUID: "other-project", UserUUID: "existing-user", Role: model.ProjectRoleEditor, Status: model.ProjectMemberStatusAccepted, InvitedByUserUUID: "other-user",
+				}).Return(nil)
+				// 両方がメンバーのプロジェクトは強いロールと承認済みの状態を残す
+				mProject.On("FindByUUID", mock.Anything, "shared-project").Return(&model.Project{UUID: "shared-project", UserUUID: "other-user"}, nil)
+				mMember.On("Find", mock.Anything, "shared-project", "existing-user").Return(&model.ProjectMember{
+					ProjectUUID: "shared-project", UserUUID: "existing-user", Role: model.ProjectRoleViewer, Status: model.ProjectMemberStatusPending,
+				}, nil)
+				mMember.On("UpdateRole", mock.Anything, "shared-project", "existing-user", model.ProjectRoleEditor).Return(nil)
+				mMember.On("Accept", mock.Anything, "shared-project", "existing-user").Return(true, nil)
+				// 既存アカウントが作成したプロジェクトでは既存アカウントが owner のため移管しない
+				mProject.On("FindByUUID", mock.Anything, "existing-project").Return(&model.Project{UUID: "existing-project", UserUUID: "existing-user"}, nil)
+				m.On("Delete", mock.Anything, "guest-user").Return(nil)
+			},
+			wantUUID: "existing-user",
+		},
+		{
+			name: "異常系: プロジェクトの移管に失敗した場合エラーになること",
+			setupMock: func(m *mockUserRepository, mProject *mockProjectRepository, mMember *mockProjectMemberRepository) {
+				m.On("FindByProviderSubject", mock.Anything, "mock", "test-subject").Return(&model.User{UUID: "existing-user", Provider: "mock"}, nil)
+				mProject.On("FindAllByUserUUID", mock.Anything, "guest-user").Return([]*model.Project{}, nil)
+				mProject.On("ReassignUser", mock.Anything, "guest-user", "existing-user").Return(errors.New("db error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "異常系: プロジェクトメンバーの移管に失敗した場合はゲストユーザーを削除しないこと",
+			setupMock: func(m *mockUserRepository, mProject *mockProjectRepository, mMember *mockProjectMemberRepository) {
+				m.On("FindByProviderSubject", mock.Anything, "mock", "test-subject").Return(&model.User{UUID: "existing-user", Provider: "mock"}, nil)
+				mProject.On("FindAllByUserUUID", mock.Anything, "guest-user").Return([]*model.Project{}, nil)
+				mProject.On("ReassignUser", mock.Anything, "guest-user", "existing-user").Return(nil)
+				mMember.On("FindByUserUUID", mock.Anything, "guest-user").Return(nil, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idp := oidctest.NewServer(t)
+			provider := oidc.NewProvider("mock", config.OIDCProviderConfig{
+				Issuer:      idp.URL,
+				ClientID:    idp.ClientID,
+				RedirectURL: "http://localhost/api/auth/oidc/mock/callback",
+			}, nil)
+
+			mockRepo := new(mockUserRepository)
+			mockProjectRepo := new(mockProjectRepository)
+			mockMemberRepo := new(mockProjectMemberRepository)
+			mockRepo.On("FindByUUID", mock.Anything, "guest-user").Return(&model.User{UUID: "guest-user", Name: "Guest-1234", Provider: model.ProviderGuest}, nil)
+			tt.setupMock(mockRepo, mockProjectRepo, mockMemberRepo)
+			u := NewAuthUsecase(mockRepo, mockProjectRepo, mockMemberRepo, newMockSessionRepository(), new(mockTransactionManager), cfg, map[string]usecase.OIDCProvider{"mock": provider})
+
+			authReq, err := u.StartOIDCLink(context.Background(), "mock", "guest-user")
+			if err != nil {
+				t.Fatalf("failed to start oidc link: %v", err)
+			}
+			code, state := idp.Authorize(t, authReq.AuthURL)
+
+			user, tokens, err := u.OIDCLogin(context.Background(), "mock", code, state, authReq.FlowToken, model.SessionClient{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("authUsecase.OIDCLogin() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+				return
 			}
+			assert.NotEmpty(t, tokens.AccessToken)
+			assert.Equal(t, tt.wantUUID, user.UUID)
 			mockRepo.AssertExpectations(t)
+			mockProjectRepo.AssertExpectations(t)
+			mockMemberRepo.AssertExpectations(t)
 		})
 	}
 }
+
+func TestAuthUsecase_StartOIDCLink(t *testing.T) {
+	cfg := &config.Config{JWT: config.JWTConfig{Secret: "test-secret"}}
+	mockRepo := new(mockUserRepository)
+	mockRepo.On("FindByUUID", mock.Anything, "linked-user").Return(&model.User{UUID: "linked-user", Provider: "google"}, nil)
+	u := NewAuthUsecase(mockRepo, nil, nil, newMockSessionRepository(), nil, cfg, nil)
+
+	_, err := u.StartOIDCLink(context.Background(), "mock", "linked-user")
+	assert.ErrorIs(t, err, model.ErrAlreadyLinked)
+}
+
+func TestAuthUsecase_RefreshSession(t *testing.T) {
+	cfg := &config.Config{
+		JWT: config.JWTConfig{
+			Secret:       "test-secret",
+			Expiration:   15 * time.Minute,
+			CurrentKeyID: "key-2",
+			Keys:         map[string]string{"key-1": "old-secret", "key-2": "new-secret"},
+		},
+	}
+	refreshHash := hashToken("refresh-token")
+	activeSession := func() *model.Session {
+		return &model.Session{UUID: "session-uuid", UserUUID: "user-uuid", ExpiresAt: time.Now().Add(time.Hour)}
+	}
+
+	tests := []struct {
+		name      string
+		setupMock func(m *mockSessionRepository)
+		wantErr   error
+	}{
+		{
+			name: "正常系: リフレッシュトークンがローテーションされアクセストークンが再発行されること",
+			setupMock: func(m *mockSessionRepository) {
+				m.On("FindByRefreshTokenHash", mock.Anything, refreshHash).Return(activeSession(), nil)
+				m.On("Rotate", mock.Anything, "session-uuid", refreshHash, mock.MatchedBy(func(newHash string) bool {
+					return newHash != refreshHash
+				}), mock.Anything, mock.Anything).Return(true, nil)
+			},
+		},
+		{
+			name: "異常系: ローテーション済みのトークンが再利用された場合セッションが失効されること",
+			setupMock: func(m *mockSessionRepository) {
+				m.On("FindByRefreshTokenHash", mock.Anything, refreshHash).Return(nil, nil)
+				m.On("FindByPreviousTokenHash", mock.Anything, refreshHash).Return(activeSession(), nil)
+				m.On("Revoke", mock.Anything, "user-uuid", "session-uuid", mock.Anything).Return(true, nil)
+			},
+			wantErr: model.ErrInvalidRefreshToken,
+		},
+		{
+			name: "異常系: 期限切れのセッションの場合エラーになること",
+			setupMock: func(m *mockSessionRepository) {
+				m.On("FindByRefreshTokenHash", mock.Anything, refreshHash).Return(&model.Session{
+					UUID:      "session-uuid",
+					UserUUID:  "user-uuid",
+					ExpiresAt: time.Now().Add(-time.Minute),
+				}, nil)
+			},
+			wantErr: model.ErrInvalidRefreshToken,
+		},
+		{
+			name: "異常系: 同時に再発行されローテーションできなかった場合エラーになること",
+			setupMock: func(m *mockSessionRepository) {
+				m.On("FindByRefreshTokenHash", mock.Anything, refreshHash).Return(activeSession(), nil)
+				m.On("Rotate", mock.Anything, "session-uuid", refreshHash, mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+			},
+			wantErr: model.ErrInvalidRefreshToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSessionRepo := new(mockSessionRepository)
+			tt.setupMock(mockSessionRepo)
+			u := NewAuthUsecase(new(mockUserRepository), nil, nil, mockSessionRepo, nil, cfg, nil)
+
+			tokens, err := u.RefreshSession(context.Background(), "refresh-token", model.SessionClient{})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockSessionRepo.AssertExpectations(t)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotEqual(t, "refresh-token", tokens.RefreshToken)
+			assert.Equal(t, "session-uuid", tokens.SessionUUID)
+
+			// 現在の鍵IDで署名され、セッションIDが含まれること
+			claims := jwt.MapClaims{}
+			token, err := jwt.ParseWithClaims(tokens.AccessToken, claims, func(token *jwt.Token) (interface{}, error) {
+				return []byte("new-secret"), nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, "key-2", token.Header["kid"])
+			assert.Equal(t, "user-uuid", claims["user_uuid"])
+			assert.Equal(t, "session-uuid", claims["sid"])
+			assert.NotEmpty(t, claims["jti"])
+			mockSessionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthUsecase_Logout(t *testing.T) {
+	tests := []struct {
+		name         string
+		refreshToken string
+		setupMock    func(m *mockSessionRepository)
+		wantErr      bool
+	}{
+		{
+			name:         "正常系: リフレッシュトークンのセッションが失効されること",
+			refreshToken: "refresh-token",
+			setupMock: func(m *mockSessionRepository) {
+				m.On("FindByRefreshTokenHash", mock.Anything, hashToken("refresh-token")).Return(&model.Session{UUID: "session-uuid", UserUUID: "user-uuid"}, nil)
+				m.On("Revoke", mock.Anything, "user-uuid", "session-uuid", mock.Anything).Return(true, nil)
+			},
+		},
+		{
+			name:         "正常系: セッションが存在しない場合も成功すること",
+			refreshToken: "unknown-token",
+			setupMock: func(m *mockSessionRepository) {
+				m.On("FindByRefreshTokenHash", mock.Anything, hashToken("unknown-token")).Return(nil, nil)
+			},
+		},
+		{
+			name:         "正常系: リフレッシュトークンがない場合は何もしないこと",
+			refreshToken: "",
+			setupMock:    func(m *mockSessionRepository) {},
+		},
+		{
+			name:         "異常系: 失効に失敗した場合エラーになること",
+			refreshToken: "refresh-token",
+			setupMock: func(m *mockSessionRepository) {
+				m.On("FindByRefreshTokenHash", mock.Anything, hashToken("refresh-token")).Return(&model.Session{UUID: "session-uuid", UserUUID: "user-uuid"}, nil)
+				m.On("Revoke", mock.Anything, "user-uuid", "session-uuid", mock.Anything).Return(false, errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSessionRepo := new(mockSessionRepository)
+			tt.setupMock(mockSessionRepo)
+			u := NewAuthUsecase(new(mockUserRepository), nil, nil, mockSessionRepo, nil, &config.Config{}, nil)
+
+			err := u.Logout(context.Background(), tt.refreshToken)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("authUsecase.Logout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			mockSessionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthUsecase_RevokeSession(t *testing.T) {
+	mockSessionRepo := new(mockSessionRepository)
+	mockSessionRepo.On("Revoke", mock.Anything, "user-uuid", "other-session", mock.Anything).Return(false, nil)
+	u := NewAuthUsecase(new(mockUserRepository), nil, nil, mockSessionRepo, nil, &config.Config{}, nil)
+
+	err := u.RevokeSession(context.Background(), "user-uuid", "other-session")
+	assert.ErrorIs(t, err, model.ErrSessionNotFound)
+}
+
+func TestAuthUsecase_ValidateSession(t *testing.T) {
+	now := time.Now()
+	revokedAt := now.Add(-time.Minute)
+
+	tests := []struct {
+		name    string
+		session *model.Session
+		wantErr error
+	}{
+		{
+			name:    "正常系: 有効なセッションの場合エラーにならないこと",
+			session: &model.Session{UUID: "session-uuid", UserUUID: "user-uuid", ExpiresAt: now.Add(time.Hour)},
+		},
+		{
+			name:    "異常系: ログアウトで失効したセッションの場合エラー",
+			session: &model.Session{UUID: "session-uuid", UserUUID: "user-uuid", ExpiresAt: now.Add(time.Hour), RevokedAt: &revokedAt},
+			wantErr: model.ErrSessionRevoked,
+		},
+		{
+			name:    "異常系: 期限切れのセッションの場合エラー",
+			session: &model.Session{UUID: "session-uuid", UserUUID: "user-uuid", ExpiresAt: now.Add(-time.Hour)},
+			wantErr: model.ErrSessionRevoked,
+		},
+		{
+			name:    "異常系: 他のユーザーのセッションの場合エラー",
+			session: &model.Session{UUID: "session-uuid", UserUUID: "other-user", ExpiresAt: now.Add(time.Hour)},
+			wantErr: model.ErrSessionNotFound,
+		},
+		{
+			name:    "異常系: セッションが存在しない場合エラー",
+			session: nil,
+			wantErr: model.ErrSessionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSessionRepo := new(mockSessionRepository)
+			if tt.session != nil {
+				mockSessionRepo.On("FindByUUID", mock.Anything, "session-uuid").Return(tt.session, nil)
+			} else {
+				mockSessionRepo.On("FindByUUID", mock.Anything, "session-uuid").Return(nil, nil)
+			}
+			u := NewAuthUsecase(new(mockUserRepository), nil, nil, mockSessionRepo, nil, &config.Config{}, nil)
+
+			err := u.ValidateSession(context.Background(), "user-uuid", "session-uuid")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAuthUsecase_DefaultAccessExpiration(t *testing.T) {
+	mockRepo := new(mockUserRepository)
+	mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	// アクセストークンの有効期限を設定していない場合は短い既定値にすること
+	u := NewAuthUsecase(mockRepo, nil, nil, newMockSessionRepository(), nil, &config.Config{JWT: config.JWTConfig{Secret: "test-secret"}}, nil)
+
+	before := time.Now()
+	_, tokens, err := u.GuestSignup(context.Background(), model.SessionClient{})
+	assert.NoError(t, err)
+	assert.WithinDuration(t, before.Add(defaultAccessExpiration), tokens.AccessTokenExpiresAt, 2*time.Second)
+}
@@ -10,7 +10,11 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"sort"
+	"strings"
 	"time"
+	"unicode/utf16"
+	"unicode/utf8"
 
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/google/uuid"
@@ -39,35 +43,175 @@ type chatUsecase struct {
 	messageRepo          repository.MessageRepository
 	messageSelectionRepo repository.MessageSelectionRepository
 	edgeRepo             repository.EdgeRepository
+	chatMergeEventRepo   repository.ChatMergeEventRepository
+	branchComparisonRepo repository.BranchComparisonRepository
+	forkSuggestionRepo   repository.ForkSuggestionRepository
+	projectRepo          repository.ProjectRepository
+	userRepo             repository.UserRepository
 	transactionManager   repository.TransactionManager
 	genaiClient          domainUsecase.GenAIClient
 	publisher            message.Publisher
+	promptRenderer       domainUsecase.PromptRenderer
+	eventHub             domainUsecase.ChatEventHub
 }
 
+// 回答生成のロックの有効期限 (生成中にプロセスが停止してもロックが残り続けないようにする)
+const chatGenerationLockTTL = 5 * time.Minute
+
+// 回答の受信中に回答生成のロックを延長する間隔 (有効期限より十分短くする)
+const chatGenerationLockRenewInterval = time.Minute
+
+// フォーク候補を自動で抽出する回答の最小文字数
+const forkSuggestionMinLength = 1000
+
+// 1つの回答から抽出するフォーク候補の最大数
+const maxForkSuggestions = 5
+
+// 一括フォークで一度に作成できるブランチの最大数
+const maxBatchForks = 10
+
 func NewChatUsecase(
 	chatRepo repository.ChatRepository,
 	messageRepo repository.MessageRepository,
 	messageSelectionRepo repository.MessageSelectionRepository,
 	edgeRepo repository.EdgeRepository,
+	chatMergeEventRepo repository.ChatMergeEventRepository,
+	branchComparisonRepo repository.BranchComparisonRepository,
+	forkSuggestionRepo repository.ForkSuggestionRepository,
+	projectRepo repository.ProjectRepository,
+	userRepo repository.UserRepository,
 	transactionManager repository.TransactionManager,
 	genaiClient domainUsecase.GenAIClient,
 	publisher message.Publisher,
+	promptRenderer domainUsecase.PromptRenderer,
+	eventHub domainUsecase.ChatEventHub,
 ) domainUsecase.ChatUsecase {
 	return &chatUsecase{
 		chatRepo:             chatRepo,
 		messageRepo:          messageRepo,
 		messageSelectionRepo: messageSelectionRepo,
 		edgeRepo:             edgeRepo,
+		chatMergeEventRepo:   chatMergeEventRepo,
+		branchComparisonRepo: branchComparisonRepo,
+		forkSuggestionRepo:   forkSuggestionRepo,
+		projectRepo:          projectRepo,
+		userRepo:             userRepo,
 		transactionManager:   transactionManager,
 		genaiClient:          genaiClient,
 		publisher:            publisher,
+		promptRenderer:       promptRenderer,
+		eventHub:             eventHub,
+	}
+}
+
+// 取得した回答生成のロック
+type generationLock struct {
+	chatUUID string
+	// ロックを取得した生成を識別する値 (延長と解放は同じ値のロックだけを対象にする)
+	owner string
+	// 最後にロックを取得・延長した時刻
+	renewedAt time.Time
+}
+
+// 回答生成のロックを取得する処理
+// 同じチャットで同時に生成すると、エッジが古い履歴を元に計算されるため一方のみ許可する
+func (u *chatUsecase) lockGeneration(ctx context.Context, chatUUID string) (*generationLock, error) {
+	now := time.Now()
+	lock := &generationLock{chatUUID: chatUUID, owner: uuid.New().String(), renewedAt: now}
+	acquired, err := u.chatRepo.AcquireGenerationLock(ctx, chatUUID, lock.owner, now, now.Add(chatGenerationLockTTL))
+	if err != nil {
+		return nil, fmt.Errorf("回答生成ロックの取得に失敗: %w", err)
+	}
+	if !acquired {
+		return nil, fmt.Errorf("%w: %s", model.ErrChatGenerationInProgress, chatUUID)
+	}
+	u.publishEvent(model.ChatEvent{Type: model.ChatEventGenerationStarted, ChatUUID: chatUUID})
+	return lock, nil
+}
+
+// 回答の受信中に回答生成のロックを延長する処理
+// 前回の延長から間隔が空いていない場合は何もしない
+// 期限が切れて別の生成がロックを取得していた場合は、生成を続けずにエラーを返す
+func (u *chatUsecase) extendGenerationLock(ctx context.Context, lock *generationLock) error {
+	now := time.Now()
+	if now.Sub(lock.renewedAt) < chatGenerationLockRenewInterval {
+		return nil
+	}
+	extended, err := u.chatRepo.ExtendGenerationLock(ctx, lock.chatUUID, lock.owner, now.Add(chatGenerationLockTTL))
+	if err != nil {
+		return fmt.Errorf("回答生成ロックの延長に失敗: %w", err)
+	}
+	if !extended {
+		return fmt.Errorf("%w: ロックの期限が切れました: %s", model.ErrChatGenerationInProgress, lock.chatUUID)
+	}
+	lock.renewedAt = now
+	return nil
+}
+
+// 回答生成のロックを解放する処理
+func (u *chatUsecase) releaseGenerationLock(ctx context.Context, lock *generationLock) {
+	// クライアントが切断した場合もロックは解放する
+	releaseCtx := context.WithoutCancel(ctx)
+	if err := u.chatRepo.ReleaseGenerationLock(releaseCtx, lock.chatUUID, lock.owner); err != nil {
+		slog.ErrorContext(releaseCtx, "回答生成ロックの解放に失敗しました", "chat_uuid", lock.chatUUID, "error", err)
+	}
+	u.publishEvent(model.ChatEvent{Type: model.ChatEventGenerationFinished, ChatUUID: lock.chatUUID})
+}
+
+// チャットの閲覧者にイベントを配信する処理 (ハブが設定されていない場合は何もしない)
+func (u *chatUsecase) publishEvent(event model.ChatEvent) {
+	if u.eventHub == nil {
+		return
+	}
+	u.eventHub.Publish(event)
+}
+
+// チャットの出力言語を決定する
+// プロジェクト > プロジェクト所有ユーザーの優先言語 > 既定の言語 の順に使用する
+func (u *chatUsecase) resolveLanguage(ctx context.Context, chat *model.Chat) string {
+	project, err := u.projectRepo.FindByUUID(ctx, chat.ProjectUUID)
+	if err != nil {
+		slog.WarnContext(ctx, "プロジェクト取得失敗のため既定の言語を使用", "project_uuid", chat.ProjectUUID, "error", err)
+		return u.promptRenderer.DefaultLanguage()
+	}
+	if project.Language != "" {
+		return project.Language
+	}
+
+	user, err := u.userRepo.FindByUUID(ctx, project.UserUUID)
+	if err != nil {
+		slog.WarnContext(ctx, "ユーザー取得失敗のため既定の言語を使用", "user_uuid", project.UserUUID, "error", err)
+		return u.promptRenderer.DefaultLanguage()
 	}
+	if user.Language != "" {
+		return user.Language
+	}
+	return u.promptRenderer.DefaultLanguage()
 }
 
 // ユーザーのメッセージを元に、GenAI にストリームを送信する
 func (u *chatUsecase) StreamMessage(ctx context.Context, chatUUID string, outputChan chan<- string) error {
 	slog.InfoContext(ctx, "メッセージストリーム処理開始", "chat_uuid", chatUUID)
 
+	// 0. チャットと出力言語の取得
+	chat, err := u.chatRepo.FindByID(ctx, chatUUID)
+	if err != nil {
+		slog.ErrorContext(ctx, "チャット取得失敗", "chat_uuid", chatUUID, "error", err)
+		return err
+	}
+	if !chat.IsWritable() {
+		slog.WarnContext(ctx, "open 以外のチャットには回答を生成しません", "chat_uuid", chatUUID, "status", chat.Status)
+		return fmt.Errorf("%w: %s", model.ErrChatNotOpen, chatUUID)
+	}
+	language := u.resolveLanguage(ctx, chat)
+
+	lock, err := u.lockGeneration(ctx, chatUUID)
+	if err != nil {
+		slog.WarnContext(ctx, "回答生成ロックを取得できません", "chat_uuid", chatUUID, "error", err)
+		return err
+	}
+	defer u.releaseGenerationLock(ctx, lock)
+
 	// 1. 最新のサマリを持つメッセージを取得
 	latestSummaryMessage, err := u.messageRepo.FindLatestMessageWithSummary(ctx, chatUUID)
 	if err != nil {
@@ -103,26 +247,29 @@ func (u *chatUsecase) StreamMessage(ctx context.Context, chatUUID string, output
 
 	// サマリがあれば追加
 	if latestSummaryMessage != nil && latestSummaryMessage.ContextSummary != nil {
+		summaryPrompt, err := u.promptRenderer.Render(model.PromptStreamSummaryContext, language, map[string]string{
+			"Summary": *latestSummaryMessage.ContextSummary,
+		})
+		if err != nil {
+			slog.ErrorContext(ctx, "プロンプトのレンダリングに失敗", "chat_uuid", chatUUID, "error", err)
+			return err
+		}
 		parts = append(parts, &genai.Content{
 			Role: "user",
 			Parts: []*genai.Part{
-				{Text: "以下の会話の要約を踏まえて回答してください:\n" + *latestSummaryMessage.ContextSummary},
+				{Text: summaryPrompt.Text},
 			},
 		})
 	}
 
-	for _, msg := range contextMessages {
-		role := "user"
-		if msg.Role == "assistant" {
-			role = "model"
-		}
-		parts = append(parts, &genai.Content{
-			Role: role,
-			Parts: []*genai.Part{
-				{Text: msg.Content},
-			},
-		})
+	// 最後がマージレポートの場合は、レポートの結論を会話に統合する回答を生成する
+	acknowledge := len(contextMessages) > 0 && contextMessages[len(contextMessages)-1].Role == "merge_report"
+	historyParts, err := u.buildHistoryContents(language, contextMessages, allMessages, acknowledge)
+	if err != nil {
+		slog.ErrorContext(ctx, "プロンプトのレンダリングに失敗", "chat_uuid", chatUUID, "error", err)
+		return err
 	}
+	parts = append(parts, historyParts...)
 
 	// 4. GenAI 呼び出し
 	client := u.genaiClient
@@ -144,6 +291,10 @@ func (u *chatUsecase) StreamMessage(ctx context.Context, chatUUID string, output
 			slog.ErrorContext(ctx, "GenAI APIからの受信エラー", "error", err)
 			return err
 		}
+		if err := u.extendGenerationLock(ctx, lock); err != nil {
+			slog.WarnContext(ctx, "回答生成ロックを延長できません", "chat_uuid", chatUUID, "error", err)
+			return err
+		}
 		for _, cand := range resp.Candidates {
 			if cand.Content != nil {
 				for _, part := range cand.Content.Parts {
@@ -156,33 +307,18 @@ func (u *chatUsecase) StreamMessage(ctx context.Context, chatUUID string, output
 	}
 
 	// 5. 生成された文章の保存
-	// 位置計算
-	chat, err := u.chatRepo.FindByID(ctx, chatUUID)
+	// 生成中に追加されたメッセージを反映するため、履歴を取得し直してエッジを計算する
+	latestMessages, err := u.messageRepo.FindMessagesByChatID(ctx, chatUUID)
 	if err != nil {
-		slog.ErrorContext(ctx, "チャット取得失敗", "chat_uuid", chatUUID, "error", err)
+		slog.ErrorContext(ctx, "メッセージ履歴取得失敗", "chat_uuid", chatUUID, "error", err)
 		return err
 	}
 
-	assistantCount := 0
-	for _, msg := range allMessages {
-		if msg.Role == "assistant" {
-			assistantCount++
-		}
-	}
-
-	// ノード間距離(50)を考慮
-	// PositionX = chat.PositionX
-	// PositionY = chat.PositionY + assistantCount * (100 + 50)
-	positionX := chat.PositionX
-	positionY := chat.PositionY + float64(assistantCount)*150.0
-
 	assistantMessage := &model.Message{
 		UUID:      uuid.New().String(),
 		ChatUUID:  chatUUID,
 		Role:      "assistant",
 		Content:   fullResponse,
-		PositionX: positionX,
-		PositionY: positionY,
 		CreatedAt: time.Now(),
 	}
 
@@ -194,10 +330,10 @@ func (u *chatUsecase) StreamMessage(ctx context.Context, chatUUID string, output
 	// Edgeの作成 (一つ前のrole=assistantのメッセージと繋ぐ)
 	// 履歴から最新のassistantメッセージを探す（今保存したメッセージは除く）
 	var previousAssistantMessage *model.Message
-	// allMessagesは時系列順と仮定
-	for i := len(allMessages) - 1; i >= 0; i-- {
-		if allMessages[i].Role == "assistant" {
-			previousAssistantMessage = allMessages[i]
+	// latestMessagesは時系列順と仮定
+	for i := len(latestMessages) - 1; i >= 0; i-- {
+		if latestMessages[i].Role == "assistant" {
+			previousAssistantMessage = latestMessages[i]
 			break
 		}
 	}
@@ -214,13 +350,31 @@ func (u *chatUsecase) StreamMessage(ctx context.Context, chatUUID string, output
 			return err
 		}
 	}
+	u.publishEvent(model.ChatEvent{Type: model.ChatEventMessage, ChatUUID: chatUUID, Message: assistantMessage})
 
 	// 6. サマリ生成タスクのPublish
+	u.publishSummaryTask(ctx, chatUUID, language)
+
+	// 7. 長い回答はフォーク候補の抽出タスクをPublish
+	if utf8.RuneCountInString(fullResponse) >= forkSuggestionMinLength {
+		u.publishForkSuggestionTask(ctx, chatUUID, assistantMessage.UUID)
+	}
+
+	slog.InfoContext(ctx, "メッセージストリーム処理完了", "chat_uuid", chatUUID)
+	return nil
+}
+
+// サマリ生成タスクを登録する処理
+// 非同期タスクの失敗はメイン処理のエラーにはしない
+func (u *chatUsecase) publishSummaryTask(ctx context.Context, chatUUID string, language string) {
 	topic := "chat_summary"
-	payload, err := json.Marshal(chatUUID)
+	payload, err := json.Marshal(model.SummaryTask{
+		ChatUUID: chatUUID,
+		Language: language,
+	})
 	if err != nil {
 		slog.ErrorContext(ctx, "payloadのJSON変換に失敗しました", "error", err)
-		return nil // 非同期タスクの失敗はメイン処理のエラーにはしない
+		return
 	}
 
 	if err := queue.PublishTask(u.publisher, topic, payload); err != nil {
@@ -228,9 +382,90 @@ func (u *chatUsecase) StreamMessage(ctx context.Context, chatUUID string, output
 	} else {
 		slog.InfoContext(ctx, "サマリ生成タスクを登録しました", "chat_uuid", chatUUID)
 	}
+}
 
-	slog.InfoContext(ctx, "メッセージストリーム処理完了", "chat_uuid", chatUUID)
-	return nil
+// フォーク候補の抽出タスクを登録する処理
+// 非同期タスクの失敗はメイン処理のエラーにはしない
+func (u *chatUsecase) publishForkSuggestionTask(ctx context.Context, chatUUID string, messageUUID string) {
+	topic := "fork_suggestion"
+	payload, err := json.Marshal(model.ForkSuggestionTask{
+		ChatUUID:    chatUUID,
+		MessageUUID: messageUUID,
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "payloadのJSON変換に失敗しました", "error", err)
+		return
+	}
+
+	if err := queue.PublishTask(u.publisher, topic, payload); err != nil {
+		slog.ErrorContext(ctx, "フォーク候補抽出タスクの登録に失敗しました", "error", err)
+	} else {
+		slog.InfoContext(ctx, "フォーク候補抽出タスクを登録しました", "chat_uuid", chatUUID, "message_uuid", messageUUID)
+	}
+}
+
+// フォークしたチャットの最初の回答の生成タスクを登録する処理
+// 非同期タスクの失敗はメイン処理のエラーにはしない
+func (u *chatUsecase) publishFirstAnswerTask(ctx context.Context, chatUUID string) {
+	topic := "chat_first_answer"
+	payload, err := json.Marshal(model.FirstAnswerTask{ChatUUID: chatUUID})
+	if err != nil {
+		slog.ErrorContext(ctx, "payloadのJSON変換に失敗しました", "error", err)
+		return
+	}
+
+	if err := queue.PublishTask(u.publisher, topic, payload); err != nil {
+		slog.ErrorContext(ctx, "最初の回答の生成タスクの登録に失敗しました", "error", err)
+	} else {
+		slog.InfoContext(ctx, "最初の回答の生成タスクを登録しました", "chat_uuid", chatUUID)
+	}
+}
+
+// 会話履歴をプロンプトに変換する処理
+// 別のブランチから取り込んだメッセージは、取り込んだ時点の位置に参照として置く
+// マージレポートはマージした時点の位置に置いたまま、ユーザーの発言ではなくブランチの結論として枠付けする
+// (起点メッセージの直後に移すと、マージ前に生成された回答がレポートを踏まえていたように見えるため、起点は引用で示す)
+func (u *chatUsecase) buildHistoryContents(language string, history []*model.Message, allMessages []*model.Message, acknowledgeLast bool) ([]*genai.Content, error) {
+	parts := make([]*genai.Content, 0, len(history))
+	for i, msg := range history {
+		// 別のブランチから取り込んだメッセージは、この会話で生成されたものではないことを示して枠付けする
+		if msg.OriginMessageUUID != nil {
+			rendered, err := u.promptRenderer.Render(model.PromptCherryPickContext, language, map[string]string{
+				"Role":    msg.Role,
+				"Content": msg.Content,
+			})
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, &genai.Content{
+				Role: "user",
+				Parts: []*genai.Part{
+					{Text: rendered.Text},
+				},
+			})
+			continue
+		}
+		text := msg.Content
+		role := "user"
+		switch msg.Role {
+		case "assistant":
+			role = "model"
+		case "merge_report":
+			acknowledge := acknowledgeLast && i == len(history)-1
+			rendered, err := u.promptRenderer.Render(model.PromptMergeReportContext, language, model.MergeReportPromptData(msg, allMessages, acknowledge))
+			if err != nil {
+				return nil, err
+			}
+			text = rendered.Text
+		}
+		parts = append(parts, &genai.Content{
+			Role: role,
+			Parts: []*genai.Part{
+				{Text: text},
+			},
+		})
+	}
+	return parts, nil
 }
 
 // チャットの最初のメッセージを元に、GenAI にストリームを送信する
@@ -243,6 +478,17 @@ func (u *chatUsecase) FirstStreamChat(ctx context.Context, chatUUID string, outp
 		slog.ErrorContext(ctx, "チャットが見つかりません", "chat_uuid", chatUUID, "error", err)
 		return err
 	}
+	if !chat.IsWritable() {
+		slog.WarnContext(ctx, "open 以外のチャットには回答を生成しません", "chat_uuid", chatUUID, "status", chat.Status)
+		return fmt.Errorf("%w: %s", model.ErrChatNotOpen, chatUUID)
+	}
+
+	lock, err := u.lockGeneration(ctx, chatUUID)
+	if err != nil {
+		slog.WarnContext(ctx, "回答生成ロックを取得できません", "chat_uuid", chatUUID, "error", err)
+		return err
+	}
+	defer u.releaseGenerationLock(ctx, lock)
 
 	// 2. メッセージ履歴の取得
 	messages, err := u.messageRepo.FindMessagesByChatID(ctx, chatUUID)
@@ -252,7 +498,7 @@ func (u *chatUsecase) FirstStreamChat(ctx context.Context, chatUUID string, outp
 	}
 
 	if len(messages) != 1 {
-		return errors.New("invalid message state: expected exactly one initial message")
+		return fmt.Errorf("%w: expected exactly one initial message", model.ErrChatAlreadyStarted)
 	}
 
 	// 3. GenAI クライアント (注入されたものを使用)
@@ -274,6 +520,10 @@ func (u *chatUsecase) FirstStreamChat(ctx context.Context, chatUUID string, outp
 			slog.ErrorContext(ctx, "GenAI APIからの受信エラー", "error", err)
 			return err
 		}
+		if err := u.extendGenerationLock(ctx, lock); err != nil {
+			slog.WarnContext(ctx, "回答生成ロックを延長できません", "chat_uuid", chatUUID, "error", err)
+			return err
+		}
 
 		for _, cand := range resp.Candidates {
 			if cand.Content != nil {
@@ -287,19 +537,11 @@ func (u *chatUsecase) FirstStreamChat(ctx context.Context, chatUUID string, outp
 	}
 
 	// 6. 生成された文章の保存
-	// 位置計算
-	// PositionX = chat.PositionX
-	// PositionY = chat.PositionY + (0 + 1) * (100 + 50)
-	positionX := chat.PositionX
-	positionY := chat.PositionY
-
 	assistantMessage := &model.Message{
 		UUID:      uuid.New().String(),
 		ChatUUID:  chatUUID,
 		Role:      "assistant",
 		Content:   fullResponse,
-		PositionX: positionX,
-		PositionY: positionY,
 		CreatedAt: time.Now(),
 	}
 
@@ -308,6 +550,21 @@ func (u *chatUsecase) FirstStreamChat(ctx context.Context, chatUUID string, outp
 		return err
 	}
 
+	// フォークしたチャットでは初期メッセージと繋ぐ (StreamMessage と同じく一つ前のAIの回答へのエッジ)
+	if targetMessage.Role == "assistant" {
+		edge := &model.Edge{
+			UUID:              uuid.New().String(),
+			ChatUUID:          chatUUID,
+			SourceMessageUUID: assistantMessage.UUID,
+			TargetMessageUUID: targetMessage.UUID,
+		}
+		if err := u.edgeRepo.Create(ctx, edge); err != nil {
+			slog.ErrorContext(ctx, "エッジの作成に失敗しました", "error", err)
+			return err
+		}
+	}
+	u.publishEvent(model.ChatEvent{Type: model.ChatEventMessage, ChatUUID: chatUUID, Message: assistantMessage})
+
 	slog.InfoContext(ctx, "チャットストリーム処理完了", "chat_uuid", chatUUID)
 	return nil
 }
@@ -332,6 +589,11 @@ func (u *chatUsecase) GetMessages(ctx context.Context, chatUUID string) ([]*mode
 		return nil, err
 	}
 
+	return groupMergeReports(allMessages), nil
+}
+
+// マージレポートを親メッセージの MergeReports にまとめ、それ以外のメッセージを返す処理
+func groupMergeReports(allMessages []*model.Message) []*model.Message {
 	var mainMessages []*model.Message
 	reportsByParent := make(map[string][]*model.Message)
 
@@ -351,7 +613,7 @@ func (u *chatUsecase) GetMessages(ctx context.Context, chatUUID string) ([]*mode
 		}
 	}
 
-	return mainMessages, nil
+	return mainMessages
 }
 
 // メッセージを送信する
@@ -364,14 +626,22 @@ func (u *chatUsecase) SendMessage(ctx context.Context, chatUUID string, content
 		slog.ErrorContext(ctx, "チャットが見つかりません", "chat_uuid", chatUUID, "error", err)
 		return nil, err
 	}
+	// クローズ・マージ済みのチャットは再開するまでメッセージを受け付けない
+	if !chat.IsWritable() {
+		slog.WarnContext(ctx, "open 以外のチャットにはメッセージを追加できません", "chat_uuid", chatUUID, "status", chat.Status)
+		return nil, fmt.Errorf("%w: %s", model.ErrChatNotOpen, chatUUID)
+	}
+	// 回答の生成中に送信されたメッセージは生成中の回答の文脈に含まれないため受け付けない
+	if chat.IsGenerating(time.Now()) {
+		slog.WarnContext(ctx, "回答の生成中のためメッセージを受け付けません", "chat_uuid", chatUUID)
+		return nil, fmt.Errorf("%w: %s", model.ErrChatGenerationInProgress, chatUUID)
+	}
 
 	message := &model.Message{
 		UUID:      uuid.New().String(),
 		ChatUUID:  chatUUID,
 		Role:      "user",
 		Content:   content,
-		PositionX: chat.PositionX, // ユーザーメッセージはチャットと同じX位置（暫定）
-		PositionY: chat.PositionY, // ユーザーメッセージはチャットと同じY位置（暫定、AIメッセージ計算時に使用されないので影響小）
 		CreatedAt: time.Now(),
 	}
 
@@ -380,14 +650,157 @@ func (u *chatUsecase) SendMessage(ctx context.Context, chatUUID string, content
 		return nil, err
 	}
 
+	u.publishEvent(model.ChatEvent{Type: model.ChatEventMessage, ChatUUID: chatUUID, Message: message})
+
 	slog.InfoContext(ctx, "メッセージ送信成功", "message_uuid", message.UUID)
 	return message, nil
 }
 
+// 別のブランチのメッセージをチャットに取り込む
+// 取り込み元を記録したコピーを末尾に追加するので、以降の回答生成の文脈に含まれる
+func (u *chatUsecase) CherryPickMessages(ctx context.Context, chatUUID string, params model.CherryPickParams) (*model.CherryPickResult, error) {
+	slog.InfoContext(ctx, "メッセージ取り込み処理開始", "chat_uuid", chatUUID, "messages", len(params.MessageUUIDs))
+
+	// 1. 取り込み先のチャットの取得 (メッセージを追加するので open である必要がある)
+	chat, err := u.chatRepo.FindByID(ctx, chatUUID)
+	if err != nil {
+		slog.ErrorContext(ctx, "チャットが見つかりません", "chat_uuid", chatUUID, "error", err)
+		return nil, err
+	}
+	if !chat.IsWritable() {
+		slog.WarnContext(ctx, "open 以外のチャットにはメッセージを取り込めません", "chat_uuid", chatUUID, "status", chat.Status)
+		return nil, fmt.Errorf("%w: %s", model.ErrChatNotOpen, chatUUID)
+	}
+	// 回答の生成中に取り込んだメッセージは生成中の回答の文脈に含まれないため受け付けない
+	if chat.IsGenerating(time.Now()) {
+		slog.WarnContext(ctx, "回答の生成中のためメッセージを取り込めません", "chat_uuid", chatUUID)
+		return nil, fmt.Errorf("%w: %s", model.ErrChatGenerationInProgress, chatUUID)
+	}
+	if len(params.MessageUUIDs) == 0 {
+		return nil, fmt.Errorf("%w: 取り込むメッセージが指定されていません", model.ErrInvalidCherryPick)
+	}
+
+	// 2. 取り込むメッセージの検証 (同じプロジェクトの別のチャットのユーザー・AIのメッセージのみ)
+	sourceChats := map[string]*model.Chat{}
+	seen := map[string]bool{}
+	var sources []*model.Message
+	for _, messageUUID := range params.MessageUUIDs {
+		if seen[messageUUID] {
+			continue
+		}
+		seen[messageUUID] = true
+
+		source, err := u.messageRepo.FindByID(ctx, messageUUID)
+		if err != nil {
+			return nil, fmt.Errorf("取り込むメッセージの取得に失敗: %w", err)
+		}
+		if source == nil {
+			return nil, fmt.Errorf("%w: メッセージが見つかりません: %s", model.ErrInvalidCherryPick, messageUUID)
+		}
+		if source.Role != "user" && source.Role != "assistant" {
+			return nil, fmt.Errorf("%w: %s のメッセージは取り込めません: %s", model.ErrInvalidCherryPick, source.Role, messageUUID)
+		}
+		if source.ChatUUID == chatUUID {
+			return nil, fmt.Errorf("%w: 取り込み先のチャットのメッセージです: %s", model.ErrInvalidCherryPick, messageUUID)
+		}
+		sourceChat, ok := sourceChats[source.ChatUUID]
+		if !ok {
+			sourceChat, err = u.chatRepo.FindByID(ctx, source.ChatUUID)
+			if err != nil {
+				return nil, fmt.Errorf("取り込み元のチャット取得に失敗: %w", err)
+			}
+			sourceChats[source.ChatUUID] = sourceChat
+		}
+		if sourceChat.ProjectUUID != chat.ProjectUUID {
+			return nil, fmt.Errorf("%w: 別のプロジェクトのメッセージは取り込めません: %s", model.ErrInvalidCherryPick, messageUUID)
+		}
+		sources = append(sources, source)
+	}
+	// 取り込み元の会話の順序を保つ
+	sort.SliceStable(sources, func(i, j int) bool {
+		return sources[i].CreatedAt.Before(sources[j].CreatedAt)
+	})
+
+	// 3. 取り込み先で、エッジで繋ぐ直前のAIの回答を特定する
+	latestMessages, err := u.messageRepo.FindMessagesByChatID(ctx, chatUUID)
+	if err != nil {
+		return nil, fmt.Errorf("メッセージ履歴の取得に失敗: %w", err)
+	}
+	var previousAssistantMessage *model.Message
+	for _, msg := range latestMessages {
+		if msg.Role == "assistant" {
+			previousAssistantMessage = msg
+		}
+	}
+
+	// 4. トランザクション処理
+	// Message作成 -> Edge作成 (直前のAIの回答)
+	// 取り込み元は OriginMessageUUID / OriginChatUUID に記録し、エッジは作らない
+	// (別のチャットへのエッジがあるとツリーの親が取り込み元のチャットになってしまうため)
+	now := time.Now()
+	copies := make([]*model.Message, 0, len(sources))
+	err = u.transactionManager.Do(ctx, func(ctx context.Context) error {
+		for i, source := range sources {
+			message := &model.Message{
+				UUID:              uuid.New().String(),
+				ChatUUID:          chatUUID,
+				Role:              source.Role,
+				Content:           source.Content,
+				PromptVersion:     source.PromptVersion,
+				OriginMessageUUID: &source.UUID,
+				OriginChatUUID:    &source.ChatUUID,
+				// 同じ時刻になると順序が入れ替わるため、取り込み順にずらす
+				CreatedAt: now.Add(time.Duration(i) * time.Microsecond),
+			}
+			if err := u.messageRepo.Create(ctx, message); err != nil {
+				return fmt.Errorf("取り込んだメッセージの作成に失敗: %w", err)
+			}
+
+			if source.Role == "assistant" {
+				// 一つ前の role=assistant のメッセージと繋ぐ
+				if previousAssistantMessage != nil {
+					edge := &model.Edge{
+						UUID:              uuid.New().String(),
+						ChatUUID:          chatUUID,
+						SourceMessageUUID: message.UUID,
+						TargetMessageUUID: previousAssistantMessage.UUID,
+					}
+					if err := u.edgeRepo.Create(ctx, edge); err != nil {
+						return fmt.Errorf("エッジの作成に失敗: %w", err)
+					}
+				}
+				previousAssistantMessage = message
+			}
+			copies = append(copies, message)
+		}
+		return nil
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "メッセージ取り込み処理失敗", "chat_uuid", chatUUID, "error", err)
+		return nil, err
+	}
+
+	for _, message := range copies {
+		u.publishEvent(model.ChatEvent{Type: model.ChatEventMessage, ChatUUID: chatUUID, Message: message})
+	}
+	// 取り込んだメッセージも含めて要約し直す
+	u.publishSummaryTask(ctx, chatUUID, u.resolveLanguage(ctx, chat))
+
+	slog.InfoContext(ctx, "メッセージ取り込み処理完了", "chat_uuid", chatUUID, "messages", len(copies))
+	return &model.CherryPickResult{ChatUUID: chatUUID, Messages: copies}, nil
+}
+
 // フォークプレビューを生成する
 func (u *chatUsecase) GenerateForkPreview(ctx context.Context, chatUUID string, req model.ForkPreviewRequest) (*model.ForkPreviewResponse, error) {
 	slog.InfoContext(ctx, "フォークプレビュー生成開始", "chat_uuid", chatUUID, "target_message_uuid", req.TargetMessageUUID)
 
+	// 0. チャットと出力言語の取得
+	chat, err := u.chatRepo.FindByID(ctx, chatUUID)
+	if err != nil {
+		return nil, fmt.Errorf("チャット取得失敗: %w", err)
+	}
+	language := u.resolveLanguage(ctx, chat)
+
 	// 1. メッセージ履歴の取得
 	allMessages, err := u.messageRepo.FindMessagesByChatID(ctx, chatUUID)
 	if err != nil {
@@ -399,9 +812,9 @@ func (u *chatUsecase) GenerateForkPreview(ctx context.Context, chatUUID string,
 	var latestSummaryMessage *model.Message
 	var targetIndex int = -1
 
-	// 対象メッセージのインデックスを探す
+	// 対象メッセージのインデックスを探す (指定がない場合はチャットの先頭のメッセージからフォークする)
 	for i, msg := range allMessages {
-		if msg.UUID == req.TargetMessageUUID {
+		if msg.UUID == req.TargetMessageUUID || (req.TargetMessageUUID == "" && msg.Role != "merge_report") {
 			targetMessage = msg
 			targetIndex = i
 			break
@@ -409,7 +822,13 @@ func (u *chatUsecase) GenerateForkPreview(ctx context.Context, chatUUID string,
 	}
 
 	if targetMessage == nil {
-		return nil, fmt.Errorf("target message not found")
+		return nil, fmt.Errorf("%w: target message not found in chat: %s", model.ErrInvalidForkTarget, req.TargetMessageUUID)
+	}
+
+	// 選択範囲を本文と照合し、プロンプトには文字単位の範囲を渡す (ForkChat で保存する範囲と揃える)
+	req.RangeStart, req.RangeEnd, err = normalizeForkSelection(targetMessage.Content, req.SelectedText, req.RangeStart, req.RangeEnd)
+	if err != nil {
+		return nil, err
 	}
 
 	// 対象メッセージから遡ってサマリを探す（対象メッセージ含む）
@@ -450,26 +869,25 @@ func (u *chatUsecase) GenerateForkPreview(ctx context.Context, chatUUID string,
 	var parts []*genai.Content
 
 	if latestSummaryMessage != nil && latestSummaryMessage.ContextSummary != nil {
+		summaryPrompt, err := u.promptRenderer.Render(model.PromptForkSummaryContext, language, map[string]string{
+			"Summary": *latestSummaryMessage.ContextSummary,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("プロンプトのレンダリングに失敗: %w", err)
+		}
 		parts = append(parts, &genai.Content{
 			Role: "user",
 			Parts: []*genai.Part{
-				{Text: "以下の会話の要約を踏まえてください:\n" + *latestSummaryMessage.ContextSummary},
+				{Text: summaryPrompt.Text},
 			},
 		})
 	}
 
-	for _, msg := range targetMessages {
-		role := "user"
-		if msg.Role == "assistant" {
-			role = "model"
-		}
-		parts = append(parts, &genai.Content{
-			Role: role,
-			Parts: []*genai.Part{
-				{Text: msg.Content},
-			},
-		})
+	historyParts, err := u.buildHistoryContents(language, targetMessages, allMessages, false)
+	if err != nil {
+		return nil, fmt.Errorf("プロンプトのレンダリングに失敗: %w", err)
 	}
+	parts = append(parts, historyParts...)
 
 	// 対象メッセージの内容
 	// 対象メッセージは必ず含める（roleに応じて）
@@ -485,26 +903,19 @@ func (u *chatUsecase) GenerateForkPreview(ctx context.Context, chatUUID string,
 	})
 
 	// 指示プロンプト
-	prompt := fmt.Sprintf(`
-ユーザーは上記の会話の最後のメッセージの以下の部分を選択して、新しい話題（チャット）を開始しようとしています。
-選択範囲: "%s"
-(範囲: %d文字目から%d文字目)
-
-以下のJSON形式で、新しいチャットのタイトル案と、これまでの文脈を考慮した「新しいチャットの冒頭に設定するコンテキスト（要約）」を生成してください。
-コンテキストは、選択された話題について深掘りするための導入として機能するようにしてください。
-生成はJSONのみで良いです。説明は不要です。
-
-JSON形式:
-{
-  "suggested_title": "タイトル案",
-  "generated_context": "生成されたコンテキスト"
-}
-`, req.SelectedText, req.RangeStart, req.RangeEnd)
+	prompt, err := u.promptRenderer.Render(model.PromptForkPreview, language, map[string]any{
+		"SelectedText": req.SelectedText,
+		"RangeStart":   req.RangeStart,
+		"RangeEnd":     req.RangeEnd,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("プロンプトのレンダリングに失敗: %w", err)
+	}
 
 	parts = append(parts, &genai.Content{
 		Role: "user",
 		Parts: []*genai.Part{
-			{Text: prompt},
+			{Text: prompt.Text},
 		},
 	})
 
@@ -535,190 +946,897 @@ JSON形式:
 	if err := json.Unmarshal([]byte(generatedText), &result); err != nil {
 		return nil, fmt.Errorf("JSON出力に失敗: %w", err)
 	}
+	result.PromptVersion = prompt.Version
 
 	return &result, nil
 }
 
-// チャットをフォークする
-func (u *chatUsecase) ForkChat(ctx context.Context, params model.ForkChatParams) (string, error) {
-	slog.InfoContext(ctx, "チャットフォーク処理開始", "parent_chat_uuid", params.ParentChatUUID, "target_message_uuid", params.TargetMessageUUID)
+// メッセージからフォーク候補を抽出する
+// モデルが示した範囲は本文と照合し、一致しない候補は位置を補正するか除外してから保存する
+func (u *chatUsecase) SuggestForks(ctx context.Context, chatUUID string, messageUUID string) ([]*model.ForkSuggestion, error) {
+	slog.InfoContext(ctx, "フォーク候補抽出開始", "chat_uuid", chatUUID, "message_uuid", messageUUID)
 
-	// 1. 親チャットの存在確認
-	parentChat, err := u.chatRepo.FindByID(ctx, params.ParentChatUUID)
+	// 1. チャットと出力言語の取得
+	chat, err := u.chatRepo.FindByID(ctx, chatUUID)
 	if err != nil {
-		return "", fmt.Errorf("親チャットの存在確認に失敗: %w", err)
+		return nil, fmt.Errorf("チャット取得失敗: %w", err)
 	}
+	language := u.resolveLanguage(ctx, chat)
 
-	// 2. フォーク元のメッセージを取得（位置計算のため）
-	targetMessage, err := u.messageRepo.FindByID(ctx, params.TargetMessageUUID)
+	// 2. 対象メッセージの特定
+	allMessages, err := u.messageRepo.FindMessagesByChatID(ctx, chatUUID)
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("メッセージ履歴取得失敗: %w", err)
 	}
-
-	// 3. プロジェクト内のチャット数を取得
-	chatCount, err := u.chatRepo.CountByProjectUUID(ctx, parentChat.ProjectUUID)
-	if err != nil {
-		return "", fmt.Errorf("チャット数の取得に失敗: %w", err)
+	targetIndex := -1
+	for i, msg := range allMessages {
+		if msg.UUID == messageUUID {
+			targetIndex = i
+			break
+		}
+	}
+	if targetIndex < 0 {
+		return nil, fmt.Errorf("%w: このチャットのメッセージではありません: %s", model.ErrInvalidForkSuggestionTarget, messageUUID)
+	}
+	targetMessage := allMessages[targetIndex]
+	if targetMessage.Role != "assistant" {
+		return nil, fmt.Errorf("%w: AIの回答ではありません: %s", model.ErrInvalidForkSuggestionTarget, messageUUID)
 	}
 
-	// 4. 新しいチャットの位置計算
-	// PositionX = count * (200 + 50)
-	// PositionY = TargetMessage.PositionY
-	newChatPositionX := float64(chatCount) * 250.0
-	newChatPositionY := targetMessage.PositionY
-
-	// 5. トランザクション処理
-	// MessageSelection作成 -> Chat作成 -> Message作成
-	newChatUUID := uuid.New().String()
-	selectionUUID := uuid.New().String()
-	messageUUID := uuid.New().String()
-
-	err = u.transactionManager.Do(ctx, func(ctx context.Context) error {
-		// 5-1. MessageSelection作成
-		selection := &model.MessageSelection{
-			UUID:         selectionUUID,
-			SelectedText: params.SelectedText,
-			RangeStart:   params.RangeStart,
-			RangeEnd:     params.RangeEnd,
-			CreatedAt:    time.Now(),
-		}
-		if err := u.messageSelectionRepo.Create(ctx, selection); err != nil {
-			return fmt.Errorf("メッセージ選択の作成に失敗: %w", err)
-		}
-
-		// 5-2. Chat作成
-		newChat := &model.Chat{
-			UUID:                 newChatUUID,
-			ProjectUUID:          parentChat.ProjectUUID, // 親チャットと同じプロジェクト
-			ParentUUID:           &params.ParentChatUUID,
-			SourceMessageUUID:    &params.TargetMessageUUID,
-			MessageSelectionUUID: &selectionUUID,
-			Title:                params.Title,
-			Status:               "open",
-			ContextSummary:       params.ContextSummary,
-			PositionX:            newChatPositionX,
-			PositionY:            newChatPositionY,
-			CreatedAt:            time.Now(),
-			UpdatedAt:            time.Now(),
-		}
-		if err := u.chatRepo.Create(ctx, newChat); err != nil {
-			return fmt.Errorf("新しいチャットの作成に失敗: %w", err)
-		}
-
-		// 5-3. Message作成 (最初のメッセージ)
-		// タイトルとコンテキストサマリを結合した文章をユーザーメッセージとして保存
-		initialContent := fmt.Sprintf("%s\n\n%s", params.Title, params.ContextSummary)
-		message := &model.Message{
-			UUID:      messageUUID,
-			ChatUUID:  newChatUUID,
-			Role:      "assistant",
-			Content:   initialContent,
-			PositionX: newChatPositionX, // チャットと同じ位置
-			PositionY: newChatPositionY, // チャットと同じ位置
-			CreatedAt: time.Now(),
-		}
-		if err := u.messageRepo.Create(ctx, message); err != nil {
-			return fmt.Errorf("初期メッセージの作成に失敗: %w", err)
-		}
-
-		// 5-4. Edge作成
-		// 新しいチャットの初期メッセージ(Source) -> Fork元のメッセージ(Target)
-		edge := &model.Edge{
-			UUID:              uuid.New().String(),
-			ChatUUID:          newChatUUID,
-			SourceMessageUUID: message.UUID,
-			TargetMessageUUID: params.TargetMessageUUID,
+	// 3. プロンプト構築
+	// 回答の前提となる要約と、回答の元になったメッセージのみを含める
+	var parts []*genai.Content
+	for i := targetIndex - 1; i >= 0; i-- {
+		if allMessages[i].ContextSummary != nil && *allMessages[i].ContextSummary != "" {
+			summaryPrompt, err := u.promptRenderer.Render(model.PromptForkSummaryContext, language, map[string]string{
+				"Summary": *allMessages[i].ContextSummary,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("プロンプトのレンダリングに失敗: %w", err)
+			}
+			parts = append(parts, &genai.Content{
+				Role: "user",
+				Parts: []*genai.Part{
+					{Text: summaryPrompt.Text},
+				},
+			})
+			break
 		}
-		if err := u.edgeRepo.Create(ctx, edge); err != nil {
-			return fmt.Errorf("エッジの作成に失敗: %w", err)
+	}
+	if targetIndex > 0 {
+		historyParts, err := u.buildHistoryContents(language, allMessages[targetIndex-1:targetIndex], allMessages, false)
+		if err != nil {
+			return nil, fmt.Errorf("プロンプトのレンダリングに失敗: %w", err)
 		}
+		parts = append(parts, historyParts...)
+	}
+	parts = append(parts, &genai.Content{
+		Role: "model",
+		Parts: []*genai.Part{
+			{Text: targetMessage.Content},
+		},
+	})
+
+	prompt, err := u.promptRenderer.Render(model.PromptForkSuggestion, language, map[string]any{
+		"MaxSuggestions": maxForkSuggestions,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("プロンプトのレンダリングに失敗: %w", err)
+	}
+	parts = append(parts, &genai.Content{
+		Role: "user",
+		Parts: []*genai.Part{
+			{Text: prompt.Text},
+		},
+	})
+
+	// 4. GenAI 呼び出し
+	config := &genai.GenerateContentConfig{
+		ResponseMIMEType: "application/json",
+	}
+	resp, err := u.genaiClient.GenerateContent(ctx, "gemini-2.5-flash", parts, config)
+	if err != nil {
+		return nil, fmt.Errorf("GenAI呼び出しに失敗: %w", err)
+	}
+
+	var generatedText string
+	for _, cand := range resp.Candidates {
+		if cand.Content != nil {
+			for _, part := range cand.Content.Parts {
+				generatedText += part.Text
+			}
+		}
+	}
+
+	var generated struct {
+		Suggestions []struct {
+			SelectedText     string `json:"selected_text"`
+			RangeStart       int    `json:"range_start"`
+			RangeEnd         int    `json:"range_end"`
+			SuggestedTitle   string `json:"suggested_title"`
+			GeneratedContext string `json:"generated_context"`
+		} `json:"suggestions"`
+	}
+	if err := json.Unmarshal([]byte(generatedText), &generated); err != nil {
+		return nil, fmt.Errorf("JSON出力に失敗: %w", err)
+	}
+
+	// 5. 本文との照合
+	now := time.Now()
+	candidates := make([]*model.ForkSuggestion, 0, len(generated.Suggestions))
+	for _, g := range generated.Suggestions {
+		if strings.TrimSpace(g.SuggestedTitle) == "" {
+			continue
+		}
+		start, end, ok := resolveSelectionRange(targetMessage.Content, g.SelectedText, g.RangeStart, g.RangeEnd)
+		if !ok {
+			slog.WarnContext(ctx, "本文と一致しないフォーク候補を除外", "message_uuid", messageUUID, "selected_text", g.SelectedText)
+			continue
+		}
+		candidates = append(candidates, &model.ForkSuggestion{
+			UUID:             uuid.New().String(),
+			ChatUUID:         chatUUID,
+			MessageUUID:      messageUUID,
+			SelectedText:     g.SelectedText,
+			RangeStart:       start,
+			RangeEnd:         end,
+			SuggestedTitle:   g.SuggestedTitle,
+			GeneratedContext: g.GeneratedContext,
+			PromptVersion:    prompt.Version,
+			CreatedAt:        now,
+		})
+	}
+
+	// 範囲が重なる候補は先に示されたものを残す
+	suggestions := make([]*model.ForkSuggestion, 0, len(candidates))
+	for _, c := range candidates {
+		if len(suggestions) >= maxForkSuggestions {
+			break
+		}
+		overlapped := false
+		for _, s := range suggestions {
+			if c.RangeStart < s.RangeEnd && s.RangeStart < c.RangeEnd {
+				overlapped = true
+				break
+			}
+		}
+		if !overlapped {
+			suggestions = append(suggestions, c)
+		}
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].RangeStart < suggestions[j].RangeStart
+	})
+
+	// 6. 保存 (以前に抽出した候補は置き換える)
+	err = u.transactionManager.Do(ctx, func(ctx context.Context) error {
+		return u.forkSuggestionRepo.ReplaceByMessageUUID(ctx, messageUUID, suggestions)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("フォーク候補の保存に失敗: %w", err)
+	}
+
+	slog.InfoContext(ctx, "フォーク候補抽出完了", "chat_uuid", chatUUID, "message_uuid", messageUUID, "suggestions", len(suggestions), "prompt_version", prompt.Version)
+	return suggestions, nil
+}
+
+// 選択範囲を本文と照合する処理 (フォーク候補・フォークの選択範囲で共通)
+// 範囲は文字 (rune) 単位で、示された範囲が抜き出した部分と一致しない場合は本文から探して補正する
+// (同じ部分が本文に複数ある場合は、示された開始位置に最も近いものを使う)
+func resolveSelectionRange(content string, selectedText string, rangeStart int, rangeEnd int) (int, int, bool) {
+	if strings.TrimSpace(selectedText) == "" {
+		return 0, 0, false
+	}
+	runes := []rune(content)
+	if 0 <= rangeStart && rangeStart < rangeEnd && rangeEnd <= len(runes) && string(runes[rangeStart:rangeEnd]) == selectedText {
+		return rangeStart, rangeEnd, true
+	}
+
+	distance := func(start int) int {
+		if start > rangeStart {
+			return start - rangeStart
+		}
+		return rangeStart - start
+	}
+	best := -1
+	for offset := 0; offset < len(content); {
+		index := strings.Index(content[offset:], selectedText)
+		if index < 0 {
+			break
+		}
+		start := utf8.RuneCountInString(content[:offset+index])
+		if best < 0 || distance(start) < distance(best) {
+			best = start
+		}
+		_, size := utf8.DecodeRuneInString(content[offset+index:])
+		offset += index + size
+	}
+	if best < 0 {
+		return 0, 0, false
+	}
+	return best, best + utf8.RuneCountInString(selectedText), true
+}
+
+// メッセージのフォーク候補を取得する
+func (u *chatUsecase) GetForkSuggestions(ctx context.Context, chatUUID string, messageUUID string) ([]*model.ForkSuggestion, error) {
+	slog.InfoContext(ctx, "フォーク候補取得開始", "chat_uuid", chatUUID, "message_uuid", messageUUID)
+
+	message, err := u.messageRepo.FindByID(ctx, messageUUID)
+	if err != nil {
+		return nil, fmt.Errorf("メッセージ取得失敗: %w", err)
+	}
+	if message == nil || message.ChatUUID != chatUUID {
+		return nil, fmt.Errorf("%w: このチャットのメッセージではありません: %s", model.ErrInvalidForkSuggestionTarget, messageUUID)
+	}
+
+	suggestions, err := u.forkSuggestionRepo.FindByMessageUUID(ctx, messageUUID)
+	if err != nil {
+		return nil, fmt.Errorf("フォーク候補の取得に失敗: %w", err)
+	}
+	return suggestions, nil
+}
+
+// チャットをフォークする
+func (u *chatUsecase) ForkChat(ctx context.Context, params model.ForkChatParams) (string, error) {
+	slog.InfoContext(ctx, "チャットフォーク処理開始", "parent_chat_uuid", params.ParentChatUUID, "target_message_uuid", params.TargetMessageUUID)
+
+	// 1. 親チャットの存在確認
+	parentChat, err := u.chatRepo.FindByID(ctx, params.ParentChatUUID)
+	if err != nil {
+		return "", fmt.Errorf("親チャットの存在確認に失敗: %w", err)
+	}
+	// マージ・クローズしたチャットからはフォークしない (ブランチが閉じた部分木に追加されるのを防ぐ)
+	if !parentChat.IsWritable() {
+		slog.WarnContext(ctx, "open 以外のチャットからはフォークできません", "chat_uuid", params.ParentChatUUID, "status", parentChat.Status)
+		return "", fmt.Errorf("%w: %s", model.ErrChatNotOpen, params.ParentChatUUID)
+	}
+
+	// 2. フォーク元のメッセージを取得（選択範囲の照合とエッジの作成のため）
+	targetMessage, err := u.resolveForkTarget(ctx, params.ParentChatUUID, params.TargetMessageUUID)
+	if err != nil {
+		return "", err
+	}
+
+	// 選択範囲を本文と照合し、文字単位の範囲で保存する (GetMessages のハイライト位置に使う)
+	params.RangeStart, params.RangeEnd, err = normalizeForkSelection(targetMessage.Content, params.SelectedText, params.RangeStart, params.RangeEnd)
+	if err != nil {
+		return "", err
+	}
+
+	// 3. トランザクション処理
+	var newChatUUID string
+	err = u.transactionManager.Do(ctx, func(ctx context.Context) error {
+		var err error
+		newChatUUID, err = u.createForkedChat(ctx, parentChat, targetMessage, params)
+		return err
+	})
 
-		return nil
-	})
-
 	if err != nil {
 		slog.ErrorContext(ctx, "チャットフォーク処理失敗", "error", err)
 		return "", err
 	}
 
-	slog.InfoContext(ctx, "チャットフォーク処理完了", "new_chat_uuid", newChatUUID)
+	slog.InfoContext(ctx, "チャットフォーク処理完了", "new_chat_uuid", newChatUUID)
+
+	return newChatUUID, nil
+}
+
+// 1つのメッセージから複数のブランチをまとめてフォークする
+// 全てのブランチを1つのトランザクションで作成する (ノードの位置は取得時にレイアウトで計算する)
+func (u *chatUsecase) BatchForkChat(ctx context.Context, params model.BatchForkChatParams) ([]string, error) {
+	slog.InfoContext(ctx, "チャット一括フォーク処理開始", "parent_chat_uuid", params.ParentChatUUID, "target_message_uuid", params.TargetMessageUUID, "forks", len(params.Forks))
+
+	if len(params.Forks) == 0 || len(params.Forks) > maxBatchForks {
+		return nil, fmt.Errorf("%w: 1件以上%d件以下のブランチを指定してください (指定: %d件)", model.ErrInvalidBatchFork, maxBatchForks, len(params.Forks))
+	}
+
+	// 1. 親チャットの存在確認
+	parentChat, err := u.chatRepo.FindByID(ctx, params.ParentChatUUID)
+	if err != nil {
+		return nil, fmt.Errorf("親チャットの存在確認に失敗: %w", err)
+	}
+	// マージ・クローズしたチャットからはフォークしない (ブランチが閉じた部分木に追加されるのを防ぐ)
+	if !parentChat.IsWritable() {
+		slog.WarnContext(ctx, "open 以外のチャットからはフォークできません", "chat_uuid", params.ParentChatUUID, "status", parentChat.Status)
+		return nil, fmt.Errorf("%w: %s", model.ErrChatNotOpen, params.ParentChatUUID)
+	}
+
+	// 2. フォーク元のメッセージを取得（選択範囲の照合とエッジの作成のため）
+	targetMessage, err := u.resolveForkTarget(ctx, params.ParentChatUUID, params.TargetMessageUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 全てのブランチの選択範囲を作成前に照合する (1件でも一致しない場合は何も作成しない)
+	forks := make([]model.BatchForkItem, len(params.Forks))
+	for i, fork := range params.Forks {
+		fork.RangeStart, fork.RangeEnd, err = normalizeForkSelection(targetMessage.Content, fork.SelectedText, fork.RangeStart, fork.RangeEnd)
+		if err != nil {
+			return nil, fmt.Errorf("%d件目のブランチ: %w", i+1, err)
+		}
+		forks[i] = fork
+	}
+
+	// 3. トランザクション処理
+	newChatUUIDs := make([]string, 0, len(params.Forks))
+	err = u.transactionManager.Do(ctx, func(ctx context.Context) error {
+		for _, fork := range forks {
+			newChatUUID, err := u.createForkedChat(ctx, parentChat, targetMessage, model.ForkChatParams{
+				TargetMessageUUID: targetMessage.UUID,
+				ParentChatUUID:    parentChat.UUID,
+				SelectedText:      fork.SelectedText,
+				RangeStart:        fork.RangeStart,
+				RangeEnd:          fork.RangeEnd,
+				Title:             fork.Title,
+				ContextSummary:    fork.ContextSummary,
+				PromptVersion:     fork.PromptVersion,
+			})
+			if err != nil {
+				return err
+			}
+			newChatUUIDs = append(newChatUUIDs, newChatUUID)
+		}
+		return nil
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "チャット一括フォーク処理失敗", "error", err)
+		return nil, err
+	}
+
+	// 4. 最初の回答の生成タスクのPublish
+	if params.GenerateFirstAnswers {
+		for _, newChatUUID := range newChatUUIDs {
+			u.publishFirstAnswerTask(ctx, newChatUUID)
+		}
+	}
+
+	slog.InfoContext(ctx, "チャット一括フォーク処理完了", "new_chat_uuids", newChatUUIDs)
+	return newChatUUIDs, nil
+}
+
+// フォークしたチャットを作成する処理 (トランザクション内で呼び出す)
+// MessageSelection作成 -> Chat作成 -> Message作成 -> Edge作成
+func (u *chatUsecase) createForkedChat(ctx context.Context, parentChat *model.Chat, targetMessage *model.Message, params model.ForkChatParams) (string, error) {
+	newChatUUID := uuid.New().String()
+
+	// 1. MessageSelection作成 (範囲を選択せずにメッセージ全体からフォークする場合は作成しない)
+	var selectionUUID *string
+	if params.SelectedText != "" {
+		selection := &model.MessageSelection{
+			UUID:         uuid.New().String(),
+			SelectedText: params.SelectedText,
+			RangeStart:   params.RangeStart,
+			RangeEnd:     params.RangeEnd,
+			CreatedAt:    time.Now(),
+		}
+		if err := u.messageSelectionRepo.Create(ctx, selection); err != nil {
+			return "", fmt.Errorf("メッセージ選択の作成に失敗: %w", err)
+		}
+		selectionUUID = &selection.UUID
+	}
+
+	// 2. Chat作成
+	newChat := &model.Chat{
+		UUID:                 newChatUUID,
+		ProjectUUID:          parentChat.ProjectUUID, // 親チャットと同じプロジェクト
+		ParentUUID:           &parentChat.UUID,
+		SourceMessageUUID:    &targetMessage.UUID,
+		MessageSelectionUUID: selectionUUID,
+		Title:                params.Title,
+		Status:               model.ChatStatusOpen,
+		ContextSummary:       params.ContextSummary,
+		ContextPromptVersion: params.PromptVersion,
+		CreatedAt:            time.Now(),
+		UpdatedAt:            time.Now(),
+	}
+	if err := u.chatRepo.Create(ctx, newChat); err != nil {
+		return "", fmt.Errorf("新しいチャットの作成に失敗: %w", err)
+	}
+
+	// 3. Message作成 (最初のメッセージ)
+	// タイトルとコンテキストサマリを結合した文章をユーザーメッセージとして保存
+	initialContent := fmt.Sprintf("%s\n\n%s", params.Title, params.ContextSummary)
+	message := &model.Message{
+		UUID:      uuid.New().String(),
+		ChatUUID:  newChatUUID,
+		Role:      "assistant",
+		Content:   initialContent,
+		CreatedAt: time.Now(),
+	}
+	if err := u.messageRepo.Create(ctx, message); err != nil {
+		return "", fmt.Errorf("初期メッセージの作成に失敗: %w", err)
+	}
+
+	// 4. Edge作成
+	// 新しいチャットの初期メッセージ(Source) -> Fork元のメッセージ(Target)
+	edge := &model.Edge{
+		UUID:              uuid.New().String(),
+		ChatUUID:          newChatUUID,
+		SourceMessageUUID: message.UUID,
+		TargetMessageUUID: targetMessage.UUID,
+	}
+	if err := u.edgeRepo.Create(ctx, edge); err != nil {
+		return "", fmt.Errorf("エッジの作成に失敗: %w", err)
+	}
+
+	return newChatUUID, nil
+}
+
+// フォーク元のメッセージを取得する処理
+// 指定がない場合はチャットの先頭のメッセージ (マージレポートを除く) からフォークする
+func (u *chatUsecase) resolveForkTarget(ctx context.Context, parentChatUUID string, targetMessageUUID string) (*model.Message, error) {
+	if targetMessageUUID != "" {
+		message, err := u.messageRepo.FindByID(ctx, targetMessageUUID)
+		if err != nil {
+			return nil, fmt.Errorf("フォーク元のメッセージ取得に失敗: %w", err)
+		}
+		// 別のチャットのメッセージやマージレポートからはフォークできない
+		if message == nil || message.ChatUUID != parentChatUUID || message.Role == "merge_report" {
+			return nil, fmt.Errorf("%w: このチャットのメッセージではありません: %s", model.ErrInvalidForkTarget, targetMessageUUID)
+		}
+		return message, nil
+	}
+
+	messages, err := u.messageRepo.FindMessagesByChatID(ctx, parentChatUUID)
+	if err != nil {
+		return nil, fmt.Errorf("フォーク元のメッセージ取得に失敗: %w", err)
+	}
+	for _, msg := range messages {
+		if msg.Role != "merge_report" {
+			return msg, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: フォーク元のチャットにメッセージがありません: %s", model.ErrInvalidForkTarget, parentChatUUID)
+}
+
+// フォークの選択範囲をフォーク元のメッセージの本文と照合し、文字 (rune) 単位の範囲に正規化する処理
+// 範囲を選択しない場合は範囲を使わず、選択した文章が本文にない場合はエラーにする
+func normalizeForkSelection(content string, selectedText string, rangeStart int, rangeEnd int) (int, int, error) {
+	if selectedText == "" {
+		return 0, 0, nil
+	}
+	validRange := func(length int) bool {
+		return 0 <= rangeStart && rangeStart < rangeEnd && rangeEnd <= length
+	}
+
+	// 1. 文字 (rune) 単位で一致する場合はそのまま使う
+	runes := []rune(content)
+	if validRange(len(runes)) && string(runes[rangeStart:rangeEnd]) == selectedText {
+		return rangeStart, rangeEnd, nil
+	}
+
+	// 2. バイト (UTF-8) 単位の範囲で一致する場合は文字単位に変換する
+	if validRange(len(content)) && content[rangeStart:rangeEnd] == selectedText {
+		start := utf8.RuneCountInString(content[:rangeStart])
+		return start, start + utf8.RuneCountInString(selectedText), nil
+	}
+
+	// 3. UTF-16 単位 (ブラウザの選択範囲) で一致する場合は文字単位に変換する
+	units := utf16.Encode(runes)
+	if validRange(len(units)) && string(utf16.Decode(units[rangeStart:rangeEnd])) == selectedText {
+		start := len(utf16.Decode(units[:rangeStart]))
+		return start, start + utf8.RuneCountInString(selectedText), nil
+	}
+
+	// 4. いずれも一致しない場合 (Markdown の表示上の位置など) は本文から探して補正する
+	start, end, ok := resolveSelectionRange(content, selectedText, rangeStart, rangeEnd)
+	if !ok {
+		return 0, 0, fmt.Errorf("%w: 選択した文章がフォーク元のメッセージにありません", model.ErrInvalidForkSelection)
+	}
+	return start, end, nil
+}
+
+// ブランチを別のメッセージに付け替える
+// 同じプロジェクト内であれば別のチャットのメッセージにも付け替えられるが、自身や子孫のチャットには付け替えられない
+func (u *chatUsecase) RebaseChat(ctx context.Context, chatUUID string, params model.RebaseChatParams) (*model.RebaseChatResult, error) {
+	slog.InfoContext(ctx, "ブランチ付け替え処理開始", "chat_uuid", chatUUID, "source_message_uuid", params.SourceMessageUUID, "regenerate_context", params.RegenerateContext)
+
+	// 1. 付け替えるチャットの取得 (ルートとマージ済みのチャットは移動できない)
+	chat, err := u.chatRepo.FindByID(ctx, chatUUID)
+	if err != nil {
+		return nil, fmt.Errorf("チャット取得失敗: %w", err)
+	}
+	if chat.ParentUUID == nil {
+		return nil, fmt.Errorf("%w: ルートのチャットは付け替えられません", model.ErrInvalidRebaseTarget)
+	}
+	if chat.Status == model.ChatStatusMerged {
+		return nil, fmt.Errorf("%w: マージ済みのチャットは付け替えられません", model.ErrInvalidRebaseTarget)
+	}
+
+	// 2. 付け替え先のメッセージと親チャットの取得
+	sourceMessage, err := u.messageRepo.FindByID(ctx, params.SourceMessageUUID)
+	if err != nil {
+		return nil, fmt.Errorf("付け替え先のメッセージ取得に失敗: %w", err)
+	}
+	if sourceMessage == nil || sourceMessage.Role == "merge_report" {
+		return nil, fmt.Errorf("%w: 付け替え先のメッセージが見つかりません: %s", model.ErrInvalidRebaseTarget, params.SourceMessageUUID)
+	}
+	parentChat, err := u.chatRepo.FindByID(ctx, sourceMessage.ChatUUID)
+	if err != nil {
+		return nil, fmt.Errorf("付け替え先のチャット取得に失敗: %w", err)
+	}
+	if parentChat.ProjectUUID != chat.ProjectUUID {
+		return nil, fmt.Errorf("%w: 別のプロジェクトのメッセージには付け替えられません", model.ErrInvalidRebaseTarget)
+	}
+	// 選択範囲を本文と照合し、ForkChat と同じく文字単位の範囲で保存する
+	params.RangeStart, params.RangeEnd, err = normalizeForkSelection(sourceMessage.Content, params.SelectedText, params.RangeStart, params.RangeEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. 循環の検出 (自身や子孫のチャットを親にすると木構造が壊れる)
+	if parentChat.UUID == chat.UUID {
+		return nil, fmt.Errorf("%w: 自身のメッセージには付け替えられません", model.ErrInvalidRebaseTarget)
+	}
+	descendants, err := u.collectDescendantChats(ctx, chat.UUID)
+	if err != nil {
+		return nil, err
+	}
+	for _, descendant := range descendants {
+		if descendant.UUID == parentChat.UUID {
+			return nil, fmt.Errorf("%w: 子孫のチャットのメッセージには付け替えられません (chat_uuid: %s)", model.ErrInvalidRebaseTarget, descendant.UUID)
+		}
+	}
+
+	// 4. 新しい起点からフォーク時の文脈を生成し直す
+	result := &model.RebaseChatResult{
+		ChatUUID:             chat.UUID,
+		ParentChatUUID:       parentChat.UUID,
+		SourceMessageUUID:    sourceMessage.UUID,
+		ContextSummary:       chat.ContextSummary,
+		ContextPromptVersion: chat.ContextPromptVersion,
+	}
+	if params.RegenerateContext {
+		preview, err := u.GenerateForkPreview(ctx, parentChat.UUID, model.ForkPreviewRequest{
+			TargetMessageUUID: sourceMessage.UUID,
+			SelectedText:      params.SelectedText,
+			RangeStart:        params.RangeStart,
+			RangeEnd:          params.RangeEnd,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("フォーク時の文脈の再生成に失敗: %w", err)
+		}
+		result.ContextSummary = preview.GeneratedContext
+		result.ContextPromptVersion = preview.PromptVersion
+	}
+
+	// 5. 付け替え前の起点につながるエッジと初期メッセージの特定
+	edges, err := u.edgeRepo.FindEdgesByChatID(ctx, chat.UUID)
+	if err != nil {
+		return nil, fmt.Errorf("エッジの取得に失敗: %w", err)
+	}
+	var forkEdge *model.Edge
+	for _, edge := range edges {
+		if chat.SourceMessageUUID != nil && edge.TargetMessageUUID == *chat.SourceMessageUUID {
+			forkEdge = edge
+			break
+		}
+	}
+	messages, err := u.messageRepo.FindMessagesByChatID(ctx, chat.UUID)
+	if err != nil {
+		return nil, fmt.Errorf("メッセージ履歴の取得に失敗: %w", err)
+	}
+	var initialMessage *model.Message
+	for _, msg := range messages {
+		if msg.Role != "merge_report" {
+			initialMessage = msg
+			break
+		}
+	}
+	if forkEdge == nil && initialMessage == nil {
+		return nil, fmt.Errorf("付け替えるチャットにメッセージがありません: %s", chat.UUID)
+	}
+
+	// 6. トランザクション処理
+	// MessageSelection作成 -> 親チャットの付け替え -> Edgeの付け替え -> 文脈の更新
+	err = u.transactionManager.Do(ctx, func(ctx context.Context) error {
+		// 6-1. MessageSelection作成 (範囲を選択せずにメッセージ全体を起点にする場合は作成しない)
+		var selectionUUID *string
+		if params.SelectedText != "" {
+			selection := &model.MessageSelection{
+				UUID:         uuid.New().String(),
+				SelectedText: params.SelectedText,
+				RangeStart:   params.RangeStart,
+				RangeEnd:     params.RangeEnd,
+				CreatedAt:    time.Now(),
+			}
+			if err := u.messageSelectionRepo.Create(ctx, selection); err != nil {
+				return fmt.Errorf("メッセージ選択の作成に失敗: %w", err)
+			}
+			selectionUUID = &selection.UUID
+		}
+
+		// 6-2. 親チャットと起点メッセージの付け替え
+		if err := u.chatRepo.UpdateParent(ctx, chat.UUID, parentChat.UUID, sourceMessage.UUID, selectionUUID); err != nil {
+			return fmt.Errorf("親チャットの付け替えに失敗: %w", err)
+		}
+
+		// 6-3. Edgeの付け替え (フォーク時のエッジがない場合は初期メッセージから作成する)
+		if forkEdge != nil {
+			if err := u.edgeRepo.UpdateTarget(ctx, forkEdge.UUID, sourceMessage.UUID); err != nil {
+				return fmt.Errorf("エッジの付け替えに失敗: %w", err)
+			}
+		} else {
+			edge := &model.Edge{
+				UUID:              uuid.New().String(),
+				ChatUUID:          chat.UUID,
+				SourceMessageUUID: initialMessage.UUID,
+				TargetMessageUUID: sourceMessage.UUID,
+			}
+			if err := u.edgeRepo.Create(ctx, edge); err != nil {
+				return fmt.Errorf("エッジの作成に失敗: %w", err)
+			}
+		}
+
+		// 6-4. フォーク時の文脈の更新
+		if !params.RegenerateContext {
+			return nil
+		}
+		if err := u.chatRepo.UpdateContextSummary(ctx, chat.UUID, result.ContextSummary, result.ContextPromptVersion); err != nil {
+			return fmt.Errorf("フォーク時の文脈の更新に失敗: %w", err)
+		}
+		// 初期メッセージがフォーク時のまま編集されていなければ新しい文脈で書き換える
+		if initialMessage != nil && initialMessage.Content == fmt.Sprintf("%s\n\n%s", chat.Title, chat.ContextSummary) {
+			if err := u.messageRepo.UpdateContent(ctx, initialMessage.UUID, fmt.Sprintf("%s\n\n%s", chat.Title, result.ContextSummary)); err != nil {
+				return fmt.Errorf("初期メッセージの更新に失敗: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "ブランチ付け替え処理失敗", "chat_uuid", chatUUID, "error", err)
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "ブランチ付け替え処理完了", "chat_uuid", chatUUID, "parent_chat_uuid", parentChat.UUID)
+	return result, nil
+}
+
+// マージプレビューを生成する
+func (u *chatUsecase) GetMergePreview(ctx context.Context, chatUUID string, targetChatUUID string) (*model.MergePreview, error) {
+	slog.InfoContext(ctx, "マージプレビュー生成開始", "chat_uuid", chatUUID, "target_chat_uuid", targetChatUUID)
+
+	// 1. チャット情報の取得 (親からforkした理由 = ContextSummary を取得)
+	chat, err := u.chatRepo.FindByID(ctx, chatUUID)
+	if err != nil {
+		return nil, fmt.Errorf("チャット取得失敗: %w", err)
+	}
+
+	// 2. 子チャットの最新のサマリを取得
+	latestSummaryMessage, err := u.messageRepo.FindLatestMessageWithSummary(ctx, chatUUID)
+	if err != nil {
+		return nil, fmt.Errorf("最新サマリ取得失敗: %w", err)
+	}
+
+	// 3. role='assistant' の最新メッセージを取得
+	latestAssistantMessage, err := u.messageRepo.FindLatestMessageByRole(ctx, chatUUID, "assistant")
+	if err != nil {
+		return nil, fmt.Errorf("最新アシスタントメッセージ取得失敗: %w", err)
+	}
+
+	// 3.5. フォーク後にマージ先のチャットがどれだけ進んだかを調べる
+	if targetChatUUID == "" && chat.ParentUUID != nil {
+		targetChatUUID = *chat.ParentUUID
+	}
+	var divergence *model.MergeDivergence
+	var parentProgress string
+	if targetChatUUID != "" {
+		anchorMessageUUID, err := u.resolveMergeAnchor(ctx, chat, targetChatUUID)
+		if err != nil {
+			return nil, err
+		}
+		targetMessages, err := u.messageRepo.FindMessagesByChatID(ctx, targetChatUUID)
+		if err != nil {
+			return nil, fmt.Errorf("マージ先のメッセージ取得に失敗: %w", err)
+		}
+		divergence, parentProgress = analyzeMergeDivergence(anchorMessageUUID, targetMessages)
+		slog.InfoContext(ctx, "マージ先の進み具合を確認", "target_chat_uuid", targetChatUUID, "new_messages", divergence.NewMessages, "stale", divergence.Stale)
+	}
+
+	// 4. プロンプト構築
+	var parts []*genai.Content
+
+	var latestSummary, latestAssistant string
+	if latestSummaryMessage != nil && latestSummaryMessage.ContextSummary != nil {
+		latestSummary = *latestSummaryMessage.ContextSummary
+	}
+	if latestAssistantMessage != nil {
+		latestAssistant = latestAssistantMessage.Content
+	}
+
+	prompt, err := u.promptRenderer.Render(model.PromptMergePreview, u.resolveLanguage(ctx, chat), map[string]string{
+		"ContextSummary":  chat.ContextSummary,
+		"LatestSummary":   latestSummary,
+		"LatestAssistant": latestAssistant,
+		// マージ先が進んでいない場合は空になり、調整の指示は省略される
+		"ParentProgress": parentProgress,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("プロンプトのレンダリングに失敗: %w", err)
+	}
+
+	parts = append(parts, &genai.Content{
+		Role: "user",
+		Parts: []*genai.Part{
+			{Text: prompt.Text},
+		},
+	})
+
+	// 5. GenAI 呼び出し
+	client := u.genaiClient
+	modelName := "gemini-2.5-flash"
+
+	config := &genai.GenerateContentConfig{
+		ResponseMIMEType: "text/plain",
+	}
+
+	resp, err := client.GenerateContent(ctx, modelName, parts, config)
+	if err != nil {
+		return nil, fmt.Errorf("GenAI呼び出しに失敗: %w", err)
+	}
+
+	// 6. レスポンス解析
+	var generatedText string
+	for _, cand := range resp.Candidates {
+		if cand.Content != nil {
+			for _, part := range cand.Content.Parts {
+				generatedText += part.Text
+			}
+		}
+	}
+
+	return &model.MergePreview{
+		SuggestedSummary: generatedText,
+		PromptVersion:    prompt.Version,
+		TargetChatUUID:   targetChatUUID,
+		Divergence:       divergence,
+	}, nil
+}
+
+// マージプレビューでマージ先の進展として渡す直近のメッセージ数と、1件あたりの最大文字数
+const (
+	mergeProgressMessageLimit  = 3
+	mergeProgressMessageLength = 500
+)
+
+// 起点メッセージより後にマージ先へ追加されたメッセージを数え、進展の抜粋を作成する処理
+// messages にはマージ先のチャットの全メッセージを作成日時の昇順で渡す
+func analyzeMergeDivergence(anchorMessageUUID string, messages []*model.Message) (*model.MergeDivergence, string) {
+	divergence := &model.MergeDivergence{AnchorMessageUUID: anchorMessageUUID}
+
+	anchorIndex := -1
+	for i, msg := range messages {
+		if msg.UUID == anchorMessageUUID {
+			anchorIndex = i
+			break
+		}
+	}
+	// 起点メッセージが見つからない場合は比較できないので、進展なしとして扱う
+	if anchorIndex < 0 {
+		return divergence, ""
+	}
+
+	var progress []string
+	for _, msg := range messages[anchorIndex+1:] {
+		divergence.NewMessages++
+		switch msg.Role {
+		case "assistant":
+			divergence.NewAssistantMessages++
+		case "merge_report":
+			divergence.MergedReports++
+		default:
+			continue
+		}
+		content := []rune(msg.Content)
+		if len(content) > mergeProgressMessageLength {
+			progress = append(progress, string(content[:mergeProgressMessageLength])+"…")
+		} else {
+			progress = append(progress, msg.Content)
+		}
+	}
+	// 質問だけが追加された場合は結論が変わっていないので調整は不要
+	divergence.Stale = divergence.NewAssistantMessages > 0 || divergence.MergedReports > 0
+
+	if len(progress) > mergeProgressMessageLimit {
+		progress = progress[len(progress)-mergeProgressMessageLimit:]
+	}
+	return divergence, strings.Join(progress, "\n\n---\n\n")
+}
+
+// ブランチの比較で渡す主なAI回答の数と、1件あたりの最大文字数
+const (
+	comparisonMessageLimit  = 3
+	comparisonMessageLength = 1000
+)
 
-	return newChatUUID, nil
+// LLM が出力するブランチの比較結果
+type branchComparisonOutput struct {
+	ChatPosition   string   `json:"chat_position"`
+	OtherPosition  string   `json:"other_position"`
+	Agreements     []string `json:"agreements"`
+	Disagreements  []string `json:"disagreements"`
+	Recommendation string   `json:"recommendation"`
 }
 
-// マージプレビューを生成する
-func (u *chatUsecase) GetMergePreview(ctx context.Context, chatUUID string) (*model.MergePreview, error) {
-	slog.InfoContext(ctx, "マージプレビュー生成開始", "chat_uuid", chatUUID)
+// 2つのブランチを比較する
+// SaveNote を指定すると、比較結果を共通の祖先メッセージのメモとして保存する
+func (u *chatUsecase) CompareBranches(ctx context.Context, chatUUID string, params model.CompareBranchesParams) (*model.BranchComparison, error) {
+	slog.InfoContext(ctx, "ブランチ比較処理開始", "chat_uuid", chatUUID, "other_chat_uuid", params.OtherChatUUID, "save_note", params.SaveNote)
 
-	// 1. チャット情報の取得 (親からforkした理由 = ContextSummary を取得)
+	// 1. 比較する2つのチャットの取得 (同じプロジェクトの別のチャットのみ比較できる)
+	if params.OtherChatUUID == "" || params.OtherChatUUID == chatUUID {
+		return nil, fmt.Errorf("%w: 比較相手に別のチャットを指定してください", model.ErrInvalidComparisonTarget)
+	}
 	chat, err := u.chatRepo.FindByID(ctx, chatUUID)
 	if err != nil {
 		return nil, fmt.Errorf("チャット取得失敗: %w", err)
 	}
-
-	// 2. 子チャットの最新のサマリを取得
-	latestSummaryMessage, err := u.messageRepo.FindLatestMessageWithSummary(ctx, chatUUID)
+	otherChat, err := u.chatRepo.FindByID(ctx, params.OtherChatUUID)
 	if err != nil {
-		return nil, fmt.Errorf("最新サマリ取得失敗: %w", err)
+		return nil, fmt.Errorf("比較相手のチャット取得失敗: %w", err)
+	}
+	if otherChat.ProjectUUID != chat.ProjectUUID {
+		return nil, fmt.Errorf("%w: 別のプロジェクトのチャットとは比較できません", model.ErrInvalidComparisonTarget)
 	}
 
-	// 3. role='assistant' の最新メッセージを取得
-	latestAssistantMessage, err := u.messageRepo.FindLatestMessageByRole(ctx, chatUUID, "assistant")
+	// 2. 共通の祖先メッセージの特定
+	anchorChatUUID, anchorMessageUUID, err := u.findCommonAncestorMessage(ctx, chat, otherChat)
 	if err != nil {
-		return nil, fmt.Errorf("最新アシスタントメッセージ取得失敗: %w", err)
+		return nil, err
 	}
-
-	// 4. プロンプト構築
-	var parts []*genai.Content
-
-	prompt := "以下の情報を元に、子チャットでの議論の流れと結論を要約してください。\n\n"
-
-	prompt += "## 親チャットからForkした理由 (文脈)\n"
-	if chat.ContextSummary != "" {
-		prompt += chat.ContextSummary + "\n\n"
-	} else {
-		prompt += "なし\n\n"
+	if params.SaveNote && anchorMessageUUID == "" {
+		return nil, fmt.Errorf("%w: %s と %s", model.ErrNoCommonAncestor, chat.UUID, otherChat.UUID)
 	}
-
-	prompt += "## 子チャットの最新のサマリ (途中経過)\n"
-	if latestSummaryMessage != nil && latestSummaryMessage.ContextSummary != nil {
-		prompt += *latestSummaryMessage.ContextSummary + "\n\n"
-	} else {
-		prompt += "なし\n\n"
+	var anchor string
+	if anchorMessageUUID != "" {
+		anchorMessage, err := u.messageRepo.FindByID(ctx, anchorMessageUUID)
+		if err != nil {
+			return nil, fmt.Errorf("共通の祖先メッセージの取得に失敗: %w", err)
+		}
+		if anchorMessage != nil {
+			anchor = truncateRunes(anchorMessage.Content, comparisonMessageLength)
+		}
 	}
 
-	prompt += "## 最新のAI回答 (直近の結論)\n"
-	if latestAssistantMessage != nil {
-		prompt += latestAssistantMessage.Content + "\n\n"
-	} else {
-		prompt += "なし\n\n"
+	// 3. それぞれのブランチのサマリと主なAI回答を集める
+	chatSummary, chatMessages, err := u.collectBranchExcerpt(ctx, chat.UUID)
+	if err != nil {
+		return nil, err
+	}
+	otherSummary, otherMessages, err := u.collectBranchExcerpt(ctx, otherChat.UUID)
+	if err != nil {
+		return nil, err
 	}
 
-	prompt += `
-出力フォーマット:
-## 議論の流れ
-(ここに議論の流れを記述)
-
-## 結論
-(ここに結論を記述)
-`
-
-	parts = append(parts, &genai.Content{
-		Role: "user",
-		Parts: []*genai.Part{
-			{Text: prompt},
-		},
+	// 4. プロンプト構築
+	prompt, err := u.promptRenderer.Render(model.PromptBranchComparison, u.resolveLanguage(ctx, chat), map[string]string{
+		"Anchor":        anchor,
+		"ChatTitle":     chat.Title,
+		"ChatContext":   chat.ContextSummary,
+		"ChatSummary":   chatSummary,
+		"ChatMessages":  chatMessages,
+		"OtherTitle":    otherChat.Title,
+		"OtherContext":  otherChat.ContextSummary,
+		"OtherSummary":  otherSummary,
+		"OtherMessages": otherMessages,
 	})
+	if err != nil {
+		return nil, fmt.Errorf("プロンプトのレンダリングに失敗: %w", err)
+	}
+	parts := []*genai.Content{
+		{
+			Role: "user",
+			Parts: []*genai.Part{
+				{Text: prompt.Text},
+			},
+		},
+	}
 
 	// 5. GenAI 呼び出し
-	client := u.genaiClient
-	modelName := "gemini-2.5-flash"
-
 	config := &genai.GenerateContentConfig{
-		ResponseMIMEType: "text/plain",
+		ResponseMIMEType: "application/json",
 	}
-
-	resp, err := client.GenerateContent(ctx, modelName, parts, config)
+	resp, err := u.genaiClient.GenerateContent(ctx, "gemini-2.5-flash", parts, config)
 	if err != nil {
 		return nil, fmt.Errorf("GenAI呼び出しに失敗: %w", err)
 	}
@@ -732,15 +1850,180 @@ func (u *chatUsecase) GetMergePreview(ctx context.Context, chatUUID string) (*mo
 			}
 		}
 	}
+	var output branchComparisonOutput
+	if err := json.Unmarshal([]byte(generatedText), &output); err != nil {
+		return nil, fmt.Errorf("JSON出力に失敗: %w", err)
+	}
 
-	return &model.MergePreview{
-		SuggestedSummary: generatedText,
-	}, nil
+	comparison := &model.BranchComparison{
+		ProjectUUID:   chat.ProjectUUID,
+		ChatUUID:      chat.UUID,
+		OtherChatUUID: otherChat.UUID,
+		Positions: []model.BranchPosition{
+			{ChatUUID: chat.UUID, Title: chat.Title, Position: output.ChatPosition},
+			{ChatUUID: otherChat.UUID, Title: otherChat.Title, Position: output.OtherPosition},
+		},
+		Agreements:     output.Agreements,
+		Disagreements:  output.Disagreements,
+		Recommendation: output.Recommendation,
+		PromptVersion:  prompt.Version,
+		CreatedAt:      time.Now(),
+	}
+	if comparison.Agreements == nil {
+		comparison.Agreements = []string{}
+	}
+	if comparison.Disagreements == nil {
+		comparison.Disagreements = []string{}
+	}
+	if anchorMessageUUID != "" {
+		comparison.AnchorChatUUID = &anchorChatUUID
+		comparison.AnchorMessageUUID = &anchorMessageUUID
+	}
+
+	// 7. 共通の祖先メッセージのメモとして保存
+	if params.SaveNote {
+		comparison.UUID = uuid.New().String()
+		if params.UserUUID != "" {
+			comparison.UserUUID = &params.UserUUID
+		}
+		if err := u.branchComparisonRepo.Create(ctx, comparison); err != nil {
+			return nil, fmt.Errorf("比較結果の保存に失敗: %w", err)
+		}
+	}
+
+	slog.InfoContext(ctx, "ブランチ比較処理完了", "chat_uuid", chatUUID, "other_chat_uuid", otherChat.UUID, "anchor_message_uuid", anchorMessageUUID, "saved", params.SaveNote)
+	return comparison, nil
+}
+
+// チャットのメッセージに紐づく比較結果のメモを取得する
+func (u *chatUsecase) GetBranchComparisons(ctx context.Context, chatUUID string) ([]*model.BranchComparison, error) {
+	slog.InfoContext(ctx, "ブランチ比較結果取得処理開始", "chat_uuid", chatUUID)
+	comparisons, err := u.branchComparisonRepo.FindByAnchorChatUUID(ctx, chatUUID)
+	if err != nil {
+		return nil, fmt.Errorf("比較結果の取得に失敗: %w", err)
+	}
+	slog.InfoContext(ctx, "ブランチ比較結果取得処理完了", "chat_uuid", chatUUID, "count", len(comparisons))
+	return comparisons, nil
+}
+
+// 2つのチャットの共通の祖先メッセージを特定する処理
+// 一方が他方の祖先の場合は、もう一方のブランチがそのチャットから分岐したメッセージを返す
+// 共通の祖先がない場合は空文字を返す
+func (u *chatUsecase) findCommonAncestorMessage(ctx context.Context, chat *model.Chat, otherChat *model.Chat) (string, string, error) {
+	// 祖先のチャットごとに、そのチャットへ下から入ってきたブランチの起点メッセージを記録する
+	chatAncestors, err := u.collectAncestorEntries(ctx, chat)
+	if err != nil {
+		return "", "", err
+	}
+	chatEntries := make(map[string]string, len(chatAncestors))
+	for _, entry := range chatAncestors {
+		chatEntries[entry.chatUUID] = entry.sourceMessageUUID
+	}
+	otherEntries, err := u.collectAncestorEntries(ctx, otherChat)
+	if err != nil {
+		return "", "", err
+	}
+
+	// 近い祖先から順に探すので、最初に見つかったものが最も近い共通の祖先になる
+	for _, entry := range otherEntries {
+		chatEntry, ok := chatEntries[entry.chatUUID]
+		if !ok {
+			continue
+		}
+		switch {
+		case chatEntry == "":
+			return entry.chatUUID, entry.sourceMessageUUID, nil
+		case entry.sourceMessageUUID == "" || entry.sourceMessageUUID == chatEntry:
+			return entry.chatUUID, chatEntry, nil
+		}
+		// 同じチャットの別々のメッセージから分岐している場合は、先に分岐した方を起点とする
+		messages, err := u.messageRepo.FindMessagesByChatID(ctx, entry.chatUUID)
+		if err != nil {
+			return "", "", fmt.Errorf("共通の祖先チャットのメッセージ取得に失敗: %w", err)
+		}
+		for _, msg := range messages {
+			if msg.UUID == chatEntry || msg.UUID == entry.sourceMessageUUID {
+				return entry.chatUUID, msg.UUID, nil
+			}
+		}
+		return entry.chatUUID, chatEntry, nil
+	}
+	return "", "", nil
+}
+
+// 祖先を辿る際に、チャットとそのチャットから分岐した起点メッセージを組にしたもの
+type ancestorEntry struct {
+	chatUUID          string
+	sourceMessageUUID string
+}
+
+// チャット自身から根までの祖先を近い順に取得する処理
+// 起点メッセージはチャット自身の場合は空になる
+func (u *chatUsecase) collectAncestorEntries(ctx context.Context, chat *model.Chat) ([]ancestorEntry, error) {
+	entries := []ancestorEntry{{chatUUID: chat.UUID}}
+	visited := map[string]bool{chat.UUID: true}
+	current := chat
+	for current.ParentUUID != nil {
+		parentUUID := *current.ParentUUID
+		// 親子関係が循環している場合は辿るのをやめる
+		if visited[parentUUID] {
+			break
+		}
+		visited[parentUUID] = true
+		sourceMessageUUID := ""
+		if current.SourceMessageUUID != nil {
+			sourceMessageUUID = *current.SourceMessageUUID
+		}
+		entries = append(entries, ancestorEntry{chatUUID: parentUUID, sourceMessageUUID: sourceMessageUUID})
+
+		parent, err := u.chatRepo.FindByID(ctx, parentUUID)
+		if err != nil {
+			return nil, fmt.Errorf("祖先チャットの取得に失敗: %w", err)
+		}
+		current = parent
+	}
+	return entries, nil
+}
+
+// ブランチの最新のサマリと、直近の主なAI回答の抜粋を作成する処理
+func (u *chatUsecase) collectBranchExcerpt(ctx context.Context, chatUUID string) (string, string, error) {
+	var summary string
+	summaryMessage, err := u.messageRepo.FindLatestMessageWithSummary(ctx, chatUUID)
+	if err != nil {
+		return "", "", fmt.Errorf("最新サマリ取得失敗 (chat_uuid: %s): %w", chatUUID, err)
+	}
+	if summaryMessage != nil && summaryMessage.ContextSummary != nil {
+		summary = *summaryMessage.ContextSummary
+	}
+
+	messages, err := u.messageRepo.FindMessagesByChatID(ctx, chatUUID)
+	if err != nil {
+		return "", "", fmt.Errorf("メッセージ履歴の取得に失敗 (chat_uuid: %s): %w", chatUUID, err)
+	}
+	var answers []string
+	for _, msg := range messages {
+		if msg.Role == "assistant" {
+			answers = append(answers, truncateRunes(msg.Content, comparisonMessageLength))
+		}
+	}
+	if len(answers) > comparisonMessageLimit {
+		answers = answers[len(answers)-comparisonMessageLimit:]
+	}
+	return summary, strings.Join(answers, "\n\n---\n\n"), nil
+}
+
+// 文字数が上限を超える場合に末尾を省略する処理
+func truncateRunes(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit]) + "…"
 }
 
 // チャットをマージする
 func (u *chatUsecase) MergeChat(ctx context.Context, chatUUID string, params model.MergeChatParams) (*model.MergeChatResult, error) {
-	slog.InfoContext(ctx, "チャットマージ処理開始", "chat_uuid", chatUUID, "parent_chat_uuid", params.ParentChatUUID)
+	slog.InfoContext(ctx, "チャットマージ処理開始", "chat_uuid", chatUUID, "parent_chat_uuid", params.ParentChatUUID, "include_descendants", params.IncludeDescendants)
 
 	// 1. 子チャットの取得
 	childChat, err := u.chatRepo.FindByID(ctx, chatUUID)
@@ -751,30 +2034,105 @@ func (u *chatUsecase) MergeChat(ctx context.Context, chatUUID string, params mod
 	if childChat.SourceMessageUUID == nil {
 		return nil, fmt.Errorf("子チャットにソースメッセージが設定されていません")
 	}
+	if !model.CanTransitionChatStatus(childChat.Status, model.ChatStatusMerged) {
+		return nil, fmt.Errorf("%w: %s から %s には遷移できません", model.ErrInvalidChatStatusTransition, childChat.Status, model.ChatStatusMerged)
+	}
 
-	reportMessageID := uuid.New().String()
+	// 2. マージ先が祖先のチャットであることを検証し、レポートを紐付けるメッセージを特定する
+	// マージ先を指定しない場合は直接の親チャットにマージする
+	targetChatUUID := params.ParentChatUUID
+	if targetChatUUID == "" && childChat.ParentUUID != nil {
+		targetChatUUID = *childChat.ParentUUID
+	}
+	anchorMessageUUID, err := u.resolveMergeAnchor(ctx, childChat, targetChatUUID)
+	if err != nil {
+		return nil, err
+	}
+	// マージレポートを追加するので、マージ先も open である必要がある
+	targetChat, err := u.chatRepo.FindByID(ctx, targetChatUUID)
+	if err != nil {
+		return nil, fmt.Errorf("マージ先のチャットの取得に失敗: %w", err)
+	}
+	if !targetChat.IsWritable() {
+		return nil, fmt.Errorf("%w: %s", model.ErrChatNotOpen, targetChatUUID)
+	}
 
-	// 2. トランザクション処理
-	err = u.transactionManager.Do(ctx, func(ctx context.Context) error {
-		// 2-1. マージレポートメッセージの作成
-		// 親チャットに追加するので、ChatUUIDはParentChatUUIDになる
-		reportMessage := &model.Message{
-			UUID:              reportMessageID,
-			ChatUUID:          params.ParentChatUUID,
-			Role:              "merge_report",
-			Content:           params.SummaryContent,
-			ParentMessageUUID: childChat.SourceMessageUUID, // どのメッセージから派生したチャットがマージされたかを示す
-			SourceChatUUID:    &chatUUID,                   // どのチャットがマージされたか
-			CreatedAt:         time.Now(),
+	// 3. 部分木ごとマージする場合は子孫のチャットもまとめてマージ済みにする
+	mergedChats := []*model.Chat{childChat}
+	if params.IncludeDescendants {
+		descendants, err := u.collectDescendantChats(ctx, chatUUID)
+		if err != nil {
+			return nil, err
+		}
+		for _, descendant := range descendants {
+			// 既に個別にマージされたブランチはそのレポートを残し、クローズされたブランチはそのままにする
+			if model.CanTransitionChatStatus(descendant.Status, model.ChatStatusMerged) {
+				mergedChats = append(mergedChats, descendant)
+			}
 		}
+	}
+
+	reportMessageID := uuid.New().String()
+
+	var userUUID *string
+	if params.UserUUID != "" {
+		userUUID = &params.UserUUID
+	}
 
+	var promptVersion *string
+	if params.PromptVersion != "" {
+		promptVersion = &params.PromptVersion
+	}
+
+	// マージレポートはマージ先のチャットに追加するので、ChatUUIDはマージ先のチャットになる
+	reportMessage := &model.Message{
+		UUID:              reportMessageID,
+		ChatUUID:          targetChatUUID,
+		Role:              "merge_report",
+		Content:           params.SummaryContent,
+		ParentMessageUUID: &anchorMessageUUID, // マージ先のチャットのどのメッセージから派生したブランチがマージされたかを示す
+		SourceChatUUID:    &chatUUID,          // どのチャットがマージされたか
+		PromptVersion:     promptVersion,      // マージレポートのプレビュー生成に使用したプロンプト
+		CreatedAt:         time.Now(),
+	}
+
+	// 4. トランザクション処理
+	var mergedChatUUIDs []string
+	err = u.transactionManager.Do(ctx, func(ctx context.Context) error {
+		mergedChatUUIDs = mergedChatUUIDs[:0]
+		// 4-1. マージレポートメッセージの作成
 		if err := u.messageRepo.Create(ctx, reportMessage); err != nil {
 			return fmt.Errorf("マージレポートメッセージの作成に失敗: %w", err)
 		}
 
-		// 2-2. 子チャットのステータス更新
-		if err := u.chatRepo.UpdateStatus(ctx, chatUUID, "merged"); err != nil {
-			return fmt.Errorf("子チャットのステータス更新に失敗: %w", err)
+		for _, chat := range mergedChats {
+			// 4-2. チャットのステータス更新 (確認した後に別のリクエストがステータスを変えていた場合は更新しない)
+			ok, err := u.chatRepo.TransitionStatus(ctx, chat.UUID, chat.Status, model.ChatStatusMerged)
+			if err != nil {
+				return fmt.Errorf("子チャットのステータス更新に失敗: %w", err)
+			}
+			if !ok {
+				if chat.UUID == childChat.UUID {
+					return fmt.Errorf("%w: ステータスが変更されたためマージできません (chat_uuid: %s)", model.ErrInvalidChatStatusTransition, chat.UUID)
+				}
+				// 子孫のチャットは個別にマージ・クローズされたものとしてそのままにする
+				continue
+			}
+			mergedChatUUIDs = append(mergedChatUUIDs, chat.UUID)
+
+			// 4-3. マージ取り消し時にステータスを戻せるように履歴を残す
+			if err := u.chatMergeEventRepo.Create(ctx, &model.ChatMergeEvent{
+				UUID:              uuid.New().String(),
+				ChatUUID:          chat.UUID,
+				ParentChatUUID:    targetChatUUID,
+				ReportMessageUUID: reportMessageID,
+				Action:            model.ChatMergeActionMerged,
+				PreviousStatus:    chat.Status,
+				UserUUID:          userUUID,
+				CreatedAt:         reportMessage.CreatedAt,
+			}); err != nil {
+				return fmt.Errorf("マージ履歴の作成に失敗: %w", err)
+			}
 		}
 
 		return nil
@@ -785,51 +2143,344 @@ func (u *chatUsecase) MergeChat(ctx context.Context, chatUUID string, params mod
 		return nil, err
 	}
 
-	slog.InfoContext(ctx, "チャットマージ処理完了", "chat_uuid", chatUUID)
+	u.publishEvent(model.ChatEvent{Type: model.ChatEventMessage, ChatUUID: targetChatUUID, Message: reportMessage})
+
+	// 5. マージ先のチャットにレポートの結論を取り込む
+	acknowledgmentMessageID := ""
+	if params.Acknowledge {
+		// レポートの結論を会話に統合する回答を生成する (回答の生成後にサマリも更新される)
+		acknowledgmentMessageID = u.acknowledgeMergeReport(ctx, targetChatUUID)
+	} else {
+		// マージ先の要約にレポートの結論が含まれるようにサマリを生成し直す
+		u.publishSummaryTask(ctx, targetChatUUID, u.resolveLanguage(ctx, targetChat))
+	}
+
+	slog.InfoContext(ctx, "チャットマージ処理完了", "chat_uuid", chatUUID, "target_chat_uuid", targetChatUUID, "merged_chats", len(mergedChatUUIDs))
 
 	return &model.MergeChatResult{
 		ReportMessageID: reportMessageID,
 		SummaryContent:  params.SummaryContent,
+		TargetChatUUID:  targetChatUUID,
+		MergedChatUUIDs: mergedChatUUIDs,
+		// 回答を生成しなかった、または生成に失敗した場合は空になる
+		AcknowledgmentMessageID: acknowledgmentMessageID,
+	}, nil
+}
+
+// マージレポートの結論を会話に統合する回答を生成し、生成したメッセージのIDを返す処理
+// マージ自体は完了しているので、生成に失敗してもエラーにはしない
+func (u *chatUsecase) acknowledgeMergeReport(ctx context.Context, chatUUID string) string {
+	outputChan := make(chan string)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range outputChan {
+		}
+	}()
+	err := u.StreamMessage(ctx, chatUUID, outputChan)
+	close(outputChan)
+	<-done
+	if err != nil {
+		slog.WarnContext(ctx, "マージレポートへの回答生成に失敗", "chat_uuid", chatUUID, "error", err)
+		return ""
+	}
+
+	message, err := u.messageRepo.FindLatestMessageByRole(ctx, chatUUID, "assistant")
+	if err != nil || message == nil {
+		slog.WarnContext(ctx, "マージレポートへの回答の取得に失敗", "chat_uuid", chatUUID, "error", err)
+		return ""
+	}
+	return message.UUID
+}
+
+// マージ先が子チャットの祖先であることを検証し、マージレポートを紐付けるメッセージを返す処理
+// 祖先へのマージでは、マージ先のチャットから直接フォークされたブランチの起点メッセージに紐付ける
+func (u *chatUsecase) resolveMergeAnchor(ctx context.Context, childChat *model.Chat, targetChatUUID string) (string, error) {
+	visited := map[string]bool{childChat.UUID: true}
+	current := childChat
+	for current.ParentUUID != nil {
+		parentUUID := *current.ParentUUID
+		if parentUUID == targetChatUUID {
+			if current.SourceMessageUUID == nil {
+				return "", fmt.Errorf("%w: ブランチにソースメッセージが設定されていません: %s", model.ErrInvalidMergeTarget, current.UUID)
+			}
+			return *current.SourceMessageUUID, nil
+		}
+		// 親子関係が循環している場合は辿るのをやめる
+		if visited[parentUUID] {
+			break
+		}
+		visited[parentUUID] = true
+
+		parent, err := u.chatRepo.FindByID(ctx, parentUUID)
+		if err != nil {
+			return "", fmt.Errorf("祖先チャットの取得に失敗: %w", err)
+		}
+		current = parent
+	}
+	return "", fmt.Errorf("%w: %s は %s の祖先ではありません", model.ErrInvalidMergeTarget, targetChatUUID, childChat.UUID)
+}
+
+// 指定したチャットの子孫のチャットを幅優先で取得する処理
+func (u *chatUsecase) collectDescendantChats(ctx context.Context, chatUUID string) ([]*model.Chat, error) {
+	descendants := []*model.Chat{}
+	visited := map[string]bool{chatUUID: true}
+	queue := []string{chatUUID}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		children, err := u.chatRepo.FindByParentUUID(ctx, current)
+		if err != nil {
+			return nil, fmt.Errorf("子チャットの取得に失敗 (chat_uuid: %s): %w", current, err)
+		}
+		for _, child := range children {
+			if visited[child.UUID] {
+				continue
+			}
+			visited[child.UUID] = true
+			descendants = append(descendants, child)
+			queue = append(queue, child.UUID)
+		}
+	}
+	return descendants, nil
+}
+
+// チャットのマージを取り消す
+// 親チャットのマージレポートを論理削除し、子チャットをマージ前のステータスに戻す
+func (u *chatUsecase) UnmergeChat(ctx context.Context, chatUUID string, params model.UnmergeChatParams) (*model.UnmergeChatResult, error) {
+	slog.InfoContext(ctx, "チャットマージ取り消し処理開始", "chat_uuid", chatUUID, "force", params.Force)
+
+	// 1. 子チャットの取得
+	childChat, err := u.chatRepo.FindByID(ctx, chatUUID)
+	if err != nil {
+		return nil, fmt.Errorf("子チャットの取得に失敗: %w", err)
+	}
+	if childChat.Status != model.ChatStatusMerged {
+		return nil, fmt.Errorf("%w: %s", model.ErrChatNotMerged, chatUUID)
+	}
+
+	// 2. マージ先とマージ前のステータスを履歴から取得
+	mergedEvent, err := u.chatMergeEventRepo.FindLatestByChatUUID(ctx, chatUUID, model.ChatMergeActionMerged)
+	if err != nil {
+		return nil, fmt.Errorf("マージ履歴の取得に失敗: %w", err)
+	}
+	parentChatUUID := ""
+	// merged から open に戻せるのはマージの取り消しのみ
+	previousStatus := model.ChatStatusOpen
+	if mergedEvent != nil {
+		parentChatUUID = mergedEvent.ParentChatUUID
+		if mergedEvent.PreviousStatus != "" && mergedEvent.PreviousStatus != model.ChatStatusMerged {
+			previousStatus = mergedEvent.PreviousStatus
+		}
+	} else if childChat.ParentUUID != nil {
+		// 履歴を残す前にマージされたチャットは親チャットにマージされたものとみなす
+		parentChatUUID = *childChat.ParentUUID
+	}
+	if parentChatUUID == "" {
+		return nil, fmt.Errorf("%w: マージ先のチャットが特定できません: %s", model.ErrChatNotMerged, chatUUID)
+	}
+
+	// 3. 親チャットからマージレポートを探す
+	parentMessages, err := u.messageRepo.FindMessagesByChatID(ctx, parentChatUUID)
+	if err != nil {
+		return nil, fmt.Errorf("親チャットのメッセージ取得に失敗: %w", err)
+	}
+	var report *model.Message
+	for _, msg := range parentMessages {
+		if msg.Role != "merge_report" {
+			continue
+		}
+		if mergedEvent != nil {
+			// 部分木ごとマージされた子孫のチャットは、起点のチャットのレポートを共有している
+			if msg.UUID == mergedEvent.ReportMessageUUID {
+				report = msg
+			}
+		} else if msg.SourceChatUUID != nil && *msg.SourceChatUUID == chatUUID {
+			report = msg
+		}
+	}
+	if report == nil {
+		return nil, fmt.Errorf("%w: マージレポートが見つかりません: %s", model.ErrChatNotMerged, chatUUID)
+	}
+
+	// 4. マージレポートを文脈に含めて生成された回答がある場合は、強制指定がない限り取り消さない
+	if !params.Force {
+		for _, msg := range parentMessages {
+			if msg.Role == "assistant" && msg.CreatedAt.After(report.CreatedAt) {
+				return nil, fmt.Errorf("%w: %s", model.ErrMergeReportInUse, report.UUID)
+			}
+		}
+	}
+
+	var userUUID *string
+	if params.UserUUID != "" {
+		userUUID = &params.UserUUID
+	}
+
+	// 5. 同じレポートでマージされた部分木のチャットもまとめて元に戻す
+	restores := map[string]string{chatUUID: previousStatus}
+	restoreOrder := []string{chatUUID}
+	reportEvents, err := u.chatMergeEventRepo.FindByReportMessageUUID(ctx, report.UUID)
+	if err != nil {
+		return nil, fmt.Errorf("マージ履歴の取得に失敗: %w", err)
+	}
+	for _, event := range reportEvents {
+		if event.Action != model.ChatMergeActionMerged {
+			continue
+		}
+		if _, ok := restores[event.ChatUUID]; ok {
+			continue
+		}
+		chat, err := u.chatRepo.FindByID(ctx, event.ChatUUID)
+		if err != nil {
+			return nil, fmt.Errorf("チャットの取得に失敗: %w", err)
+		}
+		if chat.Status != model.ChatStatusMerged {
+			continue
+		}
+		status := model.ChatStatusOpen
+		if event.PreviousStatus != "" && event.PreviousStatus != model.ChatStatusMerged {
+			status = event.PreviousStatus
+		}
+		restores[event.ChatUUID] = status
+		restoreOrder = append(restoreOrder, event.ChatUUID)
+	}
+
+	// 6. トランザクション処理
+	now := time.Now()
+	err = u.transactionManager.Do(ctx, func(ctx context.Context) error {
+		if err := u.messageRepo.Delete(ctx, report.UUID); err != nil {
+			return fmt.Errorf("マージレポートの削除に失敗: %w", err)
+		}
+		for _, restoreChatUUID := range restoreOrder {
+			if err := u.chatRepo.UpdateStatus(ctx, restoreChatUUID, restores[restoreChatUUID]); err != nil {
+				return fmt.Errorf("子チャットのステータス更新に失敗: %w", err)
+			}
+			if err := u.chatMergeEventRepo.Create(ctx, &model.ChatMergeEvent{
+				UUID:              uuid.New().String(),
+				ChatUUID:          restoreChatUUID,
+				ParentChatUUID:    parentChatUUID,
+				ReportMessageUUID: report.UUID,
+				Action:            model.ChatMergeActionUnmerged,
+				PreviousStatus:    "merged",
+				Forced:            params.Force,
+				UserUUID:          userUUID,
+				CreatedAt:         now,
+			}); err != nil {
+				return fmt.Errorf("マージ履歴の作成に失敗: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "チャットマージ取り消し処理失敗", "error", err)
+		return nil, err
+	}
+
+	u.publishEvent(model.ChatEvent{Type: model.ChatEventMessageRemoved, ChatUUID: parentChatUUID, Message: report})
+
+	slog.InfoContext(ctx, "チャットマージ取り消し処理完了", "chat_uuid", chatUUID, "status", previousStatus)
+	return &model.UnmergeChatResult{
+		ChatUUID:          chatUUID,
+		Status:            previousStatus,
+		ReportMessageUUID: report.UUID,
+		RestoredChatUUIDs: restoreOrder,
 	}, nil
 }
 
+// チャットのマージ履歴を取得する
+func (u *chatUsecase) GetMergeHistory(ctx context.Context, chatUUID string) ([]*model.ChatMergeEvent, error) {
+	slog.InfoContext(ctx, "マージ履歴取得処理開始", "chat_uuid", chatUUID)
+	events, err := u.chatMergeEventRepo.FindByChatUUID(ctx, chatUUID)
+	if err != nil {
+		return nil, fmt.Errorf("マージ履歴の取得に失敗: %w", err)
+	}
+	slog.InfoContext(ctx, "マージ履歴取得処理完了", "chat_uuid", chatUUID, "count", len(events))
+	return events, nil
+}
+
 // チャットをクローズする
-func (u *chatUsecase) CloseChat(ctx context.Context, chatUUID string) (string, error) {
-	slog.InfoContext(ctx, "チャットクローズ処理開始", "chat_uuid", chatUUID)
+// cascade を指定すると、子孫のブランチのうち open のものもまとめてクローズする
+func (u *chatUsecase) CloseChat(ctx context.Context, chatUUID string, cascade bool) ([]string, error) {
+	slog.InfoContext(ctx, "チャットクローズ処理開始", "chat_uuid", chatUUID, "cascade", cascade)
 
 	// 1. チャットの存在確認
-	_, err := u.chatRepo.FindByID(ctx, chatUUID)
+	chat, err := u.chatRepo.FindByID(ctx, chatUUID)
 	if err != nil {
 		slog.ErrorContext(ctx, "チャットが見つかりません", "chat_uuid", chatUUID, "error", err)
-		return "", err
+		return nil, err
+	}
+	if !model.CanTransitionChatStatus(chat.Status, model.ChatStatusClosed) {
+		return nil, fmt.Errorf("%w: %s から %s には遷移できません", model.ErrInvalidChatStatusTransition, chat.Status, model.ChatStatusClosed)
+	}
+
+	// 2. 子孫のブランチのうちクローズできるものを集める (マージ済み・クローズ済みのものはそのままにする)
+	candidates := []*model.Chat{chat}
+	if cascade {
+		descendants, err := u.collectDescendantChats(ctx, chatUUID)
+		if err != nil {
+			return nil, err
+		}
+		for _, descendant := range descendants {
+			if model.CanTransitionChatStatus(descendant.Status, model.ChatStatusClosed) {
+				candidates = append(candidates, descendant)
+			}
+		}
 	}
 
-	// 2. ステータスを closed に更新
-	if err := u.chatRepo.UpdateStatus(ctx, chatUUID, "closed"); err != nil {
+	// 3. ステータスを closed に更新 (確認した後に別のリクエストがステータスを変えていた場合は更新しない)
+	var closedChatUUIDs []string
+	err = u.transactionManager.Do(ctx, func(ctx context.Context) error {
+		closedChatUUIDs = closedChatUUIDs[:0]
+		for _, candidate := range candidates {
+			ok, err := u.chatRepo.TransitionStatus(ctx, candidate.UUID, candidate.Status, model.ChatStatusClosed)
+			if err != nil {
+				return fmt.Errorf("チャットステータス更新失敗 (chat_uuid: %s): %w", candidate.UUID, err)
+			}
+			if !ok {
+				if candidate.UUID == chatUUID {
+					return fmt.Errorf("%w: ステータスが変更されたためクローズできません (chat_uuid: %s)", model.ErrInvalidChatStatusTransition, chatUUID)
+				}
+				continue
+			}
+			closedChatUUIDs = append(closedChatUUIDs, candidate.UUID)
+		}
+		return nil
+	})
+	if err != nil {
 		slog.ErrorContext(ctx, "チャットステータス更新失敗", "chat_uuid", chatUUID, "error", err)
-		return "", err
+		return nil, err
 	}
 
-	slog.InfoContext(ctx, "チャットクローズ処理完了", "chat_uuid", chatUUID)
-	return chatUUID, nil
+	slog.InfoContext(ctx, "チャットクローズ処理完了", "chat_uuid", chatUUID, "closed_chats", len(closedChatUUIDs))
+	return closedChatUUIDs, nil
 }
 
 // チャットをオープンする
+// マージ済みのチャットはマージを取り消して再開する必要があるため、クローズしたチャットのみ対象とする
 func (u *chatUsecase) OpenChat(ctx context.Context, chatUUID string) (string, error) {
 	slog.InfoContext(ctx, "チャットオープン処理開始", "chat_uuid", chatUUID)
 
 	// 1. チャットの存在確認
-	_, err := u.chatRepo.FindByID(ctx, chatUUID)
+	chat, err := u.chatRepo.FindByID(ctx, chatUUID)
 	if err != nil {
 		slog.ErrorContext(ctx, "チャットが見つかりません", "chat_uuid", chatUUID, "error", err)
 		return "", err
 	}
+	if !model.CanTransitionChatStatus(chat.Status, model.ChatStatusOpen) {
+		return "", fmt.Errorf("%w: %s から %s には遷移できません", model.ErrInvalidChatStatusTransition, chat.Status, model.ChatStatusOpen)
+	}
 
-	// 2. ステータスを open に更新
-	if err := u.chatRepo.UpdateStatus(ctx, chatUUID, "open"); err != nil {
+	// 2. ステータスを open に更新 (確認した後に別のリクエストがステータスを変えていた場合は更新しない)
+	ok, err := u.chatRepo.TransitionStatus(ctx, chatUUID, chat.Status, model.ChatStatusOpen)
+	if err != nil {
 		slog.ErrorContext(ctx, "チャットステータス更新失敗", "chat_uuid", chatUUID, "error", err)
 		return "", err
 	}
+	if !ok {
+		return "", fmt.Errorf("%w: ステータスが変更されたためオープンできません (chat_uuid: %s)", model.ErrInvalidChatStatusTransition, chatUUID)
+	}
 
 	slog.InfoContext(ctx, "チャットオープン処理完了", "chat_uuid", chatUUID)
 	return chatUUID, nil
@@ -4,7 +4,10 @@ import (
 	"backend/internal/domain/model"
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/stretchr/testify/assert"
@@ -49,6 +52,11 @@ func (m *MockChatRepository) UpdateStatus(ctx context.Context, chatUUID string,
 	return args.Error(0)
 }
 
+func (m *MockChatRepository) TransitionStatus(ctx context.Context, chatUUID string, from string, to string) (bool, error) {
+	args := m.Called(ctx, chatUUID, from, to)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockChatRepository) FindOldestByProjectUUID(ctx context.Context, projectUUID string) (*model.Chat, error) {
 	args := m.Called(ctx, projectUUID)
 	if args.Get(0) == nil {
@@ -57,11 +65,52 @@ func (m *MockChatRepository) FindOldestByProjectUUID(ctx context.Context, projec
 	return args.Get(0).(*model.Chat), args.Error(1)
 }
 
+func (m *MockChatRepository) FindAllByProjectUUID(ctx context.Context, projectUUID string) ([]*model.Chat, error) {
+	args := m.Called(ctx, projectUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Chat), args.Error(1)
+}
+
 func (m *MockChatRepository) CountByProjectUUID(ctx context.Context, projectUUID string) (int64, error) {
 	args := m.Called(ctx, projectUUID)
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockChatRepository) AcquireGenerationLock(ctx context.Context, chatUUID string, owner string, now time.Time, until time.Time) (bool, error) {
+	args := m.Called(ctx, chatUUID, owner, now, until)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockChatRepository) ExtendGenerationLock(ctx context.Context, chatUUID string, owner string, until time.Time) (bool, error) {
+	args := m.Called(ctx, chatUUID, owner, until)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockChatRepository) FindByParentUUID(ctx context.Context, parentChatUUID string) ([]*model.Chat, error) {
+	args := m.Called(ctx, parentChatUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Chat), args.Error(1)
+}
+
+func (m *MockChatRepository) ReleaseGenerationLock(ctx context.Context, chatUUID string, owner string) error {
+	args := m.Called(ctx, chatUUID, owner)
+	return args.Error(0)
+}
+
+func (m *MockChatRepository) UpdateParent(ctx context.Context, chatUUID string, parentChatUUID string, sourceMessageUUID string, messageSelectionUUID *string) error {
+	args := m.Called(ctx, chatUUID, parentChatUUID, sourceMessageUUID, messageSelectionUUID)
+	return args.Error(0)
+}
+
+func (m *MockChatRepository) UpdateContextSummary(ctx context.Context, chatUUID string, summary string, promptVersion string) error {
+	args := m.Called(ctx, chatUUID, summary, promptVersion)
+	return args.Error(0)
+}
+
 type MockMessageRepository struct {
 	mock.Mock
 }
@@ -79,8 +128,24 @@ func (m *MockMessageRepository) FindMessagesByChatID(ctx context.Context, chatUU
 	return args.Get(0).([]*model.Message), args.Error(1)
 }
 
-func (m *MockMessageRepository) UpdateContextSummary(ctx context.Context, messageUUID string, summary string) error {
-	args := m.Called(ctx, messageUUID, summary)
+func (m *MockMessageRepository) FindMessagesByProjectUUID(ctx context.Context, projectUUID string) ([]*model.Message, error) {
+	args := m.Called(ctx, projectUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Message), args.Error(1)
+}
+
+func (m *MockMessageRepository) FindMessageStructureByProjectUUID(ctx context.Context, projectUUID string) ([]*model.Message, error) {
+	args := m.Called(ctx, projectUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Message), args.Error(1)
+}
+
+func (m *MockMessageRepository) UpdateContextSummary(ctx context.Context, messageUUID string, summary string, promptVersion string) error {
+	args := m.Called(ctx, messageUUID, summary, promptVersion)
 	return args.Error(0)
 }
 
@@ -108,6 +173,83 @@ func (m *MockMessageRepository) FindByID(ctx context.Context, uuid string) (*mod
 	return args.Get(0).(*model.Message), args.Error(1)
 }
 
+func (m *MockMessageRepository) Delete(ctx context.Context, uuid string) error {
+	args := m.Called(ctx, uuid)
+	return args.Error(0)
+}
+
+func (m *MockMessageRepository) UpdateContent(ctx context.Context, messageUUID string, content string) error {
+	args := m.Called(ctx, messageUUID, content)
+	return args.Error(0)
+}
+
+type MockChatMergeEventRepository struct {
+	mock.Mock
+}
+
+func (m *MockChatMergeEventRepository) Create(ctx context.Context, event *model.ChatMergeEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockChatMergeEventRepository) FindByChatUUID(ctx context.Context, chatUUID string) ([]*model.ChatMergeEvent, error) {
+	args := m.Called(ctx, chatUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.ChatMergeEvent), args.Error(1)
+}
+
+func (m *MockChatMergeEventRepository) FindByReportMessageUUID(ctx context.Context, reportMessageUUID string) ([]*model.ChatMergeEvent, error) {
+	args := m.Called(ctx, reportMessageUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.ChatMergeEvent), args.Error(1)
+}
+
+func (m *MockChatMergeEventRepository) FindLatestByChatUUID(ctx context.Context, chatUUID string, action string) (*model.ChatMergeEvent, error) {
+	args := m.Called(ctx, chatUUID, action)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ChatMergeEvent), args.Error(1)
+}
+
+type MockBranchComparisonRepository struct {
+	mock.Mock
+}
+
+func (m *MockBranchComparisonRepository) Create(ctx context.Context, comparison *model.BranchComparison) error {
+	args := m.Called(ctx, comparison)
+	return args.Error(0)
+}
+
+func (m *MockBranchComparisonRepository) FindByAnchorChatUUID(ctx context.Context, anchorChatUUID string) ([]*model.BranchComparison, error) {
+	args := m.Called(ctx, anchorChatUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.BranchComparison), args.Error(1)
+}
+
+type MockForkSuggestionRepository struct {
+	mock.Mock
+}
+
+func (m *MockForkSuggestionRepository) ReplaceByMessageUUID(ctx context.Context, messageUUID string, suggestions []*model.ForkSuggestion) error {
+	args := m.Called(ctx, messageUUID, suggestions)
+	return args.Error(0)
+}
+
+func (m *MockForkSuggestionRepository) FindByMessageUUID(ctx context.Context, messageUUID string) ([]*model.ForkSuggestion, error) {
+	args := m.Called(ctx, messageUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.ForkSuggestion), args.Error(1)
+}
+
 type MockGenAIClient struct {
 	mock.Mock
 }
@@ -134,6 +276,32 @@ func (m *MockMessageSelectionRepository) Create(ctx context.Context, selection *
 	return args.Error(0)
 }
 
+type MockPromptRenderer struct {
+	mock.Mock
+}
+
+func (m *MockPromptRenderer) Render(name string, language string, data any) (*model.RenderedPrompt, error) {
+	args := m.Called(name, language, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.RenderedPrompt), args.Error(1)
+}
+
+func (m *MockPromptRenderer) DefaultLanguage() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+// 言語解決とプロンプトのレンダリングの既定の振る舞いを設定する
+// テストケース側で先に設定された期待値が優先される
+func setupDefaultPrompt(projectRepo *mockProjectRepository, userRepo *mockUserRepository, promptRenderer *MockPromptRenderer) {
+	projectRepo.On("FindByUUID", mock.Anything, mock.Anything).Return(&model.Project{}, nil).Maybe()
+	userRepo.On("FindByUUID", mock.Anything, mock.Anything).Return(&model.User{}, nil).Maybe()
+	promptRenderer.On("DefaultLanguage").Return(model.LanguageJapanese).Maybe()
+	promptRenderer.On("Render", mock.Anything, mock.Anything, mock.Anything).Return(&model.RenderedPrompt{Text: "prompt", Version: "prompt/ja/v1"}, nil).Maybe()
+}
+
 type MockTransactionManager struct {
 	mock.Mock
 }
@@ -149,9 +317,13 @@ func TestChatUsecase_FirstStreamChat(t *testing.T) {
 		messageRepo          *MockMessageRepository
 		messageSelectionRepo *MockMessageSelectionRepository
 		edgeRepo             *mockEdgeRepository
+		chatMergeEventRepo   *MockChatMergeEventRepository
+		projectRepo          *mockProjectRepository
+		userRepo             *mockUserRepository
 		transactionManager   *MockTransactionManager
 		genaiClient          *MockGenAIClient
 		publisher            *MockPublisher
+		promptRenderer       *MockPromptRenderer
 	}
 	type args struct {
 		chatUUID string
@@ -169,7 +341,9 @@ func TestChatUsecase_FirstStreamChat(t *testing.T) {
 			},
 			setupMock: func(m *mocks) {
 				// 1. FindByID
-				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid"}, nil)
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open"}, nil)
+				m.chatRepo.On("AcquireGenerationLock", mock.Anything, "chat-uuid", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+				m.chatRepo.On("ReleaseGenerationLock", mock.Anything, "chat-uuid", mock.Anything).Return(nil)
 				// 2. FindMessagesByChatID
 				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return([]*model.Message{
 					{Content: "hello", Role: "user"},
@@ -188,12 +362,58 @@ func TestChatUsecase_FirstStreamChat(t *testing.T) {
 				m.genaiClient.On("GenerateContentStream", mock.Anything, "gemini-2.5-flash", mock.Anything, (*genai.GenerateContentConfig)(nil)).Return(mockIter)
 				// 4. Create (Assistant Message)
 				m.messageRepo.On("Create", mock.Anything, mock.MatchedBy(func(msg *model.Message) bool {
-					// PositionY should be equal to chat.PositionY (0)
-					return msg.Role == "assistant" && msg.Content == "world" && msg.ChatUUID == "chat-uuid" && msg.PositionY == 0
+					return msg.Role == "assistant" && msg.Content == "world" && msg.ChatUUID == "chat-uuid"
+				})).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常系: フォークしたチャットでは初期メッセージの下に回答を置きエッジで繋ぐこと",
+			args: args{
+				chatUUID: "chat-uuid",
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open"}, nil)
+				m.chatRepo.On("AcquireGenerationLock", mock.Anything, "chat-uuid", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+				m.chatRepo.On("ReleaseGenerationLock", mock.Anything, "chat-uuid", mock.Anything).Return(nil)
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return([]*model.Message{
+					{UUID: "initial-msg", Content: "Title\n\nSummary", Role: "assistant"},
+				}, nil)
+				mockIter := func(yield func(*genai.GenerateContentResponse, error) bool) {
+					yield(&genai.GenerateContentResponse{
+						Candidates: []*genai.Candidate{
+							{
+								Content: genai.Text("world")[0],
+							},
+						},
+					}, nil)
+				}
+				m.genaiClient.On("GenerateContentStream", mock.Anything, "gemini-2.5-flash", mock.Anything, (*genai.GenerateContentConfig)(nil)).Return(mockIter)
+				m.messageRepo.On("Create", mock.Anything, mock.MatchedBy(func(msg *model.Message) bool {
+					return msg.Role == "assistant"
+				})).Return(nil)
+				m.edgeRepo.On("Create", mock.Anything, mock.MatchedBy(func(edge *model.Edge) bool {
+					return edge.ChatUUID == "chat-uuid" && edge.TargetMessageUUID == "initial-msg"
 				})).Return(nil)
 			},
 			wantErr: false,
 		},
+		{
+			name: "異常系: 既に会話が始まっているチャットはエラー",
+			args: args{
+				chatUUID: "chat-uuid",
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open"}, nil)
+				m.chatRepo.On("AcquireGenerationLock", mock.Anything, "chat-uuid", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+				m.chatRepo.On("ReleaseGenerationLock", mock.Anything, "chat-uuid", mock.Anything).Return(nil)
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return([]*model.Message{
+					{Content: "hello", Role: "user"},
+					{Content: "world", Role: "assistant"},
+				}, nil)
+			},
+			wantErr: true,
+		},
 		{
 			name: "異常系: チャットが存在しない場合エラー",
 			args: args{
@@ -210,7 +430,9 @@ func TestChatUsecase_FirstStreamChat(t *testing.T) {
 				chatUUID: "chat-uuid",
 			},
 			setupMock: func(m *mocks) {
-				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid"}, nil)
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open"}, nil)
+				m.chatRepo.On("AcquireGenerationLock", mock.Anything, "chat-uuid", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+				m.chatRepo.On("ReleaseGenerationLock", mock.Anything, "chat-uuid", mock.Anything).Return(nil)
 				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return([]*model.Message{}, nil)
 			},
 			wantErr: true,
@@ -221,7 +443,9 @@ func TestChatUsecase_FirstStreamChat(t *testing.T) {
 				chatUUID: "chat-uuid",
 			},
 			setupMock: func(m *mocks) {
-				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid"}, nil)
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open"}, nil)
+				m.chatRepo.On("AcquireGenerationLock", mock.Anything, "chat-uuid", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+				m.chatRepo.On("ReleaseGenerationLock", mock.Anything, "chat-uuid", mock.Anything).Return(nil)
 				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return(nil, errors.New("db error"))
 			},
 			wantErr: true,
@@ -232,7 +456,9 @@ func TestChatUsecase_FirstStreamChat(t *testing.T) {
 				chatUUID: "chat-uuid",
 			},
 			setupMock: func(m *mocks) {
-				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid"}, nil)
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open"}, nil)
+				m.chatRepo.On("AcquireGenerationLock", mock.Anything, "chat-uuid", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+				m.chatRepo.On("ReleaseGenerationLock", mock.Anything, "chat-uuid", mock.Anything).Return(nil)
 				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return([]*model.Message{
 					{Content: "hello", Role: "user"},
 				}, nil)
@@ -250,7 +476,9 @@ func TestChatUsecase_FirstStreamChat(t *testing.T) {
 				chatUUID: "chat-uuid",
 			},
 			setupMock: func(m *mocks) {
-				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid"}, nil)
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open"}, nil)
+				m.chatRepo.On("AcquireGenerationLock", mock.Anything, "chat-uuid", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+				m.chatRepo.On("ReleaseGenerationLock", mock.Anything, "chat-uuid", mock.Anything).Return(nil)
 				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return([]*model.Message{
 					{Content: "hello", Role: "user"},
 				}, nil)
@@ -281,13 +509,18 @@ func TestChatUsecase_FirstStreamChat(t *testing.T) {
 				messageRepo:          &MockMessageRepository{},
 				messageSelectionRepo: &MockMessageSelectionRepository{},
 				edgeRepo:             &mockEdgeRepository{},
+				chatMergeEventRepo:   &MockChatMergeEventRepository{},
+				projectRepo:          &mockProjectRepository{},
+				userRepo:             &mockUserRepository{},
 				transactionManager:   &MockTransactionManager{},
 				genaiClient:          &MockGenAIClient{},
 				publisher:            &MockPublisher{},
+				promptRenderer:       &MockPromptRenderer{},
 			}
 			tt.setupMock(m)
+			setupDefaultPrompt(m.projectRepo, m.userRepo, m.promptRenderer)
 
-			u := NewChatUsecase(m.chatRepo, m.messageRepo, m.messageSelectionRepo, m.edgeRepo, m.transactionManager, m.genaiClient, m.publisher)
+			u := NewChatUsecase(m.chatRepo, m.messageRepo, m.messageSelectionRepo, m.edgeRepo, m.chatMergeEventRepo, &MockBranchComparisonRepository{}, &MockForkSuggestionRepository{}, m.projectRepo, m.userRepo, m.transactionManager, m.genaiClient, m.publisher, m.promptRenderer, nil)
 
 			outputChan := make(chan string, 10)
 			err := u.FirstStreamChat(context.Background(), tt.args.chatUUID, outputChan)
@@ -315,9 +548,13 @@ func TestChatUsecase_GetChat(t *testing.T) {
 		messageRepo          *MockMessageRepository
 		messageSelectionRepo *MockMessageSelectionRepository
 		edgeRepo             *mockEdgeRepository
+		chatMergeEventRepo   *MockChatMergeEventRepository
+		projectRepo          *mockProjectRepository
+		userRepo             *mockUserRepository
 		transactionManager   *MockTransactionManager
 		genaiClient          *MockGenAIClient
 		publisher            *MockPublisher
+		promptRenderer       *MockPromptRenderer
 	}
 	type args struct {
 		chatUUID string
@@ -363,13 +600,18 @@ func TestChatUsecase_GetChat(t *testing.T) {
 				messageRepo:          &MockMessageRepository{},
 				messageSelectionRepo: &MockMessageSelectionRepository{},
 				edgeRepo:             &mockEdgeRepository{},
+				chatMergeEventRepo:   &MockChatMergeEventRepository{},
+				projectRepo:          &mockProjectRepository{},
+				userRepo:             &mockUserRepository{},
 				transactionManager:   &MockTransactionManager{},
 				genaiClient:          &MockGenAIClient{},
 				publisher:            &MockPublisher{},
+				promptRenderer:       &MockPromptRenderer{},
 			}
 			tt.setupMock(m)
+			setupDefaultPrompt(m.projectRepo, m.userRepo, m.promptRenderer)
 
-			u := NewChatUsecase(m.chatRepo, m.messageRepo, m.messageSelectionRepo, m.edgeRepo, m.transactionManager, m.genaiClient, m.publisher)
+			u := NewChatUsecase(m.chatRepo, m.messageRepo, m.messageSelectionRepo, m.edgeRepo, m.chatMergeEventRepo, &MockBranchComparisonRepository{}, &MockForkSuggestionRepository{}, m.projectRepo, m.userRepo, m.transactionManager, m.genaiClient, m.publisher, m.promptRenderer, nil)
 
 			got, err := u.GetChat(context.Background(), tt.args.chatUUID)
 			if (err != nil) != tt.wantErr {
@@ -387,9 +629,13 @@ func TestChatUsecase_GetMessages(t *testing.T) {
 		messageRepo          *MockMessageRepository
 		messageSelectionRepo *MockMessageSelectionRepository
 		edgeRepo             *mockEdgeRepository
+		chatMergeEventRepo   *MockChatMergeEventRepository
+		projectRepo          *mockProjectRepository
+		userRepo             *mockUserRepository
 		transactionManager   *MockTransactionManager
 		genaiClient          *MockGenAIClient
 		publisher            *MockPublisher
+		promptRenderer       *MockPromptRenderer
 	}
 	type args struct {
 		chatUUID string
@@ -503,13 +749,18 @@ func TestChatUsecase_GetMessages(t *testing.T) {
 				messageRepo:          &MockMessageRepository{},
 				messageSelectionRepo: &MockMessageSelectionRepository{},
 				edgeRepo:             &mockEdgeRepository{},
+				chatMergeEventRepo:   &MockChatMergeEventRepository{},
+				projectRepo:          &mockProjectRepository{},
+				userRepo:             &mockUserRepository{},
 				transactionManager:   &MockTransactionManager{},
 				genaiClient:          &MockGenAIClient{},
 				publisher:            &MockPublisher{},
+				promptRenderer:       &MockPromptRenderer{},
 			}
 			tt.setupMock(m)
+			setupDefaultPrompt(m.projectRepo, m.userRepo, m.promptRenderer)
 
-			u := NewChatUsecase(m.chatRepo, m.messageRepo, m.messageSelectionRepo, m.edgeRepo, m.transactionManager, m.genaiClient, m.publisher)
+			u := NewChatUsecase(m.chatRepo, m.messageRepo, m.messageSelectionRepo, m.edgeRepo, m.chatMergeEventRepo, &MockBranchComparisonRepository{}, &MockForkSuggestionRepository{}, m.projectRepo, m.userRepo, m.transactionManager, m.genaiClient, m.publisher, m.promptRenderer, nil)
 
 			got, err := u.GetMessages(context.Background(), tt.args.chatUUID)
 			if (err != nil) != tt.wantErr {
@@ -527,9 +778,13 @@ func TestChatUsecase_SendMessage(t *testing.T) {
 		messageRepo          *MockMessageRepository
 		messageSelectionRepo *MockMessageSelectionRepository
 		edgeRepo             *mockEdgeRepository
+		chatMergeEventRepo   *MockChatMergeEventRepository
+		projectRepo          *mockProjectRepository
+		userRepo             *mockUserRepository
 		transactionManager   *MockTransactionManager
 		genaiClient          *MockGenAIClient
 		publisher            *MockPublisher
+		promptRenderer       *MockPromptRenderer
 	}
 	type args struct {
 		chatUUID string
@@ -549,7 +804,7 @@ func TestChatUsecase_SendMessage(t *testing.T) {
 				content:  "hello",
 			},
 			setupMock: func(m *mocks) {
-				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid"}, nil)
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open"}, nil)
 				m.messageRepo.On("Create", mock.Anything, mock.MatchedBy(func(msg *model.Message) bool {
 					return msg.Role == "user" && msg.Content == "hello" && msg.ChatUUID == "chat-uuid"
 				})).Return(nil)
@@ -573,6 +828,31 @@ func TestChatUsecase_SendMessage(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "異常系: 回答の生成中の場合エラー",
+			args: args{
+				chatUUID: "chat-uuid",
+				content:  "hello",
+			},
+			setupMock: func(m *mocks) {
+				lockedUntil := time.Now().Add(time.Minute)
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open", GenerationLockedUntil: &lockedUntil}, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "異常系: クローズされたチャットにはメッセージを追加できないこと",
+			args: args{
+				chatUUID: "chat-uuid",
+				content:  "hello",
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "closed"}, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "異常系: メッセージ保存に失敗した場合エラー",
 			args: args{
@@ -580,7 +860,7 @@ func TestChatUsecase_SendMessage(t *testing.T) {
 				content:  "hello",
 			},
 			setupMock: func(m *mocks) {
-				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid"}, nil)
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open"}, nil)
 				m.messageRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("db error"))
 			},
 			want:    nil,
@@ -594,13 +874,18 @@ func TestChatUsecase_SendMessage(t *testing.T) {
 				messageRepo:          &MockMessageRepository{},
 				messageSelectionRepo: &MockMessageSelectionRepository{},
 				edgeRepo:             &mockEdgeRepository{},
+				chatMergeEventRepo:   &MockChatMergeEventRepository{},
+				projectRepo:          &mockProjectRepository{},
+				userRepo:             &mockUserRepository{},
 				transactionManager:   &MockTransactionManager{},
 				genaiClient:          &MockGenAIClient{},
 				publisher:            &MockPublisher{},
+				promptRenderer:       &MockPromptRenderer{},
 			}
 			tt.setupMock(m)
+			setupDefaultPrompt(m.projectRepo, m.userRepo, m.promptRenderer)
 
-			u := NewChatUsecase(m.chatRepo, m.messageRepo, m.messageSelectionRepo, m.edgeRepo, m.transactionManager, m.genaiClient, m.publisher)
+			u := NewChatUsecase(m.chatRepo, m.messageRepo, m.messageSelectionRepo, m.edgeRepo, m.chatMergeEventRepo, &MockBranchComparisonRepository{}, &MockForkSuggestionRepository{}, m.projectRepo, m.userRepo, m.transactionManager, m.genaiClient, m.publisher, m.promptRenderer, nil)
 
 			got, err := u.SendMessage(context.Background(), tt.args.chatUUID, tt.args.content)
 			if (err != nil) != tt.wantErr {
@@ -624,19 +909,53 @@ func TestChatUsecase_StreamMessage(t *testing.T) {
 		messageRepo          *MockMessageRepository
 		messageSelectionRepo *MockMessageSelectionRepository
 		edgeRepo             *mockEdgeRepository
+		chatMergeEventRepo   *MockChatMergeEventRepository
+		projectRepo          *mockProjectRepository
+		userRepo             *mockUserRepository
 		transactionManager   *MockTransactionManager
 		genaiClient          *MockGenAIClient
 		publisher            *MockPublisher
+		promptRenderer       *MockPromptRenderer
 	}
 	type args struct {
 		chatUUID string
 	}
+	longAnswer := strings.Repeat("長い回答。", 200)
 	tests := []struct {
 		name      string
 		args      args
 		setupMock func(m *mocks)
 		wantErr   bool
 	}{
+		{
+			name: "正常系: 長い回答の場合はフォーク候補の抽出タスクを登録すること",
+			args: args{
+				chatUUID: "chat-uuid",
+			},
+			setupMock: func(m *mocks) {
+				m.messageRepo.On("FindLatestMessageWithSummary", mock.Anything, "chat-uuid").Return(nil, nil)
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return([]*model.Message{
+					{UUID: "user-1", Content: "hello", Role: "user"},
+				}, nil)
+				mockIter := func(yield func(*genai.GenerateContentResponse, error) bool) {
+					yield(&genai.GenerateContentResponse{
+						Candidates: []*genai.Candidate{
+							{
+								Content: genai.Text(longAnswer)[0],
+							},
+						},
+					}, nil)
+				}
+				m.genaiClient.On("GenerateContentStream", mock.Anything, "gemini-2.5-flash", mock.Anything, (*genai.GenerateContentConfig)(nil)).Return(mockIter)
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open"}, nil)
+				m.chatRepo.On("AcquireGenerationLock", mock.Anything, "chat-uuid", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+				m.chatRepo.On("ReleaseGenerationLock", mock.Anything, "chat-uuid", mock.Anything).Return(nil)
+				m.messageRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				m.publisher.On("Publish", "chat_summary", mock.Anything).Return(nil)
+				m.publisher.On("Publish", "fork_suggestion", mock.Anything).Return(nil).Once()
+			},
+			wantErr: false,
+		},
 		{
 			name: "正常系: ストリームメッセージが成功すること（サマリなし）",
 			args: args{
@@ -661,17 +980,139 @@ func TestChatUsecase_StreamMessage(t *testing.T) {
 				}
 				m.genaiClient.On("GenerateContentStream", mock.Anything, "gemini-2.5-flash", mock.Anything, (*genai.GenerateContentConfig)(nil)).Return(mockIter)
 				// 4. FindByID (for position)
-				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", PositionX: 0, PositionY: 0}, nil)
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open"}, nil)
+				m.chatRepo.On("AcquireGenerationLock", mock.Anything, "chat-uuid", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+				m.chatRepo.On("ReleaseGenerationLock", mock.Anything, "chat-uuid", mock.Anything).Return(nil)
 				// 5. Create (Assistant Message)
 				m.messageRepo.On("Create", mock.Anything, mock.MatchedBy(func(msg *model.Message) bool {
-					// assistantCount is 0, so PositionY = 0
-					return msg.Role == "assistant" && msg.Content == "world" && msg.ChatUUID == "chat-uuid" && msg.PositionY == 0
+					return msg.Role == "assistant" && msg.Content == "world" && msg.ChatUUID == "chat-uuid"
 				})).Return(nil)
 				// 6. PublishTask
 				m.publisher.On("Publish", "chat_summary", mock.Anything).Return(nil)
 			},
 			wantErr: false,
 		},
+		{
+			name: "正常系: 生成中に追加されたメッセージを反映してエッジが計算されること",
+			args: args{
+				chatUUID: "chat-uuid",
+			},
+			setupMock: func(m *mocks) {
+				m.messageRepo.On("FindLatestMessageWithSummary", mock.Anything, "chat-uuid").Return(nil, nil)
+				// 生成開始時点の履歴
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return([]*model.Message{
+					{UUID: "user-1", Content: "hello", Role: "user"},
+				}, nil).Once()
+				// 保存時点の履歴 (ロックの期限切れ後に別の回答が保存された)
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return([]*model.Message{
+					{UUID: "user-1", Content: "hello", Role: "user"},
+					{UUID: "assistant-1", Content: "hi", Role: "assistant"},
+				}, nil).Once()
+				mockIter := func(yield func(*genai.GenerateContentResponse, error) bool) {
+					yield(&genai.GenerateContentResponse{
+						Candidates: []*genai.Candidate{
+							{
+								Content: genai.Text("world")[0],
+							},
+						},
+					}, nil)
+				}
+				m.genaiClient.On("GenerateContentStream", mock.Anything, "gemini-2.5-flash", mock.Anything, (*genai.GenerateContentConfig)(nil)).Return(mockIter)
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open"}, nil)
+				m.chatRepo.On("AcquireGenerationLock", mock.Anything, "chat-uuid", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+				m.chatRepo.On("ReleaseGenerationLock", mock.Anything, "chat-uuid", mock.Anything).Return(nil)
+				m.messageRepo.On("Create", mock.Anything, mock.MatchedBy(func(msg *model.Message) bool {
+					return msg.Role == "assistant"
+				})).Return(nil)
+				m.edgeRepo.On("Create", mock.Anything, mock.MatchedBy(func(edge *model.Edge) bool {
+					return edge.TargetMessageUUID == "assistant-1"
+				})).Return(nil)
+				m.publisher.On("Publish", "chat_summary", mock.Anything).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常系: マージレポートがブランチの結論として枠付けされること",
+			args: args{
+				chatUUID: "chat-uuid",
+			},
+			setupMock: func(m *mocks) {
+				anchor := "assistant-1"
+				m.messageRepo.On("FindLatestMessageWithSummary", mock.Anything, "chat-uuid").Return(nil, nil)
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return([]*model.Message{
+					{UUID: "user-1", Content: "hello", Role: "user"},
+					{UUID: "assistant-1", Content: "hi", Role: "assistant"},
+					{UUID: "report-1", Content: "conclusion", Role: "merge_report", ParentMessageUUID: &anchor},
+				}, nil)
+				// 最後がマージレポートなので、結論を統合する回答を求める
+				m.promptRenderer.On("Render", model.PromptMergeReportContext, mock.Anything, mock.MatchedBy(func(data map[string]string) bool {
+					return data["Report"] == "conclusion" && data["Anchor"] == "hi" && data["Acknowledge"] == "true"
+				})).Return(&model.RenderedPrompt{Text: "framed report"}, nil)
+				mockIter := func(yield func(*genai.GenerateContentResponse, error) bool) {
+					yield(&genai.GenerateContentResponse{
+						Candidates: []*genai.Candidate{{Content: genai.Text("world")[0]}},
+					}, nil)
+				}
+				m.genaiClient.On("GenerateContentStream", mock.Anything, "gemini-2.5-flash", mock.MatchedBy(func(parts []*genai.Content) bool {
+					last := parts[len(parts)-1]
+					return len(parts) == 3 && last.Role == "user" && last.Parts[0].Text == "framed report"
+				}), (*genai.GenerateContentConfig)(nil)).Return(mockIter)
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open"}, nil)
+				m.chatRepo.On("AcquireGenerationLock", mock.Anything, "chat-uuid", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+				m.chatRepo.On("ReleaseGenerationLock", mock.Anything, "chat-uuid", mock.Anything).Return(nil)
+				m.messageRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				m.edgeRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				m.publisher.On("Publish", "chat_summary", mock.Anything).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常系: 別のブランチから取り込んだメッセージが参照として枠付けされること",
+			args: args{
+				chatUUID: "chat-uuid",
+			},
+			setupMock: func(m *mocks) {
+				origin := "other-assistant"
+				originChat := "other-chat"
+				m.messageRepo.On("FindLatestMessageWithSummary", mock.Anything, "chat-uuid").Return(nil, nil)
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return([]*model.Message{
+					{UUID: "user-1", Content: "hello", Role: "user"},
+					{UUID: "assistant-1", Content: "hi", Role: "assistant"},
+					{UUID: "picked-1", Content: "good answer", Role: "assistant", OriginMessageUUID: &origin, OriginChatUUID: &originChat},
+					{UUID: "user-2", Content: "compare them", Role: "user"},
+				}, nil)
+				m.promptRenderer.On("Render", model.PromptCherryPickContext, mock.Anything, map[string]string{
+					"Role":    "assistant",
+					"Content": "good answer",
+				}).Return(&model.RenderedPrompt{Text: "framed answer"}, nil)
+				mockIter := func(yield func(*genai.GenerateContentResponse, error) bool) {
+					yield(&genai.GenerateContentResponse{
+						Candidates: []*genai.Candidate{{Content: genai.Text("world")[0]}},
+					}, nil)
+				}
+				m.genaiClient.On("GenerateContentStream", mock.Anything, "gemini-2.5-flash", mock.MatchedBy(func(parts []*genai.Content) bool {
+					return len(parts) == 4 && parts[2].Role == "user" && parts[2].Parts[0].Text == "framed answer" && parts[3].Parts[0].Text == "compare them"
+				}), (*genai.GenerateContentConfig)(nil)).Return(mockIter)
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open"}, nil)
+				m.chatRepo.On("AcquireGenerationLock", mock.Anything, "chat-uuid", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+				m.chatRepo.On("ReleaseGenerationLock", mock.Anything, "chat-uuid", mock.Anything).Return(nil)
+				m.messageRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				m.edgeRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				m.publisher.On("Publish", "chat_summary", mock.Anything).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常系: 他のメンバーが回答を生成中の場合エラー",
+			args: args{
+				chatUUID: "chat-uuid",
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open"}, nil)
+				m.chatRepo.On("AcquireGenerationLock", mock.Anything, "chat-uuid", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+			},
+			wantErr: true,
+		},
 		{
 			name: "正常系: ストリームメッセージが成功すること（サマリあり）",
 			args: args{
@@ -701,11 +1142,12 @@ func TestChatUsecase_StreamMessage(t *testing.T) {
 				}
 				m.genaiClient.On("GenerateContentStream", mock.Anything, "gemini-2.5-flash", mock.Anything, (*genai.GenerateContentConfig)(nil)).Return(mockIter)
 				// 4. FindByID (for position)
-				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", PositionX: 0, PositionY: 0}, nil)
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open"}, nil)
+				m.chatRepo.On("AcquireGenerationLock", mock.Anything, "chat-uuid", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+				m.chatRepo.On("ReleaseGenerationLock", mock.Anything, "chat-uuid", mock.Anything).Return(nil)
 				// 5. Create (Assistant Message)
 				m.messageRepo.On("Create", mock.Anything, mock.MatchedBy(func(msg *model.Message) bool {
-					// assistantCount is 0, so PositionY = 0
-					return msg.Role == "assistant" && msg.Content == "response" && msg.PositionY == 0
+					return msg.Role == "assistant" && msg.Content == "response"
 				})).Return(nil)
 				// 6. PublishTask
 				m.publisher.On("Publish", "chat_summary", mock.Anything).Return(nil)
@@ -718,6 +1160,9 @@ func TestChatUsecase_StreamMessage(t *testing.T) {
 				chatUUID: "chat-uuid",
 			},
 			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open"}, nil)
+				m.chatRepo.On("AcquireGenerationLock", mock.Anything, "chat-uuid", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+				m.chatRepo.On("ReleaseGenerationLock", mock.Anything, "chat-uuid", mock.Anything).Return(nil)
 				m.messageRepo.On("FindLatestMessageWithSummary", mock.Anything, "chat-uuid").Return(nil, errors.New("db error"))
 			},
 			wantErr: true,
@@ -728,6 +1173,9 @@ func TestChatUsecase_StreamMessage(t *testing.T) {
 				chatUUID: "chat-uuid",
 			},
 			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open"}, nil)
+				m.chatRepo.On("AcquireGenerationLock", mock.Anything, "chat-uuid", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+				m.chatRepo.On("ReleaseGenerationLock", mock.Anything, "chat-uuid", mock.Anything).Return(nil)
 				m.messageRepo.On("FindLatestMessageWithSummary", mock.Anything, "chat-uuid").Return(nil, nil)
 				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return(nil, errors.New("db error"))
 			},
@@ -739,6 +1187,9 @@ func TestChatUsecase_StreamMessage(t *testing.T) {
 				chatUUID: "chat-uuid",
 			},
 			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open"}, nil)
+				m.chatRepo.On("AcquireGenerationLock", mock.Anything, "chat-uuid", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+				m.chatRepo.On("ReleaseGenerationLock", mock.Anything, "chat-uuid", mock.Anything).Return(nil)
 				m.messageRepo.On("FindLatestMessageWithSummary", mock.Anything, "chat-uuid").Return(nil, nil)
 				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return([]*model.Message{}, nil)
 			},
@@ -750,6 +1201,9 @@ func TestChatUsecase_StreamMessage(t *testing.T) {
 				chatUUID: "chat-uuid",
 			},
 			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open"}, nil)
+				m.chatRepo.On("AcquireGenerationLock", mock.Anything, "chat-uuid", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+				m.chatRepo.On("ReleaseGenerationLock", mock.Anything, "chat-uuid", mock.Anything).Return(nil)
 				m.messageRepo.On("FindLatestMessageWithSummary", mock.Anything, "chat-uuid").Return(nil, nil)
 				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return([]*model.Message{
 					{Content: "hello", Role: "user"},
@@ -784,7 +1238,9 @@ func TestChatUsecase_StreamMessage(t *testing.T) {
 				}
 				m.genaiClient.On("GenerateContentStream", mock.Anything, "gemini-2.5-flash", mock.Anything, (*genai.GenerateContentConfig)(nil)).Return(mockIter)
 				// FindByID (for position)
-				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", PositionX: 0, PositionY: 0}, nil)
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open"}, nil)
+				m.chatRepo.On("AcquireGenerationLock", mock.Anything, "chat-uuid", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+				m.chatRepo.On("ReleaseGenerationLock", mock.Anything, "chat-uuid", mock.Anything).Return(nil)
 
 				m.messageRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("db error"))
 			},
@@ -799,13 +1255,18 @@ func TestChatUsecase_StreamMessage(t *testing.T) {
 				messageRepo:          &MockMessageRepository{},
 				messageSelectionRepo: &MockMessageSelectionRepository{},
 				edgeRepo:             &mockEdgeRepository{},
+				chatMergeEventRepo:   &MockChatMergeEventRepository{},
+				projectRepo:          &mockProjectRepository{},
+				userRepo:             &mockUserRepository{},
 				transactionManager:   &MockTransactionManager{},
 				genaiClient:          &MockGenAIClient{},
 				publisher:            &MockPublisher{},
+				promptRenderer:       &MockPromptRenderer{},
 			}
 			tt.setupMock(m)
+			setupDefaultPrompt(m.projectRepo, m.userRepo, m.promptRenderer)
 
-			u := NewChatUsecase(m.chatRepo, m.messageRepo, m.messageSelectionRepo, m.edgeRepo, m.transactionManager, m.genaiClient, m.publisher)
+			u := NewChatUsecase(m.chatRepo, m.messageRepo, m.messageSelectionRepo, m.edgeRepo, m.chatMergeEventRepo, &MockBranchComparisonRepository{}, &MockForkSuggestionRepository{}, m.projectRepo, m.userRepo, m.transactionManager, m.genaiClient, m.publisher, m.promptRenderer, nil)
 
 			outputChan := make(chan string, 10)
 			err := u.StreamMessage(context.Background(), tt.args.chatUUID, outputChan)
@@ -822,6 +1283,9 @@ func TestChatUsecase_StreamMessage(t *testing.T) {
 				}
 				if tt.name == "正常系: ストリームメッセージが成功すること（サマリあり）" {
 					assert.Equal(t, "response", output)
+				} else if tt.name == "正常系: 長い回答の場合はフォーク候補の抽出タスクを登録すること" {
+					assert.Equal(t, longAnswer, output)
+					m.publisher.AssertExpectations(t)
 				} else {
 					assert.Equal(t, "world", output)
 				}
@@ -830,15 +1294,205 @@ func TestChatUsecase_StreamMessage(t *testing.T) {
 	}
 }
 
-func TestChatUsecase_GenerateForkPreview(t *testing.T) {
+func TestChatUsecase_CherryPickMessages(t *testing.T) {
 	type mocks struct {
-		chatRepo             *MockChatRepository
-		messageRepo          *MockMessageRepository
+		chatRepo           *MockChatRepository
+		messageRepo        *MockMessageRepository
+		edgeRepo           *mockEdgeRepository
+		projectRepo        *mockProjectRepository
+		userRepo           *mockUserRepository
+		transactionManager *MockTransactionManager
+		publisher          *MockPublisher
+		promptRenderer     *MockPromptRenderer
+	}
+	base := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	targetChat := &model.Chat{UUID: "target-chat", ProjectUUID: "project-1", Status: "open"}
+	setupSources := func(m *mocks) {
+		m.chatRepo.On("FindByID", mock.Anything, "sibling-chat").Return(&model.Chat{UUID: "sibling-chat", ProjectUUID: "project-1"}, nil)
+		m.messageRepo.On("FindByID", mock.Anything, "sibling-user").Return(&model.Message{UUID: "sibling-user", ChatUUID: "sibling-chat", Role: "user", Content: "question", CreatedAt: base}, nil)
+		m.messageRepo.On("FindByID", mock.Anything, "sibling-answer").Return(&model.Message{UUID: "sibling-answer", ChatUUID: "sibling-chat", Role: "assistant", Content: "good answer", CreatedAt: base.Add(time.Minute)}, nil)
+	}
+	setupTx := func(m *mocks) {
+		m.transactionManager.On("Do", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			fn(context.Background())
+		})
+	}
+	tests := []struct {
+		name      string
+		chat      *model.Chat
+		params    model.CherryPickParams
+		setupMock func(m *mocks)
+		wantErrIs error
+		wantErr   bool
+		assertion func(t *testing.T, got *model.CherryPickResult)
+	}{
+		{
+			name:   "正常系: 取り込み元の順序で末尾に取り込み、直前のAIの回答とだけエッジで繋ぐこと",
+			chat:   targetChat,
+			params: model.CherryPickParams{MessageUUIDs: []string{"sibling-answer", "sibling-user", "sibling-answer"}},
+			setupMock: func(m *mocks) {
+				setupSources(m)
+				setupTx(m)
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "target-chat").Return([]*model.Message{
+					{UUID: "target-initial", Role: "assistant"},
+					{UUID: "target-user", Role: "user"},
+					{UUID: "target-answer", Role: "assistant"},
+				}, nil)
+				m.messageRepo.On("Create", mock.Anything, mock.MatchedBy(func(msg *model.Message) bool {
+					return msg.Role == "user" && *msg.OriginMessageUUID == "sibling-user" && *msg.OriginChatUUID == "sibling-chat"
+				})).Return(nil).Once()
+				m.messageRepo.On("Create", mock.Anything, mock.MatchedBy(func(msg *model.Message) bool {
+					return msg.Role == "assistant" && msg.Content == "good answer" && *msg.OriginMessageUUID == "sibling-answer"
+				})).Return(nil).Once()
+				// 取り込み元のチャットへのエッジがあるとツリーの親が取り込み元になるため、直前のAIの回答への繋がりだけを作る
+				m.edgeRepo.On("Create", mock.Anything, mock.MatchedBy(func(edge *model.Edge) bool {
+					return edge.ChatUUID == "target-chat" && edge.TargetMessageUUID == "target-answer"
+				})).Return(nil).Once()
+				m.publisher.On("Publish", "chat_summary", mock.Anything).Return(nil)
+			},
+			assertion: func(t *testing.T, got *model.CherryPickResult) {
+				assert.Equal(t, "target-chat", got.ChatUUID)
+				if assert.Len(t, got.Messages, 2) {
+					assert.Equal(t, "question", got.Messages[0].Content)
+					assert.Equal(t, "good answer", got.Messages[1].Content)
+					assert.True(t, got.Messages[0].CreatedAt.Before(got.Messages[1].CreatedAt))
+				}
+			},
+		},
+		{
+			name:   "正常系: 取り込み先にAIの回答がない場合はエッジを作らないこと",
+			chat:   targetChat,
+			params: model.CherryPickParams{MessageUUIDs: []string{"sibling-user", "sibling-answer"}},
+			setupMock: func(m *mocks) {
+				setupSources(m)
+				setupTx(m)
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "target-chat").Return([]*model.Message{
+					{UUID: "target-initial", Role: "user"},
+				}, nil)
+				m.messageRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Twice()
+				m.publisher.On("Publish", "chat_summary", mock.Anything).Return(nil)
+			},
+			assertion: func(t *testing.T, got *model.CherryPickResult) {
+				if assert.Len(t, got.Messages, 2) {
+					assert.Equal(t, "sibling-answer", *got.Messages[1].OriginMessageUUID)
+				}
+			},
+		},
+		{
+			name:   "異常系: 別のプロジェクトのメッセージは取り込めないこと",
+			chat:   targetChat,
+			params: model.CherryPickParams{MessageUUIDs: []string{"foreign-msg"}},
+			setupMock: func(m *mocks) {
+				m.messageRepo.On("FindByID", mock.Anything, "foreign-msg").Return(&model.Message{UUID: "foreign-msg", ChatUUID: "foreign-chat", Role: "assistant"}, nil)
+				m.chatRepo.On("FindByID", mock.Anything, "foreign-chat").Return(&model.Chat{UUID: "foreign-chat", ProjectUUID: "project-2"}, nil)
+			},
+			wantErrIs: model.ErrInvalidCherryPick,
+		},
+		{
+			name:   "異常系: マージレポートは取り込めないこと",
+			chat:   targetChat,
+			params: model.CherryPickParams{MessageUUIDs: []string{"report"}},
+			setupMock: func(m *mocks) {
+				m.messageRepo.On("FindByID", mock.Anything, "report").Return(&model.Message{UUID: "report", ChatUUID: "sibling-chat", Role: "merge_report"}, nil)
+			},
+			wantErrIs: model.ErrInvalidCherryPick,
+		},
+		{
+			name:   "異常系: 取り込み先のチャットのメッセージは取り込めないこと",
+			chat:   targetChat,
+			params: model.CherryPickParams{MessageUUIDs: []string{"own"}},
+			setupMock: func(m *mocks) {
+				m.messageRepo.On("FindByID", mock.Anything, "own").Return(&model.Message{UUID: "own", ChatUUID: "target-chat", Role: "assistant"}, nil)
+			},
+			wantErrIs: model.ErrInvalidCherryPick,
+		},
+		{
+			name:   "異常系: 存在しないメッセージは取り込めないこと",
+			chat:   targetChat,
+			params: model.CherryPickParams{MessageUUIDs: []string{"missing"}},
+			setupMock: func(m *mocks) {
+				m.messageRepo.On("FindByID", mock.Anything, "missing").Return(nil, nil)
+			},
+			wantErrIs: model.ErrInvalidCherryPick,
+		},
+		{
+			name:      "異常系: 取り込むメッセージが指定されていない場合",
+			chat:      targetChat,
+			params:    model.CherryPickParams{},
+			setupMock: func(m *mocks) {},
+			wantErrIs: model.ErrInvalidCherryPick,
+		},
+		{
+			name:      "異常系: open 以外のチャットには取り込めないこと",
+			chat:      &model.Chat{UUID: "target-chat", ProjectUUID: "project-1", Status: "closed"},
+			params:    model.CherryPickParams{MessageUUIDs: []string{"sibling-answer"}},
+			setupMock: func(m *mocks) {},
+			wantErrIs: model.ErrChatNotOpen,
+		},
+		{
+			name:   "異常系: トランザクションエラー",
+			chat:   targetChat,
+			params: model.CherryPickParams{MessageUUIDs: []string{"sibling-answer"}},
+			setupMock: func(m *mocks) {
+				setupSources(m)
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "target-chat").Return([]*model.Message{}, nil)
+				m.transactionManager.On("Do", mock.Anything, mock.Anything).Return(errors.New("tx error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mocks{
+				chatRepo:           &MockChatRepository{},
+				messageRepo:        &MockMessageRepository{},
+				edgeRepo:           &mockEdgeRepository{},
+				projectRepo:        &mockProjectRepository{},
+				userRepo:           &mockUserRepository{},
+				transactionManager: &MockTransactionManager{},
+				publisher:          &MockPublisher{},
+				promptRenderer:     &MockPromptRenderer{},
+			}
+			m.chatRepo.On("FindByID", mock.Anything, "target-chat").Return(tt.chat, nil)
+			tt.setupMock(m)
+			setupDefaultPrompt(m.projectRepo, m.userRepo, m.promptRenderer)
+
+			u := NewChatUsecase(m.chatRepo, m.messageRepo, &MockMessageSelectionRepository{}, m.edgeRepo, &MockChatMergeEventRepository{}, &MockBranchComparisonRepository{}, &MockForkSuggestionRepository{}, m.projectRepo, m.userRepo, m.transactionManager, &MockGenAIClient{}, m.publisher, m.promptRenderer, nil)
+
+			got, err := u.CherryPickMessages(context.Background(), "target-chat", tt.params)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			tt.assertion(t, got)
+			m.messageRepo.AssertExpectations(t)
+			m.edgeRepo.AssertExpectations(t)
+			m.edgeRepo.AssertNotCalled(t, "Create", mock.Anything, mock.MatchedBy(func(edge *model.Edge) bool {
+				return strings.HasPrefix(edge.TargetMessageUUID, "sibling-")
+			}))
+		})
+	}
+}
+
+func TestChatUsecase_GenerateForkPreview(t *testing.T) {
+	type mocks struct {
+		chatRepo             *MockChatRepository
+		messageRepo          *MockMessageRepository
 		messageSelectionRepo *MockMessageSelectionRepository
 		edgeRepo             *mockEdgeRepository
+		chatMergeEventRepo   *MockChatMergeEventRepository
+		projectRepo          *mockProjectRepository
+		userRepo             *mockUserRepository
 		transactionManager   *MockTransactionManager
 		genaiClient          *MockGenAIClient
 		publisher            *MockPublisher
+		promptRenderer       *MockPromptRenderer
 	}
 	type args struct {
 		chatUUID string
@@ -863,10 +1517,11 @@ func TestChatUsecase_GenerateForkPreview(t *testing.T) {
 				},
 			},
 			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open", ProjectUUID: "project-uuid"}, nil)
 				// 1. FindMessagesByChatID
 				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return([]*model.Message{
 					{UUID: "msg-1", Content: "hello", Role: "user"},
-					{UUID: "msg-2", Content: "world", Role: "assistant"},
+					{UUID: "msg-2", Content: "selected world", Role: "assistant"},
 					{UUID: "msg-3", Content: "ignored", Role: "user"},
 				}, nil)
 
@@ -886,9 +1541,70 @@ func TestChatUsecase_GenerateForkPreview(t *testing.T) {
 			want: &model.ForkPreviewResponse{
 				SuggestedTitle:   "New Title",
 				GeneratedContext: "New Context",
+				PromptVersion:    "prompt/ja/v1",
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常系: バイト単位の範囲を文字単位に正規化してプロンプトに渡すこと",
+			args: args{
+				chatUUID: "chat-uuid",
+				req: model.ForkPreviewRequest{
+					TargetMessageUUID: "msg-1",
+					SelectedText:      "深掘り",
+					RangeStart:        12,
+					RangeEnd:          21,
+				},
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open", ProjectUUID: "project-uuid"}, nil)
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return([]*model.Message{
+					{UUID: "msg-1", Content: "日本語の深掘りをする", Role: "assistant"},
+				}, nil)
+				m.promptRenderer.On("Render", model.PromptForkPreview, mock.Anything, map[string]any{
+					"SelectedText": "深掘り",
+					"RangeStart":   4,
+					"RangeEnd":     7,
+				}).Return(&model.RenderedPrompt{Text: "prompt", Version: "prompt/ja/v1"}, nil).Once()
+				m.genaiClient.On("GenerateContent", mock.Anything, "gemini-2.5-flash", mock.Anything, mock.Anything).Return(&genai.GenerateContentResponse{
+					Candidates: []*genai.Candidate{
+						{
+							Content: &genai.Content{
+								Parts: []*genai.Part{
+									{Text: `{"suggested_title": "New Title", "generated_context": "New Context"}`},
+								},
+							},
+						},
+					},
+				}, nil)
+			},
+			want: &model.ForkPreviewResponse{
+				SuggestedTitle:   "New Title",
+				GeneratedContext: "New Context",
+				PromptVersion:    "prompt/ja/v1",
 			},
 			wantErr: false,
 		},
+		{
+			name: "異常系: 選択した文章がメッセージにない場合はプレビューを生成しない",
+			args: args{
+				chatUUID: "chat-uuid",
+				req: model.ForkPreviewRequest{
+					TargetMessageUUID: "msg-1",
+					SelectedText:      "missing",
+					RangeStart:        0,
+					RangeEnd:          7,
+				},
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open", ProjectUUID: "project-uuid"}, nil)
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return([]*model.Message{
+					{UUID: "msg-1", Content: "hello", Role: "user"},
+				}, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "異常系: メッセージ履歴取得失敗",
 			args: args{
@@ -898,6 +1614,7 @@ func TestChatUsecase_GenerateForkPreview(t *testing.T) {
 				},
 			},
 			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open", ProjectUUID: "project-uuid"}, nil)
 				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return(nil, errors.New("db error"))
 			},
 			want:    nil,
@@ -912,6 +1629,7 @@ func TestChatUsecase_GenerateForkPreview(t *testing.T) {
 				},
 			},
 			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open", ProjectUUID: "project-uuid"}, nil)
 				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return([]*model.Message{
 					{UUID: "msg-1", Content: "hello", Role: "user"},
 				}, nil)
@@ -928,6 +1646,7 @@ func TestChatUsecase_GenerateForkPreview(t *testing.T) {
 				},
 			},
 			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open", ProjectUUID: "project-uuid"}, nil)
 				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return([]*model.Message{
 					{UUID: "msg-1", Content: "hello", Role: "user"},
 				}, nil)
@@ -946,6 +1665,7 @@ func TestChatUsecase_GenerateForkPreview(t *testing.T) {
 				},
 			},
 			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open", ProjectUUID: "project-uuid"}, nil)
 				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return([]*model.Message{
 					{UUID: "msg-1", Content: "hello", Role: "user"},
 				}, nil)
@@ -974,13 +1694,18 @@ func TestChatUsecase_GenerateForkPreview(t *testing.T) {
 				messageRepo:          &MockMessageRepository{},
 				messageSelectionRepo: &MockMessageSelectionRepository{},
 				edgeRepo:             &mockEdgeRepository{},
+				chatMergeEventRepo:   &MockChatMergeEventRepository{},
+				projectRepo:          &mockProjectRepository{},
+				userRepo:             &mockUserRepository{},
 				transactionManager:   &MockTransactionManager{},
 				genaiClient:          &MockGenAIClient{},
 				publisher:            &MockPublisher{},
+				promptRenderer:       &MockPromptRenderer{},
 			}
 			tt.setupMock(m)
+			setupDefaultPrompt(m.projectRepo, m.userRepo, m.promptRenderer)
 
-			u := NewChatUsecase(m.chatRepo, m.messageRepo, m.messageSelectionRepo, m.edgeRepo, m.transactionManager, m.genaiClient, m.publisher)
+			u := NewChatUsecase(m.chatRepo, m.messageRepo, m.messageSelectionRepo, m.edgeRepo, m.chatMergeEventRepo, &MockBranchComparisonRepository{}, &MockForkSuggestionRepository{}, m.projectRepo, m.userRepo, m.transactionManager, m.genaiClient, m.publisher, m.promptRenderer, nil)
 
 			got, err := u.GenerateForkPreview(context.Background(), tt.args.chatUUID, tt.args.req)
 			if (err != nil) != tt.wantErr {
@@ -988,6 +1713,323 @@ func TestChatUsecase_GenerateForkPreview(t *testing.T) {
 				return
 			}
 			assert.Equal(t, tt.want, got)
+			m.promptRenderer.AssertExpectations(t)
+		})
+	}
+}
+
+func TestChatUsecase_SuggestForks(t *testing.T) {
+	type mocks struct {
+		chatRepo           *MockChatRepository
+		messageRepo        *MockMessageRepository
+		forkSuggestionRepo *MockForkSuggestionRepository
+		projectRepo        *mockProjectRepository
+		userRepo           *mockUserRepository
+		transactionManager *MockTransactionManager
+		genaiClient        *MockGenAIClient
+		promptRenderer     *MockPromptRenderer
+	}
+	answer := "まず性能について説明します。次にセキュリティについて。最後に運用について。"
+	messages := []*model.Message{
+		{UUID: "msg-1", Role: "user", Content: "設計の観点を教えて"},
+		{UUID: "msg-2", Role: "assistant", Content: answer},
+	}
+	generated := func(text string) *genai.GenerateContentResponse {
+		return &genai.GenerateContentResponse{
+			Candidates: []*genai.Candidate{
+				{Content: &genai.Content{Parts: []*genai.Part{{Text: text}}}},
+			},
+		}
+	}
+	tests := []struct {
+		name        string
+		messageUUID string
+		setupMock   func(m *mocks)
+		wantErrIs   error
+		wantErr     bool
+		assertion   func(t *testing.T, got []*model.ForkSuggestion)
+	}{
+		{
+			name:        "正常系: 本文と照合し、位置を補正・重複や不一致を除外して保存すること",
+			messageUUID: "msg-2",
+			setupMock: func(m *mocks) {
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return(messages, nil)
+				m.genaiClient.On("GenerateContent", mock.Anything, "gemini-2.5-flash", mock.Anything, mock.Anything).Return(generated(`{"suggestions": [
+					{"selected_text": "性能について", "range_start": 2, "range_end": 8, "suggested_title": "性能", "generated_context": "性能を深掘り"},
+					{"selected_text": "セキュリティについて", "range_start": 10, "range_end": 20, "suggested_title": "セキュリティ", "generated_context": "セキュリティを深掘り"},
+					{"selected_text": "存在しない話題", "range_start": 0, "range_end": 7, "suggested_title": "存在しない", "generated_context": ""},
+					{"selected_text": "について説明", "range_start": 4, "range_end": 10, "suggested_title": "重複", "generated_context": ""},
+					{"selected_text": "運用について", "range_start": 90, "range_end": 108, "suggested_title": "運用", "generated_context": "運用を深掘り"}
+				]}`), nil)
+				m.transactionManager.On("Do", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(context.Context) error)
+					fn(context.Background())
+				})
+				m.forkSuggestionRepo.On("ReplaceByMessageUUID", mock.Anything, "msg-2", mock.MatchedBy(func(suggestions []*model.ForkSuggestion) bool {
+					return len(suggestions) == 3
+				})).Return(nil)
+			},
+			assertion: func(t *testing.T, got []*model.ForkSuggestion) {
+				if !assert.Len(t, got, 3) {
+					return
+				}
+				assert.Equal(t, "性能", got[0].SuggestedTitle)
+				assert.Equal(t, []int{2, 8}, []int{got[0].RangeStart, got[0].RangeEnd})
+				assert.Equal(t, "セキュリティ", got[1].SuggestedTitle)
+				assert.Equal(t, []int{16, 26}, []int{got[1].RangeStart, got[1].RangeEnd})
+				assert.Equal(t, "運用", got[2].SuggestedTitle)
+				assert.Equal(t, []int{30, 36}, []int{got[2].RangeStart, got[2].RangeEnd})
+				for _, s := range got {
+					assert.Equal(t, string([]rune(answer)[s.RangeStart:s.RangeEnd]), s.SelectedText)
+					assert.Equal(t, "msg-2", s.PreviewRequest().TargetMessageUUID)
+					assert.Equal(t, "prompt/ja/v1", s.PromptVersion)
+				}
+			},
+		},
+		{
+			name:        "異常系: 別のチャットのメッセージの場合",
+			messageUUID: "other-msg",
+			setupMock: func(m *mocks) {
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return(messages, nil)
+			},
+			wantErrIs: model.ErrInvalidForkSuggestionTarget,
+		},
+		{
+			name:        "異常系: AIの回答以外のメッセージの場合",
+			messageUUID: "msg-1",
+			setupMock: func(m *mocks) {
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return(messages, nil)
+			},
+			wantErrIs: model.ErrInvalidForkSuggestionTarget,
+		},
+		{
+			name:        "異常系: GenAIがエラーを返した場合",
+			messageUUID: "msg-2",
+			setupMock: func(m *mocks) {
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return(messages, nil)
+				m.genaiClient.On("GenerateContent", mock.Anything, "gemini-2.5-flash", mock.Anything, mock.Anything).Return(nil, errors.New("genai error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mocks{
+				chatRepo:           &MockChatRepository{},
+				messageRepo:        &MockMessageRepository{},
+				forkSuggestionRepo: &MockForkSuggestionRepository{},
+				projectRepo:        &mockProjectRepository{},
+				userRepo:           &mockUserRepository{},
+				transactionManager: &MockTransactionManager{},
+				genaiClient:        &MockGenAIClient{},
+				promptRenderer:     &MockPromptRenderer{},
+			}
+			m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", ProjectUUID: "project-uuid", Status: "open"}, nil)
+			tt.setupMock(m)
+			setupDefaultPrompt(m.projectRepo, m.userRepo, m.promptRenderer)
+
+			u := NewChatUsecase(m.chatRepo, m.messageRepo, &MockMessageSelectionRepository{}, &mockEdgeRepository{}, &MockChatMergeEventRepository{}, &MockBranchComparisonRepository{}, m.forkSuggestionRepo, m.projectRepo, m.userRepo, m.transactionManager, m.genaiClient, &MockPublisher{}, m.promptRenderer, nil)
+
+			got, err := u.SuggestForks(context.Background(), "chat-uuid", tt.messageUUID)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			tt.assertion(t, got)
+			m.forkSuggestionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestResolveSelectionRange(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		selectedText string
+		rangeStart   int
+		rangeEnd     int
+		wantStart    int
+		wantEnd      int
+		wantOK       bool
+	}{
+		{
+			name:         "正常系: 示された範囲が一致する場合はそのまま使うこと",
+			content:      "AのについてBのについて",
+			selectedText: "について",
+			rangeStart:   2,
+			rangeEnd:     6,
+			wantStart:    2,
+			wantEnd:      6,
+			wantOK:       true,
+		},
+		{
+			name:         "正常系: 複数ある場合は示された位置に最も近いものに補正すること",
+			content:      "AのについてBのについて",
+			selectedText: "について",
+			rangeStart:   9,
+			rangeEnd:     20,
+			wantStart:    8,
+			wantEnd:      12,
+			wantOK:       true,
+		},
+		{
+			name:         "異常系: 本文に含まれない場合",
+			content:      "AのについてBのについて",
+			selectedText: "Cについて",
+			rangeStart:   0,
+			rangeEnd:     5,
+			wantOK:       false,
+		},
+		{
+			name:         "異常系: 空白のみの場合",
+			content:      "A B",
+			selectedText: " ",
+			rangeStart:   1,
+			rangeEnd:     2,
+			wantOK:       false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, ok := resolveSelectionRange(tt.content, tt.selectedText, tt.rangeStart, tt.rangeEnd)
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.Equal(t, tt.wantStart, start)
+				assert.Equal(t, tt.wantEnd, end)
+			}
+		})
+	}
+}
+
+func TestNormalizeForkSelection(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		selectedText string
+		rangeStart   int
+		rangeEnd     int
+		wantStart    int
+		wantEnd      int
+		wantErr      error
+	}{
+		{
+			name:         "正常系: 文字単位の範囲が一致する場合はそのまま使うこと",
+			content:      "日本語の深掘りをする",
+			selectedText: "深掘り",
+			rangeStart:   4,
+			rangeEnd:     7,
+			wantStart:    4,
+			wantEnd:      7,
+		},
+		{
+			name:         "正常系: バイト単位の範囲を文字単位に変換すること",
+			content:      "日本語の深掘りをする",
+			selectedText: "深掘り",
+			rangeStart:   12,
+			rangeEnd:     21,
+			wantStart:    4,
+			wantEnd:      7,
+		},
+		{
+			name:         "正常系: UTF-16単位の範囲を文字単位に変換すること",
+			content:      "😀絵文字の後の話題",
+			selectedText: "話題",
+			rangeStart:   8,
+			rangeEnd:     10,
+			wantStart:    7,
+			wantEnd:      9,
+		},
+		{
+			name:         "正常系: 範囲が一致しない場合は本文から探して補正すること",
+			content:      "## 見出し\n\n本文の話題",
+			selectedText: "本文の話題",
+			rangeStart:   3,
+			rangeEnd:     8,
+			wantStart:    8,
+			wantEnd:      13,
+		},
+		{
+			name:         "正常系: 範囲を選択しない場合は範囲を使わないこと",
+			content:      "本文",
+			selectedText: "",
+			rangeStart:   3,
+			rangeEnd:     5,
+			wantStart:    0,
+			wantEnd:      0,
+		},
+		{
+			name:         "異常系: 選択した文章が本文にない場合",
+			content:      "日本語の深掘りをする",
+			selectedText: "英語",
+			rangeStart:   0,
+			rangeEnd:     2,
+			wantErr:      model.ErrInvalidForkSelection,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := normalizeForkSelection(tt.content, tt.selectedText, tt.rangeStart, tt.rangeEnd)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStart, start)
+			assert.Equal(t, tt.wantEnd, end)
+		})
+	}
+}
+
+func TestChatUsecase_GetForkSuggestions(t *testing.T) {
+	tests := []struct {
+		name      string
+		setupMock func(messageRepo *MockMessageRepository, forkSuggestionRepo *MockForkSuggestionRepository)
+		wantErrIs error
+		wantLen   int
+	}{
+		{
+			name: "正常系: 抽出済みのフォーク候補を取得すること",
+			setupMock: func(messageRepo *MockMessageRepository, forkSuggestionRepo *MockForkSuggestionRepository) {
+				messageRepo.On("FindByID", mock.Anything, "msg-uuid").Return(&model.Message{UUID: "msg-uuid", ChatUUID: "chat-uuid", Role: "assistant"}, nil)
+				forkSuggestionRepo.On("FindByMessageUUID", mock.Anything, "msg-uuid").Return([]*model.ForkSuggestion{{UUID: "suggestion-1"}}, nil)
+			},
+			wantLen: 1,
+		},
+		{
+			name: "異常系: 別のチャットのメッセージの場合",
+			setupMock: func(messageRepo *MockMessageRepository, forkSuggestionRepo *MockForkSuggestionRepository) {
+				messageRepo.On("FindByID", mock.Anything, "msg-uuid").Return(&model.Message{UUID: "msg-uuid", ChatUUID: "other-chat", Role: "assistant"}, nil)
+			},
+			wantErrIs: model.ErrInvalidForkSuggestionTarget,
+		},
+		{
+			name: "異常系: メッセージが存在しない場合",
+			setupMock: func(messageRepo *MockMessageRepository, forkSuggestionRepo *MockForkSuggestionRepository) {
+				messageRepo.On("FindByID", mock.Anything, "msg-uuid").Return(nil, nil)
+			},
+			wantErrIs: model.ErrInvalidForkSuggestionTarget,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messageRepo := &MockMessageRepository{}
+			forkSuggestionRepo := &MockForkSuggestionRepository{}
+			tt.setupMock(messageRepo, forkSuggestionRepo)
+
+			u := NewChatUsecase(&MockChatRepository{}, messageRepo, &MockMessageSelectionRepository{}, &mockEdgeRepository{}, &MockChatMergeEventRepository{}, &MockBranchComparisonRepository{}, forkSuggestionRepo, &mockProjectRepository{}, &mockUserRepository{}, &MockTransactionManager{}, &MockGenAIClient{}, &MockPublisher{}, &MockPromptRenderer{}, nil)
+
+			got, err := u.GetForkSuggestions(context.Background(), "chat-uuid", "msg-uuid")
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, got, tt.wantLen)
 		})
 	}
 }
@@ -998,9 +2040,13 @@ func TestChatUsecase_ForkChat(t *testing.T) {
 		messageRepo          *MockMessageRepository
 		messageSelectionRepo *MockMessageSelectionRepository
 		edgeRepo             *mockEdgeRepository
+		chatMergeEventRepo   *MockChatMergeEventRepository
+		projectRepo          *mockProjectRepository
+		userRepo             *mockUserRepository
 		transactionManager   *MockTransactionManager
 		genaiClient          *MockGenAIClient
 		publisher            *MockPublisher
+		promptRenderer       *MockPromptRenderer
 	}
 	type args struct {
 		params model.ForkChatParams
@@ -1011,6 +2057,7 @@ func TestChatUsecase_ForkChat(t *testing.T) {
 		setupMock func(m *mocks)
 		want      string
 		wantErr   bool
+		wantErrIs error
 	}{
 		{
 			name: "正常系: チャットフォーク成功",
@@ -1030,17 +2077,17 @@ func TestChatUsecase_ForkChat(t *testing.T) {
 				m.chatRepo.On("FindByID", mock.Anything, "parent-chat").Return(&model.Chat{
 					UUID:        "parent-chat",
 					ProjectUUID: "project-1",
+					Status:      model.ChatStatusOpen,
 				}, nil)
 
 				// 2. FindByID (Target Message)
 				m.messageRepo.On("FindByID", mock.Anything, "msg-1").Return(&model.Message{
-					UUID:      "msg-1",
-					PositionY: 100,
+					UUID:     "msg-1",
+					ChatUUID: "parent-chat",
+					Role:     "assistant",
+					Content:  "selected text",
 				}, nil)
 
-				// 3. CountByProjectUUID
-				m.chatRepo.On("CountByProjectUUID", mock.Anything, "project-1").Return(int64(5), nil)
-
 				// 4. Transaction
 				m.transactionManager.On("Do", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 					fn := args.Get(1).(func(context.Context) error)
@@ -1054,13 +2101,12 @@ func TestChatUsecase_ForkChat(t *testing.T) {
 
 				// 6. Create Chat
 				m.chatRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *model.Chat) bool {
-					// PositionX = 5 * 250 = 1250, PositionY = 100
-					return c.Title == "New Chat" && c.ProjectUUID == "project-1" && *c.ParentUUID == "parent-chat" && c.PositionX == 1250 && c.PositionY == 100
+					return c.Title == "New Chat" && c.ProjectUUID == "project-1" && *c.ParentUUID == "parent-chat"
 				})).Return(nil)
 
 				// 7. Create Message
 				m.messageRepo.On("Create", mock.Anything, mock.MatchedBy(func(msg *model.Message) bool {
-					return msg.Role == "assistant" && msg.Content == "New Chat\n\nSummary" && msg.PositionX == 1250 && msg.PositionY == 100
+					return msg.Role == "assistant" && msg.Content == "New Chat\n\nSummary"
 				})).Return(nil)
 
 				// 8. Create Edge
@@ -1073,42 +2119,641 @@ func TestChatUsecase_ForkChat(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "異常系: 親チャットが見つからない",
-			args: args{
-				params: model.ForkChatParams{
-					ParentChatUUID: "parent-chat",
-				},
-			},
-			setupMock: func(m *mocks) {
-				m.chatRepo.On("FindByID", mock.Anything, "parent-chat").Return(nil, errors.New("not found"))
-			},
-			want:    "",
-			wantErr: true,
-		},
-		{
-			name: "異常系: トランザクションエラー",
+			name: "正常系: 範囲を選択せずにユーザーメッセージ全体からフォークできること",
 			args: args{
 				params: model.ForkChatParams{
+					TargetMessageUUID: "user-msg",
 					ParentChatUUID:    "parent-chat",
-					TargetMessageUUID: "msg-1",
+					Title:             "New Chat",
+					ContextSummary:    "Summary",
 				},
 			},
 			setupMock: func(m *mocks) {
 				m.chatRepo.On("FindByID", mock.Anything, "parent-chat").Return(&model.Chat{
 					UUID:        "parent-chat",
 					ProjectUUID: "project-1",
+					Status:      model.ChatStatusOpen,
 				}, nil)
-
-				m.messageRepo.On("FindByID", mock.Anything, "msg-1").Return(&model.Message{
-					UUID:      "msg-1",
-					PositionY: 100,
+				m.messageRepo.On("FindByID", mock.Anything, "user-msg").Return(&model.Message{
+					UUID:     "user-msg",
+					ChatUUID: "parent-chat",
+					Role:     "user",
 				}, nil)
-
-				m.chatRepo.On("CountByProjectUUID", mock.Anything, "project-1").Return(int64(5), nil)
-
-				m.transactionManager.On("Do", mock.Anything, mock.Anything).Return(errors.New("tx error"))
+				m.transactionManager.On("Do", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(context.Context) error)
+					fn(context.Background())
+				})
+				// MessageSelection は作成しない
+				m.chatRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *model.Chat) bool {
+					return c.MessageSelectionUUID == nil && *c.SourceMessageUUID == "user-msg"
+				})).Return(nil)
+				m.messageRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				m.edgeRepo.On("Create", mock.Anything, mock.MatchedBy(func(edge *model.Edge) bool {
+					return edge.TargetMessageUUID == "user-msg"
+				})).Return(nil)
+			},
+			want:    "new-chat-id",
+			wantErr: false,
+		},
+		{
+			name: "正常系: フォーク元のメッセージを省略するとチャットの先頭からフォークすること",
+			args: args{
+				params: model.ForkChatParams{
+					ParentChatUUID: "parent-chat",
+					Title:          "New Chat",
+					ContextSummary: "Summary",
+				},
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "parent-chat").Return(&model.Chat{
+					UUID:        "parent-chat",
+					ProjectUUID: "project-1",
+					Status:      model.ChatStatusOpen,
+				}, nil)
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "parent-chat").Return([]*model.Message{
+					{UUID: "first-msg", Role: "user"},
+					{UUID: "second-msg", Role: "assistant"},
+				}, nil)
+				m.transactionManager.On("Do", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(context.Context) error)
+					fn(context.Background())
+				})
+				m.chatRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *model.Chat) bool {
+					return c.MessageSelectionUUID == nil && *c.SourceMessageUUID == "first-msg"
+				})).Return(nil)
+				m.messageRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				m.edgeRepo.On("Create", mock.Anything, mock.MatchedBy(func(edge *model.Edge) bool {
+					return edge.TargetMessageUUID == "first-msg"
+				})).Return(nil)
+			},
+			want:    "new-chat-id",
+			wantErr: false,
+		},
+		{
+			name: "異常系: フォーク元のチャットにメッセージがない場合エラー",
+			args: args{
+				params: model.ForkChatParams{
+					ParentChatUUID: "parent-chat",
+				},
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "parent-chat").Return(&model.Chat{
+					UUID:        "parent-chat",
+					ProjectUUID: "project-1",
+					Status:      model.ChatStatusOpen,
+				}, nil)
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "parent-chat").Return([]*model.Message{}, nil)
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "異常系: 親チャットが見つからない",
+			args: args{
+				params: model.ForkChatParams{
+					ParentChatUUID: "parent-chat",
+				},
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "parent-chat").Return(nil, errors.New("not found"))
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "異常系: マージ済みのチャットからはフォークできない",
+			args: args{
+				params: model.ForkChatParams{
+					ParentChatUUID:    "parent-chat",
+					TargetMessageUUID: "msg-1",
+				},
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "parent-chat").Return(&model.Chat{UUID: "parent-chat", ProjectUUID: "project-1", Status: model.ChatStatusMerged}, nil)
+			},
+			want:      "",
+			wantErr:   true,
+			wantErrIs: model.ErrChatNotOpen,
+		},
+		{
+			name: "異常系: トランザクションエラー",
+			args: args{
+				params: model.ForkChatParams{
+					ParentChatUUID:    "parent-chat",
+					TargetMessageUUID: "msg-1",
+				},
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "parent-chat").Return(&model.Chat{
+					UUID:        "parent-chat",
+					ProjectUUID: "project-1",
+					Status:      model.ChatStatusOpen,
+				}, nil)
+
+				m.messageRepo.On("FindByID", mock.Anything, "msg-1").Return(&model.Message{
+					UUID:     "msg-1",
+					ChatUUID: "parent-chat",
+				}, nil)
+
+				m.transactionManager.On("Do", mock.Anything, mock.Anything).Return(errors.New("tx error"))
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "正常系: バイト単位の範囲を文字単位に正規化して保存すること",
+			args: args{
+				params: model.ForkChatParams{
+					TargetMessageUUID: "msg-1",
+					ParentChatUUID:    "parent-chat",
+					SelectedText:      "深掘り",
+					// "日本語の" は12バイト・4文字
+					RangeStart: 12,
+					RangeEnd:   21,
+					Title:      "New Chat",
+				},
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "parent-chat").Return(&model.Chat{UUID: "parent-chat", ProjectUUID: "project-1", Status: model.ChatStatusOpen}, nil)
+				m.messageRepo.On("FindByID", mock.Anything, "msg-1").Return(&model.Message{UUID: "msg-1", ChatUUID: "parent-chat", Role: "assistant", Content: "日本語の深掘りをする"}, nil)
+				m.transactionManager.On("Do", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(context.Context) error)
+					fn(context.Background())
+				})
+				m.messageSelectionRepo.On("Create", mock.Anything, mock.MatchedBy(func(sel *model.MessageSelection) bool {
+					return sel.SelectedText == "深掘り" && sel.RangeStart == 4 && sel.RangeEnd == 7
+				})).Return(nil)
+				m.chatRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				m.messageRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				m.edgeRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			},
+			want:    "new-chat-id",
+			wantErr: false,
+		},
+		{
+			name: "異常系: 別のチャットのメッセージからはフォークできない",
+			args: args{
+				params: model.ForkChatParams{
+					TargetMessageUUID: "other-msg",
+					ParentChatUUID:    "parent-chat",
+				},
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "parent-chat").Return(&model.Chat{UUID: "parent-chat", ProjectUUID: "project-1", Status: model.ChatStatusOpen}, nil)
+				m.messageRepo.On("FindByID", mock.Anything, "other-msg").Return(&model.Message{UUID: "other-msg", ChatUUID: "other-chat", Role: "assistant"}, nil)
+			},
+			want:      "",
+			wantErr:   true,
+			wantErrIs: model.ErrInvalidForkTarget,
+		},
+		{
+			name: "異常系: フォーク元のメッセージが存在しない場合エラー",
+			args: args{
+				params: model.ForkChatParams{
+					TargetMessageUUID: "missing-msg",
+					ParentChatUUID:    "parent-chat",
+				},
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "parent-chat").Return(&model.Chat{UUID: "parent-chat", ProjectUUID: "project-1", Status: model.ChatStatusOpen}, nil)
+				m.messageRepo.On("FindByID", mock.Anything, "missing-msg").Return(nil, nil)
+			},
+			want:      "",
+			wantErr:   true,
+			wantErrIs: model.ErrInvalidForkTarget,
+		},
+		{
+			name: "異常系: 選択した文章がメッセージにない場合エラー",
+			args: args{
+				params: model.ForkChatParams{
+					TargetMessageUUID: "msg-1",
+					ParentChatUUID:    "parent-chat",
+					SelectedText:      "存在しない",
+					RangeStart:        0,
+					RangeEnd:          5,
+				},
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "parent-chat").Return(&model.Chat{UUID: "parent-chat", ProjectUUID: "project-1", Status: model.ChatStatusOpen}, nil)
+				m.messageRepo.On("FindByID", mock.Anything, "msg-1").Return(&model.Message{UUID: "msg-1", ChatUUID: "parent-chat", Role: "assistant", Content: "日本語の深掘りをする"}, nil)
+			},
+			want:      "",
+			wantErr:   true,
+			wantErrIs: model.ErrInvalidForkSelection,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mocks{
+				chatRepo:             &MockChatRepository{},
+				messageRepo:          &MockMessageRepository{},
+				messageSelectionRepo: &MockMessageSelectionRepository{},
+				edgeRepo:             &mockEdgeRepository{},
+				chatMergeEventRepo:   &MockChatMergeEventRepository{},
+				projectRepo:          &mockProjectRepository{},
+				userRepo:             &mockUserRepository{},
+				transactionManager:   &MockTransactionManager{},
+				genaiClient:          &MockGenAIClient{},
+				publisher:            &MockPublisher{},
+				promptRenderer:       &MockPromptRenderer{},
+			}
+			tt.setupMock(m)
+			setupDefaultPrompt(m.projectRepo, m.userRepo, m.promptRenderer)
+
+			u := NewChatUsecase(m.chatRepo, m.messageRepo, m.messageSelectionRepo, m.edgeRepo, m.chatMergeEventRepo, &MockBranchComparisonRepository{}, &MockForkSuggestionRepository{}, m.projectRepo, m.userRepo, m.transactionManager, m.genaiClient, m.publisher, m.promptRenderer, nil)
+
+			got, err := u.ForkChat(context.Background(), tt.args.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("chatUsecase.ForkChat() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+			}
+			if !tt.wantErr {
+				assert.NotEmpty(t, got)
+				m.messageSelectionRepo.AssertExpectations(t)
+			} else {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestChatUsecase_BatchForkChat(t *testing.T) {
+	type mocks struct {
+		chatRepo             *MockChatRepository
+		messageRepo          *MockMessageRepository
+		messageSelectionRepo *MockMessageSelectionRepository
+		edgeRepo             *mockEdgeRepository
+		projectRepo          *mockProjectRepository
+		transactionManager   *MockTransactionManager
+		publisher            *MockPublisher
+	}
+	forks := func(n int) []model.BatchForkItem {
+		items := make([]model.BatchForkItem, n)
+		for i := range items {
+			items[i] = model.BatchForkItem{Title: fmt.Sprintf("Branch %d", i), ContextSummary: "Summary"}
+		}
+		return items
+	}
+	tests := []struct {
+		name      string
+		params    model.BatchForkChatParams
+		setupMock func(m *mocks)
+		wantCount int
+		wantErr   error
+	}{
+		{
+			name: "正常系: 複数のブランチを作成し最初の回答の生成タスクを登録すること",
+			params: model.BatchForkChatParams{
+				ParentChatUUID:    "parent-chat",
+				TargetMessageUUID: "msg-1",
+				Forks: []model.BatchForkItem{
+					{SelectedText: "first", RangeStart: 0, RangeEnd: 5, Title: "A", ContextSummary: "Summary A"},
+					{Title: "B", ContextSummary: "Summary B"},
+					{SelectedText: "third", RangeStart: 10, RangeEnd: 15, Title: "C", ContextSummary: "Summary C"},
+				},
+				GenerateFirstAnswers: true,
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "parent-chat").Return(&model.Chat{UUID: "parent-chat", ProjectUUID: "project-1", Status: model.ChatStatusOpen}, nil)
+				m.messageRepo.On("FindByID", mock.Anything, "msg-1").Return(&model.Message{UUID: "msg-1", ChatUUID: "parent-chat", Role: "assistant", Content: "first and third"}, nil)
+				m.transactionManager.On("Do", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(context.Context) error)
+					fn(context.Background())
+				})
+				// 範囲を選択したブランチのみ MessageSelection を作成する
+				m.messageSelectionRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Times(2)
+				for _, title := range []string{"A", "B", "C"} {
+					m.chatRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *model.Chat) bool {
+						return c.Title == title && *c.SourceMessageUUID == "msg-1"
+					})).Return(nil).Once()
+				}
+				m.messageRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Times(3)
+				m.edgeRepo.On("Create", mock.Anything, mock.MatchedBy(func(edge *model.Edge) bool {
+					return edge.TargetMessageUUID == "msg-1"
+				})).Return(nil).Times(3)
+				m.publisher.On("Publish", "chat_first_answer", mock.Anything).Return(nil).Times(3)
+			},
+			wantCount: 3,
+		},
+		{
+			name: "正常系: 最初の回答を生成しない場合はタスクを登録しないこと",
+			params: model.BatchForkChatParams{
+				ParentChatUUID:    "parent-chat",
+				TargetMessageUUID: "msg-1",
+				Forks:             forks(2),
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "parent-chat").Return(&model.Chat{UUID: "parent-chat", ProjectUUID: "project-1", Status: model.ChatStatusOpen}, nil)
+				m.messageRepo.On("FindByID", mock.Anything, "msg-1").Return(&model.Message{UUID: "msg-1", ChatUUID: "parent-chat", Role: "assistant"}, nil)
+				m.transactionManager.On("Do", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(context.Context) error)
+					fn(context.Background())
+				})
+				m.chatRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				m.messageRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				m.edgeRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantCount: 2,
+		},
+		{
+			name: "異常系: ブランチが0件の場合エラー",
+			params: model.BatchForkChatParams{
+				ParentChatUUID:    "parent-chat",
+				TargetMessageUUID: "msg-1",
+			},
+			setupMock: func(m *mocks) {},
+			wantErr:   model.ErrInvalidBatchFork,
+		},
+		{
+			name: "異常系: ブランチが上限を超える場合エラー",
+			params: model.BatchForkChatParams{
+				ParentChatUUID:    "parent-chat",
+				TargetMessageUUID: "msg-1",
+				Forks:             forks(maxBatchForks + 1),
+			},
+			setupMock: func(m *mocks) {},
+			wantErr:   model.ErrInvalidBatchFork,
+		},
+		{
+			name: "異常系: 選択範囲が本文と一致しないブランチがある場合は何も作成しないこと",
+			params: model.BatchForkChatParams{
+				ParentChatUUID:    "parent-chat",
+				TargetMessageUUID: "msg-1",
+				Forks: []model.BatchForkItem{
+					{SelectedText: "first", RangeStart: 0, RangeEnd: 5, Title: "A"},
+					{SelectedText: "missing", RangeStart: 0, RangeEnd: 7, Title: "B"},
+				},
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "parent-chat").Return(&model.Chat{UUID: "parent-chat", ProjectUUID: "project-1", Status: model.ChatStatusOpen}, nil)
+				m.messageRepo.On("FindByID", mock.Anything, "msg-1").Return(&model.Message{UUID: "msg-1", ChatUUID: "parent-chat", Role: "assistant", Content: "first and third"}, nil)
+			},
+			wantErr: model.ErrInvalidForkSelection,
+		},
+		{
+			name: "異常系: クローズしたチャットからはフォークできないこと",
+			params: model.BatchForkChatParams{
+				ParentChatUUID:    "parent-chat",
+				TargetMessageUUID: "msg-1",
+				Forks:             forks(2),
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "parent-chat").Return(&model.Chat{UUID: "parent-chat", ProjectUUID: "project-1", Status: model.ChatStatusClosed}, nil)
+			},
+			wantErr: model.ErrChatNotOpen,
+		},
+		{
+			name: "異常系: 途中のブランチの作成に失敗した場合は全体をエラーにしタスクを登録しないこと",
+			params: model.BatchForkChatParams{
+				ParentChatUUID:       "parent-chat",
+				TargetMessageUUID:    "msg-1",
+				Forks:                forks(2),
+				GenerateFirstAnswers: true,
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "parent-chat").Return(&model.Chat{UUID: "parent-chat", ProjectUUID: "project-1", Status: model.ChatStatusOpen}, nil)
+				m.messageRepo.On("FindByID", mock.Anything, "msg-1").Return(&model.Message{UUID: "msg-1", ChatUUID: "parent-chat", Role: "assistant"}, nil)
+				m.transactionManager.On("Do", mock.Anything, mock.Anything).Return(errors.New("tx error")).Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(context.Context) error)
+					fn(context.Background())
+				})
+				m.chatRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *model.Chat) bool { return c.Title == "Branch 0" })).Return(nil)
+				m.chatRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *model.Chat) bool { return c.Title == "Branch 1" })).Return(errors.New("db error"))
+				m.messageRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				m.edgeRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			},
+			wantErr: errors.New("tx error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mocks{
+				chatRepo:             &MockChatRepository{},
+				messageRepo:          &MockMessageRepository{},
+				messageSelectionRepo: &MockMessageSelectionRepository{},
+				edgeRepo:             &mockEdgeRepository{},
+				projectRepo:          &mockProjectRepository{},
+				transactionManager:   &MockTransactionManager{},
+				publisher:            &MockPublisher{},
+			}
+			tt.setupMock(m)
+
+			u := NewChatUsecase(m.chatRepo, m.messageRepo, m.messageSelectionRepo, m.edgeRepo, &MockChatMergeEventRepository{}, &MockBranchComparisonRepository{}, &MockForkSuggestionRepository{}, m.projectRepo, &mockUserRepository{}, m.transactionManager, &MockGenAIClient{}, m.publisher, &MockPromptRenderer{}, nil)
+
+			got, err := u.BatchForkChat(context.Background(), tt.params)
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+				// 指定が不正な場合はトランザクションを開始しない
+				if errors.Is(tt.wantErr, model.ErrInvalidBatchFork) || errors.Is(tt.wantErr, model.ErrInvalidForkSelection) || errors.Is(tt.wantErr, model.ErrChatNotOpen) {
+					assert.ErrorIs(t, err, tt.wantErr)
+					m.transactionManager.AssertNotCalled(t, "Do", mock.Anything, mock.Anything)
+				}
+				assert.Nil(t, got)
+				m.publisher.AssertNotCalled(t, "Publish", "chat_first_answer", mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, got, tt.wantCount)
+			m.chatRepo.AssertExpectations(t)
+			m.publisher.AssertExpectations(t)
+			m.messageSelectionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestChatUsecase_RebaseChat(t *testing.T) {
+	type mocks struct {
+		chatRepo             *MockChatRepository
+		messageRepo          *MockMessageRepository
+		messageSelectionRepo *MockMessageSelectionRepository
+		edgeRepo             *mockEdgeRepository
+		projectRepo          *mockProjectRepository
+		userRepo             *mockUserRepository
+		transactionManager   *MockTransactionManager
+		genaiClient          *MockGenAIClient
+		promptRenderer       *MockPromptRenderer
+	}
+	parentUUID := "parent-chat"
+	oldSourceUUID := "old-msg"
+	childChat := func(status string) *model.Chat {
+		return &model.Chat{
+			UUID:              "child-chat",
+			ProjectUUID:       "project-1",
+			ParentUUID:        &parentUUID,
+			SourceMessageUUID: &oldSourceUUID,
+			Title:             "ブランチ",
+			Status:            status,
+			ContextSummary:    "古い文脈",
+		}
+	}
+	// 付け替え先の検証までの共通の振る舞い
+	setupTarget := func(m *mocks, targetChat *model.Chat) {
+		m.chatRepo.On("FindByID", mock.Anything, "child-chat").Return(childChat("open"), nil)
+		m.messageRepo.On("FindByID", mock.Anything, "new-msg").Return(&model.Message{UUID: "new-msg", ChatUUID: targetChat.UUID, Role: "assistant", Content: "topic"}, nil)
+		m.chatRepo.On("FindByID", mock.Anything, targetChat.UUID).Return(targetChat, nil)
+	}
+	// 付け替えに必要なエッジと初期メッセージの取得
+	setupEdges := func(m *mocks) {
+		m.chatRepo.On("FindByParentUUID", mock.Anything, "child-chat").Return([]*model.Chat{}, nil)
+		m.edgeRepo.On("FindEdgesByChatID", mock.Anything, "child-chat").Return([]*model.Edge{
+			{UUID: "edge-1", ChatUUID: "child-chat", SourceMessageUUID: "initial-msg", TargetMessageUUID: "old-msg"},
+		}, nil)
+		m.messageRepo.On("FindMessagesByChatID", mock.Anything, "child-chat").Return([]*model.Message{
+			{UUID: "initial-msg", ChatUUID: "child-chat", Role: "assistant", Content: "ブランチ\n\n古い文脈"},
+		}, nil)
+		m.transactionManager.On("Do", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			fn(context.Background())
+		})
+	}
+	tests := []struct {
+		name      string
+		params    model.RebaseChatParams
+		setupMock func(m *mocks)
+		want      *model.RebaseChatResult
+		wantErrIs error
+		wantErr   bool
+	}{
+		{
+			name:   "正常系: 別のチャットのメッセージに付け替えられること",
+			params: model.RebaseChatParams{SourceMessageUUID: "new-msg"},
+			setupMock: func(m *mocks) {
+				setupTarget(m, &model.Chat{UUID: "other-chat", ProjectUUID: "project-1", Status: "open"})
+				setupEdges(m)
+				m.chatRepo.On("UpdateParent", mock.Anything, "child-chat", "other-chat", "new-msg", (*string)(nil)).Return(nil)
+				m.edgeRepo.On("UpdateTarget", mock.Anything, "edge-1", "new-msg").Return(nil)
+			},
+			want: &model.RebaseChatResult{
+				ChatUUID:          "child-chat",
+				ParentChatUUID:    "other-chat",
+				SourceMessageUUID: "new-msg",
+				ContextSummary:    "古い文脈",
+			},
+		},
+		{
+			name:   "正常系: 選択範囲を指定し、文脈を生成し直せること",
+			params: model.RebaseChatParams{SourceMessageUUID: "new-msg", SelectedText: "topic", RangeStart: 0, RangeEnd: 5, RegenerateContext: true},
+			setupMock: func(m *mocks) {
+				setupTarget(m, &model.Chat{UUID: "other-chat", ProjectUUID: "project-1", Status: "open"})
+				setupEdges(m)
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "other-chat").Return([]*model.Message{
+					{UUID: "new-msg", ChatUUID: "other-chat", Role: "assistant", Content: "topic"},
+				}, nil)
+				m.genaiClient.On("GenerateContent", mock.Anything, "gemini-2.5-flash", mock.Anything, mock.Anything).Return(&genai.GenerateContentResponse{
+					Candidates: []*genai.Candidate{
+						{Content: &genai.Content{Parts: []*genai.Part{{Text: `{"suggested_title": "Title", "generated_context": "新しい文脈"}`}}}},
+					},
+				}, nil)
+				m.messageSelectionRepo.On("Create", mock.Anything, mock.MatchedBy(func(s *model.MessageSelection) bool {
+					return s.SelectedText == "topic" && s.RangeEnd == 5
+				})).Return(nil)
+				m.chatRepo.On("UpdateParent", mock.Anything, "child-chat", "other-chat", "new-msg", mock.AnythingOfType("*string")).Return(nil)
+				m.edgeRepo.On("UpdateTarget", mock.Anything, "edge-1", "new-msg").Return(nil)
+				m.chatRepo.On("UpdateContextSummary", mock.Anything, "child-chat", "新しい文脈", "prompt/ja/v1").Return(nil)
+				m.messageRepo.On("UpdateContent", mock.Anything, "initial-msg", "ブランチ\n\n新しい文脈").Return(nil)
+			},
+			want: &model.RebaseChatResult{
+				ChatUUID:             "child-chat",
+				ParentChatUUID:       "other-chat",
+				SourceMessageUUID:    "new-msg",
+				ContextSummary:       "新しい文脈",
+				ContextPromptVersion: "prompt/ja/v1",
+			},
+		},
+		{
+			name:   "正常系: バイト単位の選択範囲は文字単位に変換して保存すること",
+			params: model.RebaseChatParams{SourceMessageUUID: "new-msg", SelectedText: "topic", RangeStart: 12, RangeEnd: 17},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "child-chat").Return(childChat("open"), nil)
+				m.messageRepo.On("FindByID", mock.Anything, "new-msg").Return(&model.Message{UUID: "new-msg", ChatUUID: "other-chat", Role: "assistant", Content: "日本語のtopic"}, nil)
+				m.chatRepo.On("FindByID", mock.Anything, "other-chat").Return(&model.Chat{UUID: "other-chat", ProjectUUID: "project-1", Status: "open"}, nil)
+				setupEdges(m)
+				m.messageSelectionRepo.On("Create", mock.Anything, mock.MatchedBy(func(s *model.MessageSelection) bool {
+					return s.SelectedText == "topic" && s.RangeStart == 4 && s.RangeEnd == 9
+				})).Return(nil)
+				m.chatRepo.On("UpdateParent", mock.Anything, "child-chat", "other-chat", "new-msg", mock.AnythingOfType("*string")).Return(nil)
+				m.edgeRepo.On("UpdateTarget", mock.Anything, "edge-1", "new-msg").Return(nil)
+			},
+			want: &model.RebaseChatResult{
+				ChatUUID:          "child-chat",
+				ParentChatUUID:    "other-chat",
+				SourceMessageUUID: "new-msg",
+				ContextSummary:    "古い文脈",
+			},
+		},
+		{
+			name:   "異常系: 選択した文章が付け替え先のメッセージにない場合はエラー",
+			params: model.RebaseChatParams{SourceMessageUUID: "new-msg", SelectedText: "missing", RangeStart: 0, RangeEnd: 7},
+			setupMock: func(m *mocks) {
+				setupTarget(m, &model.Chat{UUID: "other-chat", ProjectUUID: "project-1", Status: "open"})
+			},
+			wantErrIs: model.ErrInvalidForkSelection,
+		},
+		{
+			name:   "異常系: 子孫のチャットのメッセージには付け替えられないこと",
+			params: model.RebaseChatParams{SourceMessageUUID: "new-msg"},
+			setupMock: func(m *mocks) {
+				setupTarget(m, &model.Chat{UUID: "grandchild-chat", ProjectUUID: "project-1", Status: "open"})
+				m.chatRepo.On("FindByParentUUID", mock.Anything, "child-chat").Return([]*model.Chat{{UUID: "grandchild-chat"}}, nil)
+				m.chatRepo.On("FindByParentUUID", mock.Anything, "grandchild-chat").Return([]*model.Chat{}, nil)
+			},
+			wantErrIs: model.ErrInvalidRebaseTarget,
+		},
+		{
+			name:   "異常系: 自身のメッセージには付け替えられないこと",
+			params: model.RebaseChatParams{SourceMessageUUID: "new-msg"},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "child-chat").Return(childChat("open"), nil)
+				m.messageRepo.On("FindByID", mock.Anything, "new-msg").Return(&model.Message{UUID: "new-msg", ChatUUID: "child-chat", Role: "user"}, nil)
+			},
+			wantErrIs: model.ErrInvalidRebaseTarget,
+		},
+		{
+			name:   "異常系: 別のプロジェクトのメッセージには付け替えられないこと",
+			params: model.RebaseChatParams{SourceMessageUUID: "new-msg"},
+			setupMock: func(m *mocks) {
+				setupTarget(m, &model.Chat{UUID: "other-chat", ProjectUUID: "project-2", Status: "open"})
+			},
+			wantErrIs: model.ErrInvalidRebaseTarget,
+		},
+		{
+			name:   "異常系: 付け替え先のメッセージが存在しない場合",
+			params: model.RebaseChatParams{SourceMessageUUID: "missing-msg"},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "child-chat").Return(childChat("open"), nil)
+				m.messageRepo.On("FindByID", mock.Anything, "missing-msg").Return(nil, nil)
+			},
+			wantErrIs: model.ErrInvalidRebaseTarget,
+		},
+		{
+			name:   "異常系: マージ済みのチャットは付け替えられないこと",
+			params: model.RebaseChatParams{SourceMessageUUID: "new-msg"},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "child-chat").Return(childChat("merged"), nil)
+			},
+			wantErrIs: model.ErrInvalidRebaseTarget,
+		},
+		{
+			name:   "異常系: ルートのチャットは付け替えられないこと",
+			params: model.RebaseChatParams{SourceMessageUUID: "new-msg"},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "child-chat").Return(&model.Chat{UUID: "child-chat", ProjectUUID: "project-1", Status: "open"}, nil)
+			},
+			wantErrIs: model.ErrInvalidRebaseTarget,
+		},
+		{
+			name:   "異常系: トランザクションエラー",
+			params: model.RebaseChatParams{SourceMessageUUID: "new-msg"},
+			setupMock: func(m *mocks) {
+				setupTarget(m, &model.Chat{UUID: "other-chat", ProjectUUID: "project-1", Status: "open"})
+				m.chatRepo.On("FindByParentUUID", mock.Anything, "child-chat").Return([]*model.Chat{}, nil)
+				m.edgeRepo.On("FindEdgesByChatID", mock.Anything, "child-chat").Return([]*model.Edge{}, nil)
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "child-chat").Return([]*model.Message{{UUID: "initial-msg", Role: "assistant"}}, nil)
+				m.transactionManager.On("Do", mock.Anything, mock.Anything).Return(errors.New("tx error"))
 			},
-			want:    "",
 			wantErr: true,
 		},
 	}
@@ -1119,24 +2764,33 @@ func TestChatUsecase_ForkChat(t *testing.T) {
 				messageRepo:          &MockMessageRepository{},
 				messageSelectionRepo: &MockMessageSelectionRepository{},
 				edgeRepo:             &mockEdgeRepository{},
+				projectRepo:          &mockProjectRepository{},
+				userRepo:             &mockUserRepository{},
 				transactionManager:   &MockTransactionManager{},
 				genaiClient:          &MockGenAIClient{},
-				publisher:            &MockPublisher{},
+				promptRenderer:       &MockPromptRenderer{},
 			}
 			tt.setupMock(m)
+			setupDefaultPrompt(m.projectRepo, m.userRepo, m.promptRenderer)
 
-			u := NewChatUsecase(m.chatRepo, m.messageRepo, m.messageSelectionRepo, m.edgeRepo, m.transactionManager, m.genaiClient, m.publisher)
+			u := NewChatUsecase(m.chatRepo, m.messageRepo, m.messageSelectionRepo, m.edgeRepo, &MockChatMergeEventRepository{}, &MockBranchComparisonRepository{}, &MockForkSuggestionRepository{}, m.projectRepo, m.userRepo, m.transactionManager, m.genaiClient, &MockPublisher{}, m.promptRenderer, nil)
 
-			got, err := u.ForkChat(context.Background(), tt.args.params)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("chatUsecase.ForkChat() error = %v, wantErr %v", err, tt.wantErr)
+			got, err := u.RebaseChat(context.Background(), "child-chat", tt.params)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				m.messageSelectionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
 				return
 			}
-			if !tt.wantErr {
-				assert.NotEmpty(t, got)
-			} else {
-				assert.Equal(t, tt.want, got)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
 			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			m.chatRepo.AssertExpectations(t)
+			m.messageRepo.AssertExpectations(t)
+			m.messageSelectionRepo.AssertExpectations(t)
+			m.edgeRepo.AssertExpectations(t)
 		})
 	}
 }
@@ -1147,12 +2801,17 @@ func TestChatUsecase_GetMergePreview(t *testing.T) {
 		messageRepo          *MockMessageRepository
 		messageSelectionRepo *MockMessageSelectionRepository
 		edgeRepo             *mockEdgeRepository
+		chatMergeEventRepo   *MockChatMergeEventRepository
+		projectRepo          *mockProjectRepository
+		userRepo             *mockUserRepository
 		transactionManager   *MockTransactionManager
 		genaiClient          *MockGenAIClient
 		publisher            *MockPublisher
+		promptRenderer       *MockPromptRenderer
 	}
 	type args struct {
-		chatUUID string
+		chatUUID       string
+		targetChatUUID string
 	}
 	tests := []struct {
 		name      string
@@ -1177,6 +2836,12 @@ func TestChatUsecase_GetMergePreview(t *testing.T) {
 				m.messageRepo.On("FindLatestMessageByRole", mock.Anything, "chat-uuid", "assistant").Return(&model.Message{
 					Content: "latest assistant message",
 				}, nil)
+				m.promptRenderer.On("Render", model.PromptMergePreview, model.LanguageJapanese, map[string]string{
+					"ContextSummary":  "parent context",
+					"LatestSummary":   "child summary",
+					"LatestAssistant": "latest assistant message",
+					"ParentProgress":  "",
+				}).Return(&model.RenderedPrompt{Text: "merge prompt", Version: "merge_preview/ja/v1"}, nil)
 
 				m.genaiClient.On("GenerateContent", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&genai.GenerateContentResponse{
 					Candidates: []*genai.Candidate{
@@ -1192,9 +2857,113 @@ func TestChatUsecase_GetMergePreview(t *testing.T) {
 			},
 			want: &model.MergePreview{
 				SuggestedSummary: "summary",
+				PromptVersion:    "merge_preview/ja/v1",
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常系: フォーク後に親チャットが進んでいる場合は進展と調整を含めること",
+			args: args{
+				chatUUID: "chat-uuid",
+			},
+			setupMock: func(m *mocks) {
+				parentUUID := "parent-uuid"
+				anchorUUID := "anchor-uuid"
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{
+					UUID:              "chat-uuid",
+					ParentUUID:        &parentUUID,
+					SourceMessageUUID: &anchorUUID,
+					ContextSummary:    "parent context",
+				}, nil)
+				m.messageRepo.On("FindLatestMessageWithSummary", mock.Anything, "chat-uuid").Return(nil, nil)
+				m.messageRepo.On("FindLatestMessageByRole", mock.Anything, "chat-uuid", "assistant").Return(&model.Message{
+					Content: "child conclusion",
+				}, nil)
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "parent-uuid").Return([]*model.Message{
+					{UUID: "before-uuid", Role: "user", Content: "before fork"},
+					{UUID: "anchor-uuid", Role: "assistant", Content: "anchor"},
+					{UUID: "question-uuid", Role: "user", Content: "next question"},
+					{UUID: "answer-uuid", Role: "assistant", Content: "parent answer"},
+					{UUID: "report-uuid", Role: "merge_report", Content: "other branch report"},
+				}, nil)
+				m.promptRenderer.On("Render", model.PromptMergePreview, model.LanguageJapanese, map[string]string{
+					"ContextSummary":  "parent context",
+					"LatestSummary":   "",
+					"LatestAssistant": "child conclusion",
+					"ParentProgress":  "parent answer\n\n---\n\nother branch report",
+				}).Return(&model.RenderedPrompt{Text: "merge prompt", Version: "merge_preview/ja/v2"}, nil)
+
+				m.genaiClient.On("GenerateContent", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&genai.GenerateContentResponse{
+					Candidates: []*genai.Candidate{
+						{Content: &genai.Content{Parts: []*genai.Part{{Text: "summary"}}}},
+					},
+				}, nil)
+			},
+			want: &model.MergePreview{
+				SuggestedSummary: "summary",
+				PromptVersion:    "merge_preview/ja/v2",
+				TargetChatUUID:   "parent-uuid",
+				Divergence: &model.MergeDivergence{
+					AnchorMessageUUID:    "anchor-uuid",
+					NewMessages:          3,
+					NewAssistantMessages: 1,
+					MergedReports:        1,
+					Stale:                true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常系: 起点の後に質問しかない場合は調整が不要と判定されること",
+			args: args{
+				chatUUID: "chat-uuid",
+			},
+			setupMock: func(m *mocks) {
+				parentUUID := "parent-uuid"
+				anchorUUID := "anchor-uuid"
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{
+					UUID:              "chat-uuid",
+					ParentUUID:        &parentUUID,
+					SourceMessageUUID: &anchorUUID,
+				}, nil)
+				m.messageRepo.On("FindLatestMessageWithSummary", mock.Anything, "chat-uuid").Return(nil, nil)
+				m.messageRepo.On("FindLatestMessageByRole", mock.Anything, "chat-uuid", "assistant").Return(nil, nil)
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "parent-uuid").Return([]*model.Message{
+					{UUID: "anchor-uuid", Role: "assistant", Content: "anchor"},
+					{UUID: "question-uuid", Role: "user", Content: "next question"},
+				}, nil)
+				m.genaiClient.On("GenerateContent", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&genai.GenerateContentResponse{
+					Candidates: []*genai.Candidate{
+						{Content: &genai.Content{Parts: []*genai.Part{{Text: "summary"}}}},
+					},
+				}, nil)
+			},
+			want: &model.MergePreview{
+				SuggestedSummary: "summary",
+				PromptVersion:    "prompt/ja/v1",
+				TargetChatUUID:   "parent-uuid",
+				Divergence: &model.MergeDivergence{
+					AnchorMessageUUID: "anchor-uuid",
+					NewMessages:       1,
+					Stale:             false,
+				},
 			},
 			wantErr: false,
 		},
+		{
+			name: "異常系: マージ先が祖先のチャットでない場合エラーになること",
+			args: args{
+				chatUUID:       "chat-uuid",
+				targetChatUUID: "other-uuid",
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid"}, nil)
+				m.messageRepo.On("FindLatestMessageWithSummary", mock.Anything, "chat-uuid").Return(nil, nil)
+				m.messageRepo.On("FindLatestMessageByRole", mock.Anything, "chat-uuid", "assistant").Return(nil, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "異常系: チャット取得失敗",
 			args: args{
@@ -1214,15 +2983,20 @@ func TestChatUsecase_GetMergePreview(t *testing.T) {
 				messageRepo:          &MockMessageRepository{},
 				messageSelectionRepo: &MockMessageSelectionRepository{},
 				edgeRepo:             &mockEdgeRepository{},
+				chatMergeEventRepo:   &MockChatMergeEventRepository{},
+				projectRepo:          &mockProjectRepository{},
+				userRepo:             &mockUserRepository{},
 				transactionManager:   &MockTransactionManager{},
 				genaiClient:          &MockGenAIClient{},
 				publisher:            &MockPublisher{},
+				promptRenderer:       &MockPromptRenderer{},
 			}
 			tt.setupMock(m)
+			setupDefaultPrompt(m.projectRepo, m.userRepo, m.promptRenderer)
 
-			u := NewChatUsecase(m.chatRepo, m.messageRepo, m.messageSelectionRepo, m.edgeRepo, m.transactionManager, m.genaiClient, m.publisher)
+			u := NewChatUsecase(m.chatRepo, m.messageRepo, m.messageSelectionRepo, m.edgeRepo, m.chatMergeEventRepo, &MockBranchComparisonRepository{}, &MockForkSuggestionRepository{}, m.projectRepo, m.userRepo, m.transactionManager, m.genaiClient, m.publisher, m.promptRenderer, nil)
 
-			got, err := u.GetMergePreview(context.Background(), tt.args.chatUUID)
+			got, err := u.GetMergePreview(context.Background(), tt.args.chatUUID, tt.args.targetChatUUID)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("chatUsecase.GetMergePreview() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -1240,9 +3014,13 @@ func TestChatUsecase_MergeChat(t *testing.T) {
 		messageRepo          *MockMessageRepository
 		messageSelectionRepo *MockMessageSelectionRepository
 		edgeRepo             *mockEdgeRepository
+		chatMergeEventRepo   *MockChatMergeEventRepository
+		projectRepo          *mockProjectRepository
+		userRepo             *mockUserRepository
 		transactionManager   *MockTransactionManager
 		genaiClient          *MockGenAIClient
 		publisher            *MockPublisher
+		promptRenderer       *MockPromptRenderer
 	}
 	type args struct {
 		chatUUID string
@@ -1260,6 +3038,7 @@ func TestChatUsecase_MergeChat(t *testing.T) {
 			args: args{
 				chatUUID: "child-chat-uuid",
 				params: model.MergeChatParams{
+					UserUUID:       "user-uuid",
 					ParentChatUUID: "parent-chat-uuid",
 					SummaryContent: "summary content",
 				},
@@ -1267,8 +3046,11 @@ func TestChatUsecase_MergeChat(t *testing.T) {
 			setupMock: func(m *mocks) {
 				sourceMsgUUID := "source-msg-uuid"
 				// 1. FindByID (Child Chat)
+				parentUUID := "parent-chat-uuid"
 				m.chatRepo.On("FindByID", mock.Anything, "child-chat-uuid").Return(&model.Chat{
 					UUID:              "child-chat-uuid",
+					ParentUUID:        &parentUUID,
+					Status:            "open",
 					SourceMessageUUID: &sourceMsgUUID,
 				}, nil)
 
@@ -1286,62 +3068,350 @@ func TestChatUsecase_MergeChat(t *testing.T) {
 						*msg.SourceChatUUID == "child-chat-uuid"
 				})).Return(nil)
 
-				// 4. UpdateStatus
-				m.chatRepo.On("UpdateStatus", mock.Anything, "child-chat-uuid", "merged").Return(nil)
+				// 4. ステータスの条件付き更新
+				m.chatRepo.On("TransitionStatus", mock.Anything, "child-chat-uuid", "open", "merged").Return(true, nil)
+
+				// 5. マージ履歴の作成
+				m.chatMergeEventRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *model.ChatMergeEvent) bool {
+					return e.Action == model.ChatMergeActionMerged &&
+						e.ChatUUID == "child-chat-uuid" &&
+						e.ParentChatUUID == "parent-chat-uuid" &&
+						e.PreviousStatus == "open" &&
+						e.UserUUID != nil && *e.UserUUID == "user-uuid"
+				})).Return(nil)
+
+				// 6. マージ先のサマリ再生成タスクの登録
+				m.chatRepo.On("FindByID", mock.Anything, "parent-chat-uuid").Return(&model.Chat{UUID: "parent-chat-uuid", Status: "open"}, nil)
+				m.publisher.On("Publish", "chat_summary", mock.Anything).Return(nil)
+			},
+			want: &model.MergeChatResult{
+				SummaryContent:  "summary content",
+				TargetChatUUID:  "parent-chat-uuid",
+				MergedChatUUIDs: []string{"child-chat-uuid"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常系: マージ後にレポートの結論を統合する回答を生成できること",
+			args: args{
+				chatUUID: "child-chat-uuid",
+				params: model.MergeChatParams{
+					ParentChatUUID: "parent-chat-uuid",
+					SummaryContent: "summary content",
+					Acknowledge:    true,
+				},
+			},
+			setupMock: func(m *mocks) {
+				sourceMsgUUID := "source-msg-uuid"
+				parentUUID := "parent-chat-uuid"
+				m.chatRepo.On("FindByID", mock.Anything, "child-chat-uuid").Return(&model.Chat{
+					UUID:              "child-chat-uuid",
+					ParentUUID:        &parentUUID,
+					Status:            "open",
+					SourceMessageUUID: &sourceMsgUUID,
+				}, nil)
+				m.transactionManager.On("Do", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(context.Context) error)
+					fn(context.Background())
+				})
+				m.messageRepo.On("Create", mock.Anything, mock.MatchedBy(func(msg *model.Message) bool {
+					return msg.Role == "merge_report"
+				})).Return(nil)
+				m.chatRepo.On("TransitionStatus", mock.Anything, "child-chat-uuid", "open", "merged").Return(true, nil)
+				m.chatMergeEventRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+				// 親チャットでの回答生成
+				m.chatRepo.On("FindByID", mock.Anything, "parent-chat-uuid").Return(&model.Chat{UUID: "parent-chat-uuid", Status: "open"}, nil)
+				m.chatRepo.On("AcquireGenerationLock", mock.Anything, "parent-chat-uuid", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+				m.chatRepo.On("ReleaseGenerationLock", mock.Anything, "parent-chat-uuid", mock.Anything).Return(nil)
+				m.messageRepo.On("FindLatestMessageWithSummary", mock.Anything, "parent-chat-uuid").Return(nil, nil)
+				reportParent := "source-msg-uuid"
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "parent-chat-uuid").Return([]*model.Message{
+					{UUID: "source-msg-uuid", Role: "assistant", Content: "answer"},
+					{UUID: "report-uuid", Role: "merge_report", Content: "summary content", ParentMessageUUID: &reportParent},
+				}, nil)
+				mockIter := func(yield func(*genai.GenerateContentResponse, error) bool) {
+					yield(&genai.GenerateContentResponse{
+						Candidates: []*genai.Candidate{{Content: genai.Text("integrated")[0]}},
+					}, nil)
+				}
+				m.genaiClient.On("GenerateContentStream", mock.Anything, "gemini-2.5-flash", mock.Anything, (*genai.GenerateContentConfig)(nil)).Return(mockIter)
+				m.messageRepo.On("Create", mock.Anything, mock.MatchedBy(func(msg *model.Message) bool {
+					return msg.Role == "assistant" && msg.Content == "integrated"
+				})).Return(nil)
+				m.edgeRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				m.publisher.On("Publish", "chat_summary", mock.Anything).Return(nil)
+				m.messageRepo.On("FindLatestMessageByRole", mock.Anything, "parent-chat-uuid", "assistant").Return(&model.Message{UUID: "ack-uuid"}, nil)
+			},
+			want: &model.MergeChatResult{
+				SummaryContent:          "summary content",
+				TargetChatUUID:          "parent-chat-uuid",
+				MergedChatUUIDs:         []string{"child-chat-uuid"},
+				AcknowledgmentMessageID: "ack-uuid",
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常系: 祖先のチャットに部分木ごとマージできること",
+			args: args{
+				chatUUID: "child-chat-uuid",
+				params: model.MergeChatParams{
+					ParentChatUUID:     "root-chat-uuid",
+					SummaryContent:     "summary content",
+					IncludeDescendants: true,
+				},
+			},
+			setupMock: func(m *mocks) {
+				// root -(root-msg)-> parent -(parent-msg)-> child -> grandchild
+				rootUUID := "root-chat-uuid"
+				parentUUID := "parent-chat-uuid"
+				childUUID := "child-chat-uuid"
+				rootMsg := "root-msg-uuid"
+				parentMsg := "parent-msg-uuid"
+				m.chatRepo.On("FindByID", mock.Anything, "child-chat-uuid").Return(&model.Chat{
+					UUID:              childUUID,
+					ParentUUID:        &parentUUID,
+					Status:            "open",
+					SourceMessageUUID: &parentMsg,
+				}, nil)
+				m.chatRepo.On("FindByID", mock.Anything, "parent-chat-uuid").Return(&model.Chat{
+					UUID:              parentUUID,
+					ParentUUID:        &rootUUID,
+					Status:            "open",
+					SourceMessageUUID: &rootMsg,
+				}, nil)
+				m.chatRepo.On("FindByParentUUID", mock.Anything, "child-chat-uuid").Return([]*model.Chat{
+					{UUID: "grandchild-uuid", ParentUUID: &childUUID, Status: "open"},
+					{UUID: "merged-grandchild-uuid", ParentUUID: &childUUID, Status: "merged"},
+					{UUID: "closed-grandchild-uuid", ParentUUID: &childUUID, Status: "closed"},
+				}, nil)
+				m.chatRepo.On("FindByParentUUID", mock.Anything, "grandchild-uuid").Return([]*model.Chat{}, nil)
+				m.chatRepo.On("FindByParentUUID", mock.Anything, "merged-grandchild-uuid").Return([]*model.Chat{}, nil)
+				m.chatRepo.On("FindByParentUUID", mock.Anything, "closed-grandchild-uuid").Return([]*model.Chat{}, nil)
+
+				m.transactionManager.On("Do", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(context.Context) error)
+					fn(context.Background())
+				})
+				// レポートはルートのチャットに追加し、ルートから派生したメッセージに紐付ける
+				m.messageRepo.On("Create", mock.Anything, mock.MatchedBy(func(msg *model.Message) bool {
+					return msg.ChatUUID == "root-chat-uuid" && *msg.ParentMessageUUID == "root-msg-uuid"
+				})).Return(nil)
+				m.chatRepo.On("TransitionStatus", mock.Anything, "child-chat-uuid", "open", "merged").Return(true, nil)
+				m.chatRepo.On("TransitionStatus", mock.Anything, "grandchild-uuid", "open", "merged").Return(true, nil)
+				m.chatMergeEventRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *model.ChatMergeEvent) bool {
+					return e.ChatUUID == "child-chat-uuid" && e.ParentChatUUID == "root-chat-uuid" && e.PreviousStatus == "open"
+				})).Return(nil)
+				m.chatMergeEventRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *model.ChatMergeEvent) bool {
+					return e.ChatUUID == "grandchild-uuid" && e.ParentChatUUID == "root-chat-uuid" && e.PreviousStatus == "open"
+				})).Return(nil)
+				m.chatRepo.On("FindByID", mock.Anything, "root-chat-uuid").Return(&model.Chat{UUID: rootUUID, Status: "open"}, nil)
+				m.publisher.On("Publish", "chat_summary", mock.Anything).Return(nil)
+			},
+			want: &model.MergeChatResult{
+				SummaryContent:  "summary content",
+				TargetChatUUID:  "root-chat-uuid",
+				MergedChatUUIDs: []string{"child-chat-uuid", "grandchild-uuid"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常系: 他の操作でステータスが変更された子孫のチャットはマージ対象から外れること",
+			args: args{
+				chatUUID: "child-chat-uuid",
+				params: model.MergeChatParams{
+					ParentChatUUID:     "parent-chat-uuid",
+					SummaryContent:     "summary content",
+					IncludeDescendants: true,
+				},
+			},
+			setupMock: func(m *mocks) {
+				parentUUID := "parent-chat-uuid"
+				childUUID := "child-chat-uuid"
+				sourceMsgUUID := "source-msg-uuid"
+				m.chatRepo.On("FindByID", mock.Anything, "child-chat-uuid").Return(&model.Chat{
+					UUID:              childUUID,
+					ParentUUID:        &parentUUID,
+					Status:            "open",
+					SourceMessageUUID: &sourceMsgUUID,
+				}, nil)
+				m.chatRepo.On("FindByParentUUID", mock.Anything, "child-chat-uuid").Return([]*model.Chat{
+					{UUID: "grandchild-uuid", ParentUUID: &childUUID, Status: "open"},
+				}, nil)
+				m.chatRepo.On("FindByParentUUID", mock.Anything, "grandchild-uuid").Return([]*model.Chat{}, nil)
+				m.transactionManager.On("Do", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(context.Context) error)
+					fn(context.Background())
+				})
+				m.messageRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				m.chatRepo.On("TransitionStatus", mock.Anything, "child-chat-uuid", "open", "merged").Return(true, nil)
+				m.chatRepo.On("TransitionStatus", mock.Anything, "grandchild-uuid", "open", "merged").Return(false, nil)
+				m.chatMergeEventRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *model.ChatMergeEvent) bool {
+					return e.ChatUUID == "child-chat-uuid"
+				})).Return(nil)
+				m.chatRepo.On("FindByID", mock.Anything, "parent-chat-uuid").Return(&model.Chat{UUID: parentUUID, Status: "open"}, nil)
+				m.publisher.On("Publish", "chat_summary", mock.Anything).Return(nil)
 			},
 			want: &model.MergeChatResult{
-				SummaryContent: "summary content",
+				SummaryContent:  "summary content",
+				TargetChatUUID:  "parent-chat-uuid",
+				MergedChatUUIDs: []string{"child-chat-uuid"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常系: 他の操作でステータスが変更されていた場合",
+			args: args{
+				chatUUID: "child-chat-uuid",
+				params: model.MergeChatParams{
+					ParentChatUUID: "parent-chat-uuid",
+					SummaryContent: "summary content",
+				},
+			},
+			setupMock: func(m *mocks) {
+				parentUUID := "parent-chat-uuid"
+				sourceMsgUUID := "source-msg-uuid"
+				m.chatRepo.On("FindByID", mock.Anything, "child-chat-uuid").Return(&model.Chat{
+					UUID:              "child-chat-uuid",
+					ParentUUID:        &parentUUID,
+					Status:            "open",
+					SourceMessageUUID: &sourceMsgUUID,
+				}, nil)
+				m.chatRepo.On("FindByID", mock.Anything, "parent-chat-uuid").Return(&model.Chat{UUID: parentUUID, Status: "open"}, nil)
+				m.transactionManager.On("Do", mock.Anything, mock.Anything).Return(model.ErrInvalidChatStatusTransition).Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(context.Context) error)
+					fn(context.Background())
+				})
+				m.messageRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				m.chatRepo.On("TransitionStatus", mock.Anything, "child-chat-uuid", "open", "merged").Return(false, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "異常系: マージ先が祖先のチャットではない場合",
+			args: args{
+				chatUUID: "child-chat-uuid",
+				params: model.MergeChatParams{
+					ParentChatUUID: "sibling-chat-uuid",
+				},
+			},
+			setupMock: func(m *mocks) {
+				rootUUID := "root-chat-uuid"
+				parentUUID := "parent-chat-uuid"
+				sourceMsgUUID := "source-msg-uuid"
+				m.chatRepo.On("FindByID", mock.Anything, "child-chat-uuid").Return(&model.Chat{
+					UUID:              "child-chat-uuid",
+					ParentUUID:        &parentUUID,
+					Status:            "open",
+					SourceMessageUUID: &sourceMsgUUID,
+				}, nil)
+				m.chatRepo.On("FindByID", mock.Anything, "parent-chat-uuid").Return(&model.Chat{
+					UUID:       "parent-chat-uuid",
+					ParentUUID: &rootUUID,
+				}, nil)
+				m.chatRepo.On("FindByID", mock.Anything, "root-chat-uuid").Return(&model.Chat{
+					UUID: "root-chat-uuid",
+				}, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "異常系: 子チャット取得失敗",
+			args: args{
+				chatUUID: "child-chat-uuid",
+				params: model.MergeChatParams{
+					ParentChatUUID: "parent-chat-uuid",
+				},
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "child-chat-uuid").Return(nil, errors.New("db error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "異常系: ソースメッセージがない場合",
+			args: args{
+				chatUUID: "child-chat-uuid",
+				params: model.MergeChatParams{
+					ParentChatUUID: "parent-chat-uuid",
+				},
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "child-chat-uuid").Return(&model.Chat{
+					UUID:              "child-chat-uuid",
+					SourceMessageUUID: nil,
+				}, nil)
 			},
-			wantErr: false,
+			want:    nil,
+			wantErr: true,
 		},
 		{
-			name: "異常系: 子チャット取得失敗",
+			name: "異常系: トランザクションエラー",
 			args: args{
 				chatUUID: "child-chat-uuid",
 				params: model.MergeChatParams{
 					ParentChatUUID: "parent-chat-uuid",
+					SummaryContent: "summary content",
 				},
 			},
 			setupMock: func(m *mocks) {
-				m.chatRepo.On("FindByID", mock.Anything, "child-chat-uuid").Return(nil, errors.New("db error"))
+				sourceMsgUUID := "source-msg-uuid"
+				parentUUID := "parent-chat-uuid"
+				m.chatRepo.On("FindByID", mock.Anything, "child-chat-uuid").Return(&model.Chat{
+					UUID:              "child-chat-uuid",
+					ParentUUID:        &parentUUID,
+					Status:            "open",
+					SourceMessageUUID: &sourceMsgUUID,
+				}, nil)
+				m.chatRepo.On("FindByID", mock.Anything, "parent-chat-uuid").Return(&model.Chat{UUID: "parent-chat-uuid", Status: "open"}, nil)
+
+				m.transactionManager.On("Do", mock.Anything, mock.Anything).Return(errors.New("tx error"))
 			},
 			want:    nil,
 			wantErr: true,
 		},
 		{
-			name: "異常系: ソースメッセージがない場合",
+			name: "異常系: クローズされた子チャットはマージできないこと",
 			args: args{
 				chatUUID: "child-chat-uuid",
 				params: model.MergeChatParams{
-					ParentChatUUID: "parent-chat-uuid",
+					SummaryContent: "summary content",
 				},
 			},
 			setupMock: func(m *mocks) {
+				sourceMsgUUID := "source-msg-uuid"
+				parentUUID := "parent-chat-uuid"
 				m.chatRepo.On("FindByID", mock.Anything, "child-chat-uuid").Return(&model.Chat{
 					UUID:              "child-chat-uuid",
-					SourceMessageUUID: nil,
+					ParentUUID:        &parentUUID,
+					Status:            "closed",
+					SourceMessageUUID: &sourceMsgUUID,
 				}, nil)
 			},
 			want:    nil,
 			wantErr: true,
 		},
 		{
-			name: "異常系: トランザクションエラー",
+			name: "異常系: マージ先がクローズされている場合はレポートを追加しないこと",
 			args: args{
 				chatUUID: "child-chat-uuid",
 				params: model.MergeChatParams{
-					ParentChatUUID: "parent-chat-uuid",
 					SummaryContent: "summary content",
 				},
 			},
 			setupMock: func(m *mocks) {
 				sourceMsgUUID := "source-msg-uuid"
+				parentUUID := "parent-chat-uuid"
 				m.chatRepo.On("FindByID", mock.Anything, "child-chat-uuid").Return(&model.Chat{
 					UUID:              "child-chat-uuid",
+					ParentUUID:        &parentUUID,
+					Status:            "open",
 					SourceMessageUUID: &sourceMsgUUID,
 				}, nil)
-
-				m.transactionManager.On("Do", mock.Anything, mock.Anything).Return(errors.New("tx error"))
+				m.chatRepo.On("FindByID", mock.Anything, "parent-chat-uuid").Return(&model.Chat{UUID: "parent-chat-uuid", Status: "closed"}, nil)
 			},
 			want:    nil,
 			wantErr: true,
@@ -1354,13 +3424,18 @@ func TestChatUsecase_MergeChat(t *testing.T) {
 				messageRepo:          &MockMessageRepository{},
 				messageSelectionRepo: &MockMessageSelectionRepository{},
 				edgeRepo:             &mockEdgeRepository{},
+				chatMergeEventRepo:   &MockChatMergeEventRepository{},
+				projectRepo:          &mockProjectRepository{},
+				userRepo:             &mockUserRepository{},
 				transactionManager:   &MockTransactionManager{},
 				genaiClient:          &MockGenAIClient{},
 				publisher:            &MockPublisher{},
+				promptRenderer:       &MockPromptRenderer{},
 			}
 			tt.setupMock(m)
+			setupDefaultPrompt(m.projectRepo, m.userRepo, m.promptRenderer)
 
-			u := NewChatUsecase(m.chatRepo, m.messageRepo, m.messageSelectionRepo, m.edgeRepo, m.transactionManager, m.genaiClient, m.publisher)
+			u := NewChatUsecase(m.chatRepo, m.messageRepo, m.messageSelectionRepo, m.edgeRepo, m.chatMergeEventRepo, &MockBranchComparisonRepository{}, &MockForkSuggestionRepository{}, m.projectRepo, m.userRepo, m.transactionManager, m.genaiClient, m.publisher, m.promptRenderer, nil)
 
 			got, err := u.MergeChat(context.Background(), tt.args.chatUUID, tt.args.params)
 			if (err != nil) != tt.wantErr {
@@ -1369,8 +3444,353 @@ func TestChatUsecase_MergeChat(t *testing.T) {
 			}
 			if !tt.wantErr {
 				assert.Equal(t, tt.want.SummaryContent, got.SummaryContent)
+				assert.Equal(t, tt.want.TargetChatUUID, got.TargetChatUUID)
+				assert.Equal(t, tt.want.MergedChatUUIDs, got.MergedChatUUIDs)
+				assert.Equal(t, tt.want.AcknowledgmentMessageID, got.AcknowledgmentMessageID)
 				assert.NotEmpty(t, got.ReportMessageID)
+				m.chatRepo.AssertExpectations(t)
+				m.chatMergeEventRepo.AssertExpectations(t)
+			} else {
+				assert.Nil(t, got)
+			}
+		})
+	}
+}
+
+func TestChatUsecase_UnmergeChat(t *testing.T) {
+	type mocks struct {
+		chatRepo           *MockChatRepository
+		messageRepo        *MockMessageRepository
+		chatMergeEventRepo *MockChatMergeEventRepository
+		transactionManager *MockTransactionManager
+	}
+	childUUID := "child-chat-uuid"
+	base := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	mergedChat := &model.Chat{UUID: childUUID, Status: "merged"}
+	mergedEvent := &model.ChatMergeEvent{
+		ChatUUID:          childUUID,
+		ParentChatUUID:    "parent-chat-uuid",
+		ReportMessageUUID: "report-uuid",
+		Action:            model.ChatMergeActionMerged,
+		PreviousStatus:    "closed",
+	}
+	parentMessages := func(withLaterAnswer bool) []*model.Message {
+		msgs := []*model.Message{
+			{UUID: "msg-1", ChatUUID: "parent-chat-uuid", Role: "assistant", CreatedAt: base},
+			{UUID: "report-uuid", ChatUUID: "parent-chat-uuid", Role: "merge_report", SourceChatUUID: &childUUID, CreatedAt: base.Add(time.Minute)},
+		}
+		if withLaterAnswer {
+			msgs = append(msgs, &model.Message{UUID: "msg-2", ChatUUID: "parent-chat-uuid", Role: "assistant", CreatedAt: base.Add(2 * time.Minute)})
+		}
+		return msgs
+	}
+	runTx := func(m *mocks) {
+		m.transactionManager.On("Do", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			fn := args.Get(1).(func(context.Context) error)
+			fn(context.Background())
+		})
+	}
+	tests := []struct {
+		name       string
+		params     model.UnmergeChatParams
+		setupMock  func(m *mocks)
+		want       *model.UnmergeChatResult
+		wantErrIs  error
+		wantAnyErr bool
+	}{
+		{
+			name:   "正常系: マージ前のステータスに戻してレポートを削除すること",
+			params: model.UnmergeChatParams{UserUUID: "user-uuid"},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, childUUID).Return(mergedChat, nil)
+				m.chatMergeEventRepo.On("FindLatestByChatUUID", mock.Anything, childUUID, model.ChatMergeActionMerged).Return(mergedEvent, nil)
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "parent-chat-uuid").Return(parentMessages(false), nil)
+				m.chatMergeEventRepo.On("FindByReportMessageUUID", mock.Anything, "report-uuid").Return([]*model.ChatMergeEvent{mergedEvent}, nil)
+				runTx(m)
+				m.messageRepo.On("Delete", mock.Anything, "report-uuid").Return(nil)
+				m.chatRepo.On("UpdateStatus", mock.Anything, childUUID, "closed").Return(nil)
+				m.chatMergeEventRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *model.ChatMergeEvent) bool {
+					return e.Action == model.ChatMergeActionUnmerged && e.ReportMessageUUID == "report-uuid" && !e.Forced && *e.UserUUID == "user-uuid"
+				})).Return(nil)
+			},
+			want: &model.UnmergeChatResult{ChatUUID: childUUID, Status: "closed", ReportMessageUUID: "report-uuid", RestoredChatUUIDs: []string{childUUID}},
+		},
+		{
+			name:   "正常系: 部分木ごとマージされた子孫のチャットもまとめて元に戻すこと",
+			params: model.UnmergeChatParams{},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, childUUID).Return(mergedChat, nil)
+				m.chatMergeEventRepo.On("FindLatestByChatUUID", mock.Anything, childUUID, model.ChatMergeActionMerged).Return(mergedEvent, nil)
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "parent-chat-uuid").Return(parentMessages(false), nil)
+				m.chatMergeEventRepo.On("FindByReportMessageUUID", mock.Anything, "report-uuid").Return([]*model.ChatMergeEvent{
+					mergedEvent,
+					{ChatUUID: "grandchild-uuid", ReportMessageUUID: "report-uuid", Action: model.ChatMergeActionMerged, PreviousStatus: "open"},
+					// 既に個別に開き直されたチャットはそのままにする
+					{ChatUUID: "reopened-uuid", ReportMessageUUID: "report-uuid", Action: model.ChatMergeActionMerged, PreviousStatus: "open"},
+				}, nil)
+				m.chatRepo.On("FindByID", mock.Anything, "grandchild-uuid").Return(&model.Chat{UUID: "grandchild-uuid", Status: "merged"}, nil)
+				m.chatRepo.On("FindByID", mock.Anything, "reopened-uuid").Return(&model.Chat{UUID: "reopened-uuid", Status: "open"}, nil)
+				runTx(m)
+				m.messageRepo.On("Delete", mock.Anything, "report-uuid").Return(nil)
+				m.chatRepo.On("UpdateStatus", mock.Anything, childUUID, "closed").Return(nil)
+				m.chatRepo.On("UpdateStatus", mock.Anything, "grandchild-uuid", "open").Return(nil)
+				m.chatMergeEventRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *model.ChatMergeEvent) bool {
+					return e.Action == model.ChatMergeActionUnmerged && e.ChatUUID == childUUID
+				})).Return(nil)
+				m.chatMergeEventRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *model.ChatMergeEvent) bool {
+					return e.Action == model.ChatMergeActionUnmerged && e.ChatUUID == "grandchild-uuid"
+				})).Return(nil)
+			},
+			want: &model.UnmergeChatResult{ChatUUID: childUUID, Status: "closed", ReportMessageUUID: "report-uuid", RestoredChatUUIDs: []string{childUUID, "grandchild-uuid"}},
+		},
+		{
+			name:   "正常系: 強制指定ならレポートを参照した回答があっても取り消せること",
+			params: model.UnmergeChatParams{Force: true},
+			setupMock: func(m *mocks) {
+				// 履歴がない場合は親チャットにマージされたものとみなして open に戻す
+				parentUUID := "parent-chat-uuid"
+				m.chatRepo.On("FindByID", mock.Anything, childUUID).Return(&model.Chat{UUID: childUUID, Status: "merged", ParentUUID: &parentUUID}, nil)
+				m.chatMergeEventRepo.On("FindLatestByChatUUID", mock.Anything, childUUID, model.ChatMergeActionMerged).Return(nil, nil)
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "parent-chat-uuid").Return(parentMessages(true), nil)
+				m.chatMergeEventRepo.On("FindByReportMessageUUID", mock.Anything, "report-uuid").Return([]*model.ChatMergeEvent{}, nil)
+				runTx(m)
+				m.messageRepo.On("Delete", mock.Anything, "report-uuid").Return(nil)
+				m.chatRepo.On("UpdateStatus", mock.Anything, childUUID, "open").Return(nil)
+				m.chatMergeEventRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *model.ChatMergeEvent) bool {
+					return e.Action == model.ChatMergeActionUnmerged && e.Forced && e.UserUUID == nil
+				})).Return(nil)
+			},
+			want: &model.UnmergeChatResult{ChatUUID: childUUID, Status: "open", ReportMessageUUID: "report-uuid", RestoredChatUUIDs: []string{childUUID}},
+		},
+		{
+			name: "異常系: マージされていないチャットはエラーになること",
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, childUUID).Return(&model.Chat{UUID: childUUID, Status: "open"}, nil)
+			},
+			wantErrIs: model.ErrChatNotMerged,
+		},
+		{
+			name: "異常系: レポートを参照した回答がある場合はエラーになること",
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, childUUID).Return(mergedChat, nil)
+				m.chatMergeEventRepo.On("FindLatestByChatUUID", mock.Anything, childUUID, model.ChatMergeActionMerged).Return(mergedEvent, nil)
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "parent-chat-uuid").Return(parentMessages(true), nil)
+			},
+			wantErrIs: model.ErrMergeReportInUse,
+		},
+		{
+			name: "異常系: トランザクションエラー",
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, childUUID).Return(mergedChat, nil)
+				m.chatMergeEventRepo.On("FindLatestByChatUUID", mock.Anything, childUUID, model.ChatMergeActionMerged).Return(mergedEvent, nil)
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "parent-chat-uuid").Return(parentMessages(false), nil)
+				m.chatMergeEventRepo.On("FindByReportMessageUUID", mock.Anything, "report-uuid").Return([]*model.ChatMergeEvent{mergedEvent}, nil)
+				m.transactionManager.On("Do", mock.Anything, mock.Anything).Return(errors.New("tx error"))
+			},
+			wantAnyErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mocks{
+				chatRepo:           &MockChatRepository{},
+				messageRepo:        &MockMessageRepository{},
+				chatMergeEventRepo: &MockChatMergeEventRepository{},
+				transactionManager: &MockTransactionManager{},
+			}
+			tt.setupMock(m)
+
+			u := NewChatUsecase(m.chatRepo, m.messageRepo, &MockMessageSelectionRepository{}, &mockEdgeRepository{}, m.chatMergeEventRepo, &MockBranchComparisonRepository{}, &MockForkSuggestionRepository{}, &mockProjectRepository{}, &mockUserRepository{}, m.transactionManager, &MockGenAIClient{}, &MockPublisher{}, &MockPromptRenderer{}, nil)
+
+			got, err := u.UnmergeChat(context.Background(), childUUID, tt.params)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			if tt.wantAnyErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			m.chatRepo.AssertExpectations(t)
+			m.messageRepo.AssertExpectations(t)
+			m.chatMergeEventRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestChatUsecase_CompareBranches(t *testing.T) {
+	type mocks struct {
+		chatRepo             *MockChatRepository
+		messageRepo          *MockMessageRepository
+		branchComparisonRepo *MockBranchComparisonRepository
+		projectRepo          *mockProjectRepository
+		userRepo             *mockUserRepository
+		genaiClient          *MockGenAIClient
+		promptRenderer       *MockPromptRenderer
+	}
+	rootUUID := "root-chat"
+	branchAUUID := "branch-a"
+	rootMsg1 := "root-msg-1"
+	rootMsg2 := "root-msg-2"
+	branchMsg := "branch-a-msg"
+	chats := map[string]*model.Chat{
+		"root-chat": {UUID: "root-chat", ProjectUUID: "project-1", Title: "ルート"},
+		// root-msg-1 から分岐したブランチ
+		"branch-a": {UUID: "branch-a", ProjectUUID: "project-1", ParentUUID: &rootUUID, SourceMessageUUID: &rootMsg1, Title: "案A", ContextSummary: "Aを検討"},
+		"branch-b": {UUID: "branch-b", ProjectUUID: "project-1", ParentUUID: &rootUUID, SourceMessageUUID: &rootMsg1, Title: "案B", ContextSummary: "Bを検討"},
+		// root-msg-2 から分岐したブランチ
+		"branch-c": {UUID: "branch-c", ProjectUUID: "project-1", ParentUUID: &rootUUID, SourceMessageUUID: &rootMsg2, Title: "案C"},
+		// branch-a から分岐したブランチ
+		"branch-a-child": {UUID: "branch-a-child", ProjectUUID: "project-1", ParentUUID: &branchAUUID, SourceMessageUUID: &branchMsg, Title: "案Aの詳細"},
+		"other-root":     {UUID: "other-root", ProjectUUID: "project-1", Title: "別のルート"},
+		"other-project":  {UUID: "other-project", ProjectUUID: "project-2", Title: "別のプロジェクト"},
+	}
+	setupChats := func(m *mocks) {
+		for id, chat := range chats {
+			m.chatRepo.On("FindByID", mock.Anything, id).Return(chat, nil).Maybe()
+		}
+	}
+	setupGenerate := func(m *mocks) {
+		m.messageRepo.On("FindByID", mock.Anything, mock.Anything).Return(&model.Message{UUID: "anchor", Content: "起点"}, nil).Maybe()
+		m.messageRepo.On("FindLatestMessageWithSummary", mock.Anything, mock.Anything).Return(nil, nil)
+		m.messageRepo.On("FindMessagesByChatID", mock.Anything, "root-chat").Return([]*model.Message{
+			{UUID: "root-msg-1", Role: "user"},
+			{UUID: "root-msg-2", Role: "assistant"},
+		}, nil).Maybe()
+		m.messageRepo.On("FindMessagesByChatID", mock.Anything, mock.Anything).Return([]*model.Message{
+			{UUID: "answer", Role: "assistant", Content: "回答"},
+		}, nil)
+		m.genaiClient.On("GenerateContent", mock.Anything, "gemini-2.5-flash", mock.Anything, mock.Anything).Return(&genai.GenerateContentResponse{
+			Candidates: []*genai.Candidate{
+				{Content: &genai.Content{Parts: []*genai.Part{{Text: `{"chat_position": "Aを採用", "other_position": "Bを採用", "agreements": ["目的は同じ"], "disagreements": ["手段"], "recommendation": "Aを推奨"}`}}}},
+			},
+		}, nil)
+	}
+	strPtr := func(s string) *string { return &s }
+	tests := []struct {
+		name          string
+		chatUUID      string
+		params        model.CompareBranchesParams
+		setupMock     func(m *mocks)
+		wantAnchor    *string
+		wantAnchorIn  *string
+		wantSaved     bool
+		wantErrIs     error
+		wantAnyErr    bool
+		wantPositions []model.BranchPosition
+	}{
+		{
+			name:         "正常系: 同じメッセージから分岐したブランチを比較できること",
+			chatUUID:     "branch-a",
+			params:       model.CompareBranchesParams{OtherChatUUID: "branch-b"},
+			setupMock:    func(m *mocks) { setupChats(m); setupGenerate(m) },
+			wantAnchor:   strPtr("root-msg-1"),
+			wantAnchorIn: strPtr("root-chat"),
+			wantPositions: []model.BranchPosition{
+				{ChatUUID: "branch-a", Title: "案A", Position: "Aを採用"},
+				{ChatUUID: "branch-b", Title: "案B", Position: "Bを採用"},
+			},
+		},
+		{
+			name:         "正常系: 別々のメッセージから分岐した場合は先に分岐したメッセージを起点とすること",
+			chatUUID:     "branch-c",
+			params:       model.CompareBranchesParams{OtherChatUUID: "branch-a-child"},
+			setupMock:    func(m *mocks) { setupChats(m); setupGenerate(m) },
+			wantAnchor:   strPtr("root-msg-1"),
+			wantAnchorIn: strPtr("root-chat"),
+		},
+		{
+			name:         "正常系: 祖先のチャットと比較した場合は子孫が分岐したメッセージを起点とし、メモとして保存すること",
+			chatUUID:     "root-chat",
+			params:       model.CompareBranchesParams{UserUUID: "user-1", OtherChatUUID: "branch-a-child", SaveNote: true},
+			wantAnchor:   strPtr("root-msg-1"),
+			wantAnchorIn: strPtr("root-chat"),
+			wantSaved:    true,
+			setupMock: func(m *mocks) {
+				setupChats(m)
+				setupGenerate(m)
+				m.branchComparisonRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *model.BranchComparison) bool {
+					return c.UUID != "" && *c.AnchorMessageUUID == "root-msg-1" && *c.UserUUID == "user-1" && c.Recommendation == "Aを推奨"
+				})).Return(nil)
+			},
+		},
+		{
+			name:      "正常系: 共通の祖先がない場合は起点なしで比較できること",
+			chatUUID:  "branch-a",
+			params:    model.CompareBranchesParams{OtherChatUUID: "other-root"},
+			setupMock: func(m *mocks) { setupChats(m); setupGenerate(m) },
+		},
+		{
+			name:      "異常系: 共通の祖先がない場合はメモとして保存できないこと",
+			chatUUID:  "branch-a",
+			params:    model.CompareBranchesParams{OtherChatUUID: "other-root", SaveNote: true},
+			setupMock: func(m *mocks) { setupChats(m) },
+			wantErrIs: model.ErrNoCommonAncestor,
+		},
+		{
+			name:      "異常系: 別のプロジェクトのチャットとは比較できないこと",
+			chatUUID:  "branch-a",
+			params:    model.CompareBranchesParams{OtherChatUUID: "other-project"},
+			setupMock: func(m *mocks) { setupChats(m) },
+			wantErrIs: model.ErrInvalidComparisonTarget,
+		},
+		{
+			name:      "異常系: 同じチャットとは比較できないこと",
+			chatUUID:  "branch-a",
+			params:    model.CompareBranchesParams{OtherChatUUID: "branch-a"},
+			setupMock: func(m *mocks) {},
+			wantErrIs: model.ErrInvalidComparisonTarget,
+		},
+		{
+			name:     "異常系: 比較結果の保存に失敗した場合",
+			chatUUID: "branch-a",
+			params:   model.CompareBranchesParams{OtherChatUUID: "branch-b", SaveNote: true},
+			setupMock: func(m *mocks) {
+				setupChats(m)
+				setupGenerate(m)
+				m.branchComparisonRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("db error"))
+			},
+			wantAnyErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mocks{
+				chatRepo:             &MockChatRepository{},
+				messageRepo:          &MockMessageRepository{},
+				branchComparisonRepo: &MockBranchComparisonRepository{},
+				projectRepo:          &mockProjectRepository{},
+				userRepo:             &mockUserRepository{},
+				genaiClient:          &MockGenAIClient{},
+				promptRenderer:       &MockPromptRenderer{},
 			}
+			tt.setupMock(m)
+			setupDefaultPrompt(m.projectRepo, m.userRepo, m.promptRenderer)
+
+			u := NewChatUsecase(m.chatRepo, m.messageRepo, &MockMessageSelectionRepository{}, &mockEdgeRepository{}, &MockChatMergeEventRepository{}, m.branchComparisonRepo, &MockForkSuggestionRepository{}, m.projectRepo, m.userRepo, &MockTransactionManager{}, m.genaiClient, &MockPublisher{}, m.promptRenderer, nil)
+
+			got, err := u.CompareBranches(context.Background(), tt.chatUUID, tt.params)
+			if tt.wantErrIs != nil {
+				assert.ErrorIs(t, err, tt.wantErrIs)
+				return
+			}
+			if tt.wantAnyErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantAnchor, got.AnchorMessageUUID)
+			assert.Equal(t, tt.wantAnchorIn, got.AnchorChatUUID)
+			assert.Equal(t, tt.wantSaved, got.UUID != "")
+			assert.Equal(t, []string{"目的は同じ"}, got.Agreements)
+			assert.Equal(t, "Aを推奨", got.Recommendation)
+			assert.Equal(t, "prompt/ja/v1", got.PromptVersion)
+			if tt.wantPositions != nil {
+				assert.Equal(t, tt.wantPositions, got.Positions)
+			}
+			m.branchComparisonRepo.AssertExpectations(t)
 		})
 	}
 }
@@ -1381,18 +3801,23 @@ func TestChatUsecase_CloseChat(t *testing.T) {
 		messageRepo          *MockMessageRepository
 		messageSelectionRepo *MockMessageSelectionRepository
 		edgeRepo             *mockEdgeRepository
+		chatMergeEventRepo   *MockChatMergeEventRepository
+		projectRepo          *mockProjectRepository
+		userRepo             *mockUserRepository
 		transactionManager   *MockTransactionManager
 		genaiClient          *MockGenAIClient
 		publisher            *MockPublisher
+		promptRenderer       *MockPromptRenderer
 	}
 	type args struct {
 		chatUUID string
+		cascade  bool
 	}
 	tests := []struct {
 		name      string
 		args      args
 		setupMock func(m *mocks)
-		want      string
+		want      []string
 		wantErr   bool
 	}{
 		{
@@ -1401,12 +3826,58 @@ func TestChatUsecase_CloseChat(t *testing.T) {
 				chatUUID: "chat-uuid",
 			},
 			setupMock: func(m *mocks) {
-				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid"}, nil)
-				m.chatRepo.On("UpdateStatus", mock.Anything, "chat-uuid", "closed").Return(nil)
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: model.ChatStatusOpen}, nil)
+				m.transactionManager.On("Do", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(context.Context) error)
+					fn(context.Background())
+				})
+				m.chatRepo.On("TransitionStatus", mock.Anything, "chat-uuid", model.ChatStatusOpen, model.ChatStatusClosed).Return(true, nil)
 			},
-			want:    "chat-uuid",
+			want:    []string{"chat-uuid"},
+			wantErr: false,
+		},
+		{
+			name: "正常系: cascade を指定すると open の子孫のブランチのみクローズされること",
+			args: args{
+				chatUUID: "chat-uuid",
+				cascade:  true,
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: model.ChatStatusOpen}, nil)
+				m.chatRepo.On("FindByParentUUID", mock.Anything, "chat-uuid").Return([]*model.Chat{
+					{UUID: "open-child-uuid", Status: model.ChatStatusOpen},
+					{UUID: "merged-child-uuid", Status: model.ChatStatusMerged},
+				}, nil)
+				m.chatRepo.On("FindByParentUUID", mock.Anything, "open-child-uuid").Return([]*model.Chat{
+					{UUID: "closed-grandchild-uuid", Status: model.ChatStatusClosed},
+				}, nil)
+				m.chatRepo.On("FindByParentUUID", mock.Anything, "merged-child-uuid").Return([]*model.Chat{
+					{UUID: "open-grandchild-uuid", Status: model.ChatStatusOpen},
+				}, nil)
+				m.chatRepo.On("FindByParentUUID", mock.Anything, "closed-grandchild-uuid").Return([]*model.Chat{}, nil)
+				m.chatRepo.On("FindByParentUUID", mock.Anything, "open-grandchild-uuid").Return([]*model.Chat{}, nil)
+				m.transactionManager.On("Do", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(context.Context) error)
+					fn(context.Background())
+				})
+				m.chatRepo.On("TransitionStatus", mock.Anything, "chat-uuid", model.ChatStatusOpen, model.ChatStatusClosed).Return(true, nil)
+				m.chatRepo.On("TransitionStatus", mock.Anything, "open-child-uuid", model.ChatStatusOpen, model.ChatStatusClosed).Return(true, nil)
+				m.chatRepo.On("TransitionStatus", mock.Anything, "open-grandchild-uuid", model.ChatStatusOpen, model.ChatStatusClosed).Return(true, nil)
+			},
+			want:    []string{"chat-uuid", "open-child-uuid", "open-grandchild-uuid"},
 			wantErr: false,
 		},
+		{
+			name: "異常系: マージ済みのチャットはクローズできないこと",
+			args: args{
+				chatUUID: "chat-uuid",
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: model.ChatStatusMerged}, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "異常系: チャットが存在しない場合エラー",
 			args: args{
@@ -1415,7 +3886,7 @@ func TestChatUsecase_CloseChat(t *testing.T) {
 			setupMock: func(m *mocks) {
 				m.chatRepo.On("FindByID", mock.Anything, "non-existent").Return(nil, errors.New("not found"))
 			},
-			want:    "",
+			want:    nil,
 			wantErr: true,
 		},
 		{
@@ -1424,10 +3895,30 @@ func TestChatUsecase_CloseChat(t *testing.T) {
 				chatUUID: "chat-uuid",
 			},
 			setupMock: func(m *mocks) {
-				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid"}, nil)
-				m.chatRepo.On("UpdateStatus", mock.Anything, "chat-uuid", "closed").Return(errors.New("db error"))
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: model.ChatStatusOpen}, nil)
+				m.transactionManager.On("Do", mock.Anything, mock.Anything).Return(errors.New("db error")).Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(context.Context) error)
+					fn(context.Background())
+				})
+				m.chatRepo.On("TransitionStatus", mock.Anything, "chat-uuid", model.ChatStatusOpen, model.ChatStatusClosed).Return(false, errors.New("db error"))
 			},
-			want:    "",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "異常系: 他の操作でステータスが変更されていた場合エラー",
+			args: args{
+				chatUUID: "chat-uuid",
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: model.ChatStatusOpen}, nil)
+				m.transactionManager.On("Do", mock.Anything, mock.Anything).Return(model.ErrInvalidChatStatusTransition).Run(func(args mock.Arguments) {
+					fn := args.Get(1).(func(context.Context) error)
+					fn(context.Background())
+				})
+				m.chatRepo.On("TransitionStatus", mock.Anything, "chat-uuid", model.ChatStatusOpen, model.ChatStatusClosed).Return(false, nil)
+			},
+			want:    nil,
 			wantErr: true,
 		},
 	}
@@ -1438,15 +3929,20 @@ func TestChatUsecase_CloseChat(t *testing.T) {
 				messageRepo:          &MockMessageRepository{},
 				messageSelectionRepo: &MockMessageSelectionRepository{},
 				edgeRepo:             &mockEdgeRepository{},
+				chatMergeEventRepo:   &MockChatMergeEventRepository{},
+				projectRepo:          &mockProjectRepository{},
+				userRepo:             &mockUserRepository{},
 				transactionManager:   &MockTransactionManager{},
 				genaiClient:          &MockGenAIClient{},
 				publisher:            &MockPublisher{},
+				promptRenderer:       &MockPromptRenderer{},
 			}
 			tt.setupMock(m)
+			setupDefaultPrompt(m.projectRepo, m.userRepo, m.promptRenderer)
 
-			u := NewChatUsecase(m.chatRepo, m.messageRepo, m.messageSelectionRepo, m.edgeRepo, m.transactionManager, m.genaiClient, m.publisher)
+			u := NewChatUsecase(m.chatRepo, m.messageRepo, m.messageSelectionRepo, m.edgeRepo, m.chatMergeEventRepo, &MockBranchComparisonRepository{}, &MockForkSuggestionRepository{}, m.projectRepo, m.userRepo, m.transactionManager, m.genaiClient, m.publisher, m.promptRenderer, nil)
 
-			got, err := u.CloseChat(context.Background(), tt.args.chatUUID)
+			got, err := u.CloseChat(context.Background(), tt.args.chatUUID, tt.args.cascade)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("chatUsecase.CloseChat() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -1464,9 +3960,13 @@ func TestChatUsecase_OpenChat(t *testing.T) {
 		messageRepo          *MockMessageRepository
 		messageSelectionRepo *MockMessageSelectionRepository
 		edgeRepo             *mockEdgeRepository
+		chatMergeEventRepo   *MockChatMergeEventRepository
+		projectRepo          *mockProjectRepository
+		userRepo             *mockUserRepository
 		transactionManager   *MockTransactionManager
 		genaiClient          *MockGenAIClient
 		publisher            *MockPublisher
+		promptRenderer       *MockPromptRenderer
 	}
 	type args struct {
 		chatUUID string
@@ -1484,8 +3984,8 @@ func TestChatUsecase_OpenChat(t *testing.T) {
 				chatUUID: "chat-uuid",
 			},
 			setupMock: func(m *mocks) {
-				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid"}, nil)
-				m.chatRepo.On("UpdateStatus", mock.Anything, "chat-uuid", "open").Return(nil)
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: model.ChatStatusClosed}, nil)
+				m.chatRepo.On("TransitionStatus", mock.Anything, "chat-uuid", model.ChatStatusClosed, model.ChatStatusOpen).Return(true, nil)
 			},
 			want:    "chat-uuid",
 			wantErr: false,
@@ -1501,14 +4001,37 @@ func TestChatUsecase_OpenChat(t *testing.T) {
 			want:    "",
 			wantErr: true,
 		},
+		{
+			name: "異常系: マージ済みのチャットはマージを取り消さないと再開できないこと",
+			args: args{
+				chatUUID: "chat-uuid",
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: model.ChatStatusMerged}, nil)
+			},
+			want:    "",
+			wantErr: true,
+		},
 		{
 			name: "異常系: ステータス更新に失敗した場合エラー",
 			args: args{
 				chatUUID: "chat-uuid",
 			},
 			setupMock: func(m *mocks) {
-				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid"}, nil)
-				m.chatRepo.On("UpdateStatus", mock.Anything, "chat-uuid", "open").Return(errors.New("db error"))
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: model.ChatStatusClosed}, nil)
+				m.chatRepo.On("TransitionStatus", mock.Anything, "chat-uuid", model.ChatStatusClosed, model.ChatStatusOpen).Return(false, errors.New("db error"))
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "異常系: 他の操作でステータスが変更されていた場合エラー",
+			args: args{
+				chatUUID: "chat-uuid",
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: model.ChatStatusClosed}, nil)
+				m.chatRepo.On("TransitionStatus", mock.Anything, "chat-uuid", model.ChatStatusClosed, model.ChatStatusOpen).Return(false, nil)
 			},
 			want:    "",
 			wantErr: true,
@@ -1521,13 +4044,18 @@ func TestChatUsecase_OpenChat(t *testing.T) {
 				messageRepo:          &MockMessageRepository{},
 				messageSelectionRepo: &MockMessageSelectionRepository{},
 				edgeRepo:             &mockEdgeRepository{},
+				chatMergeEventRepo:   &MockChatMergeEventRepository{},
+				projectRepo:          &mockProjectRepository{},
+				userRepo:             &mockUserRepository{},
 				transactionManager:   &MockTransactionManager{},
 				genaiClient:          &MockGenAIClient{},
 				publisher:            &MockPublisher{},
+				promptRenderer:       &MockPromptRenderer{},
 			}
 			tt.setupMock(m)
+			setupDefaultPrompt(m.projectRepo, m.userRepo, m.promptRenderer)
 
-			u := NewChatUsecase(m.chatRepo, m.messageRepo, m.messageSelectionRepo, m.edgeRepo, m.transactionManager, m.genaiClient, m.publisher)
+			u := NewChatUsecase(m.chatRepo, m.messageRepo, m.messageSelectionRepo, m.edgeRepo, m.chatMergeEventRepo, &MockBranchComparisonRepository{}, &MockForkSuggestionRepository{}, m.projectRepo, m.userRepo, m.transactionManager, m.genaiClient, m.publisher, m.promptRenderer, nil)
 
 			got, err := u.OpenChat(context.Background(), tt.args.chatUUID)
 			if (err != nil) != tt.wantErr {
@@ -1540,3 +4068,125 @@ func TestChatUsecase_OpenChat(t *testing.T) {
 		})
 	}
 }
+
+func TestChatUsecase_resolveLanguage(t *testing.T) {
+	tests := []struct {
+		name      string
+		setupMock func(projectRepo *mockProjectRepository, userRepo *mockUserRepository)
+		want      string
+	}{
+		{
+			name: "正常系: プロジェクトの言語が優先されること",
+			setupMock: func(projectRepo *mockProjectRepository, userRepo *mockUserRepository) {
+				projectRepo.On("FindByUUID", mock.Anything, "project-uuid").Return(&model.Project{UUID: "project-uuid", UserUUID: "user-uuid", Language: "en"}, nil)
+			},
+			want: "en",
+		},
+		{
+			name: "正常系: プロジェクトに言語がない場合はユーザーの優先言語が使われること",
+			setupMock: func(projectRepo *mockProjectRepository, userRepo *mockUserRepository) {
+				projectRepo.On("FindByUUID", mock.Anything, "project-uuid").Return(&model.Project{UUID: "project-uuid", UserUUID: "user-uuid"}, nil)
+				userRepo.On("FindByUUID", mock.Anything, "user-uuid").Return(&model.User{UUID: "user-uuid", Language: "en"}, nil)
+			},
+			want: "en",
+		},
+		{
+			name: "正常系: どちらも未設定の場合は既定の言語が使われること",
+			setupMock: func(projectRepo *mockProjectRepository, userRepo *mockUserRepository) {
+				projectRepo.On("FindByUUID", mock.Anything, "project-uuid").Return(&model.Project{UUID: "project-uuid", UserUUID: "user-uuid"}, nil)
+				userRepo.On("FindByUUID", mock.Anything, "user-uuid").Return(&model.User{UUID: "user-uuid"}, nil)
+			},
+			want: "ja",
+		},
+		{
+			name: "異常系: ユーザー取得に失敗した場合は既定の言語が使われること",
+			setupMock: func(projectRepo *mockProjectRepository, userRepo *mockUserRepository) {
+				projectRepo.On("FindByUUID", mock.Anything, "project-uuid").Return(&model.Project{UUID: "project-uuid", UserUUID: "user-uuid"}, nil)
+				userRepo.On("FindByUUID", mock.Anything, "user-uuid").Return(nil, errors.New("db error"))
+			},
+			want: "ja",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectRepo := &mockProjectRepository{}
+			userRepo := &mockUserRepository{}
+			promptRenderer := &MockPromptRenderer{}
+			promptRenderer.On("DefaultLanguage").Return("ja").Maybe()
+			tt.setupMock(projectRepo, userRepo)
+
+			u := &chatUsecase{projectRepo: projectRepo, userRepo: userRepo, promptRenderer: promptRenderer}
+			got := u.resolveLanguage(context.Background(), &model.Chat{ProjectUUID: "project-uuid"})
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestChatUsecase_GenerationLock(t *testing.T) {
+	t.Run("正常系: 取得したときと同じ識別子でロックを解放すること", func(t *testing.T) {
+		chatRepo := new(MockChatRepository)
+		var owner string
+		chatRepo.On("AcquireGenerationLock", mock.Anything, "chat-uuid", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			owner = args.String(2)
+		}).Return(true, nil)
+		chatRepo.On("ReleaseGenerationLock", mock.Anything, "chat-uuid", mock.MatchedBy(func(got string) bool {
+			return got != "" && got == owner
+		})).Return(nil)
+
+		u := &chatUsecase{chatRepo: chatRepo}
+		lock, err := u.lockGeneration(context.Background(), "chat-uuid")
+		assert.NoError(t, err)
+		u.releaseGenerationLock(context.Background(), lock)
+		chatRepo.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name       string
+		renewedAgo time.Duration
+		setupMock  func(chatRepo *MockChatRepository)
+		wantErr    error
+		wantRenew  bool
+	}{
+		{
+			name:       "正常系: 前回の延長から間隔が空いていない場合は延長しないこと",
+			renewedAgo: 0,
+			setupMock:  func(chatRepo *MockChatRepository) {},
+		},
+		{
+			name:       "正常系: 間隔が空いた場合は有効期限を延長すること",
+			renewedAgo: chatGenerationLockRenewInterval,
+			setupMock: func(chatRepo *MockChatRepository) {
+				chatRepo.On("ExtendGenerationLock", mock.Anything, "chat-uuid", "owner", mock.Anything).Return(true, nil).Once()
+			},
+			wantRenew: true,
+		},
+		{
+			name:       "異常系: 期限切れの後に別の生成がロックを取得していた場合はエラー",
+			renewedAgo: chatGenerationLockTTL,
+			setupMock: func(chatRepo *MockChatRepository) {
+				chatRepo.On("ExtendGenerationLock", mock.Anything, "chat-uuid", "owner", mock.Anything).Return(false, nil).Once()
+			},
+			wantErr: model.ErrChatGenerationInProgress,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chatRepo := new(MockChatRepository)
+			tt.setupMock(chatRepo)
+			renewedAt := time.Now().Add(-tt.renewedAgo)
+			lock := &generationLock{chatUUID: "chat-uuid", owner: "owner", renewedAt: renewedAt}
+
+			u := &chatUsecase{chatRepo: chatRepo}
+			err := u.extendGenerationLock(context.Background(), lock)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantRenew, lock.renewedAt.After(renewedAt))
+			chatRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"backend/internal/domain/model"
+	"backend/internal/domain/repository"
+	"backend/internal/domain/usecase"
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+type collaborationUsecase struct {
+	eventHub usecase.ChatEventHub
+	chatRepo repository.ChatRepository
+	userRepo repository.UserRepository
+}
+
+// CollaborationUsecase の新しいインスタンスを作成する処理
+func NewCollaborationUsecase(eventHub usecase.ChatEventHub, chatRepo repository.ChatRepository, userRepo repository.UserRepository) usecase.CollaborationUsecase {
+	return &collaborationUsecase{
+		eventHub: eventHub,
+		chatRepo: chatRepo,
+		userRepo: userRepo,
+	}
+}
+
+// チャットのイベントを購読する処理
+// 購読中は閲覧者として他のメンバーに表示される
+func (u *collaborationUsecase) Watch(ctx context.Context, chatUUID string, userUUID string) (<-chan model.ChatEvent, func(), error) {
+	slog.InfoContext(ctx, "チャットイベント購読処理を開始", "chat_uuid", chatUUID, "user_uuid", userUUID)
+	chat, err := u.chatRepo.FindByID(ctx, chatUUID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("チャットの取得に失敗: %w", err)
+	}
+	user, err := u.userRepo.FindByUUID(ctx, userUUID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ユーザーの取得に失敗: %w", err)
+	}
+
+	events, unsubscribe := u.eventHub.Subscribe(model.ChatPresence{
+		ProjectUUID: chat.ProjectUUID,
+		ChatUUID:    chatUUID,
+		UserUUID:    userUUID,
+		UserName:    user.Name,
+	})
+	return events, unsubscribe, nil
+}
+
+// 入力中の状態を更新する処理 (チャットを購読していない場合は何もしない)
+func (u *collaborationUsecase) SetTyping(ctx context.Context, chatUUID string, userUUID string, typing bool) error {
+	if !u.eventHub.SetTyping(chatUUID, userUUID, typing) {
+		slog.DebugContext(ctx, "チャットを購読していないため入力中の状態を更新しません", "chat_uuid", chatUUID, "user_uuid", userUUID)
+	}
+	return nil
+}
+
+// チャットの閲覧者一覧を取得する処理
+func (u *collaborationUsecase) GetChatPresence(ctx context.Context, chatUUID string) ([]*model.ChatPresence, error) {
+	return u.eventHub.ChatPresence(chatUUID), nil
+}
+
+// プロジェクト内で誰がどのチャットを閲覧しているかを取得する処理
+func (u *collaborationUsecase) GetProjectPresence(ctx context.Context, projectUUID string) ([]*model.ChatPresence, error) {
+	return u.eventHub.ProjectPresence(projectUUID), nil
+}
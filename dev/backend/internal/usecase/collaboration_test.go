@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"backend/internal/domain/model"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockChatEventHub struct {
+	mock.Mock
+}
+
+func (m *mockChatEventHub) Subscribe(viewer model.ChatPresence) (<-chan model.ChatEvent, func()) {
+	args := m.Called(viewer)
+	return args.Get(0).(<-chan model.ChatEvent), args.Get(1).(func())
+}
+
+func (m *mockChatEventHub) Publish(event model.ChatEvent) {
+	m.Called(event)
+}
+
+func (m *mockChatEventHub) SetTyping(chatUUID string, userUUID string, typing bool) bool {
+	args := m.Called(chatUUID, userUUID, typing)
+	return args.Bool(0)
+}
+
+func (m *mockChatEventHub) ChatPresence(chatUUID string) []*model.ChatPresence {
+	args := m.Called(chatUUID)
+	return args.Get(0).([]*model.ChatPresence)
+}
+
+func (m *mockChatEventHub) ProjectPresence(projectUUID string) []*model.ChatPresence {
+	args := m.Called(projectUUID)
+	return args.Get(0).([]*model.ChatPresence)
+}
+
+func TestCollaborationUsecase_Watch(t *testing.T) {
+	tests := []struct {
+		name      string
+		setupMock func(hub *mockChatEventHub, chatRepo *mockChatRepository, userRepo *mockUserRepository)
+		wantErr   bool
+	}{
+		{
+			name: "正常系: プロジェクトとユーザー名を付けて閲覧者として登録されること",
+			setupMock: func(hub *mockChatEventHub, chatRepo *mockChatRepository, userRepo *mockUserRepository) {
+				chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", ProjectUUID: "project-uuid"}, nil)
+				userRepo.On("FindByUUID", mock.Anything, "user-uuid").Return(&model.User{UUID: "user-uuid", Name: "alice"}, nil)
+				hub.On("Subscribe", model.ChatPresence{ProjectUUID: "project-uuid", ChatUUID: "chat-uuid", UserUUID: "user-uuid", UserName: "alice"}).
+					Return((<-chan model.ChatEvent)(make(chan model.ChatEvent)), func() {})
+			},
+		},
+		{
+			name: "異常系: チャットが存在しない場合エラー",
+			setupMock: func(hub *mockChatEventHub, chatRepo *mockChatRepository, userRepo *mockUserRepository) {
+				chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(nil, errors.New("not found"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := new(mockChatEventHub)
+			chatRepo := new(mockChatRepository)
+			userRepo := new(mockUserRepository)
+			tt.setupMock(hub, chatRepo, userRepo)
+
+			u := NewCollaborationUsecase(hub, chatRepo, userRepo)
+			events, unsubscribe, err := u.Watch(context.Background(), "chat-uuid", "user-uuid")
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				hub.AssertNotCalled(t, "Subscribe", mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, events)
+			assert.NotNil(t, unsubscribe)
+			hub.AssertExpectations(t)
+		})
+	}
+}
+
+func TestChatUsecase_SendMessage_Broadcast(t *testing.T) {
+	t.Run("正常系: 送信したメッセージがチャットの閲覧者に配信されること", func(t *testing.T) {
+		chatRepo := &MockChatRepository{}
+		messageRepo := &MockMessageRepository{}
+		hub := new(mockChatEventHub)
+		chatRepo.On("FindByID", mock.Anything, "chat-uuid").Return(&model.Chat{UUID: "chat-uuid", Status: "open"}, nil)
+		messageRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		hub.On("Publish", mock.MatchedBy(func(event model.ChatEvent) bool {
+			return event.Type == model.ChatEventMessage && event.ChatUUID == "chat-uuid" && event.Message.Content == "hello"
+		})).Return()
+
+		u := NewChatUsecase(chatRepo, messageRepo, &MockMessageSelectionRepository{}, &mockEdgeRepository{}, &MockChatMergeEventRepository{}, &MockBranchComparisonRepository{}, &MockForkSuggestionRepository{}, &mockProjectRepository{}, &mockUserRepository{}, &MockTransactionManager{}, &MockGenAIClient{}, &MockPublisher{}, &MockPromptRenderer{}, hub)
+		_, err := u.SendMessage(context.Background(), "chat-uuid", "hello")
+
+		assert.NoError(t, err)
+		hub.AssertExpectations(t)
+	})
+}
@@ -7,44 +7,62 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type projectUsecase struct {
-	projectRepo repository.ProjectRepository
-	chatRepo    repository.ChatRepository
-	messageRepo repository.MessageRepository
-	edgeRepo    repository.EdgeRepository
-	txManager   repository.TransactionManager
+	projectRepo       repository.ProjectRepository
+	projectMemberRepo repository.ProjectMemberRepository
+	chatRepo          repository.ChatRepository
+	messageRepo       repository.MessageRepository
+	edgeRepo          repository.EdgeRepository
+	treeNodeStateRepo repository.TreeNodeStateRepository
+	txManager         repository.TransactionManager
+	treeLayouter      usecase.TreeLayouter
 }
 
 func NewProjectUsecase(
 	projectRepo repository.ProjectRepository,
+	projectMemberRepo repository.ProjectMemberRepository,
 	chatRepo repository.ChatRepository,
 	messageRepo repository.MessageRepository,
 	edgeRepo repository.EdgeRepository,
+	treeNodeStateRepo repository.TreeNodeStateRepository,
 	txManager repository.TransactionManager,
+	treeLayouter usecase.TreeLayouter,
 ) usecase.ProjectUsecase {
 	return &projectUsecase{
-		projectRepo: projectRepo,
-		chatRepo:    chatRepo,
-		messageRepo: messageRepo,
-		edgeRepo:    edgeRepo,
-		txManager:   txManager,
+		projectRepo:       projectRepo,
+		projectMemberRepo: projectMemberRepo,
+		chatRepo:          chatRepo,
+		messageRepo:       messageRepo,
+		edgeRepo:          edgeRepo,
+		treeNodeStateRepo: treeNodeStateRepo,
+		txManager:         txManager,
+		treeLayouter:      treeLayouter,
 	}
 }
 
 // プロジェクト取得処理
-func (u *projectUsecase) GetProjects(ctx context.Context, userUUID string) ([]*model.Project, error) {
+// 自分が作成したプロジェクトと、招待を承認して共有されたプロジェクトを分けて返す
+func (u *projectUsecase) GetProjects(ctx context.Context, userUUID string) (*model.ProjectList, error) {
 	slog.InfoContext(ctx, "プロジェクト一覧取得処理を開始", "user_uuid", userUUID)
 	projects, err := u.projectRepo.FindAllByUserUUID(ctx, userUUID)
 	if err != nil {
 		return nil, fmt.Errorf("プロジェクト一覧の取得に失敗: %w", err)
 	}
-	slog.InfoContext(ctx, "プロジェクト一覧取得処理を完了", "user_uuid", userUUID)
-	return projects, nil
+	shared, err := u.projectMemberRepo.FindSharedByUserUUID(ctx, userUUID, model.ProjectMemberStatusAccepted)
+	if err != nil {
+		return nil, fmt.Errorf("共有プロジェクト一覧の取得に失敗: %w", err)
+	}
+	slog.InfoContext(ctx, "プロジェクト一覧取得処理を完了", "user_uuid", userUUID, "owned", len(projects), "shared", len(shared))
+	return &model.ProjectList{
+		Owned:  projects,
+		Shared: shared,
+	}, nil
 }
 
 // プロジェクト作成処理
@@ -67,8 +85,8 @@ func (u *projectUsecase) CreateProject(ctx context.Context, userUUID, initialMes
 	chat := &model.Chat{
 		UUID:        chatID,
 		ProjectUUID: projectID,
-		Title:       initialMessage, // チャットのタイトルも最初のメッセージとする
-		Status:      "open",         // 初期状態はopen
+		Title:       initialMessage,       // チャットのタイトルも最初のメッセージとする
+		Status:      model.ChatStatusOpen, // 初期状態はopen
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}
@@ -114,19 +132,528 @@ func (u *projectUsecase) GetParentChat(ctx context.Context, projectUUID string)
 }
 
 // プロジェクトツリー取得処理
-func (u *projectUsecase) GetProjectTree(ctx context.Context, projectUUID string) (*model.ProjectTree, error) {
-	slog.InfoContext(ctx, "プロジェクトツリー取得処理を開始", "project_uuid", projectUUID)
+func (u *projectUsecase) GetProjectTree(ctx context.Context, projectUUID string, query model.ProjectTreeQuery) (*model.ProjectTree, error) {
+	slog.InfoContext(ctx, "プロジェクトツリー取得処理を開始", "project_uuid", projectUUID, "chat_uuid", query.ChatUUID, "summary_only", query.SummaryOnly)
+	if err := validateProjectTreeQuery(query); err != nil {
+		return nil, err
+	}
+
+	tree, snapshot, err := u.buildProjectTree(ctx, projectUUID)
+	if err != nil {
+		return nil, err
+	}
+	// 位置がずれないように、プロジェクト全体でレイアウトしてから絞り込む
+	tree, err = filterProjectTree(tree, snapshot, query)
+	if err != nil {
+		return nil, err
+	}
+	if query.SummaryOnly {
+		summarizeTreeNodes(tree)
+	}
+
+	slog.InfoContext(ctx, "プロジェクトツリー取得処理を完了", "project_uuid", projectUUID, "nodes", len(tree.Nodes))
+	return tree, nil
+}
+
+// ツリーのノード取得処理
+// 本文はノードのチャットのメッセージだけを取得し、位置は本文を除いたプロジェクトのツリーの構造から計算する
+func (u *projectUsecase) GetProjectTreeNode(ctx context.Context, projectUUID string, nodeID string) (*model.ProjectNode, error) {
+	slog.InfoContext(ctx, "ツリーのノード取得処理を開始", "project_uuid", projectUUID, "node_id", nodeID)
+	// 1. ノードのメッセージを取得し、プロジェクトのチャットのメッセージであることを確認
+	msg, err := u.messageRepo.FindByID(ctx, nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("メッセージの取得に失敗: %w", err)
+	}
+	if msg == nil {
+		return nil, fmt.Errorf("%w: node_id: %s", model.ErrTreeNodeNotFound, nodeID)
+	}
+	chat, err := u.chatRepo.FindByID(ctx, msg.ChatUUID)
+	if err != nil {
+		return nil, fmt.Errorf("チャットの取得に失敗: %w", err)
+	}
+	if chat.ProjectUUID != projectUUID {
+		return nil, fmt.Errorf("%w: node_id: %s", model.ErrTreeNodeNotFound, nodeID)
+	}
+
+	// 2. 回答とまとめるユーザーメッセージを特定するため、ノードのチャットのメッセージだけを取得
+	messages, err := u.messageRepo.FindMessagesByChatID(ctx, chat.UUID)
+	if err != nil {
+		return nil, fmt.Errorf("メッセージの取得に失敗: %w", err)
+	}
+	node := findProjectNode(messages, nodeID)
+	if node == nil {
+		return nil, fmt.Errorf("%w: node_id: %s", model.ErrTreeNodeNotFound, nodeID)
+	}
+	node.Title = chat.Title
+
+	// 3. ユーザーが移動した位置か、現在のツリーの構造から計算した位置を返す
+	state, err := u.treeNodeStateRepo.FindByNodeID(ctx, projectUUID, nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("ノード表示状態の取得に失敗: %w", err)
+	}
+	if state != nil {
+		applyTreeNodeState(node, state)
+	}
+	if !node.Pinned && u.treeLayouter != nil {
+		position, err := u.layoutProjectTreeNode(ctx, projectUUID, nodeID)
+		if err != nil {
+			return nil, err
+		}
+		node.Position = position
+	}
+	slog.InfoContext(ctx, "ツリーのノード取得処理を完了", "project_uuid", projectUUID, "node_id", nodeID)
+	return node, nil
+}
+
+// プロジェクトのツリーの構造だけを取得してレイアウトし、ノードの位置を返す処理
+// 本文は読み込まず、構造が変わっていない部分木はレイアウトの計算結果を再利用する
+func (u *projectUsecase) layoutProjectTreeNode(ctx context.Context, projectUUID string, nodeID string) (model.ProjectNodePosition, error) {
+	snapshot, err := loadProjectStructure(ctx, u.chatRepo, u.messageRepo, u.edgeRepo, projectUUID)
+	if err != nil {
+		return model.ProjectNodePosition{}, err
+	}
+	rootChatUUID, err := snapshot.rootChatUUID(projectUUID)
+	if err != nil {
+		return model.ProjectNodePosition{}, err
+	}
+	tree, _ := collectProjectTree(snapshot, rootChatUUID)
+	u.treeLayouter.Layout(tree)
+	for _, n := range tree.Nodes {
+		if n.ID == nodeID {
+			return n.Position, nil
+		}
+	}
+	return model.ProjectNodePosition{}, fmt.Errorf("%w: node_id: %s", model.ErrTreeNodeNotFound, nodeID)
+}
+
+// チャットのメッセージから、ツリーを構築したときと同じ組み合わせでノードを作る処理
+// 回答とまとめたユーザーメッセージや、フォークの起点でない回答のないユーザーメッセージはノードにならない
+func findProjectNode(messages []*model.Message, nodeID string) *model.ProjectNode {
+	for i, msg := range messages {
+		if msg.UUID != nodeID {
+			continue
+		}
+		node := &model.ProjectNode{ID: msg.UUID, ChatUUID: msg.ChatUUID}
+		switch msg.Role {
+		case "assistant":
+			node.Data.Assistant = msg.Content
+			if i > 0 && messages[i-1].Role == "user" {
+				userContent := messages[i-1].Content
+				node.Data.UserMessage = &userContent
+			}
+			return node
+		case "user":
+			if i+1 < len(messages) && messages[i+1].Role == "assistant" {
+				return nil
+			}
+			if len(msg.Forks) == 0 {
+				return nil
+			}
+			userContent := msg.Content
+			node.Data.UserMessage = &userContent
+			return node
+		}
+		return nil
+	}
+	return nil
+}
+
+// プロジェクト全体のツリーを構築し、レイアウトと表示状態を反映する処理
+func (u *projectUsecase) buildProjectTree(ctx context.Context, projectUUID string) (*model.ProjectTree, *projectSnapshot, error) {
+	// 1. プロジェクトのチャット・メッセージ・エッジをまとめて取得
+	snapshot, err := loadProjectSnapshot(ctx, u.chatRepo, u.messageRepo, u.edgeRepo, projectUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+	rootChatUUID, err := snapshot.rootChatUUID(projectUUID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tree, _ := collectProjectTree(snapshot, rootChatUUID)
+	// ノードの位置は保存せず、ツリーの構造から計算する
+	if u.treeLayouter != nil {
+		u.treeLayouter.Layout(tree)
+	}
+	// ユーザーが移動・折りたたみしたノードは自動レイアウトより優先する
+	states, err := u.treeNodeStateRepo.FindByProjectUUID(ctx, projectUUID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ノード表示状態の取得に失敗: %w", err)
+	}
+	applyTreeNodeStates(tree, states)
+
+	titles := make(map[string]string, len(snapshot.chats))
+	for _, chat := range snapshot.chats {
+		titles[chat.UUID] = chat.Title
+	}
+	for i := range tree.Nodes {
+		tree.Nodes[i].Title = titles[tree.Nodes[i].ChatUUID]
+	}
+	return tree, snapshot, nil
+}
+
+// 要約のみを返すときのノードの本文の文字数
+const treeNodeSummaryLength = 200
+
+// ツリーの取得条件を検証する処理
+func validateProjectTreeQuery(query model.ProjectTreeQuery) error {
+	if query.MaxDepth != nil && *query.MaxDepth < 0 {
+		return fmt.Errorf("%w: 階層数は0以上で指定してください", model.ErrInvalidTreeQuery)
+	}
+	for _, status := range query.ExcludeStatuses {
+		if !model.IsValidChatStatus(status) {
+			return fmt.Errorf("%w: 不正なステータスです: %s", model.ErrInvalidTreeQuery, status)
+		}
+	}
+	return nil
+}
+
+// 取得条件に合うチャットのノードとエッジだけにツリーを絞り込む処理
+func filterProjectTree(tree *model.ProjectTree, snapshot *projectSnapshot, query model.ProjectTreeQuery) (*model.ProjectTree, error) {
+	if query.ChatUUID == "" && query.MaxDepth == nil && len(query.ExcludeStatuses) == 0 {
+		return tree, nil
+	}
+
+	chats := make(map[string]*model.Chat, len(snapshot.chats))
+	for _, chat := range snapshot.chats {
+		chats[chat.UUID] = chat
+	}
+	startChatUUID := query.ChatUUID
+	if startChatUUID == "" {
+		startChatUUID = snapshot.chats[0].UUID
+	}
+	start, ok := chats[startChatUUID]
+	if !ok {
+		return nil, fmt.Errorf("%w: プロジェクトに存在しないチャットです: %s", model.ErrInvalidTreeQuery, startChatUUID)
+	}
+	excluded := make(map[string]bool, len(query.ExcludeStatuses))
+	for _, status := range query.ExcludeStatuses {
+		excluded[status] = true
+	}
+	if excluded[start.Status] {
+		return &model.ProjectTree{Nodes: []model.ProjectNode{}, Edges: []model.ProjectEdge{}}, nil
+	}
+
+	// 起点のチャットからフォークを辿り、含めるチャットと階層数の制限で読み込まないチャットを分ける
+	type queued struct {
+		chatUUID string
+		depth    int
+	}
+	included := map[string]bool{startChatUUID: true}
+	// フォーク元のメッセージのUUID -> 読み込んでいない子チャットがあるか
+	hasMore := make(map[string]bool)
+	queue := []queued{{chatUUID: startChatUUID}}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, msg := range snapshot.messages[current.chatUUID] {
+			for _, fork := range msg.Forks {
+				child, ok := chats[fork.ChatUUID]
+				if !ok || included[child.UUID] || excluded[child.Status] {
+					continue
+				}
+				if query.MaxDepth != nil && current.depth >= *query.MaxDepth {
+					hasMore[msg.UUID] = true
+					continue
+				}
+				included[child.UUID] = true
+				queue = append(queue, queued{chatUUID: child.UUID, depth: current.depth + 1})
+			}
+		}
+	}
+
+	// 回答とまとめたユーザーメッセージは、回答のノードに読み替える
+	for chatUUID := range included {
+		messages := snapshot.messages[chatUUID]
+		for i, msg := range messages {
+			if hasMore[msg.UUID] && msg.Role == "user" && i+1 < len(messages) && messages[i+1].Role == "assistant" {
+				hasMore[messages[i+1].UUID] = true
+			}
+		}
+	}
+
+	nodes := []model.ProjectNode{}
+	nodeIDs := make(map[string]bool)
+	for _, node := range tree.Nodes {
+		if !included[node.ChatUUID] {
+			continue
+		}
+		node.HasMoreChildren = hasMore[node.ID]
+		nodes = append(nodes, node)
+		nodeIDs[node.ID] = true
+	}
+	edges := []model.ProjectEdge{}
+	for _, edge := range tree.Edges {
+		if nodeIDs[edge.Source] && nodeIDs[edge.Target] {
+			edges = append(edges, edge)
+		}
+	}
+	return &model.ProjectTree{Nodes: nodes, Edges: edges}, nil
+}
+
+// ノードの本文を先頭の一部に省略する処理
+func summarizeTreeNodes(tree *model.ProjectTree) {
+	for i := range tree.Nodes {
+		data := &tree.Nodes[i].Data
+		if data.UserMessage != nil {
+			summary := truncateRunes(*data.UserMessage, treeNodeSummaryLength)
+			if summary != *data.UserMessage {
+				data.UserMessage = &summary
+				tree.Nodes[i].Truncated = true
+			}
+		}
+		if summary := truncateRunes(data.Assistant, treeNodeSummaryLength); summary != data.Assistant {
+			data.Assistant = summary
+			tree.Nodes[i].Truncated = true
+		}
+	}
+}
+
+// ノードの表示状態をツリーに反映する処理
+func applyTreeNodeStates(tree *model.ProjectTree, states []*model.TreeNodeState) {
+	byNodeID := make(map[string]*model.TreeNodeState, len(states))
+	for _, state := range states {
+		byNodeID[state.NodeID] = state
+	}
+	for i := range tree.Nodes {
+		if state, ok := byNodeID[tree.Nodes[i].ID]; ok {
+			applyTreeNodeState(&tree.Nodes[i], state)
+		}
+	}
+}
+
+// ノードの表示状態をノードに反映する処理
+func applyTreeNodeState(node *model.ProjectNode, state *model.TreeNodeState) {
+	node.Collapsed = state.Collapsed
+	node.Version = state.Version
+	if state.Position != nil {
+		node.Position = *state.Position
+		node.Pinned = true
+	}
+}
+
+// ノードの位置の一括更新処理
+// 1件でも他の操作で更新されていた場合はすべて更新せずにエラーを返す
+func (u *projectUsecase) UpdateTreeNodePositions(ctx context.Context, projectUUID string, updates []model.TreeNodePositionUpdate) ([]*model.TreeNodeState, error) {
+	slog.InfoContext(ctx, "ノード位置一括更新処理を開始", "project_uuid", projectUUID, "count", len(updates))
+	if len(updates) == 0 || len(updates) > maxTreeNodePositionUpdates {
+		return nil, fmt.Errorf("%w: 更新するノードは1件以上%d件以下で指定してください", model.ErrInvalidTreeNode, maxTreeNodePositionUpdates)
+	}
+	changes := make([]treeNodeStateChange, len(updates))
+	seen := make(map[string]bool, len(updates))
+	for i, update := range updates {
+		if seen[update.NodeID] {
+			return nil, fmt.Errorf("%w: ノードが重複しています (node_id: %s)", model.ErrInvalidTreeNode, update.NodeID)
+		}
+		seen[update.NodeID] = true
+		if !update.Reset && !isFinitePosition(update.Position) {
+			return nil, fmt.Errorf("%w: 位置が不正です (node_id: %s)", model.ErrInvalidTreeNode, update.NodeID)
+		}
+		changes[i] = treeNodeStateChange{
+			nodeID:  update.NodeID,
+			version: update.Version,
+			apply: func(state *model.TreeNodeState) {
+				if update.Reset {
+					state.Position = nil
+					return
+				}
+				position := update.Position
+				state.Position = &position
+			},
+		}
+	}
+
+	states, err := u.saveTreeNodeStates(ctx, projectUUID, changes)
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "ノード位置一括更新処理を完了", "project_uuid", projectUUID, "count", len(states))
+	return states, nil
+}
+
+// ノードの折りたたみ状態の更新処理
+func (u *projectUsecase) SetTreeNodeCollapsed(ctx context.Context, projectUUID string, nodeID string, collapsed bool, version int) (*model.TreeNodeState, error) {
+	slog.InfoContext(ctx, "ノード折りたたみ状態更新処理を開始", "project_uuid", projectUUID, "node_id", nodeID, "collapsed", collapsed)
+	states, err := u.saveTreeNodeStates(ctx, projectUUID, []treeNodeStateChange{{
+		nodeID:  nodeID,
+		version: version,
+		apply: func(state *model.TreeNodeState) {
+			state.Collapsed = collapsed
+		},
+	}})
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "ノード折りたたみ状態更新処理を完了", "project_uuid", projectUUID, "node_id", nodeID)
+	return states[0], nil
+}
+
+// 一度に位置を更新できるノードの上限
+const maxTreeNodePositionUpdates = 500
+
+// ノードの表示状態の変更内容
+type treeNodeStateChange struct {
+	nodeID string
+	// クライアントがツリーを取得したときのノードのバージョン
+	version int
+	apply   func(state *model.TreeNodeState)
+}
+
+// ノードの表示状態の変更をまとめて保存する処理
+// 保存されているバージョンがクライアントの取得したバージョンと異なる場合は競合として扱う
+func (u *projectUsecase) saveTreeNodeStates(ctx context.Context, projectUUID string, changes []treeNodeStateChange) ([]*model.TreeNodeState, error) {
+	snapshot, err := loadProjectSnapshot(ctx, u.chatRepo, u.messageRepo, u.edgeRepo, projectUUID)
+	if err != nil {
+		return nil, err
+	}
+	rootChatUUID, err := snapshot.rootChatUUID(projectUUID)
+	if err != nil {
+		return nil, err
+	}
+	tree, _ := collectProjectTree(snapshot, rootChatUUID)
+	nodeIDs := make(map[string]bool, len(tree.Nodes))
+	for _, node := range tree.Nodes {
+		nodeIDs[node.ID] = true
+	}
+	for _, change := range changes {
+		if !nodeIDs[change.nodeID] {
+			return nil, fmt.Errorf("%w: プロジェクトのツリーにないノードです (node_id: %s)", model.ErrInvalidTreeNode, change.nodeID)
+		}
+	}
+
+	current, err := u.treeNodeStateRepo.FindByProjectUUID(ctx, projectUUID)
+	if err != nil {
+		return nil, fmt.Errorf("ノード表示状態の取得に失敗: %w", err)
+	}
+	byNodeID := make(map[string]*model.TreeNodeState, len(current))
+	for _, state := range current {
+		byNodeID[state.NodeID] = state
+	}
+
+	now := time.Now()
+	saved := make([]*model.TreeNodeState, len(changes))
+	err = u.txManager.Do(ctx, func(ctx context.Context) error {
+		for i, change := range changes {
+			state := &model.TreeNodeState{NodeID: change.nodeID, ProjectUUID: projectUUID}
+			if prev, ok := byNodeID[change.nodeID]; ok {
+				copied := *prev
+				state = &copied
+			}
+			change.apply(state)
+			state.Version = change.version + 1
+			state.UpdatedAt = now
+
+			ok, err := u.treeNodeStateRepo.SaveIfVersion(ctx, state, change.version)
+			if err != nil {
+				return fmt.Errorf("ノード表示状態の保存に失敗: %w", err)
+			}
+			if !ok {
+				return fmt.Errorf("%w: node_id: %s", model.ErrTreeNodeConflict, change.nodeID)
+			}
+			saved[i] = state
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+// 位置が有限の値か判定する処理
+func isFinitePosition(position model.ProjectNodePosition) bool {
+	return !math.IsNaN(position.X) && !math.IsInf(position.X, 0) && !math.IsNaN(position.Y) && !math.IsInf(position.Y, 0)
+}
+
+// プロジェクトのチャット・メッセージ・エッジをまとめて取得したもの
+type projectSnapshot struct {
+	// 作成順のチャット (先頭がルートチャット)
+	chats []*model.Chat
+	// チャットのUUID -> 作成順のメッセージ
+	messages map[string][]*model.Message
+	// チャットのUUID -> エッジ
+	edges map[string][]*model.Edge
+}
 
-	// 1. プロジェクトのルートチャットを取得
-	rootChat, err := u.chatRepo.FindOldestByProjectUUID(ctx, projectUUID)
+// プロジェクトのチャット・メッセージ・エッジを一括で取得する処理
+// チャットごとに取得するとチャットの数だけクエリが増えるため、プロジェクト単位で決まった回数のクエリで取得する
+func loadProjectSnapshot(ctx context.Context, chatRepo repository.ChatRepository, messageRepo repository.MessageRepository, edgeRepo repository.EdgeRepository, projectUUID string) (*projectSnapshot, error) {
+	chats, err := chatRepo.FindAllByProjectUUID(ctx, projectUUID)
+	if err != nil {
+		return nil, fmt.Errorf("チャットの取得に失敗: %w", err)
+	}
+	messages, err := messageRepo.FindMessagesByProjectUUID(ctx, projectUUID)
+	if err != nil {
+		return nil, fmt.Errorf("メッセージの取得に失敗: %w", err)
+	}
+	edges, err := edgeRepo.FindEdgesByProjectUUID(ctx, projectUUID)
+	if err != nil {
+		return nil, fmt.Errorf("エッジの取得に失敗: %w", err)
+	}
+	return newProjectSnapshot(chats, messages, edges), nil
+}
+
+// プロジェクトのチャット・エッジと、本文を除いたメッセージを一括で取得する処理
+// ノードの位置の計算のようにツリーの構造だけが必要な場合に使う
+func loadProjectStructure(ctx context.Context, chatRepo repository.ChatRepository, messageRepo repository.MessageRepository, edgeRepo repository.EdgeRepository, projectUUID string) (*projectSnapshot, error) {
+	chats, err := chatRepo.FindAllByProjectUUID(ctx, projectUUID)
+	if err != nil {
+		return nil, fmt.Errorf("チャットの取得に失敗: %w", err)
+	}
+	messages, err := messageRepo.FindMessageStructureByProjectUUID(ctx, projectUUID)
 	if err != nil {
-		return nil, fmt.Errorf("ルートチャットの取得に失敗: %w", err)
+		return nil, fmt.Errorf("メッセージの取得に失敗: %w", err)
 	}
+	edges, err := edgeRepo.FindEdgesByProjectUUID(ctx, projectUUID)
+	if err != nil {
+		return nil, fmt.Errorf("エッジの取得に失敗: %w", err)
+	}
+	return newProjectSnapshot(chats, messages, edges), nil
+}
+
+// 取得したチャット・メッセージ・エッジをチャットごとにまとめる処理
+func newProjectSnapshot(chats []*model.Chat, messages []*model.Message, edges []*model.Edge) *projectSnapshot {
+	snapshot := &projectSnapshot{
+		chats:    chats,
+		messages: make(map[string][]*model.Message, len(chats)),
+		edges:    make(map[string][]*model.Edge, len(chats)),
+	}
+	for _, msg := range messages {
+		snapshot.messages[msg.ChatUUID] = append(snapshot.messages[msg.ChatUUID], msg)
+	}
+	for _, edge := range edges {
+		snapshot.edges[edge.ChatUUID] = append(snapshot.edges[edge.ChatUUID], edge)
+	}
+	return snapshot
+}
+
+// プロジェクトのルートチャット (最も古いチャット) のUUIDを返す処理
+func (s *projectSnapshot) rootChatUUID(projectUUID string) (string, error) {
+	if len(s.chats) == 0 {
+		return "", fmt.Errorf("ルートチャットの取得に失敗: プロジェクトにチャットがありません (project_uuid: %s)", projectUUID)
+	}
+	return s.chats[0].UUID, nil
+}
+
+// ツリーの構築中に辿ったチャットとメッセージ
+type projectTreeScope struct {
+	chats    map[string]bool
+	messages map[string]bool
+}
 
+// 指定したチャットからフォークを辿ってツリーを構築する処理
+// 共有リンクで部分木だけを公開できるように、辿ったチャットとメッセージも返す
+func collectProjectTree(snapshot *projectSnapshot, rootChatUUID string) (*model.ProjectTree, *projectTreeScope) {
 	nodes := []model.ProjectNode{}
 	edges := []model.ProjectEdge{}
-	visitedChats := make(map[string]bool)
-	queue := []string{rootChat.UUID}
+	scope := &projectTreeScope{
+		chats:    make(map[string]bool),
+		messages: make(map[string]bool),
+	}
+	visitedChats := scope.chats
+	queue := []string{rootChatUUID}
+	// ユーザーメッセージは回答とまとめて1つのノードにするので、エッジの端点をノードのIDに置き換える
+	nodeIDs := make(map[string]string)
 
 	for len(queue) > 0 {
 		currentChatUUID := queue[0]
@@ -138,9 +665,9 @@ func (u *projectUsecase) GetProjectTree(ctx context.Context, projectUUID string)
 		visitedChats[currentChatUUID] = true
 
 		// 2. チャットのメッセージを取得
-		messages, err := u.messageRepo.FindMessagesByChatID(ctx, currentChatUUID)
-		if err != nil {
-			return nil, fmt.Errorf("メッセージの取得に失敗 (chat_uuid: %s): %w", currentChatUUID, err)
+		messages := snapshot.messages[currentChatUUID]
+		for _, msg := range messages {
+			scope.messages[msg.UUID] = true
 		}
 
 		// 3. メッセージからノードを作成
@@ -153,6 +680,7 @@ func (u *projectUsecase) GetProjectTree(ctx context.Context, projectUUID string)
 				if i+1 < len(messages) && messages[i+1].Role == "assistant" {
 					assistantMsg := messages[i+1]
 					userContent := msg.Content
+					nodeIDs[msg.UUID] = assistantMsg.UUID
 					node = &model.ProjectNode{
 						ID:       assistantMsg.UUID,
 						ChatUUID: assistantMsg.ChatUUID,
@@ -160,10 +688,6 @@ func (u *projectUsecase) GetProjectTree(ctx context.Context, projectUUID string)
 							UserMessage: &userContent,
 							Assistant:   assistantMsg.Content,
 						},
-						Position: model.ProjectNodePosition{
-							X: assistantMsg.PositionX,
-							Y: assistantMsg.PositionY,
-						},
 					}
 
 					// アシスタントメッセージのフォークも確認
@@ -174,6 +698,16 @@ func (u *projectUsecase) GetProjectTree(ctx context.Context, projectUUID string)
 					}
 
 					i++ // アシスタントメッセージをスキップ
+				} else if len(msg.Forks) > 0 {
+					// 回答がまだないユーザーメッセージでも、フォークの起点になっていればノードにする
+					userContent := msg.Content
+					node = &model.ProjectNode{
+						ID:       msg.UUID,
+						ChatUUID: msg.ChatUUID,
+						Data: model.ProjectNodeData{
+							UserMessage: &userContent,
+						},
+					}
 				}
 			} else if msg.Role == "assistant" {
 				// アシスタントメッセージ単体の場合
@@ -184,10 +718,6 @@ func (u *projectUsecase) GetProjectTree(ctx context.Context, projectUUID string)
 						UserMessage: nil,
 						Assistant:   msg.Content,
 					},
-					Position: model.ProjectNodePosition{
-						X: msg.PositionX,
-						Y: msg.PositionY,
-					},
 				}
 			}
 
@@ -204,12 +734,7 @@ func (u *projectUsecase) GetProjectTree(ctx context.Context, projectUUID string)
 		}
 
 		// 4. チャットのエッジを取得
-		chatEdges, err := u.edgeRepo.FindEdgesByChatID(ctx, currentChatUUID)
-		if err != nil {
-			return nil, fmt.Errorf("エッジの取得に失敗 (chat_uuid: %s): %w", currentChatUUID, err)
-		}
-
-		for _, edge := range chatEdges {
+		for _, edge := range snapshot.edges[currentChatUUID] {
 			edges = append(edges, model.ProjectEdge{
 				ID:     edge.UUID,
 				Source: edge.SourceMessageUUID,
@@ -218,9 +743,37 @@ func (u *projectUsecase) GetProjectTree(ctx context.Context, projectUUID string)
 		}
 	}
 
-	slog.InfoContext(ctx, "プロジェクトツリー取得処理を完了", "project_uuid", projectUUID)
+	// ユーザーメッセージからフォークしたエッジは、そのメッセージを含むノードにつなぐ
+	for i := range edges {
+		if id, ok := nodeIDs[edges[i].Source]; ok {
+			edges[i].Source = id
+		}
+		if id, ok := nodeIDs[edges[i].Target]; ok {
+			edges[i].Target = id
+		}
+	}
+
 	return &model.ProjectTree{
 		Nodes: nodes,
 		Edges: edges,
-	}, nil
+	}, scope
+}
+
+// プロジェクトの出力言語更新処理
+func (u *projectUsecase) UpdateLanguage(ctx context.Context, projectUUID string, language string) error {
+	slog.InfoContext(ctx, "プロジェクト出力言語更新処理を開始", "project_uuid", projectUUID, "language", language)
+	if language != "" && !model.IsSupportedLanguage(language) {
+		return fmt.Errorf("%w: %s", model.ErrUnsupportedLanguage, language)
+	}
+
+	if _, err := u.projectRepo.FindByUUID(ctx, projectUUID); err != nil {
+		return fmt.Errorf("プロジェクトの取得に失敗: %w", err)
+	}
+
+	if err := u.projectRepo.UpdateLanguage(ctx, projectUUID, language); err != nil {
+		return fmt.Errorf("プロジェクト出力言語の更新に失敗: %w", err)
+	}
+
+	slog.InfoContext(ctx, "プロジェクト出力言語更新処理を完了", "project_uuid", projectUUID)
+	return nil
 }
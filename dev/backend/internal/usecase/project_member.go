@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"backend/internal/domain/model"
+	"backend/internal/domain/repository"
+	"backend/internal/domain/usecase"
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+type projectMemberUsecase struct {
+	projectMemberRepo repository.ProjectMemberRepository
+	projectRepo       repository.ProjectRepository
+	chatRepo          repository.ChatRepository
+	userRepo          repository.UserRepository
+}
+
+// ProjectMemberUsecase の新しいインスタンスを作成する処理
+func NewProjectMemberUsecase(
+	projectMemberRepo repository.ProjectMemberRepository,
+	projectRepo repository.ProjectRepository,
+	chatRepo repository.ChatRepository,
+	userRepo repository.UserRepository,
+) usecase.ProjectMemberUsecase {
+	return &projectMemberUsecase{
+		projectMemberRepo: projectMemberRepo,
+		projectRepo:       projectRepo,
+		chatRepo:          chatRepo,
+		userRepo:          userRepo,
+	}
+}
+
+// プロジェクトでのユーザーのロールを取得する処理
+// 作成者は常に owner、招待を承認したメンバーは招待時のロールになる
+func (u *projectMemberUsecase) ResolveRole(ctx context.Context, userUUID string, access model.ProjectAccess) (string, error) {
+	projectUUID := access.ProjectUUID
+	if projectUUID == "" && access.ChatUUID != "" {
+		chat, err := u.chatRepo.FindByID(ctx, access.ChatUUID)
+		if err != nil {
+			return "", fmt.Errorf("%w: チャットのプロジェクトを特定できません", model.ErrProjectNotFound)
+		}
+		projectUUID = chat.ProjectUUID
+	}
+
+	project, err := u.projectRepo.FindByUUID(ctx, projectUUID)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", model.ErrProjectNotFound, projectUUID)
+	}
+	if project.UserUUID == userUUID {
+		return model.ProjectRoleOwner, nil
+	}
+
+	member, err := u.projectMemberRepo.Find(ctx, projectUUID, userUUID)
+	if err != nil {
+		return "", fmt.Errorf("プロジェクトメンバーの取得に失敗: %w", err)
+	}
+	if member == nil || member.Status != model.ProjectMemberStatusAccepted {
+		return "", fmt.Errorf("%w: %s", model.ErrProjectNotFound, projectUUID)
+	}
+	return member.Role, nil
+}
+
+// プロジェクトのメンバー一覧を取得する処理
+func (u *projectMemberUsecase) ListMembers(ctx context.Context, projectUUID string) ([]*model.ProjectMember, error) {
+	slog.InfoContext(ctx, "プロジェクトメンバー一覧取得処理を開始", "project_uuid", projectUUID)
+	project, err := u.projectRepo.FindByUUID(ctx, projectUUID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", model.ErrProjectNotFound, projectUUID)
+	}
+	members, err := u.projectMemberRepo.FindByProjectUUID(ctx, projectUUID)
+	if err != nil {
+		return nil, fmt.Errorf("プロジェクトメンバー一覧の取得に失敗: %w", err)
+	}
+
+	owner := &model.ProjectMember{
+		ProjectUUID: project.UUID,
+		UserUUID:    project.UserUUID,
+		Role:        model.ProjectRoleOwner,
+		Status:      model.ProjectMemberStatusAccepted,
+		CreatedAt:   project.CreatedAt,
+		UpdatedAt:   project.CreatedAt,
+	}
+	if user, err := u.userRepo.FindByUUID(ctx, project.UserUUID); err == nil {
+		owner.UserName = user.Name
+	} else {
+		slog.WarnContext(ctx, "プロジェクト作成者の取得に失敗", "user_uuid", project.UserUUID, "error", err)
+	}
+
+	slog.InfoContext(ctx, "プロジェクトメンバー一覧取得処理を完了", "project_uuid", projectUUID, "count", len(members)+1)
+	return append([]*model.ProjectMember{owner}, members...), nil
+}
+
+// ユーザーをプロジェクトに招待する処理
+// 招待されたユーザーが承認するまでプロジェクトにはアクセスできない
+func (u *projectMemberUsecase) InviteMember(ctx context.Context, inviterUUID string, projectUUID string, params model.InviteProjectMemberParams) (*model.ProjectMember, error) {
+	slog.InfoContext(ctx, "プロジェクト招待処理を開始", "project_uuid", projectUUID, "user_uuid", params.UserUUID, "role", params.Role)
+	role := params.Role
+	if role == "" {
+		role = model.ProjectRoleViewer
+	}
+	if !model.IsValidProjectRole(role) {
+		return nil, fmt.Errorf("%w: %s", model.ErrInvalidProjectRole, role)
+	}
+
+	project, err := u.projectRepo.FindByUUID(ctx, projectUUID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", model.ErrProjectNotFound, projectUUID)
+	}
+	if project.UserUUID == params.UserUUID {
+		return nil, fmt.Errorf("%w: プロジェクトの作成者は招待できません", model.ErrProjectMemberExists)
+	}
+	user, err := u.userRepo.FindByUUID(ctx, params.UserUUID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", model.ErrUserNotFound, params.UserUUID)
+	}
+
+	existing, err := u.projectMemberRepo.Find(ctx, projectUUID, params.UserUUID)
+	if err != nil {
+		return nil, fmt.Errorf("プロジェクトメンバーの取得に失敗: %w", err)
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("%w: %s", model.ErrProjectMemberExists, params.UserUUID)
+	}
+
+	now := time.Now()
+	member := &model.ProjectMember{
+		ProjectUUID:       projectUUID,
+		UserUUID:          user.UUID,
+		UserName:          user.Name,
+		Role:              role,
+		Status:            model.ProjectMemberStatusPending,
+		InvitedByUserUUID: inviterUUID,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if err := u.projectMemberRepo.Create(ctx, member); err != nil {
+		return nil, fmt.Errorf("プロジェクトへの招待に失敗: %w", err)
+	}
+
+	slog.InfoContext(ctx, "プロジェクト招待処理を完了", "project_uuid", projectUUID, "user_uuid", member.UserUUID)
+	return member, nil
+}
+
+// メンバーのロールを変更する処理
+func (u *projectMemberUsecase) UpdateMemberRole(ctx context.Context, projectUUID string, memberUUID string, role string) (*model.ProjectMember, error) {
+	slog.InfoContext(ctx, "プロジェクトメンバーのロール変更処理を開始", "project_uuid", projectUUID, "user_uuid", memberUUID, "role", role)
+	if !model.IsValidProjectRole(role) {
+		return nil, fmt.Errorf("%w: %s", model.ErrInvalidProjectRole, role)
+	}
+
+	member, err := u.projectMemberRepo.Find(ctx, projectUUID, memberUUID)
+	if err != nil {
+		return nil, fmt.Errorf("プロジェクトメンバーの取得に失敗: %w", err)
+	}
+	if member == nil {
+		return nil, fmt.Errorf("%w: %s", model.ErrProjectMemberNotFound, memberUUID)
+	}
+	if err := u.projectMemberRepo.UpdateRole(ctx, projectUUID, memberUUID, role); err != nil {
+		return nil, fmt.Errorf("プロジェクトメンバーのロール変更に失敗: %w", err)
+	}
+	member.Role = role
+
+	slog.InfoContext(ctx, "プロジェクトメンバーのロール変更処理を完了", "project_uuid", projectUUID, "user_uuid", memberUUID)
+	return member, nil
+}
+
+// メンバーを削除する処理
+func (u *projectMemberUsecase) RemoveMember(ctx context.Context, userUUID string, projectUUID string, memberUUID string) error {
+	slog.InfoContext(ctx, "プロジェクトメンバー削除処理を開始", "project_uuid", projectUUID, "user_uuid", memberUUID)
+	if userUUID != memberUUID {
+		role, err := u.ResolveRole(ctx, userUUID, model.ProjectAccess{ProjectUUID: projectUUID})
+		if err != nil {
+			return err
+		}
+		if !model.HasProjectRole(role, model.ProjectRoleOwner) {
+			return fmt.Errorf("%w: 他のメンバーの削除にはオーナー権限が必要です", model.ErrProjectRoleDenied)
+		}
+	}
+
+	deleted, err := u.projectMemberRepo.Delete(ctx, projectUUID, memberUUID)
+	if err != nil {
+		return fmt.Errorf("プロジェクトメンバーの削除に失敗: %w", err)
+	}
+	if !deleted {
+		return fmt.Errorf("%w: %s", model.ErrProjectMemberNotFound, memberUUID)
+	}
+
+	slog.InfoContext(ctx, "プロジェクトメンバー削除処理を完了", "project_uuid", projectUUID, "user_uuid", memberUUID)
+	return nil
+}
+
+// ログイン中のユーザー宛ての招待一覧を取得する処理
+func (u *projectMemberUsecase) ListInvitations(ctx context.Context, userUUID string) ([]*model.SharedProject, error) {
+	slog.InfoContext(ctx, "プロジェクト招待一覧取得処理を開始", "user_uuid", userUUID)
+	invitations, err := u.projectMemberRepo.FindSharedByUserUUID(ctx, userUUID, model.ProjectMemberStatusPending)
+	if err != nil {
+		return nil, fmt.Errorf("プロジェクト招待一覧の取得に失敗: %w", err)
+	}
+	return invitations, nil
+}
+
+// 招待を承認する処理
+func (u *projectMemberUsecase) AcceptInvitation(ctx context.Context, userUUID string, projectUUID string) error {
+	slog.InfoContext(ctx, "プロジェクト招待承認処理を開始", "user_uuid", userUUID, "project_uuid", projectUUID)
+	accepted, err := u.projectMemberRepo.Accept(ctx, projectUUID, userUUID)
+	if err != nil {
+		return fmt.Errorf("プロジェクト招待の承認に失敗: %w", err)
+	}
+	if !accepted {
+		return fmt.Errorf("%w: %s", model.ErrProjectMemberNotFound, projectUUID)
+	}
+	slog.InfoContext(ctx, "プロジェクト招待承認処理を完了", "user_uuid", userUUID, "project_uuid", projectUUID)
+	return nil
+}
+
+// 招待を辞退する処理
+func (u *projectMemberUsecase) DeclineInvitation(ctx context.Context, userUUID string, projectUUID string) error {
+	slog.InfoContext(ctx, "プロジェクト招待辞退処理を開始", "user_uuid", userUUID, "project_uuid", projectUUID)
+	member, err := u.projectMemberRepo.Find(ctx, projectUUID, userUUID)
+	if err != nil {
+		return fmt.Errorf("プロジェクトメンバーの取得に失敗: %w", err)
+	}
+	if member == nil || member.Status != model.ProjectMemberStatusPending {
+		return fmt.Errorf("%w: %s", model.ErrProjectMemberNotFound, projectUUID)
+	}
+	if _, err := u.projectMemberRepo.Delete(ctx, projectUUID, userUUID); err != nil {
+		return fmt.Errorf("プロジェクト招待の辞退に失敗: %w", err)
+	}
+	slog.InfoContext(ctx, "プロジェクト招待辞退処理を完了", "user_uuid", userUUID, "project_uuid", projectUUID)
+	return nil
+}
@@ -0,0 +1,371 @@
+package usecase
+
+import (
+	"backend/internal/domain/model"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockProjectMemberRepository struct {
+	mock.Mock
+}
+
+func (m *mockProjectMemberRepository) Create(ctx context.Context, member *model.ProjectMember) error {
+	args := m.Called(ctx, member)
+	return args.Error(0)
+}
+
+func (m *mockProjectMemberRepository) Find(ctx context.Context, projectUUID string, userUUID string) (*model.ProjectMember, error) {
+	args := m.Called(ctx, projectUUID, userUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ProjectMember), args.Error(1)
+}
+
+func (m *mockProjectMemberRepository) FindByProjectUUID(ctx context.Context, projectUUID string) ([]*model.ProjectMember, error) {
+	args := m.Called(ctx, projectUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.ProjectMember), args.Error(1)
+}
+
+func (m *mockProjectMemberRepository) FindByUserUUID(ctx context.Context, userUUID string) ([]*model.ProjectMember, error) {
+	args := m.Called(ctx, userUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.ProjectMember), args.Error(1)
+}
+
+func (m *mockProjectMemberRepository) FindSharedByUserUUID(ctx context.Context, userUUID string, status string) ([]*model.SharedProject, error) {
+	args := m.Called(ctx, userUUID, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.SharedProject), args.Error(1)
+}
+
+func (m *mockProjectMemberRepository) UpdateRole(ctx context.Context, projectUUID string, userUUID string, role string) error {
+	args := m.Called(ctx, projectUUID, userUUID, role)
+	return args.Error(0)
+}
+
+func (m *mockProjectMemberRepository) Accept(ctx context.Context, projectUUID string, userUUID string) (bool, error) {
+	args := m.Called(ctx, projectUUID, userUUID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockProjectMemberRepository) Delete(ctx context.Context, projectUUID string, userUUID string) (bool, error) {
+	args := m.Called(ctx, projectUUID, userUUID)
+	return args.Bool(0), args.Error(1)
+}
+
+// プロジェクトメンバーのテストで使用するモック
+type projectMemberMocks struct {
+	memberRepo  *mockProjectMemberRepository
+	projectRepo *mockProjectRepository
+	chatRepo    *mockChatRepository
+	userRepo    *mockUserRepository
+}
+
+func newProjectMemberMocks() *projectMemberMocks {
+	return &projectMemberMocks{
+		memberRepo:  new(mockProjectMemberRepository),
+		projectRepo: new(mockProjectRepository),
+		chatRepo:    new(mockChatRepository),
+		userRepo:    new(mockUserRepository),
+	}
+}
+
+func (m *projectMemberMocks) usecase() *projectMemberUsecase {
+	return NewProjectMemberUsecase(m.memberRepo, m.projectRepo, m.chatRepo, m.userRepo).(*projectMemberUsecase)
+}
+
+func TestProjectMemberUsecase_ResolveRole(t *testing.T) {
+	project := &model.Project{UUID: "project-1", UserUUID: "owner-1"}
+
+	tests := []struct {
+		name      string
+		userUUID  string
+		access    model.ProjectAccess
+		setupMock func(m *projectMemberMocks)
+		wantRole  string
+		wantErr   error
+	}{
+		{
+			name:     "正常系: 作成者は owner になること",
+			userUUID: "owner-1",
+			access:   model.ProjectAccess{ProjectUUID: "project-1"},
+			setupMock: func(m *projectMemberMocks) {
+				m.projectRepo.On("FindByUUID", mock.Anything, "project-1").Return(project, nil)
+			},
+			wantRole: model.ProjectRoleOwner,
+		},
+		{
+			name:     "正常系: チャットからプロジェクトを特定し承認済みメンバーのロールになること",
+			userUUID: "user-1",
+			access:   model.ProjectAccess{ChatUUID: "chat-1"},
+			setupMock: func(m *projectMemberMocks) {
+				m.chatRepo.On("FindByID", mock.Anything, "chat-1").Return(&model.Chat{UUID: "chat-1", ProjectUUID: "project-1"}, nil)
+				m.projectRepo.On("FindByUUID", mock.Anything, "project-1").Return(project, nil)
+				m.memberRepo.On("Find", mock.Anything, "project-1", "user-1").Return(&model.ProjectMember{Role: model.ProjectRoleViewer, Status: model.ProjectMemberStatusAccepted}, nil)
+			},
+			wantRole: model.ProjectRoleViewer,
+		},
+		{
+			name:     "異常系: 招待を承認していない場合アクセスできないこと",
+			userUUID: "user-1",
+			access:   model.ProjectAccess{ProjectUUID: "project-1"},
+			setupMock: func(m *projectMemberMocks) {
+				m.projectRepo.On("FindByUUID", mock.Anything, "project-1").Return(project, nil)
+				m.memberRepo.On("Find", mock.Anything, "project-1", "user-1").Return(&model.ProjectMember{Role: model.ProjectRoleEditor, Status: model.ProjectMemberStatusPending}, nil)
+			},
+			wantErr: model.ErrProjectNotFound,
+		},
+		{
+			name:     "異常系: メンバーでない場合アクセスできないこと",
+			userUUID: "user-2",
+			access:   model.ProjectAccess{ProjectUUID: "project-1"},
+			setupMock: func(m *projectMemberMocks) {
+				m.projectRepo.On("FindByUUID", mock.Anything, "project-1").Return(project, nil)
+				m.memberRepo.On("Find", mock.Anything, "project-1", "user-2").Return(nil, nil)
+			},
+			wantErr: model.ErrProjectNotFound,
+		},
+		{
+			name:     "異常系: プロジェクトが存在しない場合エラー",
+			userUUID: "user-1",
+			access:   model.ProjectAccess{ProjectUUID: "unknown"},
+			setupMock: func(m *projectMemberMocks) {
+				m.projectRepo.On("FindByUUID", mock.Anything, "unknown").Return(nil, errors.New("record not found"))
+			},
+			wantErr: model.ErrProjectNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newProjectMemberMocks()
+			tt.setupMock(m)
+
+			role, err := m.usecase().ResolveRole(context.Background(), tt.userUUID, tt.access)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRole, role)
+		})
+	}
+}
+
+func TestProjectMemberUsecase_InviteMember(t *testing.T) {
+	project := &model.Project{UUID: "project-1", UserUUID: "owner-1"}
+
+	tests := []struct {
+		name      string
+		params    model.InviteProjectMemberParams
+		setupMock func(m *projectMemberMocks)
+		wantRole  string
+		wantErr   error
+	}{
+		{
+			name:   "正常系: ロール未指定の場合 viewer として招待されること",
+			params: model.InviteProjectMemberParams{UserUUID: "user-1"},
+			setupMock: func(m *projectMemberMocks) {
+				m.projectRepo.On("FindByUUID", mock.Anything, "project-1").Return(project, nil)
+				m.userRepo.On("FindByUUID", mock.Anything, "user-1").Return(&model.User{UUID: "user-1", Name: "Alice"}, nil)
+				m.memberRepo.On("Find", mock.Anything, "project-1", "user-1").Return(nil, nil)
+				m.memberRepo.On("Create", mock.Anything, mock.MatchedBy(func(member *model.ProjectMember) bool {
+					return member.Status == model.ProjectMemberStatusPending && member.InvitedByUserUUID == "owner-1"
+				})).Return(nil)
+			},
+			wantRole: model.ProjectRoleViewer,
+		},
+		{
+			name:      "異常系: 定義されていないロールの場合エラー",
+			params:    model.InviteProjectMemberParams{UserUUID: "user-1", Role: "admin"},
+			setupMock: func(m *projectMemberMocks) {},
+			wantErr:   model.ErrInvalidProjectRole,
+		},
+		{
+			name:   "異常系: 作成者を招待した場合エラー",
+			params: model.InviteProjectMemberParams{UserUUID: "owner-1", Role: model.ProjectRoleEditor},
+			setupMock: func(m *projectMemberMocks) {
+				m.projectRepo.On("FindByUUID", mock.Anything, "project-1").Return(project, nil)
+			},
+			wantErr: model.ErrProjectMemberExists,
+		},
+		{
+			name:   "異常系: 存在しないユーザーの場合エラー",
+			params: model.InviteProjectMemberParams{UserUUID: "unknown", Role: model.ProjectRoleEditor},
+			setupMock: func(m *projectMemberMocks) {
+				m.projectRepo.On("FindByUUID", mock.Anything, "project-1").Return(project, nil)
+				m.userRepo.On("FindByUUID", mock.Anything, "unknown").Return(nil, errors.New("record not found"))
+			},
+			wantErr: model.ErrUserNotFound,
+		},
+		{
+			name:   "異常系: 既に招待済みの場合エラー",
+			params: model.InviteProjectMemberParams{UserUUID: "user-1", Role: model.ProjectRoleEditor},
+			setupMock: func(m *projectMemberMocks) {
+				m.projectRepo.On("FindByUUID", mock.Anything, "project-1").Return(project, nil)
+				m.userRepo.On("FindByUUID", mock.Anything, "user-1").Return(&model.User{UUID: "user-1"}, nil)
+				m.memberRepo.On("Find", mock.Anything, "project-1", "user-1").Return(&model.ProjectMember{Status: model.ProjectMemberStatusPending}, nil)
+			},
+			wantErr: model.ErrProjectMemberExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newProjectMemberMocks()
+			tt.setupMock(m)
+
+			member, err := m.usecase().InviteMember(context.Background(), "owner-1", "project-1", tt.params)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, member)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantRole, member.Role)
+			assert.Equal(t, "Alice", member.UserName)
+			m.memberRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProjectMemberUsecase_RemoveMember(t *testing.T) {
+	project := &model.Project{UUID: "project-1", UserUUID: "owner-1"}
+
+	tests := []struct {
+		name       string
+		userUUID   string
+		memberUUID string
+		setupMock  func(m *projectMemberMocks)
+		wantErr    error
+	}{
+		{
+			name:       "正常系: メンバーが自分自身を削除してプロジェクトから抜けられること",
+			userUUID:   "user-1",
+			memberUUID: "user-1",
+			setupMock: func(m *projectMemberMocks) {
+				m.memberRepo.On("Delete", mock.Anything, "project-1", "user-1").Return(true, nil)
+			},
+		},
+		{
+			name:       "正常系: オーナーが他のメンバーを削除できること",
+			userUUID:   "owner-1",
+			memberUUID: "user-1",
+			setupMock: func(m *projectMemberMocks) {
+				m.projectRepo.On("FindByUUID", mock.Anything, "project-1").Return(project, nil)
+				m.memberRepo.On("Delete", mock.Anything, "project-1", "user-1").Return(true, nil)
+			},
+		},
+		{
+			name:       "異常系: editor が他のメンバーを削除しようとした場合エラー",
+			userUUID:   "user-2",
+			memberUUID: "user-1",
+			setupMock: func(m *projectMemberMocks) {
+				m.projectRepo.On("FindByUUID", mock.Anything, "project-1").Return(project, nil)
+				m.memberRepo.On("Find", mock.Anything, "project-1", "user-2").Return(&model.ProjectMember{Role: model.ProjectRoleEditor, Status: model.ProjectMemberStatusAccepted}, nil)
+			},
+			wantErr: model.ErrProjectRoleDenied,
+		},
+		{
+			name:       "異常系: メンバーが存在しない場合エラー",
+			userUUID:   "owner-1",
+			memberUUID: "unknown",
+			setupMock: func(m *projectMemberMocks) {
+				m.projectRepo.On("FindByUUID", mock.Anything, "project-1").Return(project, nil)
+				m.memberRepo.On("Delete", mock.Anything, "project-1", "unknown").Return(false, nil)
+			},
+			wantErr: model.ErrProjectMemberNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newProjectMemberMocks()
+			tt.setupMock(m)
+
+			err := m.usecase().RemoveMember(context.Background(), tt.userUUID, "project-1", tt.memberUUID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			m.memberRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProjectMemberUsecase_AcceptAndDeclineInvitation(t *testing.T) {
+	tests := []struct {
+		name      string
+		decline   bool
+		setupMock func(m *projectMemberMocks)
+		wantErr   error
+	}{
+		{
+			name: "正常系: 招待を承認できること",
+			setupMock: func(m *projectMemberMocks) {
+				m.memberRepo.On("Accept", mock.Anything, "project-1", "user-1").Return(true, nil)
+			},
+		},
+		{
+			name: "異常系: 招待が存在しない場合承認できないこと",
+			setupMock: func(m *projectMemberMocks) {
+				m.memberRepo.On("Accept", mock.Anything, "project-1", "user-1").Return(false, nil)
+			},
+			wantErr: model.ErrProjectMemberNotFound,
+		},
+		{
+			name:    "正常系: 招待を辞退できること",
+			decline: true,
+			setupMock: func(m *projectMemberMocks) {
+				m.memberRepo.On("Find", mock.Anything, "project-1", "user-1").Return(&model.ProjectMember{Status: model.ProjectMemberStatusPending}, nil)
+				m.memberRepo.On("Delete", mock.Anything, "project-1", "user-1").Return(true, nil)
+			},
+		},
+		{
+			name:    "異常系: 承認済みの招待は辞退できないこと",
+			decline: true,
+			setupMock: func(m *projectMemberMocks) {
+				m.memberRepo.On("Find", mock.Anything, "project-1", "user-1").Return(&model.ProjectMember{Status: model.ProjectMemberStatusAccepted}, nil)
+			},
+			wantErr: model.ErrProjectMemberNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newProjectMemberMocks()
+			tt.setupMock(m)
+
+			var err error
+			if tt.decline {
+				err = m.usecase().DeclineInvitation(context.Background(), "user-1", "project-1")
+			} else {
+				err = m.usecase().AcceptInvitation(context.Background(), "user-1", "project-1")
+			}
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			m.memberRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -1,9 +1,13 @@
 package usecase
 
 import (
+	"backend/config"
 	"backend/internal/domain/model"
+	"backend/internal/infrastructure/layout"
 	"context"
 	"errors"
+	"math"
+	"strings"
 	"testing"
 	"time"
 
@@ -29,6 +33,24 @@ func (m *mockProjectRepository) Create(ctx context.Context, project *model.Proje
 	return args.Error(0)
 }
 
+func (m *mockProjectRepository) FindByUUID(ctx context.Context, projectUUID string) (*model.Project, error) {
+	args := m.Called(ctx, projectUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Project), args.Error(1)
+}
+
+func (m *mockProjectRepository) UpdateLanguage(ctx context.Context, projectUUID string, language string) error {
+	args := m.Called(ctx, projectUUID, language)
+	return args.Error(0)
+}
+
+func (m *mockProjectRepository) ReassignUser(ctx context.Context, fromUserUUID string, toUserUUID string) error {
+	args := m.Called(ctx, fromUserUUID, toUserUUID)
+	return args.Error(0)
+}
+
 type mockChatRepository struct {
 	mock.Mock
 }
@@ -51,6 +73,11 @@ func (m *mockChatRepository) UpdateStatus(ctx context.Context, chatUUID string,
 	return args.Error(0)
 }
 
+func (m *mockChatRepository) TransitionStatus(ctx context.Context, chatUUID string, from string, to string) (bool, error) {
+	args := m.Called(ctx, chatUUID, from, to)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *mockChatRepository) FindOldestByProjectUUID(ctx context.Context, projectUUID string) (*model.Chat, error) {
 	args := m.Called(ctx, projectUUID)
 	if args.Get(0) == nil {
@@ -59,11 +86,52 @@ func (m *mockChatRepository) FindOldestByProjectUUID(ctx context.Context, projec
 	return args.Get(0).(*model.Chat), args.Error(1)
 }
 
+func (m *mockChatRepository) FindAllByProjectUUID(ctx context.Context, projectUUID string) ([]*model.Chat, error) {
+	args := m.Called(ctx, projectUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Chat), args.Error(1)
+}
+
 func (m *mockChatRepository) CountByProjectUUID(ctx context.Context, projectUUID string) (int64, error) {
 	args := m.Called(ctx, projectUUID)
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *mockChatRepository) AcquireGenerationLock(ctx context.Context, chatUUID string, owner string, now time.Time, until time.Time) (bool, error) {
+	args := m.Called(ctx, chatUUID, owner, now, until)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockChatRepository) ExtendGenerationLock(ctx context.Context, chatUUID string, owner string, until time.Time) (bool, error) {
+	args := m.Called(ctx, chatUUID, owner, until)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *mockChatRepository) FindByParentUUID(ctx context.Context, parentChatUUID string) ([]*model.Chat, error) {
+	args := m.Called(ctx, parentChatUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Chat), args.Error(1)
+}
+
+func (m *mockChatRepository) ReleaseGenerationLock(ctx context.Context, chatUUID string, owner string) error {
+	args := m.Called(ctx, chatUUID, owner)
+	return args.Error(0)
+}
+
+func (m *mockChatRepository) UpdateParent(ctx context.Context, chatUUID string, parentChatUUID string, sourceMessageUUID string, messageSelectionUUID *string) error {
+	args := m.Called(ctx, chatUUID, parentChatUUID, sourceMessageUUID, messageSelectionUUID)
+	return args.Error(0)
+}
+
+func (m *mockChatRepository) UpdateContextSummary(ctx context.Context, chatUUID string, summary string, promptVersion string) error {
+	args := m.Called(ctx, chatUUID, summary, promptVersion)
+	return args.Error(0)
+}
+
 type mockMessageRepository struct {
 	mock.Mock
 }
@@ -81,8 +149,24 @@ func (m *mockMessageRepository) FindMessagesByChatID(ctx context.Context, chatUU
 	return args.Get(0).([]*model.Message), args.Error(1)
 }
 
-func (m *mockMessageRepository) UpdateContextSummary(ctx context.Context, messageUUID string, summary string) error {
-	args := m.Called(ctx, messageUUID, summary)
+func (m *mockMessageRepository) FindMessagesByProjectUUID(ctx context.Context, projectUUID string) ([]*model.Message, error) {
+	args := m.Called(ctx, projectUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Message), args.Error(1)
+}
+
+func (m *mockMessageRepository) FindMessageStructureByProjectUUID(ctx context.Context, projectUUID string) ([]*model.Message, error) {
+	args := m.Called(ctx, projectUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Message), args.Error(1)
+}
+
+func (m *mockMessageRepository) UpdateContextSummary(ctx context.Context, messageUUID string, summary string, promptVersion string) error {
+	args := m.Called(ctx, messageUUID, summary, promptVersion)
 	return args.Error(0)
 }
 
@@ -110,6 +194,16 @@ func (m *mockMessageRepository) FindByID(ctx context.Context, uuid string) (*mod
 	return args.Get(0).(*model.Message), args.Error(1)
 }
 
+func (m *mockMessageRepository) Delete(ctx context.Context, uuid string) error {
+	args := m.Called(ctx, uuid)
+	return args.Error(0)
+}
+
+func (m *mockMessageRepository) UpdateContent(ctx context.Context, messageUUID string, content string) error {
+	args := m.Called(ctx, messageUUID, content)
+	return args.Error(0)
+}
+
 type mockEdgeRepository struct {
 	mock.Mock
 }
@@ -122,11 +216,49 @@ func (m *mockEdgeRepository) FindEdgesByChatID(ctx context.Context, chatUUID str
 	return args.Get(0).([]*model.Edge), args.Error(1)
 }
 
+func (m *mockEdgeRepository) FindEdgesByProjectUUID(ctx context.Context, projectUUID string) ([]*model.Edge, error) {
+	args := m.Called(ctx, projectUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Edge), args.Error(1)
+}
+
 func (m *mockEdgeRepository) Create(ctx context.Context, edge *model.Edge) error {
 	args := m.Called(ctx, edge)
 	return args.Error(0)
 }
 
+func (m *mockEdgeRepository) UpdateTarget(ctx context.Context, edgeUUID string, targetMessageUUID string) error {
+	args := m.Called(ctx, edgeUUID, targetMessageUUID)
+	return args.Error(0)
+}
+
+type mockTreeNodeStateRepository struct {
+	mock.Mock
+}
+
+func (m *mockTreeNodeStateRepository) FindByProjectUUID(ctx context.Context, projectUUID string) ([]*model.TreeNodeState, error) {
+	args := m.Called(ctx, projectUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.TreeNodeState), args.Error(1)
+}
+
+func (m *mockTreeNodeStateRepository) FindByNodeID(ctx context.Context, projectUUID string, nodeID string) (*model.TreeNodeState, error) {
+	args := m.Called(ctx, projectUUID, nodeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.TreeNodeState), args.Error(1)
+}
+
+func (m *mockTreeNodeStateRepository) SaveIfVersion(ctx context.Context, state *model.TreeNodeState, expectedVersion int) (bool, error) {
+	args := m.Called(ctx, state, expectedVersion)
+	return args.Bool(0), args.Error(1)
+}
+
 type mockTransactionManager struct {
 	mock.Mock
 }
@@ -141,50 +273,63 @@ func TestProjectUsecase_GetProjects(t *testing.T) {
 		userUUID string
 	}
 	tests := []struct {
-		name      string
-		args      args
-		setupMock func(m *mockProjectRepository)
-		wantCount int
-		wantErr   bool
+		name       string
+		args       args
+		setupMock  func(m *mockProjectRepository, mMember *mockProjectMemberRepository)
+		wantOwned  int
+		wantShared int
+		wantErr    bool
 	}{
 		{
-			name: "正常系: プロジェクト一覧が取得できること",
+			name: "正常系: 作成したプロジェクトと共有されたプロジェクトが分けて取得できること",
 			args: args{
 				userUUID: "user-1",
 			},
-			setupMock: func(m *mockProjectRepository) {
+			setupMock: func(m *mockProjectRepository, mMember *mockProjectMemberRepository) {
 				projects := []*model.Project{
 					{UUID: "p1", UserUUID: "user-1", Title: "Project 1", UpdatedAt: time.Now()},
 					{UUID: "p2", UserUUID: "user-1", Title: "Project 2", UpdatedAt: time.Now()},
 				}
 				m.On("FindAllByUserUUID", mock.Anything, "user-1").Return(projects, nil)
+				shared := []*model.SharedProject{
+					{Project: &model.Project{UUID: "p3", UserUUID: "user-2", Title: "Project 3"}, Role: model.ProjectRoleViewer, Status: model.ProjectMemberStatusAccepted},
+				}
+				mMember.On("FindSharedByUserUUID", mock.Anything, "user-1", model.ProjectMemberStatusAccepted).Return(shared, nil)
 			},
-			wantCount: 2,
-			wantErr:   false,
+			wantOwned:  2,
+			wantShared: 1,
+			wantErr:    false,
 		},
 		{
 			name: "異常系: リポジトリでエラーが発生した場合エラーになること",
 			args: args{
 				userUUID: "user-error",
 			},
-			setupMock: func(m *mockProjectRepository) {
+			setupMock: func(m *mockProjectRepository, mMember *mockProjectMemberRepository) {
 				m.On("FindAllByUserUUID", mock.Anything, "user-error").Return(nil, errors.New("db error"))
 			},
-			wantCount: 0,
-			wantErr:   true,
+			wantErr: true,
+		},
+		{
+			name: "異常系: 共有プロジェクトの取得でエラーが発生した場合エラーになること",
+			args: args{
+				userUUID: "user-1",
+			},
+			setupMock: func(m *mockProjectRepository, mMember *mockProjectMemberRepository) {
+				m.On("FindAllByUserUUID", mock.Anything, "user-1").Return([]*model.Project{}, nil)
+				mMember.On("FindSharedByUserUUID", mock.Anything, "user-1", model.ProjectMemberStatusAccepted).Return(nil, errors.New("db error"))
+			},
+			wantErr: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(mockProjectRepository)
-			mockChatRepo := new(mockChatRepository)
-			mockMessageRepo := new(mockMessageRepository)
-			mockEdgeRepo := new(mockEdgeRepository)
-			mockTxManager := new(mockTransactionManager)
-			tt.setupMock(mockRepo)
+			mockMemberRepo := new(mockProjectMemberRepository)
+			tt.setupMock(mockRepo, mockMemberRepo)
 
-			u := NewProjectUsecase(mockRepo, mockChatRepo, mockMessageRepo, mockEdgeRepo, mockTxManager)
+			u := NewProjectUsecase(mockRepo, mockMemberRepo, new(mockChatRepository), new(mockMessageRepository), new(mockEdgeRepository), new(mockTreeNodeStateRepository), new(mockTransactionManager), nil)
 			got, err := u.GetProjects(context.Background(), tt.args.userUUID)
 
 			if (err != nil) != tt.wantErr {
@@ -192,9 +337,11 @@ func TestProjectUsecase_GetProjects(t *testing.T) {
 				return
 			}
 			if !tt.wantErr {
-				assert.Len(t, got, tt.wantCount)
+				assert.Len(t, got.Owned, tt.wantOwned)
+				assert.Len(t, got.Shared, tt.wantShared)
 			}
 			mockRepo.AssertExpectations(t)
+			mockMemberRepo.AssertExpectations(t)
 		})
 	}
 }
@@ -275,7 +422,7 @@ func TestProjectUsecase_CreateProject(t *testing.T) {
 			mockTxManager := new(mockTransactionManager)
 			tt.setupMock(mockRepo, mockChatRepo, mockMessageRepo, mockTxManager)
 
-			u := NewProjectUsecase(mockRepo, mockChatRepo, mockMessageRepo, mockEdgeRepo, mockTxManager)
+			u := NewProjectUsecase(mockRepo, new(mockProjectMemberRepository), mockChatRepo, mockMessageRepo, mockEdgeRepo, new(mockTreeNodeStateRepository), mockTxManager, nil)
 			p, c, m, err := u.CreateProject(context.Background(), tt.args.userUUID, tt.args.initialMessage)
 
 			if (err != nil) != tt.wantErr {
@@ -343,7 +490,7 @@ func TestProjectUsecase_GetParentChat(t *testing.T) {
 			mockTxManager := new(mockTransactionManager)
 			tt.setupMock(mockRepo, mockChatRepo, mockMessageRepo, mockTxManager)
 
-			u := NewProjectUsecase(mockRepo, mockChatRepo, mockMessageRepo, mockEdgeRepo, mockTxManager)
+			u := NewProjectUsecase(mockRepo, new(mockProjectMemberRepository), mockChatRepo, mockMessageRepo, mockEdgeRepo, new(mockTreeNodeStateRepository), mockTxManager, nil)
 			got, err := u.GetParentChat(context.Background(), tt.args.projectUUID)
 
 			if (err != nil) != tt.wantErr {
@@ -359,11 +506,12 @@ func TestProjectUsecase_GetParentChat(t *testing.T) {
 
 func TestProjectUsecase_GetProjectTree(t *testing.T) {
 	type mocks struct {
-		projectRepo *mockProjectRepository
-		chatRepo    *mockChatRepository
-		messageRepo *mockMessageRepository
-		edgeRepo    *mockEdgeRepository
-		txManager   *mockTransactionManager
+		projectRepo       *mockProjectRepository
+		chatRepo          *mockChatRepository
+		messageRepo       *mockMessageRepository
+		edgeRepo          *mockEdgeRepository
+		txManager         *mockTransactionManager
+		treeNodeStateRepo *mockTreeNodeStateRepository
 	}
 	type args struct {
 		projectUUID string
@@ -381,48 +529,43 @@ func TestProjectUsecase_GetProjectTree(t *testing.T) {
 				projectUUID: "project-uuid",
 			},
 			setupMock: func(m *mocks) {
-				// 1. Root Chat
-				m.chatRepo.On("FindOldestByProjectUUID", mock.Anything, "project-uuid").Return(&model.Chat{UUID: "root-chat"}, nil)
+				// 1. プロジェクトのチャット (作成順)
+				m.chatRepo.On("FindAllByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Chat{
+					{UUID: "root-chat"},
+					{UUID: "child-chat"},
+				}, nil)
 
-				// 2. Root Chat Messages
-				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "root-chat").Return([]*model.Message{
+				// 2. プロジェクトのメッセージ
+				m.messageRepo.On("FindMessagesByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Message{
 					{
-						UUID:    "msg-1",
-						Role:    "user",
-						Content: "user prompt",
-						Forks:   []model.Fork{},
+						UUID:     "msg-1",
+						ChatUUID: "root-chat",
+						Role:     "user",
+						Content:  "user prompt",
+						Forks:    []model.Fork{},
 					},
 					{
-						UUID:      "msg-2",
-						Role:      "assistant",
-						Content:   "ai response",
-						PositionX: 100,
-						PositionY: 200,
+						UUID:     "msg-2",
+						ChatUUID: "root-chat",
+						Role:     "assistant",
+						Content:  "ai response",
 						Forks: []model.Fork{
 							{ChatUUID: "child-chat"},
 						},
 					},
-				}, nil)
-
-				// 3. Root Chat Edges
-				m.edgeRepo.On("FindEdgesByChatID", mock.Anything, "root-chat").Return([]*model.Edge{
-					{UUID: "edge-1", SourceMessageUUID: "msg-2", TargetMessageUUID: "msg-3"},
-				}, nil)
-
-				// 4. Child Chat Messages
-				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "child-chat").Return([]*model.Message{
 					{
-						UUID:      "msg-3",
-						Role:      "assistant",
-						Content:   "child response",
-						PositionX: 300,
-						PositionY: 400,
-						Forks:     []model.Fork{},
+						UUID:     "msg-3",
+						ChatUUID: "child-chat",
+						Role:     "assistant",
+						Content:  "child response",
+						Forks:    []model.Fork{},
 					},
 				}, nil)
 
-				// 5. Child Chat Edges
-				m.edgeRepo.On("FindEdgesByChatID", mock.Anything, "child-chat").Return([]*model.Edge{}, nil)
+				// 3. プロジェクトのエッジ
+				m.edgeRepo.On("FindEdgesByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Edge{
+					{UUID: "edge-1", ChatUUID: "root-chat", SourceMessageUUID: "msg-2", TargetMessageUUID: "msg-3"},
+				}, nil)
 			},
 			want: &model.ProjectTree{
 				Nodes: []model.ProjectNode{
@@ -450,12 +593,104 @@ func TestProjectUsecase_GetProjectTree(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "異常系: ルートチャットが見つからない場合エラー",
+			name: "正常系: ユーザーメッセージからのフォークは回答とまとめたノードにつながること",
+			args: args{
+				projectUUID: "project-uuid",
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindAllByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Chat{
+					{UUID: "root-chat"},
+					{UUID: "child-chat"},
+					{UUID: "pending-child-chat"},
+				}, nil)
+				m.messageRepo.On("FindMessagesByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Message{
+					{
+						UUID:     "msg-1",
+						ChatUUID: "root-chat",
+						Role:     "user",
+						Content:  "user prompt",
+						Forks: []model.Fork{
+							{ChatUUID: "child-chat", WholeMessage: true},
+						},
+					},
+					{
+						UUID:     "msg-2",
+						ChatUUID: "root-chat",
+						Role:     "assistant",
+						Content:  "ai response",
+					},
+					{UUID: "msg-3", ChatUUID: "child-chat", Role: "assistant", Content: "child response"},
+					{
+						UUID:     "msg-4",
+						ChatUUID: "root-chat",
+						Role:     "user",
+						Content:  "pending question",
+						Forks: []model.Fork{
+							{ChatUUID: "pending-child-chat", WholeMessage: true},
+						},
+					},
+					{UUID: "msg-5", ChatUUID: "pending-child-chat", Role: "assistant", Content: "pending child response"},
+				}, nil)
+				m.edgeRepo.On("FindEdgesByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Edge{
+					{UUID: "edge-1", ChatUUID: "child-chat", SourceMessageUUID: "msg-3", TargetMessageUUID: "msg-1"},
+					{UUID: "edge-2", ChatUUID: "pending-child-chat", SourceMessageUUID: "msg-5", TargetMessageUUID: "msg-4"},
+				}, nil)
+			},
+			want: &model.ProjectTree{
+				Nodes: []model.ProjectNode{
+					{
+						ID: "msg-2",
+						Data: model.ProjectNodeData{
+							UserMessage: func() *string { s := "user prompt"; return &s }(),
+							Assistant:   "ai response",
+						},
+						Position: model.ProjectNodePosition{X: 100, Y: 200},
+					},
+					{
+						ID: "msg-4",
+						Data: model.ProjectNodeData{
+							UserMessage: func() *string { s := "pending question"; return &s }(),
+						},
+						Position: model.ProjectNodePosition{X: 100, Y: 500},
+					},
+					{
+						ID:       "msg-3",
+						Data:     model.ProjectNodeData{Assistant: "child response"},
+						Position: model.ProjectNodePosition{X: 300, Y: 400},
+					},
+					{
+						ID:       "msg-5",
+						Data:     model.ProjectNodeData{Assistant: "pending child response"},
+						Position: model.ProjectNodePosition{X: 300, Y: 700},
+					},
+				},
+				Edges: []model.ProjectEdge{
+					{ID: "edge-1", Source: "msg-3", Target: "msg-2"},
+					{ID: "edge-2", Source: "msg-5", Target: "msg-4"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常系: チャット取得失敗",
+			args: args{
+				projectUUID: "project-uuid",
+			},
+			setupMock: func(m *mocks) {
+				m.chatRepo.On("FindAllByProjectUUID", mock.Anything, "project-uuid").Return(nil, errors.New("db error"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "異常系: プロジェクトにチャットがない場合エラー",
 			args: args{
 				projectUUID: "project-uuid",
 			},
 			setupMock: func(m *mocks) {
-				m.chatRepo.On("FindOldestByProjectUUID", mock.Anything, "project-uuid").Return(nil, errors.New("not found"))
+				m.chatRepo.On("FindAllByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Chat{}, nil)
+				m.messageRepo.On("FindMessagesByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Message{}, nil)
+				m.edgeRepo.On("FindEdgesByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Edge{}, nil)
 			},
 			want:    nil,
 			wantErr: true,
@@ -466,8 +701,8 @@ func TestProjectUsecase_GetProjectTree(t *testing.T) {
 				projectUUID: "project-uuid",
 			},
 			setupMock: func(m *mocks) {
-				m.chatRepo.On("FindOldestByProjectUUID", mock.Anything, "project-uuid").Return(&model.Chat{UUID: "root-chat"}, nil)
-				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "root-chat").Return(nil, errors.New("db error"))
+				m.chatRepo.On("FindAllByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Chat{{UUID: "root-chat"}}, nil)
+				m.messageRepo.On("FindMessagesByProjectUUID", mock.Anything, "project-uuid").Return(nil, errors.New("db error"))
 			},
 			want:    nil,
 			wantErr: true,
@@ -478,9 +713,9 @@ func TestProjectUsecase_GetProjectTree(t *testing.T) {
 				projectUUID: "project-uuid",
 			},
 			setupMock: func(m *mocks) {
-				m.chatRepo.On("FindOldestByProjectUUID", mock.Anything, "project-uuid").Return(&model.Chat{UUID: "root-chat"}, nil)
-				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "root-chat").Return([]*model.Message{}, nil)
-				m.edgeRepo.On("FindEdgesByChatID", mock.Anything, "root-chat").Return(nil, errors.New("db error"))
+				m.chatRepo.On("FindAllByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Chat{{UUID: "root-chat"}}, nil)
+				m.messageRepo.On("FindMessagesByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Message{}, nil)
+				m.edgeRepo.On("FindEdgesByProjectUUID", mock.Anything, "project-uuid").Return(nil, errors.New("db error"))
 			},
 			want:    nil,
 			wantErr: true,
@@ -490,17 +725,19 @@ func TestProjectUsecase_GetProjectTree(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			m := &mocks{
-				projectRepo: &mockProjectRepository{},
-				chatRepo:    &mockChatRepository{},
-				messageRepo: &mockMessageRepository{},
-				edgeRepo:    &mockEdgeRepository{},
-				txManager:   &mockTransactionManager{},
+				projectRepo:       &mockProjectRepository{},
+				chatRepo:          &mockChatRepository{},
+				messageRepo:       &mockMessageRepository{},
+				edgeRepo:          &mockEdgeRepository{},
+				txManager:         &mockTransactionManager{},
+				treeNodeStateRepo: &mockTreeNodeStateRepository{},
 			}
 			tt.setupMock(m)
+			m.treeNodeStateRepo.On("FindByProjectUUID", mock.Anything, tt.args.projectUUID).Return([]*model.TreeNodeState{}, nil).Maybe()
 
-			u := NewProjectUsecase(m.projectRepo, m.chatRepo, m.messageRepo, m.edgeRepo, m.txManager)
+			u := NewProjectUsecase(m.projectRepo, new(mockProjectMemberRepository), m.chatRepo, m.messageRepo, m.edgeRepo, m.treeNodeStateRepo, m.txManager, nil)
 
-			got, err := u.GetProjectTree(context.Background(), tt.args.projectUUID)
+			got, err := u.GetProjectTree(context.Background(), tt.args.projectUUID, model.ProjectTreeQuery{})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("projectUsecase.GetProjectTree() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -512,3 +749,737 @@ func TestProjectUsecase_GetProjectTree(t *testing.T) {
 		})
 	}
 }
+
+func TestProjectUsecase_UpdateLanguage(t *testing.T) {
+	type args struct {
+		projectUUID string
+		language    string
+	}
+	tests := []struct {
+		name      string
+		args      args
+		setupMock func(mRepo *mockProjectRepository)
+		wantErr   error
+	}{
+		{
+			name: "正常系: 出力言語が更新できること",
+			args: args{
+				projectUUID: "project-uuid",
+				language:    model.LanguageEnglish,
+			},
+			setupMock: func(mRepo *mockProjectRepository) {
+				mRepo.On("FindByUUID", mock.Anything, "project-uuid").Return(&model.Project{UUID: "project-uuid"}, nil)
+				mRepo.On("UpdateLanguage", mock.Anything, "project-uuid", model.LanguageEnglish).Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "正常系: 空文字の場合は既定の言語に戻すこと",
+			args: args{
+				projectUUID: "project-uuid",
+				language:    "",
+			},
+			setupMock: func(mRepo *mockProjectRepository) {
+				mRepo.On("FindByUUID", mock.Anything, "project-uuid").Return(&model.Project{UUID: "project-uuid"}, nil)
+				mRepo.On("UpdateLanguage", mock.Anything, "project-uuid", "").Return(nil)
+			},
+			wantErr: nil,
+		},
+		{
+			name: "異常系: 対応していない言語の場合エラーになること",
+			args: args{
+				projectUUID: "project-uuid",
+				language:    "fr",
+			},
+			setupMock: func(mRepo *mockProjectRepository) {
+				// 呼び出されない
+			},
+			wantErr: model.ErrUnsupportedLanguage,
+		},
+		{
+			name: "異常系: プロジェクトが存在しない場合エラーになること",
+			args: args{
+				projectUUID: "project-error",
+				language:    model.LanguageJapanese,
+			},
+			setupMock: func(mRepo *mockProjectRepository) {
+				mRepo.On("FindByUUID", mock.Anything, "project-error").Return(nil, errors.New("not found"))
+			},
+			wantErr: errors.New("not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mockProjectRepository)
+			tt.setupMock(mockRepo)
+
+			u := NewProjectUsecase(mockRepo, new(mockProjectMemberRepository), new(mockChatRepository), new(mockMessageRepository), new(mockEdgeRepository), new(mockTreeNodeStateRepository), new(mockTransactionManager), nil)
+			err := u.UpdateLanguage(context.Background(), tt.args.projectUUID, tt.args.language)
+
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else if errors.Is(tt.wantErr, model.ErrUnsupportedLanguage) {
+				assert.ErrorIs(t, err, model.ErrUnsupportedLanguage)
+			} else {
+				assert.Error(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+type mockTreeLayouter struct {
+	mock.Mock
+}
+
+func (m *mockTreeLayouter) Layout(tree *model.ProjectTree) {
+	m.Called(tree)
+}
+
+func TestProjectUsecase_GetProjectTree_Layout(t *testing.T) {
+	chatRepo := &mockChatRepository{}
+	messageRepo := &mockMessageRepository{}
+	edgeRepo := &mockEdgeRepository{}
+	layouter := &mockTreeLayouter{}
+
+	chatRepo.On("FindAllByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Chat{{UUID: "root-chat"}}, nil)
+	messageRepo.On("FindMessagesByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Message{
+		{UUID: "msg-1", ChatUUID: "root-chat", Role: "assistant", Content: "answer"},
+	}, nil)
+	edgeRepo.On("FindEdgesByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Edge{}, nil)
+	treeNodeStateRepo := &mockTreeNodeStateRepository{}
+	treeNodeStateRepo.On("FindByProjectUUID", mock.Anything, "project-uuid").Return([]*model.TreeNodeState{}, nil)
+	// レイアウトで計算した位置を返すこと
+	layouter.On("Layout", mock.Anything).Run(func(args mock.Arguments) {
+		tree := args.Get(0).(*model.ProjectTree)
+		tree.Nodes[0].Position = model.ProjectNodePosition{X: 0, Y: 0}
+	}).Once()
+
+	u := NewProjectUsecase(&mockProjectRepository{}, new(mockProjectMemberRepository), chatRepo, messageRepo, edgeRepo, treeNodeStateRepo, &mockTransactionManager{}, layouter)
+	got, err := u.GetProjectTree(context.Background(), "project-uuid", model.ProjectTreeQuery{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.ProjectNodePosition{X: 0, Y: 0}, got.Nodes[0].Position)
+	layouter.AssertExpectations(t)
+}
+
+func TestProjectUsecase_GetProjectTree_NodeStates(t *testing.T) {
+	chatRepo := &mockChatRepository{}
+	messageRepo := &mockMessageRepository{}
+	edgeRepo := &mockEdgeRepository{}
+	treeNodeStateRepo := &mockTreeNodeStateRepository{}
+	layouter := &mockTreeLayouter{}
+
+	chatRepo.On("FindAllByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Chat{{UUID: "root-chat"}}, nil)
+	messageRepo.On("FindMessagesByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Message{
+		{UUID: "msg-1", ChatUUID: "root-chat", Role: "assistant", Content: "answer 1"},
+		{UUID: "msg-2", ChatUUID: "root-chat", Role: "assistant", Content: "answer 2"},
+		{UUID: "msg-3", ChatUUID: "root-chat", Role: "assistant", Content: "answer 3"},
+	}, nil)
+	edgeRepo.On("FindEdgesByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Edge{}, nil)
+	layouter.On("Layout", mock.Anything).Run(func(args mock.Arguments) {
+		tree := args.Get(0).(*model.ProjectTree)
+		for i := range tree.Nodes {
+			tree.Nodes[i].Position = model.ProjectNodePosition{X: 0, Y: float64(i) * 150}
+		}
+	})
+	treeNodeStateRepo.On("FindByProjectUUID", mock.Anything, "project-uuid").Return([]*model.TreeNodeState{
+		{NodeID: "msg-1", Position: &model.ProjectNodePosition{X: 500, Y: 40}, Version: 3},
+		{NodeID: "msg-2", Collapsed: true, Version: 1},
+		{NodeID: "deleted", Position: &model.ProjectNodePosition{X: 1, Y: 1}, Version: 1},
+	}, nil)
+
+	u := NewProjectUsecase(&mockProjectRepository{}, new(mockProjectMemberRepository), chatRepo, messageRepo, edgeRepo, treeNodeStateRepo, &mockTransactionManager{}, layouter)
+	got, err := u.GetProjectTree(context.Background(), "project-uuid", model.ProjectTreeQuery{})
+
+	assert.NoError(t, err)
+	if assert.Len(t, got.Nodes, 3) {
+		// 移動したノードは自動レイアウトより移動した位置を優先すること
+		assert.Equal(t, model.ProjectNodePosition{X: 500, Y: 40}, got.Nodes[0].Position)
+		assert.True(t, got.Nodes[0].Pinned)
+		assert.Equal(t, 3, got.Nodes[0].Version)
+		// 折りたたんだだけのノードは自動レイアウトの位置のままにすること
+		assert.Equal(t, model.ProjectNodePosition{X: 0, Y: 150}, got.Nodes[1].Position)
+		assert.False(t, got.Nodes[1].Pinned)
+		assert.True(t, got.Nodes[1].Collapsed)
+		// 表示状態を保存していないノードはバージョン 0 になること
+		assert.Equal(t, model.ProjectNodePosition{X: 0, Y: 300}, got.Nodes[2].Position)
+		assert.Equal(t, 0, got.Nodes[2].Version)
+	}
+}
+
+// 取得条件のテスト用に、ルートから a・b (closed) が分岐し、さらに c・d が分岐したツリーを返すモックを設定する処理
+func setupTreeQueryMocks(chatRepo *mockChatRepository, messageRepo *mockMessageRepository, edgeRepo *mockEdgeRepository, treeNodeStateRepo *mockTreeNodeStateRepository) {
+	chatRepo.On("FindAllByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Chat{
+		{UUID: "root-chat", Title: "ルート", Status: model.ChatStatusOpen},
+		{UUID: "chat-a", Title: "A", Status: model.ChatStatusOpen},
+		{UUID: "chat-b", Title: "B", Status: model.ChatStatusClosed},
+		{UUID: "chat-c", Title: "C", Status: model.ChatStatusOpen},
+		{UUID: "chat-d", Title: "D", Status: model.ChatStatusMerged},
+	}, nil)
+	messageRepo.On("FindMessagesByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Message{
+		{UUID: "root-1", ChatUUID: "root-chat", Role: "user", Content: "質問"},
+		{UUID: "root-2", ChatUUID: "root-chat", Role: "assistant", Content: strings.Repeat("長", 300), Forks: []model.Fork{{ChatUUID: "chat-a"}, {ChatUUID: "chat-b"}}},
+		{UUID: "a-1", ChatUUID: "chat-a", Role: "user", Content: "A の質問", Forks: []model.Fork{{ChatUUID: "chat-c"}}},
+		{UUID: "a-2", ChatUUID: "chat-a", Role: "assistant", Content: "A の回答"},
+		{UUID: "b-1", ChatUUID: "chat-b", Role: "assistant", Content: "B の回答", Forks: []model.Fork{{ChatUUID: "chat-d"}}},
+		{UUID: "c-1", ChatUUID: "chat-c", Role: "assistant", Content: "C の回答"},
+		{UUID: "d-1", ChatUUID: "chat-d", Role: "assistant", Content: "D の回答"},
+	}, nil)
+	edgeRepo.On("FindEdgesByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Edge{
+		{UUID: "edge-a", ChatUUID: "chat-a", SourceMessageUUID: "a-1", TargetMessageUUID: "root-2"},
+		{UUID: "edge-b", ChatUUID: "chat-b", SourceMessageUUID: "b-1", TargetMessageUUID: "root-2"},
+		{UUID: "edge-c", ChatUUID: "chat-c", SourceMessageUUID: "c-1", TargetMessageUUID: "a-1"},
+		{UUID: "edge-d", ChatUUID: "chat-d", SourceMessageUUID: "d-1", TargetMessageUUID: "b-1"},
+	}, nil)
+	treeNodeStateRepo.On("FindByProjectUUID", mock.Anything, "project-uuid").Return([]*model.TreeNodeState{}, nil)
+}
+
+func TestProjectUsecase_GetProjectTree_Query(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+	tests := []struct {
+		name          string
+		query         model.ProjectTreeQuery
+		wantNodes     []string
+		wantEdges     []string
+		wantHasMore   []string
+		wantTruncated []string
+		wantErr       error
+	}{
+		{
+			name:      "正常系: 取得条件を指定しない場合はツリー全体を返すこと",
+			query:     model.ProjectTreeQuery{},
+			wantNodes: []string{"root-2", "a-2", "b-1", "c-1", "d-1"},
+			wantEdges: []string{"edge-a", "edge-b", "edge-c", "edge-d"},
+		},
+		{
+			name:        "正常系: 階層数が0の場合は起点のチャットのみを返し、子チャットがあることを示すこと",
+			query:       model.ProjectTreeQuery{MaxDepth: intPtr(0)},
+			wantNodes:   []string{"root-2"},
+			wantEdges:   []string{},
+			wantHasMore: []string{"root-2"},
+		},
+		{
+			name:        "正常系: 階層数の分だけフォークを辿ること",
+			query:       model.ProjectTreeQuery{MaxDepth: intPtr(1)},
+			wantNodes:   []string{"root-2", "a-2", "b-1"},
+			wantEdges:   []string{"edge-a", "edge-b"},
+			wantHasMore: []string{"a-2", "b-1"},
+		},
+		{
+			name:      "正常系: 除外したステータスのチャットとその子孫を含めないこと",
+			query:     model.ProjectTreeQuery{ExcludeStatuses: []string{model.ChatStatusClosed, model.ChatStatusMerged}},
+			wantNodes: []string{"root-2", "a-2", "c-1"},
+			wantEdges: []string{"edge-a", "edge-c"},
+		},
+		{
+			name:        "正常系: 指定したチャットを起点にできること",
+			query:       model.ProjectTreeQuery{ChatUUID: "chat-a", MaxDepth: intPtr(0)},
+			wantNodes:   []string{"a-2"},
+			wantEdges:   []string{},
+			wantHasMore: []string{"a-2"},
+		},
+		{
+			name:      "正常系: 起点のチャットが除外したステータスの場合は空のツリーを返すこと",
+			query:     model.ProjectTreeQuery{ChatUUID: "chat-b", ExcludeStatuses: []string{model.ChatStatusClosed}},
+			wantNodes: []string{},
+			wantEdges: []string{},
+		},
+		{
+			name:          "正常系: 要約のみの場合は長い本文を省略すること",
+			query:         model.ProjectTreeQuery{SummaryOnly: true},
+			wantNodes:     []string{"root-2", "a-2", "b-1", "c-1", "d-1"},
+			wantEdges:     []string{"edge-a", "edge-b", "edge-c", "edge-d"},
+			wantTruncated: []string{"root-2"},
+		},
+		{
+			name:    "異常系: 階層数が負の場合はエラー",
+			query:   model.ProjectTreeQuery{MaxDepth: intPtr(-1)},
+			wantErr: model.ErrInvalidTreeQuery,
+		},
+		{
+			name:    "異常系: 不正なステータスを指定した場合はエラー",
+			query:   model.ProjectTreeQuery{ExcludeStatuses: []string{"archived"}},
+			wantErr: model.ErrInvalidTreeQuery,
+		},
+		{
+			name:    "異常系: プロジェクトにないチャットを起点にした場合はエラー",
+			query:   model.ProjectTreeQuery{ChatUUID: "other-chat"},
+			wantErr: model.ErrInvalidTreeQuery,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chatRepo := &mockChatRepository{}
+			messageRepo := &mockMessageRepository{}
+			edgeRepo := &mockEdgeRepository{}
+			treeNodeStateRepo := &mockTreeNodeStateRepository{}
+			setupTreeQueryMocks(chatRepo, messageRepo, edgeRepo, treeNodeStateRepo)
+
+			u := NewProjectUsecase(&mockProjectRepository{}, new(mockProjectMemberRepository), chatRepo, messageRepo, edgeRepo, treeNodeStateRepo, &mockTransactionManager{}, nil)
+			got, err := u.GetProjectTree(context.Background(), "project-uuid", tt.query)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+
+			nodes := []string{}
+			hasMore := []string{}
+			truncated := []string{}
+			for _, node := range got.Nodes {
+				nodes = append(nodes, node.ID)
+				if node.HasMoreChildren {
+					hasMore = append(hasMore, node.ID)
+				}
+				if node.Truncated {
+					truncated = append(truncated, node.ID)
+					assert.Len(t, []rune(node.Data.Assistant), treeNodeSummaryLength+1)
+				}
+			}
+			edges := []string{}
+			for _, edge := range got.Edges {
+				edges = append(edges, edge.ID)
+			}
+			assert.Equal(t, tt.wantNodes, nodes)
+			assert.ElementsMatch(t, tt.wantEdges, edges)
+			assert.ElementsMatch(t, tt.wantHasMore, hasMore)
+			assert.ElementsMatch(t, tt.wantTruncated, truncated)
+		})
+	}
+}
+
+func TestProjectUsecase_GetProjectTreeNode(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+	// ルートのチャットは 質問 -> 回答 -> フォークの起点になった回答のない質問 の順
+	rootMessages := []*model.Message{
+		{UUID: "root-1", ChatUUID: "root-chat", Role: "user", Content: "質問"},
+		{UUID: "root-2", ChatUUID: "root-chat", Role: "assistant", Content: strings.Repeat("長", 300)},
+		{UUID: "root-3", ChatUUID: "root-chat", Role: "user", Content: "続きの質問", Forks: []model.Fork{{ChatUUID: "chat-a"}}},
+	}
+	rootChat := &model.Chat{UUID: "root-chat", ProjectUUID: "project-uuid", Title: "ルート"}
+
+	type mocks struct {
+		chatRepo          *mockChatRepository
+		messageRepo       *mockMessageRepository
+		edgeRepo          *mockEdgeRepository
+		treeNodeStateRepo *mockTreeNodeStateRepository
+		layouter          *mockTreeLayouter
+	}
+	// ノードのメッセージとチャットを返すモックを設定する処理
+	setupNodeMocks := func(m mocks, nodeID string) {
+		for _, msg := range rootMessages {
+			if msg.UUID == nodeID {
+				m.messageRepo.On("FindByID", mock.Anything, nodeID).Return(msg, nil)
+			}
+		}
+		m.chatRepo.On("FindByID", mock.Anything, "root-chat").Return(rootChat, nil)
+		m.messageRepo.On("FindMessagesByChatID", mock.Anything, "root-chat").Return(rootMessages, nil)
+	}
+	// 位置の計算に使うツリーの構造 (本文を除いたメッセージ) を返すモックを設定する処理
+	setupStructureMocks := func(m mocks) {
+		structure := make([]*model.Message, len(rootMessages))
+		for i, msg := range rootMessages {
+			structure[i] = &model.Message{UUID: msg.UUID, ChatUUID: msg.ChatUUID, Role: msg.Role, Forks: msg.Forks}
+		}
+		m.chatRepo.On("FindAllByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Chat{rootChat}, nil)
+		m.messageRepo.On("FindMessageStructureByProjectUUID", mock.Anything, "project-uuid").Return(structure, nil)
+		m.edgeRepo.On("FindEdgesByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Edge{
+			{ChatUUID: "root-chat", SourceMessageUUID: "root-3", TargetMessageUUID: "root-2"},
+		}, nil)
+		m.layouter.On("Layout", mock.Anything).Run(func(args mock.Arguments) {
+			tree := args.Get(0).(*model.ProjectTree)
+			for i := range tree.Nodes {
+				tree.Nodes[i].Position = model.ProjectNodePosition{X: 200, Y: float64(i+1) * 150}
+			}
+		}).Once()
+	}
+
+	tests := []struct {
+		name      string
+		nodeID    string
+		setupMock func(m mocks)
+		want      *model.ProjectNode
+		wantErr   error
+	}{
+		{
+			name:   "正常系: ノードの本文を省略せずに、現在のツリーの構造から計算した位置で取得できること",
+			nodeID: "root-2",
+			setupMock: func(m mocks) {
+				setupNodeMocks(m, "root-2")
+				m.treeNodeStateRepo.On("FindByNodeID", mock.Anything, "project-uuid", "root-2").Return(nil, nil)
+				setupStructureMocks(m)
+			},
+			want: &model.ProjectNode{
+				ID: "root-2", ChatUUID: "root-chat", Title: "ルート",
+				Data:     model.ProjectNodeData{UserMessage: strPtr("質問"), Assistant: strings.Repeat("長", 300)},
+				Position: model.ProjectNodePosition{X: 200, Y: 150},
+			},
+		},
+		{
+			name:   "正常系: ユーザーが移動したノードは保存されている位置で取得できること",
+			nodeID: "root-2",
+			setupMock: func(m mocks) {
+				setupNodeMocks(m, "root-2")
+				m.treeNodeStateRepo.On("FindByNodeID", mock.Anything, "project-uuid", "root-2").Return(&model.TreeNodeState{
+					NodeID: "root-2", ProjectUUID: "project-uuid", Position: &model.ProjectNodePosition{X: 10, Y: 20}, Collapsed: true, Version: 2,
+				}, nil)
+			},
+			want: &model.ProjectNode{
+				ID: "root-2", ChatUUID: "root-chat", Title: "ルート",
+				Data:     model.ProjectNodeData{UserMessage: strPtr("質問"), Assistant: strings.Repeat("長", 300)},
+				Position: model.ProjectNodePosition{X: 10, Y: 20}, Pinned: true, Collapsed: true, Version: 2,
+			},
+		},
+		{
+			name:   "正常系: 回答のないユーザーメッセージでもフォークの起点であれば取得できること",
+			nodeID: "root-3",
+			setupMock: func(m mocks) {
+				setupNodeMocks(m, "root-3")
+				m.treeNodeStateRepo.On("FindByNodeID", mock.Anything, "project-uuid", "root-3").Return(nil, nil)
+				setupStructureMocks(m)
+			},
+			want: &model.ProjectNode{
+				ID: "root-3", ChatUUID: "root-chat", Title: "ルート",
+				Data:     model.ProjectNodeData{UserMessage: strPtr("続きの質問")},
+				Position: model.ProjectNodePosition{X: 200, Y: 300},
+			},
+		},
+		{
+			name:   "異常系: ツリーの構造の取得に失敗した場合はエラー",
+			nodeID: "root-2",
+			setupMock: func(m mocks) {
+				setupNodeMocks(m, "root-2")
+				m.treeNodeStateRepo.On("FindByNodeID", mock.Anything, "project-uuid", "root-2").Return(nil, nil)
+				m.chatRepo.On("FindAllByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Chat{rootChat}, nil)
+				m.messageRepo.On("FindMessageStructureByProjectUUID", mock.Anything, "project-uuid").Return(nil, errors.New("db error"))
+			},
+			wantErr: errors.New("db error"),
+		},
+		{
+			name:   "異常系: 存在しないメッセージの場合はエラー",
+			nodeID: "missing",
+			setupMock: func(m mocks) {
+				m.messageRepo.On("FindByID", mock.Anything, "missing").Return(nil, nil)
+			},
+			wantErr: model.ErrTreeNodeNotFound,
+		},
+		{
+			name:   "異常系: 別のプロジェクトのメッセージの場合はエラー",
+			nodeID: "other-1",
+			setupMock: func(m mocks) {
+				m.messageRepo.On("FindByID", mock.Anything, "other-1").Return(&model.Message{UUID: "other-1", ChatUUID: "other-chat", Role: "assistant"}, nil)
+				m.chatRepo.On("FindByID", mock.Anything, "other-chat").Return(&model.Chat{UUID: "other-chat", ProjectUUID: "other-project"}, nil)
+			},
+			wantErr: model.ErrTreeNodeNotFound,
+		},
+		{
+			name:   "異常系: 回答とまとめたユーザーメッセージのIDの場合はエラー",
+			nodeID: "root-1",
+			setupMock: func(m mocks) {
+				setupNodeMocks(m, "root-1")
+			},
+			wantErr: model.ErrTreeNodeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := mocks{
+				chatRepo:          &mockChatRepository{},
+				messageRepo:       &mockMessageRepository{},
+				edgeRepo:          &mockEdgeRepository{},
+				treeNodeStateRepo: &mockTreeNodeStateRepository{},
+				layouter:          &mockTreeLayouter{},
+			}
+			tt.setupMock(m)
+
+			u := NewProjectUsecase(&mockProjectRepository{}, new(mockProjectMemberRepository), m.chatRepo, m.messageRepo, m.edgeRepo, m.treeNodeStateRepo, &mockTransactionManager{}, m.layouter)
+			got, err := u.GetProjectTreeNode(context.Background(), "project-uuid", tt.nodeID)
+			if tt.wantErr != nil {
+				assert.ErrorContains(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+			// プロジェクト全体のメッセージの本文とノードの表示状態は読み込まないこと
+			m.messageRepo.AssertNotCalled(t, "FindMessagesByProjectUUID", mock.Anything, mock.Anything)
+			m.treeNodeStateRepo.AssertNotCalled(t, "FindByProjectUUID", mock.Anything, mock.Anything)
+			m.layouter.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProjectUsecase_GetProjectTreeNode_AfterSharedTree(t *testing.T) {
+	rawToken := shareLinkTokenPrefix + "secret"
+	chats := []*model.Chat{
+		{UUID: "root-chat", ProjectUUID: "project-uuid", Title: "ルート"},
+		{UUID: "child-chat", ProjectUUID: "project-uuid", Title: "子"},
+	}
+	// ルートのチャットの回答 root-2 から child-chat をフォークしたツリー
+	messages := []*model.Message{
+		{UUID: "root-1", ChatUUID: "root-chat", Role: "user", Content: "質問"},
+		{UUID: "root-2", ChatUUID: "root-chat", Role: "assistant", Content: "回答", Forks: []model.Fork{{ChatUUID: "child-chat"}}},
+		{UUID: "root-3", ChatUUID: "root-chat", Role: "user", Content: "続きの質問"},
+		{UUID: "root-4", ChatUUID: "root-chat", Role: "assistant", Content: "続きの回答"},
+		{UUID: "child-1", ChatUUID: "child-chat", Role: "user", Content: "子の質問"},
+		{UUID: "child-2", ChatUUID: "child-chat", Role: "assistant", Content: "子の回答"},
+		{UUID: "child-3", ChatUUID: "child-chat", Role: "user", Content: "子の続きの質問"},
+		{UUID: "child-4", ChatUUID: "child-chat", Role: "assistant", Content: "子の続きの回答"},
+	}
+	structure := make([]*model.Message, len(messages))
+	for i, msg := range messages {
+		structure[i] = &model.Message{UUID: msg.UUID, ChatUUID: msg.ChatUUID, Role: msg.Role, Forks: msg.Forks}
+	}
+	edges := []*model.Edge{
+		{UUID: "edge-1", ChatUUID: "root-chat", SourceMessageUUID: "root-4", TargetMessageUUID: "root-2"},
+		{UUID: "edge-2", ChatUUID: "child-chat", SourceMessageUUID: "child-2", TargetMessageUUID: "root-2"},
+		{UUID: "edge-3", ChatUUID: "child-chat", SourceMessageUUID: "child-4", TargetMessageUUID: "child-2"},
+	}
+
+	chatRepo := &mockChatRepository{}
+	messageRepo := &mockMessageRepository{}
+	edgeRepo := &mockEdgeRepository{}
+	treeNodeStateRepo := &mockTreeNodeStateRepository{}
+	shareLinkRepo := &mockShareLinkRepository{}
+	chatRepo.On("FindAllByProjectUUID", mock.Anything, "project-uuid").Return(chats, nil)
+	chatRepo.On("FindByID", mock.Anything, "child-chat").Return(chats[1], nil)
+	messageRepo.On("FindMessagesByProjectUUID", mock.Anything, "project-uuid").Return(messages, nil)
+	messageRepo.On("FindMessageStructureByProjectUUID", mock.Anything, "project-uuid").Return(structure, nil)
+	messageRepo.On("FindByID", mock.Anything, "child-4").Return(messages[7], nil)
+	messageRepo.On("FindMessagesByChatID", mock.Anything, "child-chat").Return(messages[4:], nil)
+	edgeRepo.On("FindEdgesByProjectUUID", mock.Anything, "project-uuid").Return(edges, nil)
+	treeNodeStateRepo.On("FindByProjectUUID", mock.Anything, "project-uuid").Return([]*model.TreeNodeState{}, nil)
+	treeNodeStateRepo.On("FindByNodeID", mock.Anything, "project-uuid", "child-4").Return(nil, nil)
+	shareLinkRepo.On("FindByHash", mock.Anything, hashToken(rawToken)).Return(&model.ShareLink{UUID: "link-uuid", ProjectUUID: "project-uuid", RootChatUUID: "child-chat"}, nil)
+
+	// 同じレイアウトのインスタンスを使っても、部分木の配置がプロジェクトのツリーの位置に混ざらないこと
+	layouter := layout.NewTidyTree(config.LayoutConfig{})
+	projectUsecase := NewProjectUsecase(&mockProjectRepository{}, new(mockProjectMemberRepository), chatRepo, messageRepo, edgeRepo, treeNodeStateRepo, &mockTransactionManager{}, layouter)
+	shareLinkUsecase := NewShareLinkUsecase(shareLinkRepo, new(mockProjectRepository), chatRepo, messageRepo, edgeRepo, layouter)
+
+	tree, err := projectUsecase.GetProjectTree(context.Background(), "project-uuid", model.ProjectTreeQuery{})
+	assert.NoError(t, err)
+	var want model.ProjectNodePosition
+	for _, node := range tree.Nodes {
+		if node.ID == "child-4" {
+			want = node.Position
+		}
+	}
+
+	shared, err := shareLinkUsecase.GetSharedTree(context.Background(), rawToken)
+	assert.NoError(t, err)
+	for _, node := range shared.Nodes {
+		// 部分木だけで計算した位置はプロジェクトのツリーの位置と異なる
+		if node.ID == "child-4" {
+			assert.NotEqual(t, want, node.Position)
+		}
+	}
+
+	got, err := projectUsecase.GetProjectTreeNode(context.Background(), "project-uuid", "child-4")
+	assert.NoError(t, err)
+	assert.Equal(t, want, got.Position)
+}
+
+// 表示状態の更新テスト用に、ノードが msg-1 と msg-2 のツリーを返すモックを設定する処理
+func setupTreeNodeStateMocks(chatRepo *mockChatRepository, messageRepo *mockMessageRepository, edgeRepo *mockEdgeRepository) {
+	chatRepo.On("FindAllByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Chat{{UUID: "root-chat"}}, nil)
+	messageRepo.On("FindMessagesByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Message{
+		{UUID: "msg-1", ChatUUID: "root-chat", Role: "assistant", Content: "answer 1"},
+		{UUID: "msg-2", ChatUUID: "root-chat", Role: "assistant", Content: "answer 2"},
+	}, nil)
+	edgeRepo.On("FindEdgesByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Edge{}, nil)
+}
+
+func TestProjectUsecase_UpdateTreeNodePositions(t *testing.T) {
+	saved := []*model.TreeNodeState{
+		{NodeID: "msg-1", ProjectUUID: "project-uuid", Position: &model.ProjectNodePosition{X: 10, Y: 10}, Collapsed: true, Version: 2},
+	}
+	tests := []struct {
+		name      string
+		updates   []model.TreeNodePositionUpdate
+		setupMock func(repo *mockTreeNodeStateRepository)
+		want      []*model.TreeNodeState
+		wantErr   error
+	}{
+		{
+			name: "正常系: 保存済みのノードと未保存のノードの位置をまとめて更新できること",
+			updates: []model.TreeNodePositionUpdate{
+				{NodeID: "msg-1", Position: model.ProjectNodePosition{X: 100, Y: 200}, Version: 2},
+				{NodeID: "msg-2", Position: model.ProjectNodePosition{X: -50, Y: 300}, Version: 0},
+			},
+			setupMock: func(repo *mockTreeNodeStateRepository) {
+				repo.On("FindByProjectUUID", mock.Anything, "project-uuid").Return(saved, nil)
+				repo.On("SaveIfVersion", mock.Anything, mock.MatchedBy(func(s *model.TreeNodeState) bool { return s.NodeID == "msg-1" }), 2).Return(true, nil)
+				repo.On("SaveIfVersion", mock.Anything, mock.MatchedBy(func(s *model.TreeNodeState) bool { return s.NodeID == "msg-2" }), 0).Return(true, nil)
+			},
+			want: []*model.TreeNodeState{
+				// 折りたたみ状態は変えないこと
+				{NodeID: "msg-1", ProjectUUID: "project-uuid", Position: &model.ProjectNodePosition{X: 100, Y: 200}, Collapsed: true, Version: 3},
+				{NodeID: "msg-2", ProjectUUID: "project-uuid", Position: &model.ProjectNodePosition{X: -50, Y: 300}, Version: 1},
+			},
+		},
+		{
+			name: "正常系: 位置を破棄して自動レイアウトに戻せること",
+			updates: []model.TreeNodePositionUpdate{
+				{NodeID: "msg-1", Reset: true, Version: 2},
+			},
+			setupMock: func(repo *mockTreeNodeStateRepository) {
+				repo.On("FindByProjectUUID", mock.Anything, "project-uuid").Return(saved, nil)
+				repo.On("SaveIfVersion", mock.Anything, mock.Anything, 2).Return(true, nil)
+			},
+			want: []*model.TreeNodeState{
+				{NodeID: "msg-1", ProjectUUID: "project-uuid", Collapsed: true, Version: 3},
+			},
+		},
+		{
+			name: "異常系: 他の操作で更新されていた場合は競合エラーになること",
+			updates: []model.TreeNodePositionUpdate{
+				{NodeID: "msg-2", Position: model.ProjectNodePosition{X: 1, Y: 1}, Version: 0},
+				{NodeID: "msg-1", Position: model.ProjectNodePosition{X: 1, Y: 1}, Version: 1},
+			},
+			setupMock: func(repo *mockTreeNodeStateRepository) {
+				repo.On("FindByProjectUUID", mock.Anything, "project-uuid").Return(saved, nil)
+				repo.On("SaveIfVersion", mock.Anything, mock.Anything, 0).Return(true, nil)
+				repo.On("SaveIfVersion", mock.Anything, mock.Anything, 1).Return(false, nil)
+			},
+			wantErr: model.ErrTreeNodeConflict,
+		},
+		{
+			name: "異常系: ツリーにないノードを指定した場合エラーになること",
+			updates: []model.TreeNodePositionUpdate{
+				{NodeID: "other-project-msg", Position: model.ProjectNodePosition{X: 1, Y: 1}},
+			},
+			setupMock: func(repo *mockTreeNodeStateRepository) {},
+			wantErr:   model.ErrInvalidTreeNode,
+		},
+		{
+			name: "異常系: 同じノードを重複して指定した場合エラーになること",
+			updates: []model.TreeNodePositionUpdate{
+				{NodeID: "msg-1", Position: model.ProjectNodePosition{X: 1, Y: 1}},
+				{NodeID: "msg-1", Position: model.ProjectNodePosition{X: 2, Y: 2}},
+			},
+			setupMock: func(repo *mockTreeNodeStateRepository) {},
+			wantErr:   model.ErrInvalidTreeNode,
+		},
+		{
+			name: "異常系: 位置が有限の値でない場合エラーになること",
+			updates: []model.TreeNodePositionUpdate{
+				{NodeID: "msg-1", Position: model.ProjectNodePosition{X: math.Inf(1), Y: 1}},
+			},
+			setupMock: func(repo *mockTreeNodeStateRepository) {},
+			wantErr:   model.ErrInvalidTreeNode,
+		},
+		{
+			name:      "異常系: 更新するノードがない場合エラーになること",
+			updates:   []model.TreeNodePositionUpdate{},
+			setupMock: func(repo *mockTreeNodeStateRepository) {},
+			wantErr:   model.ErrInvalidTreeNode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chatRepo := &mockChatRepository{}
+			messageRepo := &mockMessageRepository{}
+			edgeRepo := &mockEdgeRepository{}
+			treeNodeStateRepo := &mockTreeNodeStateRepository{}
+			setupTreeNodeStateMocks(chatRepo, messageRepo, edgeRepo)
+			tt.setupMock(treeNodeStateRepo)
+
+			u := NewProjectUsecase(&mockProjectRepository{}, new(mockProjectMemberRepository), chatRepo, messageRepo, edgeRepo, treeNodeStateRepo, &mockTransactionManager{}, nil)
+			got, err := u.UpdateTreeNodePositions(context.Background(), "project-uuid", tt.updates)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			for _, s := range got {
+				s.UpdatedAt = time.Time{}
+			}
+			assert.Equal(t, tt.want, got)
+			treeNodeStateRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProjectUsecase_SetTreeNodeCollapsed(t *testing.T) {
+	tests := []struct {
+		name      string
+		nodeID    string
+		collapsed bool
+		version   int
+		setupMock func(repo *mockTreeNodeStateRepository)
+		want      *model.TreeNodeState
+		wantErr   error
+	}{
+		{
+			name:      "正常系: 移動した位置を保ったまま折りたためること",
+			nodeID:    "msg-1",
+			collapsed: true,
+			version:   1,
+			setupMock: func(repo *mockTreeNodeStateRepository) {
+				repo.On("FindByProjectUUID", mock.Anything, "project-uuid").Return([]*model.TreeNodeState{
+					{NodeID: "msg-1", ProjectUUID: "project-uuid", Position: &model.ProjectNodePosition{X: 10, Y: 20}, Version: 1},
+				}, nil)
+				repo.On("SaveIfVersion", mock.Anything, mock.Anything, 1).Return(true, nil)
+			},
+			want: &model.TreeNodeState{NodeID: "msg-1", ProjectUUID: "project-uuid", Position: &model.ProjectNodePosition{X: 10, Y: 20}, Collapsed: true, Version: 2},
+		},
+		{
+			name:      "正常系: 表示状態を保存していないノードを折りたためること",
+			nodeID:    "msg-2",
+			collapsed: true,
+			version:   0,
+			setupMock: func(repo *mockTreeNodeStateRepository) {
+				repo.On("FindByProjectUUID", mock.Anything, "project-uuid").Return([]*model.TreeNodeState{}, nil)
+				repo.On("SaveIfVersion", mock.Anything, mock.Anything, 0).Return(true, nil)
+			},
+			want: &model.TreeNodeState{NodeID: "msg-2", ProjectUUID: "project-uuid", Collapsed: true, Version: 1},
+		},
+		{
+			name:      "異常系: 他の操作で更新されていた場合は競合エラーになること",
+			nodeID:    "msg-2",
+			collapsed: false,
+			version:   0,
+			setupMock: func(repo *mockTreeNodeStateRepository) {
+				repo.On("FindByProjectUUID", mock.Anything, "project-uuid").Return([]*model.TreeNodeState{}, nil)
+				repo.On("SaveIfVersion", mock.Anything, mock.Anything, 0).Return(false, nil)
+			},
+			wantErr: model.ErrTreeNodeConflict,
+		},
+		{
+			name:      "異常系: ツリーにないノードを指定した場合エラーになること",
+			nodeID:    "unknown",
+			collapsed: true,
+			setupMock: func(repo *mockTreeNodeStateRepository) {},
+			wantErr:   model.ErrInvalidTreeNode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chatRepo := &mockChatRepository{}
+			messageRepo := &mockMessageRepository{}
+			edgeRepo := &mockEdgeRepository{}
+			treeNodeStateRepo := &mockTreeNodeStateRepository{}
+			setupTreeNodeStateMocks(chatRepo, messageRepo, edgeRepo)
+			tt.setupMock(treeNodeStateRepo)
+
+			u := NewProjectUsecase(&mockProjectRepository{}, new(mockProjectMemberRepository), chatRepo, messageRepo, edgeRepo, treeNodeStateRepo, &mockTransactionManager{}, nil)
+			got, err := u.SetTreeNodeCollapsed(context.Background(), "project-uuid", tt.nodeID, tt.collapsed, tt.version)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			got.UpdatedAt = time.Time{}
+			assert.Equal(t, tt.want, got)
+			treeNodeStateRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"backend/internal/domain/model"
+	"backend/internal/domain/repository"
+	"backend/internal/domain/usecase"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// 共有リンクのトークンの接頭辞
+	shareLinkTokenPrefix = "cbs_"
+	// 一覧で識別するために保存するトークン先頭の文字数
+	shareLinkDisplayLength = 12
+)
+
+type shareLinkUsecase struct {
+	shareLinkRepo repository.ShareLinkRepository
+	projectRepo   repository.ProjectRepository
+	chatRepo      repository.ChatRepository
+	messageRepo   repository.MessageRepository
+	edgeRepo      repository.EdgeRepository
+	treeLayouter  usecase.TreeLayouter
+}
+
+// ShareLinkUsecase の新しいインスタンスを作成する処理
+func NewShareLinkUsecase(
+	shareLinkRepo repository.ShareLinkRepository,
+	projectRepo repository.ProjectRepository,
+	chatRepo repository.ChatRepository,
+	messageRepo repository.MessageRepository,
+	edgeRepo repository.EdgeRepository,
+	treeLayouter usecase.TreeLayouter,
+) usecase.ShareLinkUsecase {
+	return &shareLinkUsecase{
+		shareLinkRepo: shareLinkRepo,
+		projectRepo:   projectRepo,
+		chatRepo:      chatRepo,
+		messageRepo:   messageRepo,
+		edgeRepo:      edgeRepo,
+		treeLayouter:  treeLayouter,
+	}
+}
+
+// 共有リンクを発行する処理
+func (u *shareLinkUsecase) CreateLink(ctx context.Context, userUUID string, projectUUID string, params model.CreateShareLinkParams) (*model.ShareLink, string, error) {
+	slog.InfoContext(ctx, "共有リンク発行処理を開始", "project_uuid", projectUUID, "root_chat_uuid", params.RootChatUUID)
+	now := time.Now()
+	if params.ExpiresAt != nil && !params.ExpiresAt.After(now) {
+		return nil, "", fmt.Errorf("%w: 有効期限が過去の日時です", model.ErrInvalidShareLinkParams)
+	}
+	if params.RootChatUUID != "" {
+		chat, err := u.chatRepo.FindByID(ctx, params.RootChatUUID)
+		if err != nil || chat.ProjectUUID != projectUUID {
+			return nil, "", fmt.Errorf("%w: プロジェクトに存在しないチャットです: %s", model.ErrInvalidShareLinkParams, params.RootChatUUID)
+		}
+	}
+
+	rawToken := shareLinkTokenPrefix + randomToken()
+	link := &model.ShareLink{
+		UUID:              uuid.New().String(),
+		ProjectUUID:       projectUUID,
+		RootChatUUID:      params.RootChatUUID,
+		Prefix:            rawToken[:shareLinkDisplayLength],
+		CreatedByUserUUID: userUUID,
+		ExpiresAt:         params.ExpiresAt,
+		CreatedAt:         now,
+	}
+	if err := u.shareLinkRepo.Create(ctx, link, hashToken(rawToken)); err != nil {
+		return nil, "", fmt.Errorf("共有リンクの作成に失敗: %w", err)
+	}
+
+	slog.InfoContext(ctx, "共有リンクを発行しました", "project_uuid", projectUUID, "share_link_uuid", link.UUID)
+	return link, rawToken, nil
+}
+
+// プロジェクトの有効な共有リンク一覧を取得する処理
+func (u *shareLinkUsecase) ListLinks(ctx context.Context, projectUUID string) ([]*model.ShareLink, error) {
+	slog.InfoContext(ctx, "共有リンク一覧取得処理を開始", "project_uuid", projectUUID)
+	links, err := u.shareLinkRepo.FindActiveByProjectUUID(ctx, projectUUID)
+	if err != nil {
+		return nil, fmt.Errorf("共有リンク一覧の取得に失敗: %w", err)
+	}
+	return links, nil
+}
+
+// 共有リンクを失効させる処理
+func (u *shareLinkUsecase) RevokeLink(ctx context.Context, projectUUID string, linkUUID string) error {
+	slog.InfoContext(ctx, "共有リンク失効処理を開始", "project_uuid", projectUUID, "share_link_uuid", linkUUID)
+	revoked, err := u.shareLinkRepo.Revoke(ctx, projectUUID, linkUUID, time.Now())
+	if err != nil {
+		return fmt.Errorf("共有リンクの失効に失敗: %w", err)
+	}
+	if !revoked {
+		return fmt.Errorf("%w: %s", model.ErrShareLinkNotFound, linkUUID)
+	}
+	slog.InfoContext(ctx, "共有リンクを失効しました", "project_uuid", projectUUID, "share_link_uuid", linkUUID)
+	return nil
+}
+
+// 共有リンクで公開しているプロジェクトの概要を取得する処理
+func (u *shareLinkUsecase) GetSharedView(ctx context.Context, rawToken string) (*model.SharedView, error) {
+	link, rootChatUUID, err := u.resolve(ctx, rawToken)
+	if err != nil {
+		return nil, err
+	}
+	project, err := u.projectRepo.FindByUUID(ctx, link.ProjectUUID)
+	if err != nil {
+		return nil, fmt.Errorf("プロジェクトの取得に失敗: %w", err)
+	}
+	return &model.SharedView{
+		ProjectTitle: project.Title,
+		RootChatUUID: rootChatUUID,
+		ExpiresAt:    link.ExpiresAt,
+	}, nil
+}
+
+// 共有リンクで公開している範囲のツリーを取得する処理
+// 部分木を公開する場合、起点チャットより上のチャットにつながるエッジは含めない
+func (u *shareLinkUsecase) GetSharedTree(ctx context.Context, rawToken string) (*model.ProjectTree, error) {
+	link, rootChatUUID, err := u.resolve(ctx, rawToken)
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "共有ツリー取得処理を開始", "share_link_uuid", link.UUID, "root_chat_uuid", rootChatUUID)
+
+	snapshot, err := loadProjectSnapshot(ctx, u.chatRepo, u.messageRepo, u.edgeRepo, link.ProjectUUID)
+	if err != nil {
+		return nil, err
+	}
+	tree, scope := collectProjectTree(snapshot, rootChatUUID)
+	edges := make([]model.ProjectEdge, 0, len(tree.Edges))
+	for _, edge := range tree.Edges {
+		if scope.messages[edge.Source] && scope.messages[edge.Target] {
+			edges = append(edges, edge)
+		}
+	}
+	tree.Edges = edges
+	// 公開範囲の部分木だけで配置を計算する
+	if u.treeLayouter != nil {
+		u.treeLayouter.Layout(tree)
+	}
+
+	slog.InfoContext(ctx, "共有ツリー取得処理を完了", "share_link_uuid", link.UUID, "nodes", len(tree.Nodes))
+	return tree, nil
+}
+
+// 共有リンクで公開している範囲のチャットのメッセージを取得する処理
+// 公開範囲外のチャットへのフォーク・マージレポートと、内部向けの要約やプロンプトのバージョンは取り除く
+func (u *shareLinkUsecase) GetSharedMessages(ctx context.Context, rawToken string, chatUUID string) ([]*model.Message, error) {
+	link, rootChatUUID, err := u.resolve(ctx, rawToken)
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "共有メッセージ取得処理を開始", "share_link_uuid", link.UUID, "chat_uuid", chatUUID)
+
+	snapshot, err := loadProjectSnapshot(ctx, u.chatRepo, u.messageRepo, u.edgeRepo, link.ProjectUUID)
+	if err != nil {
+		return nil, err
+	}
+	_, scope := collectProjectTree(snapshot, rootChatUUID)
+	if !scope.chats[chatUUID] {
+		return nil, fmt.Errorf("%w: %s", model.ErrChatNotShared, chatUUID)
+	}
+
+	allMessages := snapshot.messages[chatUUID]
+
+	messages := make([]*model.Message, 0, len(allMessages))
+	for _, msg := range groupMergeReports(allMessages) {
+		if msg.Role == "system" {
+			continue
+		}
+		messages = append(messages, sanitizeSharedMessage(msg, scope.chats))
+	}
+
+	slog.InfoContext(ctx, "共有メッセージ取得処理を完了", "share_link_uuid", link.UUID, "count", len(messages))
+	return messages, nil
+}
+
+// トークンから有効な共有リンクと公開範囲の起点チャットを取得する処理
+func (u *shareLinkUsecase) resolve(ctx context.Context, rawToken string) (*model.ShareLink, string, error) {
+	if !strings.HasPrefix(rawToken, shareLinkTokenPrefix) {
+		return nil, "", model.ErrShareLinkNotFound
+	}
+	link, err := u.shareLinkRepo.FindByHash(ctx, hashToken(rawToken))
+	if err != nil {
+		return nil, "", fmt.Errorf("共有リンクの検索に失敗: %w", err)
+	}
+	if link == nil || link.RevokedAt != nil || (link.ExpiresAt != nil && !time.Now().Before(*link.ExpiresAt)) {
+		return nil, "", model.ErrShareLinkNotFound
+	}
+
+	if link.RootChatUUID != "" {
+		return link, link.RootChatUUID, nil
+	}
+	rootChat, err := u.chatRepo.FindOldestByProjectUUID(ctx, link.ProjectUUID)
+	if err != nil {
+		return nil, "", fmt.Errorf("ルートチャットの取得に失敗: %w", err)
+	}
+	return link, rootChat.UUID, nil
+}
+
+// 共有リンクで公開するメッセージに必要な項目だけを複製する処理
+func sanitizeSharedMessage(msg *model.Message, visibleChats map[string]bool) *model.Message {
+	shared := &model.Message{
+		UUID:      msg.UUID,
+		ChatUUID:  msg.ChatUUID,
+		Role:      msg.Role,
+		Content:   msg.Content,
+		CreatedAt: msg.CreatedAt,
+	}
+	if msg.SourceChatUUID != nil && visibleChats[*msg.SourceChatUUID] {
+		sourceChatUUID := *msg.SourceChatUUID
+		shared.SourceChatUUID = &sourceChatUUID
+	}
+	for _, fork := range msg.Forks {
+		if visibleChats[fork.ChatUUID] {
+			shared.Forks = append(shared.Forks, fork)
+		}
+	}
+	for _, report := range msg.MergeReports {
+		if report.SourceChatUUID != nil && visibleChats[*report.SourceChatUUID] {
+			shared.MergeReports = append(shared.MergeReports, sanitizeSharedMessage(report, visibleChats))
+		}
+	}
+	return shared
+}
@@ -0,0 +1,267 @@
+package usecase
+
+import (
+	"backend/internal/domain/model"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockShareLinkRepository struct {
+	mock.Mock
+}
+
+func (m *mockShareLinkRepository) Create(ctx context.Context, link *model.ShareLink, tokenHash string) error {
+	args := m.Called(ctx, link, tokenHash)
+	return args.Error(0)
+}
+
+func (m *mockShareLinkRepository) FindByHash(ctx context.Context, tokenHash string) (*model.ShareLink, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ShareLink), args.Error(1)
+}
+
+func (m *mockShareLinkRepository) FindActiveByProjectUUID(ctx context.Context, projectUUID string) ([]*model.ShareLink, error) {
+	args := m.Called(ctx, projectUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.ShareLink), args.Error(1)
+}
+
+func (m *mockShareLinkRepository) Revoke(ctx context.Context, projectUUID string, linkUUID string, revokedAt time.Time) (bool, error) {
+	args := m.Called(ctx, projectUUID, linkUUID, revokedAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestShareLinkUsecase_CreateLink(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		params    model.CreateShareLinkParams
+		setupMock func(shareLinkRepo *mockShareLinkRepository, chatRepo *mockChatRepository)
+		wantErr   error
+	}{
+		{
+			name:   "正常系: プロジェクト全体の共有リンクが発行されること",
+			params: model.CreateShareLinkParams{ExpiresAt: &future},
+			setupMock: func(shareLinkRepo *mockShareLinkRepository, chatRepo *mockChatRepository) {
+				shareLinkRepo.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:   "正常系: プロジェクト内のチャットを起点に発行できること",
+			params: model.CreateShareLinkParams{RootChatUUID: "child-chat"},
+			setupMock: func(shareLinkRepo *mockShareLinkRepository, chatRepo *mockChatRepository) {
+				chatRepo.On("FindByID", mock.Anything, "child-chat").Return(&model.Chat{UUID: "child-chat", ProjectUUID: "project-uuid"}, nil)
+				shareLinkRepo.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:      "異常系: 有効期限が過去の場合エラー",
+			params:    model.CreateShareLinkParams{ExpiresAt: &past},
+			setupMock: func(shareLinkRepo *mockShareLinkRepository, chatRepo *mockChatRepository) {},
+			wantErr:   model.ErrInvalidShareLinkParams,
+		},
+		{
+			name:   "異常系: 他のプロジェクトのチャットの場合エラー",
+			params: model.CreateShareLinkParams{RootChatUUID: "other-chat"},
+			setupMock: func(shareLinkRepo *mockShareLinkRepository, chatRepo *mockChatRepository) {
+				chatRepo.On("FindByID", mock.Anything, "other-chat").Return(&model.Chat{UUID: "other-chat", ProjectUUID: "other-project-uuid"}, nil)
+			},
+			wantErr: model.ErrInvalidShareLinkParams,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shareLinkRepo := new(mockShareLinkRepository)
+			chatRepo := new(mockChatRepository)
+			tt.setupMock(shareLinkRepo, chatRepo)
+
+			u := NewShareLinkUsecase(shareLinkRepo, new(mockProjectRepository), chatRepo, new(mockMessageRepository), new(mockEdgeRepository), nil)
+			link, rawToken, err := u.CreateLink(context.Background(), "user-uuid", "project-uuid", tt.params)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, link)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(rawToken, shareLinkTokenPrefix))
+			assert.Equal(t, rawToken[:shareLinkDisplayLength], link.Prefix)
+			assert.Equal(t, tt.params.RootChatUUID, link.RootChatUUID)
+			shareLinkRepo.AssertCalled(t, "Create", mock.Anything, link, hashToken(rawToken))
+			chatRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestShareLinkUsecase_GetSharedTree(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	rawToken := shareLinkTokenPrefix + "secret"
+
+	tests := []struct {
+		name      string
+		rawToken  string
+		setupMock func(shareLinkRepo *mockShareLinkRepository, chatRepo *mockChatRepository, messageRepo *mockMessageRepository, edgeRepo *mockEdgeRepository)
+		wantNodes []string
+		wantEdges []string
+		wantErr   error
+	}{
+		{
+			name:     "正常系: 部分木の共有では起点チャットより上につながるエッジが含まれないこと",
+			rawToken: rawToken,
+			setupMock: func(shareLinkRepo *mockShareLinkRepository, chatRepo *mockChatRepository, messageRepo *mockMessageRepository, edgeRepo *mockEdgeRepository) {
+				shareLinkRepo.On("FindByHash", mock.Anything, hashToken(rawToken)).Return(&model.ShareLink{UUID: "link-uuid", ProjectUUID: "project-uuid", RootChatUUID: "child-chat"}, nil)
+				chatRepo.On("FindAllByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Chat{{UUID: "root-chat"}, {UUID: "child-chat"}}, nil)
+				messageRepo.On("FindMessagesByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Message{
+					{UUID: "msg-1", ChatUUID: "root-chat", Role: "user", Content: "root question"},
+					{UUID: "msg-2", ChatUUID: "root-chat", Role: "assistant", Content: "root answer", Forks: []model.Fork{{ChatUUID: "child-chat"}}},
+					{UUID: "msg-3", ChatUUID: "child-chat", Role: "user", Content: "question"},
+					{UUID: "msg-4", ChatUUID: "child-chat", Role: "assistant", Content: "answer"},
+				}, nil)
+				edgeRepo.On("FindEdgesByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Edge{
+					{UUID: "edge-parent", ChatUUID: "child-chat", SourceMessageUUID: "msg-2", TargetMessageUUID: "msg-4"},
+				}, nil)
+			},
+			wantNodes: []string{"msg-4"},
+			wantEdges: []string{},
+		},
+		{
+			name:     "正常系: 起点チャットがない場合プロジェクト全体が共有されること",
+			rawToken: rawToken,
+			setupMock: func(shareLinkRepo *mockShareLinkRepository, chatRepo *mockChatRepository, messageRepo *mockMessageRepository, edgeRepo *mockEdgeRepository) {
+				shareLinkRepo.On("FindByHash", mock.Anything, hashToken(rawToken)).Return(&model.ShareLink{UUID: "link-uuid", ProjectUUID: "project-uuid"}, nil)
+				chatRepo.On("FindOldestByProjectUUID", mock.Anything, "project-uuid").Return(&model.Chat{UUID: "root-chat"}, nil)
+				chatRepo.On("FindAllByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Chat{{UUID: "root-chat"}, {UUID: "child-chat"}}, nil)
+				messageRepo.On("FindMessagesByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Message{
+					{UUID: "msg-1", ChatUUID: "root-chat", Role: "user", Content: "question"},
+					{UUID: "msg-2", ChatUUID: "root-chat", Role: "assistant", Content: "answer", Forks: []model.Fork{{ChatUUID: "child-chat"}}},
+					{UUID: "msg-4", ChatUUID: "child-chat", Role: "assistant", Content: "child answer"},
+				}, nil)
+				edgeRepo.On("FindEdgesByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Edge{
+					{UUID: "edge-1", ChatUUID: "root-chat", SourceMessageUUID: "msg-2", TargetMessageUUID: "msg-4"},
+				}, nil)
+			},
+			wantNodes: []string{"msg-2", "msg-4"},
+			wantEdges: []string{"edge-1"},
+		},
+		{
+			name:     "異常系: 接頭辞が異なる場合エラー",
+			rawToken: "invalid",
+			setupMock: func(shareLinkRepo *mockShareLinkRepository, chatRepo *mockChatRepository, messageRepo *mockMessageRepository, edgeRepo *mockEdgeRepository) {
+			},
+			wantErr: model.ErrShareLinkNotFound,
+		},
+		{
+			name:     "異常系: 失効した共有リンクの場合エラー",
+			rawToken: rawToken,
+			setupMock: func(shareLinkRepo *mockShareLinkRepository, chatRepo *mockChatRepository, messageRepo *mockMessageRepository, edgeRepo *mockEdgeRepository) {
+				shareLinkRepo.On("FindByHash", mock.Anything, hashToken(rawToken)).Return(&model.ShareLink{UUID: "link-uuid", RevokedAt: &past}, nil)
+			},
+			wantErr: model.ErrShareLinkNotFound,
+		},
+		{
+			name:     "異常系: 有効期限切れの共有リンクの場合エラー",
+			rawToken: rawToken,
+			setupMock: func(shareLinkRepo *mockShareLinkRepository, chatRepo *mockChatRepository, messageRepo *mockMessageRepository, edgeRepo *mockEdgeRepository) {
+				shareLinkRepo.On("FindByHash", mock.Anything, hashToken(rawToken)).Return(&model.ShareLink{UUID: "link-uuid", ExpiresAt: &past}, nil)
+			},
+			wantErr: model.ErrShareLinkNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shareLinkRepo := new(mockShareLinkRepository)
+			chatRepo := new(mockChatRepository)
+			messageRepo := new(mockMessageRepository)
+			edgeRepo := new(mockEdgeRepository)
+			tt.setupMock(shareLinkRepo, chatRepo, messageRepo, edgeRepo)
+
+			u := NewShareLinkUsecase(shareLinkRepo, new(mockProjectRepository), chatRepo, messageRepo, edgeRepo, nil)
+			tree, err := u.GetSharedTree(context.Background(), tt.rawToken)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			nodeIDs := []string{}
+			for _, node := range tree.Nodes {
+				nodeIDs = append(nodeIDs, node.ID)
+			}
+			edgeIDs := []string{}
+			for _, edge := range tree.Edges {
+				edgeIDs = append(edgeIDs, edge.ID)
+			}
+			assert.Equal(t, tt.wantNodes, nodeIDs)
+			assert.Equal(t, tt.wantEdges, edgeIDs)
+		})
+	}
+}
+
+func TestShareLinkUsecase_GetSharedMessages(t *testing.T) {
+	rawToken := shareLinkTokenPrefix + "secret"
+	parentMessageUUID := "msg-2"
+	childChatUUID := "child-chat"
+	hiddenChatUUID := "hidden-chat"
+	contextSummary := "internal summary"
+
+	setupTree := func(shareLinkRepo *mockShareLinkRepository, chatRepo *mockChatRepository, messageRepo *mockMessageRepository, edgeRepo *mockEdgeRepository) {
+		shareLinkRepo.On("FindByHash", mock.Anything, hashToken(rawToken)).Return(&model.ShareLink{UUID: "link-uuid", ProjectUUID: "project-uuid", RootChatUUID: "shared-chat"}, nil)
+		chatRepo.On("FindAllByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Chat{{UUID: "shared-chat"}, {UUID: childChatUUID}, {UUID: hiddenChatUUID}}, nil)
+		messageRepo.On("FindMessagesByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Message{
+			{UUID: "msg-0", ChatUUID: "shared-chat", Role: "system", Content: "system prompt"},
+			{UUID: "msg-1", ChatUUID: "shared-chat", Role: "user", Content: "question"},
+			{UUID: parentMessageUUID, ChatUUID: "shared-chat", Role: "assistant", Content: "answer", ContextSummary: &contextSummary, Forks: []model.Fork{{ChatUUID: childChatUUID}}},
+			{UUID: "report-1", ChatUUID: "shared-chat", Role: "merge_report", Content: "child report", ParentMessageUUID: &parentMessageUUID, SourceChatUUID: &childChatUUID},
+			{UUID: "report-2", ChatUUID: "shared-chat", Role: "merge_report", Content: "hidden report", ParentMessageUUID: &parentMessageUUID, SourceChatUUID: &hiddenChatUUID},
+		}, nil)
+		edgeRepo.On("FindEdgesByProjectUUID", mock.Anything, "project-uuid").Return([]*model.Edge{}, nil)
+	}
+
+	t.Run("正常系: システムメッセージと公開範囲外のマージレポートが取り除かれること", func(t *testing.T) {
+		shareLinkRepo := new(mockShareLinkRepository)
+		chatRepo := new(mockChatRepository)
+		messageRepo := new(mockMessageRepository)
+		edgeRepo := new(mockEdgeRepository)
+		setupTree(shareLinkRepo, chatRepo, messageRepo, edgeRepo)
+
+		u := NewShareLinkUsecase(shareLinkRepo, new(mockProjectRepository), chatRepo, messageRepo, edgeRepo, nil)
+		messages, err := u.GetSharedMessages(context.Background(), rawToken, "shared-chat")
+
+		assert.NoError(t, err)
+		assert.Len(t, messages, 2)
+		assert.Equal(t, "user", messages[0].Role)
+		assistant := messages[1]
+		assert.Nil(t, assistant.ContextSummary)
+		assert.Equal(t, []model.Fork{{ChatUUID: childChatUUID}}, assistant.Forks)
+		assert.Len(t, assistant.MergeReports, 1)
+		assert.Equal(t, "report-1", assistant.MergeReports[0].UUID)
+		assert.Nil(t, assistant.MergeReports[0].ParentMessageUUID)
+	})
+
+	t.Run("異常系: 公開範囲外のチャットの場合エラー", func(t *testing.T) {
+		shareLinkRepo := new(mockShareLinkRepository)
+		chatRepo := new(mockChatRepository)
+		messageRepo := new(mockMessageRepository)
+		edgeRepo := new(mockEdgeRepository)
+		setupTree(shareLinkRepo, chatRepo, messageRepo, edgeRepo)
+
+		u := NewShareLinkUsecase(shareLinkRepo, new(mockProjectRepository), chatRepo, messageRepo, edgeRepo, nil)
+		_, err := u.GetSharedMessages(context.Background(), rawToken, hiddenChatUUID)
+
+		assert.ErrorIs(t, err, model.ErrChatNotShared)
+	})
+}
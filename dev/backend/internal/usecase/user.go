@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"backend/internal/domain/model"
+	"backend/internal/domain/repository"
+	"backend/internal/domain/usecase"
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+type userUsecase struct {
+	userRepo repository.UserRepository
+}
+
+// UserUsecase の新しいインスタンスを作成する処理
+func NewUserUsecase(userRepo repository.UserRepository) usecase.UserUsecase {
+	return &userUsecase{
+		userRepo: userRepo,
+	}
+}
+
+// ログイン中のユーザーのプロフィールを取得する処理
+func (u *userUsecase) GetProfile(ctx context.Context, userUUID string) (*model.User, error) {
+	slog.InfoContext(ctx, "プロフィール取得処理を開始", "user_uuid", userUUID)
+	user, err := u.userRepo.FindByUUID(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("ユーザー検索に失敗: %w", err)
+	}
+	return user, nil
+}
+
+// ユーザーの優先言語を更新する処理
+// 空文字の場合は未設定に戻し、既定の言語が使われるようにする
+func (u *userUsecase) UpdateLanguage(ctx context.Context, userUUID string, language string) (*model.User, error) {
+	slog.InfoContext(ctx, "ユーザー優先言語更新処理を開始", "user_uuid", userUUID, "language", language)
+	if language != "" && !model.IsSupportedLanguage(language) {
+		return nil, fmt.Errorf("%w: %s", model.ErrUnsupportedLanguage, language)
+	}
+
+	if err := u.userRepo.UpdateLanguage(ctx, userUUID, language); err != nil {
+		return nil, fmt.Errorf("ユーザー優先言語の更新に失敗: %w", err)
+	}
+
+	user, err := u.userRepo.FindByUUID(ctx, userUUID)
+	if err != nil {
+		return nil, fmt.Errorf("ユーザー検索に失敗: %w", err)
+	}
+
+	slog.InfoContext(ctx, "ユーザー優先言語更新処理を完了", "user_uuid", userUUID)
+	return user, nil
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"backend/internal/domain/model"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUserUsecase_GetProfile(t *testing.T) {
+	tests := []struct {
+		name      string
+		userUUID  string
+		setupMock func(m *mockUserRepository)
+		want      *model.User
+		wantErr   bool
+	}{
+		{
+			name:     "正常系: プロフィールが取得できること",
+			userUUID: "user-uuid",
+			setupMock: func(m *mockUserRepository) {
+				m.On("FindByUUID", mock.Anything, "user-uuid").Return(&model.User{UUID: "user-uuid", Name: "Guest", Language: "en"}, nil)
+			},
+			want:    &model.User{UUID: "user-uuid", Name: "Guest", Language: "en"},
+			wantErr: false,
+		},
+		{
+			name:     "異常系: ユーザーが存在しない場合エラーになること",
+			userUUID: "non-existent",
+			setupMock: func(m *mockUserRepository) {
+				m.On("FindByUUID", mock.Anything, "non-existent").Return(nil, errors.New("not found"))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mockUserRepository)
+			tt.setupMock(mockRepo)
+
+			u := NewUserUsecase(mockRepo)
+			got, err := u.GetProfile(context.Background(), tt.userUUID)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("userUsecase.GetProfile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserUsecase_UpdateLanguage(t *testing.T) {
+	type args struct {
+		userUUID string
+		language string
+	}
+	tests := []struct {
+		name      string
+		args      args
+		setupMock func(m *mockUserRepository)
+		want      *model.User
+		wantErr   error
+	}{
+		{
+			name: "正常系: 優先言語が更新できること",
+			args: args{
+				userUUID: "user-uuid",
+				language: model.LanguageEnglish,
+			},
+			setupMock: func(m *mockUserRepository) {
+				m.On("UpdateLanguage", mock.Anything, "user-uuid", model.LanguageEnglish).Return(nil)
+				m.On("FindByUUID", mock.Anything, "user-uuid").Return(&model.User{UUID: "user-uuid", Language: model.LanguageEnglish}, nil)
+			},
+			want:    &model.User{UUID: "user-uuid", Language: model.LanguageEnglish},
+			wantErr: nil,
+		},
+		{
+			name: "異常系: 対応していない言語の場合エラーになること",
+			args: args{
+				userUUID: "user-uuid",
+				language: "fr",
+			},
+			setupMock: func(m *mockUserRepository) {
+				// 呼び出されない
+			},
+			want:    nil,
+			wantErr: model.ErrUnsupportedLanguage,
+		},
+		{
+			name: "異常系: 更新に失敗した場合エラーになること",
+			args: args{
+				userUUID: "user-uuid",
+				language: model.LanguageJapanese,
+			},
+			setupMock: func(m *mockUserRepository) {
+				m.On("UpdateLanguage", mock.Anything, "user-uuid", model.LanguageJapanese).Return(errors.New("db error"))
+			},
+			want:    nil,
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mockUserRepository)
+			tt.setupMock(mockRepo)
+
+			u := NewUserUsecase(mockRepo)
+			got, err := u.UpdateLanguage(context.Background(), tt.args.userUUID, tt.args.language)
+
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else if errors.Is(tt.wantErr, model.ErrUnsupportedLanguage) {
+				assert.ErrorIs(t, err, model.ErrUnsupportedLanguage)
+			} else {
+				assert.Error(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package worker
+
+import (
+	"backend/internal/domain/model"
+	"backend/internal/domain/usecase"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+)
+
+type FirstAnswerWorker struct {
+	subscriber  message.Subscriber
+	chatUsecase usecase.ChatUsecase
+}
+
+func NewFirstAnswerWorker(subscriber message.Subscriber, chatUsecase usecase.ChatUsecase) *FirstAnswerWorker {
+	return &FirstAnswerWorker{
+		subscriber:  subscriber,
+		chatUsecase: chatUsecase,
+	}
+}
+
+// 最初の回答の生成タスクの起動
+func (w *FirstAnswerWorker) Run(ctx context.Context) error {
+	messages, err := w.subscriber.Subscribe(ctx, "chat_first_answer")
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to chat_first_answer: %w", err)
+	}
+
+	for msg := range messages {
+		if err := w.Handle(ctx, msg); err != nil {
+			slog.ErrorContext(ctx, "最初の回答の生成タスクの処理に失敗", "error", err)
+			msg.Nack()
+		} else {
+			msg.Ack()
+		}
+	}
+
+	return nil
+}
+
+// 最初の回答の生成タスクの処理
+// 回答の生成・保存・リアルタイム通知はチャットを開いて生成する場合と同じ処理を使う
+func (w *FirstAnswerWorker) Handle(ctx context.Context, msg *message.Message) error {
+	var task model.FirstAnswerTask
+	if err := json.Unmarshal(msg.Payload, &task); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+	slog.InfoContext(ctx, "最初の回答の生成タスク開始", "chat_uuid", task.ChatUUID)
+
+	// ストリームの送信先はないため、生成された文章は読み捨てる
+	outputChan := make(chan string)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range outputChan {
+		}
+	}()
+	err := w.chatUsecase.FirstStreamChat(ctx, task.ChatUUID, outputChan)
+	close(outputChan)
+	<-done
+
+	if err != nil {
+		// 先にチャットを開いて回答を生成した・チャットを閉じたなど、生成する必要がなくなった場合は再試行しない
+		if errors.Is(err, model.ErrChatAlreadyStarted) || errors.Is(err, model.ErrChatGenerationInProgress) || errors.Is(err, model.ErrChatNotOpen) {
+			slog.InfoContext(ctx, "最初の回答の生成が不要なためスキップ", "chat_uuid", task.ChatUUID, "reason", err)
+			return nil
+		}
+		return fmt.Errorf("failed to generate first answer: %w", err)
+	}
+
+	slog.InfoContext(ctx, "最初の回答の生成タスク完了", "chat_uuid", task.ChatUUID)
+	return nil
+}
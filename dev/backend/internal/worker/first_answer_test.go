@@ -0,0 +1,115 @@
+package worker
+
+import (
+	"backend/internal/domain/model"
+	"backend/internal/domain/usecase"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// 最初の回答の生成のみをモック化した ChatUsecase
+type MockFirstAnswerUsecase struct {
+	usecase.ChatUsecase
+	mock.Mock
+}
+
+func (m *MockFirstAnswerUsecase) FirstStreamChat(ctx context.Context, chatUUID string, outputChan chan<- string) error {
+	args := m.Called(ctx, chatUUID, outputChan)
+	// 実際の処理と同じくストリームに書き込む (読み捨てられないとブロックする)
+	outputChan <- "answer"
+	return args.Error(0)
+}
+
+func TestFirstAnswerWorker_Handle(t *testing.T) {
+	tests := []struct {
+		name      string
+		payload   []byte
+		setupMock func(m *MockFirstAnswerUsecase)
+		wantErr   bool
+	}{
+		{
+			name:    "正常系: タスクのチャットの最初の回答を生成すること",
+			payload: []byte(`{"chat_uuid":"chat-uuid"}`),
+			setupMock: func(m *MockFirstAnswerUsecase) {
+				m.On("FirstStreamChat", mock.Anything, "chat-uuid", mock.Anything).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:    "正常系: 既に会話が始まっている場合はスキップすること",
+			payload: []byte(`{"chat_uuid":"chat-uuid"}`),
+			setupMock: func(m *MockFirstAnswerUsecase) {
+				m.On("FirstStreamChat", mock.Anything, "chat-uuid", mock.Anything).Return(fmt.Errorf("%w: expected exactly one initial message", model.ErrChatAlreadyStarted))
+			},
+			wantErr: false,
+		},
+		{
+			name:    "正常系: 別の回答を生成中の場合はスキップすること",
+			payload: []byte(`{"chat_uuid":"chat-uuid"}`),
+			setupMock: func(m *MockFirstAnswerUsecase) {
+				m.On("FirstStreamChat", mock.Anything, "chat-uuid", mock.Anything).Return(model.ErrChatGenerationInProgress)
+			},
+			wantErr: false,
+		},
+		{
+			name:    "異常系: 生成に失敗した場合はエラーを返すこと",
+			payload: []byte(`{"chat_uuid":"chat-uuid"}`),
+			setupMock: func(m *MockFirstAnswerUsecase) {
+				m.On("FirstStreamChat", mock.Anything, "chat-uuid", mock.Anything).Return(errors.New("genai error"))
+			},
+			wantErr: true,
+		},
+		{
+			name:      "異常系: ペイロードが不正な場合",
+			payload:   []byte(`invalid`),
+			setupMock: func(m *MockFirstAnswerUsecase) {},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &MockFirstAnswerUsecase{}
+			tt.setupMock(m)
+
+			w := NewFirstAnswerWorker(&MockSubscriber{}, m)
+			err := w.Handle(context.Background(), message.NewMessage("msg-uuid", tt.payload))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFirstAnswerWorker_Run(t *testing.T) {
+	subscriber := &MockSubscriber{}
+	chatUsecase := &MockFirstAnswerUsecase{}
+
+	msgChan := make(chan *message.Message, 1)
+	payload, _ := json.Marshal(model.FirstAnswerTask{ChatUUID: "chat-uuid"})
+	msg := message.NewMessage("msg-uuid", payload)
+	msgChan <- msg
+	close(msgChan)
+
+	subscriber.On("Subscribe", mock.Anything, "chat_first_answer").Return((<-chan *message.Message)(msgChan), nil)
+	chatUsecase.On("FirstStreamChat", mock.Anything, "chat-uuid", mock.Anything).Return(nil)
+
+	w := NewFirstAnswerWorker(subscriber, chatUsecase)
+	err := w.Run(context.Background())
+	assert.NoError(t, err)
+
+	select {
+	case <-msg.Acked():
+	default:
+		t.Error("message should be acked")
+	}
+}
@@ -0,0 +1,61 @@
+package worker
+
+import (
+	"backend/internal/domain/model"
+	"backend/internal/domain/usecase"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+)
+
+type ForkSuggestionWorker struct {
+	subscriber  message.Subscriber
+	chatUsecase usecase.ChatUsecase
+}
+
+func NewForkSuggestionWorker(subscriber message.Subscriber, chatUsecase usecase.ChatUsecase) *ForkSuggestionWorker {
+	return &ForkSuggestionWorker{
+		subscriber:  subscriber,
+		chatUsecase: chatUsecase,
+	}
+}
+
+// フォーク候補抽出タスクの起動
+func (w *ForkSuggestionWorker) Run(ctx context.Context) error {
+	messages, err := w.subscriber.Subscribe(ctx, "fork_suggestion")
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to fork_suggestion: %w", err)
+	}
+
+	for msg := range messages {
+		if err := w.Handle(ctx, msg); err != nil {
+			slog.ErrorContext(ctx, "フォーク候補抽出タスクの処理に失敗", "error", err)
+			msg.Nack()
+		} else {
+			msg.Ack()
+		}
+	}
+
+	return nil
+}
+
+// フォーク候補抽出タスクの処理
+// 候補の抽出・照合・保存は手動で抽出する場合と同じ処理を使う
+func (w *ForkSuggestionWorker) Handle(ctx context.Context, msg *message.Message) error {
+	var task model.ForkSuggestionTask
+	if err := json.Unmarshal(msg.Payload, &task); err != nil {
+		return fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+	slog.InfoContext(ctx, "フォーク候補抽出タスク開始", "chat_uuid", task.ChatUUID, "message_uuid", task.MessageUUID)
+
+	suggestions, err := w.chatUsecase.SuggestForks(ctx, task.ChatUUID, task.MessageUUID)
+	if err != nil {
+		return fmt.Errorf("failed to suggest forks: %w", err)
+	}
+
+	slog.InfoContext(ctx, "フォーク候補抽出タスク完了", "chat_uuid", task.ChatUUID, "message_uuid", task.MessageUUID, "suggestions", len(suggestions))
+	return nil
+}
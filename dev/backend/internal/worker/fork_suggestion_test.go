@@ -0,0 +1,99 @@
+package worker
+
+import (
+	"backend/internal/domain/model"
+	"backend/internal/domain/usecase"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ThreeDotsLabs/watermill/message"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// フォーク候補の抽出のみをモック化した ChatUsecase
+type MockForkSuggestionUsecase struct {
+	usecase.ChatUsecase
+	mock.Mock
+}
+
+func (m *MockForkSuggestionUsecase) SuggestForks(ctx context.Context, chatUUID string, messageUUID string) ([]*model.ForkSuggestion, error) {
+	args := m.Called(ctx, chatUUID, messageUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.ForkSuggestion), args.Error(1)
+}
+
+func TestForkSuggestionWorker_Handle(t *testing.T) {
+	tests := []struct {
+		name      string
+		payload   []byte
+		setupMock func(m *MockForkSuggestionUsecase)
+		wantErr   bool
+	}{
+		{
+			name:    "正常系: タスクのメッセージからフォーク候補を抽出すること",
+			payload: []byte(`{"chat_uuid":"chat-uuid","message_uuid":"msg-uuid"}`),
+			setupMock: func(m *MockForkSuggestionUsecase) {
+				m.On("SuggestForks", mock.Anything, "chat-uuid", "msg-uuid").Return([]*model.ForkSuggestion{{UUID: "suggestion-1"}}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:    "異常系: 抽出に失敗した場合はエラーを返すこと",
+			payload: []byte(`{"chat_uuid":"chat-uuid","message_uuid":"msg-uuid"}`),
+			setupMock: func(m *MockForkSuggestionUsecase) {
+				m.On("SuggestForks", mock.Anything, "chat-uuid", "msg-uuid").Return(nil, errors.New("genai error"))
+			},
+			wantErr: true,
+		},
+		{
+			name:      "異常系: ペイロードが不正な場合",
+			payload:   []byte(`invalid`),
+			setupMock: func(m *MockForkSuggestionUsecase) {},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &MockForkSuggestionUsecase{}
+			tt.setupMock(m)
+
+			w := NewForkSuggestionWorker(&MockSubscriber{}, m)
+			err := w.Handle(context.Background(), message.NewMessage("msg-uuid", tt.payload))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestForkSuggestionWorker_Run(t *testing.T) {
+	subscriber := &MockSubscriber{}
+	chatUsecase := &MockForkSuggestionUsecase{}
+
+	msgChan := make(chan *message.Message, 1)
+	payload, _ := json.Marshal(model.ForkSuggestionTask{ChatUUID: "chat-uuid", MessageUUID: "msg-uuid"})
+	msg := message.NewMessage("msg-uuid", payload)
+	msgChan <- msg
+	close(msgChan)
+
+	subscriber.On("Subscribe", mock.Anything, "fork_suggestion").Return((<-chan *message.Message)(msgChan), nil)
+	chatUsecase.On("SuggestForks", mock.Anything, "chat-uuid", "msg-uuid").Return([]*model.ForkSuggestion{}, nil)
+
+	w := NewForkSuggestionWorker(subscriber, chatUsecase)
+	err := w.Run(context.Background())
+	assert.NoError(t, err)
+
+	select {
+	case <-msg.Acked():
+	default:
+		t.Error("message should be acked")
+	}
+}
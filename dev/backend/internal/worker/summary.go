@@ -14,16 +14,18 @@ import (
 )
 
 type SummaryWorker struct {
-	subscriber  message.Subscriber
-	messageRepo repository.MessageRepository
-	genaiClient usecase.GenAIClient
+	subscriber     message.Subscriber
+	messageRepo    repository.MessageRepository
+	genaiClient    usecase.GenAIClient
+	promptRenderer usecase.PromptRenderer
 }
 
-func NewSummaryWorker(subscriber message.Subscriber, messageRepo repository.MessageRepository, genaiClient usecase.GenAIClient) *SummaryWorker {
+func NewSummaryWorker(subscriber message.Subscriber, messageRepo repository.MessageRepository, genaiClient usecase.GenAIClient, promptRenderer usecase.PromptRenderer) *SummaryWorker {
 	return &SummaryWorker{
-		subscriber:  subscriber,
-		messageRepo: messageRepo,
-		genaiClient: genaiClient,
+		subscriber:     subscriber,
+		messageRepo:    messageRepo,
+		genaiClient:    genaiClient,
+		promptRenderer: promptRenderer,
 	}
 }
 
@@ -48,11 +50,16 @@ func (w *SummaryWorker) Run(ctx context.Context) error {
 
 // 要約生成タスクの処理
 func (w *SummaryWorker) Handle(ctx context.Context, msg *message.Message) error {
-	var chatUUID string
-	if err := json.Unmarshal(msg.Payload, &chatUUID); err != nil {
-		return fmt.Errorf("failed to unmarshal payload: %w", err)
+	task, err := parseSummaryTask(msg.Payload)
+	if err != nil {
+		return err
 	}
-	slog.InfoContext(ctx, "要約生成タスク開始", "chat_uuid", chatUUID)
+	chatUUID := task.ChatUUID
+	language := task.Language
+	if language == "" {
+		language = w.promptRenderer.DefaultLanguage()
+	}
+	slog.InfoContext(ctx, "要約生成タスク開始", "chat_uuid", chatUUID, "language", language)
 
 	// 1. 最新のサマリを持つメッセージを取得
 	latestSummaryMessage, err := w.messageRepo.FindLatestMessageWithSummary(ctx, chatUUID)
@@ -97,33 +104,51 @@ func (w *SummaryWorker) Handle(ctx context.Context, msg *message.Message) error
 
 	// ベースとなるサマリがある場合
 	if latestSummaryMessage != nil && latestSummaryMessage.ContextSummary != nil {
+		base, err := w.promptRenderer.Render(model.PromptSummaryBase, language, map[string]string{
+			"Summary": *latestSummaryMessage.ContextSummary,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to render summary base prompt: %w", err)
+		}
 		parts = append(parts, &genai.Content{
 			Role: "user",
 			Parts: []*genai.Part{
-				{Text: "これまでの会話の要約:\n" + *latestSummaryMessage.ContextSummary},
+				{Text: base.Text},
 			},
 		})
 	}
 
 	for _, m := range targetMessages {
+		text := m.Content
 		role := "user"
-		if m.Role == "assistant" {
+		switch m.Role {
+		case "assistant":
 			role = "model"
+		case "merge_report":
+			// マージレポートはユーザーの発言ではなくブランチの結論として要約に含める
+			rendered, err := w.promptRenderer.Render(model.PromptMergeReportContext, language, model.MergeReportPromptData(m, allMessages, false))
+			if err != nil {
+				return fmt.Errorf("failed to render merge report prompt: %w", err)
+			}
+			text = rendered.Text
 		}
 		parts = append(parts, &genai.Content{
 			Role: role,
 			Parts: []*genai.Part{
-				{Text: m.Content},
+				{Text: text},
 			},
 		})
 	}
 
 	// 要約指示
-	prompt := "上記の会話（これまでの要約を含む）を、次の会話のコンテキストとして使用できるように要約してください。"
+	instruction, err := w.promptRenderer.Render(model.PromptSummaryInstruction, language, nil)
+	if err != nil {
+		return fmt.Errorf("failed to render summary instruction prompt: %w", err)
+	}
 	parts = append(parts, &genai.Content{
 		Role: "user",
 		Parts: []*genai.Part{
-			{Text: prompt},
+			{Text: instruction.Text},
 		},
 	})
 
@@ -153,10 +178,25 @@ func (w *SummaryWorker) Handle(ctx context.Context, msg *message.Message) error
 	// targetMessagesの最後ではなく、allMessagesの最後（＝最新のメッセージ）に紐づける
 	lastMessage := allMessages[len(allMessages)-1]
 
-	if err := w.messageRepo.UpdateContextSummary(ctx, lastMessage.UUID, summary); err != nil {
+	if err := w.messageRepo.UpdateContextSummary(ctx, lastMessage.UUID, summary, instruction.Version); err != nil {
 		return fmt.Errorf("failed to update context summary: %w", err)
 	}
 
-	slog.InfoContext(ctx, "要約生成完了", "chat_uuid", chatUUID, "summary_length", len(summary))
+	slog.InfoContext(ctx, "要約生成完了", "chat_uuid", chatUUID, "summary_length", len(summary), "prompt_version", instruction.Version)
 	return nil
 }
+
+// タスクのペイロードを解析する処理
+// 以前の形式（チャットUUIDのみのJSON文字列）も受け付ける
+func parseSummaryTask(payload []byte) (*model.SummaryTask, error) {
+	var task model.SummaryTask
+	if err := json.Unmarshal(payload, &task); err == nil {
+		return &task, nil
+	}
+
+	var chatUUID string
+	if err := json.Unmarshal(payload, &chatUUID); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal payload: %w", err)
+	}
+	return &model.SummaryTask{ChatUUID: chatUUID}, nil
+}
@@ -49,8 +49,24 @@ func (m *MockMessageRepository) FindMessagesByChatID(ctx context.Context, chatUU
 	return args.Get(0).([]*model.Message), args.Error(1)
 }
 
-func (m *MockMessageRepository) UpdateContextSummary(ctx context.Context, messageUUID string, summary string) error {
-	args := m.Called(ctx, messageUUID, summary)
+func (m *MockMessageRepository) FindMessagesByProjectUUID(ctx context.Context, projectUUID string) ([]*model.Message, error) {
+	args := m.Called(ctx, projectUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Message), args.Error(1)
+}
+
+func (m *MockMessageRepository) FindMessageStructureByProjectUUID(ctx context.Context, projectUUID string) ([]*model.Message, error) {
+	args := m.Called(ctx, projectUUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Message), args.Error(1)
+}
+
+func (m *MockMessageRepository) UpdateContextSummary(ctx context.Context, messageUUID string, summary string, promptVersion string) error {
+	args := m.Called(ctx, messageUUID, summary, promptVersion)
 	return args.Error(0)
 }
 
@@ -78,6 +94,16 @@ func (m *MockMessageRepository) FindByID(ctx context.Context, uuid string) (*mod
 	return args.Get(0).(*model.Message), args.Error(1)
 }
 
+func (m *MockMessageRepository) Delete(ctx context.Context, uuid string) error {
+	args := m.Called(ctx, uuid)
+	return args.Error(0)
+}
+
+func (m *MockMessageRepository) UpdateContent(ctx context.Context, messageUUID string, content string) error {
+	args := m.Called(ctx, messageUUID, content)
+	return args.Error(0)
+}
+
 type MockGenAIClient struct {
 	mock.Mock
 }
@@ -95,14 +121,26 @@ func (m *MockGenAIClient) GenerateContent(ctx context.Context, model string, par
 	return args.Get(0).(*genai.GenerateContentResponse), args.Error(1)
 }
 
-func TestSummaryWorker_Handle(t *testing.T) {
-	type mocks struct {
-		subscriber  *MockSubscriber
-		messageRepo *MockMessageRepository
-		genaiClient *MockGenAIClient
+type MockPromptRenderer struct {
+	mock.Mock
+}
+
+func (m *MockPromptRenderer) Render(name string, language string, data any) (*model.RenderedPrompt, error) {
+	args := m.Called(name, language, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
 	}
+	return args.Get(0).(*model.RenderedPrompt), args.Error(1)
+}
+
+func (m *MockPromptRenderer) DefaultLanguage() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func TestSummaryWorker_Handle(t *testing.T) {
 	type args struct {
-		chatUUID string
+		payload any
 	}
 	tests := []struct {
 		name      string
@@ -113,7 +151,7 @@ func TestSummaryWorker_Handle(t *testing.T) {
 		{
 			name: "正常系: 要約生成と保存が成功すること",
 			args: args{
-				chatUUID: "chat-uuid",
+				payload: "chat-uuid",
 			},
 			setupMock: func(m *mocks) {
 				// 1. FindLatestMessageWithSummary
@@ -136,14 +174,84 @@ func TestSummaryWorker_Handle(t *testing.T) {
 				m.genaiClient.On("GenerateContent", mock.Anything, "gemini-2.5-flash", mock.Anything, (*genai.GenerateContentConfig)(nil)).Return(resp, nil)
 
 				// 4. UpdateContextSummary
-				m.messageRepo.On("UpdateContextSummary", mock.Anything, "msg-2", "summary content").Return(nil)
+				m.messageRepo.On("UpdateContextSummary", mock.Anything, "msg-2", "summary content", "summary_instruction/ja/v1").Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常系: マージレポートがブランチの結論として要約に含まれること",
+			args: args{
+				payload: model.SummaryTask{ChatUUID: "chat-uuid", Language: model.LanguageJapanese},
+			},
+			setupMock: func(m *mocks) {
+				anchor := "msg-2"
+				m.messageRepo.On("FindLatestMessageWithSummary", mock.Anything, "chat-uuid").Return((*model.Message)(nil), nil)
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return([]*model.Message{
+					{UUID: "msg-1", Content: "hello", Role: "user"},
+					{UUID: "msg-2", Content: "world", Role: "assistant"},
+					{UUID: "report-1", Content: "conclusion", Role: "merge_report", ParentMessageUUID: &anchor},
+				}, nil)
+				m.promptRenderer.On("Render", model.PromptMergeReportContext, model.LanguageJapanese, map[string]string{"Report": "conclusion", "Anchor": "world", "Acknowledge": ""}).
+					Return(&model.RenderedPrompt{Text: "framed report", Version: "merge_report_context/ja/v1"}, nil)
+
+				resp := &genai.GenerateContentResponse{
+					Candidates: []*genai.Candidate{
+						{
+							Content: genai.Text("summary content")[0],
+						},
+					},
+				}
+				m.genaiClient.On("GenerateContent", mock.Anything, "gemini-2.5-flash", mock.MatchedBy(func(parts []*genai.Content) bool {
+					// 履歴3件と要約指示
+					return len(parts) == 4 && parts[2].Role == "user" && parts[2].Parts[0].Text == "framed report"
+				}), (*genai.GenerateContentConfig)(nil)).Return(resp, nil)
+
+				// 要約は最新のメッセージであるマージレポートに紐付ける
+				m.messageRepo.On("UpdateContextSummary", mock.Anything, "report-1", "summary content", "summary_instruction/ja/v1").Return(nil)
 			},
 			wantErr: false,
 		},
+		{
+			name: "正常系: タスクで指定された言語のプロンプトが使われること",
+			args: args{
+				payload: model.SummaryTask{ChatUUID: "chat-uuid", Language: model.LanguageEnglish},
+			},
+			setupMock: func(m *mocks) {
+				summary := "previous summary"
+				m.messageRepo.On("FindLatestMessageWithSummary", mock.Anything, "chat-uuid").Return(&model.Message{UUID: "msg-1", ContextSummary: &summary}, nil)
+				m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return([]*model.Message{
+					{UUID: "msg-1", Content: "hello", Role: "user"},
+					{UUID: "msg-2", Content: "world", Role: "assistant"},
+				}, nil)
+				m.promptRenderer.On("Render", model.PromptSummaryBase, model.LanguageEnglish, map[string]string{"Summary": "previous summary"}).
+					Return(&model.RenderedPrompt{Text: "base", Version: "summary_base/en/v1"}, nil)
+				m.promptRenderer.On("Render", model.PromptSummaryInstruction, model.LanguageEnglish, nil).
+					Return(&model.RenderedPrompt{Text: "instruction", Version: "summary_instruction/en/v1"}, nil)
+
+				resp := &genai.GenerateContentResponse{
+					Candidates: []*genai.Candidate{
+						{
+							Content: genai.Text("summary content")[0],
+						},
+					},
+				}
+				m.genaiClient.On("GenerateContent", mock.Anything, "gemini-2.5-flash", mock.Anything, (*genai.GenerateContentConfig)(nil)).Return(resp, nil)
+				m.messageRepo.On("UpdateContextSummary", mock.Anything, "msg-2", "summary content", "summary_instruction/en/v1").Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常系: ペイロードが不正な場合",
+			args: args{
+				payload: 123,
+			},
+			setupMock: func(m *mocks) {},
+			wantErr:   true,
+		},
 		{
 			name: "異常系: メッセージ取得失敗",
 			args: args{
-				chatUUID: "chat-uuid",
+				payload: "chat-uuid",
 			},
 			setupMock: func(m *mocks) {
 				m.messageRepo.On("FindLatestMessageWithSummary", mock.Anything, "chat-uuid").Return((*model.Message)(nil), nil)
@@ -154,7 +262,7 @@ func TestSummaryWorker_Handle(t *testing.T) {
 		{
 			name: "正常系: メッセージが0件の場合は何もしない",
 			args: args{
-				chatUUID: "chat-uuid",
+				payload: "chat-uuid",
 			},
 			setupMock: func(m *mocks) {
 				m.messageRepo.On("FindLatestMessageWithSummary", mock.Anything, "chat-uuid").Return((*model.Message)(nil), nil)
@@ -165,7 +273,7 @@ func TestSummaryWorker_Handle(t *testing.T) {
 		{
 			name: "異常系: GenAIエラー",
 			args: args{
-				chatUUID: "chat-uuid",
+				payload: "chat-uuid",
 			},
 			setupMock: func(m *mocks) {
 				m.messageRepo.On("FindLatestMessageWithSummary", mock.Anything, "chat-uuid").Return((*model.Message)(nil), nil)
@@ -180,7 +288,7 @@ func TestSummaryWorker_Handle(t *testing.T) {
 		{
 			name: "異常系: 要約保存失敗",
 			args: args{
-				chatUUID: "chat-uuid",
+				payload: "chat-uuid",
 			},
 			setupMock: func(m *mocks) {
 				m.messageRepo.On("FindLatestMessageWithSummary", mock.Anything, "chat-uuid").Return((*model.Message)(nil), nil)
@@ -197,7 +305,7 @@ func TestSummaryWorker_Handle(t *testing.T) {
 				}
 				m.genaiClient.On("GenerateContent", mock.Anything, "gemini-2.5-flash", mock.Anything, (*genai.GenerateContentConfig)(nil)).Return(resp, nil)
 
-				m.messageRepo.On("UpdateContextSummary", mock.Anything, "msg-1", "summary").Return(errors.New("db error"))
+				m.messageRepo.On("UpdateContextSummary", mock.Anything, "msg-1", "summary", mock.Anything).Return(errors.New("db error"))
 			},
 			wantErr: true,
 		},
@@ -206,16 +314,18 @@ func TestSummaryWorker_Handle(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			m := &mocks{
-				subscriber:  &MockSubscriber{},
-				messageRepo: &MockMessageRepository{},
-				genaiClient: &MockGenAIClient{},
+				subscriber:     &MockSubscriber{},
+				messageRepo:    &MockMessageRepository{},
+				genaiClient:    &MockGenAIClient{},
+				promptRenderer: &MockPromptRenderer{},
 			}
 			tt.setupMock(m)
+			setupDefaultPrompt(m.promptRenderer)
 
-			w := NewSummaryWorker(m.subscriber, m.messageRepo, m.genaiClient)
+			w := NewSummaryWorker(m.subscriber, m.messageRepo, m.genaiClient, m.promptRenderer)
 
-			// JSON marshal the chatUUID
-			payload, _ := json.Marshal(tt.args.chatUUID)
+			// JSON marshal the payload
+			payload, _ := json.Marshal(tt.args.payload)
 			msg := message.NewMessage("msg-uuid", payload)
 			err := w.Handle(context.Background(), msg)
 
@@ -232,10 +342,12 @@ func TestSummaryWorker_Run(t *testing.T) {
 	// ただし、Runは無限ループするので、Contextでキャンセルするか、チャネルを閉じる必要がある
 
 	m := &mocks{
-		subscriber:  &MockSubscriber{},
-		messageRepo: &MockMessageRepository{},
-		genaiClient: &MockGenAIClient{},
+		subscriber:     &MockSubscriber{},
+		messageRepo:    &MockMessageRepository{},
+		genaiClient:    &MockGenAIClient{},
+		promptRenderer: &MockPromptRenderer{},
 	}
+	setupDefaultPrompt(m.promptRenderer)
 
 	// Subscribeのモック
 	msgChan := make(chan *message.Message, 1)
@@ -250,7 +362,7 @@ func TestSummaryWorker_Run(t *testing.T) {
 	m.messageRepo.On("FindLatestMessageWithSummary", mock.Anything, "chat-uuid").Return((*model.Message)(nil), nil)
 	m.messageRepo.On("FindMessagesByChatID", mock.Anything, "chat-uuid").Return([]*model.Message{}, nil) // 0件で即終了
 
-	w := NewSummaryWorker(m.subscriber, m.messageRepo, m.genaiClient)
+	w := NewSummaryWorker(m.subscriber, m.messageRepo, m.genaiClient, m.promptRenderer)
 
 	err := w.Run(context.Background())
 	assert.NoError(t, err)
@@ -266,7 +378,15 @@ func TestSummaryWorker_Run(t *testing.T) {
 
 // mocks struct for helper
 type mocks struct {
-	subscriber  *MockSubscriber
-	messageRepo *MockMessageRepository
-	genaiClient *MockGenAIClient
+	subscriber     *MockSubscriber
+	messageRepo    *MockMessageRepository
+	genaiClient    *MockGenAIClient
+	promptRenderer *MockPromptRenderer
+}
+
+// 個別に設定されていないプロンプト関連の呼び出しに既定値を返す
+func setupDefaultPrompt(promptRenderer *MockPromptRenderer) {
+	promptRenderer.On("DefaultLanguage").Return(model.LanguageJapanese).Maybe()
+	promptRenderer.On("Render", mock.Anything, mock.Anything, mock.Anything).
+		Return(&model.RenderedPrompt{Text: "prompt", Version: "summary_instruction/ja/v1"}, nil).Maybe()
 }
@@ -4,18 +4,31 @@ import type {
   CreateProjectRequest,
   CreateProjectResponse,
   ForkPreviewResponse,
+  ForkSuggestion,
+  MergePreviewRequest,
   MergePreviewResponse,
   Message,
-  Project,
+  GetProjectsResponse,
   GetProjectResponse,
   ForkChatResponse,
+  BatchForkChatRequest,
+  BatchForkChatResponse,
   MergeChatRequest,
   MergeChatResponse,
+  UnmergeChatRequest,
+  UnmergeChatResponse,
+  RebaseChatRequest,
+  RebaseChatResponse,
+  CherryPickRequest,
+  CherryPickResponse,
+  CompareBranchesRequest,
+  BranchComparison,
+  CloseChatRequest,
   CloseChatResponse,
   OpenChatResponse,
 } from "../types";
 
-export const getProjects = async (): Promise<Project[]> => {
+export const getProjects = async (): Promise<GetProjectsResponse> => {
   return apiClient.get("/api/projects");
 };
 
@@ -49,11 +62,13 @@ export const sendMessage = async (
 export const forkChat = async (
   chatId: string,
   data: {
-    targetMessageId: string;
+    // 省略するとチャットの先頭からフォークする
+    targetMessageId?: string;
     parentChatId: string;
-    selectedText: string;
-    rangeStart: number;
-    rangeEnd: number;
+    // 省略するとメッセージ全体からフォークする
+    selectedText?: string;
+    rangeStart?: number;
+    rangeEnd?: number;
     title: string;
     contextSummary: string;
   }
@@ -69,13 +84,20 @@ export const forkChat = async (
   });
 };
 
+export const batchForkChat = async (
+  chatId: string,
+  data: BatchForkChatRequest
+): Promise<BatchForkChatResponse> => {
+  return apiClient.post(`/api/chats/${chatId}/fork/batch`, data);
+};
+
 export const getForkPreview = async (
   chatId: string,
   data: {
-    messageId: string;
-    selectedText: string;
-    rangeStart: number;
-    rangeEnd: number;
+    messageId?: string;
+    selectedText?: string;
+    rangeStart?: number;
+    rangeEnd?: number;
   }
 ): Promise<ForkPreviewResponse> => {
   return apiClient.post(`/api/chats/${chatId}/fork/preview`, {
@@ -86,6 +108,24 @@ export const getForkPreview = async (
   });
 };
 
+export const suggestForks = async (
+  chatId: string,
+  messageId: string
+): Promise<ForkSuggestion[]> => {
+  return apiClient.post(
+    `/api/chats/${chatId}/messages/${messageId}/fork-suggestions`
+  );
+};
+
+export const getForkSuggestions = async (
+  chatId: string,
+  messageId: string
+): Promise<ForkSuggestion[]> => {
+  return apiClient.get(
+    `/api/chats/${chatId}/messages/${messageId}/fork-suggestions`
+  );
+};
+
 export const mergeChat = async (
   chatId: string,
   data: MergeChatRequest
@@ -94,13 +134,51 @@ export const mergeChat = async (
 };
 
 export const getMergePreview = async (
-  chatId: string
+  chatId: string,
+  data: MergePreviewRequest = {}
 ): Promise<MergePreviewResponse> => {
-  return apiClient.post(`/api/chats/${chatId}/merge/preview`);
+  return apiClient.post(`/api/chats/${chatId}/merge/preview`, data);
 };
 
-export const closeChat = async (chatId: string): Promise<CloseChatResponse> => {
-  return apiClient.post(`/api/chats/${chatId}/close`);
+export const unmergeChat = async (
+  chatId: string,
+  data: UnmergeChatRequest = {}
+): Promise<UnmergeChatResponse> => {
+  return apiClient.post(`/api/chats/${chatId}/unmerge`, data);
+};
+
+export const rebaseChat = async (
+  chatId: string,
+  data: RebaseChatRequest
+): Promise<RebaseChatResponse> => {
+  return apiClient.post(`/api/chats/${chatId}/rebase`, data);
+};
+
+export const cherryPickMessages = async (
+  chatId: string,
+  data: CherryPickRequest
+): Promise<CherryPickResponse> => {
+  return apiClient.post(`/api/chats/${chatId}/cherry-pick`, data);
+};
+
+export const compareBranches = async (
+  chatId: string,
+  data: CompareBranchesRequest
+): Promise<BranchComparison> => {
+  return apiClient.post(`/api/chats/${chatId}/compare`, data);
+};
+
+export const getBranchComparisons = async (
+  chatId: string
+): Promise<BranchComparison[]> => {
+  return apiClient.get(`/api/chats/${chatId}/comparisons`);
+};
+
+export const closeChat = async (
+  chatId: string,
+  data: CloseChatRequest = {}
+): Promise<CloseChatResponse> => {
+  return apiClient.post(`/api/chats/${chatId}/close`, data);
 };
 
 export const openChat = async (chatId: string): Promise<OpenChatResponse> => {
@@ -130,6 +208,9 @@ export const getInitialChatStream = (
       } else if (data.status === "done") {
         callbacks.onDone();
         eventSource.close();
+      } else if (data.status === "error") {
+        callbacks.onError?.(new MessageEvent("error", { data: data.message }));
+        eventSource.close();
       }
     } catch (e) {
       console.error("Failed to parse SSE message", e);
@@ -171,6 +252,9 @@ export const getMessagesStream = (
       } else if (data.status === "done") {
         callbacks.onDone();
         eventSource.close();
+      } else if (data.status === "error") {
+        callbacks.onError?.(new MessageEvent("error", { data: data.message }));
+        eventSource.close();
       }
     } catch (e) {
       console.error("Failed to parse SSE message", e);
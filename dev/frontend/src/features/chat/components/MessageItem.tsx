@@ -175,7 +175,8 @@ export function MessageItem({ message }: MessageItemProps) {
 
     let result = "";
     let searchStartIndex = 0;
-    const originalContent = message.content;
+    // 範囲はサーバー側で文字 (コードポイント) 単位に正規化されているため、UTF-16 の添字ではなく文字単位で切り出す
+    const originalContent = Array.from(message.content);
 
     for (const fork of sortedForks) {
       // 正確な位置決めのためにrange_startとrange_endを直接使用
@@ -186,10 +187,10 @@ export function MessageItem({ message }: MessageItemProps) {
       if (start < searchStartIndex) continue;
 
       // 一致する前のテキストを追加
-      result += originalContent.slice(searchStartIndex, start);
+      result += originalContent.slice(searchStartIndex, start).join("");
 
       // 完全一致を保証するために、元のコンテンツからリンクするテキストを取得
-      const textToLink = originalContent.slice(start, end);
+      const textToLink = originalContent.slice(start, end).join("");
 
       // リンクを追加
       result += `[${textToLink}](/chat/${fork.chat_uuid})`;
@@ -199,7 +200,7 @@ export function MessageItem({ message }: MessageItemProps) {
     }
 
     // 残りのテキストを追加
-    result += originalContent.slice(searchStartIndex);
+    result += originalContent.slice(searchStartIndex).join("");
 
     return result;
   }, [message.content, message.forks]);
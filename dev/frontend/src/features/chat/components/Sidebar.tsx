@@ -16,6 +16,9 @@ export function Sidebar() {
     queryFn: getProjects,
   });
 
+  // 自分のプロジェクトの後に共有されたプロジェクトを並べる
+  const allProjects = [...(projects?.owned ?? []), ...(projects?.shared ?? [])];
+
   const isChatIndex =
     location.pathname === "/chat" || location.pathname === "/chat/";
 
@@ -44,7 +47,7 @@ export function Sidebar() {
 
       <ScrollArea className="flex-1 px-2">
         <div className="space-y-1">
-          {projects?.map((project) => (
+          {allProjects.map((project) => (
             <div
               key={project.uuid}
               onClick={() => handleProjectClick(project.uuid)}
@@ -4,6 +4,18 @@ export type Project = {
   updated_at: string;
 };
 
+export type ProjectRole = "viewer" | "editor" | "owner";
+
+export type SharedProject = Project & {
+  role: ProjectRole;
+  owner_user_uuid: string;
+};
+
+export type GetProjectsResponse = {
+  owned: Project[];
+  shared: SharedProject[];
+};
+
 export type MessageRole = "user" | "assistant" | "system";
 
 export type ForkResponse = {
@@ -11,6 +23,8 @@ export type ForkResponse = {
   selected_text: string;
   range_start: number;
   range_end: number;
+  // 範囲を選択せずにメッセージ全体からフォークした
+  whole_message: boolean;
 };
 
 export type Message = {
@@ -19,6 +33,8 @@ export type Message = {
   content: string;
   forks: ForkResponse[];
   source_chat_uuid?: string;
+  origin_message_uuid?: string;
+  origin_chat_uuid?: string;
   merge_reports: Message[];
 };
 
@@ -52,8 +68,35 @@ export type ForkPreviewResponse = {
   generated_context: string;
 };
 
+export type ForkSuggestion = {
+  uuid: string;
+  target_message_uuid: string;
+  selected_text: string;
+  range_start: number;
+  range_end: number;
+  suggested_title: string;
+  generated_context: string;
+  prompt_version: string;
+  created_at: string;
+};
+
+export type MergePreviewRequest = {
+  parent_chat_uuid?: string;
+};
+
+export type MergeDivergence = {
+  anchor_message_uuid: string;
+  new_messages: number;
+  new_assistant_messages: number;
+  merged_reports: number;
+  stale: boolean;
+};
+
 export type MergePreviewResponse = {
   suggested_summary: string;
+  prompt_version: string;
+  target_chat_uuid: string;
+  divergence: MergeDivergence | null;
 };
 
 export type GetProjectResponse = {
@@ -65,18 +108,112 @@ export type ForkChatResponse = {
   message: string;
 };
 
+export type BatchForkItem = {
+  // 省略するとメッセージ全体からフォークする
+  selected_text?: string;
+  range_start?: number;
+  range_end?: number;
+  title: string;
+  context_summary: string;
+  prompt_version?: string;
+};
+
+export type BatchForkChatRequest = {
+  // 省略するとチャットの先頭からフォークする
+  target_message_uuid?: string;
+  forks: BatchForkItem[];
+  generate_first_answers?: boolean;
+};
+
+export type BatchForkChatResponse = {
+  new_chat_ids: string[];
+  message: string;
+};
+
 export type MergeChatRequest = {
   parent_chat_uuid: string;
   summary_content: string;
+  include_descendants?: boolean;
+  acknowledge?: boolean;
 };
 
 export type MergeChatResponse = {
   report_message_id: string;
   summary_content: string;
+  target_chat_uuid: string;
+  merged_chat_uuids: string[];
+  acknowledgment_message_id: string;
+};
+
+export type UnmergeChatRequest = {
+  force?: boolean;
+};
+
+export type UnmergeChatResponse = {
+  chat_uuid: string;
+  status: string;
+  report_message_uuid: string;
+  restored_chat_uuids: string[];
+};
+
+export type RebaseChatRequest = {
+  source_message_uuid: string;
+  selected_text?: string;
+  range_start?: number;
+  range_end?: number;
+  regenerate_context?: boolean;
+};
+
+export type RebaseChatResponse = {
+  chat_uuid: string;
+  parent_chat_uuid: string;
+  source_message_uuid: string;
+  context_summary: string;
+  context_prompt_version: string;
+};
+
+export type CherryPickRequest = {
+  message_uuids: string[];
+};
+
+export type CherryPickResponse = {
+  chat_uuid: string;
+  messages: Message[];
+};
+
+export type CompareBranchesRequest = {
+  other_chat_uuid: string;
+  save_note?: boolean;
+};
+
+export type BranchPosition = {
+  chat_uuid: string;
+  title: string;
+  position: string;
+};
+
+export type BranchComparison = {
+  uuid: string;
+  chat_uuid: string;
+  other_chat_uuid: string;
+  anchor_chat_uuid: string | null;
+  anchor_message_uuid: string | null;
+  positions: BranchPosition[];
+  agreements: string[];
+  disagreements: string[];
+  recommendation: string;
+  prompt_version: string;
+  user_uuid: string | null;
+  created_at: string;
+};
+
+export type CloseChatRequest = {
+  cascade?: boolean;
 };
 
 export type CloseChatResponse = {
   chat_uuid: string;
+  closed_chat_uuids: string[];
 };
 
 export type OpenChatResponse = {
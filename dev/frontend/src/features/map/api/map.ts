@@ -1,8 +1,52 @@
 import { apiClient } from "@/lib/api-client";
-import type { GetProjectTreeResponse } from "../types";
+import type {
+  GetProjectTreeResponse,
+  MapNode,
+  ProjectTreeQuery,
+  SetTreeNodeCollapsedRequest,
+  TreeNodeState,
+  UpdateTreeNodePositionsRequest,
+  UpdateTreeNodePositionsResponse,
+} from "../types";
 
 export const getProjectTree = async (
-  projectUuid: string
+  projectUuid: string,
+  query: ProjectTreeQuery = {}
 ): Promise<GetProjectTreeResponse> => {
-  return apiClient.get(`/api/projects/${projectUuid}/tree`);
+  return apiClient.get(`/api/projects/${projectUuid}/tree`, {
+    params: {
+      chat_uuid: query.chatUuid,
+      depth: query.depth,
+      exclude_status: query.excludeStatuses?.join(","),
+      summary: query.summary,
+    },
+  });
+};
+
+// 要約のみで取得したノードの全文を取得する
+export const getProjectTreeNode = async (
+  projectUuid: string,
+  nodeId: string
+): Promise<MapNode> => {
+  return apiClient.get(`/api/projects/${projectUuid}/tree/nodes/${nodeId}`);
+};
+
+// 移動したノードの位置をまとめて保存する (他の操作で更新されていた場合は 409)
+export const updateTreeNodePositions = async (
+  projectUuid: string,
+  data: UpdateTreeNodePositionsRequest
+): Promise<UpdateTreeNodePositionsResponse> => {
+  return apiClient.put(`/api/projects/${projectUuid}/tree/positions`, data);
+};
+
+// ノードの折りたたみ状態を保存する (他の操作で更新されていた場合は 409)
+export const setTreeNodeCollapsed = async (
+  projectUuid: string,
+  nodeId: string,
+  data: SetTreeNodeCollapsedRequest
+): Promise<TreeNodeState> => {
+  return apiClient.put(
+    `/api/projects/${projectUuid}/tree/nodes/${nodeId}/collapsed`,
+    data
+  );
 };
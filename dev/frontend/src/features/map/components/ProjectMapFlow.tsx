@@ -1,4 +1,4 @@
-import { useCallback, useEffect } from "react";
+import { useCallback, useEffect, useRef, type MouseEvent } from "react";
 import {
   ReactFlow,
   MiniMap,
@@ -12,8 +12,35 @@ import {
   type Node,
 } from "@xyflow/react";
 import "@xyflow/react/dist/style.css";
-import { useQuery } from "@tanstack/react-query";
-import { getProjectTree } from "../api/map";
+import { useQuery, useQueryClient } from "@tanstack/react-query";
+import {
+  getProjectTree,
+  setTreeNodeCollapsed,
+  updateTreeNodePositions,
+} from "../api/map";
+import type { MapEdge, MapNode } from "../types";
+
+// 折りたたんだノードの子孫のノードIDを集める (エッジは子ノードから親ノードに向かう)
+const collectHiddenNodeIds = (nodes: MapNode[], edges: MapEdge[]) => {
+  const children = new Map<string, string[]>();
+  for (const edge of edges) {
+    children.set(edge.target, [
+      ...(children.get(edge.target) ?? []),
+      edge.source,
+    ]);
+  }
+  const hidden = new Set<string>();
+  const stack = nodes
+    .filter((node) => node.collapsed)
+    .flatMap((node) => children.get(node.id) ?? []);
+  while (stack.length > 0) {
+    const id = stack.pop()!;
+    if (hidden.has(id)) continue;
+    hidden.add(id);
+    stack.push(...(children.get(id) ?? []));
+  }
+  return hidden;
+};
 
 type ProjectMapFlowProps = {
   projectId: string;
@@ -22,6 +49,9 @@ type ProjectMapFlowProps = {
 export function ProjectMapFlow({ projectId }: ProjectMapFlowProps) {
   const [nodes, setNodes, onNodesChange] = useNodesState<Node>([]);
   const [edges, setEdges, onEdgesChange] = useEdgesState<Edge>([]);
+  const queryClient = useQueryClient();
+  // ノードの表示状態のバージョン (保存するたびにサーバーから返る値で更新する)
+  const versions = useRef(new Map<string, number>());
 
   const { data } = useQuery({
     queryKey: ["projectTree", projectId],
@@ -30,11 +60,16 @@ export function ProjectMapFlow({ projectId }: ProjectMapFlowProps) {
 
   useEffect(() => {
     if (data) {
+      versions.current = new Map(
+        data.nodes.map((node) => [node.id, node.version])
+      );
+      const hidden = collectHiddenNodeIds(data.nodes, data.edges);
       const flowNodes: Node[] = data.nodes.map((node) => ({
         id: node.id,
         position: node.position,
-        data: { label: node.data.user_message },
+        data: { label: node.data.user_message, collapsed: node.collapsed },
         type: "default",
+        hidden: hidden.has(node.id),
       }));
 
       const flowEdges: Edge[] = data.edges.map((edge) => ({
@@ -43,6 +78,7 @@ export function ProjectMapFlow({ projectId }: ProjectMapFlowProps) {
         target: edge.target,
         type: "smoothstep",
         animated: true,
+        hidden: hidden.has(edge.source),
       }));
 
       setNodes(flowNodes);
@@ -50,6 +86,52 @@ export function ProjectMapFlow({ projectId }: ProjectMapFlowProps) {
     }
   }, [data, setNodes, setEdges]);
 
+  // 他の操作で更新されていた場合は最新のツリーを取得し直す
+  const refetchTree = useCallback(
+    () =>
+      queryClient.invalidateQueries({ queryKey: ["projectTree", projectId] }),
+    [queryClient, projectId]
+  );
+
+  // ドラッグで移動したノードの位置を保存する
+  const onNodeDragStop = useCallback(
+    async (_event: MouseEvent, _node: Node, draggedNodes: Node[]) => {
+      try {
+        const res = await updateTreeNodePositions(projectId, {
+          positions: draggedNodes.map((node) => ({
+            node_id: node.id,
+            x: node.position.x,
+            y: node.position.y,
+            version: versions.current.get(node.id) ?? 0,
+          })),
+        });
+        for (const state of res.nodes) {
+          versions.current.set(state.node_id, state.version);
+        }
+      } catch (error) {
+        console.error("Failed to save node positions:", error);
+        refetchTree();
+      }
+    },
+    [projectId, refetchTree]
+  );
+
+  // ダブルクリックでノードの子孫を折りたたむ・展開する
+  const onNodeDoubleClick = useCallback(
+    async (_event: MouseEvent, node: Node) => {
+      try {
+        await setTreeNodeCollapsed(projectId, node.id, {
+          collapsed: !node.data.collapsed,
+          version: versions.current.get(node.id) ?? 0,
+        });
+      } catch (error) {
+        console.error("Failed to save collapsed state:", error);
+      }
+      refetchTree();
+    },
+    [projectId, refetchTree]
+  );
+
   const onConnect = useCallback(
     (params: Connection) => setEdges((eds) => addEdge(params, eds)),
     [setEdges]
@@ -63,6 +145,8 @@ export function ProjectMapFlow({ projectId }: ProjectMapFlowProps) {
         onNodesChange={onNodesChange}
         onEdgesChange={onEdgesChange}
         onConnect={onConnect}
+        onNodeDragStop={onNodeDragStop}
+        onNodeDoubleClick={onNodeDoubleClick}
         fitView
       >
         <Controls />
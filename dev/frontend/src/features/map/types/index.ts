@@ -13,6 +13,17 @@ export type MapNode = {
   chat_uuid: string;
   data: NodeData;
   position: NodePosition;
+  collapsed: boolean;
+  // ユーザーが移動した位置で表示しているか
+  pinned: boolean;
+  // 表示状態を更新するときにそのまま送り返す
+  version: number;
+  // ノードが属するチャットのタイトル
+  title: string;
+  // 本文を省略している場合は true (全文は getProjectTreeNode で取得する)
+  truncated: boolean;
+  // 階層数の制限で読み込んでいない子チャットがある場合は true
+  has_more_children: boolean;
 };
 
 export type MapEdge = {
@@ -21,7 +32,48 @@ export type MapEdge = {
   target: string;
 };
 
+export type ProjectTreeQuery = {
+  // ツリーの起点にするチャット (省略した場合はルートチャット)
+  chatUuid?: string;
+  // 起点のチャットから辿るフォークの階層数 (省略した場合は制限しない)
+  depth?: number;
+  // ツリーに含めないチャットのステータス
+  excludeStatuses?: string[];
+  // true の場合はノードの本文を先頭の一部に省略する
+  summary?: boolean;
+};
+
 export type GetProjectTreeResponse = {
   nodes: MapNode[];
   edges: MapEdge[];
 };
+
+export type TreeNodePositionUpdate = {
+  node_id: string;
+  x: number;
+  y: number;
+  // true の場合は自動レイアウトの位置に戻す
+  reset?: boolean;
+  version: number;
+};
+
+export type UpdateTreeNodePositionsRequest = {
+  positions: TreeNodePositionUpdate[];
+};
+
+export type TreeNodeState = {
+  node_id: string;
+  // 自動レイアウトの位置で表示する場合は null
+  position: NodePosition | null;
+  collapsed: boolean;
+  version: number;
+};
+
+export type UpdateTreeNodePositionsResponse = {
+  nodes: TreeNodeState[];
+};
+
+export type SetTreeNodeCollapsedRequest = {
+  collapsed: boolean;
+  version: number;
+};